@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/interest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+const accrueInterestCommand = "accrue-interest"
+
+var errAccrueInterestUsage = errors.New("usage: xbankapi accrue-interest -date YYYY-MM-DD")
+
+// accrueInterest accrues the interest of a day in the database of dbURL, and capitalizes it when it is the last day of
+// a month. Accounts already accrued for the day are skipped, so that the day can be processed again:
+//
+//	xbankapi accrue-interest -date 2024-05-31
+func accrueInterest(ctx context.Context, dbURL string, args []string) error {
+	flags := flag.NewFlagSet(accrueInterestCommand, flag.ContinueOnError)
+	date := flags.String("date", "", "the day, in UTC, to accrue the interest of")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errAccrueInterestUsage, err)
+	}
+
+	day, err := time.Parse(time.DateOnly, *date)
+	if err != nil || flags.NArg() != 0 {
+		return errAccrueInterestUsage
+	}
+
+	pool, err := newPool(ctx, dbURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	store := storage.New(pool)
+	ctx = audit.ContextWithActor(ctx, audit.Actor{Principal: audit.PrincipalSystem})
+
+	auditLog := audit.New(store, slog.Default())
+	interests := interest.New(pool, store, auditLog, holder.New(pool, store, auditLog, slog.Default()), slog.Default())
+
+	if err := interests.Process(ctx, day); err != nil {
+		return fmt.Errorf("failed to accrue interest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+// Package client is a Go client for xbankAPI.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	headerIdempotencyKey = "Idempotency-Key"
+	headerAuthorization  = "Authorization"
+
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRetries = 3
+	defaultBackoff    = 100 * time.Millisecond
+)
+
+var errInvalidBaseURL = errors.New("base url must be absolute")
+
+type (
+	Client struct {
+		baseURL    *url.URL
+		httpClient *http.Client
+		token      string
+		maxRetries int
+		backoff    time.Duration
+	}
+
+	Option func(*Client)
+
+	idempotencyKeyCtxKey struct{}
+)
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBearerToken authenticates requests with the bearer token.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times a failed request is retried, waiting backoff before
+// the first retry and doubling the wait before each following one.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithIdempotencyKey returns a context making the request sent with it use the key,
+// instead of a random one, so it can be safely retried across process restarts.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// New returns a new Client sending requests to baseURL, e.g. http://localhost:3000.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base url: %w", err)
+	}
+
+	if !u.IsAbs() {
+		return nil, errInvalidBaseURL
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// CreateAccount creates a bank account.
+func (c *Client) CreateAccount(
+	ctx context.Context,
+	req *types.CreateAccountRequest,
+) (*types.CreateAccountResponse, error) {
+	res := &types.CreateAccountResponse{}
+
+	if err := c.do(ctx, http.MethodPost, "/accounts", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AddMoney adds money to a bank account.
+func (c *Client) AddMoney(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.AddMoneyRequest,
+) (*types.AddMoneyResponse, error) {
+	res := &types.AddMoneyResponse{}
+
+	if err := c.do(ctx, http.MethodPost, "/accounts/"+accountID.String()+"/transactions", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// TransferMoney transfers money from a bank account to another.
+func (c *Client) TransferMoney(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.TransferMoneyRequest,
+) (*types.TransferMoneyResponse, error) {
+	res := &types.TransferMoneyResponse{}
+
+	if err := c.do(ctx, http.MethodPost, "/accounts/"+accountID.String()+"/transactions/transfer", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAccount returns a bank account and its balance.
+func (c *Client) GetAccount(ctx context.Context, accountID uuid.UUID) (*types.GetAccountResponse, error) {
+	res := &types.GetAccountResponse{}
+
+	if err := c.do(ctx, http.MethodGet, "/accounts/"+accountID.String(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAccountByIBAN returns the bank account of an IBAN and its balance.
+func (c *Client) GetAccountByIBAN(ctx context.Context, iban string) (*types.GetAccountResponse, error) {
+	res := &types.GetAccountResponse{}
+
+	if err := c.do(ctx, http.MethodGet, "/accounts?iban="+url.QueryEscape(iban), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListTransactions lists the transactions of a bank account, latest first.
+func (c *Client) ListTransactions(
+	ctx context.Context,
+	accountID uuid.UUID,
+	limit, offset int32,
+) (*types.ListTransactionsResponse, error) {
+	res := &types.ListTransactionsResponse{}
+
+	path := fmt.Sprintf("/accounts/%s/transactions?limit=%d&offset=%d", accountID, limit, offset)
+
+	if err := c.do(ctx, http.MethodGet, path, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// do sends a request, retrying it on transient failures. Requests other than GET
+// carry an idempotency key, which stays the same across retries.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	body, err := encode(in)
+	if err != nil {
+		return err
+	}
+
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	if key == "" && method != http.MethodGet {
+		key = uuid.NewString()
+	}
+
+	backoff := c.backoff
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, key, body, out)
+		if err == nil || attempt >= c.maxRetries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to retry request: %w", ctx.Err())
+		case <-time.After(retryWait(err, backoff)):
+		}
+
+		backoff *= 2
+	}
+}
+
+// retryWait returns how long to wait before retrying a request which failed with err, the backoff unless the API
+// asked to wait longer.
+func retryWait(err error, backoff time.Duration) time.Duration {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > backoff {
+		return apiErr.RetryAfter
+	}
+
+	return backoff
+}
+
+func (c *Client) send(ctx context.Context, method, path, key string, body []byte, out any) error {
+	path, rawQuery, _ := strings.Cut(path, "?")
+
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = rawQuery
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if key != "" {
+		req.Header.Set(headerIdempotencyKey, key)
+	}
+
+	if c.token != "" {
+		req.Header.Set(headerAuthorization, "Bearer "+c.token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return &transportError{err: err}
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return &transportError{err: err}
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newError(res.StatusCode, res.Header, data)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func encode(in any) ([]byte, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	return body, nil
+}
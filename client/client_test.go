@@ -0,0 +1,424 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/api"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/validator"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID            = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantTransactionID        = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantReciverAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantReciverTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789004")
+)
+
+// newServer starts a server running the real handlers backed by a mocked service.
+func newServer(t *testing.T, wrap func(http.Handler) http.Handler) (*mocks.MockAccountService, *httptest.Server) {
+	t.Helper()
+
+	validator.ConfigureDefaultValidator()
+
+	service := mocks.NewMockAccountService(t)
+
+	mux := http.NewServeMux()
+	api.NewAccountHandler(service).Register(mux)
+
+	var h http.Handler = mux
+	if wrap != nil {
+		h = wrap(h)
+	}
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return service, srv
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New("localhost")
+	assert.ErrorIs(t, err, errInvalidBaseURL)
+
+	got, err := New("http://localhost:3000")
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestClient_CreateAccount(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	req := &types.CreateAccountRequest{Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"}
+
+	service.EXPECT().CreateAccount(mock.Anything, req).Return(types.CreateAccountResponse{
+		Account: types.Account{ID: wantAccountID, Name: req.Name, Email: req.Email, CurrencyCode: req.CurrencyCode},
+	}, nil).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	got, err := c.CreateAccount(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.CreateAccountResponse{
+		Account: types.Account{ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"},
+	}, got)
+}
+
+func TestClient_CreateAccount_validationError(t *testing.T) {
+	t.Parallel()
+
+	_, srv := newServer(t, nil)
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	_, err = c.CreateAccount(context.Background(), &types.CreateAccountRequest{
+		Name: "name", Email: "wrong", CurrencyCode: "EUR",
+	})
+
+	apiErr := &Error{}
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, map[string]map[string]string{
+		"email": {"email": "email value is an invalid email address"},
+	}, apiErr.Violations)
+}
+
+func TestClient_AddMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mockErr error
+		want    *types.AddMoneyResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when account not found",
+			mockErr: api.ErrAccountNotFound,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when service returns an internal error",
+			mockErr: api.ErrInternal,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when money is added",
+			want: &types.AddMoneyResponse{TransactionID: wantTransactionID},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, srv := newServer(t, nil)
+
+			service.EXPECT().AddMoney(mock.Anything, &types.AddMoneyRequest{Amount: 100}, wantAccountID).
+				Return(types.AddMoneyResponse{TransactionID: wantTransactionID}, tt.mockErr).Once()
+
+			c, err := New(srv.URL, WithRetries(0, 0))
+			require.NoError(t, err)
+
+			got, err := c.AddMoney(context.Background(), wantAccountID, &types.AddMoneyRequest{Amount: 100})
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_TransferMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mockErr error
+		want    *types.TransferMoneyResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when insufficient account balance",
+			mockErr: api.ErrInsufficientAccountBalance,
+			wantErr: api.ErrInsufficientAccountBalance,
+		},
+		{
+			name:    "failed when reciver account not found",
+			mockErr: api.ErrRecieverAccountNotFound,
+			wantErr: types.ErrRecieverAccountNotFound,
+		},
+		{
+			name: "success when money is transferred",
+			want: &types.TransferMoneyResponse{TransactionID: wantReciverTransactionID},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, srv := newServer(t, nil)
+
+			req := &types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 100}
+
+			service.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+				Return(types.TransferMoneyResponse{TransactionID: wantReciverTransactionID}, tt.mockErr).Once()
+
+			c, err := New(srv.URL)
+			require.NoError(t, err)
+
+			got, err := c.TransferMoney(context.Background(), wantAccountID, req)
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_TransferMoney_limitExceeded(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	req := &types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 100}
+
+	service.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).Return(types.TransferMoneyResponse{},
+		&types.LimitExceededError{Limit: types.LimitDailyAmount, Remaining: 50}).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	_, err = c.TransferMoney(context.Background(), wantAccountID, req)
+	require.ErrorIs(t, err, types.ErrLimitExceeded)
+
+	limitErr := &types.LimitExceededError{}
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, &types.LimitExceededError{Limit: types.LimitDailyAmount, Remaining: 50}, limitErr)
+}
+
+func TestClient_TransferMoney_pendingReview(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	req := &types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 100}
+	pendingTransferID := uuid.New()
+
+	service.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).Return(types.TransferMoneyResponse{},
+		&types.PendingReviewError{PendingTransferID: pendingTransferID}).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	_, err = c.TransferMoney(context.Background(), wantAccountID, req)
+	require.ErrorIs(t, err, types.ErrTransferPendingReview)
+
+	pendingErr := &types.PendingReviewError{}
+	require.ErrorAs(t, err, &pendingErr)
+	assert.Equal(t, pendingTransferID, pendingErr.PendingTransferID)
+}
+
+func TestClient_GetAccount(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	account := types.Account{ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"}
+
+	service.EXPECT().GetAccount(mock.Anything, wantAccountID).
+		Return(types.GetAccountResponse{Account: account, Balance: 100}, nil).Once()
+	service.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).
+		Return(types.GetAccountResponse{}, api.ErrAccountNotFound).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	got, err := c.GetAccount(context.Background(), wantAccountID)
+	require.NoError(t, err)
+	assert.Equal(t, &types.GetAccountResponse{Account: account, Balance: 100}, got)
+
+	_, err = c.GetAccount(context.Background(), wantReciverAccountID)
+	assert.ErrorIs(t, err, types.ErrAccountNotFound)
+}
+
+func TestClient_GetAccountByIBAN(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	account := types.Account{
+		ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR", IBAN: "DE89370400440532013000",
+	}
+
+	service.EXPECT().GetAccountByIBAN(mock.Anything, "DE89 3704 0044 0532 0130 00").
+		Return(types.GetAccountResponse{Account: account, Balance: 100}, nil).Once()
+	service.EXPECT().GetAccountByIBAN(mock.Anything, "DE00").
+		Return(types.GetAccountResponse{}, types.ErrInvalidIBAN).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	got, err := c.GetAccountByIBAN(context.Background(), "DE89 3704 0044 0532 0130 00")
+	require.NoError(t, err)
+	assert.Equal(t, &types.GetAccountResponse{Account: account, Balance: 100}, got)
+
+	_, err = c.GetAccountByIBAN(context.Background(), "DE00")
+	assert.ErrorIs(t, err, types.ErrInvalidIBAN)
+}
+
+func TestClient_ListTransactions(t *testing.T) {
+	t.Parallel()
+
+	service, srv := newServer(t, nil)
+
+	want := &types.ListTransactionsResponse{Transactions: []types.Transaction{{
+		ID:        wantTransactionID,
+		AccountID: wantAccountID,
+		Amount:    100,
+		CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}}}
+
+	service.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(10), int32(20)).
+		Return(*want, nil).Once()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	got, err := c.ListTransactions(context.Background(), wantAccountID, 10, 20)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestClient_retries(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	// The first attempt fails before reaching the handlers.
+	flaky := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			keys = append(keys, r.Header.Get(headerIdempotencyKey))
+			first := len(keys) == 1
+			mu.Unlock()
+
+			if first {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	service, srv := newServer(t, flaky)
+
+	req := &types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 100}
+
+	service.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+		Return(types.TransferMoneyResponse{TransactionID: wantReciverTransactionID}, nil).Once()
+
+	c, err := New(srv.URL, WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+
+	got, err := c.TransferMoney(WithIdempotencyKey(context.Background(), "key"), wantAccountID, req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.TransferMoneyResponse{TransactionID: wantReciverTransactionID}, got)
+	assert.Equal(t, []string{"key", "key"}, keys)
+}
+
+func TestClient_retriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, WithRetries(2, time.Millisecond), WithBearerToken("token"))
+	require.NoError(t, err)
+
+	_, err = c.AddMoney(context.Background(), wantAccountID, &types.AddMoneyRequest{Amount: 100})
+
+	apiErr := &Error{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_retryAfter(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "40")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"a request with the same idempotency key is in progress",` +
+			`"code":"IDEMPOTENCY_KEY_IN_USE"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, WithRetries(0, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = c.AddMoney(context.Background(), wantAccountID, &types.AddMoneyRequest{Amount: 100})
+	require.ErrorIs(t, err, types.ErrIdempotencyKeyInUse)
+
+	apiErr := &Error{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 40*time.Second, apiErr.RetryAfter)
+}
+
+func TestClient_bearerToken(t *testing.T) {
+	t.Parallel()
+
+	var got string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(headerAuthorization)
+
+		_, _ = w.Write([]byte(`{"id":"` + wantTransactionID.String() + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, WithBearerToken("token"))
+	require.NoError(t, err)
+
+	_, err = c.AddMoney(context.Background(), wantAccountID, &types.AddMoneyRequest{Amount: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", got)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Error is returned when the API responds with an error. It wraps the error the API
+// reported, so callers can test for it, e.g. errors.Is(err, types.ErrAccountNotFound), or get the remaining allowance
+// of a *types.LimitExceededError and the pending transfer of a *types.PendingReviewError with errors.As.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Violations holds the failed rules by field name when the request failed validation.
+	Violations map[string]map[string]string
+	// RetryAfter is how long the API asked to wait before sending the request again, if it did, e.g. while
+	// a request with the same idempotency key is in progress.
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("xbankapi: %d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("xbankapi: %d: %v", e.StatusCode, e.Violations)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(statusCode int, header http.Header, body []byte) *Error {
+	e := &Error{StatusCode: statusCode}
+
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var apiErr types.Error
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		e.Code = apiErr.Code
+		e.Message = apiErr.Message
+		e.err = types.ErrorFromCode(apiErr.Code)
+
+		if apiErr.Limit != "" && apiErr.Remaining != nil {
+			e.err = &types.LimitExceededError{Limit: apiErr.Limit, Remaining: *apiErr.Remaining}
+		}
+
+		if apiErr.PendingTransferID != nil {
+			e.err = &types.PendingReviewError{PendingTransferID: *apiErr.PendingTransferID}
+		}
+
+		return e
+	}
+
+	if err := json.Unmarshal(body, &e.Violations); err != nil || len(e.Violations) == 0 {
+		e.Violations = nil
+		e.Message = http.StatusText(statusCode)
+	}
+
+	return e
+}
+
+// transportError is returned when a response could not be received.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "xbankapi: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// retryable reports whether a failed request may succeed when sent again.
+func retryable(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests ||
+			apiErr.Code == types.ErrorCodeIdempotencyKeyInUse ||
+			apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
@@ -0,0 +1,8 @@
+CREATE TABLE "idempotency_key"(
+    key varchar(255) PRIMARY KEY,
+    request_hash bytea NOT NULL,
+    status_code integer,
+    content_type varchar(255),
+    response_body bytea,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,3 @@
+DROP INDEX transaction_account_id_created_at_idx;
+ALTER TABLE "transaction"
+    DROP COLUMN created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE "transaction"
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
+CREATE INDEX transaction_account_id_created_at_idx ON "transaction"(account_id, created_at);
@@ -0,0 +1,31 @@
+CREATE TABLE "audit_event"(
+    audit_event_id bigserial PRIMARY KEY,
+    occurred_at timestamptz NOT NULL,
+    principal varchar(255) NOT NULL,
+    action varchar(255) NOT NULL,
+    account_id uuid,
+    request_id varchar(255) NOT NULL,
+    client_ip varchar(255) NOT NULL,
+    outcome varchar(255) NOT NULL,
+    -- json keeps the snapshots byte for byte, as they are part of the hash.
+    before json,
+    after json,
+    prev_hash bytea NOT NULL,
+    hash bytea NOT NULL UNIQUE
+);
+
+CREATE INDEX audit_event_account_id_idx ON "audit_event"(account_id);
+
+CREATE FUNCTION audit_event_append_only()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_event is append-only';
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_event_append_only
+    BEFORE UPDATE OR DELETE ON "audit_event"
+    FOR EACH ROW
+    EXECUTE FUNCTION audit_event_append_only();
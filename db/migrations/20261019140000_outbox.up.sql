@@ -0,0 +1,13 @@
+CREATE TABLE "outbox"(
+    outbox_event_id bigserial PRIMARY KEY,
+    event_id uuid NOT NULL UNIQUE DEFAULT uuid_generate_v4(),
+    event_type varchar(255) NOT NULL,
+    account_id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamptz NOT NULL,
+    published_at timestamptz
+);
+
+CREATE INDEX outbox_unpublished_idx ON "outbox"(outbox_event_id)
+WHERE
+    published_at IS NULL;
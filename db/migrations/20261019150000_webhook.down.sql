@@ -0,0 +1,5 @@
+DROP TABLE "webhook_delivery_attempt";
+
+DROP TABLE "webhook_delivery";
+
+DROP TABLE "webhook";
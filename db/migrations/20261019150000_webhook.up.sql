@@ -0,0 +1,42 @@
+CREATE TABLE "webhook"(
+    webhook_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url varchar(2048) NOT NULL,
+    -- An empty array subscribes to every event type.
+    event_types varchar(255)[] NOT NULL,
+    account_id uuid REFERENCES "account"(account_id),
+    secret varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "webhook_delivery"(
+    webhook_delivery_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id uuid NOT NULL REFERENCES "webhook"(webhook_id),
+    event_id uuid NOT NULL,
+    event_type varchar(255) NOT NULL,
+    payload json NOT NULL,
+    status varchar(255) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_status_code integer,
+    last_error text,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_delivery_due_idx ON "webhook_delivery"(next_attempt_at)
+WHERE
+    status = 'pending';
+
+CREATE INDEX webhook_delivery_webhook_id_idx ON "webhook_delivery"(webhook_id, created_at);
+
+CREATE TABLE "webhook_delivery_attempt"(
+    webhook_delivery_attempt_id bigserial PRIMARY KEY,
+    webhook_delivery_id uuid NOT NULL REFERENCES "webhook_delivery"(webhook_delivery_id),
+    attempted_at timestamptz NOT NULL,
+    status_code integer,
+    error text,
+    duration_ms bigint NOT NULL
+);
+
+CREATE INDEX webhook_delivery_attempt_webhook_delivery_id_idx ON "webhook_delivery_attempt"(webhook_delivery_id);
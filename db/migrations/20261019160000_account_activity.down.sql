@@ -0,0 +1,2 @@
+DROP TRIGGER transaction_notify_account_activity ON "transaction";
+DROP FUNCTION notify_account_activity;
@@ -0,0 +1,16 @@
+-- Notifies the account of every transaction on the account_activity channel, once the transaction is committed.
+CREATE FUNCTION notify_account_activity()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    PERFORM
+        pg_notify('account_activity', NEW.account_id::text);
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER transaction_notify_account_activity
+    AFTER INSERT ON "transaction"
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_account_activity();
@@ -0,0 +1,35 @@
+CREATE TABLE "payment_file"(
+    payment_file_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    -- The identification of the pain.001 message, a message being imported once.
+    message_id varchar(35) NOT NULL,
+    message_created_at timestamptz NOT NULL,
+    number_of_transactions integer NOT NULL,
+    control_sum numeric,
+    status varchar(4) NOT NULL,
+    reason_code varchar(4),
+    reason varchar(105),
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    UNIQUE (account_id, message_id)
+);
+
+CREATE TABLE "payment"(
+    payment_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_file_id uuid NOT NULL REFERENCES "payment_file"(payment_file_id),
+    -- The position of the payment in the file, which the status report follows.
+    position integer NOT NULL,
+    payment_information_id varchar(35) NOT NULL,
+    instruction_id varchar(35),
+    end_to_end_id varchar(35) NOT NULL,
+    amount numeric,
+    currency_code varchar(3) NOT NULL,
+    creditor_account varchar(34) NOT NULL,
+    status varchar(4) NOT NULL,
+    reason_code varchar(4),
+    reason varchar(105),
+    transaction_id uuid REFERENCES "transaction"(transaction_id),
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    UNIQUE (payment_file_id, position)
+);
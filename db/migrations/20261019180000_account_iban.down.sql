@@ -0,0 +1,3 @@
+ALTER TABLE "account"
+    DROP COLUMN iban,
+    DROP COLUMN account_number;
@@ -0,0 +1,5 @@
+CREATE SEQUENCE account_number_seq;
+ALTER TABLE "account"
+    ADD COLUMN account_number bigint UNIQUE NOT NULL DEFAULT nextval('account_number_seq'),
+    ADD COLUMN iban varchar(34) UNIQUE;
+ALTER SEQUENCE account_number_seq OWNED BY "account".account_number;
@@ -0,0 +1,11 @@
+CREATE TABLE "beneficiary"(
+    beneficiary_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    nickname varchar(255) NOT NULL,
+    reciver_account_id uuid NOT NULL REFERENCES "account"(account_id),
+    -- The maximum amount of a transfer to the beneficiary, if any.
+    transfer_limit numeric,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    UNIQUE (account_id, reciver_account_id)
+);
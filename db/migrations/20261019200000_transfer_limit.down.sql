@@ -0,0 +1,3 @@
+DROP TABLE "account_limit";
+
+DROP TABLE "limit_tier";
@@ -0,0 +1,24 @@
+-- The limits of the transfers from the accounts of a tier, null when there is none.
+CREATE TABLE "limit_tier"(
+    tier varchar(32) PRIMARY KEY,
+    max_transfer numeric,
+    daily_amount numeric,
+    monthly_amount numeric,
+    daily_count integer,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO "limit_tier"(tier, max_transfer, daily_amount, monthly_amount, daily_count)
+    VALUES ('standard', 10000, 20000, 100000, 50),
+    ('premium', 100000, 200000, 1000000, 200);
+
+-- The tier of an account and the limits overriding those of its tier, accounts without one are of the standard tier.
+CREATE TABLE "account_limit"(
+    account_id uuid PRIMARY KEY REFERENCES "account"(account_id),
+    tier varchar(32) NOT NULL REFERENCES "limit_tier"(tier),
+    max_transfer numeric,
+    daily_amount numeric,
+    monthly_amount numeric,
+    daily_count integer,
+    updated_at timestamptz NOT NULL
+);
@@ -0,0 +1,17 @@
+-- Transfers held for review by the risk engine, which are made once the admin approves them.
+CREATE TABLE "pending_transfer"(
+    pending_transfer_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    reciver_account_id uuid NOT NULL REFERENCES "account"(account_id),
+    amount numeric NOT NULL,
+    -- pending, approved or rejected.
+    status varchar(16) NOT NULL,
+    -- The names of the rules the transfer matched.
+    rules text[] NOT NULL,
+    -- The transaction received once the transfer is approved.
+    transaction_id uuid REFERENCES "transaction"(transaction_id),
+    created_at timestamptz NOT NULL,
+    decided_at timestamptz
+);
+
+CREATE INDEX pending_transfer_status_created_at_idx ON "pending_transfer"(status, created_at);
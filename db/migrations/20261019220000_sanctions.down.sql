@@ -0,0 +1,8 @@
+DROP TABLE "sanctions_screening";
+
+ALTER TABLE "account"
+    DROP COLUMN screening_status;
+
+DROP TABLE "sanctions_entry";
+
+DROP TABLE "sanctions_list";
@@ -0,0 +1,36 @@
+-- The sanctions lists, each replaced as a whole when it is imported again.
+CREATE TABLE "sanctions_list"(
+    list varchar(64) PRIMARY KEY,
+    entries integer NOT NULL,
+    imported_at timestamptz NOT NULL
+);
+
+CREATE TABLE "sanctions_entry"(
+    list varchar(64) NOT NULL REFERENCES "sanctions_list"(list) ON DELETE CASCADE,
+    reference varchar(255) NOT NULL,
+    name varchar(255) NOT NULL
+);
+
+CREATE INDEX sanctions_entry_list_idx ON "sanctions_entry"(list);
+
+-- clear, review, cleared or blocked: accounts whose name nearly matches a sanctions entry are under review until the
+-- admin clears or blocks them.
+ALTER TABLE "account"
+    ADD COLUMN screening_status varchar(16) NOT NULL DEFAULT 'clear';
+
+-- The results of screening the names of accounts against the sanctions lists.
+CREATE TABLE "sanctions_screening"(
+    screening_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    name varchar(255) NOT NULL,
+    -- clear, review, cleared or blocked.
+    status varchar(16) NOT NULL,
+    -- The sanctions entries the name matched, with their scores.
+    matches jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    resolved_at timestamptz
+);
+
+CREATE INDEX sanctions_screening_status_created_at_idx ON "sanctions_screening"(status, created_at);
+
+CREATE INDEX sanctions_screening_account_id_idx ON "sanctions_screening"(account_id);
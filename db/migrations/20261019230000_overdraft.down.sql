@@ -0,0 +1,4 @@
+DROP TABLE "overdraft_interest";
+
+ALTER TABLE "account"
+    DROP COLUMN overdraft_limit;
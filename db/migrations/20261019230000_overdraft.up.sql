@@ -0,0 +1,15 @@
+-- The overdraft agreed for an account: money can be transferred from it until its balance is the negative of the
+-- limit. There is none when zero.
+ALTER TABLE "account"
+    ADD COLUMN overdraft_limit numeric NOT NULL DEFAULT 0;
+
+-- The interest charged on the negative balances of accounts at the end of a day, once per account and day.
+CREATE TABLE "overdraft_interest"(
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    day date NOT NULL,
+    transaction_id uuid NOT NULL REFERENCES "transaction"(transaction_id),
+    balance numeric NOT NULL,
+    amount numeric NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (account_id, day)
+);
@@ -0,0 +1,9 @@
+DROP TABLE "interest_accrual";
+
+ALTER TABLE "transaction"
+    DROP COLUMN type;
+
+ALTER TABLE "account"
+    DROP COLUMN product_code;
+
+DROP TABLE "account_product";
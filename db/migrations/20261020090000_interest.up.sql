@@ -0,0 +1,62 @@
+-- The products accounts are opened for, the interest of their positive balances accruing daily at the annual
+-- interest rate, e.g. 0.025 for 2.5%, following the day count convention, ACT/365 or 30/360.
+CREATE TABLE "account_product"(
+    product_code varchar(32) PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    interest_rate numeric NOT NULL DEFAULT 0,
+    day_count varchar(16) NOT NULL DEFAULT 'ACT/365',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO "account_product"(product_code, name)
+    VALUES ('current', 'Current account');
+
+ALTER TABLE "account"
+    ADD COLUMN product_code varchar(32) NOT NULL DEFAULT 'current' REFERENCES "account_product"(product_code);
+
+-- deposit, transfer or interest.
+ALTER TABLE "transaction"
+    ADD COLUMN type varchar(16) NOT NULL DEFAULT 'transfer';
+
+UPDATE
+    "transaction"
+SET
+    type = 'deposit'
+WHERE
+    source_id IS NULL
+    AND amount > 0;
+
+UPDATE
+    "transaction"
+SET
+    type = 'interest'
+WHERE
+    transaction_id IN (
+        SELECT
+            transaction_id
+        FROM
+            "overdraft_interest");
+
+ALTER TABLE "transaction"
+    ALTER COLUMN type DROP DEFAULT;
+
+-- The interest accrued on the balance of an account at the end of a day, once per account and day, until it is
+-- capitalized by the transaction of the month.
+CREATE TABLE "interest_accrual"(
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    day date NOT NULL,
+    product_code varchar(32) NOT NULL,
+    balance numeric NOT NULL,
+    interest_rate numeric NOT NULL,
+    day_count varchar(16) NOT NULL,
+    -- The exact interest, which is rounded to the minor unit when capitalized.
+    amount numeric NOT NULL,
+    transaction_id uuid REFERENCES "transaction"(transaction_id),
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (account_id, day)
+);
+
+CREATE INDEX interest_accrual_uncapitalized_idx ON "interest_accrual"(account_id, day)
+WHERE
+    transaction_id IS NULL;
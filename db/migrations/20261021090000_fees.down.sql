@@ -0,0 +1,3 @@
+DROP TABLE "fee";
+
+DROP TABLE "fee_schedule";
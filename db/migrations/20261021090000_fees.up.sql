@@ -0,0 +1,38 @@
+-- The fees of the accounts of a product, per type: transfer fees are charged on each transfer from an account, and
+-- maintenance fees once a month. A fee is flat, a percentage of the amount between a minimum and a maximum, or the flat
+-- amount of the tier the amount falls in.
+CREATE TABLE "fee_schedule"(
+    product_code varchar(32) NOT NULL REFERENCES "account_product"(product_code) ON DELETE CASCADE,
+    fee_type varchar(16) NOT NULL,
+    kind varchar(16) NOT NULL,
+    amount numeric NOT NULL DEFAULT 0,
+    rate numeric NOT NULL DEFAULT 0,
+    min_amount numeric NOT NULL DEFAULT 0,
+    -- 0 when the fee has no maximum.
+    max_amount numeric NOT NULL DEFAULT 0,
+    -- The tiers, by increasing amount: [{"from": 0, "amount": 50}, {"from": 100000, "amount": 200}].
+    tiers jsonb NOT NULL DEFAULT '[]',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (product_code, fee_type)
+);
+
+-- The fees charged, booked as a transaction from the account and one to the fee income account, linked to the
+-- transaction of the transfer they are charged for, or to the month of the maintenance fees.
+CREATE TABLE "fee"(
+    transaction_id uuid PRIMARY KEY REFERENCES "transaction"(transaction_id),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    fee_type varchar(16) NOT NULL,
+    income_transaction_id uuid NOT NULL REFERENCES "transaction"(transaction_id),
+    charged_transaction_id uuid REFERENCES "transaction"(transaction_id),
+    period date,
+    amount numeric NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX fee_charged_transaction_idx ON "fee"(charged_transaction_id);
+
+-- Maintenance fees are charged once per account and month.
+CREATE UNIQUE INDEX fee_period_idx ON "fee"(account_id, period)
+WHERE
+    period IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE "account_product"
+    DROP COLUMN currency_codes,
+    DROP COLUMN limit_tier,
+    DROP COLUMN overdraft_eligible,
+    DROP COLUMN max_overdraft_limit;
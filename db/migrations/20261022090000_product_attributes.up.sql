@@ -0,0 +1,14 @@
+-- The attributes of the products driving the accounts opened for them: the currencies they can be opened in, the
+-- limit tier of their transfers, and whether they can be granted an overdraft, up to a maximum limit, 0 when there is
+-- none.
+ALTER TABLE "account_product"
+    ADD COLUMN currency_codes varchar(3)[] NOT NULL DEFAULT '{EUR}',
+    ADD COLUMN limit_tier varchar(32) NOT NULL DEFAULT 'standard' REFERENCES "limit_tier"(tier),
+    ADD COLUMN overdraft_eligible boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN max_overdraft_limit numeric NOT NULL DEFAULT 0;
+
+-- Overdrafts could be granted to the accounts of any product so far.
+UPDATE
+    "account_product"
+SET
+    overdraft_eligible = TRUE;
@@ -0,0 +1,6 @@
+-- Fails when a customer has several accounts.
+ALTER TABLE "account"
+    ADD CONSTRAINT account_email_key UNIQUE (email),
+    DROP COLUMN customer_id;
+
+DROP TABLE "customer";
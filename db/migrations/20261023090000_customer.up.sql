@@ -0,0 +1,38 @@
+-- The customers accounts are opened for: their profile, contact details and the status of their KYC checks. A
+-- customer has many accounts, so the email of accounts is no longer unique.
+CREATE TABLE "customer"(
+    customer_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name varchar(255) NOT NULL,
+    email varchar(255) UNIQUE NOT NULL,
+    phone varchar(32),
+    -- pending, verified or rejected.
+    kyc_status varchar(16) NOT NULL DEFAULT 'pending',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Each account was opened for a distinct email, which becomes its customer.
+INSERT INTO "customer"(name, email)
+SELECT
+    name,
+    email
+FROM
+    "account";
+
+ALTER TABLE "account"
+    ADD COLUMN customer_id uuid REFERENCES "customer"(customer_id);
+
+UPDATE
+    "account"
+SET
+    customer_id = "customer".customer_id
+FROM
+    "customer"
+WHERE
+    "customer".email = "account".email;
+
+ALTER TABLE "account"
+    ALTER COLUMN customer_id SET NOT NULL,
+    DROP CONSTRAINT account_email_key;
+
+CREATE INDEX account_customer_id_idx ON "account"(customer_id);
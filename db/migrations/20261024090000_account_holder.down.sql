@@ -0,0 +1,10 @@
+DROP TABLE "transfer_approval";
+
+ALTER TABLE "account"
+    DROP COLUMN approval_threshold;
+
+DROP TRIGGER account_add_owner ON "account";
+
+DROP FUNCTION add_account_owner();
+
+DROP TABLE "account_holder";
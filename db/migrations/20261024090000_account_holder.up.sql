@@ -0,0 +1,63 @@
+-- The customers operating an account, by role: the owner, the customer the account was opened for, co-owners, who
+-- operate it as the owner does, signatories, who make transfers, and viewers.
+CREATE TABLE "account_holder"(
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    customer_id uuid NOT NULL REFERENCES "customer"(customer_id),
+    -- owner, co-owner, signatory or viewer.
+    role varchar(16) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (account_id, customer_id)
+);
+
+CREATE INDEX account_holder_customer_id_idx ON "account_holder"(customer_id);
+
+INSERT INTO "account_holder"(account_id, customer_id, role)
+SELECT
+    account_id,
+    customer_id,
+    'owner'
+FROM
+    "account";
+
+-- The customer an account is opened for is its owner.
+CREATE FUNCTION add_account_owner()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    INSERT INTO "account_holder"(account_id, customer_id, role)
+        VALUES (NEW.account_id, NEW.customer_id, 'owner');
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER account_add_owner
+    AFTER INSERT ON "account"
+    FOR EACH ROW
+    EXECUTE FUNCTION add_account_owner();
+
+-- The mandate of an account: its transfers above the threshold need the approval of a second holder, there is no
+-- mandate when it is NULL.
+ALTER TABLE "account"
+    ADD COLUMN approval_threshold numeric;
+
+-- Transfers held by the mandate of their account, which are made once a holder other than the one who initiated them
+-- approves them.
+CREATE TABLE "transfer_approval"(
+    transfer_approval_id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id uuid NOT NULL REFERENCES "account"(account_id),
+    reciver_account_id uuid NOT NULL REFERENCES "account"(account_id),
+    amount numeric NOT NULL,
+    -- pending, approved or rejected.
+    status varchar(16) NOT NULL,
+    -- The principals who initiated and decided the transfer.
+    initiated_by varchar(255) NOT NULL,
+    decided_by varchar(255),
+    -- The transaction received once the transfer is approved.
+    transaction_id uuid REFERENCES "transaction"(transaction_id),
+    created_at timestamptz NOT NULL,
+    decided_at timestamptz
+);
+
+CREATE INDEX transfer_approval_account_id_status_idx ON "transfer_approval"(account_id, status, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE "account"
+    DROP COLUMN goal_date,
+    DROP COLUMN goal_amount,
+    DROP COLUMN parent_account_id;
@@ -0,0 +1,9 @@
+-- Pockets ring-fence money inside an account: they are accounts of their parent account, opened for its owner, in its
+-- currency and for its product, and may have a goal, an amount to save by an optional date. Pockets have no IBAN and
+-- no pockets of their own.
+ALTER TABLE "account"
+    ADD COLUMN parent_account_id uuid REFERENCES "account"(account_id),
+    ADD COLUMN goal_amount numeric,
+    ADD COLUMN goal_date date;
+
+CREATE INDEX account_parent_account_id_idx ON "account"(parent_account_id);
@@ -0,0 +1,15 @@
+DROP INDEX idempotency_key_created_at_idx;
+
+-- Keys used by more than one principal keep their latest use only.
+DELETE FROM "idempotency_key" older USING "idempotency_key" newer
+WHERE older.key = newer.key
+    AND (older.created_at, older.principal) < (newer.created_at, newer.principal);
+
+ALTER TABLE "idempotency_key"
+    DROP CONSTRAINT idempotency_key_pkey;
+
+ALTER TABLE "idempotency_key"
+    ADD PRIMARY KEY (key);
+
+ALTER TABLE "idempotency_key"
+    DROP COLUMN principal;
@@ -0,0 +1,13 @@
+ALTER TABLE "idempotency_key"
+    ADD COLUMN principal varchar(255) NOT NULL DEFAULT '';
+
+ALTER TABLE "idempotency_key"
+    ALTER COLUMN principal DROP DEFAULT;
+
+ALTER TABLE "idempotency_key"
+    DROP CONSTRAINT idempotency_key_pkey;
+
+ALTER TABLE "idempotency_key"
+    ADD PRIMARY KEY (principal, key);
+
+CREATE INDEX idempotency_key_created_at_idx ON "idempotency_key"(created_at);
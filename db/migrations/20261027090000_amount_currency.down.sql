@@ -0,0 +1,103 @@
+-- Amounts are stored with 2 decimals again whatever their currency.
+--
+-- The decimals of the minor unit of the currencies whose minor unit is not the hundredth, amounts of the other
+-- currencies being stored with 2 decimals before and after.
+CREATE TEMPORARY TABLE "minor_unit"(
+    currency_code varchar(3) PRIMARY KEY,
+    scale integer NOT NULL
+);
+
+INSERT INTO "minor_unit"(currency_code, scale)
+    VALUES ('BHD', 3), ('BIF', 0), ('BYR', 0), ('CLF', 4), ('CLP', 0), ('DJF', 0),
+    ('GNF', 0), ('IQD', 3), ('ISK', 0), ('JOD', 3), ('JPY', 0), ('KMF', 0),
+    ('KRW', 0), ('KWD', 3), ('LYD', 3), ('OMR', 3), ('PYG', 0), ('RWF', 0),
+    ('TND', 3), ('TZS', 0), ('UGX', 0), ('VND', 0), ('VUV', 0), ('XAF', 0),
+    ('XAG', 0), ('XAU', 0), ('XDR', 0), ('XOF', 0), ('XPF', 0);
+
+UPDATE
+    "account"
+SET
+    overdraft_limit = round(overdraft_limit * power(10::numeric, minor_unit.scale - 2), 2),
+    approval_threshold = round(approval_threshold * power(10::numeric, minor_unit.scale - 2), 2),
+    goal_amount = round(goal_amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "minor_unit"
+WHERE
+    minor_unit.currency_code = account.currency_code;
+
+UPDATE
+    "transaction"
+SET
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "account"
+    JOIN "minor_unit" ON minor_unit.currency_code = account.currency_code
+WHERE
+    account.account_id = "transaction".account_id;
+
+UPDATE
+    "overdraft_interest"
+SET
+    balance = round(balance * power(10::numeric, minor_unit.scale - 2), 2),
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "account"
+    JOIN "minor_unit" ON minor_unit.currency_code = account.currency_code
+WHERE
+    account.account_id = overdraft_interest.account_id;
+
+UPDATE
+    "interest_accrual"
+SET
+    balance = round(balance * power(10::numeric, minor_unit.scale - 2), 2),
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 10)
+FROM
+    "account"
+    JOIN "minor_unit" ON minor_unit.currency_code = account.currency_code
+WHERE
+    account.account_id = interest_accrual.account_id;
+
+UPDATE
+    "fee"
+SET
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "account"
+    JOIN "minor_unit" ON minor_unit.currency_code = account.currency_code
+WHERE
+    account.account_id = fee.account_id;
+
+UPDATE
+    "pending_transfer"
+SET
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "minor_unit"
+WHERE
+    minor_unit.currency_code = pending_transfer.currency_code;
+
+UPDATE
+    "transfer_approval"
+SET
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "minor_unit"
+WHERE
+    minor_unit.currency_code = transfer_approval.currency_code;
+
+UPDATE
+    "payment"
+SET
+    amount = round(amount * power(10::numeric, minor_unit.scale - 2), 2)
+FROM
+    "minor_unit"
+WHERE
+    minor_unit.currency_code = payment.currency_code;
+
+DROP TABLE "minor_unit";
+
+ALTER TABLE "transfer_approval"
+    DROP COLUMN currency_code;
+
+ALTER TABLE "pending_transfer"
+    DROP COLUMN currency_code;
@@ -0,0 +1,5 @@
+ALTER TABLE "outbox"
+    DROP COLUMN account_sequence;
+
+ALTER TABLE "account"
+    DROP COLUMN last_event_sequence;
@@ -0,0 +1,42 @@
+-- The events of an account are numbered by a sequence of the account, the next number being taken from its row when an
+-- event is added, which locks the row until the end of the transaction. The events of an account are so numbered in
+-- the order their transactions commit, which the outbox IDs, drawn from a sequence shared by every transaction, are
+-- not. The events added before are numbered in the order of their outbox IDs.
+ALTER TABLE "account"
+    ADD COLUMN last_event_sequence bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "outbox"
+    ADD COLUMN account_sequence bigint;
+
+UPDATE
+    "outbox" o
+SET
+    account_sequence = numbered.account_sequence
+FROM (
+    SELECT
+        outbox_event_id,
+        row_number() OVER (PARTITION BY account_id ORDER BY outbox_event_id) AS account_sequence
+    FROM
+        "outbox") numbered
+WHERE
+    o.outbox_event_id = numbered.outbox_event_id;
+
+UPDATE
+    "account" a
+SET
+    last_event_sequence = numbered.last_event_sequence
+FROM (
+    SELECT
+        account_id,
+        max(account_sequence) AS last_event_sequence
+    FROM
+        "outbox"
+    GROUP BY
+        account_id) numbered
+WHERE
+    a.account_id = numbered.account_id;
+
+ALTER TABLE "outbox"
+    ALTER COLUMN account_sequence SET NOT NULL;
+
+CREATE UNIQUE INDEX outbox_account_sequence_idx ON "outbox"(account_id, account_sequence);
@@ -0,0 +1,5 @@
+ALTER TABLE "transaction"
+    DROP COLUMN account_sequence;
+
+ALTER TABLE "account"
+    DROP COLUMN last_transaction_sequence;
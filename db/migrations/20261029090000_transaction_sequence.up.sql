@@ -0,0 +1,42 @@
+-- The transactions of an account are numbered by a sequence of the account, the next number being taken from its row
+-- when a transaction is added, which locks the row until the end of the database transaction. The transactions of an
+-- account are so numbered in the order they commit, which their creation times, taken when their database
+-- transactions start, are not. The transactions added before are numbered in the order of their creation times.
+ALTER TABLE "account"
+    ADD COLUMN last_transaction_sequence bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transaction"
+    ADD COLUMN account_sequence bigint;
+
+UPDATE
+    "transaction" t
+SET
+    account_sequence = numbered.account_sequence
+FROM (
+    SELECT
+        transaction_id,
+        row_number() OVER (PARTITION BY account_id ORDER BY created_at, transaction_id) AS account_sequence
+    FROM
+        "transaction") numbered
+WHERE
+    t.transaction_id = numbered.transaction_id;
+
+UPDATE
+    "account" a
+SET
+    last_transaction_sequence = numbered.last_transaction_sequence
+FROM (
+    SELECT
+        account_id,
+        max(account_sequence) AS last_transaction_sequence
+    FROM
+        "transaction"
+    GROUP BY
+        account_id) numbered
+WHERE
+    a.account_id = numbered.account_id;
+
+ALTER TABLE "transaction"
+    ALTER COLUMN account_sequence SET NOT NULL;
+
+CREATE UNIQUE INDEX transaction_account_sequence_idx ON "transaction"(account_id, account_sequence);
@@ -0,0 +1,3 @@
+ALTER TABLE "payment"
+    DROP COLUMN pending_transfer_id,
+    DROP COLUMN transfer_approval_id;
@@ -0,0 +1,5 @@
+-- The transfers held for approval or review that payments were executed as, which decide the payments once they are
+-- decided themselves.
+ALTER TABLE "payment"
+    ADD COLUMN transfer_approval_id uuid REFERENCES "transfer_approval"(transfer_approval_id),
+    ADD COLUMN pending_transfer_id uuid REFERENCES "pending_transfer"(pending_transfer_id);
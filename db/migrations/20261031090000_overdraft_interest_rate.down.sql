@@ -0,0 +1,2 @@
+ALTER TABLE "overdraft_interest"
+    DROP COLUMN interest_rate;
@@ -0,0 +1,4 @@
+-- The annual interest rate the interest of a day was charged at, e.g. 0.12 for 12%, unknown for the interest charged
+-- before it was recorded.
+ALTER TABLE "overdraft_interest"
+    ADD COLUMN interest_rate numeric;
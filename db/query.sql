@@ -1,12 +1,78 @@
 -- name: CreateAccount :one
-INSERT INTO "account"(email, name, currency_code)
-    VALUES ($1, $2, $3)
+-- The account is opened for the customer of customer_id, or for a new customer of its name and email when it is null.
+WITH c AS (
+INSERT INTO "customer"(name, email)
+    SELECT
+        sqlc.arg(name)::varchar,
+        sqlc.arg(email)::varchar
+    WHERE
+        sqlc.narg(customer_id)::uuid IS NULL
+    RETURNING
+        customer_id)
+INSERT INTO "account"(email, name, currency_code, account_number, iban, product_code, customer_id)
+    VALUES (sqlc.arg(email), sqlc.arg(name), sqlc.arg(currency_code), sqlc.arg(account_number), sqlc.arg(iban),
+        sqlc.arg(product_code), COALESCE(sqlc.narg(customer_id)::uuid,(
+                SELECT
+                    customer_id
+                FROM c)))
 RETURNING
     *;
 
+-- name: NextAccountNumber :one
+SELECT
+    nextval('account_number_seq')::bigint;
+
+-- name: GetAccountByIBAN :one
+SELECT
+    *
+FROM
+    "account"
+WHERE
+    iban = $1;
+
+-- name: ListAccountsWithoutIBAN :many
+-- Pockets have no IBAN.
+SELECT
+    *
+FROM
+    "account"
+WHERE
+    iban IS NULL
+    AND parent_account_id IS NULL
+ORDER BY
+    account_number
+LIMIT $1;
+
+-- name: SetAccountIBAN :exec
+UPDATE
+    "account"
+SET
+    iban = $2
+WHERE
+    account_id = $1;
+
 -- name: AddTransaction :one
-INSERT INTO "transaction"(account_id, amount, source_id)
-    VALUES ($1, $2, $3)
+-- Numbers the transaction by the next sequence of its account, whose row is locked until the end of the database
+-- transaction, so that the transactions of an account are numbered in the order they commit. No transaction is added
+-- when the account is not found.
+WITH numbered AS (
+    UPDATE
+        "account"
+    SET
+        last_transaction_sequence = last_transaction_sequence + 1
+    WHERE
+        account_id = sqlc.arg('account_id')
+    RETURNING
+        last_transaction_sequence)
+INSERT INTO "transaction"(account_id, amount, source_id, type, account_sequence)
+SELECT
+    sqlc.arg('account_id'),
+    sqlc.arg('amount'),
+    sqlc.arg('source_id'),
+    sqlc.arg('type'),
+    numbered.last_transaction_sequence
+FROM
+    numbered
 RETURNING
     *;
 
@@ -35,3 +101,1178 @@ FROM
     "transaction"
 WHERE
     account_id = $1;
+
+-- name: ClaimIdempotencyKey :execrows
+INSERT INTO "idempotency_key"(principal, key, request_hash)
+    VALUES ($1, $2, $3)
+ON CONFLICT (principal, key)
+    DO UPDATE SET
+        request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL, response_body = NULL,
+            created_at = now()
+    WHERE ("idempotency_key".status_code IS NULL
+        AND "idempotency_key".created_at < sqlc.arg('claimed_before'))
+        OR "idempotency_key".created_at < sqlc.arg('expired_before');
+
+-- name: GetIdempotencyKey :one
+SELECT
+    *
+FROM
+    "idempotency_key"
+WHERE
+    principal = $1
+    AND key = $2;
+
+-- name: SaveIdempotencyKeyResponse :exec
+UPDATE
+    "idempotency_key"
+SET
+    status_code = $3,
+    content_type = $4,
+    response_body = $5
+WHERE
+    principal = $1
+    AND key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM "idempotency_key"
+WHERE principal = $1
+    AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM "idempotency_key"
+WHERE created_at < sqlc.arg('expired_before');
+
+-- name: ListTransactions :many
+-- Lists the transactions of an account, the last committed first, so that the first one is the one the events of the
+-- account are streamed after.
+SELECT
+    *
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+ORDER BY
+    account_sequence DESC
+LIMIT $2 OFFSET $3;
+
+-- name: HasAccountTransaction :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "transaction"
+        WHERE
+            account_id = $1
+            AND transaction_id = $2);
+
+-- name: ListTransactionsAfter :many
+-- Lists the transactions of an account by their sequence, the order they were committed in, so that a transaction
+-- committed after the transaction after is listed after it, whatever its creation time.
+SELECT
+    t.*
+FROM
+    "transaction" t
+WHERE
+    t.account_id = sqlc.arg('account_id')
+    AND (sqlc.narg('after')::uuid IS NULL
+        OR t.account_sequence > (
+            SELECT
+                a.account_sequence
+            FROM
+                "transaction" a
+            WHERE
+                a.transaction_id = sqlc.narg('after')))
+ORDER BY
+    t.account_sequence
+LIMIT sqlc.arg('limit');
+
+-- name: GetAccountBalanceBefore :one
+SELECT
+    COALESCE(SUM(amount), 0)::numeric
+FROM
+    "transaction"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND created_at < sqlc.arg('before');
+
+-- name: ListTransactionsBetween :many
+SELECT
+    *
+FROM
+    "transaction"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND created_at >= sqlc.arg('from')
+    AND created_at < sqlc.arg('to')
+    AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+        OR (created_at, transaction_id) > (sqlc.narg('after_created_at'), sqlc.narg('after_transaction_id')::uuid))
+ORDER BY
+    created_at,
+    transaction_id
+LIMIT sqlc.arg('limit');
+
+-- name: LockAuditChain :exec
+SELECT
+    pg_advisory_xact_lock(hashtext('audit_event'));
+
+-- name: GetLastAuditEventHash :one
+SELECT
+    hash
+FROM
+    "audit_event"
+ORDER BY
+    audit_event_id DESC
+LIMIT 1;
+
+-- name: AddAuditEvent :one
+INSERT INTO "audit_event"(occurred_at, principal, action, account_id, request_id, client_ip, outcome, before, after, prev_hash, hash)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING
+    *;
+
+-- name: ListAuditEvents :many
+SELECT
+    *
+FROM
+    "audit_event"
+WHERE (sqlc.narg('account_id')::uuid IS NULL
+    OR account_id = sqlc.narg('account_id'))
+AND (sqlc.narg('principal')::varchar IS NULL
+    OR principal = sqlc.narg('principal'))
+AND (sqlc.narg('action')::varchar IS NULL
+    OR action = sqlc.narg('action'))
+AND (sqlc.narg('from')::timestamptz IS NULL
+    OR occurred_at >= sqlc.narg('from'))
+AND (sqlc.narg('to')::timestamptz IS NULL
+    OR occurred_at < sqlc.narg('to'))
+ORDER BY
+    audit_event_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListAuditEventsAfter :many
+SELECT
+    *
+FROM
+    "audit_event"
+WHERE
+    audit_event_id > $1
+ORDER BY
+    audit_event_id
+LIMIT $2;
+
+-- name: AddOutboxEvent :one
+-- Numbers the event by the next sequence of its account, whose row is locked until the end of the transaction, so
+-- that the events of an account are numbered, and their outbox IDs drawn, in the order their transactions commit.
+WITH numbered AS (
+    UPDATE
+        "account"
+    SET
+        last_event_sequence = last_event_sequence + 1
+    WHERE
+        account_id = sqlc.arg('account_id')
+    RETURNING
+        last_event_sequence)
+INSERT INTO "outbox"(event_type, account_id, account_sequence, payload, occurred_at)
+SELECT
+    sqlc.arg('event_type'),
+    sqlc.arg('account_id'),
+    numbered.last_event_sequence,
+    sqlc.arg('payload'),
+    sqlc.arg('occurred_at')
+FROM
+    numbered
+RETURNING
+    *;
+
+-- name: ListUnpublishedOutboxEvents :many
+SELECT
+    *
+FROM
+    "outbox"
+WHERE
+    published_at IS NULL
+ORDER BY
+    outbox_event_id
+LIMIT $1
+FOR UPDATE;
+
+-- name: MarkOutboxEventsPublished :exec
+UPDATE
+    "outbox"
+SET
+    published_at = sqlc.arg('published_at')
+WHERE
+    outbox_event_id = ANY (sqlc.arg('outbox_event_ids')::bigint[]);
+
+-- name: Notify :exec
+SELECT
+    pg_notify(sqlc.arg('channel')::text, sqlc.arg('payload')::text);
+
+-- name: CreateWebhook :one
+INSERT INTO "webhook"(url, event_types, account_id, secret)
+    VALUES ($1, $2, $3, $4)
+RETURNING
+    *;
+
+-- name: HasWebhook :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "webhook"
+        WHERE
+            webhook_id = $1);
+
+-- name: ListWebhooksForEvent :many
+SELECT
+    *
+FROM
+    "webhook"
+WHERE (account_id IS NULL
+    OR account_id = sqlc.arg('account_id'))
+AND (cardinality(event_types) = 0
+    OR sqlc.arg('event_type')::varchar = ANY (event_types));
+
+-- name: AddWebhookDelivery :exec
+INSERT INTO "webhook_delivery"(webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+    VALUES (sqlc.arg('webhook_id'), sqlc.arg('event_id'), sqlc.arg('event_type'), sqlc.arg('payload'), sqlc.arg('status'), sqlc.arg('created_at'), sqlc.arg('created_at'), sqlc.arg('created_at'))
+ON CONFLICT (webhook_id, event_id)
+    DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- The due deliveries are leased until leased_until, when they are due again unless their attempt was recorded.
+WITH due AS (
+    SELECT
+        webhook_delivery.webhook_delivery_id
+    FROM
+        "webhook_delivery"
+    WHERE
+        webhook_delivery.status = 'pending'
+        AND webhook_delivery.next_attempt_at <= sqlc.arg('now')
+    ORDER BY
+        webhook_delivery.next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE
+        SKIP LOCKED)
+UPDATE
+    "webhook_delivery" d
+SET
+    next_attempt_at = sqlc.arg('leased_until')
+FROM
+    due,
+    "webhook" w
+WHERE
+    d.webhook_delivery_id = due.webhook_delivery_id
+    AND w.webhook_id = d.webhook_id
+RETURNING
+    d.webhook_delivery_id,
+    d.event_id,
+    d.event_type,
+    d.payload,
+    d.attempts,
+    w.url,
+    w.secret;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE
+    "webhook_delivery"
+SET
+    status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_status_code = $5,
+    last_error = $6,
+    updated_at = $7
+WHERE
+    webhook_delivery_id = $1;
+
+-- name: AddWebhookDeliveryAttempt :exec
+INSERT INTO "webhook_delivery_attempt"(webhook_delivery_id, attempted_at, status_code, error, duration_ms)
+    VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListWebhookDeliveries :many
+SELECT
+    *
+FROM
+    "webhook_delivery"
+WHERE
+    webhook_id = $1
+ORDER BY
+    created_at DESC,
+    webhook_delivery_id
+LIMIT $2 OFFSET $3;
+
+-- name: GetWebhookDelivery :one
+SELECT
+    *
+FROM
+    "webhook_delivery"
+WHERE
+    webhook_id = $1
+    AND webhook_delivery_id = $2;
+
+-- name: ListWebhookDeliveryAttempts :many
+SELECT
+    *
+FROM
+    "webhook_delivery_attempt"
+WHERE
+    webhook_delivery_id = $1
+ORDER BY
+    webhook_delivery_attempt_id;
+
+-- name: RedeliverWebhookDelivery :one
+UPDATE
+    "webhook_delivery"
+SET
+    status = 'pending',
+    attempts = 0,
+    next_attempt_at = sqlc.arg('now'),
+    updated_at = sqlc.arg('now')
+WHERE
+    webhook_id = sqlc.arg('webhook_id')
+    AND webhook_delivery_id = sqlc.arg('webhook_delivery_id')
+RETURNING
+    *;
+
+-- name: CreatePaymentFile :one
+INSERT INTO "payment_file"(account_id, message_id, message_created_at, number_of_transactions, control_sum, status, reason_code, reason, created_at, updated_at)
+    VALUES (sqlc.arg('account_id'), sqlc.arg('message_id'), sqlc.arg('message_created_at'), sqlc.arg('number_of_transactions'), sqlc.arg('control_sum'), sqlc.arg('status'), sqlc.arg('reason_code'), sqlc.arg('reason'), sqlc.arg('created_at'), sqlc.arg('created_at'))
+RETURNING
+    *;
+
+-- name: AddPayment :one
+INSERT INTO "payment"(payment_file_id, position, payment_information_id, instruction_id, end_to_end_id, amount, currency_code, creditor_account, status, reason_code, reason, created_at, updated_at)
+    VALUES (sqlc.arg('payment_file_id'), sqlc.arg('position'), sqlc.arg('payment_information_id'), sqlc.arg('instruction_id'), sqlc.arg('end_to_end_id'), sqlc.arg('amount'), sqlc.arg('currency_code'), sqlc.arg('creditor_account'), sqlc.arg('status'), sqlc.arg('reason_code'), sqlc.arg('reason'), sqlc.arg('created_at'), sqlc.arg('created_at'))
+RETURNING
+    *;
+
+-- name: ExecutePayment :execrows
+-- Saves the outcome of the transfer of a payment unless it was executed already: its transfer was made or held, so
+-- that a payment is executed once.
+UPDATE
+    "payment"
+SET
+    status = sqlc.arg('status'),
+    reason_code = sqlc.arg('reason_code'),
+    reason = sqlc.arg('reason'),
+    transaction_id = sqlc.arg('transaction_id'),
+    transfer_approval_id = sqlc.arg('transfer_approval_id'),
+    pending_transfer_id = sqlc.arg('pending_transfer_id'),
+    updated_at = sqlc.arg('updated_at')
+WHERE
+    payment_id = sqlc.arg('payment_id')
+    AND status = 'PDNG'
+    AND transaction_id IS NULL
+    AND transfer_approval_id IS NULL
+    AND pending_transfer_id IS NULL;
+
+-- name: UpdatePayment :exec
+UPDATE
+    "payment"
+SET
+    status = $2,
+    reason_code = $3,
+    reason = $4,
+    transaction_id = $5,
+    updated_at = $6
+WHERE
+    payment_id = $1;
+
+-- name: ListHeldPayments :many
+-- Lists the pending payments of a file held for approval or review, with the decision of their held transfer.
+SELECT
+    sqlc.embed(payment),
+    transfer_approval.status AS approval_status,
+    transfer_approval.transaction_id AS approval_transaction_id,
+    pending_transfer.status AS review_status,
+    pending_transfer.transaction_id AS review_transaction_id
+FROM
+    "payment"
+    LEFT JOIN "transfer_approval" ON transfer_approval.transfer_approval_id = payment.transfer_approval_id
+    LEFT JOIN "pending_transfer" ON pending_transfer.pending_transfer_id = payment.pending_transfer_id
+WHERE
+    payment.payment_file_id = $1
+    AND payment.status = 'PDNG'
+    AND (payment.transfer_approval_id IS NOT NULL
+        OR payment.pending_transfer_id IS NOT NULL)
+ORDER BY
+    payment.position;
+
+-- name: UpdatePaymentFileStatus :exec
+UPDATE
+    "payment_file"
+SET
+    status = $2,
+    updated_at = $3
+WHERE
+    payment_file_id = $1;
+
+-- name: GetPaymentFile :one
+SELECT
+    *
+FROM
+    "payment_file"
+WHERE
+    account_id = $1
+    AND payment_file_id = $2;
+
+-- name: GetPaymentFileByMessageID :one
+SELECT
+    *
+FROM
+    "payment_file"
+WHERE
+    account_id = $1
+    AND message_id = $2;
+
+-- name: ListPayments :many
+SELECT
+    *
+FROM
+    "payment"
+WHERE
+    payment_file_id = $1
+ORDER BY
+    position;
+
+-- name: CreateBeneficiary :one
+INSERT INTO "beneficiary"(account_id, nickname, reciver_account_id, transfer_limit, created_at, updated_at)
+    VALUES (sqlc.arg('account_id'), sqlc.arg('nickname'), sqlc.arg('reciver_account_id'), sqlc.arg('transfer_limit'), sqlc.arg('created_at'), sqlc.arg('created_at'))
+RETURNING
+    *;
+
+-- name: ListBeneficiaries :many
+SELECT
+    sqlc.embed(beneficiary),
+    account.iban AS reciver_iban
+FROM
+    "beneficiary"
+    JOIN "account" ON account.account_id = beneficiary.reciver_account_id
+WHERE
+    beneficiary.account_id = $1
+ORDER BY
+    beneficiary.nickname,
+    beneficiary.beneficiary_id;
+
+-- name: GetBeneficiary :one
+SELECT
+    sqlc.embed(beneficiary),
+    account.iban AS reciver_iban
+FROM
+    "beneficiary"
+    JOIN "account" ON account.account_id = beneficiary.reciver_account_id
+WHERE
+    beneficiary.account_id = $1
+    AND beneficiary.beneficiary_id = $2;
+
+-- name: UpdateBeneficiary :one
+UPDATE
+    "beneficiary"
+SET
+    nickname = $3,
+    transfer_limit = $4,
+    updated_at = $5
+WHERE
+    account_id = $1
+    AND beneficiary_id = $2
+RETURNING
+    *;
+
+-- name: DeleteBeneficiary :execrows
+DELETE FROM "beneficiary"
+WHERE account_id = $1
+    AND beneficiary_id = $2;
+
+-- name: GetAccountLimits :one
+SELECT
+    limit_tier.tier,
+    COALESCE(account_limit.max_transfer, limit_tier.max_transfer) AS max_transfer,
+    COALESCE(account_limit.daily_amount, limit_tier.daily_amount) AS daily_amount,
+    COALESCE(account_limit.monthly_amount, limit_tier.monthly_amount) AS monthly_amount,
+    COALESCE(account_limit.daily_count, limit_tier.daily_count) AS daily_count
+FROM
+    "limit_tier"
+    LEFT JOIN "account_limit" ON account_limit.account_id = $1
+WHERE
+    limit_tier.tier = COALESCE(account_limit.tier, (
+            SELECT
+                account_product.limit_tier
+            FROM "account"
+            JOIN "account_product" ON account_product.product_code = account.product_code
+            WHERE
+                account.account_id = $1), 'standard');
+
+-- name: GetTransferUsage :one
+SELECT
+    COALESCE(SUM(- amount) FILTER (WHERE created_at >= sqlc.arg('day_start')), 0)::numeric AS daily_amount,
+    COUNT(*) FILTER (WHERE created_at >= sqlc.arg('day_start'))::integer AS daily_count,
+    COALESCE(SUM(- amount), 0)::numeric AS monthly_amount,
+    (
+        SELECT
+            account.currency_code
+        FROM "account"
+        WHERE
+            account.account_id = sqlc.arg('account_id'))::varchar AS currency_code
+FROM
+    "transaction"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= sqlc.arg('month_start');
+
+-- name: SetLimitTier :one
+INSERT INTO "limit_tier"(tier, max_transfer, daily_amount, monthly_amount, daily_count, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tier)
+    DO UPDATE SET
+        max_transfer = EXCLUDED.max_transfer, daily_amount = EXCLUDED.daily_amount, monthly_amount = EXCLUDED.monthly_amount, daily_count = EXCLUDED.daily_count, updated_at = EXCLUDED.updated_at
+    RETURNING
+        *;
+
+-- name: SetAccountLimits :exec
+INSERT INTO "account_limit"(account_id, tier, max_transfer, daily_amount, monthly_amount, daily_count, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id)
+    DO UPDATE SET
+        tier = EXCLUDED.tier, max_transfer = EXCLUDED.max_transfer, daily_amount = EXCLUDED.daily_amount, monthly_amount = EXCLUDED.monthly_amount, daily_count = EXCLUDED.daily_count, updated_at = EXCLUDED.updated_at;
+
+-- name: CountTransfersSince :one
+SELECT
+    COUNT(*)::integer
+FROM
+    "transaction"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= sqlc.arg('since');
+
+-- name: GetTransferAverage :one
+SELECT
+    COUNT(*)::integer AS transfers,
+    COALESCE(ROUND(AVG(- amount), 2), 0)::numeric AS average
+FROM
+    "transaction"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= sqlc.arg('since');
+
+-- name: HasTransferredTo :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "transaction" sent
+            JOIN "transaction" received ON received.source_id = sent.transaction_id
+        WHERE
+            sent.account_id = sqlc.arg('account_id')
+            AND received.account_id = sqlc.arg('reciver_account_id')
+            AND sent.created_at >= sqlc.arg('since'));
+
+-- name: CreatePendingTransfer :one
+INSERT INTO "pending_transfer"(account_id, reciver_account_id, amount, currency_code, status, rules, created_at)
+    VALUES ($1, $2, $3, $4, 'pending', $5, $6)
+RETURNING
+    *;
+
+-- name: GetPendingTransfer :one
+SELECT
+    *
+FROM
+    "pending_transfer"
+WHERE
+    pending_transfer_id = $1;
+
+-- name: ListPendingTransfers :many
+SELECT
+    *
+FROM
+    "pending_transfer"
+WHERE
+    status = sqlc.arg('status')
+ORDER BY
+    created_at,
+    pending_transfer_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: DecidePendingTransfer :one
+UPDATE
+    "pending_transfer"
+SET
+    status = sqlc.arg('status'),
+    decided_at = sqlc.arg('decided_at')
+WHERE
+    pending_transfer_id = sqlc.arg('pending_transfer_id')
+    AND status = 'pending'
+RETURNING
+    *;
+
+-- name: ReopenPendingTransfer :exec
+UPDATE
+    "pending_transfer"
+SET
+    status = 'pending',
+    decided_at = NULL
+WHERE
+    pending_transfer_id = $1;
+
+-- name: SetPendingTransferTransaction :exec
+UPDATE
+    "pending_transfer"
+SET
+    transaction_id = $2
+WHERE
+    pending_transfer_id = $1;
+
+-- name: UpsertSanctionsList :exec
+INSERT INTO "sanctions_list"(list, entries, imported_at)
+    VALUES ($1, $2, $3)
+ON CONFLICT (list)
+    DO UPDATE SET
+        entries = EXCLUDED.entries, imported_at = EXCLUDED.imported_at;
+
+-- name: DeleteSanctionsEntries :exec
+DELETE FROM "sanctions_entry"
+WHERE list = $1;
+
+-- name: AddSanctionsEntries :copyfrom
+INSERT INTO "sanctions_entry"(list, reference, name)
+    VALUES ($1, $2, $3);
+
+-- name: GetSanctionsVersion :one
+SELECT
+    COUNT(*)::integer AS lists,
+    COALESCE(SUM(entries), 0)::integer AS entries,
+    COALESCE(MAX(imported_at), '-infinity')::timestamptz AS imported_at
+FROM
+    "sanctions_list";
+
+-- name: ListSanctionsEntries :many
+SELECT
+    *
+FROM
+    "sanctions_entry"
+ORDER BY
+    list,
+    reference;
+
+-- name: AddSanctionsScreening :one
+INSERT INTO "sanctions_screening"(account_id, name, status, matches, created_at)
+    VALUES ($1, $2, $3, $4, $5)
+RETURNING
+    *;
+
+-- name: SetAccountScreeningStatus :exec
+UPDATE
+    "account"
+SET
+    screening_status = $2
+WHERE
+    account_id = $1;
+
+-- name: GetSanctionsScreening :one
+SELECT
+    *
+FROM
+    "sanctions_screening"
+WHERE
+    screening_id = $1;
+
+-- name: ListSanctionsScreenings :many
+SELECT
+    *
+FROM
+    "sanctions_screening"
+WHERE
+    status = sqlc.arg('status')
+ORDER BY
+    created_at,
+    screening_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ResolveSanctionsScreening :one
+UPDATE
+    "sanctions_screening"
+SET
+    status = sqlc.arg('status'),
+    resolved_at = sqlc.arg('resolved_at')
+WHERE
+    screening_id = sqlc.arg('screening_id')
+    AND status = 'review'
+RETURNING
+    *;
+
+-- name: SetAccountOverdraft :execrows
+UPDATE
+    "account"
+SET
+    overdraft_limit = $2
+WHERE
+    account_id = $1;
+
+-- name: GetOverdraftTerms :one
+SELECT
+    account.currency_code,
+    account_product.overdraft_eligible,
+    account_product.max_overdraft_limit
+FROM
+    "account"
+    JOIN "account_product" ON account_product.product_code = account.product_code
+WHERE
+    account.account_id = $1;
+
+-- name: ListOverdrawnAccounts :many
+SELECT
+    "transaction".account_id,
+    account.currency_code,
+    SUM(amount)::numeric AS balance
+FROM
+    "transaction"
+    JOIN "account" ON account.account_id = "transaction".account_id
+WHERE
+    "transaction".created_at < sqlc.arg('day_end')
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "overdraft_interest"
+        WHERE
+            overdraft_interest.account_id = "transaction".account_id
+            AND overdraft_interest.day = sqlc.arg('day'))
+GROUP BY
+    "transaction".account_id,
+    account.currency_code
+HAVING
+    SUM(amount) < 0
+ORDER BY
+    "transaction".account_id;
+
+-- name: AddOverdraftInterest :execrows
+INSERT INTO "overdraft_interest"(account_id, day, transaction_id, balance, interest_rate, amount, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id, day)
+    DO NOTHING;
+
+-- name: GetLastOverdraftInterestDay :one
+-- Returns the last day interest was charged for on negative balances, null when none was.
+SELECT
+    MAX(day)::date AS day
+FROM
+    "overdraft_interest";
+
+-- name: UpsertAccountProduct :one
+INSERT INTO "account_product"(product_code, name, interest_rate, day_count, currency_codes, limit_tier, overdraft_eligible, max_overdraft_limit, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (product_code)
+    DO UPDATE SET
+        name = EXCLUDED.name, interest_rate = EXCLUDED.interest_rate, day_count = EXCLUDED.day_count, currency_codes = EXCLUDED.currency_codes, limit_tier = EXCLUDED.limit_tier, overdraft_eligible = EXCLUDED.overdraft_eligible, max_overdraft_limit = EXCLUDED.max_overdraft_limit, updated_at = EXCLUDED.updated_at
+    RETURNING
+        *;
+
+-- name: GetAccountProduct :one
+SELECT
+    *
+FROM
+    "account_product"
+WHERE
+    product_code = $1;
+
+-- name: ListAccountProducts :many
+SELECT
+    *
+FROM
+    "account_product"
+ORDER BY
+    product_code;
+
+-- name: SetAccountProduct :execrows
+UPDATE
+    "account"
+SET
+    product_code = $2
+WHERE
+    account_id = $1;
+
+-- name: ListAccruingAccounts :many
+SELECT
+    account.account_id,
+    account.product_code,
+    account_product.interest_rate,
+    account_product.day_count,
+    SUM("transaction".amount)::numeric AS balance
+FROM
+    "account"
+    JOIN "account_product" ON account_product.product_code = account.product_code
+    JOIN "transaction" ON "transaction".account_id = account.account_id
+        AND "transaction".created_at < sqlc.arg('day_end')
+WHERE
+    account_product.interest_rate > 0
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "interest_accrual"
+        WHERE
+            interest_accrual.account_id = account.account_id
+            AND interest_accrual.day = sqlc.arg('day'))
+GROUP BY
+    account.account_id,
+    account_product.product_code
+HAVING
+    SUM("transaction".amount) > 0
+ORDER BY
+    account.account_id;
+
+-- name: AddInterestAccrual :execrows
+INSERT INTO "interest_accrual"(account_id, day, product_code, balance, interest_rate, day_count, amount, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (account_id, day)
+    DO NOTHING;
+
+-- name: ListUncapitalizedAccounts :many
+SELECT DISTINCT
+    interest_accrual.account_id,
+    account.currency_code
+FROM
+    "interest_accrual"
+    JOIN "account" ON account.account_id = interest_accrual.account_id
+WHERE
+    interest_accrual.transaction_id IS NULL
+    AND interest_accrual.day < sqlc.arg('before')
+ORDER BY
+    interest_accrual.account_id;
+
+-- name: LockUncapitalizedInterest :many
+SELECT
+    amount
+FROM
+    "interest_accrual"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND transaction_id IS NULL
+    AND day < sqlc.arg('before')
+FOR UPDATE;
+
+-- name: CapitalizeInterest :exec
+UPDATE
+    "interest_accrual"
+SET
+    transaction_id = sqlc.arg('transaction_id')
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND transaction_id IS NULL
+    AND day < sqlc.arg('before');
+
+-- name: ListInterestAccruals :many
+SELECT
+    *
+FROM
+    "interest_accrual"
+WHERE
+    account_id = sqlc.arg('account_id')
+ORDER BY
+    day DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: UpsertFeeSchedule :one
+INSERT INTO "fee_schedule"(product_code, fee_type, kind, amount, rate, min_amount, max_amount, tiers, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (product_code, fee_type)
+    DO UPDATE SET
+        kind = EXCLUDED.kind, amount = EXCLUDED.amount, rate = EXCLUDED.rate, min_amount = EXCLUDED.min_amount, max_amount = EXCLUDED.max_amount, tiers = EXCLUDED.tiers, updated_at = EXCLUDED.updated_at
+    RETURNING
+        *;
+
+-- name: ListFeeSchedules :many
+SELECT
+    *
+FROM
+    "fee_schedule"
+WHERE
+    product_code = $1
+ORDER BY
+    fee_type;
+
+-- name: GetFeeSchedule :one
+SELECT
+    *
+FROM
+    "fee_schedule"
+WHERE
+    product_code = $1
+    AND fee_type = $2;
+
+-- name: DeleteFeeSchedule :execrows
+DELETE FROM "fee_schedule"
+WHERE product_code = $1
+    AND fee_type = $2;
+
+-- name: AddFee :execrows
+INSERT INTO "fee"(transaction_id, account_id, fee_type, income_transaction_id, charged_transaction_id, period, amount)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id, period)
+WHERE
+    period IS NOT NULL
+        DO NOTHING;
+
+-- name: ListMaintenanceFeeAccounts :many
+SELECT
+    account.account_id,
+    account.currency_code,
+    sqlc.embed(fee_schedule),
+    COALESCE(SUM("transaction".amount), 0)::numeric AS balance
+FROM
+    "account"
+    JOIN "fee_schedule" ON fee_schedule.product_code = account.product_code
+        AND fee_schedule.fee_type = 'maintenance'
+    LEFT JOIN "transaction" ON "transaction".account_id = account.account_id
+        AND "transaction".created_at < sqlc.arg('period_end')
+WHERE
+    account.currency_code = ANY (sqlc.arg('currency_codes')::varchar[])
+    AND account.account_id <> ALL (sqlc.arg('income_account_ids')::uuid[])
+    AND account.parent_account_id IS NULL
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "fee"
+        WHERE
+            fee.account_id = account.account_id
+            AND fee.period = sqlc.arg('period'))
+GROUP BY
+    account.account_id,
+    fee_schedule.product_code,
+    fee_schedule.fee_type
+ORDER BY
+    account.account_id;
+
+-- name: CreateCustomer :one
+INSERT INTO "customer"(name, email, phone)
+    VALUES ($1, $2, $3)
+RETURNING
+    *;
+
+-- name: GetCustomer :one
+SELECT
+    *
+FROM
+    "customer"
+WHERE
+    customer_id = $1;
+
+-- name: SetCustomerKYCStatus :one
+UPDATE
+    "customer"
+SET
+    kyc_status = $2,
+    updated_at = $3
+WHERE
+    customer_id = $1
+RETURNING
+    *;
+
+-- name: ListCustomerAccounts :many
+-- The accounts a customer holds, in any role, and their pockets, which are held through their parent account.
+SELECT
+    sqlc.embed(account),
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "account_holder" h
+        WHERE
+            h.customer_id = sqlc.arg(customer_id)
+            AND h.account_id IN (account.account_id, account.parent_account_id))
+GROUP BY
+    account.account_id
+ORDER BY
+    account.account_number;
+
+-- name: GetAccountHolderRole :one
+SELECT
+    role
+FROM
+    "account_holder"
+WHERE
+    account_id = $1
+    AND customer_id = $2;
+
+-- name: ListAccountHolders :many
+SELECT
+    *
+FROM
+    "account_holder"
+WHERE
+    account_id = $1
+ORDER BY
+    created_at,
+    customer_id;
+
+-- name: UpsertAccountHolder :one
+-- The role of the owner cannot be changed.
+INSERT INTO "account_holder"(account_id, customer_id, role, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (account_id, customer_id)
+    DO UPDATE SET
+        role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+    WHERE
+        account_holder.role <> 'owner'
+    RETURNING
+        *;
+
+-- name: DeleteAccountHolder :execrows
+DELETE FROM "account_holder"
+WHERE account_id = $1
+    AND customer_id = $2
+    AND role <> 'owner';
+
+-- name: SetAccountApprovalThreshold :execrows
+UPDATE
+    "account"
+SET
+    approval_threshold = $2
+WHERE
+    account_id = $1;
+
+-- name: CreateTransferApproval :one
+INSERT INTO "transfer_approval"(account_id, reciver_account_id, amount, currency_code, status, initiated_by, created_at)
+    VALUES ($1, $2, $3, $4, 'pending', $5, $6)
+RETURNING
+    *;
+
+-- name: GetTransferApproval :one
+SELECT
+    *
+FROM
+    "transfer_approval"
+WHERE
+    transfer_approval_id = $1
+    AND account_id = $2;
+
+-- name: ListTransferApprovals :many
+SELECT
+    *
+FROM
+    "transfer_approval"
+WHERE
+    account_id = sqlc.arg('account_id')
+    AND status = sqlc.arg('status')
+ORDER BY
+    created_at,
+    transfer_approval_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: DecideTransferApproval :one
+UPDATE
+    "transfer_approval"
+SET
+    status = sqlc.arg('status'),
+    decided_by = sqlc.arg('decided_by'),
+    decided_at = sqlc.arg('decided_at')
+WHERE
+    transfer_approval_id = sqlc.arg('transfer_approval_id')
+    AND status = 'pending'
+RETURNING
+    *;
+
+-- name: ReopenTransferApproval :exec
+UPDATE
+    "transfer_approval"
+SET
+    status = 'pending',
+    decided_by = NULL,
+    decided_at = NULL
+WHERE
+    transfer_approval_id = $1;
+
+-- name: SetTransferApprovalTransaction :exec
+UPDATE
+    "transfer_approval"
+SET
+    transaction_id = $2
+WHERE
+    transfer_approval_id = $1;
+
+
+-- name: CreatePocket :one
+-- A pocket is opened for the customer of its parent account, in its currency and for its product.
+INSERT INTO "account"(email, name, currency_code, account_number, product_code, customer_id, screening_status,
+    parent_account_id)
+SELECT
+    parent.email,
+    sqlc.arg('name'),
+    parent.currency_code,
+    sqlc.arg('account_number'),
+    parent.product_code,
+    parent.customer_id,
+    parent.screening_status,
+    parent.account_id
+FROM
+    "account" parent
+WHERE
+    parent.account_id = sqlc.arg('parent_account_id')
+RETURNING
+    *;
+
+-- name: GetPocket :one
+SELECT
+    sqlc.embed(account),
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    account.account_id = sqlc.arg('pocket_id')
+    AND account.parent_account_id = sqlc.arg('parent_account_id')
+GROUP BY
+    account.account_id;
+
+-- name: ListPockets :many
+SELECT
+    sqlc.embed(account),
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    account.parent_account_id = $1
+GROUP BY
+    account.account_id
+ORDER BY
+    account.account_number;
+
+-- name: SetPocketGoal :execrows
+UPDATE
+    "account"
+SET
+    goal_amount = sqlc.arg('goal_amount'),
+    goal_date = sqlc.arg('goal_date')
+WHERE
+    account_id = sqlc.arg('pocket_id')
+    AND parent_account_id = sqlc.arg('parent_account_id');
+
+-- name: LockAccounts :exec
+-- Locks accounts until the end of the transaction, so that the money moved from them is checked against their balance
+-- one move at a time. The accounts are locked in the order of their IDs, so that the moves between the same accounts
+-- in opposite directions do not deadlock.
+SELECT
+    account_id
+FROM
+    "account"
+WHERE
+    account_id = ANY (sqlc.arg('account_ids')::uuid[])
+ORDER BY
+    account_id
+FOR UPDATE;
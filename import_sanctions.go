@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/sanctions"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+const importSanctionsCommand = "import-sanctions"
+
+var errImportSanctionsUsage = errors.New("usage: xbankapi import-sanctions -list name [-format csv|xml] file")
+
+// importSanctions imports a sanctions list file in the database of dbURL, replacing the list of the same name. The
+// format of the file is given by its extension unless it is set:
+//
+//	xbankapi import-sanctions -list eu [-format csv|xml] file
+func importSanctions(ctx context.Context, dbURL string, args []string) error {
+	flags := flag.NewFlagSet(importSanctionsCommand, flag.ContinueOnError)
+	list := flags.String("list", "", "the name of the sanctions list")
+	format := flags.String("format", "", "the format of the file, csv or xml, by default its extension")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errImportSanctionsUsage, err)
+	}
+
+	if *list == "" || flags.NArg() != 1 {
+		return errImportSanctionsUsage
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open sanctions list: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := sanctions.Parse(f, *format)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	pool, err := newPool(ctx, dbURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	ctx = audit.ContextWithActor(ctx, audit.Actor{Principal: audit.PrincipalSystem})
+	importer := sanctions.NewImporter(pool, audit.New(storage.New(pool), slog.Default()))
+
+	if err := importer.Import(ctx, *list, entries); err != nil {
+		return fmt.Errorf("failed to import sanctions list: %w", err)
+	}
+
+	slog.InfoContext(ctx, "imported sanctions list", "list", *list, "entries", len(entries))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Package accountview maps the accounts stored to the accounts returned by the services, with their balances and
+// pockets, so that an account is returned the same way whichever service returns it.
+package accountview
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Account returns an account.
+func Account(a storage.Account) types.Account {
+	res := types.Account{
+		ID:              a.AccountID,
+		Name:            a.Name,
+		Email:           a.Email,
+		CurrencyCode:    a.CurrencyCode,
+		IBAN:            a.IBAN.String,
+		ProductCode:     a.ProductCode,
+		ScreeningStatus: a.ScreeningStatus,
+	}
+
+	if a.ParentAccountID.Valid {
+		res.ParentAccountID = &a.ParentAccountID.UUID
+	}
+
+	return res
+}
+
+// Response returns an account with its balance, and the balance available including its overdraft.
+func Response(a storage.Account, balance pgtype.Numeric) types.GetAccountResponse {
+	amount := storage.AmountFromNumeric(balance, a.CurrencyCode)
+	overdraftLimit := storage.AmountFromNumeric(a.OverdraftLimit, a.CurrencyCode)
+
+	res := types.GetAccountResponse{
+		Account:          Account(a),
+		Balance:          amount,
+		AvailableBalance: amount + overdraftLimit,
+		OverdraftLimit:   overdraftLimit,
+	}
+
+	if a.ApprovalThreshold.Valid {
+		threshold := storage.AmountFromNumeric(a.ApprovalThreshold, a.CurrencyCode)
+		res.ApprovalThreshold = &threshold
+	}
+
+	return res
+}
+
+// WithPockets returns an account with its pockets, if any, and its total balance including theirs.
+func WithPockets(res types.GetAccountResponse, pockets []types.Pocket) types.GetAccountResponse {
+	if len(pockets) == 0 {
+		return res
+	}
+
+	total := res.Balance
+	for _, p := range pockets {
+		total += p.Balance
+	}
+
+	res.Pockets = pockets
+	res.TotalBalance = &total
+
+	return res
+}
+
+// Pocket returns a pocket with its balance.
+func Pocket(a storage.Account, balance pgtype.Numeric) types.Pocket {
+	p := types.Pocket{
+		ID:              a.AccountID,
+		ParentAccountID: a.ParentAccountID.UUID,
+		Name:            a.Name,
+		CurrencyCode:    a.CurrencyCode,
+		Balance:         storage.AmountFromNumeric(balance, a.CurrencyCode),
+		GoalAmount:      storage.AmountFromNumeric(a.GoalAmount, a.CurrencyCode),
+	}
+
+	if a.GoalDate.Valid {
+		p.GoalDate = a.GoalDate.Time.Format(time.DateOnly)
+	}
+
+	return p
+}
@@ -0,0 +1,79 @@
+package accountview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantPocketID  = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+)
+
+func TestResponse(t *testing.T) {
+	t.Parallel()
+
+	threshold := money.Amount(50000)
+
+	got := Response(storage.Account{
+		AccountID:         wantAccountID,
+		Name:              "John Doe",
+		CurrencyCode:      "EUR",
+		IBAN:              pgtype.Text{String: "DE89370400440532013000", Valid: true},
+		OverdraftLimit:    storage.NumericFromAmount(5000, "EUR"),
+		ApprovalThreshold: storage.NumericFromAmount(threshold, "EUR"),
+	}, storage.NumericFromAmount(-1000, "EUR"))
+
+	assert.Equal(t, types.GetAccountResponse{
+		Account: types.Account{
+			ID:           wantAccountID,
+			Name:         "John Doe",
+			CurrencyCode: "EUR",
+			IBAN:         "DE89370400440532013000",
+		},
+		Balance:           -1000,
+		AvailableBalance:  4000,
+		OverdraftLimit:    5000,
+		ApprovalThreshold: &threshold,
+	}, got)
+}
+
+func TestWithPockets(t *testing.T) {
+	t.Parallel()
+
+	pocket := Pocket(storage.Account{
+		AccountID:       wantPocketID,
+		Name:            "Holidays",
+		CurrencyCode:    "EUR",
+		ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+		GoalAmount:      storage.NumericFromAmount(200000, "EUR"),
+		GoalDate:        pgtype.Date{Time: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}, storage.NumericFromAmount(2500, "EUR"))
+
+	assert.Equal(t, types.Pocket{
+		ID:              wantPocketID,
+		ParentAccountID: wantAccountID,
+		Name:            "Holidays",
+		CurrencyCode:    "EUR",
+		Balance:         2500,
+		GoalAmount:      200000,
+		GoalDate:        "2025-07-01",
+	}, pocket)
+
+	account := types.GetAccountResponse{Balance: -1000}
+	assert.Equal(t, account, WithPockets(account, nil))
+
+	total := money.Amount(1500)
+	assert.Equal(t, types.GetAccountResponse{
+		Balance:      -1000,
+		Pockets:      []types.Pocket{pocket},
+		TotalBalance: &total,
+	}, WithPockets(account, []types.Pocket{pocket}))
+}
@@ -0,0 +1,160 @@
+// Package activity notifies subscribers of the activity of accounts, as notified by Postgres on the
+// account_activity channel whenever a transaction is committed, so that every replica is notified.
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+)
+
+// Channel is the Postgres channel on which the activity of accounts is notified, the payload being the account ID.
+const Channel = "account_activity"
+
+const defaultRetryInterval = time.Second
+
+// Conn is a database connection dedicated to listening to notifications.
+type Conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// Hub listens to the activity of accounts and wakes up their subscribers.
+type Hub struct {
+	connect       func(ctx context.Context) (Conn, error)
+	logger        logger.Logger
+	retryInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+}
+
+// NewHub returns a new Hub listening on a connection of its own, made with config.
+func NewHub(config *pgx.ConnConfig, logger logger.Logger) *Hub {
+	return &Hub{
+		connect: func(ctx context.Context) (Conn, error) {
+			conn, err := pgx.ConnectConfig(ctx, config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect: %w", err)
+			}
+
+			return conn, nil
+		},
+		logger:        logger,
+		retryInterval: defaultRetryInterval,
+		subscribers:   make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving a value when the account has activity, until unsubscribed. Activity
+// happening while the last value is not received yet is coalesced into it, so subscribers catch up with the
+// transaction history rather than count values.
+func (h *Hub) Subscribe(accountID uuid.UUID) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[accountID] == nil {
+		h.subscribers[accountID] = make(map[chan struct{}]struct{})
+	}
+
+	h.subscribers[accountID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subscribers[accountID], ch)
+
+		if len(h.subscribers[accountID]) == 0 {
+			delete(h.subscribers, accountID)
+		}
+	}
+}
+
+// Run listens to the activity of accounts until ctx is done, reconnecting after a failure. Every subscriber is woken
+// up once listening, as activity may have been missed in between.
+func (h *Hub) Run(ctx context.Context) error {
+	for {
+		if err := h.listen(ctx); err != nil && ctx.Err() == nil {
+			h.logger.ErrorContext(ctx, "failed to listen to account activity", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(h.retryInterval):
+		}
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := h.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := conn.Close(context.WithoutCancel(ctx)); err != nil {
+			h.logger.ErrorContext(ctx, "failed to close connection", "error", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	h.notifyAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		accountID, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			h.logger.WarnContext(ctx, "invalid account activity notification", "payload", notification.Payload)
+
+			continue
+		}
+
+		h.notify(accountID)
+	}
+}
+
+func (h *Hub) notify(accountID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[accountID] {
+		wake(ch)
+	}
+}
+
+func (h *Hub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			wake(ch)
+		}
+	}
+}
+
+// wake sends a value to ch unless one is pending already.
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
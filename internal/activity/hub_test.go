@@ -0,0 +1,171 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	wantAccountID  = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	otherAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	errAnything    = errors.New("any")
+)
+
+// fakeConn receives the payloads of notifications from a channel.
+type fakeConn struct {
+	listened      chan string
+	notifications chan string
+	closed        chan struct{}
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		listened:      make(chan string, 1),
+		notifications: make(chan string),
+		closed:        make(chan struct{}),
+	}
+}
+
+func (c *fakeConn) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	c.listened <- sql
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (c *fakeConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case payload, ok := <-c.notifications:
+		if !ok {
+			return nil, errAnything
+		}
+
+		return &pgconn.Notification{Channel: Channel, Payload: payload}, nil
+	}
+}
+
+func (c *fakeConn) Close(context.Context) error {
+	close(c.closed)
+
+	return nil
+}
+
+func newTestHub(conns ...*fakeConn) *Hub {
+	h := NewHub(nil, slog.Default())
+	h.retryInterval = time.Millisecond
+	h.connect = func(context.Context) (Conn, error) {
+		if len(conns) == 0 {
+			return nil, errAnything
+		}
+
+		conn := conns[0]
+		conns = conns[1:]
+
+		return conn, nil
+	}
+
+	return h
+}
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+func TestHub_Run(t *testing.T) {
+	t.Parallel()
+
+	conn := newFakeConn()
+	h := newTestHub(conn)
+
+	activity, unsubscribe := h.Subscribe(wantAccountID)
+	defer unsubscribe()
+
+	other, unsubscribeOther := h.Subscribe(otherAccountID)
+	defer unsubscribeOther()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- h.Run(ctx)
+	}()
+
+	assert.Equal(t, "LISTEN account_activity", <-conn.listened)
+
+	// Subscribers are woken up once listening.
+	assert.True(t, received(activity))
+	assert.True(t, received(other))
+
+	conn.notifications <- "not an account id"
+	conn.notifications <- wantAccountID.String()
+	conn.notifications <- wantAccountID.String()
+	conn.notifications <- otherAccountID.String()
+
+	// The notifications before the one of the other account are handled once it is received.
+	assert.True(t, received(other))
+	assert.True(t, received(activity))
+	assert.False(t, received(activity), "activity is coalesced")
+
+	cancel()
+
+	assert.NoError(t, <-done)
+	<-conn.closed
+}
+
+func TestHub_Run_reconnects(t *testing.T) {
+	t.Parallel()
+
+	first, second := newFakeConn(), newFakeConn()
+	h := newTestHub(first, second)
+
+	activity, unsubscribe := h.Subscribe(wantAccountID)
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = h.Run(ctx)
+	}()
+
+	<-first.listened
+	assert.True(t, received(activity))
+
+	close(first.notifications)
+	<-first.closed
+
+	// Activity may have been missed while reconnecting.
+	<-second.listened
+	assert.True(t, received(activity))
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	h := NewHub(nil, slog.Default())
+
+	activity, unsubscribe := h.Subscribe(wantAccountID)
+	_, unsubscribeOther := h.Subscribe(wantAccountID)
+
+	unsubscribe()
+	assert.Len(t, h.subscribers[wantAccountID], 1)
+
+	h.notify(wantAccountID)
+	assert.False(t, received(activity))
+
+	unsubscribeOther()
+	assert.Empty(t, h.subscribers)
+}
@@ -5,20 +5,33 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/gookit/validate"
-	"github.com/zaidsasa/xbankapi/internal/types"
+	"github.com/zaidsasa/xbankapi/types"
 )
 
 const (
-	createAccountRoute = "POST /accounts"
-	addMoneyRoute      = "POST /accounts/{id}/transactions"
-	transferMoneyRoute = "POST /accounts/{id}/transactions/transfer"
+	createAccountRoute    = "POST /accounts"
+	addMoneyRoute         = "POST /accounts/{id}/transactions"
+	transferMoneyRoute    = "POST /accounts/{id}/transactions/transfer"
+	getAccountRoute       = "GET /accounts/{id}"
+	getAccountByIBANRoute = "GET /accounts"
+	listTransactionsRoute = "GET /accounts/{id}/transactions"
 
 	pathValueID = "id"
+
+	queryLimit  = "limit"
+	queryOffset = "offset"
+	queryIBAN   = "iban"
+
+	defaultListLimit = 50
+	maxListLimit     = 100
 )
 
+var errInvalidPagination = errors.New("limit must be between 1 and 100 and offset must not be negative")
+
 type AccountHandler struct {
 	service AccountService
 }
@@ -32,16 +45,27 @@ func NewAccountHandler(service AccountService) *AccountHandler {
 
 // Register routes.
 func (h *AccountHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc(createAccountRoute, h.createAccount)
-	mux.HandleFunc(addMoneyRoute, h.addMoney)
-	mux.HandleFunc(transferMoneyRoute, h.transferMoney)
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *AccountHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		createAccountRoute:    h.createAccount,
+		addMoneyRoute:         h.addMoney,
+		transferMoneyRoute:    h.transferMoney,
+		getAccountRoute:       h.getAccount,
+		getAccountByIBANRoute: h.getAccountByIBAN,
+		listTransactionsRoute: h.listTransactions,
+	}
 }
 
 func (h *AccountHandler) createAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	req := &types.CreateAccountRequest{}
 
-	if err := h.decode(r, req); err != nil {
+	if err := decode(r, req); err != nil {
 		handleError(w, err, http.StatusBadRequest)
 
 		return
@@ -60,16 +84,14 @@ func (h *AccountHandler) createAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(res); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
-	}
+	encode(w, res)
 }
 
 func (h *AccountHandler) addMoney(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	req := &types.AddMoneyRequest{}
-	if err := h.decode(r, req); err != nil {
+	if err := decode(r, req); err != nil {
 		handleError(w, err, http.StatusBadRequest)
 
 		return
@@ -95,16 +117,14 @@ func (h *AccountHandler) addMoney(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(res); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
-	}
+	encode(w, res)
 }
 
 func (h *AccountHandler) transferMoney(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	req := &types.TransferMoneyRequest{}
-	if err := h.decode(r, req); err != nil {
+	if err := decode(r, req); err != nil {
 		handleError(w, err, http.StatusBadRequest)
 
 		return
@@ -130,12 +150,94 @@ func (h *AccountHandler) transferMoney(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(res); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+	encode(w, res)
+}
+
+func (h *AccountHandler) getAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.GetAccount(ctx, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// getAccountByIBAN looks up the bank account of the IBAN given in the query.
+func (h *AccountHandler) getAccountByIBAN(w http.ResponseWriter, r *http.Request) {
+	res, err := h.service.GetAccountByIBAN(r.Context(), r.URL.Query().Get(queryIBAN))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *AccountHandler) listTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
 	}
+
+	res, err := h.service.ListTransactions(ctx, accountID, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
 }
 
-func (h *AccountHandler) decode(req *http.Request, obj any) error {
+// pagination parses the limit and offset query parameters.
+func pagination(r *http.Request) (int32, int32, error) {
+	limit, offset := int64(defaultListLimit), int64(0)
+
+	var err error
+
+	if v := r.URL.Query().Get(queryLimit); v != "" {
+		if limit, err = strconv.ParseInt(v, 10, 32); err != nil {
+			return 0, 0, errInvalidPagination
+		}
+	}
+
+	if v := r.URL.Query().Get(queryOffset); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 32); err != nil {
+			return 0, 0, errInvalidPagination
+		}
+	}
+
+	if limit < 1 || limit > maxListLimit || offset < 0 {
+		return 0, 0, errInvalidPagination
+	}
+
+	return int32(limit), int32(offset), nil //nolint:gosec // both are parsed as 32-bit integers.
+}
+
+func decode(req *http.Request, obj any) error {
 	decoder := json.NewDecoder(req.Body)
 	decoder.DisallowUnknownFields()
 
@@ -147,8 +249,17 @@ func (h *AccountHandler) decode(req *http.Request, obj any) error {
 	return nil
 }
 
-type jsonError struct {
-	Messsage string `json:"message"`
+func encode(w http.ResponseWriter, obj any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		handleError(w, err, http.StatusInternalServerError)
+	}
+}
+
+// WriteError writes an error response the way the api does, for the middlewares in front of it.
+func WriteError(w http.ResponseWriter, err error, code int) {
+	handleError(w, err, code)
 }
 
 // TODO: Create a proper error types containing cause and statusCode.
@@ -164,18 +275,21 @@ func handleError(w http.ResponseWriter, err error, code int) {
 		return
 	}
 
-	var jsonErr jsonError
+	var jsonErr types.Error
 
 	if errors.Is(err, ErrInternal) {
 		code = http.StatusInternalServerError
 
-		jsonErr = jsonError{
-			Messsage: "internal server error",
+		jsonErr = types.Error{
+			Message: "internal server error",
+			Code:    types.ErrorCodeInternal,
 		}
 	} else {
-		jsonErr = jsonError{
-			Messsage: err.Error(),
-		}
+		jsonErr = toError(err)
+	}
+
+	if errors.Is(err, types.ErrNotPermitted) {
+		code = http.StatusForbidden
 	}
 
 	w.WriteHeader(code)
@@ -185,3 +299,26 @@ func handleError(w http.ResponseWriter, err error, code int) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// toError returns the error reported to the client, with the details of the limit exceeded or of the transfer held.
+func toError(err error) types.Error {
+	jsonErr := types.Error{
+		Message: err.Error(),
+		Code:    types.ErrorCode(err),
+	}
+
+	if limitErr := (&types.LimitExceededError{}); errors.As(err, &limitErr) {
+		jsonErr.Limit = limitErr.Limit
+		jsonErr.Remaining = &limitErr.Remaining
+	}
+
+	if pendingErr := (&types.PendingReviewError{}); errors.As(err, &pendingErr) {
+		jsonErr.PendingTransferID = &pendingErr.PendingTransferID
+	}
+
+	if approvalErr := (&types.PendingApprovalError{}); errors.As(err, &approvalErr) {
+		jsonErr.TransferApprovalID = &approvalErr.TransferApprovalID
+	}
+
+	return jsonErr
+}
@@ -7,13 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/zaidsasa/xbankapi/internal/api/mocks"
-	"github.com/zaidsasa/xbankapi/internal/types"
-	"github.com/zaidsasa/xbankapi/internal/validator"
+	"github.com/zaidsasa/xbankapi/types"
 )
 
 func TestNewAccountHandler(t *testing.T) {
@@ -59,7 +59,7 @@ func TestAccountHandler_createAccount(t *testing.T) {
 				},
 			},
 			wantStatusCode: http.StatusBadRequest,
-			want:           `{"currencyCode":{"eq":"only EUR currency code is currently supported"}}`,
+			want:           `{"currencyCode":{"currency_code":"currencyCode must be a currency code"}}`,
 		},
 		{
 			name: "failed when name is invalid",
@@ -88,7 +88,7 @@ func TestAccountHandler_createAccount(t *testing.T) {
 					Return(types.CreateAccountResponse{}, ErrInternal).Once()
 			},
 			wantStatusCode: http.StatusInternalServerError,
-			want: `{"message":"internal server error"}
+			want: `{"message":"internal server error","code":"INTERNAL"}
 `,
 		},
 		{
@@ -153,8 +153,6 @@ func TestAccountHandler_createAccount(t *testing.T) {
 }
 
 func TestAccountHandler_addMoney(t *testing.T) {
-	validator.ConfigureDefaultValidator()
-
 	t.Parallel()
 
 	type args struct {
@@ -236,8 +234,6 @@ func TestAccountHandler_addMoney(t *testing.T) {
 }
 
 func TestAccountHandler_transferMoney(t *testing.T) {
-	validator.ConfigureDefaultValidator()
-
 	t.Parallel()
 
 	type args struct {
@@ -263,6 +259,18 @@ func TestAccountHandler_transferMoney(t *testing.T) {
 			wantStatusCode: http.StatusBadRequest,
 			want:           `{"amount":{"money_amount":"amount field did not pass validation"}}`,
 		},
+		{
+			name: "failed when receiver iban is invalid",
+			args: args{
+				accountID: wantAccountID,
+				body: types.TransferMoneyRequest{
+					ReciverIBAN: "DE88370400440532013000",
+					Amount:      100,
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"reciverIban":{"iban":"reciverIban must be a valid iban"}}`,
+		},
 		{
 			name: "success when money is transferred",
 			args: args{
@@ -318,3 +326,384 @@ func TestAccountHandler_transferMoney(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountHandler_getAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		accountID      string
+		mock           func(*mocks.MockAccountService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when account id is invalid",
+			accountID:      "invalid",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 7"}
+`,
+		},
+		{
+			name:      "failed when account not found",
+			accountID: wantAccountID.String(),
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(types.GetAccountResponse{}, ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "success when account exists",
+			accountID: wantAccountID.String(),
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account: types.Account{
+						ID:           wantAccountID,
+						Name:         "name",
+						Email:        "test@mail.com",
+						CurrencyCode: "EUR",
+					},
+					Balance:          1050,
+					AvailableBalance: 1050,
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"12345678-1234-1234-1234-123456789001","name":"name","email":"test@mail.com",` +
+				`"currencyCode":"EUR","balance":1050,"availableBalance":1050}
+`,
+		},
+	}
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts/:id", nil)
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			w := httptest.NewRecorder()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			accountHandler := NewAccountHandler(accountServiceMock)
+			accountHandler.getAccount(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestAccountHandler_getAccountByIBAN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		iban           string
+		mock           func(*mocks.MockAccountService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name: "failed when iban is invalid",
+			iban: "DE88370400440532013000",
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccountByIBAN(mock.Anything, "DE88370400440532013000").
+					Return(types.GetAccountResponse{}, types.ErrInvalidIBAN).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid iban","code":"INVALID_IBAN"}
+`,
+		},
+		{
+			name: "success when account exists",
+			iban: "DE89370400440532013000",
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccountByIBAN(mock.Anything, "DE89370400440532013000").Return(types.GetAccountResponse{
+					Account: types.Account{
+						ID:           wantAccountID,
+						Name:         "name",
+						Email:        "test@mail.com",
+						CurrencyCode: "EUR",
+						IBAN:         "DE89370400440532013000",
+					},
+					Balance:          1050,
+					AvailableBalance: 1050,
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"12345678-1234-1234-1234-123456789001","name":"name","email":"test@mail.com",` +
+				`"currencyCode":"EUR","iban":"DE89370400440532013000","balance":1050,"availableBalance":1050}
+`,
+		},
+	}
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts?iban="+tt.iban, nil)
+
+			w := httptest.NewRecorder()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			tt.mock(accountServiceMock)
+
+			accountHandler := NewAccountHandler(accountServiceMock)
+			accountHandler.getAccountByIBAN(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestAccountHandler_listTransactions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		query          string
+		mock           func(*mocks.MockAccountService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when limit is too large",
+			query:          "?limit=101",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit must be between 1 and 100 and offset must not be negative"}
+`,
+		},
+		{
+			name:           "failed when offset is invalid",
+			query:          "?offset=one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit must be between 1 and 100 and offset must not be negative"}
+`,
+		},
+		{
+			name: "success with default pagination",
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(50), int32(0)).
+					Return(types.ListTransactionsResponse{Transactions: []types.Transaction{}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"transactions":[]}
+`,
+		},
+		{
+			name:  "success with pagination",
+			query: "?limit=1&offset=1",
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(1), int32(1)).
+					Return(types.ListTransactionsResponse{Transactions: []types.Transaction{{
+						ID:        wantReciverTransactionID,
+						AccountID: wantAccountID,
+						Amount:    100,
+						SourceID:  uuid.NullUUID{UUID: wantTrnasactionID, Valid: true},
+						CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					}}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"transactions":[{"id":"12345678-1234-1234-1234-123456789004",` +
+				`"accountId":"12345678-1234-1234-1234-123456789001","amount":100,` +
+				`"sourceId":"12345678-1234-1234-1234-123456789002","createdAt":"2024-05-01T10:00:00Z"}]}
+`,
+		},
+	}
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts/:id/transactions"+tt.query, nil)
+			r.SetPathValue(pathValueID, wantAccountID.String())
+
+			w := httptest.NewRecorder()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			accountHandler := NewAccountHandler(accountServiceMock)
+			accountHandler.listTransactions(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func accountContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "create account",
+			method:         http.MethodPost,
+			path:           "/accounts",
+			body:           `{"name":"name","email":"test@mail.com","currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).Return(types.CreateAccountResponse{
+					Account: types.Account{ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "create account rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/accounts",
+			body:           `{"name":"name","email":"test@mail.com","currencyCode":"euro"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        accountContract(nil),
+		},
+		{
+			name:           "create account fails",
+			method:         http.MethodPost,
+			path:           "/accounts",
+			body:           `{"name":"name","email":"test@mail.com","currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusInternalServerError,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+					Return(types.CreateAccountResponse{}, ErrInternal).Once()
+			}),
+		},
+		{
+			name:           "add money",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions",
+			body:           `{"amount":100}`,
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().AddMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.AddMoneyResponse{TransactionID: wantTrnasactionID}, nil).Once()
+			}),
+		},
+		{
+			name:           "add money rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/accounts/invalid/transactions",
+			body:           `{"amount":100}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        accountContract(nil),
+		},
+		{
+			name:           "transfer money",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions/transfer",
+			body:           `{"reciverAccountId":"` + wantReciverAccountID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.TransferMoneyResponse{TransactionID: wantReciverTransactionID}, nil).Once()
+			}),
+		},
+		{
+			name:           "transfer money fails",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions/transfer",
+			body:           `{"reciverAccountId":"` + wantReciverAccountID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.TransferMoneyResponse{}, ErrInsufficientAccountBalance).Once()
+			}),
+		},
+		{
+			name:           "get account",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String(),
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account:          types.Account{ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"},
+					Balance:          -100,
+					AvailableBalance: 49900,
+					OverdraftLimit:   50000,
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "get account by iban",
+			method:         http.MethodGet,
+			path:           "/accounts?iban=DE89370400440532013000",
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccountByIBAN(mock.Anything, "DE89370400440532013000").Return(types.GetAccountResponse{
+					Account: types.Account{
+						ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR",
+						IBAN: "DE89370400440532013000",
+					},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "get account by iban rejected by the contract",
+			method:         http.MethodGet,
+			path:           "/accounts",
+			wantStatusCode: http.StatusBadRequest,
+			handler:        accountContract(nil),
+		},
+		{
+			name:           "list transactions",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions?limit=10",
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(10), int32(0)).
+					Return(types.ListTransactionsResponse{Transactions: []types.Transaction{{
+						ID:        wantTrnasactionID,
+						AccountID: wantAccountID,
+						Amount:    100,
+						CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					}}}, nil).Once()
+			}),
+		},
+		{
+			name:           "list transactions rejected by the contract",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions?limit=1000",
+			wantStatusCode: http.StatusBadRequest,
+			handler:        accountContract(nil),
+		},
+	}
+}
+
+// accountContract returns the account handler of a contract test, its service mocked with expectations.
+func accountContract(expectations func(*mocks.MockAccountService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewAccountHandler(expect(mocks.NewMockAccountService(t), expectations))
+	}
+}
@@ -1,33 +1,45 @@
 package api
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
-	"sync"
 
 	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/accountview"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/iban"
 	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/product"
+	"github.com/zaidsasa/xbankapi/internal/sanctions"
 	"github.com/zaidsasa/xbankapi/internal/storage"
-	"github.com/zaidsasa/xbankapi/internal/types"
+	"github.com/zaidsasa/xbankapi/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	pqErrorForeignKeyViolation = "23503"
-	pqErrorAlreadyExist        = "23505"
+	pqErrorAlreadyExist = "23505"
+	customerEmailKey    = "customer_email_key"
+
+	tracerName = "github.com/zaidsasa/xbankapi/internal/api"
 )
 
 var (
-	ErrInsufficientAccountBalance = errors.New("insufficient account balance")
-	ErrAccountNotFound            = errors.New("account not found")
-	ErrRecieverAccountNotFound    = errors.New("reciver account not found")
-	ErrInternal                   = errors.New("internal error")
-	ErrAccountAlreadyExist        = errors.New("account already exists")
+	ErrInsufficientAccountBalance = types.ErrInsufficientAccountBalance
+	ErrAccountNotFound            = types.ErrAccountNotFound
+	ErrRecieverAccountNotFound    = types.ErrRecieverAccountNotFound
+	ErrInternal                   = types.ErrInternal
+	ErrAccountAlreadyExist        = types.ErrAccountAlreadyExist
+	ErrTransactionNotFound        = types.ErrTransactionNotFound
 )
 
 type AccountService interface {
@@ -35,13 +47,123 @@ type AccountService interface {
 	AddMoney(ctx context.Context, req *types.AddMoneyRequest, accountID uuid.UUID) (types.AddMoneyResponse, error)
 	TransferMoney(
 		ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+	GetAccount(ctx context.Context, accountID uuid.UUID) (types.GetAccountResponse, error)
+	GetAccountByIBAN(ctx context.Context, iban string) (types.GetAccountResponse, error)
+	ListTransactions(
+		ctx context.Context, accountID uuid.UUID, limit, offset int32) (types.ListTransactionsResponse, error)
+	ListTransactionsAfter(
+		ctx context.Context, accountID uuid.UUID, after uuid.NullUUID, limit int32) (types.ListTransactionsResponse, error)
+}
+
+// Metrics records the business events of the account service.
+type Metrics interface {
+	AccountCreated()
+	MoneyAdded()
+	MoneyTransferred(amount money.Amount, currencyCode string)
+	TransferFailed(reason string)
+}
+
+// Auditor records state-changing operations in the audit log.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Beneficiaries resolves the beneficiaries of accounts money is transferred to.
+type Beneficiaries interface {
+	Resolve(ctx context.Context, accountID, beneficiaryID uuid.UUID, amount money.Amount) (uuid.UUID, error)
+}
+
+// Limits enforces the limits of the transfers from accounts.
+type Limits interface {
+	Check(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount money.Amount) error
+}
+
+// Risk screens transfers, failing with types.ErrTransferDenied or a types.PendingReviewError when they are denied
+// or held for review. The pending transfer is saved within tx, which is committed when the transfer is held.
+type Risk interface {
+	Screen(ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount money.Amount) error
+}
+
+// Sanctions screens the names of accounts against the sanctions lists, saving the result of the screening of the
+// accounts created within tx.
+type Sanctions interface {
+	Screen(ctx context.Context, name string) (sanctions.Result, error)
+	Record(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, name string, result sanctions.Result) error
+}
+
+// Fees prices the transfers from accounts by the fee schedule of their product, and charges the fees within tx as
+// transactions from the account to the fee income account of its currency, linked to the transaction of the transfer.
+type Fees interface {
+	TransferFee(
+		ctx context.Context, accountID uuid.UUID, productCode, currencyCode string, amount money.Amount,
+	) (money.Amount, error)
+	Charge(
+		ctx context.Context, tx pgx.Tx, accountID uuid.UUID, currencyCode string, transactionID uuid.UUID, fee money.Amount,
+	) error
+}
+
+// Products checks that the product accounts are opened for allows their currency.
+type Products interface {
+	CheckCurrency(ctx context.Context, productCode, currencyCode string) error
+}
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted, checks the KYC of
+// their customers, failing with types.ErrKYCRejected, and holds the transfers above the threshold of the mandate of
+// their account, failing with a types.PendingApprovalError. The transfer approval is saved within tx, which is
+// committed when the transfer is held.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+	CheckKYC(ctx context.Context, customerID uuid.UUID) error
+	Hold(
+		ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount money.Amount) error
+}
+
+// Pockets returns the pockets of accounts with their balances.
+type Pockets interface {
+	Pockets(ctx context.Context, accountID uuid.UUID) ([]types.Pocket, error)
+}
+
+// Outbox raises domain events, which are published once the transaction they are raised in is committed.
+type Outbox interface {
+	Add(ctx context.Context, tx pgx.Tx, event outbox.Event) error
+}
+
+// Payments links the transfers executing the payments of payment files to their payments within tx, whether they are
+// made or held, failing with paymentfile.ErrPaymentExecuted when the payment was executed already. Transfers executing
+// no payment are not linked.
+type Payments interface {
+	LinkTransfer(ctx context.Context, tx pgx.Tx, transactionID uuid.UUID) error
+	LinkHold(ctx context.Context, tx pgx.Tx, held error) error
 }
 
 type ImplAccountService struct {
-	logger logger.Logger
-	xlock  map[uuid.UUID]sync.Locker
-	conn   storage.DBConnection
-	store  storage.AccountStore
+	logger        logger.Logger
+	conn          storage.DBConnection
+	store         storage.AccountStore
+	storeWithTx   func(tx pgx.Tx) storage.AccountStore
+	metrics       Metrics
+	auditor       Auditor
+	outbox        Outbox
+	ibans         *iban.Generator
+	beneficiaries Beneficiaries
+	limits        Limits
+	risk          Risk
+	sanctions     Sanctions
+	fees          Fees
+	products      Products
+	holders       Holders
+	pockets       Pockets
+	payments      Payments
+	tracer        trace.Tracer
+}
+
+// balanceSnapshot is the state of an account recorded in the audit log.
+type balanceSnapshot struct {
+	Balance           money.Amount `json:"balance"`
+	TransactionID     *uuid.UUID   `json:"transactionId,omitempty"`
+	ReceiverAccountID *uuid.UUID   `json:"receiverAccountId,omitempty"`
+	Amount            money.Amount `json:"amount,omitempty"`
+	Fee               money.Amount `json:"fee,omitempty"`
 }
 
 // NewAccountService returns a new ImplAccountService.
@@ -49,66 +171,259 @@ func NewAccountService(
 	conn storage.DBConnection,
 	store storage.AccountStore,
 	logger logger.Logger,
+	metrics Metrics,
+	auditor Auditor,
+	outbox Outbox,
+	ibans *iban.Generator,
+	beneficiaries Beneficiaries,
+	limits Limits,
+	risk Risk,
+	sanctions Sanctions,
+	fees Fees,
+	products Products,
+	holders Holders,
+	pockets Pockets,
+	payments Payments,
 ) *ImplAccountService {
 	return &ImplAccountService{
-		logger: logger,
-		xlock:  make(map[uuid.UUID]sync.Locker),
-		conn:   conn,
-		store:  store,
+		logger:        logger,
+		conn:          conn,
+		store:         store,
+		storeWithTx:   storage.AccountStoreWithTx,
+		metrics:       metrics,
+		auditor:       auditor,
+		outbox:        outbox,
+		ibans:         ibans,
+		beneficiaries: beneficiaries,
+		limits:        limits,
+		risk:          risk,
+		sanctions:     sanctions,
+		fees:          fees,
+		products:      products,
+		holders:       holders,
+		pockets:       pockets,
+		payments:      payments,
+		tracer:        otel.Tracer(tracerName),
 	}
 }
 
-// CreateAccount creates a bank account.
+// CreateAccount creates a bank account for a new customer of its name and email, failing with
+// types.ErrCustomerAlreadyExist when a customer has the email already, whose accounts are opened for the customer.
+// The account is opened as by CreateCustomerAccount.
 // returns CreateAccountResponse.
 func (a *ImplAccountService) CreateAccount(
 	ctx context.Context,
 	req *types.CreateAccountRequest,
 ) (types.CreateAccountResponse, error) {
-	account, err := a.store.CreateAccount(ctx, storage.CreateAccountParams{
-		Email:        req.Email,
-		Name:         req.Name,
-		CurrencyCode: req.CurrencyCode,
-	})
-	if err != nil {
-		pgErr := &pgconn.PgError{}
-		if errors.As(err, &pgErr); pgErr.Code == pqErrorAlreadyExist {
-			return types.CreateAccountResponse{}, ErrAccountAlreadyExist
+	ctx, span := a.tracer.Start(ctx, "AccountService.CreateAccount")
+	defer span.End()
+
+	return a.createAccount(ctx, uuid.Nil, req)
+}
+
+// CreateCustomerAccount creates a bank account for a customer, with the name and email of req, for a product, current
+// by default, which must allow its currency, assigning it the next account number and its IBAN. Names matching a
+// sanctions entry exactly are refused, and accounts whose name nearly matches one are created under review.
+// returns CreateAccountResponse.
+func (a *ImplAccountService) CreateCustomerAccount(
+	ctx context.Context,
+	customerID uuid.UUID,
+	req *types.CreateAccountRequest,
+) (types.CreateAccountResponse, error) {
+	ctx, span := a.tracer.Start(ctx, "AccountService.CreateCustomerAccount")
+	defer span.End()
+
+	return a.createAccount(ctx, customerID, req)
+}
+
+// createAccount creates a bank account for a customer, or for a new customer when customerID is uuid.Nil.
+func (a *ImplAccountService) createAccount(
+	ctx context.Context,
+	customerID uuid.UUID,
+	req *types.CreateAccountRequest,
+) (types.CreateAccountResponse, error) {
+	var account storage.Account
+
+	productCode := cmp.Or(req.ProductCode, product.DefaultCode)
+
+	err := storage.InTx(ctx, a.conn, a.storeWithTx, a.logger, func(tx pgx.Tx, store storage.AccountStore) error {
+		if err := a.products.CheckCurrency(ctx, productCode, req.CurrencyCode); err != nil {
+			return err
+		}
+
+		screening, err := a.sanctions.Screen(ctx, req.Name)
+		if err != nil {
+			return err
+		}
+
+		if screening.Status == types.ScreeningStatusBlocked {
+			a.logger.WarnContext(ctx, "account name matches a sanctions entry", "matches", len(screening.Matches))
+
+			return types.ErrSanctionsMatch
 		}
 
-		a.logger.Error("failed to create account", "error", err)
+		accountNumber, err := store.NextAccountNumber(ctx)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to get next account number", "error", err)
 
-		return types.CreateAccountResponse{}, ErrInternal
+			return ErrInternal
+		}
+
+		account, err = store.CreateAccount(ctx, storage.CreateAccountParams{
+			Email:         req.Email,
+			Name:          req.Name,
+			CurrencyCode:  req.CurrencyCode,
+			AccountNumber: accountNumber,
+			IBAN:          pgtype.Text{String: a.ibans.Generate(accountNumber), Valid: true},
+			ProductCode:   productCode,
+			CustomerID:    uuid.NullUUID{UUID: customerID, Valid: customerID != uuid.Nil},
+		})
+		if err != nil {
+			return a.createAccountError(ctx, err)
+		}
+
+		if err := a.sanctions.Record(ctx, tx, account.AccountID, account.Name, screening); err != nil {
+			return err
+		}
+
+		account.ScreeningStatus = screening.Status
+
+		if err := a.record(ctx, tx, audit.Event{
+			Action:    audit.ActionCreateAccount,
+			AccountID: uuid.NullUUID{UUID: account.AccountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			After:     accountview.Account(account),
+		}); err != nil {
+			return err
+		}
+
+		return a.raise(ctx, tx, outbox.Event{
+			Type:      outbox.EventAccountCreated,
+			AccountID: account.AccountID,
+			Payload:   outbox.AccountCreated{Account: accountview.Account(account)},
+		})
+	})
+	if err != nil {
+		a.recordFailure(ctx, audit.Event{Action: audit.ActionCreateAccount}, err)
+
+		return types.CreateAccountResponse{}, err
 	}
 
+	a.metrics.AccountCreated()
+
 	return types.CreateAccountResponse{
 		Account: types.Account{
-			ID:           account.AccountID,
-			Name:         account.Name,
-			Email:        account.Email,
-			CurrencyCode: req.CurrencyCode,
+			ID:              account.AccountID,
+			Name:            account.Name,
+			Email:           account.Email,
+			CurrencyCode:    req.CurrencyCode,
+			IBAN:            account.IBAN.String,
+			ProductCode:     account.ProductCode,
+			ScreeningStatus: account.ScreeningStatus,
 		},
 	}, nil
 }
 
-// AddMoney add money to bank account.
+// createAccountError returns the error reported when an account cannot be created: types.ErrCustomerAlreadyExist
+// when its new customer has the email of another one, and ErrAccountAlreadyExist when it has the number or IBAN of
+// another account.
+func (a *ImplAccountService) createAccountError(ctx context.Context, err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pqErrorAlreadyExist {
+		if pgErr.ConstraintName == customerEmailKey {
+			return types.ErrCustomerAlreadyExist
+		}
+
+		return ErrAccountAlreadyExist
+	}
+
+	a.logger.ErrorContext(ctx, "failed to create account", "error", err)
+
+	return ErrInternal
+}
+
+// AddMoney add money to bank account, for the holders permitted to transfer from it.
 // returns AddMoneyResponse.
 func (a *ImplAccountService) AddMoney(
 	ctx context.Context,
 	req *types.AddMoneyRequest,
 	accountID uuid.UUID,
 ) (types.AddMoneyResponse, error) {
-	if err := a.hasAccount(ctx, accountID); err != nil {
+	ctx, span := a.startSpan(ctx, "AddMoney", accountID)
+	defer span.End()
+
+	event := audit.Event{
+		Action:    audit.ActionAddMoney,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+	}
+
+	res, err := a.addMoney(ctx, req, event)
+	if err != nil {
+		a.recordFailure(ctx, event, err)
+
+		return res, err
+	}
+
+	a.metrics.MoneyAdded()
+
+	return res, nil
+}
+
+func (a *ImplAccountService) addMoney(
+	ctx context.Context,
+	req *types.AddMoneyRequest,
+	event audit.Event,
+) (types.AddMoneyResponse, error) {
+	accountID := event.AccountID.UUID
+
+	account, err := a.fetchAuthorizedAccount(ctx, accountID, holder.PermissionTransfer)
+	if err != nil {
 		return types.AddMoneyResponse{}, err
 	}
 
-	t, err := a.store.AddTransaction(ctx, storage.AddTransactionParams{
-		AccountID: accountID,
-		Amount:    pgtype.Numeric{Int: big.NewInt(req.Amount), Exp: -2, Valid: true},
+	if account.ParentAccountID.Valid {
+		return types.AddMoneyResponse{}, types.ErrPocketTransfer
+	}
+
+	var t storage.Transaction
+
+	err = storage.InTx(ctx, a.conn, a.storeWithTx, a.logger, func(tx pgx.Tx, store storage.AccountStore) error {
+		totalAmount, err := store.GetAccountTotalAmount(ctx, accountID)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to get account total amount", "error", err)
+
+			return ErrInternal
+		}
+
+		t, err = store.AddTransaction(ctx, storage.AddTransactionParams{
+			AccountID: accountID,
+			Amount:    storage.NumericFromAmount(req.Amount, account.CurrencyCode),
+			Type:      types.TransactionTypeDeposit,
+		})
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to add money", "error", err)
+
+			return ErrInternal
+		}
+
+		balance := storage.AmountFromNumeric(totalAmount, account.CurrencyCode)
+
+		event.Outcome = audit.OutcomeSuccess
+		event.Before = balanceSnapshot{Balance: balance}
+		event.After = balanceSnapshot{Balance: balance + req.Amount, TransactionID: &t.TransactionID}
+
+		if err := a.record(ctx, tx, event); err != nil {
+			return err
+		}
+
+		return a.raise(ctx, tx, outbox.Event{
+			Type:      outbox.EventMoneyAdded,
+			AccountID: accountID,
+			Payload:   outbox.MoneyAdded{TransactionID: t.TransactionID, Amount: req.Amount},
+		})
 	})
 	if err != nil {
-		a.logger.Error("failed to add money", "error", err)
-
-		return types.AddMoneyResponse{}, ErrInternal
+		return types.AddMoneyResponse{}, err
 	}
 
 	return types.AddMoneyResponse{
@@ -116,97 +431,606 @@ func (a *ImplAccountService) AddMoney(
 	}, nil
 }
 
-// TransferMoney transfers money from a bank account to another.
+// TransferMoney transfers money from a bank account to another, the receiver being given by its ID, its IBAN or a
+// beneficiary of the account. The fee of the transfer, if any, is charged on top of its amount.
 // returns TransferMoneyResponse.
 func (a *ImplAccountService) TransferMoney(
 	ctx context.Context,
 	req *types.TransferMoneyRequest,
 	accountID uuid.UUID,
 ) (types.TransferMoneyResponse, error) {
+	ctx, span := a.startSpan(ctx, "TransferMoney", accountID,
+		attribute.String("receiver_account.id", req.ReciverAccountID.String()))
+	defer span.End()
+
+	res, currencyCode, err := a.transferMoney(ctx, req, accountID)
+	if err != nil {
+		reason := types.ErrorCode(err)
+		if reason == "" {
+			reason = types.ErrorCodeInternal
+		}
+
+		a.metrics.TransferFailed(reason)
+		span.SetAttributes(attribute.String("error.code", reason))
+
+		a.recordFailure(ctx, audit.Event{
+			Action:    audit.ActionTransferMoney,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			After:     balanceSnapshot{ReceiverAccountID: &req.ReciverAccountID, Amount: req.Amount},
+		}, err)
+
+		return res, err
+	}
+
+	a.metrics.MoneyTransferred(req.Amount, currencyCode)
+
+	return res, nil
+}
+
+// fetchAccount fetches an account, failing with ErrAccountNotFound when it does not exist.
+func (a *ImplAccountService) fetchAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
 	account, err := a.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return types.TransferMoneyResponse{}, ErrAccountNotFound
+			return storage.Account{}, ErrAccountNotFound
 		}
 
-		a.logger.Error("failed to fetch account", "error", err)
+		a.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
 
-		return types.TransferMoneyResponse{}, ErrInternal
+		return storage.Account{}, ErrInternal
 	}
 
-	a.lock(accountID)
-	defer a.unlock(accountID)
+	return account, nil
+}
 
-	totalAmount, err := a.store.GetAccountTotalAmount(ctx, accountID)
+// fetchAuthorizedAccount fetches an account, failing with types.ErrNotPermitted when the holder making the request is
+// not permitted to.
+func (a *ImplAccountService) fetchAuthorizedAccount(
+	ctx context.Context,
+	accountID uuid.UUID,
+	permission holder.Permission,
+) (storage.Account, error) {
+	if err := a.holders.Authorize(ctx, accountID, permission); err != nil {
+		return storage.Account{}, err
+	}
+
+	return a.fetchAccount(ctx, accountID)
+}
+
+// transferMoney transfers money, returning the currency of the transfer as well.
+func (a *ImplAccountService) transferMoney(
+	ctx context.Context,
+	req *types.TransferMoneyRequest,
+	accountID uuid.UUID,
+) (types.TransferMoneyResponse, string, error) {
+	account, err := a.fetchAuthorizedAccount(ctx, accountID, holder.PermissionTransfer)
+	if err != nil {
+		return types.TransferMoneyResponse{}, "", err
+	}
+
+	if err := a.checkTransfer(ctx, req, account); err != nil {
+		return types.TransferMoneyResponse{}, "", err
+	}
+
+	fee, err := a.fees.TransferFee(ctx, accountID, account.ProductCode, account.CurrencyCode, req.Amount)
+	if err != nil {
+		return types.TransferMoneyResponse{}, "", err
+	}
+
+	var (
+		reciverTransaction storage.Transaction
+		held               error
+	)
+
+	err = storage.InTx(ctx, a.conn, a.storeWithTx, a.logger, func(tx pgx.Tx, store storage.AccountStore) error {
+		totalAmount, err := a.checkBalance(ctx, store, account, req.ReciverAccountID, req.Amount+fee)
+		if err != nil {
+			return err
+		}
+
+		if err := a.limits.Check(ctx, tx, accountID, req.Amount); err != nil {
+			return err
+		}
+
+		// A transfer held for approval or review is not made, but the transfer approval or the pending transfer is
+		// committed.
+		if err := a.hold(ctx, tx, account, req); err != nil {
+			if isHeld(err) {
+				held = err
+
+				return nil
+			}
+
+			return err
+		}
+
+		reciverTransaction, err = a.bookTransfer(ctx, tx, store, req, account, totalAmount, fee)
+
+		return err
+	})
+	if err == nil {
+		err = held
+	}
+
+	if err != nil {
+		return types.TransferMoneyResponse{}, "", err
+	}
+
+	return types.TransferMoneyResponse{
+		TransactionID: reciverTransaction.TransactionID,
+		Fee:           fee,
+	}, account.CurrencyCode, nil
+}
+
+// hold holds a transfer within tx for the approval of a second holder of account when it is above the threshold of
+// its mandate, and screens it otherwise. A held transfer is linked to the payment it executes, if any.
+func (a *ImplAccountService) hold(
+	ctx context.Context,
+	tx pgx.Tx,
+	account storage.Account,
+	req *types.TransferMoneyRequest,
+) error {
+	err := a.holders.Hold(ctx, tx, account, req.ReciverAccountID, req.Amount)
+	if err == nil {
+		err = a.risk.Screen(ctx, tx, account, req.ReciverAccountID, req.Amount)
+	}
+
+	if isHeld(err) {
+		if err := a.payments.LinkHold(ctx, tx, err); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// isHeld reports whether err is the one of a transfer held for approval or review, which is not made, but whose
+// transfer approval or pending transfer is committed.
+func isHeld(err error) bool {
+	return errors.Is(err, types.ErrTransferPendingApproval) || errors.Is(err, types.ErrTransferPendingReview)
+}
+
+// bookTransfer adds the transactions of a transfer and of its fee within tx, records it in the audit log, raises
+// its events and links it to the payment it executes, if any.
+// returns the transaction received.
+func (a *ImplAccountService) bookTransfer(
+	ctx context.Context,
+	tx pgx.Tx,
+	store storage.AccountStore,
+	req *types.TransferMoneyRequest,
+	account storage.Account,
+	totalAmount pgtype.Numeric,
+	fee money.Amount,
+) (storage.Transaction, error) {
+	t, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: account.AccountID,
+		Amount:    storage.NumericFromAmount(-req.Amount, account.CurrencyCode),
+		Type:      types.TransactionTypeTransfer,
+	})
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to add transaction", "error", err)
+
+		return storage.Transaction{}, ErrInternal
+	}
+
+	received, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: req.ReciverAccountID,
+		Amount:    storage.NumericFromAmount(req.Amount, account.CurrencyCode),
+		SourceID:  uuid.NullUUID{UUID: t.TransactionID, Valid: true},
+		Type:      types.TransactionTypeTransfer,
+	})
 	if err != nil {
-		a.logger.Error("failed to get account total amount", "error", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.Transaction{}, ErrRecieverAccountNotFound
+		}
+
+		a.logger.ErrorContext(ctx, "failed to add transaction", "error", err)
+
+		return storage.Transaction{}, ErrInternal
+	}
+
+	if fee > 0 {
+		if err := a.fees.Charge(ctx, tx, account.AccountID, account.CurrencyCode, t.TransactionID, fee); err != nil {
+			return storage.Transaction{}, err
+		}
+	}
+
+	balance := storage.AmountFromNumeric(totalAmount, account.CurrencyCode)
 
-		return types.TransferMoneyResponse{}, ErrInternal
+	if err := a.record(ctx, tx, audit.Event{
+		Action:    audit.ActionTransferMoney,
+		AccountID: uuid.NullUUID{UUID: account.AccountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		Before:    balanceSnapshot{Balance: balance},
+		After: balanceSnapshot{
+			Balance:           balance - req.Amount - fee,
+			TransactionID:     &t.TransactionID,
+			ReceiverAccountID: &req.ReciverAccountID,
+			Amount:            req.Amount,
+			Fee:               fee,
+		},
+	}); err != nil {
+		return storage.Transaction{}, err
+	}
+
+	if err := a.raiseTransfer(ctx, tx, req, account, t, received); err != nil {
+		return storage.Transaction{}, err
+	}
+
+	return received, a.payments.LinkTransfer(ctx, tx, received.TransactionID)
+}
+
+// checkBalance locks an account and the receiver of a transfer from it until the end of the transaction of store, so
+// that the transfers and the pocket moves made from it at the same time are checked against its balance one at a time
+// and that the events of both are raised in the order the transfers commit, and returns its balance, failing when its
+// available balance, its overdraft included, is less than the amount to transfer.
+func (a *ImplAccountService) checkBalance(
+	ctx context.Context,
+	store storage.AccountStore,
+	account storage.Account,
+	reciverAccountID uuid.UUID,
+	amount money.Amount,
+) (pgtype.Numeric, error) {
+	if err := store.LockAccounts(ctx, []uuid.UUID{account.AccountID, reciverAccountID}); err != nil {
+		a.logger.ErrorContext(ctx, "failed to lock account", "error", err)
+
+		return pgtype.Numeric{}, ErrInternal
+	}
+
+	totalAmount, err := store.GetAccountTotalAmount(ctx, account.AccountID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get account total amount", "error", err)
+
+		return pgtype.Numeric{}, ErrInternal
 	}
 
 	if err = validateTotalBalanceForMoneyTransfer(
 		totalAmount,
-		req.Amount,
+		storage.AmountFromNumeric(account.OverdraftLimit, account.CurrencyCode),
+		amount,
 		account.CurrencyCode); err != nil {
-		a.logger.Error("failed to calculate expected total balance", "error", err)
+		a.logger.ErrorContext(ctx, "failed to calculate expected total balance", "error", err)
 
-		return types.TransferMoneyResponse{}, err
+		return pgtype.Numeric{}, err
 	}
 
-	tx, err := a.conn.Begin(ctx)
+	return totalAmount, nil
+}
+
+// checkTransfer checks that account may transfer money, and resolves the receiver of the transfer, failing with
+// types.ErrPocketTransfer when either is a pocket and with types.ErrCurrencyMismatch when the receiver is not in the
+// currency of the account.
+func (a *ImplAccountService) checkTransfer(
+	ctx context.Context,
+	req *types.TransferMoneyRequest,
+	account storage.Account,
+) error {
+	if err := a.checkSender(ctx, account); err != nil {
+		return err
+	}
+
+	receiver, err := a.resolveReceiver(ctx, req, account.AccountID)
 	if err != nil {
-		a.logger.Error("failed to begin transaction", "error", err)
+		return err
+	}
 
-		return types.TransferMoneyResponse{}, ErrInternal
+	if receiver.ParentAccountID.Valid {
+		return types.ErrPocketTransfer
 	}
 
-	s := storage.AccountStoreWithTx(tx)
+	if receiver.CurrencyCode != account.CurrencyCode {
+		return types.ErrCurrencyMismatch
+	}
 
-	t, err := s.AddTransaction(ctx, storage.AddTransactionParams{
-		AccountID: accountID, Amount: pgtype.Numeric{Int: big.NewInt(req.Amount * -1), Exp: -2, Valid: true},
-	})
+	return nil
+}
+
+// checkSender checks that money may be transferred from account, failing with types.ErrPocketTransfer when it is a
+// pocket and with types.ErrKYCRejected when the KYC checks of its customer were rejected.
+func (a *ImplAccountService) checkSender(ctx context.Context, account storage.Account) error {
+	if account.ParentAccountID.Valid {
+		return types.ErrPocketTransfer
+	}
+
+	return a.holders.CheckKYC(ctx, account.CustomerID)
+}
+
+// resolveReceiver returns the receiver account of a transfer, given by its ID, its IBAN or a beneficiary of the
+// account, and sets its ID.
+func (a *ImplAccountService) resolveReceiver(
+	ctx context.Context,
+	req *types.TransferMoneyRequest,
+	accountID uuid.UUID,
+) (storage.Account, error) {
+	if req.BeneficiaryID.Valid {
+		if req.ReciverAccountID != uuid.Nil || req.ReciverIBAN != "" {
+			return storage.Account{}, types.ErrAmbiguousReceiver
+		}
+
+		reciverAccountID, err := a.beneficiaries.Resolve(ctx, accountID, req.BeneficiaryID.UUID, req.Amount)
+		if err != nil {
+			return storage.Account{}, err
+		}
+
+		req.ReciverAccountID = reciverAccountID
+	}
+
+	if req.ReciverIBAN == "" {
+		return a.fetchReceiver(ctx, req.ReciverAccountID)
+	}
+
+	if req.ReciverAccountID != uuid.Nil {
+		return storage.Account{}, types.ErrAmbiguousReceiver
+	}
+
+	receiver, err := a.store.GetAccountByIBAN(ctx, pgtype.Text{String: iban.Normalize(req.ReciverIBAN), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.Account{}, ErrRecieverAccountNotFound
+		}
+
+		a.logger.ErrorContext(ctx, "failed to fetch account by iban", "error", err)
+
+		return storage.Account{}, ErrInternal
+	}
+
+	req.ReciverAccountID = receiver.AccountID
+
+	return receiver, nil
+}
+
+// fetchReceiver fetches the receiver account of a transfer.
+func (a *ImplAccountService) fetchReceiver(ctx context.Context, reciverAccountID uuid.UUID) (storage.Account, error) {
+	receiver, err := a.store.GetAccount(ctx, reciverAccountID)
 	if err != nil {
-		a.logger.Error("failed to add transaction", "error", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.Account{}, ErrRecieverAccountNotFound
+		}
 
-		return types.TransferMoneyResponse{}, ErrInternal
+		a.logger.ErrorContext(ctx, "failed to fetch receiver account", "error", err)
+
+		return storage.Account{}, ErrInternal
 	}
 
-	reciverTransaction, err := s.AddTransaction(ctx, storage.AddTransactionParams{
+	return receiver, nil
+}
+
+// raiseTransfer raises the events of a transfer, for the sender and the receiver.
+func (a *ImplAccountService) raiseTransfer(
+	ctx context.Context,
+	tx pgx.Tx,
+	req *types.TransferMoneyRequest,
+	sender storage.Account,
+	sent, received storage.Transaction,
+) error {
+	if err := a.raise(ctx, tx, outbox.Event{
+		Type:      outbox.EventMoneyTransferred,
+		AccountID: sender.AccountID,
+		Payload: outbox.MoneyTransferred{
+			TransactionID:         sent.TransactionID,
+			ReceiverAccountID:     req.ReciverAccountID,
+			ReceiverTransactionID: received.TransactionID,
+			Amount:                req.Amount,
+			CurrencyCode:          sender.CurrencyCode,
+		},
+	}); err != nil {
+		return err
+	}
+
+	return a.raise(ctx, tx, outbox.Event{
+		Type:      outbox.EventMoneyReceived,
 		AccountID: req.ReciverAccountID,
-		Amount:    pgtype.Numeric{Int: big.NewInt(req.Amount), Exp: -2, Valid: true},
-		SourceID:  uuid.NullUUID{UUID: t.TransactionID, Valid: true},
+		Payload: outbox.MoneyReceived{
+			TransactionID:       received.TransactionID,
+			SenderAccountID:     sender.AccountID,
+			SenderTransactionID: sent.TransactionID,
+			Amount:              req.Amount,
+			CurrencyCode:        sender.CurrencyCode,
+		},
 	})
+}
+
+// GetAccount returns a bank account and its balance, with the balances of its pockets and their total if it has any.
+// returns GetAccountResponse.
+func (a *ImplAccountService) GetAccount(
+	ctx context.Context,
+	accountID uuid.UUID,
+) (types.GetAccountResponse, error) {
+	ctx, span := a.startSpan(ctx, "GetAccount", accountID)
+	defer span.End()
+
+	account, err := a.fetchAuthorizedAccount(ctx, accountID, holder.PermissionView)
+	if err != nil {
+		return types.GetAccountResponse{}, err
+	}
+
+	totalAmount, err := a.store.GetAccountTotalAmount(ctx, accountID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get account total amount", "error", err)
+
+		return types.GetAccountResponse{}, ErrInternal
+	}
+
+	res := accountview.Response(account, totalAmount)
+
+	// Pockets have no pockets of their own.
+	if account.ParentAccountID.Valid {
+		return res, nil
+	}
+
+	pockets, err := a.pockets.Pockets(ctx, accountID)
+	if err != nil {
+		return types.GetAccountResponse{}, err
+	}
+
+	return accountview.WithPockets(res, pockets), nil
+}
+
+// GetAccountByIBAN returns the bank account of an IBAN, in electronic or print format, and its balance, for the holders
+// permitted to view it.
+// returns GetAccountResponse.
+func (a *ImplAccountService) GetAccountByIBAN(
+	ctx context.Context,
+	accountIBAN string,
+) (types.GetAccountResponse, error) {
+	ctx, span := a.tracer.Start(ctx, "AccountService.GetAccountByIBAN")
+	defer span.End()
+
+	if !iban.Valid(accountIBAN) {
+		return types.GetAccountResponse{}, types.ErrInvalidIBAN
+	}
+
+	account, err := a.store.GetAccountByIBAN(ctx, pgtype.Text{String: iban.Normalize(accountIBAN), Valid: true})
 	if err != nil {
-		pgErr := &pgconn.PgError{}
-		if errors.As(err, &pgErr); pgErr.Code == pqErrorForeignKeyViolation {
-			return types.TransferMoneyResponse{}, ErrRecieverAccountNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.GetAccountResponse{}, ErrAccountNotFound
 		}
 
-		a.logger.Error("failed to add transaction", "error", err)
+		a.logger.ErrorContext(ctx, "failed to fetch account by iban", "error", err)
 
-		return types.TransferMoneyResponse{}, ErrInternal
+		return types.GetAccountResponse{}, ErrInternal
 	}
 
-	err = tx.Commit(ctx)
+	if err := a.holders.Authorize(ctx, account.AccountID, holder.PermissionView); err != nil {
+		return types.GetAccountResponse{}, err
+	}
+
+	totalAmount, err := a.store.GetAccountTotalAmount(ctx, account.AccountID)
 	if err != nil {
-		a.logger.Error("failed to commit transaction", "error", err)
+		a.logger.ErrorContext(ctx, "failed to get account total amount", "error", err)
 
-		return types.TransferMoneyResponse{}, ErrInternal
+		return types.GetAccountResponse{}, ErrInternal
 	}
 
-	return types.TransferMoneyResponse{TransactionID: reciverTransaction.TransactionID}, nil
+	return accountview.Response(account, totalAmount), nil
 }
 
+// ListTransactions lists the transactions of a bank account, the last committed first.
+// returns ListTransactionsResponse.
+func (a *ImplAccountService) ListTransactions(
+	ctx context.Context,
+	accountID uuid.UUID,
+	limit, offset int32,
+) (types.ListTransactionsResponse, error) {
+	ctx, span := a.startSpan(ctx, "ListTransactions", accountID)
+	defer span.End()
+
+	if err := a.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.ListTransactionsResponse{}, err
+	}
+
+	account, err := a.fetchAccount(ctx, accountID)
+	if err != nil {
+		return types.ListTransactionsResponse{}, err
+	}
+
+	transactions, err := a.store.ListTransactions(ctx, storage.ListTransactionsParams{
+		AccountID: accountID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list transactions", "error", err)
+
+		return types.ListTransactionsResponse{}, ErrInternal
+	}
+
+	res := types.ListTransactionsResponse{
+		Transactions: make([]types.Transaction, 0, len(transactions)),
+	}
+
+	for _, t := range transactions {
+		res.Transactions = append(res.Transactions, toTransaction(t, account.CurrencyCode))
+	}
+
+	return res, nil
+}
+
+// ListTransactionsAfter lists the transactions of a bank account committed after the transaction after, in the order
+// they were committed, or from the first one when after is null.
+// returns ListTransactionsResponse.
+func (a *ImplAccountService) ListTransactionsAfter(
+	ctx context.Context,
+	accountID uuid.UUID,
+	after uuid.NullUUID,
+	limit int32,
+) (types.ListTransactionsResponse, error) {
+	ctx, span := a.startSpan(ctx, "ListTransactionsAfter", accountID)
+	defer span.End()
+
+	if err := a.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.ListTransactionsResponse{}, err
+	}
+
+	account, err := a.fetchAccount(ctx, accountID)
+	if err != nil {
+		return types.ListTransactionsResponse{}, err
+	}
+
+	if after.Valid {
+		ok, err := a.store.HasAccountTransaction(ctx, storage.HasAccountTransactionParams{
+			AccountID:     accountID,
+			TransactionID: after.UUID,
+		})
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to check transaction", "error", err)
+
+			return types.ListTransactionsResponse{}, ErrInternal
+		}
+
+		if !ok {
+			return types.ListTransactionsResponse{}, ErrTransactionNotFound
+		}
+	}
+
+	transactions, err := a.store.ListTransactionsAfter(ctx, storage.ListTransactionsAfterParams{
+		AccountID: accountID,
+		After:     after,
+		Limit:     limit,
+	})
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list transactions", "error", err)
+
+		return types.ListTransactionsResponse{}, ErrInternal
+	}
+
+	res := types.ListTransactionsResponse{
+		Transactions: make([]types.Transaction, 0, len(transactions)),
+	}
+
+	for _, t := range transactions {
+		res.Transactions = append(res.Transactions, toTransaction(t, account.CurrencyCode))
+	}
+
+	return res, nil
+}
+
+func toTransaction(t storage.Transaction, currencyCode string) types.Transaction {
+	return types.Transaction{
+		ID:        t.TransactionID,
+		AccountID: t.AccountID,
+		Amount:    storage.AmountFromNumeric(t.Amount, currencyCode),
+		Type:      t.Type,
+		SourceID:  t.SourceID,
+		CreatedAt: t.CreatedAt.Time,
+	}
+}
+
+// validateTotalBalanceForMoneyTransfer fails unless the available balance, the balance plus the overdraft limit,
+// stays positive once the amount is transferred.
 func validateTotalBalanceForMoneyTransfer(
 	totalAmount pgtype.Numeric,
+	overdraftLimit money.Amount,
 	transferableAmount int64,
 	currencyCode string,
 ) error {
-	if totalAmount.Int == nil {
+	if totalAmount.Int == nil && overdraftLimit == 0 {
 		return ErrInsufficientAccountBalance
 	}
 
-	totalMoney := money.New(totalAmount.Int.Int64(), currencyCode)
+	balance := storage.AmountFromNumeric(totalAmount, currencyCode)
+
+	totalMoney := money.New(balance+overdraftLimit, currencyCode)
 	transferAmountMoney := money.New(transferableAmount, currencyCode)
 
 	res, err := totalMoney.Subtract(transferAmountMoney)
@@ -221,38 +1045,49 @@ func validateTotalBalanceForMoneyTransfer(
 	return nil
 }
 
-func (a *ImplAccountService) hasAccount(ctx context.Context, accountID uuid.UUID) error {
-	ok, err := a.store.HasAccount(ctx, accountID)
-	if err != nil {
-		a.logger.Error("failed to check account", "error", err)
+// startSpan starts the span of a method of the account service acting on the account.
+//
+//nolint:ireturn // spans are only exposed as trace.Span.
+func (a *ImplAccountService) startSpan(
+	ctx context.Context,
+	method string,
+	accountID uuid.UUID,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return a.tracer.Start(ctx, "AccountService."+method, trace.WithAttributes(
+		append(attrs, attribute.String("account.id", accountID.String()))...,
+	))
+}
 
-		return ErrInternal
-	}
+// record records the event in the audit log within tx.
+func (a *ImplAccountService) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := a.auditor.Record(ctx, tx, event); err != nil {
+		a.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
 
-	if !ok {
-		return ErrAccountNotFound
+		return ErrInternal
 	}
 
 	return nil
 }
 
-// BUG: Current lock implementation will lead to a memory leakage and doesn't support high availability.
-func (a *ImplAccountService) getLock(accountID uuid.UUID) sync.Locker {
-	if a.xlock[accountID] == nil {
-		a.xlock[accountID] = &sync.Mutex{}
-	}
-
-	return a.xlock[accountID]
-}
+// raise adds the event to the outbox within tx. The outbox numbers the events of an account in the order their
+// transactions commit, locking the account until the end of tx.
+func (a *ImplAccountService) raise(ctx context.Context, tx pgx.Tx, event outbox.Event) error {
+	if err := a.outbox.Add(ctx, tx, event); err != nil {
+		a.logger.ErrorContext(ctx, "failed to add outbox event", "error", err)
 
-func (a *ImplAccountService) lock(accountID uuid.UUID) {
-	lock := a.getLock(accountID)
+		return ErrInternal
+	}
 
-	lock.Lock()
+	return nil
 }
 
-func (a *ImplAccountService) unlock(accountID uuid.UUID) {
-	lock := a.getLock(accountID)
+// recordFailure records in the audit log that the operation of the event failed with err.
+func (a *ImplAccountService) recordFailure(ctx context.Context, event audit.Event, err error) {
+	event.Outcome = audit.Outcome(err)
 
-	lock.Unlock()
+	// The operation was rolled back, the failure is recorded in a transaction of its own.
+	_ = storage.InTx(ctx, a.conn, a.storeWithTx, a.logger, func(tx pgx.Tx, _ storage.AccountStore) error {
+		return a.record(ctx, tx, event)
+	})
 }
@@ -1,22 +1,34 @@
 package api
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"log/slog"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/iban"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/product"
+	"github.com/zaidsasa/xbankapi/internal/sanctions"
 	"github.com/zaidsasa/xbankapi/internal/storage"
 	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
-	"github.com/zaidsasa/xbankapi/internal/types"
 	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
 )
 
 var (
@@ -25,70 +37,247 @@ var (
 	wantReciverAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789003")
 	wantReciverTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789004")
 	errAnything              = errors.New("any")
+	errDailyAmountExceeded   = &types.LimitExceededError{Limit: types.LimitDailyAmount, Remaining: 100}
+	wantPendingTransferID    = uuid.MustParse("12345678-1234-1234-1234-123456789005")
+	errHeldForReview         = &types.PendingReviewError{PendingTransferID: wantPendingTransferID}
+	wantTransferApprovalID   = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+	errHeldForApproval       = &types.PendingApprovalError{TransferApprovalID: wantTransferApprovalID}
+	wantPocketID             = uuid.MustParse("12345678-1234-1234-1234-123456789007")
+	clearScreening           = sanctions.Result{Status: types.ScreeningStatusClear, Matches: []types.SanctionsMatch{}}
+	reviewScreening          = sanctions.Result{Status: types.ScreeningStatusReview, Matches: []types.SanctionsMatch{
+		{List: "eu", Reference: "EU-1", Name: "Jon Doe", Score: 0.93},
+	}}
 )
 
 func TestNewAccountService(t *testing.T) {
 	t.Parallel()
 
-	got := NewAccountService(&pgxpool.Pool{}, storageMocks.NewMockAccountStore(t), slog.Default())
+	got := NewAccountService(&pgxpool.Pool{}, storageMocks.NewMockAccountStore(t), slog.Default(),
+		mocks.NewMockMetrics(t), mocks.NewMockAuditor(t), mocks.NewMockOutbox(t), testIBANs(t),
+		mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), mocks.NewMockSanctions(t),
+		mocks.NewMockFees(t), mocks.NewMockProducts(t), allowHolders(t), mocks.NewMockPockets(t),
+		mocks.NewMockPayments(t))
 	assert.NotNil(t, got)
 }
 
-func TestAccountService_CreateAccount(t *testing.T) {
-	t.Parallel()
+type createAccountArgs struct {
+	ctx context.Context
+	req *types.CreateAccountRequest
+}
 
-	type args struct {
-		ctx context.Context
-		req *types.CreateAccountRequest
+type createAccountTest struct {
+	name       string
+	args       createAccountArgs
+	customerID uuid.UUID
+	productErr error
+	screening  sanctions.Result
+	mock       func(*storageMocks.MockAccountStore, *mocks.MockSanctions, createAccountArgs)
+	want       types.CreateAccountResponse
+	wantErr    error
+}
+
+// createAccountCustomerTests are the accounts opened for a new customer or for the customer of customerID.
+func createAccountCustomerTests() []createAccountTest {
+	return []createAccountTest{
+		{
+			name: "failed when a customer has the email already",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", Email: "john@mail.com", CurrencyCode: "EUR"},
+			},
+			screening: clearScreening,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *mocks.MockSanctions, a createAccountArgs) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, mock.Anything).Return(storage.Account{},
+					&pgconn.PgError{Code: pqErrorAlreadyExist, ConstraintName: customerEmailKey}).Once()
+			},
+			wantErr: types.ErrCustomerAlreadyExist,
+		},
+		{
+			name: "failed when an account has the number already",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", Email: "john@mail.com", CurrencyCode: "EUR"},
+			},
+			screening: clearScreening,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *mocks.MockSanctions, a createAccountArgs) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, mock.Anything).Return(storage.Account{},
+					&pgconn.PgError{Code: pqErrorAlreadyExist, ConstraintName: "account_account_number_key"}).Once()
+			},
+			wantErr: ErrAccountAlreadyExist,
+		},
+		{
+			name: "success when creating an account for a customer",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "Holidays", Email: "john@mail.com", CurrencyCode: "EUR"},
+			},
+			customerID: wantCustomerID,
+			screening:  clearScreening,
+			mock: func(
+				accountStorageMock *storageMocks.MockAccountStore, sanctionsMock *mocks.MockSanctions, a createAccountArgs,
+			) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, storage.CreateAccountParams{
+					Email:         a.req.Email,
+					Name:          a.req.Name,
+					CurrencyCode:  a.req.CurrencyCode,
+					AccountNumber: 532013000,
+					IBAN:          pgtype.Text{String: "DE89370400440532013000", Valid: true},
+					ProductCode:   product.DefaultCode,
+					CustomerID:    uuid.NullUUID{UUID: wantCustomerID, Valid: true},
+				}).Return(storage.Account{
+					AccountID:    wantAccountID,
+					Name:         a.req.Name,
+					Email:        a.req.Email,
+					CurrencyCode: a.req.CurrencyCode,
+					CustomerID:   wantCustomerID,
+				}, nil).Once()
+				sanctionsMock.EXPECT().Record(mock.Anything, mock.Anything, wantAccountID, a.req.Name, clearScreening).
+					Return(nil).Once()
+			},
+			want: types.CreateAccountResponse{
+				Account: types.Account{
+					ID:              wantAccountID,
+					Name:            "Holidays",
+					Email:           "john@mail.com",
+					CurrencyCode:    "EUR",
+					ScreeningStatus: types.ScreeningStatusClear,
+				},
+			},
+		},
 	}
+}
 
-	tests := []struct {
-		name    string
-		args    args
-		mock    func(*storageMocks.MockAccountStore, args)
-		want    types.CreateAccountResponse
-		wantErr error
-	}{
+func TestAccountService_CreateAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := append([]createAccountTest{
+		{
+			name: "failed when product not found",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", CurrencyCode: "EUR", ProductCode: "business"},
+			},
+			productErr: types.ErrProductNotFound,
+			mock:       func(*storageMocks.MockAccountStore, *mocks.MockSanctions, createAccountArgs) {},
+			wantErr:    types.ErrProductNotFound,
+		},
+		{
+			name: "failed when the product does not allow the currency",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", CurrencyCode: "USD"},
+			},
+			productErr: types.ErrCurrencyNotAllowed,
+			mock:       func(*storageMocks.MockAccountStore, *mocks.MockSanctions, createAccountArgs) {},
+			wantErr:    types.ErrCurrencyNotAllowed,
+		},
+		{
+			name: "failed when the name matches a sanctions entry",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", CurrencyCode: "EUR"},
+			},
+			screening: sanctions.Result{Status: types.ScreeningStatusBlocked, Matches: []types.SanctionsMatch{
+				{List: "eu", Reference: "EU-1", Name: "Doe, John", Score: 1},
+			}},
+			mock:    func(*storageMocks.MockAccountStore, *mocks.MockSanctions, createAccountArgs) {},
+			wantErr: types.ErrSanctionsMatch,
+		},
 		{
 			name: "failed when creating an account returns an error",
-			args: args{
+			args: createAccountArgs{
 				ctx: context.Background(),
-				req: &types.CreateAccountRequest{},
+				req: &types.CreateAccountRequest{CurrencyCode: "EUR"},
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
-				accountStorageMock.EXPECT().CreateAccount(a.ctx, mock.Anything).
+			screening: clearScreening,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *mocks.MockSanctions, a createAccountArgs) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, mock.Anything).
 					Return(storage.Account{}, errAnything).Once()
 			},
 			wantErr: ErrInternal,
 		},
 		{
 			name: "success when creating an account",
-			args: args{
+			args: createAccountArgs{
 				ctx: context.Background(),
 				req: &types.CreateAccountRequest{
 					Name:         "test",
 					Email:        "test@mail.com",
 					CurrencyCode: "EUR",
+					ProductCode:  "savings",
 				},
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
-				accountStorageMock.EXPECT().CreateAccount(a.ctx, mock.Anything).Return(storage.Account{
+			screening: clearScreening,
+			mock: func(
+				accountStorageMock *storageMocks.MockAccountStore, sanctionsMock *mocks.MockSanctions, a createAccountArgs,
+			) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, storage.CreateAccountParams{
+					Email:         a.req.Email,
+					Name:          a.req.Name,
+					CurrencyCode:  a.req.CurrencyCode,
+					AccountNumber: 532013000,
+					IBAN:          pgtype.Text{String: "DE89370400440532013000", Valid: true},
+					ProductCode:   "savings",
+				}).Return(storage.Account{
+					AccountID:     wantAccountID,
+					Name:          a.req.Name,
+					Email:         a.req.Email,
+					CurrencyCode:  a.req.CurrencyCode,
+					AccountNumber: 532013000,
+					IBAN:          pgtype.Text{String: "DE89370400440532013000", Valid: true},
+					ProductCode:   "savings",
+				}, nil).Once()
+				sanctionsMock.EXPECT().Record(mock.Anything, mock.Anything, wantAccountID, a.req.Name, clearScreening).
+					Return(nil).Once()
+			},
+			want: types.CreateAccountResponse{
+				Account: types.Account{
+					ID:              wantAccountID,
+					Name:            "test",
+					Email:           "test@mail.com",
+					CurrencyCode:    "EUR",
+					IBAN:            "DE89370400440532013000",
+					ProductCode:     "savings",
+					ScreeningStatus: types.ScreeningStatusClear,
+				},
+			},
+		},
+		{
+			name: "success when the name nearly matches a sanctions entry, under review",
+			args: createAccountArgs{
+				ctx: context.Background(),
+				req: &types.CreateAccountRequest{Name: "John Doe", Email: "john@mail.com", CurrencyCode: "EUR"},
+			},
+			screening: reviewScreening,
+			mock: func(
+				accountStorageMock *storageMocks.MockAccountStore, sanctionsMock *mocks.MockSanctions, a createAccountArgs,
+			) {
+				accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(532013000, nil).Once()
+				accountStorageMock.EXPECT().CreateAccount(mock.Anything, mock.Anything).Return(storage.Account{
 					AccountID:    wantAccountID,
 					Name:         a.req.Name,
 					Email:        a.req.Email,
 					CurrencyCode: a.req.CurrencyCode,
 				}, nil).Once()
+				sanctionsMock.EXPECT().Record(mock.Anything, mock.Anything, wantAccountID, a.req.Name, reviewScreening).
+					Return(nil).Once()
 			},
 			want: types.CreateAccountResponse{
 				Account: types.Account{
-					ID:           wantAccountID,
-					Name:         "test",
-					Email:        "test@mail.com",
-					CurrencyCode: "EUR",
+					ID:              wantAccountID,
+					Name:            "John Doe",
+					Email:           "john@mail.com",
+					CurrencyCode:    "EUR",
+					ScreeningStatus: types.ScreeningStatusReview,
 				},
 			},
 		},
-	}
+	}, createAccountCustomerTests()...)
 
 	for _, test := range tests {
 		tt := test
@@ -96,13 +285,42 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			t.Parallel()
 
 			accountStorageMock := storageMocks.NewMockAccountStore(t)
-			connMock := storageMocks.NewMockDBConnection(t)
+			connMock, auditorMock, outboxMock := expectAuditedTx(
+				t, audit.ActionCreateAccount, tt.wantErr, outbox.EventAccountCreated)
 			logger := slog.Default()
 
-			accountService := NewAccountService(connMock, accountStorageMock, logger)
+			metricsMock := mocks.NewMockMetrics(t)
+			if tt.wantErr == nil {
+				metricsMock.EXPECT().AccountCreated().Once()
+			}
 
-			tt.mock(accountStorageMock, tt.args)
-			got, err := accountService.CreateAccount(tt.args.ctx, tt.args.req)
+			productsMock := mocks.NewMockProducts(t)
+			productsMock.EXPECT().CheckCurrency(mock.Anything, cmp.Or(tt.args.req.ProductCode, product.DefaultCode),
+				tt.args.req.CurrencyCode).Return(tt.productErr).Once()
+
+			sanctionsMock := mocks.NewMockSanctions(t)
+			if tt.screening.Status != "" {
+				sanctionsMock.EXPECT().Screen(mock.Anything, tt.args.req.Name).Return(tt.screening, nil).Once()
+			}
+
+			accountService := NewAccountService(connMock, accountStorageMock, logger, metricsMock, auditorMock, outboxMock,
+				testIBANs(t), mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), sanctionsMock,
+				mocks.NewMockFees(t), productsMock, allowHolders(t), mocks.NewMockPockets(t),
+				mocks.NewMockPayments(t))
+			accountService.storeWithTx = func(pgx.Tx) storage.AccountStore { return accountStorageMock }
+
+			tt.mock(accountStorageMock, sanctionsMock, tt.args)
+
+			createAccount := accountService.CreateAccount
+			if tt.customerID != uuid.Nil {
+				createAccount = func(
+					ctx context.Context, req *types.CreateAccountRequest,
+				) (types.CreateAccountResponse, error) {
+					return accountService.CreateCustomerAccount(ctx, tt.customerID, req)
+				}
+			}
+
+			got, err := createAccount(tt.args.ctx, tt.args.req)
 
 			assert.Equal(t, tt.want, got)
 
@@ -125,12 +343,26 @@ func TestAccountService_AddMoney(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		args    args
-		mock    func(*storageMocks.MockAccountStore, args)
-		want    types.AddMoneyResponse
-		wantErr error
+		name      string
+		args      args
+		mock      func(*storageMocks.MockAccountStore, args)
+		permitErr error
+		want      types.AddMoneyResponse
+		wantErr   error
 	}{
+		{
+			name: "failed when the holder is not permitted to deposit",
+			args: args{
+				ctx: context.Background(),
+				req: &types.AddMoneyRequest{
+					Amount: 100,
+				},
+				accountID: uuid.New(),
+			},
+			mock:      func(*storageMocks.MockAccountStore, args) {},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
 		{
 			name: "failed when account not found",
 			args: args{
@@ -141,11 +373,29 @@ func TestAccountService_AddMoney(t *testing.T) {
 				accountID: uuid.New(),
 			},
 			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
-				accountStorageMock.EXPECT().HasAccount(a.ctx, a.accountID).
-					Return(false, nil).Once()
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
 			},
 			wantErr: ErrAccountNotFound,
 		},
+		{
+			name: "failed when the account is a pocket",
+			args: args{
+				ctx: context.Background(),
+				req: &types.AddMoneyRequest{
+					Amount: 100,
+				},
+				accountID: uuid.New(),
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:       a.accountID,
+					CurrencyCode:    "EUR",
+					ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				}, nil).Once()
+			},
+			wantErr: types.ErrPocketTransfer,
+		},
 		{
 			name: "failed when create transaction returns an error",
 			args: args{
@@ -156,9 +406,11 @@ func TestAccountService_AddMoney(t *testing.T) {
 				accountID: uuid.New(),
 			},
 			mock: func(accountStorageMock *storageMocks.MockAccountStore, args args) {
-				accountStorageMock.EXPECT().HasAccount(args.ctx, args.accountID).
-					Return(true, nil).Once()
-				accountStorageMock.EXPECT().AddTransaction(args.ctx, mock.Anything).
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, args.accountID).
+					Return(storage.Account{AccountID: args.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, args.accountID).
+					Return(pgtype.Numeric{}, nil).Once()
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).
 					Return(storage.Transaction{}, errAnything).Once()
 			},
 			wantErr: ErrInternal,
@@ -173,9 +425,11 @@ func TestAccountService_AddMoney(t *testing.T) {
 				accountID: uuid.New(),
 			},
 			mock: func(accountStorageMock *storageMocks.MockAccountStore, args args) {
-				accountStorageMock.EXPECT().HasAccount(args.ctx, args.accountID).
-					Return(true, nil).Once()
-				accountStorageMock.EXPECT().AddTransaction(args.ctx, mock.Anything).
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, args.accountID).
+					Return(storage.Account{AccountID: args.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, args.accountID).
+					Return(pgtype.Numeric{}, nil).Once()
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).
 					Return(storage.Transaction{
 						TransactionID: wantTrnasactionID,
 						AccountID:     args.accountID,
@@ -194,12 +448,26 @@ func TestAccountService_AddMoney(t *testing.T) {
 			t.Parallel()
 
 			accountStorageMock := storageMocks.NewMockAccountStore(t)
-			connMock := storageMocks.NewMockDBConnection(t)
+			connMock, auditorMock, outboxMock := expectAuditedTx(
+				t, audit.ActionAddMoney, tt.wantErr, outbox.EventMoneyAdded)
 			logger := slog.Default()
 
+			metricsMock := mocks.NewMockMetrics(t)
+			if tt.wantErr == nil {
+				metricsMock.EXPECT().MoneyAdded().Once()
+			}
+
 			tt.mock(accountStorageMock, tt.args)
 
-			accountService := NewAccountService(connMock, accountStorageMock, logger)
+			holdersMock := mocks.NewMockHolders(t)
+			holdersMock.EXPECT().Authorize(mock.Anything, tt.args.accountID, holder.PermissionTransfer).
+				Return(tt.permitErr).Once()
+
+			accountService := NewAccountService(connMock, accountStorageMock, logger, metricsMock, auditorMock, outboxMock,
+				testIBANs(t), mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t),
+				mocks.NewMockSanctions(t), mocks.NewMockFees(t), mocks.NewMockProducts(t), holdersMock, mocks.NewMockPockets(t),
+				mocks.NewMockPayments(t))
+			accountService.storeWithTx = func(pgx.Tx) storage.AccountStore { return accountStorageMock }
 			got, err := accountService.AddMoney(tt.args.ctx, tt.args.req, tt.args.accountID)
 
 			assert.Equal(t, tt.want, got)
@@ -213,74 +481,113 @@ func TestAccountService_AddMoney(t *testing.T) {
 	}
 }
 
-func TestAccountService_TransferMoney(t *testing.T) {
-	t.Parallel()
+type transferMoneyArgs struct {
+	ctx       context.Context
+	req       *types.TransferMoneyRequest
+	accountID uuid.UUID
+}
 
-	type args struct {
-		ctx       context.Context
-		req       *types.TransferMoneyRequest
-		accountID uuid.UUID
-	}
+type transferMoneyTest struct {
+	name string
+	args transferMoneyArgs
+	mock func(*storageMocks.MockAccountStore, transferMoneyArgs)
+	// mockBeneficiaries sets the expectations of the beneficiaries, if any.
+	mockBeneficiaries func(*mocks.MockBeneficiaries)
+	// mockLimits sets the expectations of the limits, which let the transfer through when nil.
+	mockLimits func(*mocks.MockLimits)
+	// mockRisk sets the expectations of the risk engine, which lets the transfer through when nil.
+	mockRisk func(*mocks.MockRisk)
+	// mockFees sets the expectations of the fees, which charge no fee when nil.
+	mockFees func(*mocks.MockFees)
+	// mockHolders sets the expectations of the holders, which let the transfer through when nil.
+	mockHolders func(*mocks.MockHolders)
+	// mockPayments sets the expectations of the payments, which link the transfer to no payment when nil.
+	mockPayments func(*mocks.MockPayments)
+	want         types.TransferMoneyResponse
+	wantErr      error
+}
 
-	tests := []struct {
-		name    string
-		args    args
-		mock    func(*storageMocks.MockAccountStore, *storageMocks.MockDBConnection, args)
-		want    types.TransferMoneyResponse
-		wantErr error
-	}{
+// transferMoneyOverdraftTests are the transfers from accounts with an overdraft.
+func transferMoneyOverdraftTests() []transferMoneyTest {
+	return []transferMoneyTest{
 		{
-			name: "failed when get account returns an error",
-			args: args{
+			name: "failed when the amount exceeds the overdraft",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
 				req: &types.TransferMoneyRequest{
 					ReciverAccountID: wantReciverAccountID,
 					Amount:           200,
 				},
 				accountID: wantAccountID,
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *storageMocks.MockDBConnection, a args) {
-				accountStorageMock.EXPECT().GetAccount(a.ctx, a.accountID).
-					Return(storage.Account{}, errAnything)
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", OverdraftLimit: storage.NumericFromAmount(300, "EUR"),
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(-100), Exp: -2, Valid: true}, nil).Once()
 			},
-			wantErr: ErrInternal,
+			wantErr: ErrInsufficientAccountBalance,
 		},
 		{
-			name: "failed when get account total amount returns an error",
-			args: args{
+			name: "success when the overdraft covers the amount",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
 				req: &types.TransferMoneyRequest{
 					ReciverAccountID: wantReciverAccountID,
 					Amount:           200,
 				},
 				accountID: wantAccountID,
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *storageMocks.MockDBConnection, a args) {
-				accountStorageMock.EXPECT().GetAccount(a.ctx, a.accountID).
-					Return(storage.Account{}, nil).Once()
-				accountStorageMock.EXPECT().GetAccountTotalAmount(a.ctx, a.accountID).
-					Return(pgtype.Numeric{}, errAnything).Once()
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", OverdraftLimit: storage.NumericFromAmount(500, "EUR"),
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(-100), Exp: -2, Valid: true}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{
+					TransactionID: wantReciverTransactionID,
+				}, nil).Once()
+			},
+			want: types.TransferMoneyResponse{
+				TransactionID: wantReciverTransactionID,
 			},
-			wantErr: ErrInternal,
 		},
+	}
+}
+
+// transferMoneyFeeTests are the transfers from accounts whose product charges transfer fees.
+func transferMoneyFeeTests() []transferMoneyTest {
+	return []transferMoneyTest{
 		{
-			name: "failed when insufficient account balance",
-			args: args{
+			name: "failed when the balance does not cover the fee",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
 				req: &types.TransferMoneyRequest{
 					ReciverAccountID: wantReciverAccountID,
 					Amount:           200,
 				},
 				accountID: wantAccountID,
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, _ *storageMocks.MockDBConnection, a args) {
-				accountStorageMock.EXPECT().GetAccount(a.ctx, a.accountID).
-					Return(storage.Account{}, nil).Once()
-				accountStorageMock.EXPECT().GetAccountTotalAmount(a.ctx, a.accountID).
-					Return(pgtype.Numeric{Int: big.NewInt(200), Exp: -2}, nil).Once()
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", ProductCode: "current",
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockFees: func(feesMock *mocks.MockFees) {
+				feesMock.EXPECT().TransferFee(mock.Anything, wantAccountID, "current", "EUR", money.Amount(200)).
+					Return(50, nil).Once()
 			},
 			wantErr: ErrInsufficientAccountBalance,
 		},
 		{
-			name: "success when money transfer is succeeded",
-			args: args{
+			name: "failed when the fee cannot be charged",
+			args: transferMoneyArgs{
 				ctx: context.Background(),
 				req: &types.TransferMoneyRequest{
 					ReciverAccountID: wantReciverAccountID,
@@ -288,53 +595,1316 @@ func TestAccountService_TransferMoney(t *testing.T) {
 				},
 				accountID: wantAccountID,
 			},
-			mock: func(accountStorageMock *storageMocks.MockAccountStore, conn *storageMocks.MockDBConnection, a args) {
-				accountStorageMock.EXPECT().GetAccount(a.ctx, a.accountID).
-					Return(storage.Account{}, nil).Once()
-				accountStorageMock.EXPECT().GetAccountTotalAmount(a.ctx, a.accountID).
-					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2}, nil).Once()
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", ProductCode: "current",
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(251), Exp: -2, Valid: true}, nil).Once()
 
-				tx := txMocks.NewMockTx(t)
-				conn.EXPECT().Begin(a.ctx).Return(tx, nil).Once()
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).
+					Return(storage.Transaction{TransactionID: wantTrnasactionID}, nil).Once()
 
-				storage.AccountStoreWithTx = func(_ pgx.Tx) storage.AccountStore {
-					return accountStorageMock
-				}
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{
+					TransactionID: wantReciverTransactionID,
+				}, nil).Once()
+			},
+			mockFees: func(feesMock *mocks.MockFees) {
+				feesMock.EXPECT().TransferFee(mock.Anything, wantAccountID, "current", "EUR", money.Amount(200)).
+					Return(50, nil).Once()
+				feesMock.EXPECT().
+					Charge(mock.Anything, mock.Anything, wantAccountID, "EUR", wantTrnasactionID, money.Amount(50)).
+					Return(ErrInternal).Once()
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "success when the fee is charged on top of the amount",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", ProductCode: "current",
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(251), Exp: -2, Valid: true}, nil).Once()
 
-				accountStorageMock.EXPECT().AddTransaction(a.ctx, mock.Anything).Return(storage.Transaction{}, nil).Once()
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).
+					Return(storage.Transaction{TransactionID: wantTrnasactionID}, nil).Once()
 
-				accountStorageMock.EXPECT().AddTransaction(a.ctx, mock.Anything).Return(storage.Transaction{
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{
 					TransactionID: wantReciverTransactionID,
 				}, nil).Once()
-
-				tx.EXPECT().Commit(a.ctx).Return(nil).Once()
+			},
+			mockFees: func(feesMock *mocks.MockFees) {
+				feesMock.EXPECT().TransferFee(mock.Anything, wantAccountID, "current", "EUR", money.Amount(200)).
+					Return(50, nil).Once()
+				feesMock.EXPECT().
+					Charge(mock.Anything, mock.Anything, wantAccountID, "EUR", wantTrnasactionID, money.Amount(50)).
+					Return(nil).Once()
 			},
 			want: types.TransferMoneyResponse{
 				TransactionID: wantReciverTransactionID,
+				Fee:           50,
 			},
 		},
 	}
+}
 
-	for _, test := range tests {
-		tt := test
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			accountStorageMock := storageMocks.NewMockAccountStore(t)
-			connMock := storageMocks.NewMockDBConnection(t)
-			logger := slog.Default()
-
-			tt.mock(accountStorageMock, connMock, tt.args)
-
-			accountService := NewAccountService(connMock, accountStorageMock, logger)
-			got, err := accountService.TransferMoney(tt.args.ctx, tt.args.req, tt.args.accountID)
-			assert.Equal(t, tt.want, got)
-
-			if tt.wantErr != nil {
-				assert.ErrorIs(t, tt.wantErr, err)
-			} else {
-				assert.NoError(t, err)
-			}
-		})
+// transferMoneyHolderTests are the transfers made by the holders of accounts, of which some have a mandate.
+func transferMoneyHolderTests() []transferMoneyTest {
+	return []transferMoneyTest{
+		{
+			name: "failed when the holder is not permitted to transfer",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(*storageMocks.MockAccountStore, transferMoneyArgs) {},
+			mockHolders: func(holdersMock *mocks.MockHolders) {
+				holdersMock.EXPECT().Authorize(mock.Anything, wantAccountID, holder.PermissionTransfer).
+					Return(types.ErrNotPermitted).Once()
+			},
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name: "failed when the transfer is held for approval, without screening it or adding transactions",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockHolders: func(holdersMock *mocks.MockHolders) {
+				holdersMock.EXPECT().Authorize(mock.Anything, wantAccountID, holder.PermissionTransfer).Return(nil).Once()
+				holdersMock.EXPECT().CheckKYC(mock.Anything, uuid.Nil).Return(nil).Once()
+				holdersMock.EXPECT().Hold(mock.Anything, mock.Anything, storage.Account{
+					AccountID: wantAccountID, CurrencyCode: "EUR",
+				}, wantReciverAccountID, money.Amount(200)).Return(errHeldForApproval).Once()
+			},
+			mockRisk: func(*mocks.MockRisk) {},
+			wantErr:  errHeldForApproval,
+		},
 	}
 }
+
+// transferMoneyReceiverTests are the transfers whose receiver is given by its IBAN or by a beneficiary.
+func transferMoneyReceiverTests() []transferMoneyTest {
+	return []transferMoneyTest{
+		{
+			name: "failed when both the receiver account id and iban are set",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					ReciverIBAN:      "DE89370400440532013000",
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+			},
+			wantErr: types.ErrAmbiguousReceiver,
+		},
+		{
+			name: "failed when the receiver iban is not the iban of an account",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverIBAN: "DE89370400440532013000",
+					Amount:      200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountByIBAN(mock.Anything, mock.Anything).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrRecieverAccountNotFound,
+		},
+		{
+			name: "failed when the receiver account is not found",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrRecieverAccountNotFound,
+		},
+		{
+			name: "failed when the receiver account is in another currency",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).
+					Return(storage.Account{AccountID: wantReciverAccountID, CurrencyCode: "USD"}, nil).Once()
+			},
+			wantErr: types.ErrCurrencyMismatch,
+		},
+		{
+			name: "failed when the receiver account is a pocket",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).Return(storage.Account{
+					AccountID:       wantReciverAccountID,
+					CurrencyCode:    "EUR",
+					ParentAccountID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}, nil).Once()
+			},
+			wantErr: types.ErrPocketTransfer,
+		},
+		{
+			name: "failed when the account is a pocket",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:       a.accountID,
+					CurrencyCode:    "EUR",
+					ParentAccountID: uuid.NullUUID{UUID: wantReciverAccountID, Valid: true},
+				}, nil).Once()
+			},
+			wantErr: types.ErrPocketTransfer,
+		},
+		{
+			name: "failed when the KYC checks of the customer were rejected",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID: a.accountID, CurrencyCode: "EUR", CustomerID: wantCustomerID,
+				}, nil).Once()
+			},
+			mockHolders: func(holdersMock *mocks.MockHolders) {
+				holdersMock.EXPECT().Authorize(mock.Anything, wantAccountID, holder.PermissionTransfer).Return(nil).Once()
+				holdersMock.EXPECT().CheckKYC(mock.Anything, wantCustomerID).Return(types.ErrKYCRejected).Once()
+			},
+			wantErr: types.ErrKYCRejected,
+		},
+		{
+			name: "success when the receiver is given by its iban",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverIBAN: "de89 3704 0044 0532 0130 00",
+					Amount:      200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountByIBAN(mock.Anything,
+					pgtype.Text{String: "DE89370400440532013000", Valid: true}).
+					Return(storage.Account{AccountID: wantReciverAccountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything,
+					mock.MatchedBy(func(p storage.AddTransactionParams) bool {
+						return p.AccountID == wantReciverAccountID
+					})).Return(storage.Transaction{TransactionID: wantReciverTransactionID}, nil).Once()
+			},
+			want: types.TransferMoneyResponse{
+				TransactionID: wantReciverTransactionID,
+			},
+		},
+		{
+			name: "failed when both the receiver account id and a beneficiary are set",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					BeneficiaryID:    uuid.NullUUID{UUID: wantBeneficiaryID, Valid: true},
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+			},
+			wantErr: types.ErrAmbiguousReceiver,
+		},
+		{
+			name: "failed when the beneficiary is in its cooling-off period",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					BeneficiaryID: uuid.NullUUID{UUID: wantBeneficiaryID, Valid: true},
+					Amount:        200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+			},
+			mockBeneficiaries: func(beneficiariesMock *mocks.MockBeneficiaries) {
+				beneficiariesMock.EXPECT().Resolve(mock.Anything, wantAccountID, wantBeneficiaryID, money.Amount(200)).
+					Return(uuid.Nil, types.ErrBeneficiaryCoolingOff).Once()
+			},
+			wantErr: types.ErrBeneficiaryCoolingOff,
+		},
+		{
+			name: "success when the receiver is given by a beneficiary",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					BeneficiaryID: uuid.NullUUID{UUID: wantBeneficiaryID, Valid: true},
+					Amount:        200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything,
+					mock.MatchedBy(func(p storage.AddTransactionParams) bool {
+						return p.AccountID == wantReciverAccountID
+					})).Return(storage.Transaction{TransactionID: wantReciverTransactionID}, nil).Once()
+			},
+			mockBeneficiaries: func(beneficiariesMock *mocks.MockBeneficiaries) {
+				beneficiariesMock.EXPECT().Resolve(mock.Anything, wantAccountID, wantBeneficiaryID, money.Amount(200)).
+					Return(wantReciverAccountID, nil).Once()
+			},
+			want: types.TransferMoneyResponse{
+				TransactionID: wantReciverTransactionID,
+			},
+		},
+	}
+}
+
+// transferMoneyBalanceTests are the transfers whose balance is checked with the account locked.
+func transferMoneyBalanceTests() []transferMoneyTest {
+	return []transferMoneyTest{
+		{
+			name: "failed when the receiver account is not found once locked",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).
+					Return(storage.Transaction{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrRecieverAccountNotFound,
+		},
+		{
+			name: "failed when get account total amount returns an error",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{}, errAnything).Once()
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "failed when the account cannot be locked",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().LockAccounts(mock.Anything, []uuid.UUID{a.accountID, a.req.ReciverAccountID}).
+					Return(errAnything).Once()
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "failed when insufficient account balance",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(200), Exp: -2, Valid: true}, nil).Once()
+			},
+			wantErr: ErrInsufficientAccountBalance,
+		},
+	}
+}
+
+// transferMoneyPaymentTests are the transfers linked to the payments of payment files they execute.
+func transferMoneyPaymentTests() []transferMoneyTest {
+	return []transferMoneyTest{
+		{
+			name: "failed when the held transfer cannot be linked to its payment",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockRisk: func(riskMock *mocks.MockRisk) {
+				riskMock.EXPECT().Screen(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(errHeldForReview).Once()
+			},
+			mockPayments: func(paymentsMock *mocks.MockPayments) {
+				paymentsMock.EXPECT().LinkHold(mock.Anything, mock.Anything, errHeldForReview).Return(ErrInternal).Once()
+			},
+			wantErr: ErrInternal,
+		},
+	}
+}
+
+func TestAccountService_TransferMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := append([]transferMoneyTest{
+		{
+			name: "failed when get account returns an error",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{}, errAnything)
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "failed when a limit of the account is exceeded",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockLimits: func(limitsMock *mocks.MockLimits) {
+				limitsMock.EXPECT().Check(mock.Anything, mock.Anything, wantAccountID, money.Amount(200)).
+					Return(errDailyAmountExceeded).Once()
+			},
+			wantErr: errDailyAmountExceeded,
+		},
+		{
+			name: "failed when the transfer is denied by the risk engine",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockRisk: func(riskMock *mocks.MockRisk) {
+				riskMock.EXPECT().Screen(mock.Anything, mock.Anything,
+					storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, wantReciverAccountID, money.Amount(200)).
+					Return(types.ErrTransferDenied).Once()
+			},
+			wantErr: types.ErrTransferDenied,
+		},
+		{
+			name: "failed when the transfer is held for review, without adding transactions",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+			},
+			mockRisk: func(riskMock *mocks.MockRisk) {
+				riskMock.EXPECT().Screen(mock.Anything, mock.Anything,
+					storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, wantReciverAccountID, money.Amount(200)).
+					Return(errHeldForReview).Once()
+			},
+			wantErr: errHeldForReview,
+		},
+		{
+			name: "success when money transfer is succeeded",
+			args: transferMoneyArgs{
+				ctx: context.Background(),
+				req: &types.TransferMoneyRequest{
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           200,
+				},
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a transferMoneyArgs) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(201), Exp: -2, Valid: true}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{}, nil).Once()
+
+				accountStorageMock.EXPECT().AddTransaction(mock.Anything, mock.Anything).Return(storage.Transaction{
+					TransactionID: wantReciverTransactionID,
+				}, nil).Once()
+			},
+			mockPayments: func(paymentsMock *mocks.MockPayments) {
+				paymentsMock.EXPECT().LinkTransfer(mock.Anything, mock.Anything, wantReciverTransactionID).Return(nil).Once()
+			},
+			want: types.TransferMoneyResponse{
+				TransactionID: wantReciverTransactionID,
+			},
+		},
+	}, append(append(append(append(append(transferMoneyBalanceTests(), transferMoneyReceiverTests()...),
+		transferMoneyOverdraftTests()...), transferMoneyFeeTests()...), transferMoneyHolderTests()...),
+		transferMoneyPaymentTests()...)...)
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountStorageMock := storageMocks.NewMockAccountStore(t)
+			connMock, auditorMock, outboxMock := expectAuditedTx(
+				t, audit.ActionTransferMoney, tt.wantErr, outbox.EventMoneyTransferred, outbox.EventMoneyReceived)
+			logger := slog.Default()
+
+			metricsMock := mocks.NewMockMetrics(t)
+			if tt.wantErr == nil {
+				metricsMock.EXPECT().MoneyTransferred(tt.args.req.Amount, "EUR").Once()
+			} else {
+				metricsMock.EXPECT().TransferFailed(types.ErrorCode(tt.wantErr)).Once()
+			}
+
+			tt.mock(accountStorageMock, tt.args)
+			accountStorageMock.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).
+				Return(storage.Account{AccountID: wantReciverAccountID, CurrencyCode: "EUR"}, nil).Maybe()
+			accountStorageMock.EXPECT().LockAccounts(mock.Anything, []uuid.UUID{wantAccountID, wantReciverAccountID}).
+				Return(nil).Maybe()
+
+			beneficiariesMock := mocks.NewMockBeneficiaries(t)
+			if tt.mockBeneficiaries != nil {
+				tt.mockBeneficiaries(beneficiariesMock)
+			}
+
+			limitsMock := mocks.NewMockLimits(t)
+			if tt.mockLimits != nil {
+				tt.mockLimits(limitsMock)
+			} else {
+				limitsMock.EXPECT().Check(mock.Anything, mock.Anything, wantAccountID, tt.args.req.Amount).Return(nil).Maybe()
+			}
+
+			riskMock := mocks.NewMockRisk(t)
+			if tt.mockRisk != nil {
+				tt.mockRisk(riskMock)
+			} else {
+				riskMock.EXPECT().Screen(mock.Anything, mock.Anything, mock.Anything, mock.Anything, tt.args.req.Amount).
+					Return(nil).Maybe()
+			}
+
+			holdersMock := allowHolders(t)
+			if tt.mockHolders != nil {
+				holdersMock = mocks.NewMockHolders(t)
+				tt.mockHolders(holdersMock)
+			}
+
+			feesMock := mocks.NewMockFees(t)
+			if tt.mockFees != nil {
+				tt.mockFees(feesMock)
+			} else {
+				feesMock.EXPECT().TransferFee(mock.Anything, wantAccountID, mock.Anything, mock.Anything, tt.args.req.Amount).
+					Return(0, nil).Maybe()
+			}
+
+			paymentsMock := linkPayments(t)
+			if tt.mockPayments != nil {
+				paymentsMock = mocks.NewMockPayments(t)
+				tt.mockPayments(paymentsMock)
+			}
+
+			accountService := NewAccountService(connMock, accountStorageMock, logger, metricsMock, auditorMock, outboxMock,
+				testIBANs(t), beneficiariesMock, limitsMock, riskMock, mocks.NewMockSanctions(t), feesMock,
+				mocks.NewMockProducts(t), holdersMock, mocks.NewMockPockets(t), paymentsMock)
+			accountService.storeWithTx = func(pgx.Tx) storage.AccountStore { return accountStorageMock }
+			got, err := accountService.TransferMoney(tt.args.ctx, tt.args.req, tt.args.accountID)
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_GetAccount(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ctx       context.Context
+		accountID uuid.UUID
+	}
+
+	testAccount := storage.Account{
+		AccountID:    wantAccountID,
+		Name:         "test",
+		Email:        "test@mail.com",
+		CurrencyCode: "EUR",
+	}
+
+	totalBalance := money.Amount(3550)
+
+	pockets := []types.Pocket{
+		{ID: wantPocketID, ParentAccountID: wantAccountID, Name: "Holidays", CurrencyCode: "EUR", Balance: 2000},
+		{ID: wantReciverAccountID, ParentAccountID: wantAccountID, Name: "Car", CurrencyCode: "EUR", Balance: 500},
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		mock       func(*storageMocks.MockAccountStore, args)
+		pockets    []types.Pocket
+		pocketsErr error
+		noPockets  bool
+		want       types.GetAccountResponse
+		wantErr    error
+	}{
+		{
+			name: "failed when account not found",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			noPockets: true,
+			wantErr:   ErrAccountNotFound,
+		},
+		{
+			name: "failed when get account total amount returns an error",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{}, errAnything).Once()
+			},
+			noPockets: true,
+			wantErr:   ErrInternal,
+		},
+		{
+			name: "success when account has no transactions",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:    wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{}, nil).Once()
+			},
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				},
+			},
+		},
+		{
+			name: "success when account has transactions",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:    wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(105), Exp: -1, Valid: true}, nil).Once()
+			},
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				},
+				Balance:          1050,
+				AvailableBalance: 1050,
+			},
+		},
+		{
+			name: "success when account has an overdraft",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:      wantAccountID,
+					Name:           "test",
+					Email:          "test@mail.com",
+					CurrencyCode:   "EUR",
+					OverdraftLimit: storage.NumericFromAmount(50000, "EUR"),
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(-105), Exp: -1, Valid: true}, nil).Once()
+			},
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				},
+				Balance:          -1050,
+				AvailableBalance: 48950,
+				OverdraftLimit:   50000,
+			},
+		},
+		{
+			name: "failed when the pockets cannot be listed",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(testAccount, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{}, nil).Once()
+			},
+			pocketsErr: ErrInternal,
+			wantErr:    ErrInternal,
+		},
+		{
+			name: "success when account has pockets",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(testAccount, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(105), Exp: -1, Valid: true}, nil).Once()
+			},
+			pockets: pockets,
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "test",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				},
+				Balance:          1050,
+				AvailableBalance: 1050,
+				Pockets:          pockets,
+				TotalBalance:     &totalBalance,
+			},
+		},
+		{
+			name: "success when account is a pocket",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantPocketID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).Return(storage.Account{
+					AccountID:       wantPocketID,
+					Name:            "Holidays",
+					Email:           "test@mail.com",
+					CurrencyCode:    "EUR",
+					ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, a.accountID).
+					Return(pgtype.Numeric{Int: big.NewInt(20), Exp: 0, Valid: true}, nil).Once()
+			},
+			noPockets: true,
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:              wantPocketID,
+					Name:            "Holidays",
+					Email:           "test@mail.com",
+					CurrencyCode:    "EUR",
+					ParentAccountID: &wantAccountID,
+				},
+				Balance:          2000,
+				AvailableBalance: 2000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountStorageMock := storageMocks.NewMockAccountStore(t)
+			connMock := storageMocks.NewMockDBConnection(t)
+			logger := slog.Default()
+			metricsMock := mocks.NewMockMetrics(t)
+
+			tt.mock(accountStorageMock, tt.args)
+
+			pocketsMock := mocks.NewMockPockets(t)
+			if !tt.noPockets {
+				pocketsMock.EXPECT().Pockets(mock.Anything, tt.args.accountID).Return(tt.pockets, tt.pocketsErr).Once()
+			}
+
+			accountService := NewAccountService(
+				connMock, accountStorageMock, logger, metricsMock, mocks.NewMockAuditor(t), mocks.NewMockOutbox(t), testIBANs(t),
+				mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), mocks.NewMockSanctions(t),
+				mocks.NewMockFees(t), mocks.NewMockProducts(t), allowHolders(t), pocketsMock, mocks.NewMockPayments(t))
+			got, err := accountService.GetAccount(tt.args.ctx, tt.args.accountID)
+
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_GetAccountByIBAN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		iban      string
+		mock      func(*storageMocks.MockAccountStore)
+		permitErr error
+		want      types.GetAccountResponse
+		wantErr   error
+	}{
+		{
+			name:    "failed when the iban is invalid",
+			iban:    "DE88370400440532013000",
+			mock:    func(*storageMocks.MockAccountStore) {},
+			wantErr: types.ErrInvalidIBAN,
+		},
+		{
+			name: "failed when account not found",
+			iban: "DE89370400440532013000",
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccountByIBAN(mock.Anything, mock.Anything).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name: "failed when the holder is not permitted to view the account",
+			iban: "DE89370400440532013000",
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccountByIBAN(mock.Anything, mock.Anything).
+					Return(storage.Account{AccountID: wantAccountID}, nil).Once()
+			},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name: "success when the iban is in print format",
+			iban: "DE89 3704 0044 0532 0130 00",
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccountByIBAN(mock.Anything,
+					pgtype.Text{String: "DE89370400440532013000", Valid: true}).Return(storage.Account{
+					AccountID:    wantAccountID,
+					Name:         "name",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+					IBAN:         pgtype.Text{String: "DE89370400440532013000", Valid: true},
+				}, nil).Once()
+				accountStorageMock.EXPECT().GetAccountTotalAmount(mock.Anything, wantAccountID).
+					Return(pgtype.Numeric{Int: big.NewInt(1050), Exp: -2, Valid: true}, nil).Once()
+			},
+			want: types.GetAccountResponse{
+				Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "name",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+					IBAN:         "DE89370400440532013000",
+				},
+				Balance:          1050,
+				AvailableBalance: 1050,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountStorageMock := storageMocks.NewMockAccountStore(t)
+			tt.mock(accountStorageMock)
+
+			holdersMock := mocks.NewMockHolders(t)
+			holdersMock.EXPECT().Authorize(mock.Anything, wantAccountID, holder.PermissionView).
+				Return(tt.permitErr).Maybe()
+
+			accountService := NewAccountService(storageMocks.NewMockDBConnection(t), accountStorageMock,
+				slog.Default(), mocks.NewMockMetrics(t), mocks.NewMockAuditor(t), mocks.NewMockOutbox(t), testIBANs(t),
+				mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), mocks.NewMockSanctions(t),
+				mocks.NewMockFees(t), mocks.NewMockProducts(t), holdersMock, mocks.NewMockPockets(t),
+				mocks.NewMockPayments(t))
+			got, err := accountService.GetAccountByIBAN(context.Background(), tt.iban)
+
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAccountService_ListTransactions(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx       context.Context
+		accountID uuid.UUID
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    func(*storageMocks.MockAccountStore, args)
+		want    types.ListTransactionsResponse
+		wantErr error
+	}{
+		{
+			name: "failed when account not found",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name: "failed when list transactions returns an error",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().ListTransactions(mock.Anything, mock.Anything).
+					Return(nil, errAnything).Once()
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "success when account has transactions",
+			args: args{
+				ctx:       context.Background(),
+				accountID: wantAccountID,
+			},
+			mock: func(accountStorageMock *storageMocks.MockAccountStore, a args) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, a.accountID).
+					Return(storage.Account{AccountID: a.accountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().ListTransactions(mock.Anything, storage.ListTransactionsParams{
+					AccountID: a.accountID,
+					Limit:     10,
+					Offset:    5,
+				}).Return([]storage.Transaction{{
+					TransactionID: wantTrnasactionID,
+					AccountID:     a.accountID,
+					Amount:        pgtype.Numeric{Int: big.NewInt(-200), Exp: -2, Valid: true},
+					CreatedAt:     pgtype.Timestamptz{Time: createdAt, Valid: true},
+				}}, nil).Once()
+			},
+			want: types.ListTransactionsResponse{
+				Transactions: []types.Transaction{{
+					ID:        wantTrnasactionID,
+					AccountID: wantAccountID,
+					Amount:    -200,
+					CreatedAt: createdAt,
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountStorageMock := storageMocks.NewMockAccountStore(t)
+			connMock := storageMocks.NewMockDBConnection(t)
+			logger := slog.Default()
+			metricsMock := mocks.NewMockMetrics(t)
+
+			tt.mock(accountStorageMock, tt.args)
+
+			accountService := NewAccountService(
+				connMock, accountStorageMock, logger, metricsMock, mocks.NewMockAuditor(t), mocks.NewMockOutbox(t), testIBANs(t),
+				mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), mocks.NewMockSanctions(t),
+				mocks.NewMockFees(t), mocks.NewMockProducts(t), allowHolders(t), mocks.NewMockPockets(t),
+				mocks.NewMockPayments(t))
+			got, err := accountService.ListTransactions(tt.args.ctx, tt.args.accountID, 10, 5)
+
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_ListTransactionsAfter(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	after := uuid.NullUUID{UUID: wantTrnasactionID, Valid: true}
+
+	tests := []struct {
+		name    string
+		after   uuid.NullUUID
+		mock    func(*storageMocks.MockAccountStore)
+		want    types.ListTransactionsResponse
+		wantErr error
+	}{
+		{
+			name:  "failed when account not found",
+			after: after,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name:  "failed when the transaction is not one of the account",
+			after: after,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().HasAccountTransaction(mock.Anything, storage.HasAccountTransactionParams{
+					AccountID:     wantAccountID,
+					TransactionID: wantTrnasactionID,
+				}).Return(false, nil).Once()
+			},
+			wantErr: ErrTransactionNotFound,
+		},
+		{
+			name:  "failed when list transactions returns an error",
+			after: after,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().HasAccountTransaction(mock.Anything, mock.Anything).Return(true, nil).Once()
+				accountStorageMock.EXPECT().ListTransactionsAfter(mock.Anything, mock.Anything).
+					Return(nil, errAnything).Once()
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name: "success from the first transaction",
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().ListTransactionsAfter(mock.Anything, storage.ListTransactionsAfterParams{
+					AccountID: wantAccountID,
+					Limit:     10,
+				}).Return([]storage.Transaction{{
+					TransactionID: wantTrnasactionID,
+					AccountID:     wantAccountID,
+					Amount:        pgtype.Numeric{Int: big.NewInt(200), Exp: -2, Valid: true},
+					CreatedAt:     pgtype.Timestamptz{Time: createdAt, Valid: true},
+				}}, nil).Once()
+			},
+			want: types.ListTransactionsResponse{
+				Transactions: []types.Transaction{{
+					ID:        wantTrnasactionID,
+					AccountID: wantAccountID,
+					Amount:    200,
+					CreatedAt: createdAt,
+				}},
+			},
+		},
+		{
+			name:  "success after a transaction",
+			after: after,
+			mock: func(accountStorageMock *storageMocks.MockAccountStore) {
+				accountStorageMock.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, nil).Once()
+				accountStorageMock.EXPECT().HasAccountTransaction(mock.Anything, mock.Anything).Return(true, nil).Once()
+				accountStorageMock.EXPECT().ListTransactionsAfter(mock.Anything, storage.ListTransactionsAfterParams{
+					AccountID: wantAccountID,
+					After:     after,
+					Limit:     10,
+				}).Return(nil, nil).Once()
+			},
+			want: types.ListTransactionsResponse{Transactions: []types.Transaction{}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountStorageMock := storageMocks.NewMockAccountStore(t)
+			tt.mock(accountStorageMock)
+
+			accountService := NewAccountService(storageMocks.NewMockDBConnection(t), accountStorageMock,
+				slog.Default(), mocks.NewMockMetrics(t), mocks.NewMockAuditor(t), mocks.NewMockOutbox(t), testIBANs(t),
+				mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), mocks.NewMockSanctions(t),
+				mocks.NewMockFees(t), mocks.NewMockProducts(t), allowHolders(t), mocks.NewMockPockets(t),
+				mocks.NewMockPayments(t))
+			got, err := accountService.ListTransactionsAfter(context.Background(), wantAccountID, tt.after, 10)
+
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAccountService_CreateAccount_auditFailure(t *testing.T) {
+	t.Parallel()
+
+	accountStorageMock := storageMocks.NewMockAccountStore(t)
+	connMock := storageMocks.NewMockDBConnection(t)
+	auditorMock := mocks.NewMockAuditor(t)
+	tx := txMocks.NewMockTx(t)
+
+	connMock.EXPECT().Begin(mock.Anything).Return(tx, nil).Twice()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Twice()
+
+	productsMock := mocks.NewMockProducts(t)
+	productsMock.EXPECT().CheckCurrency(mock.Anything, product.DefaultCode, "EUR").Return(nil).Once()
+
+	accountStorageMock.EXPECT().NextAccountNumber(mock.Anything).Return(1, nil).Once()
+	accountStorageMock.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+		Return(storage.Account{AccountID: wantAccountID}, nil).Once()
+	auditorMock.EXPECT().Record(mock.Anything, tx, mock.Anything).Return(errAnything).Twice()
+
+	sanctionsMock := mocks.NewMockSanctions(t)
+	sanctionsMock.EXPECT().Screen(mock.Anything, "").Return(clearScreening, nil).Once()
+	sanctionsMock.EXPECT().Record(mock.Anything, tx, wantAccountID, "", clearScreening).Return(nil).Once()
+
+	accountService := NewAccountService(
+		connMock, accountStorageMock, slog.Default(), mocks.NewMockMetrics(t), auditorMock, mocks.NewMockOutbox(t),
+		testIBANs(t), mocks.NewMockBeneficiaries(t), mocks.NewMockLimits(t), mocks.NewMockRisk(t), sanctionsMock,
+		mocks.NewMockFees(t), productsMock, allowHolders(t), mocks.NewMockPockets(t),
+		mocks.NewMockPayments(t))
+	accountService.storeWithTx = func(pgx.Tx) storage.AccountStore { return accountStorageMock }
+
+	got, err := accountService.CreateAccount(context.Background(), &types.CreateAccountRequest{CurrencyCode: "EUR"})
+
+	assert.Equal(t, types.CreateAccountResponse{}, got)
+	assert.ErrorIs(t, err, ErrInternal)
+}
+
+// testIBANs returns a generator of the IBANs of a german bank.
+func testIBANs(t *testing.T) *iban.Generator {
+	t.Helper()
+
+	g, err := iban.NewGenerator("DE", "37040044")
+	require.NoError(t, err)
+
+	return g
+}
+
+// allowHolders returns holders permitting everything, rejecting no customer and holding no transfer.
+func allowHolders(t *testing.T) *mocks.MockHolders {
+	t.Helper()
+
+	holdersMock := mocks.NewMockHolders(t)
+	holdersMock.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	holdersMock.EXPECT().CheckKYC(mock.Anything, mock.Anything).Return(nil).Maybe()
+	holdersMock.EXPECT().Hold(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+
+	return holdersMock
+}
+
+// linkPayments returns payments linking every transfer, which executes no payment.
+func linkPayments(t *testing.T) *mocks.MockPayments {
+	t.Helper()
+
+	paymentsMock := mocks.NewMockPayments(t)
+	paymentsMock.EXPECT().LinkTransfer(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	paymentsMock.EXPECT().LinkHold(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return paymentsMock
+}
+
+// expectAuditedTx returns a connection beginning transactions in which the auditor expects a single
+// event of the action, with the outcome of wantErr, and the outbox expects the events of eventTypes on success.
+func expectAuditedTx(
+	t *testing.T,
+	action string,
+	wantErr error,
+	eventTypes ...string,
+) (*storageMocks.MockDBConnection, *mocks.MockAuditor, *mocks.MockOutbox) {
+	t.Helper()
+
+	connMock := storageMocks.NewMockDBConnection(t)
+	auditorMock := mocks.NewMockAuditor(t)
+	tx := txMocks.NewMockTx(t)
+
+	connMock.EXPECT().Begin(mock.Anything).Return(tx, nil)
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Maybe()
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Maybe()
+
+	auditorMock.EXPECT().Record(mock.Anything, tx, mock.MatchedBy(func(event audit.Event) bool {
+		return event.Action == action && event.Outcome == audit.Outcome(wantErr)
+	})).Return(nil).Once()
+
+	outboxMock := mocks.NewMockOutbox(t)
+
+	for _, eventType := range eventTypes {
+		if wantErr == nil {
+			outboxMock.EXPECT().Add(mock.Anything, tx, mock.MatchedBy(func(event outbox.Event) bool {
+				return event.Type == eventType
+			})).Return(nil).Once()
+		}
+	}
+
+	return connMock, auditorMock, outboxMock
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listAuditEventsRoute  = "GET /admin/audit"
+	verifyAuditChainRoute = "GET /admin/audit/verify"
+
+	queryAccountID = "accountId"
+	queryPrincipal = "principal"
+	queryAction    = "action"
+	queryFrom      = "from"
+	queryTo        = "to"
+)
+
+var errInvalidAuditFilter = errors.New("accountId must be a uuid, from and to must be RFC 3339 date-times")
+
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, filter audit.Filter) (types.ListAuditEventsResponse, error)
+	VerifyAuditChain(ctx context.Context) (types.VerifyAuditChainResponse, error)
+}
+
+type AuditHandler struct {
+	service AuditService
+}
+
+// NewAuditHandler returns a new AuditHandler.
+func NewAuditHandler(service AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *AuditHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *AuditHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listAuditEventsRoute:  requireAdmin(h.listAuditEvents),
+		verifyAuditChainRoute: requireAdmin(h.verifyAuditChain),
+	}
+}
+
+func (h *AuditHandler) listAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := auditFilter(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListAuditEvents(ctx, filter)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *AuditHandler) verifyAuditChain(w http.ResponseWriter, r *http.Request) {
+	res, err := h.service.VerifyAuditChain(r.Context())
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// auditFilter parses the query parameters filtering audit events.
+func auditFilter(r *http.Request) (audit.Filter, error) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		return audit.Filter{}, err
+	}
+
+	query := r.URL.Query()
+
+	filter := audit.Filter{
+		Principal: query.Get(queryPrincipal),
+		Action:    query.Get(queryAction),
+		Limit:     limit,
+		Offset:    offset,
+	}
+
+	if v := query.Get(queryAccountID); v != "" {
+		if filter.AccountID.UUID, err = uuid.Parse(v); err != nil {
+			return audit.Filter{}, errInvalidAuditFilter
+		}
+
+		filter.AccountID.Valid = true
+	}
+
+	if filter.From, err = parseTime(query.Get(queryFrom)); err != nil {
+		return audit.Filter{}, errInvalidAuditFilter
+	}
+
+	if filter.To, err = parseTime(query.Get(queryTo)); err != nil {
+		return audit.Filter{}, errInvalidAuditFilter
+	}
+
+	return filter, nil
+}
+
+// parseTime parses an RFC 3339 date-time, an empty string is the zero time.
+func parseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, errInvalidAuditFilter
+	}
+
+	return t, nil
+}
+
+// requireAdmin only lets requests made by the admin through, see audit.Identifier.
+func requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !audit.ActorFromContext(r.Context()).Admin {
+			handleError(w, types.ErrForbidden, http.StatusForbidden)
+
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestNewAuditHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewAuditHandler(mocks.NewMockAuditService(t))
+	assert.NotNil(t, got)
+}
+
+func TestAuditHandler_listAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		query          string
+		admin          bool
+		mock           func(*mocks.MockAuditService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when not made by the admin",
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "failed when account id is invalid",
+			query:          "?accountId=one",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"accountId must be a uuid, from and to must be RFC 3339 date-times"}
+`,
+		},
+		{
+			name:           "failed when from is invalid",
+			query:          "?from=2024-05-01",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"accountId must be a uuid, from and to must be RFC 3339 date-times"}
+`,
+		},
+		{
+			name:           "failed when limit is too large",
+			query:          "?limit=101",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit must be between 1 and 100 and offset must not be negative"}
+`,
+		},
+		{
+			name:  "failed when list audit events returns an error",
+			admin: true,
+			mock: func(mas *mocks.MockAuditService) {
+				mas.EXPECT().ListAuditEvents(mock.Anything, mock.Anything).
+					Return(types.ListAuditEventsResponse{}, ErrInternal).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			want: `{"message":"internal server error","code":"INTERNAL"}
+`,
+		},
+		{
+			name: "success with filter",
+			query: "?accountId=" + wantAccountID.String() + "&principal=alice&action=account.transfer" +
+				"&from=2024-05-01T00:00:00Z&to=2024-05-02T00:00:00Z&limit=1&offset=2",
+			admin: true,
+			mock: func(mas *mocks.MockAuditService) {
+				mas.EXPECT().ListAuditEvents(mock.Anything, audit.Filter{
+					AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+					Principal: "alice",
+					Action:    audit.ActionTransferMoney,
+					From:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					To:        time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+					Limit:     1,
+					Offset:    2,
+				}).Return(types.ListAuditEventsResponse{Events: []types.AuditEvent{}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"events":[]}
+`,
+		},
+	}
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/admin/audit"+tt.query, nil)
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			auditServiceMock := mocks.NewMockAuditService(t)
+
+			if tt.mock != nil {
+				tt.mock(auditServiceMock)
+			}
+
+			NewAuditHandler(auditServiceMock).routes()[listAuditEventsRoute](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestAuditHandler_verifyAuditChain(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/admin/audit/verify", nil)
+	r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+
+	w := httptest.NewRecorder()
+
+	auditServiceMock := mocks.NewMockAuditService(t)
+	auditServiceMock.EXPECT().VerifyAuditChain(mock.Anything).
+		Return(types.VerifyAuditChainResponse{Valid: true, Checked: 3}, nil).Once()
+
+	NewAuditHandler(auditServiceMock).routes()[verifyAuditChainRoute](w, r)
+
+	res := w.Result()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"valid\":true,\"checked\":3}\n", string(got))
+}
+
+func auditContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "list audit events",
+			method:         http.MethodGet,
+			path:           "/admin/audit?accountId=" + wantAccountID.String() + "&from=2024-05-01T00:00:00Z",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: auditContract(func(mas *mocks.MockAuditService) {
+				mas.EXPECT().ListAuditEvents(mock.Anything, mock.Anything).Return(types.ListAuditEventsResponse{
+					Events: []types.AuditEvent{{
+						ID:         1,
+						OccurredAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+						Principal:  "anonymous",
+						Action:     audit.ActionCreateAccount,
+						AccountID:  uuid.NullUUID{UUID: wantAccountID, Valid: true},
+						RequestID:  "request",
+						ClientIP:   "192.0.2.1",
+						Outcome:    audit.OutcomeSuccess,
+						After:      []byte(`{"id":"12345678-1234-1234-1234-123456789001"}`),
+						PrevHash:   "00",
+						Hash:       "01",
+					}},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "list audit events forbidden",
+			method:         http.MethodGet,
+			path:           "/admin/audit",
+			wantStatusCode: http.StatusForbidden,
+			handler:        auditContract(nil),
+		},
+		{
+			name:           "list audit events rejected by the contract",
+			method:         http.MethodGet,
+			path:           "/admin/audit?from=yesterday",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        auditContract(nil),
+		},
+		{
+			name:           "verify audit chain",
+			method:         http.MethodGet,
+			path:           "/admin/audit/verify",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: auditContract(func(mas *mocks.MockAuditService) {
+				brokenAt := int64(2)
+
+				mas.EXPECT().VerifyAuditChain(mock.Anything).
+					Return(types.VerifyAuditChainResponse{Checked: 2, BrokenAt: &brokenAt}, nil).Once()
+			}),
+		},
+	}
+}
+
+// auditContract returns the audit handler of a contract test, its service mocked with expectations.
+func auditContract(expectations func(*mocks.MockAuditService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewAuditHandler(expect(mocks.NewMockAuditService(t), expectations))
+	}
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	createBeneficiaryRoute = "POST /accounts/{id}/beneficiaries"
+	listBeneficiariesRoute = "GET /accounts/{id}/beneficiaries"
+	getBeneficiaryRoute    = "GET /accounts/{id}/beneficiaries/{beneficiaryId}"
+	updateBeneficiaryRoute = "PUT /accounts/{id}/beneficiaries/{beneficiaryId}"
+	deleteBeneficiaryRoute = "DELETE /accounts/{id}/beneficiaries/{beneficiaryId}"
+
+	pathValueBeneficiaryID = "beneficiaryId"
+)
+
+type BeneficiaryService interface {
+	CreateBeneficiary(
+		ctx context.Context, accountID uuid.UUID, req *types.CreateBeneficiaryRequest,
+	) (types.CreateBeneficiaryResponse, error)
+	ListBeneficiaries(ctx context.Context, accountID uuid.UUID) (types.ListBeneficiariesResponse, error)
+	GetBeneficiary(ctx context.Context, accountID, beneficiaryID uuid.UUID) (types.GetBeneficiaryResponse, error)
+	UpdateBeneficiary(
+		ctx context.Context, accountID, beneficiaryID uuid.UUID, req *types.UpdateBeneficiaryRequest,
+	) (types.UpdateBeneficiaryResponse, error)
+	DeleteBeneficiary(ctx context.Context, accountID, beneficiaryID uuid.UUID) error
+}
+
+type BeneficiaryHandler struct {
+	service BeneficiaryService
+}
+
+// NewBeneficiaryHandler returns a new BeneficiaryHandler.
+func NewBeneficiaryHandler(service BeneficiaryService) *BeneficiaryHandler {
+	return &BeneficiaryHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *BeneficiaryHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *BeneficiaryHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		createBeneficiaryRoute: h.createBeneficiary,
+		listBeneficiariesRoute: h.listBeneficiaries,
+		getBeneficiaryRoute:    h.getBeneficiary,
+		updateBeneficiaryRoute: h.updateBeneficiary,
+		deleteBeneficiaryRoute: h.deleteBeneficiary,
+	}
+}
+
+func (h *BeneficiaryHandler) createBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.CreateBeneficiaryRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.CreateBeneficiary(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *BeneficiaryHandler) listBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListBeneficiaries(ctx, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *BeneficiaryHandler) getBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, beneficiaryID, err := beneficiaryPath(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.GetBeneficiary(ctx, accountID, beneficiaryID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *BeneficiaryHandler) updateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.UpdateBeneficiaryRequest{}
+
+	accountID, beneficiaryID, err := beneficiaryPath(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.UpdateBeneficiary(ctx, accountID, beneficiaryID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *BeneficiaryHandler) deleteBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, beneficiaryID, err := beneficiaryPath(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.DeleteBeneficiary(ctx, accountID, beneficiaryID); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// beneficiaryPath parses the account and beneficiary IDs of the path.
+func beneficiaryPath(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	beneficiaryID, err := uuid.Parse(r.PathValue(pathValueBeneficiaryID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return accountID, beneficiaryID, nil
+}
@@ -0,0 +1,401 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantBeneficiaryID = uuid.MustParse("12345678-1234-1234-1234-123456789050")
+
+func testBeneficiary() types.Beneficiary {
+	return types.Beneficiary{
+		ID:               wantBeneficiaryID,
+		AccountID:        wantAccountID,
+		Nickname:         "rent",
+		ReciverAccountID: wantReciverAccountID,
+		ReciverIBAN:      "DE89370400440532013000",
+		TransferLimit:    100000,
+		CoolingOffEndsAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		CreatedAt:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const wantBeneficiary = `{"id":"12345678-1234-1234-1234-123456789050",` +
+	`"accountId":"12345678-1234-1234-1234-123456789001","nickname":"rent",` +
+	`"reciverAccountId":"12345678-1234-1234-1234-123456789003","reciverIban":"DE89370400440532013000",` +
+	`"transferLimit":100000,"coolingOffEndsAt":"2024-05-02T10:00:00Z","createdAt":"2024-05-01T10:00:00Z",` +
+	`"updatedAt":"2024-05-01T10:00:00Z"}`
+
+func TestNewBeneficiaryHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewBeneficiaryHandler(mocks.NewMockBeneficiaryService(t))
+	assert.NotNil(t, got)
+}
+
+func TestBeneficiaryHandler_createBeneficiary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		accountID      string
+		body           types.CreateBeneficiaryRequest
+		mock           func(*mocks.MockBeneficiaryService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when account id is invalid",
+			accountID:      "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "failed when nickname is missing",
+			accountID: wantAccountID.String(),
+			body: types.CreateBeneficiaryRequest{
+				ReciverAccountID: wantReciverAccountID,
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"nickname":{"required":"nickname is required to not be empty"}}`,
+		},
+		{
+			name:      "failed when receiver iban is invalid",
+			accountID: wantAccountID.String(),
+			body: types.CreateBeneficiaryRequest{
+				Nickname:    "rent",
+				ReciverIBAN: "DE00370400440532013000",
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"reciverIban":{"iban":"reciverIban must be a valid iban"}}`,
+		},
+		{
+			name:      "failed when transfer limit is negative",
+			accountID: wantAccountID.String(),
+			body: types.CreateBeneficiaryRequest{
+				Nickname:         "rent",
+				ReciverAccountID: wantReciverAccountID,
+				TransferLimit:    -1,
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"transferLimit":{"money_limit":"transferLimit field did not pass validation"}}`,
+		},
+		{
+			name:      "failed when beneficiary already exists",
+			accountID: wantAccountID.String(),
+			body: types.CreateBeneficiaryRequest{
+				Nickname:         "rent",
+				ReciverAccountID: wantReciverAccountID,
+			},
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().CreateBeneficiary(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.CreateBeneficiaryResponse{}, types.ErrBeneficiaryAlreadyExist).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"a beneficiary of the same receiver account already exists",` +
+				`"code":"BENEFICIARY_ALREADY_EXISTS"}
+`,
+		},
+		{
+			name:      "success when creating a beneficiary",
+			accountID: wantAccountID.String(),
+			body: types.CreateBeneficiaryRequest{
+				Nickname:      "rent",
+				ReciverIBAN:   "DE89370400440532013000",
+				TransferLimit: 100000,
+			},
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().CreateBeneficiary(mock.Anything, wantAccountID, &types.CreateBeneficiaryRequest{
+					Nickname:      "rent",
+					ReciverIBAN:   "DE89370400440532013000",
+					TransferLimit: 100000,
+				}).Return(types.CreateBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantBeneficiary + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(tt.body)
+			assert.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/accounts/"+tt.accountID+"/beneficiaries", bytes.NewReader(body))
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			w := httptest.NewRecorder()
+
+			beneficiaryServiceMock := mocks.NewMockBeneficiaryService(t)
+
+			if tt.mock != nil {
+				tt.mock(beneficiaryServiceMock)
+			}
+
+			NewBeneficiaryHandler(beneficiaryServiceMock).createBeneficiary(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestBeneficiaryHandler_beneficiary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		accountID      string
+		beneficiaryID  string
+		body           string
+		mock           func(*mocks.MockBeneficiaryService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:      "list success",
+			route:     listBeneficiariesRoute,
+			accountID: wantAccountID.String(),
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().ListBeneficiaries(mock.Anything, wantAccountID).Return(types.ListBeneficiariesResponse{
+					Beneficiaries: []types.Beneficiary{testBeneficiary()},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           `{"beneficiaries":[` + wantBeneficiary + "]}\n",
+		},
+		{
+			name:           "get failed when beneficiary id is invalid",
+			route:          getBeneficiaryRoute,
+			accountID:      wantAccountID.String(),
+			beneficiaryID:  "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:          "get failed when beneficiary not found",
+			route:         getBeneficiaryRoute,
+			accountID:     wantAccountID.String(),
+			beneficiaryID: wantBeneficiaryID.String(),
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().GetBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).
+					Return(types.GetBeneficiaryResponse{}, types.ErrBeneficiaryNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"beneficiary not found","code":"BENEFICIARY_NOT_FOUND"}
+`,
+		},
+		{
+			name:          "get success",
+			route:         getBeneficiaryRoute,
+			accountID:     wantAccountID.String(),
+			beneficiaryID: wantBeneficiaryID.String(),
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().GetBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).
+					Return(types.GetBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantBeneficiary + "\n",
+		},
+		{
+			name:           "update failed when nickname is too long",
+			route:          updateBeneficiaryRoute,
+			accountID:      wantAccountID.String(),
+			beneficiaryID:  wantBeneficiaryID.String(),
+			body:           `{"nickname":"` + strings.Repeat("n", 256) + `"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"nickname":{"maxLen":"nickname max length is 255"}}`,
+		},
+		{
+			name:          "update success",
+			route:         updateBeneficiaryRoute,
+			accountID:     wantAccountID.String(),
+			beneficiaryID: wantBeneficiaryID.String(),
+			body:          `{"nickname":"rent","transferLimit":100000}`,
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().UpdateBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID,
+					&types.UpdateBeneficiaryRequest{Nickname: "rent", TransferLimit: 100000}).
+					Return(types.UpdateBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantBeneficiary + "\n",
+		},
+		{
+			name:          "delete failed when beneficiary not found",
+			route:         deleteBeneficiaryRoute,
+			accountID:     wantAccountID.String(),
+			beneficiaryID: wantBeneficiaryID.String(),
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().DeleteBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).
+					Return(types.ErrBeneficiaryNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"beneficiary not found","code":"BENEFICIARY_NOT_FOUND"}
+`,
+		},
+		{
+			name:          "delete success",
+			route:         deleteBeneficiaryRoute,
+			accountID:     wantAccountID.String(),
+			beneficiaryID: wantBeneficiaryID.String(),
+			mock: func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().DeleteBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.accountID)
+			r.SetPathValue(pathValueBeneficiaryID, tt.beneficiaryID)
+
+			w := httptest.NewRecorder()
+
+			beneficiaryServiceMock := mocks.NewMockBeneficiaryService(t)
+
+			if tt.mock != nil {
+				tt.mock(beneficiaryServiceMock)
+			}
+
+			NewBeneficiaryHandler(beneficiaryServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func beneficiaryContractTests() []contractTest {
+	beneficiaryPath := "/accounts/" + wantAccountID.String() + "/beneficiaries/" + wantBeneficiaryID.String()
+
+	return []contractTest{
+		{
+			name:           "create beneficiary",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/beneficiaries",
+			body:           `{"nickname":"rent","reciverIban":"DE89 3704 0044 0532 0130 00","transferLimit":100000}`,
+			wantStatusCode: http.StatusOK,
+			handler: beneficiaryContract(func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().CreateBeneficiary(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.CreateBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			}),
+		},
+		{
+			name:           "create beneficiary rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/beneficiaries",
+			body:           `{"nickname":"rent","reciverAccountId":"` + wantReciverAccountID.String() + `","transferLimit":-1}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        beneficiaryContract(nil),
+		},
+		{
+			name:           "list beneficiaries",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/beneficiaries",
+			wantStatusCode: http.StatusOK,
+			handler: beneficiaryContract(func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().ListBeneficiaries(mock.Anything, wantAccountID).Return(types.ListBeneficiariesResponse{
+					Beneficiaries: []types.Beneficiary{testBeneficiary()},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "get beneficiary",
+			method:         http.MethodGet,
+			path:           beneficiaryPath,
+			wantStatusCode: http.StatusOK,
+			handler: beneficiaryContract(func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().GetBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).
+					Return(types.GetBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			}),
+		},
+		{
+			name:           "update beneficiary",
+			method:         http.MethodPut,
+			path:           beneficiaryPath,
+			body:           `{"nickname":"rent"}`,
+			wantStatusCode: http.StatusOK,
+			handler: beneficiaryContract(func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().UpdateBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID, mock.Anything).
+					Return(types.UpdateBeneficiaryResponse{Beneficiary: testBeneficiary()}, nil).Once()
+			}),
+		},
+		{
+			name:           "update beneficiary rejected by the contract",
+			method:         http.MethodPut,
+			path:           beneficiaryPath,
+			body:           `{"nickname":"rent","reciverAccountId":"` + wantReciverAccountID.String() + `"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        beneficiaryContract(nil),
+		},
+		{
+			name:           "delete beneficiary",
+			method:         http.MethodDelete,
+			path:           beneficiaryPath,
+			wantStatusCode: http.StatusNoContent,
+			handler: beneficiaryContract(func(mbs *mocks.MockBeneficiaryService) {
+				mbs.EXPECT().DeleteBeneficiary(mock.Anything, wantAccountID, wantBeneficiaryID).Return(nil).Once()
+			}),
+		},
+		{
+			name:           "transfer money to a beneficiary",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions/transfer",
+			body:           `{"beneficiaryId":"` + wantBeneficiaryID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, &types.TransferMoneyRequest{
+					BeneficiaryID: uuid.NullUUID{UUID: wantBeneficiaryID, Valid: true},
+					Amount:        100,
+				}, wantAccountID).Return(types.TransferMoneyResponse{TransactionID: wantTrnasactionID}, nil).Once()
+			}),
+		},
+	}
+}
+
+// beneficiaryContract returns the beneficiary handler of a contract test, its service mocked with expectations.
+func beneficiaryContract(expectations func(*mocks.MockBeneficiaryService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewBeneficiaryHandler(expect(mocks.NewMockBeneficiaryService(t), expectations))
+	}
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	createCustomerRoute        = "POST /customers"
+	createCustomerAccountRoute = "POST /customers/{id}/accounts"
+	listCustomerAccountsRoute  = "GET /customers/{id}/accounts"
+	setKYCStatusRoute          = "PUT /admin/customers/{id}/kyc-status"
+)
+
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, req *types.CreateCustomerRequest) (types.CreateCustomerResponse, error)
+	CreateAccount(
+		ctx context.Context, customerID uuid.UUID, req *types.CreateCustomerAccountRequest,
+	) (types.CreateAccountResponse, error)
+	ListAccounts(ctx context.Context, customerID uuid.UUID) (types.ListCustomerAccountsResponse, error)
+	SetKYCStatus(
+		ctx context.Context, customerID uuid.UUID, req *types.SetKYCStatusRequest,
+	) (types.SetKYCStatusResponse, error)
+}
+
+type CustomerHandler struct {
+	service CustomerService
+}
+
+// NewCustomerHandler returns a new CustomerHandler.
+func NewCustomerHandler(service CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *CustomerHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *CustomerHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		createCustomerRoute:        h.createCustomer,
+		createCustomerAccountRoute: h.createCustomerAccount,
+		listCustomerAccountsRoute:  h.listCustomerAccounts,
+		setKYCStatusRoute:          requireAdmin(h.setKYCStatus),
+	}
+}
+
+func (h *CustomerHandler) createCustomer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.CreateCustomerRequest{}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.CreateCustomer(ctx, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *CustomerHandler) createCustomerAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.CreateCustomerAccountRequest{}
+
+	customerID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.CreateAccount(ctx, customerID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *CustomerHandler) listCustomerAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	customerID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListAccounts(ctx, customerID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *CustomerHandler) setKYCStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetKYCStatusRequest{}
+
+	customerID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetKYCStatus(ctx, customerID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantCustomerID = uuid.MustParse("12345678-1234-1234-1234-123456789010")
+	wantCustomer   = types.Customer{
+		ID:        wantCustomerID,
+		Name:      "John Doe",
+		Email:     "john@example.com",
+		KYCStatus: types.KYCStatusPending,
+		CreatedAt: time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	}
+)
+
+const wantCustomerJSON = `{"id":"12345678-1234-1234-1234-123456789010","name":"John Doe","email":"john@example.com",` +
+	`"kycStatus":"pending","createdAt":"2024-05-17T10:00:00Z","updatedAt":"2024-05-17T10:00:00Z"}`
+
+func TestNewCustomerHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewCustomerHandler(mocks.NewMockCustomerService(t))
+	assert.NotNil(t, got)
+}
+
+func TestCustomerHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		customerID     string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockCustomerService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "create customer failed when email is invalid",
+			route:          createCustomerRoute,
+			body:           `{"name":"John Doe","email":"john"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"email":{"email":"email value is an invalid email address"}}`,
+		},
+		{
+			name:  "create customer failed when email is taken",
+			route: createCustomerRoute,
+			body:  `{"name":"John Doe","email":"john@example.com"}`,
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateCustomer(mock.Anything, &types.CreateCustomerRequest{
+					Name:  "John Doe",
+					Email: "john@example.com",
+				}).Return(types.CreateCustomerResponse{}, types.ErrCustomerAlreadyExist).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"a customer with the same email already exists","code":"CUSTOMER_ALREADY_EXISTS"}
+`,
+		},
+		{
+			name:  "create customer success",
+			route: createCustomerRoute,
+			body:  `{"name":"John Doe","email":"john@example.com"}`,
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateCustomer(mock.Anything, &types.CreateCustomerRequest{
+					Name:  "John Doe",
+					Email: "john@example.com",
+				}).Return(types.CreateCustomerResponse{Customer: wantCustomer}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantCustomerJSON + `
+`,
+		},
+		{
+			name:           "create customer account failed when customer id is invalid",
+			route:          createCustomerAccountRoute,
+			customerID:     "invalid",
+			body:           `{"currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 7"}
+`,
+		},
+		{
+			name:           "create customer account failed when currency code is invalid",
+			route:          createCustomerAccountRoute,
+			customerID:     wantCustomerID.String(),
+			body:           `{"currencyCode":"euro"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"currencyCode":{"currency_code":"currencyCode must be a currency code"}}`,
+		},
+		{
+			name:       "create customer account failed when customer not found",
+			route:      createCustomerAccountRoute,
+			customerID: wantCustomerID.String(),
+			body:       `{"currencyCode":"EUR"}`,
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateAccount(mock.Anything, wantCustomerID, &types.CreateCustomerAccountRequest{
+					CurrencyCode: "EUR",
+				}).Return(types.CreateAccountResponse{}, types.ErrCustomerNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"customer not found","code":"CUSTOMER_NOT_FOUND"}
+`,
+		},
+		{
+			name:       "create customer account success",
+			route:      createCustomerAccountRoute,
+			customerID: wantCustomerID.String(),
+			body:       `{"name":"Holidays","currencyCode":"EUR","productCode":"savings"}`,
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateAccount(mock.Anything, wantCustomerID, &types.CreateCustomerAccountRequest{
+					Name:         "Holidays",
+					CurrencyCode: "EUR",
+					ProductCode:  "savings",
+				}).Return(types.CreateAccountResponse{Account: types.Account{
+					ID:           wantAccountID,
+					Name:         "Holidays",
+					Email:        "john@example.com",
+					CurrencyCode: "EUR",
+					ProductCode:  "savings",
+				}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"` + wantAccountID.String() + `","name":"Holidays","email":"john@example.com",` +
+				`"currencyCode":"EUR","productCode":"savings"}
+`,
+		},
+		{
+			name:       "list customer accounts failed",
+			route:      listCustomerAccountsRoute,
+			customerID: wantCustomerID.String(),
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().ListAccounts(mock.Anything, wantCustomerID).
+					Return(types.ListCustomerAccountsResponse{}, types.ErrInternal).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			want: `{"message":"internal server error","code":"INTERNAL"}
+`,
+		},
+		{
+			name:       "list customer accounts success",
+			route:      listCustomerAccountsRoute,
+			customerID: wantCustomerID.String(),
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().ListAccounts(mock.Anything, wantCustomerID).
+					Return(types.ListCustomerAccountsResponse{Accounts: []types.GetAccountResponse{{
+						Account: types.Account{
+							ID:           wantAccountID,
+							Name:         "John Doe",
+							Email:        "john@example.com",
+							CurrencyCode: "EUR",
+						},
+						Balance:          1000,
+						AvailableBalance: 1000,
+					}}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"accounts":[{"id":"` + wantAccountID.String() + `","name":"John Doe","email":"john@example.com",` +
+				`"currencyCode":"EUR","balance":1000,"availableBalance":1000}]}
+`,
+		},
+		{
+			name:           "set kyc status failed when not made by the admin",
+			route:          setKYCStatusRoute,
+			customerID:     wantCustomerID.String(),
+			body:           `{"status":"verified"}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "set kyc status failed when status is unknown",
+			route:          setKYCStatusRoute,
+			customerID:     wantCustomerID.String(),
+			body:           `{"status":"approved"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"status":{"in":"status value must be in the enum [pending verified rejected]"}}`,
+		},
+		{
+			name:       "set kyc status success",
+			route:      setKYCStatusRoute,
+			customerID: wantCustomerID.String(),
+			body:       `{"status":"pending"}`,
+			admin:      true,
+			mock: func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().SetKYCStatus(mock.Anything, wantCustomerID, &types.SetKYCStatusRequest{
+					Status: types.KYCStatusPending,
+				}).Return(types.SetKYCStatusResponse{Customer: wantCustomer}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantCustomerJSON + `
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/customers", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.customerID)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			customerServiceMock := mocks.NewMockCustomerService(t)
+
+			if tt.mock != nil {
+				tt.mock(customerServiceMock)
+			}
+
+			NewCustomerHandler(customerServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func customerContractTests() []contractTest {
+	customerPath := "/customers/" + wantCustomerID.String()
+
+	return []contractTest{
+		{
+			name:           "create customer",
+			method:         http.MethodPost,
+			path:           "/customers",
+			body:           `{"name":"John Doe","email":"john@example.com","phone":"+4930123456"}`,
+			wantStatusCode: http.StatusOK,
+			handler: customerContract(func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateCustomer(mock.Anything, mock.Anything).
+					Return(types.CreateCustomerResponse{Customer: wantCustomer}, nil).Once()
+			}),
+		},
+		{
+			name:           "create customer rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/customers",
+			body:           `{"name":"John Doe"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        customerContract(nil),
+		},
+		{
+			name:           "create customer account",
+			method:         http.MethodPost,
+			path:           customerPath + "/accounts",
+			body:           `{"currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusOK,
+			handler: customerContract(func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().CreateAccount(mock.Anything, wantCustomerID, mock.Anything).
+					Return(types.CreateAccountResponse{Account: types.Account{
+						ID: wantAccountID, Name: "John Doe", Email: "john@example.com", CurrencyCode: "EUR",
+					}}, nil).Once()
+			}),
+		},
+		{
+			name:           "list customer accounts",
+			method:         http.MethodGet,
+			path:           customerPath + "/accounts",
+			wantStatusCode: http.StatusOK,
+			handler: customerContract(func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().ListAccounts(mock.Anything, wantCustomerID).
+					Return(types.ListCustomerAccountsResponse{Accounts: []types.GetAccountResponse{{
+						Account: types.Account{
+							ID: wantAccountID, Name: "John Doe", Email: "john@example.com", CurrencyCode: "EUR",
+						},
+						Balance:          1000,
+						AvailableBalance: 1000,
+					}}}, nil).Once()
+			}),
+		},
+		{
+			name:           "set kyc status",
+			method:         http.MethodPut,
+			path:           "/admin" + customerPath + "/kyc-status",
+			body:           `{"status":"verified"}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: customerContract(func(mcs *mocks.MockCustomerService) {
+				mcs.EXPECT().SetKYCStatus(mock.Anything, wantCustomerID, mock.Anything).
+					Return(types.SetKYCStatusResponse{Customer: wantCustomer}, nil).Once()
+			}),
+		},
+	}
+}
+
+// customerContract returns the customer handler of a contract test, its service mocked with expectations.
+func customerContract(expectations func(*mocks.MockCustomerService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewCustomerHandler(expect(mocks.NewMockCustomerService(t), expectations))
+	}
+}
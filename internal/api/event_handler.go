@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	server "github.com/zaidsasa/xbankapi/internal/http"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	accountEventsRoute = "GET /accounts/{id}/events"
+
+	headerLastEventID = "Last-Event-ID"
+
+	eventTransaction = "transaction"
+	eventBalance     = "balance"
+
+	defaultHeartbeatInterval = 15 * time.Second
+	eventBatchSize           = 100
+)
+
+// Subscriber subscribes to the activity of accounts.
+type Subscriber interface {
+	Subscribe(accountID uuid.UUID) (<-chan struct{}, func())
+}
+
+type EventHandler struct {
+	service           AccountService
+	subscriber        Subscriber
+	heartbeatInterval time.Duration
+}
+
+// NewEventHandler returns a new EventHandler.
+func NewEventHandler(service AccountService, subscriber Subscriber) *EventHandler {
+	return &EventHandler{
+		service:           service,
+		subscriber:        subscriber,
+		heartbeatInterval: defaultHeartbeatInterval,
+	}
+}
+
+// Register routes.
+func (h *EventHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *EventHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		accountEventsRoute: h.accountEvents,
+	}
+}
+
+// accountEvents streams the transactions of an account and its balance as server-sent events. The stream starts
+// after the transaction of the Last-Event-ID header, or after the latest transaction without it, and ends when the
+// client disconnects or the server shuts down.
+func (h *EventHandler) accountEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	// Subscribe before reading the history, so that no transaction is missed in between.
+	activity, unsubscribe := h.subscriber.Subscribe(accountID)
+	defer unsubscribe()
+
+	stream, transactions, err := h.newAccountEventStream(ctx, w, r, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	// Errors end the stream, clients reconnect and resume from the last event received.
+	err = stream.send(ctx, transactions)
+
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-server.ShuttingDown(ctx):
+			return
+		case <-heartbeat.C:
+			err = stream.heartbeat()
+		case <-activity:
+			err = stream.catchUp(ctx)
+		}
+	}
+}
+
+// newAccountEventStream starts the stream, returning the first transactions to send. It returns an error, without
+// writing to w, when the account or the transaction of Last-Event-ID is not found.
+func (h *EventHandler) newAccountEventStream(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	accountID uuid.UUID,
+) (*accountEventStream, []types.Transaction, error) {
+	after, err := h.lastEventID(ctx, r, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := h.service.ListTransactionsAfter(ctx, accountID, after, eventBatchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	stream := &accountEventStream{
+		w:         w,
+		rc:        http.NewResponseController(w),
+		service:   h.service,
+		accountID: accountID,
+		after:     after,
+	}
+
+	return stream, res.Transactions, nil
+}
+
+// lastEventID returns the transaction the stream starts after: the one of the Last-Event-ID header, or the latest
+// transaction of the account without it, if any.
+func (h *EventHandler) lastEventID(ctx context.Context, r *http.Request, accountID uuid.UUID) (uuid.NullUUID, error) {
+	if v := r.Header.Get(headerLastEventID); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return uuid.NullUUID{}, fmt.Errorf("invalid %s: %w", headerLastEventID, err)
+		}
+
+		return uuid.NullUUID{UUID: id, Valid: true}, nil
+	}
+
+	res, err := h.service.ListTransactions(ctx, accountID, 1, 0)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	if len(res.Transactions) == 0 {
+		return uuid.NullUUID{}, nil
+	}
+
+	return uuid.NullUUID{UUID: res.Transactions[0].ID, Valid: true}, nil
+}
+
+// accountEventStream sends the events of an account, following the format of server-sent events.
+type accountEventStream struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	service   AccountService
+	accountID uuid.UUID
+	after     uuid.NullUUID
+}
+
+// catchUp sends the transactions made since the last one sent, then the balance if any was.
+func (s *accountEventStream) catchUp(ctx context.Context) error {
+	res, err := s.service.ListTransactionsAfter(ctx, s.accountID, s.after, eventBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	if len(res.Transactions) == 0 {
+		return nil
+	}
+
+	return s.send(ctx, res.Transactions)
+}
+
+// send sends the transactions, the pages after them, then the balance of the account.
+func (s *accountEventStream) send(ctx context.Context, transactions []types.Transaction) error {
+	for {
+		for _, t := range transactions {
+			if err := s.event(eventTransaction, t.ID.String(), t); err != nil {
+				return err
+			}
+
+			s.after = uuid.NullUUID{UUID: t.ID, Valid: true}
+		}
+
+		if len(transactions) < eventBatchSize {
+			break
+		}
+
+		res, err := s.service.ListTransactionsAfter(ctx, s.accountID, s.after, eventBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		transactions = res.Transactions
+	}
+
+	balance, err := s.service.GetAccount(ctx, s.accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// The balance has no ID, so that clients resume from the last transaction.
+	return s.event(eventBalance, "", balance)
+}
+
+func (s *accountEventStream) event(event, id string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return s.flush()
+}
+
+// heartbeat sends a comment, which keeps proxies from closing an idle stream.
+func (s *accountEventStream) heartbeat() error {
+	if _, err := fmt.Fprint(s.w, ": heartbeat\n\n"); err != nil {
+		return fmt.Errorf("failed to write heartbeat: %w", err)
+	}
+
+	return s.flush()
+}
+
+func (s *accountEventStream) flush() error {
+	if err := s.rc.Flush(); err != nil {
+		return fmt.Errorf("failed to flush: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantLatestTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789007")
+
+// cancelOnWrite cancels the request once a write contains stop.
+type cancelOnWrite struct {
+	*httptest.ResponseRecorder
+	stop   string
+	cancel context.CancelFunc
+}
+
+func (w *cancelOnWrite) Write(b []byte) (int, error) {
+	if strings.Contains(string(b), w.stop) {
+		defer w.cancel()
+	}
+
+	return w.ResponseRecorder.Write(b) //nolint:wrapcheck // the recorder does not fail.
+}
+
+func testTransaction(id uuid.UUID, amount int64) types.Transaction {
+	return types.Transaction{
+		ID:        id,
+		AccountID: wantAccountID,
+		Amount:    amount,
+		CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestNewEventHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewEventHandler(mocks.NewMockAccountService(t), mocks.NewMockSubscriber(t))
+	assert.NotNil(t, got)
+}
+
+func TestEventHandler_accountEvents(t *testing.T) {
+	t.Parallel()
+
+	latest := uuid.NullUUID{UUID: wantLatestTransactionID, Valid: true}
+
+	tests := []struct {
+		name           string
+		accountID      string
+		lastEventID    string
+		activity       bool
+		heartbeat      bool
+		mock           func(*mocks.MockAccountService, context.CancelFunc)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when account id is invalid",
+			accountID:      "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:           "failed when last event id is invalid",
+			accountID:      wantAccountID.String(),
+			lastEventID:    "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid Last-Event-ID: invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "failed when account not found",
+			accountID: wantAccountID.String(),
+			mock: func(mas *mocks.MockAccountService, _ context.CancelFunc) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(1), int32(0)).
+					Return(types.ListTransactionsResponse{}, ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:        "failed when last event is not a transaction of the account",
+			accountID:   wantAccountID.String(),
+			lastEventID: wantTrnasactionID.String(),
+			mock: func(mas *mocks.MockAccountService, _ context.CancelFunc) {
+				mas.EXPECT().ListTransactionsAfter(mock.Anything, wantAccountID,
+					uuid.NullUUID{UUID: wantTrnasactionID, Valid: true}, int32(eventBatchSize)).
+					Return(types.ListTransactionsResponse{}, ErrTransactionNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"transaction not found","code":"TRANSACTION_NOT_FOUND"}
+`,
+		},
+		{
+			name:        "success when resuming after the last event",
+			accountID:   wantAccountID.String(),
+			lastEventID: wantTrnasactionID.String(),
+			mock: func(mas *mocks.MockAccountService, cancel context.CancelFunc) {
+				mas.EXPECT().ListTransactionsAfter(mock.Anything, wantAccountID,
+					uuid.NullUUID{UUID: wantTrnasactionID, Valid: true}, int32(eventBatchSize)).
+					Return(types.ListTransactionsResponse{
+						Transactions: []types.Transaction{testTransaction(wantLatestTransactionID, 200)},
+					}, nil).Once()
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account:          types.Account{ID: wantAccountID},
+					Balance:          300,
+					AvailableBalance: 300,
+				}, nil).Run(func(context.Context, uuid.UUID) { cancel() }).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: "id: 12345678-1234-1234-1234-123456789007\n" +
+				"event: transaction\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789007","accountId":"12345678-1234-1234-1234-123456789001",` +
+				`"amount":200,"sourceId":null,"createdAt":"2024-05-01T10:00:00Z"}` + "\n\n" +
+				"event: balance\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789001","name":"","email":"","currencyCode":"",` +
+				`"balance":300,"availableBalance":300}` + "\n\n",
+		},
+		{
+			name:      "success when streaming new transactions",
+			accountID: wantAccountID.String(),
+			activity:  true,
+			mock: func(mas *mocks.MockAccountService, cancel context.CancelFunc) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(1), int32(0)).
+					Return(types.ListTransactionsResponse{
+						Transactions: []types.Transaction{testTransaction(wantLatestTransactionID, 100)},
+					}, nil).Once()
+				mas.EXPECT().ListTransactionsAfter(mock.Anything, wantAccountID, latest, int32(eventBatchSize)).
+					Return(types.ListTransactionsResponse{}, nil).Once()
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account:          types.Account{ID: wantAccountID},
+					Balance:          100,
+					AvailableBalance: 100,
+				}, nil).Once()
+				mas.EXPECT().ListTransactionsAfter(mock.Anything, wantAccountID, latest, int32(eventBatchSize)).
+					Return(types.ListTransactionsResponse{
+						Transactions: []types.Transaction{testTransaction(wantTrnasactionID, -40)},
+					}, nil).Once()
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account:          types.Account{ID: wantAccountID},
+					Balance:          60,
+					AvailableBalance: 60,
+				}, nil).Run(func(context.Context, uuid.UUID) { cancel() }).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: "event: balance\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789001","name":"","email":"","currencyCode":"",` +
+				`"balance":100,"availableBalance":100}` + "\n\n" +
+				"id: 12345678-1234-1234-1234-123456789002\n" +
+				"event: transaction\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789002","accountId":"12345678-1234-1234-1234-123456789001",` +
+				`"amount":-40,"sourceId":null,"createdAt":"2024-05-01T10:00:00Z"}` + "\n\n" +
+				"event: balance\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789001","name":"","email":"","currencyCode":"",` +
+				`"balance":60,"availableBalance":60}` + "\n\n",
+		},
+		{
+			name:      "success when sending heartbeats",
+			accountID: wantAccountID.String(),
+			heartbeat: true,
+			mock: func(mas *mocks.MockAccountService, _ context.CancelFunc) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(1), int32(0)).
+					Return(types.ListTransactionsResponse{}, nil).Once()
+				mas.EXPECT().ListTransactionsAfter(mock.Anything, wantAccountID, uuid.NullUUID{}, int32(eventBatchSize)).
+					Return(types.ListTransactionsResponse{}, nil).Once()
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account: types.Account{ID: wantAccountID},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: "event: balance\n" +
+				`data: {"id":"12345678-1234-1234-1234-123456789001","name":"","email":"","currencyCode":"",` +
+				`"balance":0,"availableBalance":0}` + "\n\n" +
+				": heartbeat\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts/"+tt.accountID+"/events", nil).WithContext(ctx)
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			if tt.lastEventID != "" {
+				r.Header.Set(headerLastEventID, tt.lastEventID)
+			}
+
+			w := &cancelOnWrite{ResponseRecorder: httptest.NewRecorder(), stop: "heartbeat", cancel: cancel}
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			subscriberMock := mocks.NewMockSubscriber(t)
+
+			if tt.mock != nil {
+				tt.mock(accountServiceMock, cancel)
+			}
+
+			if tt.accountID == wantAccountID.String() {
+				activity := make(chan struct{}, 1)
+				if tt.activity {
+					activity <- struct{}{}
+				}
+
+				unsubscribed := false
+
+				subscriberMock.EXPECT().Subscribe(wantAccountID).Return(activity, func() { unsubscribed = true }).Once()
+
+				defer func() { assert.True(t, unsubscribed) }()
+			}
+
+			h := NewEventHandler(accountServiceMock, subscriberMock)
+			h.heartbeatInterval = time.Hour
+
+			if tt.heartbeat {
+				h.heartbeatInterval = time.Millisecond
+			}
+
+			h.accountEvents(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
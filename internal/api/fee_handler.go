@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listFeeSchedulesRoute   = "GET /products/{code}/fees"
+	setFeeScheduleRoute     = "PUT /admin/products/{code}/fees/{type}"
+	deleteFeeScheduleRoute  = "DELETE /admin/products/{code}/fees/{type}"
+	previewTransferFeeRoute = "GET /accounts/{id}/transfer-fee"
+
+	pathValueFeeType = "type"
+	queryAmount      = "amount"
+)
+
+var (
+	errInvalidFeeType = errors.New("type must be transfer or maintenance")
+	errInvalidAmount  = errors.New("amount must be a positive amount in the minor unit")
+
+	feeTypes = []string{types.FeeTypeTransfer, types.FeeTypeMaintenance}
+)
+
+type FeeService interface {
+	ListFeeSchedules(ctx context.Context, productCode string) (types.ListFeeSchedulesResponse, error)
+	SetFeeSchedule(
+		ctx context.Context, productCode, feeType string, req *types.SetFeeScheduleRequest,
+	) (types.SetFeeScheduleResponse, error)
+	DeleteFeeSchedule(ctx context.Context, productCode, feeType string) error
+	PreviewTransferFee(ctx context.Context, accountID uuid.UUID, amount money.Amount) (types.TransferFeePreview, error)
+}
+
+type FeeHandler struct {
+	service FeeService
+}
+
+// NewFeeHandler returns a new FeeHandler.
+func NewFeeHandler(service FeeService) *FeeHandler {
+	return &FeeHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *FeeHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *FeeHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listFeeSchedulesRoute:   h.listFeeSchedules,
+		setFeeScheduleRoute:     requireAdmin(h.setFeeSchedule),
+		deleteFeeScheduleRoute:  requireAdmin(h.deleteFeeSchedule),
+		previewTransferFeeRoute: h.previewTransferFee,
+	}
+}
+
+func (h *FeeHandler) listFeeSchedules(w http.ResponseWriter, r *http.Request) {
+	code, err := productCode(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListFeeSchedules(r.Context(), code)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *FeeHandler) setFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetFeeScheduleRequest{}
+
+	code, feeType, err := feeSchedule(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetFeeSchedule(ctx, code, feeType, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *FeeHandler) deleteFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	code, feeType, err := feeSchedule(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.DeleteFeeSchedule(r.Context(), code, feeType); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FeeHandler) previewTransferFee(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	amount, err := strconv.ParseInt(r.URL.Query().Get(queryAmount), 10, 64)
+	if err != nil || amount <= 0 {
+		handleError(w, errInvalidAmount, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.PreviewTransferFee(ctx, accountID, amount)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// feeSchedule returns the product code and the fee type of the path.
+func feeSchedule(r *http.Request) (string, string, error) {
+	code, err := productCode(r)
+	if err != nil {
+		return "", "", err
+	}
+
+	feeType := r.PathValue(pathValueFeeType)
+	if !slices.Contains(feeTypes, feeType) {
+		return "", "", errInvalidFeeType
+	}
+
+	return code, feeType, nil
+}
@@ -0,0 +1,300 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantFeeSchedule = types.FeeSchedule{
+	ProductCode: "current",
+	Type:        types.FeeTypeTransfer,
+	Kind:        types.FeeKindPercentage,
+	Rate:        "0.005",
+	MinAmount:   50,
+	MaxAmount:   1000,
+	Tiers:       []types.FeeTier{},
+	CreatedAt:   time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	UpdatedAt:   time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+}
+
+const wantFeeScheduleJSON = `{"productCode":"current","type":"transfer","kind":"percentage","amount":0,` +
+	`"rate":"0.005","minAmount":50,"maxAmount":1000,"tiers":[],"createdAt":"2024-05-17T10:00:00Z",` +
+	`"updatedAt":"2024-05-17T10:00:00Z"}`
+
+func TestNewFeeHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewFeeHandler(mocks.NewMockFeeService(t))
+	assert.NotNil(t, got)
+}
+
+func TestFeeHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		accountID      string
+		code           string
+		feeType        string
+		query          string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockFeeService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:  "list fee schedules failed",
+			route: listFeeSchedulesRoute,
+			code:  "current",
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().ListFeeSchedules(mock.Anything, "current").
+					Return(types.ListFeeSchedulesResponse{}, types.ErrInternal).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			want: `{"message":"internal server error","code":"INTERNAL"}
+`,
+		},
+		{
+			name:  "list fee schedules success",
+			route: listFeeSchedulesRoute,
+			code:  "current",
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().ListFeeSchedules(mock.Anything, "current").Return(types.ListFeeSchedulesResponse{
+					Schedules: []types.FeeSchedule{wantFeeSchedule},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"schedules":[` + wantFeeScheduleJSON + `]}
+`,
+		},
+		{
+			name:           "set fee schedule failed when not made by the admin",
+			route:          setFeeScheduleRoute,
+			code:           "current",
+			feeType:        types.FeeTypeTransfer,
+			body:           `{"kind":"flat","amount":50}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "set fee schedule failed when type is unknown",
+			route:          setFeeScheduleRoute,
+			code:           "current",
+			feeType:        "withdrawal",
+			body:           `{"kind":"flat","amount":50}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"type must be transfer or maintenance"}
+`,
+		},
+		{
+			name:           "set fee schedule failed when rate is invalid",
+			route:          setFeeScheduleRoute,
+			code:           "current",
+			feeType:        types.FeeTypeTransfer,
+			body:           `{"kind":"percentage","rate":"0.5%"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"rate":{"fee_rate":"rate must be a decimal from 0 to 1"}}`,
+		},
+		{
+			name:    "set fee schedule failed when the schedule is invalid",
+			route:   setFeeScheduleRoute,
+			code:    "current",
+			feeType: types.FeeTypeTransfer,
+			body:    `{"kind":"tiered","tiers":[{"from":100,"amount":50}]}`,
+			admin:   true,
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().SetFeeSchedule(mock.Anything, "current", types.FeeTypeTransfer, &types.SetFeeScheduleRequest{
+					Kind:  types.FeeKindTiered,
+					Tiers: []types.FeeTier{{From: 100, Amount: 50}},
+				}).Return(types.SetFeeScheduleResponse{}, types.ErrInvalidFeeSchedule).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"` + types.ErrInvalidFeeSchedule.Error() + `","code":"INVALID_FEE_SCHEDULE"}
+`,
+		},
+		{
+			name:    "set fee schedule success",
+			route:   setFeeScheduleRoute,
+			code:    "current",
+			feeType: types.FeeTypeTransfer,
+			body:    `{"kind":"percentage","rate":"0.005","minAmount":50,"maxAmount":1000}`,
+			admin:   true,
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().SetFeeSchedule(mock.Anything, "current", types.FeeTypeTransfer, &types.SetFeeScheduleRequest{
+					Kind:      types.FeeKindPercentage,
+					Rate:      "0.005",
+					MinAmount: 50,
+					MaxAmount: 1000,
+				}).Return(types.SetFeeScheduleResponse{FeeSchedule: wantFeeSchedule}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantFeeScheduleJSON + `
+`,
+		},
+		{
+			name:    "delete fee schedule failed when not found",
+			route:   deleteFeeScheduleRoute,
+			code:    "current",
+			feeType: types.FeeTypeMaintenance,
+			admin:   true,
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().DeleteFeeSchedule(mock.Anything, "current", types.FeeTypeMaintenance).
+					Return(types.ErrFeeScheduleNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"fee schedule not found","code":"FEE_SCHEDULE_NOT_FOUND"}
+`,
+		},
+		{
+			name:    "delete fee schedule success",
+			route:   deleteFeeScheduleRoute,
+			code:    "current",
+			feeType: types.FeeTypeMaintenance,
+			admin:   true,
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().DeleteFeeSchedule(mock.Anything, "current", types.FeeTypeMaintenance).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "preview transfer fee failed when amount is invalid",
+			route:          previewTransferFeeRoute,
+			accountID:      wantAccountID.String(),
+			query:          "?amount=-1",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"amount must be a positive amount in the minor unit"}
+`,
+		},
+		{
+			name:      "preview transfer fee success",
+			route:     previewTransferFeeRoute,
+			accountID: wantAccountID.String(),
+			query:     "?amount=20000",
+			mock: func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().PreviewTransferFee(mock.Anything, wantAccountID, money.Amount(20000)).
+					Return(types.TransferFeePreview{Amount: 20000, Fee: 100, Total: 20100, CurrencyCode: "EUR"}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"amount":20000,"fee":100,"total":20100,"currencyCode":"EUR"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/fees"+tt.query, strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.accountID)
+			r.SetPathValue(pathValueCode, tt.code)
+			r.SetPathValue(pathValueFeeType, tt.feeType)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			feeServiceMock := mocks.NewMockFeeService(t)
+
+			if tt.mock != nil {
+				tt.mock(feeServiceMock)
+			}
+
+			NewFeeHandler(feeServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func feeContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "list fee schedules",
+			method:         http.MethodGet,
+			path:           "/products/current/fees",
+			wantStatusCode: http.StatusOK,
+			handler: feeContract(func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().ListFeeSchedules(mock.Anything, "current").Return(types.ListFeeSchedulesResponse{
+					Schedules: []types.FeeSchedule{wantFeeSchedule},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "set fee schedule",
+			method:         http.MethodPut,
+			path:           "/admin/products/current/fees/transfer",
+			body:           `{"kind":"tiered","tiers":[{"from":0,"amount":50},{"from":100000,"amount":100}]}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: feeContract(func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().SetFeeSchedule(mock.Anything, "current", types.FeeTypeTransfer, mock.Anything).
+					Return(types.SetFeeScheduleResponse{FeeSchedule: wantFeeSchedule}, nil).Once()
+			}),
+		},
+		{
+			name:           "set fee schedule rejected by the contract",
+			method:         http.MethodPut,
+			path:           "/admin/products/current/fees/transfer",
+			body:           `{"kind":"percentage","rate":"0.5%"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        feeContract(nil),
+		},
+		{
+			name:           "delete fee schedule",
+			method:         http.MethodDelete,
+			path:           "/admin/products/current/fees/maintenance",
+			admin:          true,
+			wantStatusCode: http.StatusNoContent,
+			handler: feeContract(func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().DeleteFeeSchedule(mock.Anything, "current", types.FeeTypeMaintenance).Return(nil).Once()
+			}),
+		},
+		{
+			name:           "preview transfer fee",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/transfer-fee?amount=20000",
+			wantStatusCode: http.StatusOK,
+			handler: feeContract(func(mfs *mocks.MockFeeService) {
+				mfs.EXPECT().PreviewTransferFee(mock.Anything, wantAccountID, money.Amount(20000)).
+					Return(types.TransferFeePreview{Amount: 20000, Fee: 50, Total: 20050, CurrencyCode: "EUR"}, nil).Once()
+			}),
+		},
+	}
+}
+
+// feeContract returns the fee handler of a contract test, its service mocked with expectations.
+func feeContract(expectations func(*mocks.MockFeeService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewFeeHandler(expect(mocks.NewMockFeeService(t), expectations))
+	}
+}
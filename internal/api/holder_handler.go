@@ -0,0 +1,302 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listAccountHoldersRoute    = "GET /accounts/{id}/holders"
+	setAccountHolderRoute      = "PUT /accounts/{id}/holders/{customerId}"
+	removeAccountHolderRoute   = "DELETE /accounts/{id}/holders/{customerId}"
+	setMandateRoute            = "PUT /accounts/{id}/mandate"
+	deleteMandateRoute         = "DELETE /accounts/{id}/mandate"
+	listTransferApprovalsRoute = "GET /accounts/{id}/transfer-approvals"
+	approveTransferRoute       = "POST /accounts/{id}/transfer-approvals/{transferApprovalId}/approve"
+	rejectTransferRoute        = "POST /accounts/{id}/transfer-approvals/{transferApprovalId}/reject"
+
+	pathValueCustomerID         = "customerId"
+	pathValueTransferApprovalID = "transferApprovalId"
+)
+
+var (
+	errInvalidTransferApprovalStatus = errors.New("status must be pending, approved or rejected")
+
+	transferApprovalStatuses = []string{
+		types.TransferApprovalStatusPending, types.TransferApprovalStatusApproved, types.TransferApprovalStatusRejected,
+	}
+)
+
+type HolderService interface {
+	ListHolders(ctx context.Context, accountID uuid.UUID) (types.ListAccountHoldersResponse, error)
+	SetHolder(
+		ctx context.Context, accountID, customerID uuid.UUID, req *types.SetAccountHolderRequest,
+	) (types.SetAccountHolderResponse, error)
+	RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID) error
+	SetMandate(ctx context.Context, accountID uuid.UUID, req *types.SetMandateRequest) (types.SetMandateResponse, error)
+	DeleteMandate(ctx context.Context, accountID uuid.UUID) error
+}
+
+type TransferApprovalService interface {
+	ListTransferApprovals(
+		ctx context.Context, accountID uuid.UUID, status string, limit, offset int32,
+	) (types.ListTransferApprovalsResponse, error)
+	ApproveTransfer(ctx context.Context, accountID, transferApprovalID uuid.UUID) (types.ApproveTransferResponse, error)
+	RejectTransfer(ctx context.Context, accountID, transferApprovalID uuid.UUID) (types.RejectTransferResponse, error)
+}
+
+type HolderHandler struct {
+	service   HolderService
+	approvals TransferApprovalService
+}
+
+// NewHolderHandler returns a new HolderHandler.
+func NewHolderHandler(service HolderService, approvals TransferApprovalService) *HolderHandler {
+	return &HolderHandler{
+		service:   service,
+		approvals: approvals,
+	}
+}
+
+// Register routes.
+func (h *HolderHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *HolderHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listAccountHoldersRoute:    h.listAccountHolders,
+		setAccountHolderRoute:      h.setAccountHolder,
+		removeAccountHolderRoute:   h.removeAccountHolder,
+		setMandateRoute:            h.setMandate,
+		deleteMandateRoute:         h.deleteMandate,
+		listTransferApprovalsRoute: h.listTransferApprovals,
+		approveTransferRoute:       h.approveTransfer,
+		rejectTransferRoute:        h.rejectTransfer,
+	}
+}
+
+func (h *HolderHandler) listAccountHolders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListHolders(ctx, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *HolderHandler) setAccountHolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetAccountHolderRequest{}
+
+	accountID, customerID, err := accountPath(r, pathValueCustomerID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetHolder(ctx, accountID, customerID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *HolderHandler) removeAccountHolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, customerID, err := accountPath(r, pathValueCustomerID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.RemoveHolder(ctx, accountID, customerID); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HolderHandler) setMandate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetMandateRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetMandate(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *HolderHandler) deleteMandate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.DeleteMandate(ctx, accountID); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HolderHandler) listTransferApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	status := r.URL.Query().Get(queryStatus)
+	if status == "" {
+		status = types.TransferApprovalStatusPending
+	}
+
+	if !slices.Contains(transferApprovalStatuses, status) {
+		handleError(w, errInvalidTransferApprovalStatus, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.approvals.ListTransferApprovals(ctx, accountID, status, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *HolderHandler) approveTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, transferApprovalID, err := accountPath(r, pathValueTransferApprovalID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.approvals.ApproveTransfer(ctx, accountID, transferApprovalID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *HolderHandler) rejectTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, transferApprovalID, err := accountPath(r, pathValueTransferApprovalID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.approvals.RejectTransfer(ctx, accountID, transferApprovalID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// accountPath parses the account ID of the path and the ID of the path value name.
+func accountPath(r *http.Request, name string) (uuid.UUID, uuid.UUID, error) {
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return accountID, id, nil
+}
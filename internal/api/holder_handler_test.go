@@ -0,0 +1,489 @@
+package api
+
+import (
+	"cmp"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantHolder = types.AccountHolder{
+		CustomerID: wantCustomerID,
+		Role:       types.HolderRoleSignatory,
+		CreatedAt:  time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	}
+	wantTransferApproval = types.TransferApproval{
+		ID:               wantTransferApprovalID,
+		AccountID:        wantAccountID,
+		ReciverAccountID: wantReciverAccountID,
+		Amount:           200000,
+		Status:           types.TransferApprovalStatusPending,
+		InitiatedBy:      wantCustomerID.String(),
+		CreatedAt:        time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	}
+)
+
+const wantHolderJSON = `{"customerId":"12345678-1234-1234-1234-123456789010","role":"signatory",` +
+	`"createdAt":"2024-05-17T10:00:00Z","updatedAt":"2024-05-17T10:00:00Z"}`
+
+func TestNewHolderHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewHolderHandler(mocks.NewMockHolderService(t), mocks.NewMockTransferApprovalService(t))
+	assert.NotNil(t, got)
+}
+
+func TestHolderHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		customerID     string
+		body           string
+		mock           func(*mocks.MockHolderService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:  "list account holders failed when not permitted",
+			route: listAccountHoldersRoute,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().ListHolders(mock.Anything, wantAccountID).
+					Return(types.ListAccountHoldersResponse{}, types.ErrNotPermitted).Once()
+			},
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"the role of the holder does not permit this on the account","code":"NOT_PERMITTED"}
+`,
+		},
+		{
+			name:  "list account holders success",
+			route: listAccountHoldersRoute,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().ListHolders(mock.Anything, wantAccountID).
+					Return(types.ListAccountHoldersResponse{Holders: []types.AccountHolder{wantHolder}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"holders":[` + wantHolderJSON + `]}
+`,
+		},
+		{
+			name:           "set account holder failed when customer id is invalid",
+			route:          setAccountHolderRoute,
+			customerID:     "invalid",
+			body:           `{"role":"viewer"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 7"}
+`,
+		},
+		{
+			name:           "set account holder failed when role is owner",
+			route:          setAccountHolderRoute,
+			customerID:     wantCustomerID.String(),
+			body:           `{"role":"owner"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"role":{"in":"role value must be in the enum [co-owner signatory viewer]"}}`,
+		},
+		{
+			name:       "set account holder failed when the customer is the owner",
+			route:      setAccountHolderRoute,
+			customerID: wantCustomerID.String(),
+			body:       `{"role":"viewer"}`,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().SetHolder(mock.Anything, wantAccountID, wantCustomerID, &types.SetAccountHolderRequest{
+					Role: types.HolderRoleViewer,
+				}).Return(types.SetAccountHolderResponse{}, types.ErrOwnerImmutable).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"the owner of an account cannot be changed or removed","code":"OWNER_IMMUTABLE"}
+`,
+		},
+		{
+			name:       "set account holder success",
+			route:      setAccountHolderRoute,
+			customerID: wantCustomerID.String(),
+			body:       `{"role":"signatory"}`,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().SetHolder(mock.Anything, wantAccountID, wantCustomerID, &types.SetAccountHolderRequest{
+					Role: types.HolderRoleSignatory,
+				}).Return(types.SetAccountHolderResponse{AccountHolder: wantHolder}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantHolderJSON + `
+`,
+		},
+		{
+			name:       "remove account holder failed when holder not found",
+			route:      removeAccountHolderRoute,
+			customerID: wantCustomerID.String(),
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().RemoveHolder(mock.Anything, wantAccountID, wantCustomerID).
+					Return(types.ErrHolderNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account holder not found","code":"HOLDER_NOT_FOUND"}
+`,
+		},
+		{
+			name:       "remove account holder success",
+			route:      removeAccountHolderRoute,
+			customerID: wantCustomerID.String(),
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().RemoveHolder(mock.Anything, wantAccountID, wantCustomerID).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "set mandate failed when threshold is negative",
+			route:          setMandateRoute,
+			body:           `{"approvalThreshold":-1}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"approvalThreshold":{"money_limit":"approvalThreshold field did not pass validation"}}`,
+		},
+		{
+			name:  "set mandate success",
+			route: setMandateRoute,
+			body:  `{"approvalThreshold":100000}`,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().SetMandate(mock.Anything, wantAccountID, &types.SetMandateRequest{ApprovalThreshold: 100000}).
+					Return(types.SetMandateResponse{Mandate: types.Mandate{
+						AccountID: wantAccountID, ApprovalThreshold: 100000,
+					}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"accountId":"` + wantAccountID.String() + `","approvalThreshold":100000}
+`,
+		},
+		{
+			name:  "delete mandate success",
+			route: deleteMandateRoute,
+			mock: func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().DeleteMandate(mock.Anything, wantAccountID).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/accounts", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, wantAccountID.String())
+			r.SetPathValue(pathValueCustomerID, tt.customerID)
+
+			w := httptest.NewRecorder()
+
+			holderServiceMock := mocks.NewMockHolderService(t)
+
+			if tt.mock != nil {
+				tt.mock(holderServiceMock)
+			}
+
+			NewHolderHandler(holderServiceMock, mocks.NewMockTransferApprovalService(t)).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestHolderHandler_transferApprovals(t *testing.T) {
+	t.Parallel()
+
+	wantJSON := `{"id":"` + wantTransferApprovalID.String() + `","accountId":"` + wantAccountID.String() +
+		`","reciverAccountId":"` + wantReciverAccountID.String() + `","amount":200000,"status":"pending",` +
+		`"initiatedBy":"` + wantCustomerID.String() + `","transactionId":null,"createdAt":"2024-05-17T10:00:00Z"}`
+
+	tests := []struct {
+		name               string
+		route              string
+		url                string
+		transferApprovalID string
+		mock               func(*mocks.MockTransferApprovalService)
+		wantStatusCode     int
+		want               string
+	}{
+		{
+			name:           "list transfer approvals failed when status is unknown",
+			route:          listTransferApprovalsRoute,
+			url:            "/accounts?status=held",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"status must be pending, approved or rejected"}
+`,
+		},
+		{
+			name:  "list transfer approvals success",
+			route: listTransferApprovalsRoute,
+			url:   "/accounts?limit=10&offset=5",
+			mock: func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().ListTransferApprovals(mock.Anything, wantAccountID, types.TransferApprovalStatusPending,
+					int32(10), int32(5)).Return(types.ListTransferApprovalsResponse{
+					TransferApprovals: []types.TransferApproval{wantTransferApproval},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"transferApprovals":[` + wantJSON + `]}
+`,
+		},
+		{
+			name:               "approve transfer failed when transfer approval id is invalid",
+			route:              approveTransferRoute,
+			transferApprovalID: "invalid",
+			wantStatusCode:     http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 7"}
+`,
+		},
+		{
+			name:               "approve transfer failed when approved by the holder who initiated it",
+			route:              approveTransferRoute,
+			transferApprovalID: wantTransferApprovalID.String(),
+			mock: func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().ApproveTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.ApproveTransferResponse{}, types.ErrSameApprover).Once()
+			},
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"a transfer must be approved by a holder other than the one who initiated it",` +
+				`"code":"SAME_APPROVER"}
+`,
+		},
+		{
+			name:               "approve transfer success",
+			route:              approveTransferRoute,
+			transferApprovalID: wantTransferApprovalID.String(),
+			mock: func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().ApproveTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.ApproveTransferResponse{TransferApproval: wantTransferApproval}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantJSON + `
+`,
+		},
+		{
+			name:               "reject transfer failed when already decided",
+			route:              rejectTransferRoute,
+			transferApprovalID: wantTransferApprovalID.String(),
+			mock: func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().RejectTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.RejectTransferResponse{}, types.ErrTransferApprovalDecided).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"transfer approval was already approved or rejected","code":"TRANSFER_APPROVAL_DECIDED"}
+`,
+		},
+		{
+			name:               "reject transfer success",
+			route:              rejectTransferRoute,
+			transferApprovalID: wantTransferApprovalID.String(),
+			mock: func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().RejectTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.RejectTransferResponse{TransferApproval: wantTransferApproval}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantJSON + `
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, cmp.Or(tt.url, "/accounts"), nil)
+			r.SetPathValue(pathValueID, wantAccountID.String())
+			r.SetPathValue(pathValueTransferApprovalID, tt.transferApprovalID)
+
+			w := httptest.NewRecorder()
+
+			approvalServiceMock := mocks.NewMockTransferApprovalService(t)
+
+			if tt.mock != nil {
+				tt.mock(approvalServiceMock)
+			}
+
+			NewHolderHandler(mocks.NewMockHolderService(t), approvalServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestHandleError_pendingApproval(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	handleError(w, errHeldForApproval, http.StatusBadRequest)
+
+	res := w.Result()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"message":"transfer held for the approval of a second holder: `+
+		`12345678-1234-1234-1234-123456789006","code":"TRANSFER_PENDING_APPROVAL",`+
+		`"transferApprovalId":"12345678-1234-1234-1234-123456789006"}
+`, string(got))
+}
+
+func holderContractTests() []contractTest {
+	accountPath := "/accounts/" + wantAccountID.String()
+	approvalPath := accountPath + "/transfer-approvals/" + wantTransferApprovalID.String()
+
+	return []contractTest{
+		{
+			name:           "transfer money held for approval",
+			method:         http.MethodPost,
+			path:           accountPath + "/transactions/transfer",
+			body:           `{"reciverAccountId":"` + wantReciverAccountID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.TransferMoneyResponse{}, errHeldForApproval).Once()
+			}),
+		},
+		{
+			name:           "get account not permitted",
+			method:         http.MethodGet,
+			path:           accountPath,
+			wantStatusCode: http.StatusForbidden,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(types.GetAccountResponse{}, types.ErrNotPermitted).Once()
+			}),
+		},
+		{
+			name:           "list account holders",
+			method:         http.MethodGet,
+			path:           accountPath + "/holders",
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().ListHolders(mock.Anything, wantAccountID).
+					Return(types.ListAccountHoldersResponse{Holders: []types.AccountHolder{wantHolder}}, nil).Once()
+			}, nil),
+		},
+		{
+			name:           "set account holder",
+			method:         http.MethodPut,
+			path:           accountPath + "/holders/" + wantCustomerID.String(),
+			body:           `{"role":"signatory"}`,
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().SetHolder(mock.Anything, wantAccountID, wantCustomerID, mock.Anything).
+					Return(types.SetAccountHolderResponse{AccountHolder: wantHolder}, nil).Once()
+			}, nil),
+		},
+		{
+			name:           "set account holder rejected by the contract",
+			method:         http.MethodPut,
+			path:           accountPath + "/holders/" + wantCustomerID.String(),
+			body:           `{"role":"owner"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        holderContract(nil, nil),
+		},
+		{
+			name:           "remove account holder",
+			method:         http.MethodDelete,
+			path:           accountPath + "/holders/" + wantCustomerID.String(),
+			wantStatusCode: http.StatusNoContent,
+			handler: holderContract(func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().RemoveHolder(mock.Anything, wantAccountID, wantCustomerID).Return(nil).Once()
+			}, nil),
+		},
+		{
+			name:           "set mandate",
+			method:         http.MethodPut,
+			path:           accountPath + "/mandate",
+			body:           `{"approvalThreshold":100000}`,
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().SetMandate(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.SetMandateResponse{Mandate: types.Mandate{
+						AccountID: wantAccountID, ApprovalThreshold: 100000,
+					}}, nil).Once()
+			}, nil),
+		},
+		{
+			name:           "delete mandate",
+			method:         http.MethodDelete,
+			path:           accountPath + "/mandate",
+			wantStatusCode: http.StatusNoContent,
+			handler: holderContract(func(mhs *mocks.MockHolderService) {
+				mhs.EXPECT().DeleteMandate(mock.Anything, wantAccountID).Return(nil).Once()
+			}, nil),
+		},
+		{
+			name:           "list transfer approvals",
+			method:         http.MethodGet,
+			path:           accountPath + "/transfer-approvals?status=pending",
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(nil, func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().ListTransferApprovals(mock.Anything, wantAccountID, types.TransferApprovalStatusPending,
+					mock.Anything, mock.Anything).Return(types.ListTransferApprovalsResponse{
+					TransferApprovals: []types.TransferApproval{wantTransferApproval},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "approve transfer",
+			method:         http.MethodPost,
+			path:           approvalPath + "/approve",
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(nil, func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().ApproveTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.ApproveTransferResponse{TransferApproval: wantTransferApproval}, nil).Once()
+			}),
+		},
+		{
+			name:           "reject transfer by the holder who initiated it",
+			method:         http.MethodPost,
+			path:           approvalPath + "/reject",
+			wantStatusCode: http.StatusOK,
+			handler: holderContract(nil, func(mas *mocks.MockTransferApprovalService) {
+				mas.EXPECT().RejectTransfer(mock.Anything, wantAccountID, wantTransferApprovalID).
+					Return(types.RejectTransferResponse{TransferApproval: wantTransferApproval}, nil).Once()
+			}),
+		},
+	}
+}
+
+// holderContract returns the holder handler of a contract test, its services mocked with expectations.
+func holderContract(
+	holders func(*mocks.MockHolderService), approvals func(*mocks.MockTransferApprovalService),
+) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewHolderHandler(expect(mocks.NewMockHolderService(t), holders),
+			expect(mocks.NewMockTransferApprovalService(t), approvals))
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	getAccountLimitsRoute = "GET /accounts/{id}/limits"
+	setAccountLimitsRoute = "PUT /admin/accounts/{id}/limits"
+	setLimitTierRoute     = "PUT /admin/limits/tiers/{tier}"
+
+	pathValueTier = "tier"
+	maxTierLength = 32
+)
+
+var errInvalidLimitTier = errors.New("tier must be 1 to 32 characters")
+
+type LimitService interface {
+	GetAccountLimits(ctx context.Context, accountID uuid.UUID) (types.GetAccountLimitsResponse, error)
+	SetAccountLimits(
+		ctx context.Context, accountID uuid.UUID, req *types.SetAccountLimitsRequest,
+	) (types.SetAccountLimitsResponse, error)
+	SetLimitTier(ctx context.Context, tier string, req *types.SetLimitTierRequest) (types.SetLimitTierResponse, error)
+}
+
+type LimitHandler struct {
+	service LimitService
+}
+
+// NewLimitHandler returns a new LimitHandler.
+func NewLimitHandler(service LimitService) *LimitHandler {
+	return &LimitHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *LimitHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *LimitHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		getAccountLimitsRoute: h.getAccountLimits,
+		setAccountLimitsRoute: requireAdmin(h.setAccountLimits),
+		setLimitTierRoute:     requireAdmin(h.setLimitTier),
+	}
+}
+
+func (h *LimitHandler) getAccountLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.GetAccountLimits(ctx, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *LimitHandler) setAccountLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetAccountLimitsRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetAccountLimits(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *LimitHandler) setLimitTier(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetLimitTierRequest{}
+
+	tier := r.PathValue(pathValueTier)
+	if tier == "" || len(tier) > maxTierLength {
+		handleError(w, errInvalidLimitTier, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetLimitTier(ctx, tier, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func testAccountLimits() types.GetAccountLimitsResponse {
+	return types.GetAccountLimitsResponse{
+		AccountID: wantAccountID,
+		Tier:      "standard",
+		Limits:    types.Limits{MaxTransfer: 1000000, DailyAmount: 2000000, DailyCount: 50},
+		Usage:     types.LimitUsage{DailyAmount: 500, MonthlyAmount: 1500, DailyCount: 2},
+	}
+}
+
+const wantAccountLimits = `{"accountId":"12345678-1234-1234-1234-123456789001","tier":"standard",` +
+	`"limits":{"maxTransfer":1000000,"dailyAmount":2000000,"dailyCount":50},` +
+	`"usage":{"dailyAmount":500,"monthlyAmount":1500,"dailyCount":2}}`
+
+func TestNewLimitHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewLimitHandler(mocks.NewMockLimitService(t))
+	assert.NotNil(t, got)
+}
+
+func TestLimitHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		accountID      string
+		tier           string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockLimitService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "get failed when account id is invalid",
+			route:          getAccountLimitsRoute,
+			accountID:      "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "get failed when account not found",
+			route:     getAccountLimitsRoute,
+			accountID: wantAccountID.String(),
+			mock: func(mls *mocks.MockLimitService) {
+				mls.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).
+					Return(types.GetAccountLimitsResponse{}, ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "get success",
+			route:     getAccountLimitsRoute,
+			accountID: wantAccountID.String(),
+			mock: func(mls *mocks.MockLimitService) {
+				mls.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(testAccountLimits(), nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantAccountLimits + "\n",
+		},
+		{
+			name:           "set account limits failed when not made by the admin",
+			route:          setAccountLimitsRoute,
+			accountID:      wantAccountID.String(),
+			body:           `{"tier":"premium"}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "set account limits failed when a limit is negative",
+			route:          setAccountLimitsRoute,
+			accountID:      wantAccountID.String(),
+			body:           `{"dailyCount":-1}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"dailyCount":{"min":"dailyCount min value is 0"}}`,
+		},
+		{
+			name:      "set account limits failed when tier not found",
+			route:     setAccountLimitsRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"tier":"gold"}`,
+			admin:     true,
+			mock: func(mls *mocks.MockLimitService) {
+				mls.EXPECT().SetAccountLimits(mock.Anything, wantAccountID, &types.SetAccountLimitsRequest{Tier: "gold"}).
+					Return(types.SetAccountLimitsResponse{}, types.ErrLimitTierNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit tier not found","code":"LIMIT_TIER_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "set account limits success",
+			route:     setAccountLimitsRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"dailyCount":50}`,
+			admin:     true,
+			mock: func(mls *mocks.MockLimitService) {
+				mls.EXPECT().SetAccountLimits(mock.Anything, wantAccountID, &types.SetAccountLimitsRequest{
+					Limits: types.Limits{DailyCount: 50},
+				}).Return(types.SetAccountLimitsResponse{GetAccountLimitsResponse: testAccountLimits()}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantAccountLimits + "\n",
+		},
+		{
+			name:           "set tier failed when tier is too long",
+			route:          setLimitTierRoute,
+			tier:           strings.Repeat("t", 33),
+			body:           `{}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"tier must be 1 to 32 characters"}
+`,
+		},
+		{
+			name:  "set tier success",
+			route: setLimitTierRoute,
+			tier:  "gold",
+			body:  `{"maxTransfer":500000}`,
+			admin: true,
+			mock: func(mls *mocks.MockLimitService) {
+				mls.EXPECT().SetLimitTier(mock.Anything, "gold", &types.SetLimitTierRequest{
+					Limits: types.Limits{MaxTransfer: 500000},
+				}).Return(types.SetLimitTierResponse{
+					Tier: "gold", Limits: types.Limits{MaxTransfer: 500000},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"tier":"gold","limits":{"maxTransfer":500000}}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.accountID)
+			r.SetPathValue(pathValueTier, tt.tier)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			limitServiceMock := mocks.NewMockLimitService(t)
+
+			if tt.mock != nil {
+				tt.mock(limitServiceMock)
+			}
+
+			NewLimitHandler(limitServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestHandleError_limitExceeded(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	handleError(w, &types.LimitExceededError{Limit: types.LimitDailyCount}, http.StatusBadRequest)
+
+	res := w.Result()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"message":"transfer exceeds a limit of the account: dailyCount","code":"LIMIT_EXCEEDED",`+
+		`"limit":"dailyCount","remaining":0}
+`, string(got))
+}
+
+func limitContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "get account limits",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/limits",
+			wantStatusCode: http.StatusOK,
+			handler: limitContract(func(mls *mocks.MockLimitService) {
+				mls.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(testAccountLimits(), nil).Once()
+			}),
+		},
+		{
+			name:           "set account limits",
+			method:         http.MethodPut,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/limits",
+			body:           `{"tier":"premium","dailyCount":10}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: limitContract(func(mls *mocks.MockLimitService) {
+				mls.EXPECT().SetAccountLimits(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.SetAccountLimitsResponse{GetAccountLimitsResponse: testAccountLimits()}, nil).Once()
+			}),
+		},
+		{
+			name:           "set account limits rejected by the contract",
+			method:         http.MethodPut,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/limits",
+			body:           `{"maxTransfer":-1}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        limitContract(nil),
+		},
+		{
+			name:           "set limit tier",
+			method:         http.MethodPut,
+			path:           "/admin/limits/tiers/gold",
+			body:           `{"maxTransfer":500000}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: limitContract(func(mls *mocks.MockLimitService) {
+				mls.EXPECT().SetLimitTier(mock.Anything, "gold", mock.Anything).Return(types.SetLimitTierResponse{
+					Tier: "gold", Limits: types.Limits{MaxTransfer: 500000},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "transfer money exceeding a limit",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions/transfer",
+			body:           `{"reciverAccountId":"` + wantReciverAccountID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.TransferMoneyResponse{}, errDailyAmountExceeded).Once()
+			}),
+		},
+	}
+}
+
+// limitContract returns the limit handler of a contract test, its service mocked with expectations.
+func limitContract(expectations func(*mocks.MockLimitService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewLimitHandler(expect(mocks.NewMockLimitService(t), expectations))
+	}
+}
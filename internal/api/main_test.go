@@ -0,0 +1,14 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zaidsasa/xbankapi/internal/validator"
+)
+
+func TestMain(m *testing.M) {
+	validator.ConfigureDefaultValidator()
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+)
+
+const routeMetrics = "GET /metrics"
+
+type MetricsHandler struct {
+	metrics http.Handler
+}
+
+// NewMetricsHandler returns a new MetricsHandler serving the metrics exposed by the provided handler,
+// e.g. promhttp.HandlerFor.
+func NewMetricsHandler(metrics http.Handler) *MetricsHandler {
+	return &MetricsHandler{
+		metrics: metrics,
+	}
+}
+
+// Register routes.
+func (h *MetricsHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *MetricsHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		routeMetrics: h.metrics.ServeHTTP,
+	}
+}
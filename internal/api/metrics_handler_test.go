@@ -0,0 +1,32 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler_Register(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+
+	NewMetricsHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("xbankapi_accounts_created_total 1\n"))
+	})).Register(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	res := w.Result()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "xbankapi_accounts_created_total 1\n", string(got))
+}
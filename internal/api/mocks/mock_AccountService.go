@@ -6,7 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
-	types "github.com/zaidsasa/xbankapi/internal/types"
+	types "github.com/zaidsasa/xbankapi/types"
 
 	uuid "github.com/google/uuid"
 )
@@ -139,6 +139,238 @@ func (_c *MockAccountService_CreateAccount_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockAccountService) GetAccount(ctx context.Context, accountID uuid.UUID) (types.GetAccountResponse, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 types.GetAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.GetAccountResponse, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.GetAccountResponse); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.GetAccountResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountService_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockAccountService_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockAccountService_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockAccountService_GetAccount_Call {
+	return &MockAccountService_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockAccountService_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockAccountService_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAccountService_GetAccount_Call) Return(_a0 types.GetAccountResponse, _a1 error) *MockAccountService_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountService_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.GetAccountResponse, error)) *MockAccountService_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountByIBAN provides a mock function with given fields: ctx, iban
+func (_m *MockAccountService) GetAccountByIBAN(ctx context.Context, iban string) (types.GetAccountResponse, error) {
+	ret := _m.Called(ctx, iban)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountByIBAN")
+	}
+
+	var r0 types.GetAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (types.GetAccountResponse, error)); ok {
+		return rf(ctx, iban)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.GetAccountResponse); ok {
+		r0 = rf(ctx, iban)
+	} else {
+		r0 = ret.Get(0).(types.GetAccountResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, iban)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountService_GetAccountByIBAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountByIBAN'
+type MockAccountService_GetAccountByIBAN_Call struct {
+	*mock.Call
+}
+
+// GetAccountByIBAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - iban string
+func (_e *MockAccountService_Expecter) GetAccountByIBAN(ctx interface{}, iban interface{}) *MockAccountService_GetAccountByIBAN_Call {
+	return &MockAccountService_GetAccountByIBAN_Call{Call: _e.mock.On("GetAccountByIBAN", ctx, iban)}
+}
+
+func (_c *MockAccountService_GetAccountByIBAN_Call) Run(run func(ctx context.Context, iban string)) *MockAccountService_GetAccountByIBAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountService_GetAccountByIBAN_Call) Return(_a0 types.GetAccountResponse, _a1 error) *MockAccountService_GetAccountByIBAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountService_GetAccountByIBAN_Call) RunAndReturn(run func(context.Context, string) (types.GetAccountResponse, error)) *MockAccountService_GetAccountByIBAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactions provides a mock function with given fields: ctx, accountID, limit, offset
+func (_m *MockAccountService) ListTransactions(ctx context.Context, accountID uuid.UUID, limit int32, offset int32) (types.ListTransactionsResponse, error) {
+	ret := _m.Called(ctx, accountID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 types.ListTransactionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) (types.ListTransactionsResponse, error)); ok {
+		return rf(ctx, accountID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) types.ListTransactionsResponse); ok {
+		r0 = rf(ctx, accountID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListTransactionsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int32, int32) error); ok {
+		r1 = rf(ctx, accountID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountService_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockAccountService_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - limit int32
+//   - offset int32
+func (_e *MockAccountService_Expecter) ListTransactions(ctx interface{}, accountID interface{}, limit interface{}, offset interface{}) *MockAccountService_ListTransactions_Call {
+	return &MockAccountService_ListTransactions_Call{Call: _e.mock.On("ListTransactions", ctx, accountID, limit, offset)}
+}
+
+func (_c *MockAccountService_ListTransactions_Call) Run(run func(ctx context.Context, accountID uuid.UUID, limit int32, offset int32)) *MockAccountService_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockAccountService_ListTransactions_Call) Return(_a0 types.ListTransactionsResponse, _a1 error) *MockAccountService_ListTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountService_ListTransactions_Call) RunAndReturn(run func(context.Context, uuid.UUID, int32, int32) (types.ListTransactionsResponse, error)) *MockAccountService_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactionsAfter provides a mock function with given fields: ctx, accountID, after, limit
+func (_m *MockAccountService) ListTransactionsAfter(ctx context.Context, accountID uuid.UUID, after uuid.NullUUID, limit int32) (types.ListTransactionsResponse, error) {
+	ret := _m.Called(ctx, accountID, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactionsAfter")
+	}
+
+	var r0 types.ListTransactionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.NullUUID, int32) (types.ListTransactionsResponse, error)); ok {
+		return rf(ctx, accountID, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.NullUUID, int32) types.ListTransactionsResponse); ok {
+		r0 = rf(ctx, accountID, after, limit)
+	} else {
+		r0 = ret.Get(0).(types.ListTransactionsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.NullUUID, int32) error); ok {
+		r1 = rf(ctx, accountID, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountService_ListTransactionsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactionsAfter'
+type MockAccountService_ListTransactionsAfter_Call struct {
+	*mock.Call
+}
+
+// ListTransactionsAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - after uuid.NullUUID
+//   - limit int32
+func (_e *MockAccountService_Expecter) ListTransactionsAfter(ctx interface{}, accountID interface{}, after interface{}, limit interface{}) *MockAccountService_ListTransactionsAfter_Call {
+	return &MockAccountService_ListTransactionsAfter_Call{Call: _e.mock.On("ListTransactionsAfter", ctx, accountID, after, limit)}
+}
+
+func (_c *MockAccountService_ListTransactionsAfter_Call) Run(run func(ctx context.Context, accountID uuid.UUID, after uuid.NullUUID, limit int32)) *MockAccountService_ListTransactionsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.NullUUID), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockAccountService_ListTransactionsAfter_Call) Return(_a0 types.ListTransactionsResponse, _a1 error) *MockAccountService_ListTransactionsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountService_ListTransactionsAfter_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.NullUUID, int32) (types.ListTransactionsResponse, error)) *MockAccountService_ListTransactionsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransferMoney provides a mock function with given fields: ctx, req, accountID
 func (_m *MockAccountService) TransferMoney(ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error) {
 	ret := _m.Called(ctx, req, accountID)
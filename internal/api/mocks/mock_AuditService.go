@@ -0,0 +1,153 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/zaidsasa/xbankapi/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/zaidsasa/xbankapi/types"
+)
+
+// MockAuditService is an autogenerated mock type for the AuditService type
+type MockAuditService struct {
+	mock.Mock
+}
+
+type MockAuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditService) EXPECT() *MockAuditService_Expecter {
+	return &MockAuditService_Expecter{mock: &_m.Mock}
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, filter
+func (_m *MockAuditService) ListAuditEvents(ctx context.Context, filter audit.Filter) (types.ListAuditEventsResponse, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 types.ListAuditEventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) (types.ListAuditEventsResponse, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, audit.Filter) types.ListAuditEventsResponse); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(types.ListAuditEventsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, audit.Filter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditService_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type MockAuditService_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter audit.Filter
+func (_e *MockAuditService_Expecter) ListAuditEvents(ctx interface{}, filter interface{}) *MockAuditService_ListAuditEvents_Call {
+	return &MockAuditService_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, filter)}
+}
+
+func (_c *MockAuditService_ListAuditEvents_Call) Run(run func(ctx context.Context, filter audit.Filter)) *MockAuditService_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(audit.Filter))
+	})
+	return _c
+}
+
+func (_c *MockAuditService_ListAuditEvents_Call) Return(_a0 types.ListAuditEventsResponse, _a1 error) *MockAuditService_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditService_ListAuditEvents_Call) RunAndReturn(run func(context.Context, audit.Filter) (types.ListAuditEventsResponse, error)) *MockAuditService_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyAuditChain provides a mock function with given fields: ctx
+func (_m *MockAuditService) VerifyAuditChain(ctx context.Context) (types.VerifyAuditChainResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAuditChain")
+	}
+
+	var r0 types.VerifyAuditChainResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (types.VerifyAuditChainResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) types.VerifyAuditChainResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.VerifyAuditChainResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditService_VerifyAuditChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyAuditChain'
+type MockAuditService_VerifyAuditChain_Call struct {
+	*mock.Call
+}
+
+// VerifyAuditChain is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuditService_Expecter) VerifyAuditChain(ctx interface{}) *MockAuditService_VerifyAuditChain_Call {
+	return &MockAuditService_VerifyAuditChain_Call{Call: _e.mock.On("VerifyAuditChain", ctx)}
+}
+
+func (_c *MockAuditService_VerifyAuditChain_Call) Run(run func(ctx context.Context)) *MockAuditService_VerifyAuditChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAuditService_VerifyAuditChain_Call) Return(_a0 types.VerifyAuditChainResponse, _a1 error) *MockAuditService_VerifyAuditChain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditService_VerifyAuditChain_Call) RunAndReturn(run func(context.Context) (types.VerifyAuditChainResponse, error)) *MockAuditService_VerifyAuditChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditService creates a new instance of MockAuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditService {
+	mock := &MockAuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	audit "github.com/zaidsasa/xbankapi/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// MockAuditor is an autogenerated mock type for the Auditor type
+type MockAuditor struct {
+	mock.Mock
+}
+
+type MockAuditor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditor) EXPECT() *MockAuditor_Expecter {
+	return &MockAuditor_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, tx, event
+func (_m *MockAuditor) Record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	ret := _m.Called(ctx, tx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, audit.Event) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditor_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditor_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - event audit.Event
+func (_e *MockAuditor_Expecter) Record(ctx interface{}, tx interface{}, event interface{}) *MockAuditor_Record_Call {
+	return &MockAuditor_Record_Call{Call: _e.mock.On("Record", ctx, tx, event)}
+}
+
+func (_c *MockAuditor_Record_Call) Run(run func(ctx context.Context, tx pgx.Tx, event audit.Event)) *MockAuditor_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(audit.Event))
+	})
+	return _c
+}
+
+func (_c *MockAuditor_Record_Call) Return(_a0 error) *MockAuditor_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditor_Record_Call) RunAndReturn(run func(context.Context, pgx.Tx, audit.Event) error) *MockAuditor_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditor creates a new instance of MockAuditor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditor {
+	mock := &MockAuditor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
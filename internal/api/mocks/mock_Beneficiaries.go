@@ -0,0 +1,98 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockBeneficiaries is an autogenerated mock type for the Beneficiaries type
+type MockBeneficiaries struct {
+	mock.Mock
+}
+
+type MockBeneficiaries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBeneficiaries) EXPECT() *MockBeneficiaries_Expecter {
+	return &MockBeneficiaries_Expecter{mock: &_m.Mock}
+}
+
+// Resolve provides a mock function with given fields: ctx, accountID, beneficiaryID, amount
+func (_m *MockBeneficiaries) Resolve(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID, amount int64) (uuid.UUID, error) {
+	ret := _m.Called(ctx, accountID, beneficiaryID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int64) (uuid.UUID, error)); ok {
+		return rf(ctx, accountID, beneficiaryID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int64) uuid.UUID); ok {
+		r0 = rf(ctx, accountID, beneficiaryID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, int64) error); ok {
+		r1 = rf(ctx, accountID, beneficiaryID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaries_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockBeneficiaries_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - beneficiaryID uuid.UUID
+//   - amount int64
+func (_e *MockBeneficiaries_Expecter) Resolve(ctx interface{}, accountID interface{}, beneficiaryID interface{}, amount interface{}) *MockBeneficiaries_Resolve_Call {
+	return &MockBeneficiaries_Resolve_Call{Call: _e.mock.On("Resolve", ctx, accountID, beneficiaryID, amount)}
+}
+
+func (_c *MockBeneficiaries_Resolve_Call) Run(run func(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID, amount int64)) *MockBeneficiaries_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaries_Resolve_Call) Return(_a0 uuid.UUID, _a1 error) *MockBeneficiaries_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaries_Resolve_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, int64) (uuid.UUID, error)) *MockBeneficiaries_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBeneficiaries creates a new instance of MockBeneficiaries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBeneficiaries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBeneficiaries {
+	mock := &MockBeneficiaries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
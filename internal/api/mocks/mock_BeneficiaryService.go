@@ -0,0 +1,319 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockBeneficiaryService is an autogenerated mock type for the BeneficiaryService type
+type MockBeneficiaryService struct {
+	mock.Mock
+}
+
+type MockBeneficiaryService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBeneficiaryService) EXPECT() *MockBeneficiaryService_Expecter {
+	return &MockBeneficiaryService_Expecter{mock: &_m.Mock}
+}
+
+// CreateBeneficiary provides a mock function with given fields: ctx, accountID, req
+func (_m *MockBeneficiaryService) CreateBeneficiary(ctx context.Context, accountID uuid.UUID, req *types.CreateBeneficiaryRequest) (types.CreateBeneficiaryResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBeneficiary")
+	}
+
+	var r0 types.CreateBeneficiaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreateBeneficiaryRequest) (types.CreateBeneficiaryResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreateBeneficiaryRequest) types.CreateBeneficiaryResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.CreateBeneficiaryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.CreateBeneficiaryRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryService_CreateBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBeneficiary'
+type MockBeneficiaryService_CreateBeneficiary_Call struct {
+	*mock.Call
+}
+
+// CreateBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.CreateBeneficiaryRequest
+func (_e *MockBeneficiaryService_Expecter) CreateBeneficiary(ctx interface{}, accountID interface{}, req interface{}) *MockBeneficiaryService_CreateBeneficiary_Call {
+	return &MockBeneficiaryService_CreateBeneficiary_Call{Call: _e.mock.On("CreateBeneficiary", ctx, accountID, req)}
+}
+
+func (_c *MockBeneficiaryService_CreateBeneficiary_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.CreateBeneficiaryRequest)) *MockBeneficiaryService_CreateBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.CreateBeneficiaryRequest))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryService_CreateBeneficiary_Call) Return(_a0 types.CreateBeneficiaryResponse, _a1 error) *MockBeneficiaryService_CreateBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryService_CreateBeneficiary_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.CreateBeneficiaryRequest) (types.CreateBeneficiaryResponse, error)) *MockBeneficiaryService_CreateBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBeneficiary provides a mock function with given fields: ctx, accountID, beneficiaryID
+func (_m *MockBeneficiaryService) DeleteBeneficiary(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID, beneficiaryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBeneficiary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID, beneficiaryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBeneficiaryService_DeleteBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBeneficiary'
+type MockBeneficiaryService_DeleteBeneficiary_Call struct {
+	*mock.Call
+}
+
+// DeleteBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - beneficiaryID uuid.UUID
+func (_e *MockBeneficiaryService_Expecter) DeleteBeneficiary(ctx interface{}, accountID interface{}, beneficiaryID interface{}) *MockBeneficiaryService_DeleteBeneficiary_Call {
+	return &MockBeneficiaryService_DeleteBeneficiary_Call{Call: _e.mock.On("DeleteBeneficiary", ctx, accountID, beneficiaryID)}
+}
+
+func (_c *MockBeneficiaryService_DeleteBeneficiary_Call) Run(run func(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID)) *MockBeneficiaryService_DeleteBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryService_DeleteBeneficiary_Call) Return(_a0 error) *MockBeneficiaryService_DeleteBeneficiary_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBeneficiaryService_DeleteBeneficiary_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockBeneficiaryService_DeleteBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBeneficiary provides a mock function with given fields: ctx, accountID, beneficiaryID
+func (_m *MockBeneficiaryService) GetBeneficiary(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID) (types.GetBeneficiaryResponse, error) {
+	ret := _m.Called(ctx, accountID, beneficiaryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeneficiary")
+	}
+
+	var r0 types.GetBeneficiaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (types.GetBeneficiaryResponse, error)); ok {
+		return rf(ctx, accountID, beneficiaryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) types.GetBeneficiaryResponse); ok {
+		r0 = rf(ctx, accountID, beneficiaryID)
+	} else {
+		r0 = ret.Get(0).(types.GetBeneficiaryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID, beneficiaryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryService_GetBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBeneficiary'
+type MockBeneficiaryService_GetBeneficiary_Call struct {
+	*mock.Call
+}
+
+// GetBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - beneficiaryID uuid.UUID
+func (_e *MockBeneficiaryService_Expecter) GetBeneficiary(ctx interface{}, accountID interface{}, beneficiaryID interface{}) *MockBeneficiaryService_GetBeneficiary_Call {
+	return &MockBeneficiaryService_GetBeneficiary_Call{Call: _e.mock.On("GetBeneficiary", ctx, accountID, beneficiaryID)}
+}
+
+func (_c *MockBeneficiaryService_GetBeneficiary_Call) Run(run func(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID)) *MockBeneficiaryService_GetBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryService_GetBeneficiary_Call) Return(_a0 types.GetBeneficiaryResponse, _a1 error) *MockBeneficiaryService_GetBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryService_GetBeneficiary_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (types.GetBeneficiaryResponse, error)) *MockBeneficiaryService_GetBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBeneficiaries provides a mock function with given fields: ctx, accountID
+func (_m *MockBeneficiaryService) ListBeneficiaries(ctx context.Context, accountID uuid.UUID) (types.ListBeneficiariesResponse, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBeneficiaries")
+	}
+
+	var r0 types.ListBeneficiariesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.ListBeneficiariesResponse, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.ListBeneficiariesResponse); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.ListBeneficiariesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryService_ListBeneficiaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBeneficiaries'
+type MockBeneficiaryService_ListBeneficiaries_Call struct {
+	*mock.Call
+}
+
+// ListBeneficiaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockBeneficiaryService_Expecter) ListBeneficiaries(ctx interface{}, accountID interface{}) *MockBeneficiaryService_ListBeneficiaries_Call {
+	return &MockBeneficiaryService_ListBeneficiaries_Call{Call: _e.mock.On("ListBeneficiaries", ctx, accountID)}
+}
+
+func (_c *MockBeneficiaryService_ListBeneficiaries_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockBeneficiaryService_ListBeneficiaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryService_ListBeneficiaries_Call) Return(_a0 types.ListBeneficiariesResponse, _a1 error) *MockBeneficiaryService_ListBeneficiaries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryService_ListBeneficiaries_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.ListBeneficiariesResponse, error)) *MockBeneficiaryService_ListBeneficiaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBeneficiary provides a mock function with given fields: ctx, accountID, beneficiaryID, req
+func (_m *MockBeneficiaryService) UpdateBeneficiary(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID, req *types.UpdateBeneficiaryRequest) (types.UpdateBeneficiaryResponse, error) {
+	ret := _m.Called(ctx, accountID, beneficiaryID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBeneficiary")
+	}
+
+	var r0 types.UpdateBeneficiaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.UpdateBeneficiaryRequest) (types.UpdateBeneficiaryResponse, error)); ok {
+		return rf(ctx, accountID, beneficiaryID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.UpdateBeneficiaryRequest) types.UpdateBeneficiaryResponse); ok {
+		r0 = rf(ctx, accountID, beneficiaryID, req)
+	} else {
+		r0 = ret.Get(0).(types.UpdateBeneficiaryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *types.UpdateBeneficiaryRequest) error); ok {
+		r1 = rf(ctx, accountID, beneficiaryID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryService_UpdateBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBeneficiary'
+type MockBeneficiaryService_UpdateBeneficiary_Call struct {
+	*mock.Call
+}
+
+// UpdateBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - beneficiaryID uuid.UUID
+//   - req *types.UpdateBeneficiaryRequest
+func (_e *MockBeneficiaryService_Expecter) UpdateBeneficiary(ctx interface{}, accountID interface{}, beneficiaryID interface{}, req interface{}) *MockBeneficiaryService_UpdateBeneficiary_Call {
+	return &MockBeneficiaryService_UpdateBeneficiary_Call{Call: _e.mock.On("UpdateBeneficiary", ctx, accountID, beneficiaryID, req)}
+}
+
+func (_c *MockBeneficiaryService_UpdateBeneficiary_Call) Run(run func(ctx context.Context, accountID uuid.UUID, beneficiaryID uuid.UUID, req *types.UpdateBeneficiaryRequest)) *MockBeneficiaryService_UpdateBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*types.UpdateBeneficiaryRequest))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryService_UpdateBeneficiary_Call) Return(_a0 types.UpdateBeneficiaryResponse, _a1 error) *MockBeneficiaryService_UpdateBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryService_UpdateBeneficiary_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *types.UpdateBeneficiaryRequest) (types.UpdateBeneficiaryResponse, error)) *MockBeneficiaryService_UpdateBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBeneficiaryService creates a new instance of MockBeneficiaryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBeneficiaryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBeneficiaryService {
+	mock := &MockBeneficiaryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,269 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockCustomerService is an autogenerated mock type for the CustomerService type
+type MockCustomerService struct {
+	mock.Mock
+}
+
+type MockCustomerService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCustomerService) EXPECT() *MockCustomerService_Expecter {
+	return &MockCustomerService_Expecter{mock: &_m.Mock}
+}
+
+// CreateAccount provides a mock function with given fields: ctx, customerID, req
+func (_m *MockCustomerService) CreateAccount(ctx context.Context, customerID uuid.UUID, req *types.CreateCustomerAccountRequest) (types.CreateAccountResponse, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccount")
+	}
+
+	var r0 types.CreateAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreateCustomerAccountRequest) (types.CreateAccountResponse, error)); ok {
+		return rf(ctx, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreateCustomerAccountRequest) types.CreateAccountResponse); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		r0 = ret.Get(0).(types.CreateAccountResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.CreateCustomerAccountRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerService_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type MockCustomerService_CreateAccount_Call struct {
+	*mock.Call
+}
+
+// CreateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+//   - req *types.CreateCustomerAccountRequest
+func (_e *MockCustomerService_Expecter) CreateAccount(ctx interface{}, customerID interface{}, req interface{}) *MockCustomerService_CreateAccount_Call {
+	return &MockCustomerService_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, customerID, req)}
+}
+
+func (_c *MockCustomerService_CreateAccount_Call) Run(run func(ctx context.Context, customerID uuid.UUID, req *types.CreateCustomerAccountRequest)) *MockCustomerService_CreateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.CreateCustomerAccountRequest))
+	})
+	return _c
+}
+
+func (_c *MockCustomerService_CreateAccount_Call) Return(_a0 types.CreateAccountResponse, _a1 error) *MockCustomerService_CreateAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerService_CreateAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.CreateCustomerAccountRequest) (types.CreateAccountResponse, error)) *MockCustomerService_CreateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCustomer provides a mock function with given fields: ctx, req
+func (_m *MockCustomerService) CreateCustomer(ctx context.Context, req *types.CreateCustomerRequest) (types.CreateCustomerResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomer")
+	}
+
+	var r0 types.CreateCustomerResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateCustomerRequest) (types.CreateCustomerResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateCustomerRequest) types.CreateCustomerResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(types.CreateCustomerResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.CreateCustomerRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerService_CreateCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCustomer'
+type MockCustomerService_CreateCustomer_Call struct {
+	*mock.Call
+}
+
+// CreateCustomer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.CreateCustomerRequest
+func (_e *MockCustomerService_Expecter) CreateCustomer(ctx interface{}, req interface{}) *MockCustomerService_CreateCustomer_Call {
+	return &MockCustomerService_CreateCustomer_Call{Call: _e.mock.On("CreateCustomer", ctx, req)}
+}
+
+func (_c *MockCustomerService_CreateCustomer_Call) Run(run func(ctx context.Context, req *types.CreateCustomerRequest)) *MockCustomerService_CreateCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CreateCustomerRequest))
+	})
+	return _c
+}
+
+func (_c *MockCustomerService_CreateCustomer_Call) Return(_a0 types.CreateCustomerResponse, _a1 error) *MockCustomerService_CreateCustomer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerService_CreateCustomer_Call) RunAndReturn(run func(context.Context, *types.CreateCustomerRequest) (types.CreateCustomerResponse, error)) *MockCustomerService_CreateCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccounts provides a mock function with given fields: ctx, customerID
+func (_m *MockCustomerService) ListAccounts(ctx context.Context, customerID uuid.UUID) (types.ListCustomerAccountsResponse, error) {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccounts")
+	}
+
+	var r0 types.ListCustomerAccountsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.ListCustomerAccountsResponse, error)); ok {
+		return rf(ctx, customerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.ListCustomerAccountsResponse); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		r0 = ret.Get(0).(types.ListCustomerAccountsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerService_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
+type MockCustomerService_ListAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+func (_e *MockCustomerService_Expecter) ListAccounts(ctx interface{}, customerID interface{}) *MockCustomerService_ListAccounts_Call {
+	return &MockCustomerService_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx, customerID)}
+}
+
+func (_c *MockCustomerService_ListAccounts_Call) Run(run func(ctx context.Context, customerID uuid.UUID)) *MockCustomerService_ListAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCustomerService_ListAccounts_Call) Return(_a0 types.ListCustomerAccountsResponse, _a1 error) *MockCustomerService_ListAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerService_ListAccounts_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.ListCustomerAccountsResponse, error)) *MockCustomerService_ListAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetKYCStatus provides a mock function with given fields: ctx, customerID, req
+func (_m *MockCustomerService) SetKYCStatus(ctx context.Context, customerID uuid.UUID, req *types.SetKYCStatusRequest) (types.SetKYCStatusResponse, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetKYCStatus")
+	}
+
+	var r0 types.SetKYCStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetKYCStatusRequest) (types.SetKYCStatusResponse, error)); ok {
+		return rf(ctx, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetKYCStatusRequest) types.SetKYCStatusResponse); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetKYCStatusResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.SetKYCStatusRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerService_SetKYCStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetKYCStatus'
+type MockCustomerService_SetKYCStatus_Call struct {
+	*mock.Call
+}
+
+// SetKYCStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+//   - req *types.SetKYCStatusRequest
+func (_e *MockCustomerService_Expecter) SetKYCStatus(ctx interface{}, customerID interface{}, req interface{}) *MockCustomerService_SetKYCStatus_Call {
+	return &MockCustomerService_SetKYCStatus_Call{Call: _e.mock.On("SetKYCStatus", ctx, customerID, req)}
+}
+
+func (_c *MockCustomerService_SetKYCStatus_Call) Run(run func(ctx context.Context, customerID uuid.UUID, req *types.SetKYCStatusRequest)) *MockCustomerService_SetKYCStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.SetKYCStatusRequest))
+	})
+	return _c
+}
+
+func (_c *MockCustomerService_SetKYCStatus_Call) Return(_a0 types.SetKYCStatusResponse, _a1 error) *MockCustomerService_SetKYCStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerService_SetKYCStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.SetKYCStatusRequest) (types.SetKYCStatusResponse, error)) *MockCustomerService_SetKYCStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCustomerService creates a new instance of MockCustomerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCustomerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCustomerService {
+	mock := &MockCustomerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
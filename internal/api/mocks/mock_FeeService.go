@@ -0,0 +1,261 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockFeeService is an autogenerated mock type for the FeeService type
+type MockFeeService struct {
+	mock.Mock
+}
+
+type MockFeeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFeeService) EXPECT() *MockFeeService_Expecter {
+	return &MockFeeService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFeeSchedule provides a mock function with given fields: ctx, productCode, feeType
+func (_m *MockFeeService) DeleteFeeSchedule(ctx context.Context, productCode string, feeType string) error {
+	ret := _m.Called(ctx, productCode, feeType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeeSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productCode, feeType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFeeService_DeleteFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeeSchedule'
+type MockFeeService_DeleteFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+//   - feeType string
+func (_e *MockFeeService_Expecter) DeleteFeeSchedule(ctx interface{}, productCode interface{}, feeType interface{}) *MockFeeService_DeleteFeeSchedule_Call {
+	return &MockFeeService_DeleteFeeSchedule_Call{Call: _e.mock.On("DeleteFeeSchedule", ctx, productCode, feeType)}
+}
+
+func (_c *MockFeeService_DeleteFeeSchedule_Call) Run(run func(ctx context.Context, productCode string, feeType string)) *MockFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_DeleteFeeSchedule_Call) Return(_a0 error) *MockFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFeeService_DeleteFeeSchedule_Call) RunAndReturn(run func(context.Context, string, string) error) *MockFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFeeSchedules provides a mock function with given fields: ctx, productCode
+func (_m *MockFeeService) ListFeeSchedules(ctx context.Context, productCode string) (types.ListFeeSchedulesResponse, error) {
+	ret := _m.Called(ctx, productCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFeeSchedules")
+	}
+
+	var r0 types.ListFeeSchedulesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (types.ListFeeSchedulesResponse, error)); ok {
+		return rf(ctx, productCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.ListFeeSchedulesResponse); ok {
+		r0 = rf(ctx, productCode)
+	} else {
+		r0 = ret.Get(0).(types.ListFeeSchedulesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_ListFeeSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFeeSchedules'
+type MockFeeService_ListFeeSchedules_Call struct {
+	*mock.Call
+}
+
+// ListFeeSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+func (_e *MockFeeService_Expecter) ListFeeSchedules(ctx interface{}, productCode interface{}) *MockFeeService_ListFeeSchedules_Call {
+	return &MockFeeService_ListFeeSchedules_Call{Call: _e.mock.On("ListFeeSchedules", ctx, productCode)}
+}
+
+func (_c *MockFeeService_ListFeeSchedules_Call) Run(run func(ctx context.Context, productCode string)) *MockFeeService_ListFeeSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_ListFeeSchedules_Call) Return(_a0 types.ListFeeSchedulesResponse, _a1 error) *MockFeeService_ListFeeSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_ListFeeSchedules_Call) RunAndReturn(run func(context.Context, string) (types.ListFeeSchedulesResponse, error)) *MockFeeService_ListFeeSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewTransferFee provides a mock function with given fields: ctx, accountID, amount
+func (_m *MockFeeService) PreviewTransferFee(ctx context.Context, accountID uuid.UUID, amount int64) (types.TransferFeePreview, error) {
+	ret := _m.Called(ctx, accountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewTransferFee")
+	}
+
+	var r0 types.TransferFeePreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (types.TransferFeePreview, error)); ok {
+		return rf(ctx, accountID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) types.TransferFeePreview); ok {
+		r0 = rf(ctx, accountID, amount)
+	} else {
+		r0 = ret.Get(0).(types.TransferFeePreview)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) error); ok {
+		r1 = rf(ctx, accountID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_PreviewTransferFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewTransferFee'
+type MockFeeService_PreviewTransferFee_Call struct {
+	*mock.Call
+}
+
+// PreviewTransferFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - amount int64
+func (_e *MockFeeService_Expecter) PreviewTransferFee(ctx interface{}, accountID interface{}, amount interface{}) *MockFeeService_PreviewTransferFee_Call {
+	return &MockFeeService_PreviewTransferFee_Call{Call: _e.mock.On("PreviewTransferFee", ctx, accountID, amount)}
+}
+
+func (_c *MockFeeService_PreviewTransferFee_Call) Run(run func(ctx context.Context, accountID uuid.UUID, amount int64)) *MockFeeService_PreviewTransferFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_PreviewTransferFee_Call) Return(_a0 types.TransferFeePreview, _a1 error) *MockFeeService_PreviewTransferFee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_PreviewTransferFee_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) (types.TransferFeePreview, error)) *MockFeeService_PreviewTransferFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFeeSchedule provides a mock function with given fields: ctx, productCode, feeType, req
+func (_m *MockFeeService) SetFeeSchedule(ctx context.Context, productCode string, feeType string, req *types.SetFeeScheduleRequest) (types.SetFeeScheduleResponse, error) {
+	ret := _m.Called(ctx, productCode, feeType, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFeeSchedule")
+	}
+
+	var r0 types.SetFeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *types.SetFeeScheduleRequest) (types.SetFeeScheduleResponse, error)); ok {
+		return rf(ctx, productCode, feeType, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *types.SetFeeScheduleRequest) types.SetFeeScheduleResponse); ok {
+		r0 = rf(ctx, productCode, feeType, req)
+	} else {
+		r0 = ret.Get(0).(types.SetFeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *types.SetFeeScheduleRequest) error); ok {
+		r1 = rf(ctx, productCode, feeType, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_SetFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFeeSchedule'
+type MockFeeService_SetFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// SetFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+//   - feeType string
+//   - req *types.SetFeeScheduleRequest
+func (_e *MockFeeService_Expecter) SetFeeSchedule(ctx interface{}, productCode interface{}, feeType interface{}, req interface{}) *MockFeeService_SetFeeSchedule_Call {
+	return &MockFeeService_SetFeeSchedule_Call{Call: _e.mock.On("SetFeeSchedule", ctx, productCode, feeType, req)}
+}
+
+func (_c *MockFeeService_SetFeeSchedule_Call) Run(run func(ctx context.Context, productCode string, feeType string, req *types.SetFeeScheduleRequest)) *MockFeeService_SetFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*types.SetFeeScheduleRequest))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_SetFeeSchedule_Call) Return(_a0 types.SetFeeScheduleResponse, _a1 error) *MockFeeService_SetFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_SetFeeSchedule_Call) RunAndReturn(run func(context.Context, string, string, *types.SetFeeScheduleRequest) (types.SetFeeScheduleResponse, error)) *MockFeeService_SetFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFeeService creates a new instance of MockFeeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeService {
+	mock := &MockFeeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,150 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockFees is an autogenerated mock type for the Fees type
+type MockFees struct {
+	mock.Mock
+}
+
+type MockFees_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFees) EXPECT() *MockFees_Expecter {
+	return &MockFees_Expecter{mock: &_m.Mock}
+}
+
+// Charge provides a mock function with given fields: ctx, tx, accountID, currencyCode, transactionID, fee
+func (_m *MockFees) Charge(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, currencyCode string, transactionID uuid.UUID, fee int64) error {
+	ret := _m.Called(ctx, tx, accountID, currencyCode, transactionID, fee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Charge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, uuid.UUID, string, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, tx, accountID, currencyCode, transactionID, fee)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFees_Charge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Charge'
+type MockFees_Charge_Call struct {
+	*mock.Call
+}
+
+// Charge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - accountID uuid.UUID
+//   - currencyCode string
+//   - transactionID uuid.UUID
+//   - fee int64
+func (_e *MockFees_Expecter) Charge(ctx interface{}, tx interface{}, accountID interface{}, currencyCode interface{}, transactionID interface{}, fee interface{}) *MockFees_Charge_Call {
+	return &MockFees_Charge_Call{Call: _e.mock.On("Charge", ctx, tx, accountID, currencyCode, transactionID, fee)}
+}
+
+func (_c *MockFees_Charge_Call) Run(run func(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, currencyCode string, transactionID uuid.UUID, fee int64)) *MockFees_Charge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(uuid.UUID), args[3].(string), args[4].(uuid.UUID), args[5].(int64))
+	})
+	return _c
+}
+
+func (_c *MockFees_Charge_Call) Return(_a0 error) *MockFees_Charge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFees_Charge_Call) RunAndReturn(run func(context.Context, pgx.Tx, uuid.UUID, string, uuid.UUID, int64) error) *MockFees_Charge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferFee provides a mock function with given fields: ctx, accountID, productCode, currencyCode, amount
+func (_m *MockFees) TransferFee(ctx context.Context, accountID uuid.UUID, productCode string, currencyCode string, amount int64) (int64, error) {
+	ret := _m.Called(ctx, accountID, productCode, currencyCode, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferFee")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, int64) (int64, error)); ok {
+		return rf(ctx, accountID, productCode, currencyCode, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, int64) int64); ok {
+		r0 = rf(ctx, accountID, productCode, currencyCode, amount)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, int64) error); ok {
+		r1 = rf(ctx, accountID, productCode, currencyCode, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFees_TransferFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferFee'
+type MockFees_TransferFee_Call struct {
+	*mock.Call
+}
+
+// TransferFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - productCode string
+//   - currencyCode string
+//   - amount int64
+func (_e *MockFees_Expecter) TransferFee(ctx interface{}, accountID interface{}, productCode interface{}, currencyCode interface{}, amount interface{}) *MockFees_TransferFee_Call {
+	return &MockFees_TransferFee_Call{Call: _e.mock.On("TransferFee", ctx, accountID, productCode, currencyCode, amount)}
+}
+
+func (_c *MockFees_TransferFee_Call) Run(run func(ctx context.Context, accountID uuid.UUID, productCode string, currencyCode string, amount int64)) *MockFees_TransferFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockFees_TransferFee_Call) Return(_a0 int64, _a1 error) *MockFees_TransferFee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFees_TransferFee_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, int64) (int64, error)) *MockFees_TransferFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFees creates a new instance of MockFees. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFees(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFees {
+	mock := &MockFees{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,308 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHolderService is an autogenerated mock type for the HolderService type
+type MockHolderService struct {
+	mock.Mock
+}
+
+type MockHolderService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHolderService) EXPECT() *MockHolderService_Expecter {
+	return &MockHolderService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteMandate provides a mock function with given fields: ctx, accountID
+func (_m *MockHolderService) DeleteMandate(ctx context.Context, accountID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMandate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHolderService_DeleteMandate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMandate'
+type MockHolderService_DeleteMandate_Call struct {
+	*mock.Call
+}
+
+// DeleteMandate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockHolderService_Expecter) DeleteMandate(ctx interface{}, accountID interface{}) *MockHolderService_DeleteMandate_Call {
+	return &MockHolderService_DeleteMandate_Call{Call: _e.mock.On("DeleteMandate", ctx, accountID)}
+}
+
+func (_c *MockHolderService_DeleteMandate_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockHolderService_DeleteMandate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderService_DeleteMandate_Call) Return(_a0 error) *MockHolderService_DeleteMandate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHolderService_DeleteMandate_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockHolderService_DeleteMandate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHolders provides a mock function with given fields: ctx, accountID
+func (_m *MockHolderService) ListHolders(ctx context.Context, accountID uuid.UUID) (types.ListAccountHoldersResponse, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHolders")
+	}
+
+	var r0 types.ListAccountHoldersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.ListAccountHoldersResponse, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.ListAccountHoldersResponse); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.ListAccountHoldersResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderService_ListHolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHolders'
+type MockHolderService_ListHolders_Call struct {
+	*mock.Call
+}
+
+// ListHolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockHolderService_Expecter) ListHolders(ctx interface{}, accountID interface{}) *MockHolderService_ListHolders_Call {
+	return &MockHolderService_ListHolders_Call{Call: _e.mock.On("ListHolders", ctx, accountID)}
+}
+
+func (_c *MockHolderService_ListHolders_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockHolderService_ListHolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderService_ListHolders_Call) Return(_a0 types.ListAccountHoldersResponse, _a1 error) *MockHolderService_ListHolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderService_ListHolders_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.ListAccountHoldersResponse, error)) *MockHolderService_ListHolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveHolder provides a mock function with given fields: ctx, accountID, customerID
+func (_m *MockHolderService) RemoveHolder(ctx context.Context, accountID uuid.UUID, customerID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveHolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID, customerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHolderService_RemoveHolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveHolder'
+type MockHolderService_RemoveHolder_Call struct {
+	*mock.Call
+}
+
+// RemoveHolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - customerID uuid.UUID
+func (_e *MockHolderService_Expecter) RemoveHolder(ctx interface{}, accountID interface{}, customerID interface{}) *MockHolderService_RemoveHolder_Call {
+	return &MockHolderService_RemoveHolder_Call{Call: _e.mock.On("RemoveHolder", ctx, accountID, customerID)}
+}
+
+func (_c *MockHolderService_RemoveHolder_Call) Run(run func(ctx context.Context, accountID uuid.UUID, customerID uuid.UUID)) *MockHolderService_RemoveHolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderService_RemoveHolder_Call) Return(_a0 error) *MockHolderService_RemoveHolder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHolderService_RemoveHolder_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockHolderService_RemoveHolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHolder provides a mock function with given fields: ctx, accountID, customerID, req
+func (_m *MockHolderService) SetHolder(ctx context.Context, accountID uuid.UUID, customerID uuid.UUID, req *types.SetAccountHolderRequest) (types.SetAccountHolderResponse, error) {
+	ret := _m.Called(ctx, accountID, customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHolder")
+	}
+
+	var r0 types.SetAccountHolderResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetAccountHolderRequest) (types.SetAccountHolderResponse, error)); ok {
+		return rf(ctx, accountID, customerID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetAccountHolderRequest) types.SetAccountHolderResponse); ok {
+		r0 = rf(ctx, accountID, customerID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetAccountHolderResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetAccountHolderRequest) error); ok {
+		r1 = rf(ctx, accountID, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderService_SetHolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHolder'
+type MockHolderService_SetHolder_Call struct {
+	*mock.Call
+}
+
+// SetHolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - customerID uuid.UUID
+//   - req *types.SetAccountHolderRequest
+func (_e *MockHolderService_Expecter) SetHolder(ctx interface{}, accountID interface{}, customerID interface{}, req interface{}) *MockHolderService_SetHolder_Call {
+	return &MockHolderService_SetHolder_Call{Call: _e.mock.On("SetHolder", ctx, accountID, customerID, req)}
+}
+
+func (_c *MockHolderService_SetHolder_Call) Run(run func(ctx context.Context, accountID uuid.UUID, customerID uuid.UUID, req *types.SetAccountHolderRequest)) *MockHolderService_SetHolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*types.SetAccountHolderRequest))
+	})
+	return _c
+}
+
+func (_c *MockHolderService_SetHolder_Call) Return(_a0 types.SetAccountHolderResponse, _a1 error) *MockHolderService_SetHolder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderService_SetHolder_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *types.SetAccountHolderRequest) (types.SetAccountHolderResponse, error)) *MockHolderService_SetHolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMandate provides a mock function with given fields: ctx, accountID, req
+func (_m *MockHolderService) SetMandate(ctx context.Context, accountID uuid.UUID, req *types.SetMandateRequest) (types.SetMandateResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMandate")
+	}
+
+	var r0 types.SetMandateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetMandateRequest) (types.SetMandateResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetMandateRequest) types.SetMandateResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetMandateResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.SetMandateRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderService_SetMandate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMandate'
+type MockHolderService_SetMandate_Call struct {
+	*mock.Call
+}
+
+// SetMandate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.SetMandateRequest
+func (_e *MockHolderService_Expecter) SetMandate(ctx interface{}, accountID interface{}, req interface{}) *MockHolderService_SetMandate_Call {
+	return &MockHolderService_SetMandate_Call{Call: _e.mock.On("SetMandate", ctx, accountID, req)}
+}
+
+func (_c *MockHolderService_SetMandate_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.SetMandateRequest)) *MockHolderService_SetMandate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.SetMandateRequest))
+	})
+	return _c
+}
+
+func (_c *MockHolderService_SetMandate_Call) Return(_a0 types.SetMandateResponse, _a1 error) *MockHolderService_SetMandate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderService_SetMandate_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.SetMandateRequest) (types.SetMandateResponse, error)) *MockHolderService_SetMandate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHolderService creates a new instance of MockHolderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHolderService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHolderService {
+	mock := &MockHolderService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,188 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	holder "github.com/zaidsasa/xbankapi/internal/holder"
+
+	pgx "github.com/jackc/pgx/v5"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHolders is an autogenerated mock type for the Holders type
+type MockHolders struct {
+	mock.Mock
+}
+
+type MockHolders_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHolders) EXPECT() *MockHolders_Expecter {
+	return &MockHolders_Expecter{mock: &_m.Mock}
+}
+
+// Authorize provides a mock function with given fields: ctx, accountID, permission
+func (_m *MockHolders) Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error {
+	ret := _m.Called(ctx, accountID, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, holder.Permission) error); ok {
+		r0 = rf(ctx, accountID, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHolders_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type MockHolders_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - permission holder.Permission
+func (_e *MockHolders_Expecter) Authorize(ctx interface{}, accountID interface{}, permission interface{}) *MockHolders_Authorize_Call {
+	return &MockHolders_Authorize_Call{Call: _e.mock.On("Authorize", ctx, accountID, permission)}
+}
+
+func (_c *MockHolders_Authorize_Call) Run(run func(ctx context.Context, accountID uuid.UUID, permission holder.Permission)) *MockHolders_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(holder.Permission))
+	})
+	return _c
+}
+
+func (_c *MockHolders_Authorize_Call) Return(_a0 error) *MockHolders_Authorize_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHolders_Authorize_Call) RunAndReturn(run func(context.Context, uuid.UUID, holder.Permission) error) *MockHolders_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckKYC provides a mock function with given fields: ctx, customerID
+func (_m *MockHolders) CheckKYC(ctx context.Context, customerID uuid.UUID) error {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckKYC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHolders_CheckKYC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckKYC'
+type MockHolders_CheckKYC_Call struct {
+	*mock.Call
+}
+
+// CheckKYC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+func (_e *MockHolders_Expecter) CheckKYC(ctx interface{}, customerID interface{}) *MockHolders_CheckKYC_Call {
+	return &MockHolders_CheckKYC_Call{Call: _e.mock.On("CheckKYC", ctx, customerID)}
+}
+
+func (_c *MockHolders_CheckKYC_Call) Run(run func(ctx context.Context, customerID uuid.UUID)) *MockHolders_CheckKYC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolders_CheckKYC_Call) Return(_a0 error) *MockHolders_CheckKYC_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHolders_CheckKYC_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockHolders_CheckKYC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Hold provides a mock function with given fields: ctx, tx, account, reciverAccountID, amount
+func (_m *MockHolders) Hold(ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, tx, account, reciverAccountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Hold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, storage.Account, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, tx, account, reciverAccountID, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHolders_Hold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hold'
+type MockHolders_Hold_Call struct {
+	*mock.Call
+}
+
+// Hold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - account storage.Account
+//   - reciverAccountID uuid.UUID
+//   - amount int64
+func (_e *MockHolders_Expecter) Hold(ctx interface{}, tx interface{}, account interface{}, reciverAccountID interface{}, amount interface{}) *MockHolders_Hold_Call {
+	return &MockHolders_Hold_Call{Call: _e.mock.On("Hold", ctx, tx, account, reciverAccountID, amount)}
+}
+
+func (_c *MockHolders_Hold_Call) Run(run func(ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount int64)) *MockHolders_Hold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(storage.Account), args[3].(uuid.UUID), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockHolders_Hold_Call) Return(_a0 error) *MockHolders_Hold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHolders_Hold_Call) RunAndReturn(run func(context.Context, pgx.Tx, storage.Account, uuid.UUID, int64) error) *MockHolders_Hold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHolders creates a new instance of MockHolders. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHolders(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHolders {
+	mock := &MockHolders{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
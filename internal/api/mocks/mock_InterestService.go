@@ -0,0 +1,98 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockInterestService is an autogenerated mock type for the InterestService type
+type MockInterestService struct {
+	mock.Mock
+}
+
+type MockInterestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterestService) EXPECT() *MockInterestService_Expecter {
+	return &MockInterestService_Expecter{mock: &_m.Mock}
+}
+
+// ListAccruals provides a mock function with given fields: ctx, accountID, limit, offset
+func (_m *MockInterestService) ListAccruals(ctx context.Context, accountID uuid.UUID, limit int32, offset int32) (types.ListInterestAccrualsResponse, error) {
+	ret := _m.Called(ctx, accountID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccruals")
+	}
+
+	var r0 types.ListInterestAccrualsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) (types.ListInterestAccrualsResponse, error)); ok {
+		return rf(ctx, accountID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) types.ListInterestAccrualsResponse); ok {
+		r0 = rf(ctx, accountID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListInterestAccrualsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int32, int32) error); ok {
+		r1 = rf(ctx, accountID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestService_ListAccruals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccruals'
+type MockInterestService_ListAccruals_Call struct {
+	*mock.Call
+}
+
+// ListAccruals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - limit int32
+//   - offset int32
+func (_e *MockInterestService_Expecter) ListAccruals(ctx interface{}, accountID interface{}, limit interface{}, offset interface{}) *MockInterestService_ListAccruals_Call {
+	return &MockInterestService_ListAccruals_Call{Call: _e.mock.On("ListAccruals", ctx, accountID, limit, offset)}
+}
+
+func (_c *MockInterestService_ListAccruals_Call) Run(run func(ctx context.Context, accountID uuid.UUID, limit int32, offset int32)) *MockInterestService_ListAccruals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockInterestService_ListAccruals_Call) Return(_a0 types.ListInterestAccrualsResponse, _a1 error) *MockInterestService_ListAccruals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestService_ListAccruals_Call) RunAndReturn(run func(context.Context, uuid.UUID, int32, int32) (types.ListInterestAccrualsResponse, error)) *MockInterestService_ListAccruals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterestService creates a new instance of MockInterestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterestService {
+	mock := &MockInterestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
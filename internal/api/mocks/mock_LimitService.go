@@ -0,0 +1,212 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockLimitService is an autogenerated mock type for the LimitService type
+type MockLimitService struct {
+	mock.Mock
+}
+
+type MockLimitService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLimitService) EXPECT() *MockLimitService_Expecter {
+	return &MockLimitService_Expecter{mock: &_m.Mock}
+}
+
+// GetAccountLimits provides a mock function with given fields: ctx, accountID
+func (_m *MockLimitService) GetAccountLimits(ctx context.Context, accountID uuid.UUID) (types.GetAccountLimitsResponse, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountLimits")
+	}
+
+	var r0 types.GetAccountLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.GetAccountLimitsResponse, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.GetAccountLimitsResponse); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.GetAccountLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitService_GetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountLimits'
+type MockLimitService_GetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// GetAccountLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockLimitService_Expecter) GetAccountLimits(ctx interface{}, accountID interface{}) *MockLimitService_GetAccountLimits_Call {
+	return &MockLimitService_GetAccountLimits_Call{Call: _e.mock.On("GetAccountLimits", ctx, accountID)}
+}
+
+func (_c *MockLimitService_GetAccountLimits_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockLimitService_GetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_GetAccountLimits_Call) Return(_a0 types.GetAccountLimitsResponse, _a1 error) *MockLimitService_GetAccountLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitService_GetAccountLimits_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.GetAccountLimitsResponse, error)) *MockLimitService_GetAccountLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountLimits provides a mock function with given fields: ctx, accountID, req
+func (_m *MockLimitService) SetAccountLimits(ctx context.Context, accountID uuid.UUID, req *types.SetAccountLimitsRequest) (types.SetAccountLimitsResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountLimits")
+	}
+
+	var r0 types.SetAccountLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetAccountLimitsRequest) (types.SetAccountLimitsResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetAccountLimitsRequest) types.SetAccountLimitsResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetAccountLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.SetAccountLimitsRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitService_SetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountLimits'
+type MockLimitService_SetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// SetAccountLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.SetAccountLimitsRequest
+func (_e *MockLimitService_Expecter) SetAccountLimits(ctx interface{}, accountID interface{}, req interface{}) *MockLimitService_SetAccountLimits_Call {
+	return &MockLimitService_SetAccountLimits_Call{Call: _e.mock.On("SetAccountLimits", ctx, accountID, req)}
+}
+
+func (_c *MockLimitService_SetAccountLimits_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.SetAccountLimitsRequest)) *MockLimitService_SetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.SetAccountLimitsRequest))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_SetAccountLimits_Call) Return(_a0 types.SetAccountLimitsResponse, _a1 error) *MockLimitService_SetAccountLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitService_SetAccountLimits_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.SetAccountLimitsRequest) (types.SetAccountLimitsResponse, error)) *MockLimitService_SetAccountLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLimitTier provides a mock function with given fields: ctx, tier, req
+func (_m *MockLimitService) SetLimitTier(ctx context.Context, tier string, req *types.SetLimitTierRequest) (types.SetLimitTierResponse, error) {
+	ret := _m.Called(ctx, tier, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLimitTier")
+	}
+
+	var r0 types.SetLimitTierResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.SetLimitTierRequest) (types.SetLimitTierResponse, error)); ok {
+		return rf(ctx, tier, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.SetLimitTierRequest) types.SetLimitTierResponse); ok {
+		r0 = rf(ctx, tier, req)
+	} else {
+		r0 = ret.Get(0).(types.SetLimitTierResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *types.SetLimitTierRequest) error); ok {
+		r1 = rf(ctx, tier, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitService_SetLimitTier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLimitTier'
+type MockLimitService_SetLimitTier_Call struct {
+	*mock.Call
+}
+
+// SetLimitTier is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tier string
+//   - req *types.SetLimitTierRequest
+func (_e *MockLimitService_Expecter) SetLimitTier(ctx interface{}, tier interface{}, req interface{}) *MockLimitService_SetLimitTier_Call {
+	return &MockLimitService_SetLimitTier_Call{Call: _e.mock.On("SetLimitTier", ctx, tier, req)}
+}
+
+func (_c *MockLimitService_SetLimitTier_Call) Run(run func(ctx context.Context, tier string, req *types.SetLimitTierRequest)) *MockLimitService_SetLimitTier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*types.SetLimitTierRequest))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_SetLimitTier_Call) Return(_a0 types.SetLimitTierResponse, _a1 error) *MockLimitService_SetLimitTier_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitService_SetLimitTier_Call) RunAndReturn(run func(context.Context, string, *types.SetLimitTierRequest) (types.SetLimitTierResponse, error)) *MockLimitService_SetLimitTier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLimitService creates a new instance of MockLimitService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLimitService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLimitService {
+	mock := &MockLimitService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockLimits is an autogenerated mock type for the Limits type
+type MockLimits struct {
+	mock.Mock
+}
+
+type MockLimits_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLimits) EXPECT() *MockLimits_Expecter {
+	return &MockLimits_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx, tx, accountID, amount
+func (_m *MockLimits) Check(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, tx, accountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, tx, accountID, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLimits_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockLimits_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - accountID uuid.UUID
+//   - amount int64
+func (_e *MockLimits_Expecter) Check(ctx interface{}, tx interface{}, accountID interface{}, amount interface{}) *MockLimits_Check_Call {
+	return &MockLimits_Check_Call{Call: _e.mock.On("Check", ctx, tx, accountID, amount)}
+}
+
+func (_c *MockLimits_Check_Call) Run(run func(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount int64)) *MockLimits_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(uuid.UUID), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockLimits_Check_Call) Return(_a0 error) *MockLimits_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimits_Check_Call) RunAndReturn(run func(context.Context, pgx.Tx, uuid.UUID, int64) error) *MockLimits_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLimits creates a new instance of MockLimits. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLimits(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLimits {
+	mock := &MockLimits{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockMetrics is an autogenerated mock type for the Metrics type
+type MockMetrics struct {
+	mock.Mock
+}
+
+type MockMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetrics) EXPECT() *MockMetrics_Expecter {
+	return &MockMetrics_Expecter{mock: &_m.Mock}
+}
+
+// AccountCreated provides a mock function with given fields:
+func (_m *MockMetrics) AccountCreated() {
+	_m.Called()
+}
+
+// MockMetrics_AccountCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountCreated'
+type MockMetrics_AccountCreated_Call struct {
+	*mock.Call
+}
+
+// AccountCreated is a helper method to define mock.On call
+func (_e *MockMetrics_Expecter) AccountCreated() *MockMetrics_AccountCreated_Call {
+	return &MockMetrics_AccountCreated_Call{Call: _e.mock.On("AccountCreated")}
+}
+
+func (_c *MockMetrics_AccountCreated_Call) Run(run func()) *MockMetrics_AccountCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMetrics_AccountCreated_Call) Return() *MockMetrics_AccountCreated_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_AccountCreated_Call) RunAndReturn(run func()) *MockMetrics_AccountCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoneyAdded provides a mock function with given fields:
+func (_m *MockMetrics) MoneyAdded() {
+	_m.Called()
+}
+
+// MockMetrics_MoneyAdded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoneyAdded'
+type MockMetrics_MoneyAdded_Call struct {
+	*mock.Call
+}
+
+// MoneyAdded is a helper method to define mock.On call
+func (_e *MockMetrics_Expecter) MoneyAdded() *MockMetrics_MoneyAdded_Call {
+	return &MockMetrics_MoneyAdded_Call{Call: _e.mock.On("MoneyAdded")}
+}
+
+func (_c *MockMetrics_MoneyAdded_Call) Run(run func()) *MockMetrics_MoneyAdded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMetrics_MoneyAdded_Call) Return() *MockMetrics_MoneyAdded_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_MoneyAdded_Call) RunAndReturn(run func()) *MockMetrics_MoneyAdded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoneyTransferred provides a mock function with given fields: amount, currencyCode
+func (_m *MockMetrics) MoneyTransferred(amount int64, currencyCode string) {
+	_m.Called(amount, currencyCode)
+}
+
+// MockMetrics_MoneyTransferred_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoneyTransferred'
+type MockMetrics_MoneyTransferred_Call struct {
+	*mock.Call
+}
+
+// MoneyTransferred is a helper method to define mock.On call
+//   - amount int64
+//   - currencyCode string
+func (_e *MockMetrics_Expecter) MoneyTransferred(amount interface{}, currencyCode interface{}) *MockMetrics_MoneyTransferred_Call {
+	return &MockMetrics_MoneyTransferred_Call{Call: _e.mock.On("MoneyTransferred", amount, currencyCode)}
+}
+
+func (_c *MockMetrics_MoneyTransferred_Call) Run(run func(amount int64, currencyCode string)) *MockMetrics_MoneyTransferred_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockMetrics_MoneyTransferred_Call) Return() *MockMetrics_MoneyTransferred_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_MoneyTransferred_Call) RunAndReturn(run func(int64, string)) *MockMetrics_MoneyTransferred_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferFailed provides a mock function with given fields: reason
+func (_m *MockMetrics) TransferFailed(reason string) {
+	_m.Called(reason)
+}
+
+// MockMetrics_TransferFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferFailed'
+type MockMetrics_TransferFailed_Call struct {
+	*mock.Call
+}
+
+// TransferFailed is a helper method to define mock.On call
+//   - reason string
+func (_e *MockMetrics_Expecter) TransferFailed(reason interface{}) *MockMetrics_TransferFailed_Call {
+	return &MockMetrics_TransferFailed_Call{Call: _e.mock.On("TransferFailed", reason)}
+}
+
+func (_c *MockMetrics_TransferFailed_Call) Run(run func(reason string)) *MockMetrics_TransferFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMetrics_TransferFailed_Call) Return() *MockMetrics_TransferFailed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_TransferFailed_Call) RunAndReturn(run func(string)) *MockMetrics_TransferFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMetrics creates a new instance of MockMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetrics {
+	mock := &MockMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
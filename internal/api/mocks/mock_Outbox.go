@@ -0,0 +1,87 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	outbox "github.com/zaidsasa/xbankapi/internal/outbox"
+
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// MockOutbox is an autogenerated mock type for the Outbox type
+type MockOutbox struct {
+	mock.Mock
+}
+
+type MockOutbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutbox) EXPECT() *MockOutbox_Expecter {
+	return &MockOutbox_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, tx, event
+func (_m *MockOutbox) Add(ctx context.Context, tx pgx.Tx, event outbox.Event) error {
+	ret := _m.Called(ctx, tx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, outbox.Event) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOutbox_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockOutbox_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - event outbox.Event
+func (_e *MockOutbox_Expecter) Add(ctx interface{}, tx interface{}, event interface{}) *MockOutbox_Add_Call {
+	return &MockOutbox_Add_Call{Call: _e.mock.On("Add", ctx, tx, event)}
+}
+
+func (_c *MockOutbox_Add_Call) Run(run func(ctx context.Context, tx pgx.Tx, event outbox.Event)) *MockOutbox_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(outbox.Event))
+	})
+	return _c
+}
+
+func (_c *MockOutbox_Add_Call) Return(_a0 error) *MockOutbox_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutbox_Add_Call) RunAndReturn(run func(context.Context, pgx.Tx, outbox.Event) error) *MockOutbox_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutbox creates a new instance of MockOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutbox {
+	mock := &MockOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockOverdraftService is an autogenerated mock type for the OverdraftService type
+type MockOverdraftService struct {
+	mock.Mock
+}
+
+type MockOverdraftService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOverdraftService) EXPECT() *MockOverdraftService_Expecter {
+	return &MockOverdraftService_Expecter{mock: &_m.Mock}
+}
+
+// GrantOverdraft provides a mock function with given fields: ctx, accountID, req
+func (_m *MockOverdraftService) GrantOverdraft(ctx context.Context, accountID uuid.UUID, req *types.GrantOverdraftRequest) (types.GrantOverdraftResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantOverdraft")
+	}
+
+	var r0 types.GrantOverdraftResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.GrantOverdraftRequest) (types.GrantOverdraftResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.GrantOverdraftRequest) types.GrantOverdraftResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.GrantOverdraftResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.GrantOverdraftRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftService_GrantOverdraft_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GrantOverdraft'
+type MockOverdraftService_GrantOverdraft_Call struct {
+	*mock.Call
+}
+
+// GrantOverdraft is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.GrantOverdraftRequest
+func (_e *MockOverdraftService_Expecter) GrantOverdraft(ctx interface{}, accountID interface{}, req interface{}) *MockOverdraftService_GrantOverdraft_Call {
+	return &MockOverdraftService_GrantOverdraft_Call{Call: _e.mock.On("GrantOverdraft", ctx, accountID, req)}
+}
+
+func (_c *MockOverdraftService_GrantOverdraft_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.GrantOverdraftRequest)) *MockOverdraftService_GrantOverdraft_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.GrantOverdraftRequest))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftService_GrantOverdraft_Call) Return(_a0 types.GrantOverdraftResponse, _a1 error) *MockOverdraftService_GrantOverdraft_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftService_GrantOverdraft_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.GrantOverdraftRequest) (types.GrantOverdraftResponse, error)) *MockOverdraftService_GrantOverdraft_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOverdraft provides a mock function with given fields: ctx, accountID
+func (_m *MockOverdraftService) RevokeOverdraft(ctx context.Context, accountID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOverdraft")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOverdraftService_RevokeOverdraft_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOverdraft'
+type MockOverdraftService_RevokeOverdraft_Call struct {
+	*mock.Call
+}
+
+// RevokeOverdraft is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockOverdraftService_Expecter) RevokeOverdraft(ctx interface{}, accountID interface{}) *MockOverdraftService_RevokeOverdraft_Call {
+	return &MockOverdraftService_RevokeOverdraft_Call{Call: _e.mock.On("RevokeOverdraft", ctx, accountID)}
+}
+
+func (_c *MockOverdraftService_RevokeOverdraft_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockOverdraftService_RevokeOverdraft_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftService_RevokeOverdraft_Call) Return(_a0 error) *MockOverdraftService_RevokeOverdraft_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOverdraftService_RevokeOverdraft_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockOverdraftService_RevokeOverdraft_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOverdraftService creates a new instance of MockOverdraftService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOverdraftService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOverdraftService {
+	mock := &MockOverdraftService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
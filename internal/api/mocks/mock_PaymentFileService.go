@@ -0,0 +1,157 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	paymentfile "github.com/zaidsasa/xbankapi/internal/paymentfile"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPaymentFileService is an autogenerated mock type for the PaymentFileService type
+type MockPaymentFileService struct {
+	mock.Mock
+}
+
+type MockPaymentFileService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentFileService) EXPECT() *MockPaymentFileService_Expecter {
+	return &MockPaymentFileService_Expecter{mock: &_m.Mock}
+}
+
+// GetReport provides a mock function with given fields: ctx, accountID, paymentFileID
+func (_m *MockPaymentFileService) GetReport(ctx context.Context, accountID uuid.UUID, paymentFileID uuid.UUID) (paymentfile.Report, error) {
+	ret := _m.Called(ctx, accountID, paymentFileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 paymentfile.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (paymentfile.Report, error)); ok {
+		return rf(ctx, accountID, paymentFileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) paymentfile.Report); ok {
+		r0 = rf(ctx, accountID, paymentFileID)
+	} else {
+		r0 = ret.Get(0).(paymentfile.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID, paymentFileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileService_GetReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReport'
+type MockPaymentFileService_GetReport_Call struct {
+	*mock.Call
+}
+
+// GetReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - paymentFileID uuid.UUID
+func (_e *MockPaymentFileService_Expecter) GetReport(ctx interface{}, accountID interface{}, paymentFileID interface{}) *MockPaymentFileService_GetReport_Call {
+	return &MockPaymentFileService_GetReport_Call{Call: _e.mock.On("GetReport", ctx, accountID, paymentFileID)}
+}
+
+func (_c *MockPaymentFileService_GetReport_Call) Run(run func(ctx context.Context, accountID uuid.UUID, paymentFileID uuid.UUID)) *MockPaymentFileService_GetReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileService_GetReport_Call) Return(_a0 paymentfile.Report, _a1 error) *MockPaymentFileService_GetReport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileService_GetReport_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (paymentfile.Report, error)) *MockPaymentFileService_GetReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function with given fields: ctx, accountID, r
+func (_m *MockPaymentFileService) Import(ctx context.Context, accountID uuid.UUID, r io.Reader) (paymentfile.Report, error) {
+	ret := _m.Called(ctx, accountID, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 paymentfile.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader) (paymentfile.Report, error)); ok {
+		return rf(ctx, accountID, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, io.Reader) paymentfile.Report); ok {
+		r0 = rf(ctx, accountID, r)
+	} else {
+		r0 = ret.Get(0).(paymentfile.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, io.Reader) error); ok {
+		r1 = rf(ctx, accountID, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileService_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type MockPaymentFileService_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - r io.Reader
+func (_e *MockPaymentFileService_Expecter) Import(ctx interface{}, accountID interface{}, r interface{}) *MockPaymentFileService_Import_Call {
+	return &MockPaymentFileService_Import_Call{Call: _e.mock.On("Import", ctx, accountID, r)}
+}
+
+func (_c *MockPaymentFileService_Import_Call) Run(run func(ctx context.Context, accountID uuid.UUID, r io.Reader)) *MockPaymentFileService_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileService_Import_Call) Return(_a0 paymentfile.Report, _a1 error) *MockPaymentFileService_Import_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileService_Import_Call) RunAndReturn(run func(context.Context, uuid.UUID, io.Reader) (paymentfile.Report, error)) *MockPaymentFileService_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentFileService creates a new instance of MockPaymentFileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentFileService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentFileService {
+	mock := &MockPaymentFileService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
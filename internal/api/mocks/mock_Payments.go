@@ -0,0 +1,135 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPayments is an autogenerated mock type for the Payments type
+type MockPayments struct {
+	mock.Mock
+}
+
+type MockPayments_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPayments) EXPECT() *MockPayments_Expecter {
+	return &MockPayments_Expecter{mock: &_m.Mock}
+}
+
+// LinkHold provides a mock function with given fields: ctx, tx, held
+func (_m *MockPayments) LinkHold(ctx context.Context, tx pgx.Tx, held error) error {
+	ret := _m.Called(ctx, tx, held)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, error) error); ok {
+		r0 = rf(ctx, tx, held)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPayments_LinkHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkHold'
+type MockPayments_LinkHold_Call struct {
+	*mock.Call
+}
+
+// LinkHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - held error
+func (_e *MockPayments_Expecter) LinkHold(ctx interface{}, tx interface{}, held interface{}) *MockPayments_LinkHold_Call {
+	return &MockPayments_LinkHold_Call{Call: _e.mock.On("LinkHold", ctx, tx, held)}
+}
+
+func (_c *MockPayments_LinkHold_Call) Run(run func(ctx context.Context, tx pgx.Tx, held error)) *MockPayments_LinkHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *MockPayments_LinkHold_Call) Return(_a0 error) *MockPayments_LinkHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPayments_LinkHold_Call) RunAndReturn(run func(context.Context, pgx.Tx, error) error) *MockPayments_LinkHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkTransfer provides a mock function with given fields: ctx, tx, transactionID
+func (_m *MockPayments) LinkTransfer(ctx context.Context, tx pgx.Tx, transactionID uuid.UUID) error {
+	ret := _m.Called(ctx, tx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, uuid.UUID) error); ok {
+		r0 = rf(ctx, tx, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPayments_LinkTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkTransfer'
+type MockPayments_LinkTransfer_Call struct {
+	*mock.Call
+}
+
+// LinkTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - transactionID uuid.UUID
+func (_e *MockPayments_Expecter) LinkTransfer(ctx interface{}, tx interface{}, transactionID interface{}) *MockPayments_LinkTransfer_Call {
+	return &MockPayments_LinkTransfer_Call{Call: _e.mock.On("LinkTransfer", ctx, tx, transactionID)}
+}
+
+func (_c *MockPayments_LinkTransfer_Call) Run(run func(ctx context.Context, tx pgx.Tx, transactionID uuid.UUID)) *MockPayments_LinkTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPayments_LinkTransfer_Call) Return(_a0 error) *MockPayments_LinkTransfer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPayments_LinkTransfer_Call) RunAndReturn(run func(context.Context, pgx.Tx, uuid.UUID) error) *MockPayments_LinkTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPayments creates a new instance of MockPayments. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPayments(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPayments {
+	mock := &MockPayments{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,379 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPocketService is an autogenerated mock type for the PocketService type
+type MockPocketService struct {
+	mock.Mock
+}
+
+type MockPocketService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPocketService) EXPECT() *MockPocketService_Expecter {
+	return &MockPocketService_Expecter{mock: &_m.Mock}
+}
+
+// CreatePocket provides a mock function with given fields: ctx, accountID, req
+func (_m *MockPocketService) CreatePocket(ctx context.Context, accountID uuid.UUID, req *types.CreatePocketRequest) (types.CreatePocketResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePocket")
+	}
+
+	var r0 types.CreatePocketResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreatePocketRequest) (types.CreatePocketResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.CreatePocketRequest) types.CreatePocketResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.CreatePocketResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.CreatePocketRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketService_CreatePocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePocket'
+type MockPocketService_CreatePocket_Call struct {
+	*mock.Call
+}
+
+// CreatePocket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.CreatePocketRequest
+func (_e *MockPocketService_Expecter) CreatePocket(ctx interface{}, accountID interface{}, req interface{}) *MockPocketService_CreatePocket_Call {
+	return &MockPocketService_CreatePocket_Call{Call: _e.mock.On("CreatePocket", ctx, accountID, req)}
+}
+
+func (_c *MockPocketService_CreatePocket_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.CreatePocketRequest)) *MockPocketService_CreatePocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.CreatePocketRequest))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_CreatePocket_Call) Return(_a0 types.CreatePocketResponse, _a1 error) *MockPocketService_CreatePocket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketService_CreatePocket_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.CreatePocketRequest) (types.CreatePocketResponse, error)) *MockPocketService_CreatePocket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePocketGoal provides a mock function with given fields: ctx, accountID, pocketID
+func (_m *MockPocketService) DeletePocketGoal(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID, pocketID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePocketGoal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID, pocketID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPocketService_DeletePocketGoal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePocketGoal'
+type MockPocketService_DeletePocketGoal_Call struct {
+	*mock.Call
+}
+
+// DeletePocketGoal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - pocketID uuid.UUID
+func (_e *MockPocketService_Expecter) DeletePocketGoal(ctx interface{}, accountID interface{}, pocketID interface{}) *MockPocketService_DeletePocketGoal_Call {
+	return &MockPocketService_DeletePocketGoal_Call{Call: _e.mock.On("DeletePocketGoal", ctx, accountID, pocketID)}
+}
+
+func (_c *MockPocketService_DeletePocketGoal_Call) Run(run func(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID)) *MockPocketService_DeletePocketGoal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_DeletePocketGoal_Call) Return(_a0 error) *MockPocketService_DeletePocketGoal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPocketService_DeletePocketGoal_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockPocketService_DeletePocketGoal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPockets provides a mock function with given fields: ctx, accountID
+func (_m *MockPocketService) ListPockets(ctx context.Context, accountID uuid.UUID) (types.ListPocketsResponse, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPockets")
+	}
+
+	var r0 types.ListPocketsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.ListPocketsResponse, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.ListPocketsResponse); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(types.ListPocketsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketService_ListPockets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPockets'
+type MockPocketService_ListPockets_Call struct {
+	*mock.Call
+}
+
+// ListPockets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockPocketService_Expecter) ListPockets(ctx interface{}, accountID interface{}) *MockPocketService_ListPockets_Call {
+	return &MockPocketService_ListPockets_Call{Call: _e.mock.On("ListPockets", ctx, accountID)}
+}
+
+func (_c *MockPocketService_ListPockets_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockPocketService_ListPockets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_ListPockets_Call) Return(_a0 types.ListPocketsResponse, _a1 error) *MockPocketService_ListPockets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketService_ListPockets_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.ListPocketsResponse, error)) *MockPocketService_ListPockets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveFromPocket provides a mock function with given fields: ctx, accountID, pocketID, req
+func (_m *MockPocketService) MoveFromPocket(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error) {
+	ret := _m.Called(ctx, accountID, pocketID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveFromPocket")
+	}
+
+	var r0 types.MovePocketMoneyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error)); ok {
+		return rf(ctx, accountID, pocketID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) types.MovePocketMoneyResponse); ok {
+		r0 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r0 = ret.Get(0).(types.MovePocketMoneyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) error); ok {
+		r1 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketService_MoveFromPocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveFromPocket'
+type MockPocketService_MoveFromPocket_Call struct {
+	*mock.Call
+}
+
+// MoveFromPocket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - pocketID uuid.UUID
+//   - req *types.MovePocketMoneyRequest
+func (_e *MockPocketService_Expecter) MoveFromPocket(ctx interface{}, accountID interface{}, pocketID interface{}, req interface{}) *MockPocketService_MoveFromPocket_Call {
+	return &MockPocketService_MoveFromPocket_Call{Call: _e.mock.On("MoveFromPocket", ctx, accountID, pocketID, req)}
+}
+
+func (_c *MockPocketService_MoveFromPocket_Call) Run(run func(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest)) *MockPocketService_MoveFromPocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*types.MovePocketMoneyRequest))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_MoveFromPocket_Call) Return(_a0 types.MovePocketMoneyResponse, _a1 error) *MockPocketService_MoveFromPocket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketService_MoveFromPocket_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error)) *MockPocketService_MoveFromPocket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveToPocket provides a mock function with given fields: ctx, accountID, pocketID, req
+func (_m *MockPocketService) MoveToPocket(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error) {
+	ret := _m.Called(ctx, accountID, pocketID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveToPocket")
+	}
+
+	var r0 types.MovePocketMoneyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error)); ok {
+		return rf(ctx, accountID, pocketID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) types.MovePocketMoneyResponse); ok {
+		r0 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r0 = ret.Get(0).(types.MovePocketMoneyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) error); ok {
+		r1 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketService_MoveToPocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveToPocket'
+type MockPocketService_MoveToPocket_Call struct {
+	*mock.Call
+}
+
+// MoveToPocket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - pocketID uuid.UUID
+//   - req *types.MovePocketMoneyRequest
+func (_e *MockPocketService_Expecter) MoveToPocket(ctx interface{}, accountID interface{}, pocketID interface{}, req interface{}) *MockPocketService_MoveToPocket_Call {
+	return &MockPocketService_MoveToPocket_Call{Call: _e.mock.On("MoveToPocket", ctx, accountID, pocketID, req)}
+}
+
+func (_c *MockPocketService_MoveToPocket_Call) Run(run func(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest)) *MockPocketService_MoveToPocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*types.MovePocketMoneyRequest))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_MoveToPocket_Call) Return(_a0 types.MovePocketMoneyResponse, _a1 error) *MockPocketService_MoveToPocket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketService_MoveToPocket_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *types.MovePocketMoneyRequest) (types.MovePocketMoneyResponse, error)) *MockPocketService_MoveToPocket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPocketGoal provides a mock function with given fields: ctx, accountID, pocketID, req
+func (_m *MockPocketService) SetPocketGoal(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.SetPocketGoalRequest) (types.SetPocketGoalResponse, error) {
+	ret := _m.Called(ctx, accountID, pocketID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPocketGoal")
+	}
+
+	var r0 types.SetPocketGoalResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetPocketGoalRequest) (types.SetPocketGoalResponse, error)); ok {
+		return rf(ctx, accountID, pocketID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetPocketGoalRequest) types.SetPocketGoalResponse); ok {
+		r0 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetPocketGoalResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *types.SetPocketGoalRequest) error); ok {
+		r1 = rf(ctx, accountID, pocketID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketService_SetPocketGoal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPocketGoal'
+type MockPocketService_SetPocketGoal_Call struct {
+	*mock.Call
+}
+
+// SetPocketGoal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - pocketID uuid.UUID
+//   - req *types.SetPocketGoalRequest
+func (_e *MockPocketService_Expecter) SetPocketGoal(ctx interface{}, accountID interface{}, pocketID interface{}, req interface{}) *MockPocketService_SetPocketGoal_Call {
+	return &MockPocketService_SetPocketGoal_Call{Call: _e.mock.On("SetPocketGoal", ctx, accountID, pocketID, req)}
+}
+
+func (_c *MockPocketService_SetPocketGoal_Call) Run(run func(ctx context.Context, accountID uuid.UUID, pocketID uuid.UUID, req *types.SetPocketGoalRequest)) *MockPocketService_SetPocketGoal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*types.SetPocketGoalRequest))
+	})
+	return _c
+}
+
+func (_c *MockPocketService_SetPocketGoal_Call) Return(_a0 types.SetPocketGoalResponse, _a1 error) *MockPocketService_SetPocketGoal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketService_SetPocketGoal_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *types.SetPocketGoalRequest) (types.SetPocketGoalResponse, error)) *MockPocketService_SetPocketGoal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPocketService creates a new instance of MockPocketService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPocketService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPocketService {
+	mock := &MockPocketService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
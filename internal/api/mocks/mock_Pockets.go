@@ -0,0 +1,98 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPockets is an autogenerated mock type for the Pockets type
+type MockPockets struct {
+	mock.Mock
+}
+
+type MockPockets_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPockets) EXPECT() *MockPockets_Expecter {
+	return &MockPockets_Expecter{mock: &_m.Mock}
+}
+
+// Pockets provides a mock function with given fields: ctx, accountID
+func (_m *MockPockets) Pockets(ctx context.Context, accountID uuid.UUID) ([]types.Pocket, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pockets")
+	}
+
+	var r0 []types.Pocket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]types.Pocket, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []types.Pocket); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Pocket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPockets_Pockets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pockets'
+type MockPockets_Pockets_Call struct {
+	*mock.Call
+}
+
+// Pockets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockPockets_Expecter) Pockets(ctx interface{}, accountID interface{}) *MockPockets_Pockets_Call {
+	return &MockPockets_Pockets_Call{Call: _e.mock.On("Pockets", ctx, accountID)}
+}
+
+func (_c *MockPockets_Pockets_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockPockets_Pockets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPockets_Pockets_Call) Return(_a0 []types.Pocket, _a1 error) *MockPockets_Pockets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPockets_Pockets_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]types.Pocket, error)) *MockPockets_Pockets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPockets creates a new instance of MockPockets. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPockets(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPockets {
+	mock := &MockPockets{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
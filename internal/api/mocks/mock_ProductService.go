@@ -0,0 +1,211 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockProductService is an autogenerated mock type for the ProductService type
+type MockProductService struct {
+	mock.Mock
+}
+
+type MockProductService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProductService) EXPECT() *MockProductService_Expecter {
+	return &MockProductService_Expecter{mock: &_m.Mock}
+}
+
+// ListProducts provides a mock function with given fields: ctx
+func (_m *MockProductService) ListProducts(ctx context.Context) (types.ListProductsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProducts")
+	}
+
+	var r0 types.ListProductsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (types.ListProductsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) types.ListProductsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.ListProductsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductService_ListProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProducts'
+type MockProductService_ListProducts_Call struct {
+	*mock.Call
+}
+
+// ListProducts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProductService_Expecter) ListProducts(ctx interface{}) *MockProductService_ListProducts_Call {
+	return &MockProductService_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx)}
+}
+
+func (_c *MockProductService_ListProducts_Call) Run(run func(ctx context.Context)) *MockProductService_ListProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockProductService_ListProducts_Call) Return(_a0 types.ListProductsResponse, _a1 error) *MockProductService_ListProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductService_ListProducts_Call) RunAndReturn(run func(context.Context) (types.ListProductsResponse, error)) *MockProductService_ListProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountProduct provides a mock function with given fields: ctx, accountID, req
+func (_m *MockProductService) SetAccountProduct(ctx context.Context, accountID uuid.UUID, req *types.SetAccountProductRequest) (types.SetAccountProductResponse, error) {
+	ret := _m.Called(ctx, accountID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountProduct")
+	}
+
+	var r0 types.SetAccountProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetAccountProductRequest) (types.SetAccountProductResponse, error)); ok {
+		return rf(ctx, accountID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.SetAccountProductRequest) types.SetAccountProductResponse); ok {
+		r0 = rf(ctx, accountID, req)
+	} else {
+		r0 = ret.Get(0).(types.SetAccountProductResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.SetAccountProductRequest) error); ok {
+		r1 = rf(ctx, accountID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductService_SetAccountProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountProduct'
+type MockProductService_SetAccountProduct_Call struct {
+	*mock.Call
+}
+
+// SetAccountProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - req *types.SetAccountProductRequest
+func (_e *MockProductService_Expecter) SetAccountProduct(ctx interface{}, accountID interface{}, req interface{}) *MockProductService_SetAccountProduct_Call {
+	return &MockProductService_SetAccountProduct_Call{Call: _e.mock.On("SetAccountProduct", ctx, accountID, req)}
+}
+
+func (_c *MockProductService_SetAccountProduct_Call) Run(run func(ctx context.Context, accountID uuid.UUID, req *types.SetAccountProductRequest)) *MockProductService_SetAccountProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.SetAccountProductRequest))
+	})
+	return _c
+}
+
+func (_c *MockProductService_SetAccountProduct_Call) Return(_a0 types.SetAccountProductResponse, _a1 error) *MockProductService_SetAccountProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductService_SetAccountProduct_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.SetAccountProductRequest) (types.SetAccountProductResponse, error)) *MockProductService_SetAccountProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProduct provides a mock function with given fields: ctx, code, req
+func (_m *MockProductService) SetProduct(ctx context.Context, code string, req *types.SetProductRequest) (types.SetProductResponse, error) {
+	ret := _m.Called(ctx, code, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetProduct")
+	}
+
+	var r0 types.SetProductResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.SetProductRequest) (types.SetProductResponse, error)); ok {
+		return rf(ctx, code, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.SetProductRequest) types.SetProductResponse); ok {
+		r0 = rf(ctx, code, req)
+	} else {
+		r0 = ret.Get(0).(types.SetProductResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *types.SetProductRequest) error); ok {
+		r1 = rf(ctx, code, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductService_SetProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProduct'
+type MockProductService_SetProduct_Call struct {
+	*mock.Call
+}
+
+// SetProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - req *types.SetProductRequest
+func (_e *MockProductService_Expecter) SetProduct(ctx interface{}, code interface{}, req interface{}) *MockProductService_SetProduct_Call {
+	return &MockProductService_SetProduct_Call{Call: _e.mock.On("SetProduct", ctx, code, req)}
+}
+
+func (_c *MockProductService_SetProduct_Call) Run(run func(ctx context.Context, code string, req *types.SetProductRequest)) *MockProductService_SetProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*types.SetProductRequest))
+	})
+	return _c
+}
+
+func (_c *MockProductService_SetProduct_Call) Return(_a0 types.SetProductResponse, _a1 error) *MockProductService_SetProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductService_SetProduct_Call) RunAndReturn(run func(context.Context, string, *types.SetProductRequest) (types.SetProductResponse, error)) *MockProductService_SetProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProductService creates a new instance of MockProductService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProductService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProductService {
+	mock := &MockProductService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockProducts is an autogenerated mock type for the Products type
+type MockProducts struct {
+	mock.Mock
+}
+
+type MockProducts_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProducts) EXPECT() *MockProducts_Expecter {
+	return &MockProducts_Expecter{mock: &_m.Mock}
+}
+
+// CheckCurrency provides a mock function with given fields: ctx, productCode, currencyCode
+func (_m *MockProducts) CheckCurrency(ctx context.Context, productCode string, currencyCode string) error {
+	ret := _m.Called(ctx, productCode, currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, productCode, currencyCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProducts_CheckCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckCurrency'
+type MockProducts_CheckCurrency_Call struct {
+	*mock.Call
+}
+
+// CheckCurrency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+//   - currencyCode string
+func (_e *MockProducts_Expecter) CheckCurrency(ctx interface{}, productCode interface{}, currencyCode interface{}) *MockProducts_CheckCurrency_Call {
+	return &MockProducts_CheckCurrency_Call{Call: _e.mock.On("CheckCurrency", ctx, productCode, currencyCode)}
+}
+
+func (_c *MockProducts_CheckCurrency_Call) Run(run func(ctx context.Context, productCode string, currencyCode string)) *MockProducts_CheckCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockProducts_CheckCurrency_Call) Return(_a0 error) *MockProducts_CheckCurrency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProducts_CheckCurrency_Call) RunAndReturn(run func(context.Context, string, string) error) *MockProducts_CheckCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProducts creates a new instance of MockProducts. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProducts(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProducts {
+	mock := &MockProducts{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRisk is an autogenerated mock type for the Risk type
+type MockRisk struct {
+	mock.Mock
+}
+
+type MockRisk_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRisk) EXPECT() *MockRisk_Expecter {
+	return &MockRisk_Expecter{mock: &_m.Mock}
+}
+
+// Screen provides a mock function with given fields: ctx, tx, account, reciverAccountID, amount
+func (_m *MockRisk) Screen(ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, tx, account, reciverAccountID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Screen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, storage.Account, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, tx, account, reciverAccountID, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRisk_Screen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Screen'
+type MockRisk_Screen_Call struct {
+	*mock.Call
+}
+
+// Screen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - account storage.Account
+//   - reciverAccountID uuid.UUID
+//   - amount int64
+func (_e *MockRisk_Expecter) Screen(ctx interface{}, tx interface{}, account interface{}, reciverAccountID interface{}, amount interface{}) *MockRisk_Screen_Call {
+	return &MockRisk_Screen_Call{Call: _e.mock.On("Screen", ctx, tx, account, reciverAccountID, amount)}
+}
+
+func (_c *MockRisk_Screen_Call) Run(run func(ctx context.Context, tx pgx.Tx, account storage.Account, reciverAccountID uuid.UUID, amount int64)) *MockRisk_Screen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(storage.Account), args[3].(uuid.UUID), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRisk_Screen_Call) Return(_a0 error) *MockRisk_Screen_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRisk_Screen_Call) RunAndReturn(run func(context.Context, pgx.Tx, storage.Account, uuid.UUID, int64) error) *MockRisk_Screen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRisk creates a new instance of MockRisk. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRisk(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRisk {
+	mock := &MockRisk{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRiskService is an autogenerated mock type for the RiskService type
+type MockRiskService struct {
+	mock.Mock
+}
+
+type MockRiskService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRiskService) EXPECT() *MockRiskService_Expecter {
+	return &MockRiskService_Expecter{mock: &_m.Mock}
+}
+
+// ApprovePendingTransfer provides a mock function with given fields: ctx, pendingTransferID
+func (_m *MockRiskService) ApprovePendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (types.ApprovePendingTransferResponse, error) {
+	ret := _m.Called(ctx, pendingTransferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApprovePendingTransfer")
+	}
+
+	var r0 types.ApprovePendingTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.ApprovePendingTransferResponse, error)); ok {
+		return rf(ctx, pendingTransferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.ApprovePendingTransferResponse); ok {
+		r0 = rf(ctx, pendingTransferID)
+	} else {
+		r0 = ret.Get(0).(types.ApprovePendingTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pendingTransferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskService_ApprovePendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApprovePendingTransfer'
+type MockRiskService_ApprovePendingTransfer_Call struct {
+	*mock.Call
+}
+
+// ApprovePendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingTransferID uuid.UUID
+func (_e *MockRiskService_Expecter) ApprovePendingTransfer(ctx interface{}, pendingTransferID interface{}) *MockRiskService_ApprovePendingTransfer_Call {
+	return &MockRiskService_ApprovePendingTransfer_Call{Call: _e.mock.On("ApprovePendingTransfer", ctx, pendingTransferID)}
+}
+
+func (_c *MockRiskService_ApprovePendingTransfer_Call) Run(run func(ctx context.Context, pendingTransferID uuid.UUID)) *MockRiskService_ApprovePendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRiskService_ApprovePendingTransfer_Call) Return(_a0 types.ApprovePendingTransferResponse, _a1 error) *MockRiskService_ApprovePendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskService_ApprovePendingTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.ApprovePendingTransferResponse, error)) *MockRiskService_ApprovePendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPendingTransfers provides a mock function with given fields: ctx, status, limit, offset
+func (_m *MockRiskService) ListPendingTransfers(ctx context.Context, status string, limit int32, offset int32) (types.ListPendingTransfersResponse, error) {
+	ret := _m.Called(ctx, status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPendingTransfers")
+	}
+
+	var r0 types.ListPendingTransfersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32, int32) (types.ListPendingTransfersResponse, error)); ok {
+		return rf(ctx, status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32, int32) types.ListPendingTransfersResponse); ok {
+		r0 = rf(ctx, status, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListPendingTransfersResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int32, int32) error); ok {
+		r1 = rf(ctx, status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskService_ListPendingTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingTransfers'
+type MockRiskService_ListPendingTransfers_Call struct {
+	*mock.Call
+}
+
+// ListPendingTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - limit int32
+//   - offset int32
+func (_e *MockRiskService_Expecter) ListPendingTransfers(ctx interface{}, status interface{}, limit interface{}, offset interface{}) *MockRiskService_ListPendingTransfers_Call {
+	return &MockRiskService_ListPendingTransfers_Call{Call: _e.mock.On("ListPendingTransfers", ctx, status, limit, offset)}
+}
+
+func (_c *MockRiskService_ListPendingTransfers_Call) Run(run func(ctx context.Context, status string, limit int32, offset int32)) *MockRiskService_ListPendingTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockRiskService_ListPendingTransfers_Call) Return(_a0 types.ListPendingTransfersResponse, _a1 error) *MockRiskService_ListPendingTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskService_ListPendingTransfers_Call) RunAndReturn(run func(context.Context, string, int32, int32) (types.ListPendingTransfersResponse, error)) *MockRiskService_ListPendingTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectPendingTransfer provides a mock function with given fields: ctx, pendingTransferID
+func (_m *MockRiskService) RejectPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (types.RejectPendingTransferResponse, error) {
+	ret := _m.Called(ctx, pendingTransferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectPendingTransfer")
+	}
+
+	var r0 types.RejectPendingTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (types.RejectPendingTransferResponse, error)); ok {
+		return rf(ctx, pendingTransferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) types.RejectPendingTransferResponse); ok {
+		r0 = rf(ctx, pendingTransferID)
+	} else {
+		r0 = ret.Get(0).(types.RejectPendingTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pendingTransferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskService_RejectPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectPendingTransfer'
+type MockRiskService_RejectPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// RejectPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingTransferID uuid.UUID
+func (_e *MockRiskService_Expecter) RejectPendingTransfer(ctx interface{}, pendingTransferID interface{}) *MockRiskService_RejectPendingTransfer_Call {
+	return &MockRiskService_RejectPendingTransfer_Call{Call: _e.mock.On("RejectPendingTransfer", ctx, pendingTransferID)}
+}
+
+func (_c *MockRiskService_RejectPendingTransfer_Call) Run(run func(ctx context.Context, pendingTransferID uuid.UUID)) *MockRiskService_RejectPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRiskService_RejectPendingTransfer_Call) Return(_a0 types.RejectPendingTransferResponse, _a1 error) *MockRiskService_RejectPendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskService_RejectPendingTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID) (types.RejectPendingTransferResponse, error)) *MockRiskService_RejectPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRiskService creates a new instance of MockRiskService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRiskService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRiskService {
+	mock := &MockRiskService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
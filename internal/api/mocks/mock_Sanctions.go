@@ -0,0 +1,148 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	sanctions "github.com/zaidsasa/xbankapi/internal/sanctions"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSanctions is an autogenerated mock type for the Sanctions type
+type MockSanctions struct {
+	mock.Mock
+}
+
+type MockSanctions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSanctions) EXPECT() *MockSanctions_Expecter {
+	return &MockSanctions_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, tx, accountID, name, result
+func (_m *MockSanctions) Record(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, name string, result sanctions.Result) error {
+	ret := _m.Called(ctx, tx, accountID, name, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx, uuid.UUID, string, sanctions.Result) error); ok {
+		r0 = rf(ctx, tx, accountID, name, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSanctions_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockSanctions_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx pgx.Tx
+//   - accountID uuid.UUID
+//   - name string
+//   - result sanctions.Result
+func (_e *MockSanctions_Expecter) Record(ctx interface{}, tx interface{}, accountID interface{}, name interface{}, result interface{}) *MockSanctions_Record_Call {
+	return &MockSanctions_Record_Call{Call: _e.mock.On("Record", ctx, tx, accountID, name, result)}
+}
+
+func (_c *MockSanctions_Record_Call) Run(run func(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, name string, result sanctions.Result)) *MockSanctions_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx), args[2].(uuid.UUID), args[3].(string), args[4].(sanctions.Result))
+	})
+	return _c
+}
+
+func (_c *MockSanctions_Record_Call) Return(_a0 error) *MockSanctions_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSanctions_Record_Call) RunAndReturn(run func(context.Context, pgx.Tx, uuid.UUID, string, sanctions.Result) error) *MockSanctions_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Screen provides a mock function with given fields: ctx, name
+func (_m *MockSanctions) Screen(ctx context.Context, name string) (sanctions.Result, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Screen")
+	}
+
+	var r0 sanctions.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (sanctions.Result, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) sanctions.Result); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(sanctions.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctions_Screen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Screen'
+type MockSanctions_Screen_Call struct {
+	*mock.Call
+}
+
+// Screen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockSanctions_Expecter) Screen(ctx interface{}, name interface{}) *MockSanctions_Screen_Call {
+	return &MockSanctions_Screen_Call{Call: _e.mock.On("Screen", ctx, name)}
+}
+
+func (_c *MockSanctions_Screen_Call) Run(run func(ctx context.Context, name string)) *MockSanctions_Screen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSanctions_Screen_Call) Return(_a0 sanctions.Result, _a1 error) *MockSanctions_Screen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctions_Screen_Call) RunAndReturn(run func(context.Context, string) (sanctions.Result, error)) *MockSanctions_Screen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSanctions creates a new instance of MockSanctions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSanctions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSanctions {
+	mock := &MockSanctions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,156 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSanctionsService is an autogenerated mock type for the SanctionsService type
+type MockSanctionsService struct {
+	mock.Mock
+}
+
+type MockSanctionsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSanctionsService) EXPECT() *MockSanctionsService_Expecter {
+	return &MockSanctionsService_Expecter{mock: &_m.Mock}
+}
+
+// ListScreenings provides a mock function with given fields: ctx, status, limit, offset
+func (_m *MockSanctionsService) ListScreenings(ctx context.Context, status string, limit int32, offset int32) (types.ListSanctionsScreeningsResponse, error) {
+	ret := _m.Called(ctx, status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScreenings")
+	}
+
+	var r0 types.ListSanctionsScreeningsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32, int32) (types.ListSanctionsScreeningsResponse, error)); ok {
+		return rf(ctx, status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32, int32) types.ListSanctionsScreeningsResponse); ok {
+		r0 = rf(ctx, status, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListSanctionsScreeningsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int32, int32) error); ok {
+		r1 = rf(ctx, status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsService_ListScreenings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScreenings'
+type MockSanctionsService_ListScreenings_Call struct {
+	*mock.Call
+}
+
+// ListScreenings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - limit int32
+//   - offset int32
+func (_e *MockSanctionsService_Expecter) ListScreenings(ctx interface{}, status interface{}, limit interface{}, offset interface{}) *MockSanctionsService_ListScreenings_Call {
+	return &MockSanctionsService_ListScreenings_Call{Call: _e.mock.On("ListScreenings", ctx, status, limit, offset)}
+}
+
+func (_c *MockSanctionsService_ListScreenings_Call) Run(run func(ctx context.Context, status string, limit int32, offset int32)) *MockSanctionsService_ListScreenings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsService_ListScreenings_Call) Return(_a0 types.ListSanctionsScreeningsResponse, _a1 error) *MockSanctionsService_ListScreenings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsService_ListScreenings_Call) RunAndReturn(run func(context.Context, string, int32, int32) (types.ListSanctionsScreeningsResponse, error)) *MockSanctionsService_ListScreenings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveScreening provides a mock function with given fields: ctx, screeningID, req
+func (_m *MockSanctionsService) ResolveScreening(ctx context.Context, screeningID uuid.UUID, req *types.ResolveSanctionsScreeningRequest) (types.ResolveSanctionsScreeningResponse, error) {
+	ret := _m.Called(ctx, screeningID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveScreening")
+	}
+
+	var r0 types.ResolveSanctionsScreeningResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.ResolveSanctionsScreeningRequest) (types.ResolveSanctionsScreeningResponse, error)); ok {
+		return rf(ctx, screeningID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *types.ResolveSanctionsScreeningRequest) types.ResolveSanctionsScreeningResponse); ok {
+		r0 = rf(ctx, screeningID, req)
+	} else {
+		r0 = ret.Get(0).(types.ResolveSanctionsScreeningResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *types.ResolveSanctionsScreeningRequest) error); ok {
+		r1 = rf(ctx, screeningID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsService_ResolveScreening_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveScreening'
+type MockSanctionsService_ResolveScreening_Call struct {
+	*mock.Call
+}
+
+// ResolveScreening is a helper method to define mock.On call
+//   - ctx context.Context
+//   - screeningID uuid.UUID
+//   - req *types.ResolveSanctionsScreeningRequest
+func (_e *MockSanctionsService_Expecter) ResolveScreening(ctx interface{}, screeningID interface{}, req interface{}) *MockSanctionsService_ResolveScreening_Call {
+	return &MockSanctionsService_ResolveScreening_Call{Call: _e.mock.On("ResolveScreening", ctx, screeningID, req)}
+}
+
+func (_c *MockSanctionsService_ResolveScreening_Call) Run(run func(ctx context.Context, screeningID uuid.UUID, req *types.ResolveSanctionsScreeningRequest)) *MockSanctionsService_ResolveScreening_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*types.ResolveSanctionsScreeningRequest))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsService_ResolveScreening_Call) Return(_a0 types.ResolveSanctionsScreeningResponse, _a1 error) *MockSanctionsService_ResolveScreening_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsService_ResolveScreening_Call) RunAndReturn(run func(context.Context, uuid.UUID, *types.ResolveSanctionsScreeningRequest) (types.ResolveSanctionsScreeningResponse, error)) *MockSanctionsService_ResolveScreening_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSanctionsService creates a new instance of MockSanctionsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSanctionsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSanctionsService {
+	mock := &MockSanctionsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
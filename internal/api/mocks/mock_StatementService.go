@@ -0,0 +1,91 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	statement "github.com/zaidsasa/xbankapi/internal/statement"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockStatementService is an autogenerated mock type for the StatementService type
+type MockStatementService struct {
+	mock.Mock
+}
+
+type MockStatementService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatementService) EXPECT() *MockStatementService_Expecter {
+	return &MockStatementService_Expecter{mock: &_m.Mock}
+}
+
+// Write provides a mock function with given fields: ctx, accountID, from, to, enc
+func (_m *MockStatementService) Write(ctx context.Context, accountID uuid.UUID, from time.Time, to time.Time, enc statement.Encoder) error {
+	ret := _m.Called(ctx, accountID, from, to, enc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time, statement.Encoder) error); ok {
+		r0 = rf(ctx, accountID, from, to, enc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStatementService_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type MockStatementService_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - from time.Time
+//   - to time.Time
+//   - enc statement.Encoder
+func (_e *MockStatementService_Expecter) Write(ctx interface{}, accountID interface{}, from interface{}, to interface{}, enc interface{}) *MockStatementService_Write_Call {
+	return &MockStatementService_Write_Call{Call: _e.mock.On("Write", ctx, accountID, from, to, enc)}
+}
+
+func (_c *MockStatementService_Write_Call) Run(run func(ctx context.Context, accountID uuid.UUID, from time.Time, to time.Time, enc statement.Encoder)) *MockStatementService_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time), args[4].(statement.Encoder))
+	})
+	return _c
+}
+
+func (_c *MockStatementService_Write_Call) Return(_a0 error) *MockStatementService_Write_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStatementService_Write_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time, statement.Encoder) error) *MockStatementService_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatementService creates a new instance of MockStatementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatementService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatementService {
+	mock := &MockStatementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
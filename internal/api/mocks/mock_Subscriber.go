@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSubscriber is an autogenerated mock type for the Subscriber type
+type MockSubscriber struct {
+	mock.Mock
+}
+
+type MockSubscriber_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriber) EXPECT() *MockSubscriber_Expecter {
+	return &MockSubscriber_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with given fields: accountID
+func (_m *MockSubscriber) Subscribe(accountID uuid.UUID) (<-chan struct{}, func()) {
+	ret := _m.Called(accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan struct{}
+	var r1 func()
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (<-chan struct{}, func())); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) <-chan struct{}); ok {
+		r0 = rf(accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) func()); ok {
+		r1 = rf(accountID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
+
+// MockSubscriber_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockSubscriber_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - accountID uuid.UUID
+func (_e *MockSubscriber_Expecter) Subscribe(accountID interface{}) *MockSubscriber_Subscribe_Call {
+	return &MockSubscriber_Subscribe_Call{Call: _e.mock.On("Subscribe", accountID)}
+}
+
+func (_c *MockSubscriber_Subscribe_Call) Run(run func(accountID uuid.UUID)) *MockSubscriber_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriber_Subscribe_Call) Return(_a0 <-chan struct{}, _a1 func()) *MockSubscriber_Subscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriber_Subscribe_Call) RunAndReturn(run func(uuid.UUID) (<-chan struct{}, func())) *MockSubscriber_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriber creates a new instance of MockSubscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriber(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriber {
+	mock := &MockSubscriber{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,215 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockTransferApprovalService is an autogenerated mock type for the TransferApprovalService type
+type MockTransferApprovalService struct {
+	mock.Mock
+}
+
+type MockTransferApprovalService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransferApprovalService) EXPECT() *MockTransferApprovalService_Expecter {
+	return &MockTransferApprovalService_Expecter{mock: &_m.Mock}
+}
+
+// ApproveTransfer provides a mock function with given fields: ctx, accountID, transferApprovalID
+func (_m *MockTransferApprovalService) ApproveTransfer(ctx context.Context, accountID uuid.UUID, transferApprovalID uuid.UUID) (types.ApproveTransferResponse, error) {
+	ret := _m.Called(ctx, accountID, transferApprovalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveTransfer")
+	}
+
+	var r0 types.ApproveTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (types.ApproveTransferResponse, error)); ok {
+		return rf(ctx, accountID, transferApprovalID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) types.ApproveTransferResponse); ok {
+		r0 = rf(ctx, accountID, transferApprovalID)
+	} else {
+		r0 = ret.Get(0).(types.ApproveTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID, transferApprovalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalService_ApproveTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveTransfer'
+type MockTransferApprovalService_ApproveTransfer_Call struct {
+	*mock.Call
+}
+
+// ApproveTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - transferApprovalID uuid.UUID
+func (_e *MockTransferApprovalService_Expecter) ApproveTransfer(ctx interface{}, accountID interface{}, transferApprovalID interface{}) *MockTransferApprovalService_ApproveTransfer_Call {
+	return &MockTransferApprovalService_ApproveTransfer_Call{Call: _e.mock.On("ApproveTransfer", ctx, accountID, transferApprovalID)}
+}
+
+func (_c *MockTransferApprovalService_ApproveTransfer_Call) Run(run func(ctx context.Context, accountID uuid.UUID, transferApprovalID uuid.UUID)) *MockTransferApprovalService_ApproveTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalService_ApproveTransfer_Call) Return(_a0 types.ApproveTransferResponse, _a1 error) *MockTransferApprovalService_ApproveTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalService_ApproveTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (types.ApproveTransferResponse, error)) *MockTransferApprovalService_ApproveTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransferApprovals provides a mock function with given fields: ctx, accountID, status, limit, offset
+func (_m *MockTransferApprovalService) ListTransferApprovals(ctx context.Context, accountID uuid.UUID, status string, limit int32, offset int32) (types.ListTransferApprovalsResponse, error) {
+	ret := _m.Called(ctx, accountID, status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransferApprovals")
+	}
+
+	var r0 types.ListTransferApprovalsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int32, int32) (types.ListTransferApprovalsResponse, error)); ok {
+		return rf(ctx, accountID, status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int32, int32) types.ListTransferApprovalsResponse); ok {
+		r0 = rf(ctx, accountID, status, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListTransferApprovalsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, int32, int32) error); ok {
+		r1 = rf(ctx, accountID, status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalService_ListTransferApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransferApprovals'
+type MockTransferApprovalService_ListTransferApprovals_Call struct {
+	*mock.Call
+}
+
+// ListTransferApprovals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - status string
+//   - limit int32
+//   - offset int32
+func (_e *MockTransferApprovalService_Expecter) ListTransferApprovals(ctx interface{}, accountID interface{}, status interface{}, limit interface{}, offset interface{}) *MockTransferApprovalService_ListTransferApprovals_Call {
+	return &MockTransferApprovalService_ListTransferApprovals_Call{Call: _e.mock.On("ListTransferApprovals", ctx, accountID, status, limit, offset)}
+}
+
+func (_c *MockTransferApprovalService_ListTransferApprovals_Call) Run(run func(ctx context.Context, accountID uuid.UUID, status string, limit int32, offset int32)) *MockTransferApprovalService_ListTransferApprovals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(int32), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalService_ListTransferApprovals_Call) Return(_a0 types.ListTransferApprovalsResponse, _a1 error) *MockTransferApprovalService_ListTransferApprovals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalService_ListTransferApprovals_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, int32, int32) (types.ListTransferApprovalsResponse, error)) *MockTransferApprovalService_ListTransferApprovals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectTransfer provides a mock function with given fields: ctx, accountID, transferApprovalID
+func (_m *MockTransferApprovalService) RejectTransfer(ctx context.Context, accountID uuid.UUID, transferApprovalID uuid.UUID) (types.RejectTransferResponse, error) {
+	ret := _m.Called(ctx, accountID, transferApprovalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectTransfer")
+	}
+
+	var r0 types.RejectTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (types.RejectTransferResponse, error)); ok {
+		return rf(ctx, accountID, transferApprovalID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) types.RejectTransferResponse); ok {
+		r0 = rf(ctx, accountID, transferApprovalID)
+	} else {
+		r0 = ret.Get(0).(types.RejectTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID, transferApprovalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalService_RejectTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectTransfer'
+type MockTransferApprovalService_RejectTransfer_Call struct {
+	*mock.Call
+}
+
+// RejectTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+//   - transferApprovalID uuid.UUID
+func (_e *MockTransferApprovalService_Expecter) RejectTransfer(ctx interface{}, accountID interface{}, transferApprovalID interface{}) *MockTransferApprovalService_RejectTransfer_Call {
+	return &MockTransferApprovalService_RejectTransfer_Call{Call: _e.mock.On("RejectTransfer", ctx, accountID, transferApprovalID)}
+}
+
+func (_c *MockTransferApprovalService_RejectTransfer_Call) Run(run func(ctx context.Context, accountID uuid.UUID, transferApprovalID uuid.UUID)) *MockTransferApprovalService_RejectTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalService_RejectTransfer_Call) Return(_a0 types.RejectTransferResponse, _a1 error) *MockTransferApprovalService_RejectTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalService_RejectTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (types.RejectTransferResponse, error)) *MockTransferApprovalService_RejectTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransferApprovalService creates a new instance of MockTransferApprovalService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransferApprovalService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransferApprovalService {
+	mock := &MockTransferApprovalService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,271 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	types "github.com/zaidsasa/xbankapi/types"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockWebhookService is an autogenerated mock type for the WebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+type MockWebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookService) EXPECT() *MockWebhookService_Expecter {
+	return &MockWebhookService_Expecter{mock: &_m.Mock}
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, req
+func (_m *MockWebhookService) CreateWebhook(ctx context.Context, req *types.CreateWebhookRequest) (types.CreateWebhookResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 types.CreateWebhookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateWebhookRequest) (types.CreateWebhookResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CreateWebhookRequest) types.CreateWebhookResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(types.CreateWebhookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.CreateWebhookRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockWebhookService_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *types.CreateWebhookRequest
+func (_e *MockWebhookService_Expecter) CreateWebhook(ctx interface{}, req interface{}) *MockWebhookService_CreateWebhook_Call {
+	return &MockWebhookService_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, req)}
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) Run(run func(ctx context.Context, req *types.CreateWebhookRequest)) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CreateWebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) Return(_a0 types.CreateWebhookResponse, _a1 error) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_CreateWebhook_Call) RunAndReturn(run func(context.Context, *types.CreateWebhookRequest) (types.CreateWebhookResponse, error)) *MockWebhookService_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, webhookID, deliveryID
+func (_m *MockWebhookService) GetWebhookDelivery(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID) (types.GetWebhookDeliveryResponse, error) {
+	ret := _m.Called(ctx, webhookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 types.GetWebhookDeliveryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (types.GetWebhookDeliveryResponse, error)); ok {
+		return rf(ctx, webhookID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) types.GetWebhookDeliveryResponse); ok {
+		r0 = rf(ctx, webhookID, deliveryID)
+	} else {
+		r0 = ret.Get(0).(types.GetWebhookDeliveryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, webhookID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type MockWebhookService_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+//   - deliveryID uuid.UUID
+func (_e *MockWebhookService_Expecter) GetWebhookDelivery(ctx interface{}, webhookID interface{}, deliveryID interface{}) *MockWebhookService_GetWebhookDelivery_Call {
+	return &MockWebhookService_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery", ctx, webhookID, deliveryID)}
+}
+
+func (_c *MockWebhookService_GetWebhookDelivery_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID)) *MockWebhookService_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookDelivery_Call) Return(_a0 types.GetWebhookDeliveryResponse, _a1 error) *MockWebhookService_GetWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_GetWebhookDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (types.GetWebhookDeliveryResponse, error)) *MockWebhookService_GetWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, webhookID, limit, offset
+func (_m *MockWebhookService) ListWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, limit int32, offset int32) (types.ListWebhookDeliveriesResponse, error) {
+	ret := _m.Called(ctx, webhookID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 types.ListWebhookDeliveriesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) (types.ListWebhookDeliveriesResponse, error)); ok {
+		return rf(ctx, webhookID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int32, int32) types.ListWebhookDeliveriesResponse); ok {
+		r0 = rf(ctx, webhookID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(types.ListWebhookDeliveriesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int32, int32) error); ok {
+		r1 = rf(ctx, webhookID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockWebhookService_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+//   - limit int32
+//   - offset int32
+func (_e *MockWebhookService_Expecter) ListWebhookDeliveries(ctx interface{}, webhookID interface{}, limit interface{}, offset interface{}) *MockWebhookService_ListWebhookDeliveries_Call {
+	return &MockWebhookService_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, webhookID, limit, offset)}
+}
+
+func (_c *MockWebhookService_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, limit int32, offset int32)) *MockWebhookService_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhookDeliveries_Call) Return(_a0 types.ListWebhookDeliveriesResponse, _a1 error) *MockWebhookService_ListWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, int32, int32) (types.ListWebhookDeliveriesResponse, error)) *MockWebhookService_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeliverWebhookDelivery provides a mock function with given fields: ctx, webhookID, deliveryID
+func (_m *MockWebhookService) RedeliverWebhookDelivery(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID) (types.RedeliverWebhookDeliveryResponse, error) {
+	ret := _m.Called(ctx, webhookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverWebhookDelivery")
+	}
+
+	var r0 types.RedeliverWebhookDeliveryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (types.RedeliverWebhookDeliveryResponse, error)); ok {
+		return rf(ctx, webhookID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) types.RedeliverWebhookDeliveryResponse); ok {
+		r0 = rf(ctx, webhookID, deliveryID)
+	} else {
+		r0 = ret.Get(0).(types.RedeliverWebhookDeliveryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, webhookID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookService_RedeliverWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhookDelivery'
+type MockWebhookService_RedeliverWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// RedeliverWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+//   - deliveryID uuid.UUID
+func (_e *MockWebhookService_Expecter) RedeliverWebhookDelivery(ctx interface{}, webhookID interface{}, deliveryID interface{}) *MockWebhookService_RedeliverWebhookDelivery_Call {
+	return &MockWebhookService_RedeliverWebhookDelivery_Call{Call: _e.mock.On("RedeliverWebhookDelivery", ctx, webhookID, deliveryID)}
+}
+
+func (_c *MockWebhookService_RedeliverWebhookDelivery_Call) Run(run func(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID)) *MockWebhookService_RedeliverWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RedeliverWebhookDelivery_Call) Return(_a0 types.RedeliverWebhookDeliveryResponse, _a1 error) *MockWebhookService_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookService_RedeliverWebhookDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (types.RedeliverWebhookDeliveryResponse, error)) *MockWebhookService_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
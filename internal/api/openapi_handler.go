@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+)
+
+const routeOpenAPI = "GET /openapi.json"
+
+type OpenAPIHandler struct {
+	spec []byte
+}
+
+// NewOpenAPIHandler returns a new OpenAPIHandler serving the provided OpenAPI document.
+func NewOpenAPIHandler(spec []byte) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		spec: spec,
+	}
+}
+
+// Register routes.
+func (h *OpenAPIHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *OpenAPIHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		routeOpenAPI: h.openAPI,
+	}
+}
+
+func (h *OpenAPIHandler) openAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if _, err := w.Write(h.spec); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
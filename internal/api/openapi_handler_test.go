@@ -0,0 +1,299 @@
+package api
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/beneficiary"
+	"github.com/zaidsasa/xbankapi/internal/customer"
+	"github.com/zaidsasa/xbankapi/internal/fee"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/interest"
+	"github.com/zaidsasa/xbankapi/internal/limits"
+	"github.com/zaidsasa/xbankapi/internal/openapi"
+	"github.com/zaidsasa/xbankapi/internal/overdraft"
+	"github.com/zaidsasa/xbankapi/internal/paymentfile"
+	"github.com/zaidsasa/xbankapi/internal/pocket"
+	"github.com/zaidsasa/xbankapi/internal/product"
+	"github.com/zaidsasa/xbankapi/internal/risk"
+	"github.com/zaidsasa/xbankapi/internal/sanctions"
+	"github.com/zaidsasa/xbankapi/internal/statement"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/webhook"
+)
+
+const (
+	typesPackageDir = "../../types"
+	testAdminToken  = "secret"
+)
+
+func TestOpenAPIHandler_openAPI(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w := httptest.NewRecorder()
+
+	NewOpenAPIHandler(openapi.Spec()).openAPI(w, r)
+
+	res := w.Result()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", res.Header.Get("Content-Type"))
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, openapi.Spec(), got)
+}
+
+func TestOpenAPI_coversRoutes(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	handlers := []interface {
+		routes() map[string]http.HandlerFunc
+	}{
+		NewAccountHandler(&ImplAccountService{}),
+		NewCustomerHandler(&customer.Service{}),
+		NewHolderHandler(&holder.Service{}, &holder.Approvals{}),
+		NewPocketHandler(&pocket.Service{}),
+		NewEventHandler(&ImplAccountService{}, nil),
+		NewStatementHandler(&statement.Service{}),
+		NewPaymentFileHandler(&paymentfile.Service{}),
+		NewBeneficiaryHandler(&beneficiary.Service{}),
+		NewLimitHandler(&limits.Service{}),
+		NewRiskHandler(&risk.Service{}),
+		NewSanctionsHandler(&sanctions.Service{}),
+		NewOverdraftHandler(&overdraft.Service{}),
+		NewProductHandler(&product.Service{}, &interest.Service{}),
+		NewFeeHandler(&fee.Service{}),
+		NewAuditHandler(&audit.Log{}),
+		NewWebhookHandler(&webhook.Service{}),
+		NewPropsHandler(storageMocks.NewMockDBConnection(t)),
+		NewOpenAPIHandler(nil),
+		NewMetricsHandler(http.NotFoundHandler()),
+	}
+
+	for _, handler := range handlers {
+		for pattern := range handler.routes() {
+			_, ok := doc.Operation(pattern)
+			assert.True(t, ok, "route %q of %T is missing from the openapi document", pattern, handler)
+		}
+	}
+}
+
+// contractTest is a request to the api, of which the request and the response are validated against the openapi
+// document.
+type contractTest struct {
+	name           string
+	method         string
+	path           string
+	body           string
+	contentType    string
+	admin          bool
+	handler        func(t *testing.T) contractHandler
+	wantStatusCode int
+}
+
+// contractHandler is the handler serving the request of a contract test.
+type contractHandler interface {
+	Register(mux *http.ServeMux)
+}
+
+func TestOpenAPI_contract(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	tests := slices.Concat(accountContractTests(), auditContractTests(), webhookContractTests(), propsContractTests(),
+		openAPIContractTests(), statementContractTests(), paymentFileContractTests(), beneficiaryContractTests(),
+		limitContractTests(), riskContractTests(), sanctionsContractTests(), overdraftContractTests(),
+		productContractTests(), feeContractTests(), customerContractTests(), holderContractTests(),
+		pocketContractTests())
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := contractMux(t, tt)
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			if tt.admin {
+				r.Header.Set("Authorization", "Bearer "+testAdminToken)
+			}
+
+			w := httptest.NewRecorder()
+
+			validator := openapi.NewValidator(doc,
+				openapi.WithResponseValidation(), openapi.WithErrorHandler(handleError))
+			audit.NewIdentifier(testAdminToken).Handler(validator.Middleware(mux)).ServeHTTP(w, r)
+
+			res := w.Result()
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, string(got))
+		})
+	}
+}
+
+// contractMux returns a mux serving the handler of the contract test.
+func contractMux(t *testing.T, tt contractTest) *http.ServeMux {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	tt.handler(t).Register(mux)
+
+	return mux
+}
+
+// expect sets the expectations of a mocked service, if any, and returns it.
+//
+//nolint:ireturn // returns the mock it is given.
+func expect[M any](m M, expectations func(M)) M {
+	if expectations != nil {
+		expectations(m)
+	}
+
+	return m
+}
+
+func TestOpenAPI_coversTypes(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	structs := parseStructs(t, typesPackageDir)
+	require.NotEmpty(t, structs)
+
+	for name := range structs {
+		schema, ok := doc.Schema(name)
+		if !assert.True(t, ok, "type %q is missing from the openapi document", name) {
+			continue
+		}
+
+		properties := doc.PropertyNames(schema)
+
+		for _, field := range jsonFields(structs, name) {
+			assert.Contains(t, properties, field, "field %q of type %q is missing from the openapi document", field, name)
+		}
+	}
+}
+
+// parseStructs returns the exported struct types declared in the package dir.
+func parseStructs(t *testing.T, dir string) map[string]*ast.StructType {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	structs := make(map[string]*ast.StructType)
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, entry.Name()), nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok || !spec.Name.IsExported() {
+				return true
+			}
+
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = st
+			}
+
+			return true
+		})
+	}
+
+	return structs
+}
+
+// jsonFields returns the JSON field names of the named struct, flattening embedded structs
+// the same way encoding/json does.
+func jsonFields(structs map[string]*ast.StructType, name string) []string {
+	var fields []string
+
+	for _, field := range structs[name].Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+
+		jsonName, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if ident, ok := field.Type.(*ast.Ident); ok && jsonName == "" {
+				fields = append(fields, jsonFields(structs, ident.Name)...)
+
+				continue
+			}
+		}
+
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+
+			if jsonName == "" {
+				fields = append(fields, fieldName.Name)
+			} else {
+				fields = append(fields, jsonName)
+			}
+		}
+	}
+
+	return fields
+}
+
+func openAPIContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "openapi",
+			method:         http.MethodGet,
+			path:           "/openapi.json",
+			wantStatusCode: http.StatusOK,
+			handler:        openAPIContract(),
+		},
+	}
+}
+
+// openAPIContract returns the openapi handler of a contract test.
+func openAPIContract() func(*testing.T) contractHandler {
+	return func(*testing.T) contractHandler {
+		return NewOpenAPIHandler(openapi.Spec())
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	grantOverdraftRoute  = "PUT /admin/accounts/{id}/overdraft"
+	revokeOverdraftRoute = "DELETE /admin/accounts/{id}/overdraft"
+)
+
+type OverdraftService interface {
+	GrantOverdraft(
+		ctx context.Context, accountID uuid.UUID, req *types.GrantOverdraftRequest,
+	) (types.GrantOverdraftResponse, error)
+	RevokeOverdraft(ctx context.Context, accountID uuid.UUID) error
+}
+
+type OverdraftHandler struct {
+	service OverdraftService
+}
+
+// NewOverdraftHandler returns a new OverdraftHandler.
+func NewOverdraftHandler(service OverdraftService) *OverdraftHandler {
+	return &OverdraftHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *OverdraftHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *OverdraftHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		grantOverdraftRoute:  requireAdmin(h.grantOverdraft),
+		revokeOverdraftRoute: requireAdmin(h.revokeOverdraft),
+	}
+}
+
+func (h *OverdraftHandler) grantOverdraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.GrantOverdraftRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.GrantOverdraft(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *OverdraftHandler) revokeOverdraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.RevokeOverdraft(ctx, accountID); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestNewOverdraftHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewOverdraftHandler(mocks.NewMockOverdraftService(t))
+	assert.NotNil(t, got)
+}
+
+func TestOverdraftHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		accountID      string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockOverdraftService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "grant failed when not made by the admin",
+			route:          grantOverdraftRoute,
+			accountID:      wantAccountID.String(),
+			body:           `{"limit":50000}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "grant failed when account id is invalid",
+			route:          grantOverdraftRoute,
+			accountID:      "one",
+			body:           `{"limit":50000}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:           "grant failed when limit is not positive",
+			route:          grantOverdraftRoute,
+			accountID:      wantAccountID.String(),
+			body:           `{"limit":0}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"limit":{"money_amount":"limit field did not pass validation"}}`,
+		},
+		{
+			name:      "grant failed when account not found",
+			route:     grantOverdraftRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"limit":50000}`,
+			admin:     true,
+			mock: func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().GrantOverdraft(mock.Anything, wantAccountID, &types.GrantOverdraftRequest{Limit: 50000}).
+					Return(types.GrantOverdraftResponse{}, types.ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "grant success",
+			route:     grantOverdraftRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"limit":50000}`,
+			admin:     true,
+			mock: func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().GrantOverdraft(mock.Anything, wantAccountID, &types.GrantOverdraftRequest{Limit: 50000}).
+					Return(types.GrantOverdraftResponse{
+						Overdraft: types.Overdraft{AccountID: wantAccountID, Limit: 50000},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"accountId":"12345678-1234-1234-1234-123456789001","limit":50000}
+`,
+		},
+		{
+			name:           "revoke failed when not made by the admin",
+			route:          revokeOverdraftRoute,
+			accountID:      wantAccountID.String(),
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:      "revoke failed when account not found",
+			route:     revokeOverdraftRoute,
+			accountID: wantAccountID.String(),
+			admin:     true,
+			mock: func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().RevokeOverdraft(mock.Anything, wantAccountID).Return(types.ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "revoke success",
+			route:     revokeOverdraftRoute,
+			accountID: wantAccountID.String(),
+			admin:     true,
+			mock: func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().RevokeOverdraft(mock.Anything, wantAccountID).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/admin/accounts", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			overdraftServiceMock := mocks.NewMockOverdraftService(t)
+
+			if tt.mock != nil {
+				tt.mock(overdraftServiceMock)
+			}
+
+			NewOverdraftHandler(overdraftServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func overdraftContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "grant overdraft",
+			method:         http.MethodPut,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/overdraft",
+			body:           `{"limit":50000}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: overdraftContract(func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().GrantOverdraft(mock.Anything, wantAccountID, mock.Anything).Return(types.GrantOverdraftResponse{
+					Overdraft: types.Overdraft{AccountID: wantAccountID, Limit: 50000},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "grant overdraft rejected by the contract",
+			method:         http.MethodPut,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/overdraft",
+			body:           `{"limit":-1}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        overdraftContract(nil),
+		},
+		{
+			name:           "revoke overdraft",
+			method:         http.MethodDelete,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/overdraft",
+			admin:          true,
+			wantStatusCode: http.StatusNoContent,
+			handler: overdraftContract(func(mos *mocks.MockOverdraftService) {
+				mos.EXPECT().RevokeOverdraft(mock.Anything, wantAccountID).Return(nil).Once()
+			}),
+		},
+	}
+}
+
+// overdraftContract returns the overdraft handler of a contract test, its service mocked with expectations.
+func overdraftContract(expectations func(*mocks.MockOverdraftService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewOverdraftHandler(expect(mocks.NewMockOverdraftService(t), expectations))
+	}
+}
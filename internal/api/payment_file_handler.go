@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/zaidsasa/xbankapi/internal/paymentfile"
+)
+
+const (
+	importPaymentFileRoute = "POST /accounts/{id}/payment-files"
+	getPaymentFileRoute    = "GET /accounts/{id}/payment-files/{paymentFileId}"
+
+	pathValuePaymentFileID = "paymentFileId"
+
+	// maxPaymentFileSize is the size of payment files at most, 10 MiB.
+	maxPaymentFileSize = 10 << 20
+)
+
+type PaymentFileService interface {
+	Import(ctx context.Context, accountID uuid.UUID, r io.Reader) (paymentfile.Report, error)
+	GetReport(ctx context.Context, accountID, paymentFileID uuid.UUID) (paymentfile.Report, error)
+}
+
+type PaymentFileHandler struct {
+	service PaymentFileService
+}
+
+// NewPaymentFileHandler returns a new PaymentFileHandler.
+func NewPaymentFileHandler(service PaymentFileService) *PaymentFileHandler {
+	return &PaymentFileHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *PaymentFileHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *PaymentFileHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		importPaymentFileRoute: h.importPaymentFile,
+		getPaymentFileRoute:    h.getPaymentFile,
+	}
+}
+
+// importPaymentFile imports a pain.001 payment file, responding with its pain.002 status report, whose location is
+// where it can be fetched again.
+func (h *PaymentFileHandler) importPaymentFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	report, err := h.service.Import(ctx, accountID, http.MaxBytesReader(w, r.Body, maxPaymentFileSize))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/accounts/%s/payment-files/%s", accountID, report.ID))
+
+	writeReport(w, report)
+}
+
+func (h *PaymentFileHandler) getPaymentFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	paymentFileID, err := uuid.Parse(r.PathValue(pathValuePaymentFileID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	report, err := h.service.GetReport(ctx, accountID, paymentFileID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	writeReport(w, report)
+}
+
+func writeReport(w http.ResponseWriter, report paymentfile.Report) {
+	var b bytes.Buffer
+
+	if err := report.WriteXML(&b); err != nil {
+		handleError(w, ErrInternal, http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+
+	_, _ = w.Write(b.Bytes())
+}
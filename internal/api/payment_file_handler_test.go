@@ -0,0 +1,281 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/paymentfile"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantPaymentFileID = uuid.MustParse("12345678-1234-1234-1234-123456789030")
+
+func testReport() paymentfile.Report {
+	return paymentfile.Report{
+		ID:                   wantPaymentFileID,
+		CreatedAt:            time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		MessageID:            "MSG-1",
+		MessageCreatedAt:     time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		NumberOfTransactions: 1,
+		Status:               paymentfile.StatusAccepted,
+		PaymentInformations: []paymentfile.PaymentInformationReport{
+			{
+				ID:     "PMT-1",
+				Status: paymentfile.StatusAccepted,
+				Payments: []paymentfile.PaymentReport{
+					{EndToEndID: "E2E-1", Status: paymentfile.StatusAccepted},
+				},
+			},
+		},
+	}
+}
+
+const wantReport = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.10">
+  <CstmrPmtStsRpt>
+    <GrpHdr>
+      <MsgId>12345678123412341234123456789030</MsgId>
+      <CreDtTm>2024-05-01T10:00:00Z</CreDtTm>
+    </GrpHdr>
+    <OrgnlGrpInfAndSts>
+      <OrgnlMsgId>MSG-1</OrgnlMsgId>
+      <OrgnlMsgNmId>pain.001.001.09</OrgnlMsgNmId>
+      <OrgnlCreDtTm>2024-05-01T09:00:00Z</OrgnlCreDtTm>
+      <OrgnlNbOfTxs>1</OrgnlNbOfTxs>
+      <GrpSts>ACSC</GrpSts>
+    </OrgnlGrpInfAndSts>
+    <OrgnlPmtInfAndSts>
+      <OrgnlPmtInfId>PMT-1</OrgnlPmtInfId>
+      <OrgnlNbOfTxs>1</OrgnlNbOfTxs>
+      <PmtInfSts>ACSC</PmtInfSts>
+      <TxInfAndSts>
+        <OrgnlEndToEndId>E2E-1</OrgnlEndToEndId>
+        <TxSts>ACSC</TxSts>
+      </TxInfAndSts>
+    </OrgnlPmtInfAndSts>
+  </CstmrPmtStsRpt>
+</Document>
+`
+
+func TestNewPaymentFileHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewPaymentFileHandler(mocks.NewMockPaymentFileService(t))
+	assert.NotNil(t, got)
+}
+
+func TestPaymentFileHandler_importPaymentFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		accountID       string
+		mock            func(*mocks.MockPaymentFileService)
+		wantStatusCode  int
+		wantContentType string
+		wantLocation    string
+		want            string
+	}{
+		{
+			name:            "failed when account id is invalid",
+			accountID:       "one",
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "failed when the file is invalid",
+			accountID: wantAccountID.String(),
+			mock: func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().Import(mock.Anything, wantAccountID, mock.Anything).
+					Return(paymentfile.Report{}, types.ErrInvalidPaymentFile).Once()
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"invalid payment file","code":"INVALID_PAYMENT_FILE"}
+`,
+		},
+		{
+			name:      "failed when the file was already imported",
+			accountID: wantAccountID.String(),
+			mock: func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().Import(mock.Anything, wantAccountID, mock.Anything).
+					Return(paymentfile.Report{}, types.ErrPaymentFileAlreadyImported).Once()
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"a payment file with the same message id was already imported",` +
+				`"code":"PAYMENT_FILE_ALREADY_IMPORTED"}
+`,
+		},
+		{
+			name:      "success",
+			accountID: wantAccountID.String(),
+			mock: func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().Import(mock.Anything, wantAccountID, mock.Anything).Return(testReport(), nil).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/xml; charset=utf-8",
+			wantLocation: "/accounts/12345678-1234-1234-1234-123456789001/payment-files/" +
+				"12345678-1234-1234-1234-123456789030",
+			want: wantReport,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/accounts/"+tt.accountID+"/payment-files",
+				strings.NewReader("<Document/>"))
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			w := httptest.NewRecorder()
+
+			paymentFileServiceMock := mocks.NewMockPaymentFileService(t)
+
+			if tt.mock != nil {
+				tt.mock(paymentFileServiceMock)
+			}
+
+			NewPaymentFileHandler(paymentFileServiceMock).importPaymentFile(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+			assert.Equal(t, tt.wantContentType, res.Header.Get("Content-Type"))
+			assert.Equal(t, tt.wantLocation, res.Header.Get("Location"))
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestPaymentFileHandler_getPaymentFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		paymentFileID  string
+		mock           func(*mocks.MockPaymentFileService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "failed when payment file id is invalid",
+			paymentFileID:  "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:          "failed when payment file not found",
+			paymentFileID: wantPaymentFileID.String(),
+			mock: func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().GetReport(mock.Anything, wantAccountID, wantPaymentFileID).
+					Return(paymentfile.Report{}, types.ErrPaymentFileNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"payment file not found","code":"PAYMENT_FILE_NOT_FOUND"}
+`,
+		},
+		{
+			name:          "success",
+			paymentFileID: wantPaymentFileID.String(),
+			mock: func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().GetReport(mock.Anything, wantAccountID, wantPaymentFileID).
+					Return(testReport(), nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantReport,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet,
+				"/accounts/"+wantAccountID.String()+"/payment-files/"+tt.paymentFileID, nil)
+			r.SetPathValue(pathValueID, wantAccountID.String())
+			r.SetPathValue(pathValuePaymentFileID, tt.paymentFileID)
+
+			w := httptest.NewRecorder()
+
+			paymentFileServiceMock := mocks.NewMockPaymentFileService(t)
+
+			if tt.mock != nil {
+				tt.mock(paymentFileServiceMock)
+			}
+
+			NewPaymentFileHandler(paymentFileServiceMock).getPaymentFile(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func paymentFileContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "import payment file",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/payment-files",
+			body:           "<Document/>",
+			contentType:    "application/xml",
+			wantStatusCode: http.StatusOK,
+			handler: paymentFileContract(func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().Import(mock.Anything, wantAccountID, mock.Anything).Return(testReport(), nil).Once()
+			}),
+		},
+		{
+			name:           "import payment file rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/payment-files",
+			body:           `{"name":"name"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        paymentFileContract(nil),
+		},
+		{
+			name:           "get payment file",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/payment-files/" + wantPaymentFileID.String(),
+			wantStatusCode: http.StatusOK,
+			handler: paymentFileContract(func(mps *mocks.MockPaymentFileService) {
+				mps.EXPECT().GetReport(mock.Anything, wantAccountID, wantPaymentFileID).Return(testReport(), nil).Once()
+			}),
+		},
+	}
+}
+
+// paymentFileContract returns the payment file handler of a contract test, its service mocked with expectations.
+func paymentFileContract(expectations func(*mocks.MockPaymentFileService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewPaymentFileHandler(expect(mocks.NewMockPaymentFileService(t), expectations))
+	}
+}
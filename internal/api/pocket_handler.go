@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	createPocketRoute     = "POST /accounts/{id}/pockets"
+	listPocketsRoute      = "GET /accounts/{id}/pockets"
+	setPocketGoalRoute    = "PUT /accounts/{id}/pockets/{pocketId}/goal"
+	deletePocketGoalRoute = "DELETE /accounts/{id}/pockets/{pocketId}/goal"
+	moveToPocketRoute     = "POST /accounts/{id}/pockets/{pocketId}/deposit"
+	moveFromPocketRoute   = "POST /accounts/{id}/pockets/{pocketId}/withdraw"
+
+	pathValuePocketID = "pocketId"
+)
+
+type PocketService interface {
+	CreatePocket(
+		ctx context.Context, accountID uuid.UUID, req *types.CreatePocketRequest,
+	) (types.CreatePocketResponse, error)
+	ListPockets(ctx context.Context, accountID uuid.UUID) (types.ListPocketsResponse, error)
+	SetPocketGoal(
+		ctx context.Context, accountID, pocketID uuid.UUID, req *types.SetPocketGoalRequest,
+	) (types.SetPocketGoalResponse, error)
+	DeletePocketGoal(ctx context.Context, accountID, pocketID uuid.UUID) error
+	MoveToPocket(
+		ctx context.Context, accountID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest,
+	) (types.MovePocketMoneyResponse, error)
+	MoveFromPocket(
+		ctx context.Context, accountID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest,
+	) (types.MovePocketMoneyResponse, error)
+}
+
+type PocketHandler struct {
+	service PocketService
+}
+
+// NewPocketHandler returns a new PocketHandler.
+func NewPocketHandler(service PocketService) *PocketHandler {
+	return &PocketHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *PocketHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *PocketHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		createPocketRoute:     h.createPocket,
+		listPocketsRoute:      h.listPockets,
+		setPocketGoalRoute:    h.setPocketGoal,
+		deletePocketGoalRoute: h.deletePocketGoal,
+		moveToPocketRoute:     h.moveToPocket,
+		moveFromPocketRoute:   h.moveFromPocket,
+	}
+}
+
+func (h *PocketHandler) createPocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.CreatePocketRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.CreatePocket(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *PocketHandler) listPockets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListPockets(ctx, accountID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *PocketHandler) setPocketGoal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetPocketGoalRequest{}
+
+	accountID, pocketID, err := accountPath(r, pathValuePocketID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetPocketGoal(ctx, accountID, pocketID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *PocketHandler) deletePocketGoal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, pocketID, err := accountPath(r, pathValuePocketID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.service.DeletePocketGoal(ctx, accountID, pocketID); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PocketHandler) moveToPocket(w http.ResponseWriter, r *http.Request) {
+	h.move(w, r, h.service.MoveToPocket)
+}
+
+func (h *PocketHandler) moveFromPocket(w http.ResponseWriter, r *http.Request) {
+	h.move(w, r, h.service.MoveFromPocket)
+}
+
+// move moves money between an account and one of its pockets, in the direction of fn.
+func (h *PocketHandler) move(
+	w http.ResponseWriter,
+	r *http.Request,
+	fn func(
+		ctx context.Context, accountID, pocketID uuid.UUID, req *types.MovePocketMoneyRequest,
+	) (types.MovePocketMoneyResponse, error),
+) {
+	ctx := r.Context()
+	req := &types.MovePocketMoneyRequest{}
+
+	accountID, pocketID, err := accountPath(r, pathValuePocketID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := fn(ctx, accountID, pocketID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
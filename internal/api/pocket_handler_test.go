@@ -0,0 +1,327 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantPocket = types.Pocket{
+	ID:              wantPocketID,
+	ParentAccountID: wantAccountID,
+	Name:            "Holidays",
+	CurrencyCode:    "EUR",
+	Balance:         2000,
+	GoalAmount:      200000,
+	GoalDate:        "2025-07-01",
+}
+
+const wantPocketJSON = `{"id":"12345678-1234-1234-1234-123456789007",` +
+	`"parentAccountId":"12345678-1234-1234-1234-123456789001","name":"Holidays","currencyCode":"EUR",` +
+	`"balance":2000,"goalAmount":200000,"goalDate":"2025-07-01"}`
+
+func TestNewPocketHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewPocketHandler(mocks.NewMockPocketService(t))
+	assert.NotNil(t, got)
+}
+
+func TestPocketHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		pocketID       string
+		body           string
+		mock           func(*mocks.MockPocketService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "create pocket failed when name is too short",
+			route:          createPocketRoute,
+			body:           `{"name":"ab"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"name":{"minLen":"name min length is 3"}}`,
+		},
+		{
+			name:  "create pocket failed when the account is a pocket",
+			route: createPocketRoute,
+			body:  `{"name":"Holidays"}`,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().CreatePocket(mock.Anything, wantAccountID, &types.CreatePocketRequest{Name: "Holidays"}).
+					Return(types.CreatePocketResponse{}, types.ErrPocketNotAllowed).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"a pocket cannot have pockets","code":"POCKET_NOT_ALLOWED"}
+`,
+		},
+		{
+			name:  "create pocket success",
+			route: createPocketRoute,
+			body:  `{"name":"Holidays"}`,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().CreatePocket(mock.Anything, wantAccountID, &types.CreatePocketRequest{Name: "Holidays"}).
+					Return(types.CreatePocketResponse{Pocket: wantPocket}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantPocketJSON + `
+`,
+		},
+		{
+			name:  "list pockets success",
+			route: listPocketsRoute,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().ListPockets(mock.Anything, wantAccountID).
+					Return(types.ListPocketsResponse{Pockets: []types.Pocket{wantPocket}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"pockets":[` + wantPocketJSON + `]}
+`,
+		},
+		{
+			name:           "set pocket goal failed when pocket id is invalid",
+			route:          setPocketGoalRoute,
+			pocketID:       "invalid",
+			body:           `{"amount":200000}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 7"}
+`,
+		},
+		{
+			name:           "set pocket goal failed when date is invalid",
+			route:          setPocketGoalRoute,
+			pocketID:       wantPocketID.String(),
+			body:           `{"amount":200000,"date":"1 July"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"date":{"date_only":"date must be a date as YYYY-MM-DD"}}`,
+		},
+		{
+			name:     "set pocket goal success",
+			route:    setPocketGoalRoute,
+			pocketID: wantPocketID.String(),
+			body:     `{"amount":200000,"date":"2025-07-01"}`,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().SetPocketGoal(mock.Anything, wantAccountID, wantPocketID,
+					&types.SetPocketGoalRequest{Amount: 200000, Date: "2025-07-01"}).
+					Return(types.SetPocketGoalResponse{Pocket: wantPocket}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantPocketJSON + `
+`,
+		},
+		{
+			name:     "delete pocket goal failed when pocket not found",
+			route:    deletePocketGoalRoute,
+			pocketID: wantPocketID.String(),
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().DeletePocketGoal(mock.Anything, wantAccountID, wantPocketID).
+					Return(types.ErrPocketNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"pocket not found","code":"POCKET_NOT_FOUND"}
+`,
+		},
+		{
+			name:     "delete pocket goal success",
+			route:    deletePocketGoalRoute,
+			pocketID: wantPocketID.String(),
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().DeletePocketGoal(mock.Anything, wantAccountID, wantPocketID).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "move money to pocket failed when amount is invalid",
+			route:          moveToPocketRoute,
+			pocketID:       wantPocketID.String(),
+			body:           `{"amount":0}`,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"amount":{"money_amount":"amount field did not pass validation"}}`,
+		},
+		{
+			name:     "move money to pocket success",
+			route:    moveToPocketRoute,
+			pocketID: wantPocketID.String(),
+			body:     `{"amount":2000}`,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().MoveToPocket(mock.Anything, wantAccountID, wantPocketID,
+					&types.MovePocketMoneyRequest{Amount: 2000}).
+					Return(types.MovePocketMoneyResponse{Pocket: wantPocket}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: wantPocketJSON + `
+`,
+		},
+		{
+			name:     "move money from pocket failed when its balance is insufficient",
+			route:    moveFromPocketRoute,
+			pocketID: wantPocketID.String(),
+			body:     `{"amount":5000}`,
+			mock: func(mps *mocks.MockPocketService) {
+				mps.EXPECT().MoveFromPocket(mock.Anything, wantAccountID, wantPocketID,
+					&types.MovePocketMoneyRequest{Amount: 5000}).
+					Return(types.MovePocketMoneyResponse{}, types.ErrInsufficientAccountBalance).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"insufficient account balance","code":"INSUFFICIENT_ACCOUNT_BALANCE"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, wantAccountID.String())
+			r.SetPathValue(pathValuePocketID, tt.pocketID)
+
+			w := httptest.NewRecorder()
+
+			pocketServiceMock := mocks.NewMockPocketService(t)
+
+			if tt.mock != nil {
+				tt.mock(pocketServiceMock)
+			}
+
+			NewPocketHandler(pocketServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func pocketContractTests() []contractTest {
+	pocketsPath := "/accounts/" + wantAccountID.String() + "/pockets"
+	pocketPath := pocketsPath + "/" + wantPocketID.String()
+
+	return []contractTest{
+		{
+			name:           "get account with pockets",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String(),
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				total := money.Amount(3000)
+
+				mas.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+					Account:      types.Account{ID: wantAccountID, Name: "test", Email: "test@mail.com", CurrencyCode: "EUR"},
+					Balance:      1000,
+					Pockets:      []types.Pocket{wantPocket},
+					TotalBalance: &total,
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "create pocket",
+			method:         http.MethodPost,
+			path:           pocketsPath,
+			body:           `{"name":"Holidays"}`,
+			wantStatusCode: http.StatusOK,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().CreatePocket(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.CreatePocketResponse{Pocket: wantPocket}, nil).Once()
+			}),
+		},
+		{
+			name:           "create pocket of a pocket",
+			method:         http.MethodPost,
+			path:           pocketsPath,
+			body:           `{"name":"Holidays"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().CreatePocket(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.CreatePocketResponse{}, types.ErrPocketNotAllowed).Once()
+			}),
+		},
+		{
+			name:           "list pockets",
+			method:         http.MethodGet,
+			path:           pocketsPath,
+			wantStatusCode: http.StatusOK,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().ListPockets(mock.Anything, wantAccountID).
+					Return(types.ListPocketsResponse{Pockets: []types.Pocket{wantPocket}}, nil).Once()
+			}),
+		},
+		{
+			name:           "set pocket goal",
+			method:         http.MethodPut,
+			path:           pocketPath + "/goal",
+			body:           `{"amount":200000,"date":"2025-07-01"}`,
+			wantStatusCode: http.StatusOK,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().SetPocketGoal(mock.Anything, wantAccountID, wantPocketID, mock.Anything).
+					Return(types.SetPocketGoalResponse{Pocket: wantPocket}, nil).Once()
+			}),
+		},
+		{
+			name:           "set pocket goal rejected by the contract",
+			method:         http.MethodPut,
+			path:           pocketPath + "/goal",
+			body:           `{"amount":200000,"date":"1 July"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        pocketContract(nil),
+		},
+		{
+			name:           "delete pocket goal",
+			method:         http.MethodDelete,
+			path:           pocketPath + "/goal",
+			wantStatusCode: http.StatusNoContent,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().DeletePocketGoal(mock.Anything, wantAccountID, wantPocketID).Return(nil).Once()
+			}),
+		},
+		{
+			name:           "move money to pocket",
+			method:         http.MethodPost,
+			path:           pocketPath + "/deposit",
+			body:           `{"amount":2000}`,
+			wantStatusCode: http.StatusOK,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().MoveToPocket(mock.Anything, wantAccountID, wantPocketID, mock.Anything).
+					Return(types.MovePocketMoneyResponse{Pocket: wantPocket}, nil).Once()
+			}),
+		},
+		{
+			name:           "move money from pocket",
+			method:         http.MethodPost,
+			path:           pocketPath + "/withdraw",
+			body:           `{"amount":2000}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: pocketContract(func(mps *mocks.MockPocketService) {
+				mps.EXPECT().MoveFromPocket(mock.Anything, wantAccountID, wantPocketID, mock.Anything).
+					Return(types.MovePocketMoneyResponse{}, types.ErrInsufficientAccountBalance).Once()
+			}),
+		},
+	}
+}
+
+// pocketContract returns the pocket handler of a contract test, its service mocked with expectations.
+func pocketContract(expectations func(*mocks.MockPocketService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewPocketHandler(expect(mocks.NewMockPocketService(t), expectations))
+	}
+}
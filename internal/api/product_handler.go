@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listProductsRoute         = "GET /products"
+	setProductRoute           = "PUT /admin/products/{code}"
+	setAccountProductRoute    = "PUT /admin/accounts/{id}/product"
+	listInterestAccrualsRoute = "GET /accounts/{id}/interest-accruals"
+
+	pathValueCode        = "code"
+	maxProductCodeLength = 32
+)
+
+var errInvalidProductCode = errors.New("code must be 1 to 32 characters")
+
+type ProductService interface {
+	ListProducts(ctx context.Context) (types.ListProductsResponse, error)
+	SetProduct(ctx context.Context, code string, req *types.SetProductRequest) (types.SetProductResponse, error)
+	SetAccountProduct(
+		ctx context.Context, accountID uuid.UUID, req *types.SetAccountProductRequest,
+	) (types.SetAccountProductResponse, error)
+}
+
+type InterestService interface {
+	ListAccruals(
+		ctx context.Context, accountID uuid.UUID, limit, offset int32) (types.ListInterestAccrualsResponse, error)
+}
+
+type ProductHandler struct {
+	service  ProductService
+	interest InterestService
+}
+
+// NewProductHandler returns a new ProductHandler.
+func NewProductHandler(service ProductService, interest InterestService) *ProductHandler {
+	return &ProductHandler{
+		service:  service,
+		interest: interest,
+	}
+}
+
+// Register routes.
+func (h *ProductHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *ProductHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listProductsRoute:         h.listProducts,
+		setProductRoute:           requireAdmin(h.setProduct),
+		setAccountProductRoute:    requireAdmin(h.setAccountProduct),
+		listInterestAccrualsRoute: h.listInterestAccruals,
+	}
+}
+
+func (h *ProductHandler) listProducts(w http.ResponseWriter, r *http.Request) {
+	res, err := h.service.ListProducts(r.Context())
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *ProductHandler) setProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetProductRequest{}
+
+	code, err := productCode(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetProduct(ctx, code, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *ProductHandler) setAccountProduct(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.SetAccountProductRequest{}
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.SetAccountProduct(ctx, accountID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *ProductHandler) listInterestAccruals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.interest.ListAccruals(ctx, accountID, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// productCode returns the product code of the path, failing with errInvalidProductCode when it is too long.
+func productCode(r *http.Request) (string, error) {
+	code := r.PathValue(pathValueCode)
+	if code == "" || len(code) > maxProductCodeLength {
+		return "", errInvalidProductCode
+	}
+
+	return code, nil
+}
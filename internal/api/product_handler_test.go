@@ -0,0 +1,356 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantProduct = types.Product{
+	Code:          "savings",
+	Name:          "Savings account",
+	InterestRate:  "0.025",
+	DayCount:      types.DayCount30360,
+	CurrencyCodes: []string{"EUR", "USD"},
+	LimitTier:     "standard",
+	CreatedAt:     time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	UpdatedAt:     time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+}
+
+func TestNewProductHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewProductHandler(mocks.NewMockProductService(t), mocks.NewMockInterestService(t))
+	assert.NotNil(t, got)
+}
+
+func TestProductHandler(t *testing.T) {
+	t.Parallel()
+
+	setRequest := &types.SetProductRequest{
+		Name:         "Savings account",
+		InterestRate: "0.025",
+		DayCount:     types.DayCount30360,
+	}
+
+	tests := []struct {
+		name           string
+		route          string
+		accountID      string
+		code           string
+		query          string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockProductService)
+		interestMock   func(*mocks.MockInterestService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:  "list products failed",
+			route: listProductsRoute,
+			mock: func(mps *mocks.MockProductService) {
+				mps.EXPECT().ListProducts(mock.Anything).Return(types.ListProductsResponse{}, types.ErrInternal).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			want: `{"message":"internal server error","code":"INTERNAL"}
+`,
+		},
+		{
+			name:  "list products success",
+			route: listProductsRoute,
+			mock: func(mps *mocks.MockProductService) {
+				mps.EXPECT().ListProducts(mock.Anything).
+					Return(types.ListProductsResponse{Products: []types.Product{wantProduct}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"products":[{"code":"savings","name":"Savings account","interestRate":"0.025","dayCount":"30/360",` +
+				`"currencyCodes":["EUR","USD"],"limitTier":"standard","overdraftEligible":false,"maxOverdraftLimit":0,` +
+				`"createdAt":"2024-05-17T10:00:00Z","updatedAt":"2024-05-17T10:00:00Z"}]}
+`,
+		},
+		{
+			name:           "set product failed when not made by the admin",
+			route:          setProductRoute,
+			code:           "savings",
+			body:           `{"name":"Savings account","interestRate":"0.025","dayCount":"30/360"}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "set product failed when code is too long",
+			route:          setProductRoute,
+			code:           strings.Repeat("s", 33),
+			body:           `{"name":"Savings account","interestRate":"0.025","dayCount":"30/360"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"code must be 1 to 32 characters"}
+`,
+		},
+		{
+			name:           "set product failed when interest rate is invalid",
+			route:          setProductRoute,
+			code:           "savings",
+			body:           `{"name":"Savings account","interestRate":"2.5%","dayCount":"30/360"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"interestRate":{"interest_rate":"interestRate must be a decimal from 0 to 1"}}`,
+		},
+		{
+			name:           "set product failed when day count is unknown",
+			route:          setProductRoute,
+			code:           "savings",
+			body:           `{"name":"Savings account","interestRate":"0.025","dayCount":"ACT/360"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"dayCount":{"in":"dayCount value must be in the enum [ACT/365 30/360]"}}`,
+		},
+		{
+			name:           "set product failed when a currency code is invalid",
+			route:          setProductRoute,
+			code:           "savings",
+			body:           `{"name":"Savings account","interestRate":"0.025","dayCount":"30/360","currencyCodes":["EURO"]}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"currencyCodes":{"currency_codes":"currencyCodes must be currency codes"}}`,
+		},
+		{
+			name:  "set product success",
+			route: setProductRoute,
+			code:  "savings",
+			body:  `{"name":"Savings account","interestRate":"0.025","dayCount":"30/360"}`,
+			admin: true,
+			mock: func(mps *mocks.MockProductService) {
+				mps.EXPECT().SetProduct(mock.Anything, "savings", setRequest).
+					Return(types.SetProductResponse{Product: wantProduct}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"code":"savings","name":"Savings account","interestRate":"0.025","dayCount":"30/360",` +
+				`"currencyCodes":["EUR","USD"],"limitTier":"standard","overdraftEligible":false,"maxOverdraftLimit":0,` +
+				`"createdAt":"2024-05-17T10:00:00Z","updatedAt":"2024-05-17T10:00:00Z"}
+`,
+		},
+		{
+			name:           "set account product failed when not made by the admin",
+			route:          setAccountProductRoute,
+			accountID:      wantAccountID.String(),
+			body:           `{"productCode":"savings"}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "set account product failed when account id is invalid",
+			route:          setAccountProductRoute,
+			accountID:      "one",
+			body:           `{"productCode":"savings"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "set account product failed when product not found",
+			route:     setAccountProductRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"productCode":"savings"}`,
+			admin:     true,
+			mock: func(mps *mocks.MockProductService) {
+				mps.EXPECT().SetAccountProduct(mock.Anything, wantAccountID,
+					&types.SetAccountProductRequest{ProductCode: "savings"}).
+					Return(types.SetAccountProductResponse{}, types.ErrProductNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"product not found","code":"PRODUCT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "set account product success",
+			route:     setAccountProductRoute,
+			accountID: wantAccountID.String(),
+			body:      `{"productCode":"savings"}`,
+			admin:     true,
+			mock: func(mps *mocks.MockProductService) {
+				mps.EXPECT().SetAccountProduct(mock.Anything, wantAccountID,
+					&types.SetAccountProductRequest{ProductCode: "savings"}).
+					Return(types.SetAccountProductResponse{AccountID: wantAccountID, ProductCode: "savings"}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"accountId":"12345678-1234-1234-1234-123456789001","productCode":"savings"}
+`,
+		},
+		{
+			name:           "list accruals failed when limit is invalid",
+			route:          listInterestAccrualsRoute,
+			accountID:      wantAccountID.String(),
+			query:          "?limit=0",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit must be between 1 and 100 and offset must not be negative"}
+`,
+		},
+		{
+			name:      "list accruals failed when account not found",
+			route:     listInterestAccrualsRoute,
+			accountID: wantAccountID.String(),
+			interestMock: func(mis *mocks.MockInterestService) {
+				mis.EXPECT().ListAccruals(mock.Anything, wantAccountID, int32(50), int32(0)).
+					Return(types.ListInterestAccrualsResponse{}, types.ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "list accruals success",
+			route:     listInterestAccrualsRoute,
+			accountID: wantAccountID.String(),
+			query:     "?limit=1&offset=2",
+			interestMock: func(mis *mocks.MockInterestService) {
+				mis.EXPECT().ListAccruals(mock.Anything, wantAccountID, int32(1), int32(2)).
+					Return(types.ListInterestAccrualsResponse{Accruals: []types.InterestAccrual{{
+						Day:          "2024-05-16",
+						ProductCode:  "savings",
+						Balance:      100000,
+						InterestRate: "0.025",
+						DayCount:     types.DayCount30360,
+						Amount:       "0.0694444444",
+						CreatedAt:    time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+					}}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"accruals":[{"day":"2024-05-16","productCode":"savings","balance":100000,"interestRate":"0.025",` +
+				`"dayCount":"30/360","amount":"0.0694444444","transactionId":null,"createdAt":"2024-05-17T10:00:00Z"}]}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/products"+tt.query, strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.accountID)
+			r.SetPathValue(pathValueCode, tt.code)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			productServiceMock := mocks.NewMockProductService(t)
+			interestServiceMock := mocks.NewMockInterestService(t)
+
+			if tt.mock != nil {
+				tt.mock(productServiceMock)
+			}
+
+			if tt.interestMock != nil {
+				tt.interestMock(interestServiceMock)
+			}
+
+			NewProductHandler(productServiceMock, interestServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func productContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "list products",
+			method:         http.MethodGet,
+			path:           "/products",
+			wantStatusCode: http.StatusOK,
+			handler: productContract(func(mps *mocks.MockProductService) {
+				mps.EXPECT().ListProducts(mock.Anything).Return(types.ListProductsResponse{
+					Products: []types.Product{wantProduct},
+				}, nil).Once()
+			}, nil),
+		},
+		{
+			name:   "set product",
+			method: http.MethodPut,
+			path:   "/admin/products/savings",
+			body: `{"name":"Savings account","interestRate":"0.025","dayCount":"30/360",` +
+				`"currencyCodes":["EUR","USD"],"limitTier":"premium","overdraftEligible":true,"maxOverdraftLimit":50000}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: productContract(func(mps *mocks.MockProductService) {
+				mps.EXPECT().SetProduct(mock.Anything, "savings", mock.Anything).
+					Return(types.SetProductResponse{Product: wantProduct}, nil).Once()
+			}, nil),
+		},
+		{
+			name:           "set product rejected by the contract",
+			method:         http.MethodPut,
+			path:           "/admin/products/savings",
+			body:           `{"name":"Savings account","interestRate":"2.5%","dayCount":"30/360"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        productContract(nil, nil),
+		},
+		{
+			name:           "set account product",
+			method:         http.MethodPut,
+			path:           "/admin/accounts/" + wantAccountID.String() + "/product",
+			body:           `{"productCode":"savings"}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: productContract(func(mps *mocks.MockProductService) {
+				mps.EXPECT().SetAccountProduct(mock.Anything, wantAccountID, mock.Anything).
+					Return(types.SetAccountProductResponse{AccountID: wantAccountID, ProductCode: "savings"}, nil).Once()
+			}, nil),
+		},
+		{
+			name:           "list interest accruals",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/interest-accruals",
+			wantStatusCode: http.StatusOK,
+			handler: productContract(nil, func(mis *mocks.MockInterestService) {
+				mis.EXPECT().ListAccruals(mock.Anything, wantAccountID, int32(50), int32(0)).
+					Return(types.ListInterestAccrualsResponse{Accruals: []types.InterestAccrual{{
+						Day:          "2024-05-16",
+						ProductCode:  "savings",
+						Balance:      100000,
+						InterestRate: "0.025",
+						DayCount:     types.DayCount30360,
+						Amount:       "0.0694444444",
+						CreatedAt:    time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+					}}}, nil).Once()
+			}),
+		},
+	}
+}
+
+// productContract returns the product handler of a contract test, its services mocked with expectations.
+func productContract(
+	products func(*mocks.MockProductService), interest func(*mocks.MockInterestService),
+) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewProductHandler(expect(mocks.NewMockProductService(t), products),
+			expect(mocks.NewMockInterestService(t), interest))
+	}
+}
@@ -28,8 +28,16 @@ func NewPropsHandler(conn storage.DBConnection) *PropsHandler {
 
 // Register routes.
 func (h *PropsHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc(routeHealth, h.health)
-	mux.HandleFunc(routeReadiness, h.readiness)
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *PropsHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		routeHealth:    h.health,
+		routeReadiness: h.readiness,
+	}
 }
 
 func (h *PropsHandler) health(w http.ResponseWriter, _ *http.Request) {
@@ -94,3 +94,24 @@ func TestPropsHandler_readiness(t *testing.T) {
 		})
 	}
 }
+
+func propsContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "health",
+			method:         http.MethodGet,
+			path:           "/healthz",
+			wantStatusCode: http.StatusOK,
+			handler:        propsContract(),
+		},
+	}
+}
+
+// propsContract returns the props handler of a contract test.
+func propsContract() func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewPropsHandler(storageMocks.NewMockDBConnection(t))
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listPendingTransfersRoute   = "GET /admin/pending-transfers"
+	approvePendingTransferRoute = "POST /admin/pending-transfers/{id}/approve"
+	rejectPendingTransferRoute  = "POST /admin/pending-transfers/{id}/reject"
+
+	queryStatus = "status"
+)
+
+var (
+	errInvalidPendingTransferStatus = errors.New("status must be pending, approved or rejected")
+
+	pendingTransferStatuses = []string{
+		types.PendingTransferStatusPending, types.PendingTransferStatusApproved, types.PendingTransferStatusRejected,
+	}
+)
+
+type RiskService interface {
+	ListPendingTransfers(
+		ctx context.Context, status string, limit, offset int32) (types.ListPendingTransfersResponse, error)
+	ApprovePendingTransfer(
+		ctx context.Context, pendingTransferID uuid.UUID) (types.ApprovePendingTransferResponse, error)
+	RejectPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (types.RejectPendingTransferResponse, error)
+}
+
+type RiskHandler struct {
+	service RiskService
+}
+
+// NewRiskHandler returns a new RiskHandler.
+func NewRiskHandler(service RiskService) *RiskHandler {
+	return &RiskHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *RiskHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *RiskHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listPendingTransfersRoute:   requireAdmin(h.listPendingTransfers),
+		approvePendingTransferRoute: requireAdmin(h.approvePendingTransfer),
+		rejectPendingTransferRoute:  requireAdmin(h.rejectPendingTransfer),
+	}
+}
+
+func (h *RiskHandler) listPendingTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := r.URL.Query().Get(queryStatus)
+	if status == "" {
+		status = types.PendingTransferStatusPending
+	}
+
+	if !slices.Contains(pendingTransferStatuses, status) {
+		handleError(w, errInvalidPendingTransferStatus, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListPendingTransfers(ctx, status, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *RiskHandler) approvePendingTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pendingTransferID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ApprovePendingTransfer(ctx, pendingTransferID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *RiskHandler) rejectPendingTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	pendingTransferID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.RejectPendingTransfer(ctx, pendingTransferID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func testPendingTransfer() types.PendingTransfer {
+	return types.PendingTransfer{
+		ID:               wantPendingTransferID,
+		AccountID:        wantAccountID,
+		ReciverAccountID: wantReciverAccountID,
+		Amount:           500000,
+		Status:           types.PendingTransferStatusPending,
+		Rules:            []string{"new-receiver-large-amount"},
+		CreatedAt:        time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const wantPendingTransfer = `{"id":"12345678-1234-1234-1234-123456789005",` +
+	`"accountId":"12345678-1234-1234-1234-123456789001","reciverAccountId":"12345678-1234-1234-1234-123456789003",` +
+	`"amount":500000,"status":"pending","rules":["new-receiver-large-amount"],"transactionId":null,` +
+	`"createdAt":"2024-05-17T10:00:00Z"}`
+
+func TestNewRiskHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewRiskHandler(mocks.NewMockRiskService(t))
+	assert.NotNil(t, got)
+}
+
+func TestRiskHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		route             string
+		query             string
+		pendingTransferID string
+		admin             bool
+		mock              func(*mocks.MockRiskService)
+		wantStatusCode    int
+		want              string
+	}{
+		{
+			name:           "list failed when not made by the admin",
+			route:          listPendingTransfersRoute,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "list failed when status is invalid",
+			route:          listPendingTransfersRoute,
+			query:          "?status=held",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"status must be pending, approved or rejected"}
+`,
+		},
+		{
+			name:           "list failed when limit is invalid",
+			route:          listPendingTransfersRoute,
+			query:          "?limit=0",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"limit must be between 1 and 100 and offset must not be negative"}
+`,
+		},
+		{
+			name:  "list success with the pending transfers by default",
+			route: listPendingTransfersRoute,
+			admin: true,
+			mock: func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().ListPendingTransfers(mock.Anything, types.PendingTransferStatusPending, int32(50), int32(0)).
+					Return(types.ListPendingTransfersResponse{
+						PendingTransfers: []types.PendingTransfer{testPendingTransfer()},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           `{"pendingTransfers":[` + wantPendingTransfer + "]}\n",
+		},
+		{
+			name:              "approve failed when id is invalid",
+			route:             approvePendingTransferRoute,
+			pendingTransferID: "one",
+			admin:             true,
+			wantStatusCode:    http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:              "approve failed when the transfer was already decided",
+			route:             approvePendingTransferRoute,
+			pendingTransferID: wantPendingTransferID.String(),
+			admin:             true,
+			mock: func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().ApprovePendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.ApprovePendingTransferResponse{}, types.ErrPendingTransferDecided).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"pending transfer was already approved or rejected","code":"PENDING_TRANSFER_DECIDED"}
+`,
+		},
+		{
+			name:              "approve failed when the balance is no longer sufficient",
+			route:             approvePendingTransferRoute,
+			pendingTransferID: wantPendingTransferID.String(),
+			admin:             true,
+			mock: func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().ApprovePendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.ApprovePendingTransferResponse{}, ErrInsufficientAccountBalance).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"insufficient account balance","code":"INSUFFICIENT_ACCOUNT_BALANCE"}
+`,
+		},
+		{
+			name:              "approve success",
+			route:             approvePendingTransferRoute,
+			pendingTransferID: wantPendingTransferID.String(),
+			admin:             true,
+			mock: func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().ApprovePendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.ApprovePendingTransferResponse{PendingTransfer: testPendingTransfer()}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           wantPendingTransfer + "\n",
+		},
+		{
+			name:              "reject failed when not made by the admin",
+			route:             rejectPendingTransferRoute,
+			pendingTransferID: wantPendingTransferID.String(),
+			wantStatusCode:    http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:              "reject failed when the pending transfer is not found",
+			route:             rejectPendingTransferRoute,
+			pendingTransferID: wantPendingTransferID.String(),
+			admin:             true,
+			mock: func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().RejectPendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.RejectPendingTransferResponse{}, types.ErrPendingTransferNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"pending transfer not found","code":"PENDING_TRANSFER_NOT_FOUND"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/admin/pending-transfers"+tt.query, nil)
+			r.SetPathValue(pathValueID, tt.pendingTransferID)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			riskServiceMock := mocks.NewMockRiskService(t)
+
+			if tt.mock != nil {
+				tt.mock(riskServiceMock)
+			}
+
+			NewRiskHandler(riskServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestHandleError_pendingReview(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	handleError(w, errHeldForReview, http.StatusBadRequest)
+
+	res := w.Result()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"message":"transfer held for review: 12345678-1234-1234-1234-123456789005",`+
+		`"code":"TRANSFER_PENDING_REVIEW","pendingTransferId":"12345678-1234-1234-1234-123456789005"}
+`, string(got))
+}
+
+func riskContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "transfer money held for review",
+			method:         http.MethodPost,
+			path:           "/accounts/" + wantAccountID.String() + "/transactions/transfer",
+			body:           `{"reciverAccountId":"` + wantReciverAccountID.String() + `","amount":100}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, mock.Anything, wantAccountID).
+					Return(types.TransferMoneyResponse{}, errHeldForReview).Once()
+			}),
+		},
+		{
+			name:           "list pending transfers",
+			method:         http.MethodGet,
+			path:           "/admin/pending-transfers?status=pending&limit=10",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: riskContract(func(mrs *mocks.MockRiskService) {
+				mrs.EXPECT().ListPendingTransfers(mock.Anything, types.PendingTransferStatusPending, int32(10), int32(0)).
+					Return(types.ListPendingTransfersResponse{
+						PendingTransfers: []types.PendingTransfer{testPendingTransfer()},
+					}, nil).Once()
+			}),
+		},
+		{
+			name:           "list pending transfers rejected by the contract",
+			method:         http.MethodGet,
+			path:           "/admin/pending-transfers?status=held",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        riskContract(nil),
+		},
+		{
+			name:           "approve pending transfer",
+			method:         http.MethodPost,
+			path:           "/admin/pending-transfers/" + wantPendingTransferID.String() + "/approve",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: riskContract(func(mrs *mocks.MockRiskService) {
+				p := testPendingTransfer()
+				p.Status = types.PendingTransferStatusApproved
+				p.TransactionID = uuid.NullUUID{UUID: wantReciverTransactionID, Valid: true}
+				p.DecidedAt = &p.CreatedAt
+
+				mrs.EXPECT().ApprovePendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.ApprovePendingTransferResponse{PendingTransfer: p}, nil).Once()
+			}),
+		},
+		{
+			name:           "reject pending transfer",
+			method:         http.MethodPost,
+			path:           "/admin/pending-transfers/" + wantPendingTransferID.String() + "/reject",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: riskContract(func(mrs *mocks.MockRiskService) {
+				p := testPendingTransfer()
+				p.Status = types.PendingTransferStatusRejected
+
+				mrs.EXPECT().RejectPendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(types.RejectPendingTransferResponse{PendingTransfer: p}, nil).Once()
+			}),
+		},
+	}
+}
+
+// riskContract returns the risk handler of a contract test, its service mocked with expectations.
+func riskContract(expectations func(*mocks.MockRiskService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewRiskHandler(expect(mocks.NewMockRiskService(t), expectations))
+	}
+}
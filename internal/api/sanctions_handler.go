@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	listSanctionsScreeningsRoute   = "GET /admin/sanctions-screenings"
+	resolveSanctionsScreeningRoute = "POST /admin/sanctions-screenings/{id}/resolve"
+)
+
+var (
+	errInvalidScreeningStatus = errors.New("status must be clear, review, cleared or blocked")
+
+	screeningStatuses = []string{
+		types.ScreeningStatusClear, types.ScreeningStatusReview, types.ScreeningStatusCleared,
+		types.ScreeningStatusBlocked,
+	}
+)
+
+type SanctionsService interface {
+	ListScreenings(
+		ctx context.Context, status string, limit, offset int32) (types.ListSanctionsScreeningsResponse, error)
+	ResolveScreening(
+		ctx context.Context,
+		screeningID uuid.UUID,
+		req *types.ResolveSanctionsScreeningRequest,
+	) (types.ResolveSanctionsScreeningResponse, error)
+}
+
+type SanctionsHandler struct {
+	service SanctionsService
+}
+
+// NewSanctionsHandler returns a new SanctionsHandler.
+func NewSanctionsHandler(service SanctionsService) *SanctionsHandler {
+	return &SanctionsHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *SanctionsHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *SanctionsHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		listSanctionsScreeningsRoute:   requireAdmin(h.listScreenings),
+		resolveSanctionsScreeningRoute: requireAdmin(h.resolveScreening),
+	}
+}
+
+func (h *SanctionsHandler) listScreenings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := r.URL.Query().Get(queryStatus)
+	if status == "" {
+		status = types.ScreeningStatusReview
+	}
+
+	if !slices.Contains(screeningStatuses, status) {
+		handleError(w, errInvalidScreeningStatus, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListScreenings(ctx, status, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *SanctionsHandler) resolveScreening(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &types.ResolveSanctionsScreeningRequest{}
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	screeningID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ResolveScreening(ctx, screeningID, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
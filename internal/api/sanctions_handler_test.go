@@ -0,0 +1,259 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantScreeningID = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+
+func testScreening() types.SanctionsScreening {
+	return types.SanctionsScreening{
+		ID:        wantScreeningID,
+		AccountID: wantAccountID,
+		Name:      "John Doe",
+		Status:    types.ScreeningStatusReview,
+		Matches:   []types.SanctionsMatch{{List: "eu", Reference: "EU-1", Name: "Jon Doe", Score: 0.93}},
+		CreatedAt: time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const wantScreening = `{"id":"12345678-1234-1234-1234-123456789006",` +
+	`"accountId":"12345678-1234-1234-1234-123456789001","name":"John Doe","status":"review",` +
+	`"matches":[{"list":"eu","reference":"EU-1","name":"Jon Doe","score":0.93}],"createdAt":"2024-05-17T10:00:00Z"}`
+
+func TestNewSanctionsHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewSanctionsHandler(mocks.NewMockSanctionsService(t))
+	assert.NotNil(t, got)
+}
+
+func TestSanctionsHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		query          string
+		screeningID    string
+		body           string
+		admin          bool
+		mock           func(*mocks.MockSanctionsService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "list failed when not made by the admin",
+			route:          listSanctionsScreeningsRoute,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "list failed when status is invalid",
+			route:          listSanctionsScreeningsRoute,
+			query:          "?status=pending",
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"status must be clear, review, cleared or blocked"}
+`,
+		},
+		{
+			name:  "list success with the screenings under review by default",
+			route: listSanctionsScreeningsRoute,
+			admin: true,
+			mock: func(mss *mocks.MockSanctionsService) {
+				mss.EXPECT().ListScreenings(mock.Anything, types.ScreeningStatusReview, int32(50), int32(0)).
+					Return(types.ListSanctionsScreeningsResponse{
+						Screenings: []types.SanctionsScreening{testScreening()},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           `{"screenings":[` + wantScreening + "]}\n",
+		},
+		{
+			name:           "resolve failed when not made by the admin",
+			route:          resolveSanctionsScreeningRoute,
+			screeningID:    wantScreeningID.String(),
+			body:           `{"status":"cleared"}`,
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "resolve failed when status is invalid",
+			route:          resolveSanctionsScreeningRoute,
+			screeningID:    wantScreeningID.String(),
+			body:           `{"status":"review"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"status":{"in":"status must be cleared or blocked"}}`,
+		},
+		{
+			name:           "resolve failed when id is invalid",
+			route:          resolveSanctionsScreeningRoute,
+			screeningID:    "one",
+			body:           `{"status":"cleared"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:        "resolve failed when the screening was already resolved",
+			route:       resolveSanctionsScreeningRoute,
+			screeningID: wantScreeningID.String(),
+			body:        `{"status":"blocked"}`,
+			admin:       true,
+			mock: func(mss *mocks.MockSanctionsService) {
+				mss.EXPECT().ResolveScreening(mock.Anything, wantScreeningID, &types.ResolveSanctionsScreeningRequest{
+					Status: types.ScreeningStatusBlocked,
+				}).Return(types.ResolveSanctionsScreeningResponse{}, types.ErrScreeningResolved).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"screening was already resolved","code":"SCREENING_RESOLVED"}
+`,
+		},
+		{
+			name:        "resolve success",
+			route:       resolveSanctionsScreeningRoute,
+			screeningID: wantScreeningID.String(),
+			body:        `{"status":"cleared"}`,
+			admin:       true,
+			mock: func(mss *mocks.MockSanctionsService) {
+				screening := testScreening()
+				screening.Status = types.ScreeningStatusCleared
+
+				mss.EXPECT().ResolveScreening(mock.Anything, wantScreeningID, &types.ResolveSanctionsScreeningRequest{
+					Status: types.ScreeningStatusCleared,
+				}).Return(types.ResolveSanctionsScreeningResponse{SanctionsScreening: screening}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want:           strings.Replace(wantScreening, `"review"`, `"cleared"`, 1) + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/admin/sanctions-screenings"+tt.query, strings.NewReader(tt.body))
+			r.SetPathValue(pathValueID, tt.screeningID)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			sanctionsServiceMock := mocks.NewMockSanctionsService(t)
+
+			if tt.mock != nil {
+				tt.mock(sanctionsServiceMock)
+			}
+
+			NewSanctionsHandler(sanctionsServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func sanctionsContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "create account under review",
+			method:         http.MethodPost,
+			path:           "/accounts",
+			body:           `{"name":"John Doe","email":"john@mail.com","currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusOK,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).Return(types.CreateAccountResponse{
+					Account: types.Account{
+						ID: wantAccountID, Name: "John Doe", Email: "john@mail.com", CurrencyCode: "EUR",
+						ScreeningStatus: types.ScreeningStatusReview,
+					},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "create account matching a sanctions entry",
+			method:         http.MethodPost,
+			path:           "/accounts",
+			body:           `{"name":"John Doe","email":"john@mail.com","currencyCode":"EUR"}`,
+			wantStatusCode: http.StatusBadRequest,
+			handler: accountContract(func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+					Return(types.CreateAccountResponse{}, types.ErrSanctionsMatch).Once()
+			}),
+		},
+		{
+			name:           "list sanctions screenings",
+			method:         http.MethodGet,
+			path:           "/admin/sanctions-screenings?status=review&limit=10",
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: sanctionsContract(func(mss *mocks.MockSanctionsService) {
+				mss.EXPECT().ListScreenings(mock.Anything, types.ScreeningStatusReview, int32(10), int32(0)).
+					Return(types.ListSanctionsScreeningsResponse{
+						Screenings: []types.SanctionsScreening{testScreening()},
+					}, nil).Once()
+			}),
+		},
+		{
+			name:           "resolve sanctions screening",
+			method:         http.MethodPost,
+			path:           "/admin/sanctions-screenings/" + wantScreeningID.String() + "/resolve",
+			body:           `{"status":"blocked"}`,
+			admin:          true,
+			wantStatusCode: http.StatusOK,
+			handler: sanctionsContract(func(mss *mocks.MockSanctionsService) {
+				screening := testScreening()
+				screening.Status = types.ScreeningStatusBlocked
+				screening.ResolvedAt = &screening.CreatedAt
+
+				mss.EXPECT().ResolveScreening(mock.Anything, wantScreeningID, mock.Anything).
+					Return(types.ResolveSanctionsScreeningResponse{SanctionsScreening: screening}, nil).Once()
+			}),
+		},
+		{
+			name:           "resolve sanctions screening rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/admin/sanctions-screenings/" + wantScreeningID.String() + "/resolve",
+			body:           `{"status":"clear"}`,
+			admin:          true,
+			wantStatusCode: http.StatusBadRequest,
+			handler:        sanctionsContract(nil),
+		},
+	}
+}
+
+// sanctionsContract returns the sanctions handler of a contract test, its service mocked with expectations.
+func sanctionsContract(expectations func(*mocks.MockSanctionsService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewSanctionsHandler(expect(mocks.NewMockSanctionsService(t), expectations))
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zaidsasa/xbankapi/internal/statement"
+)
+
+const (
+	statementRoute = "GET /accounts/{id}/statements"
+
+	queryFormat = "format"
+)
+
+var (
+	errInvalidStatementPeriod = errors.New("from and to must be dates formatted as 2006-01-02, from not after to")
+	errUnknownStatementFormat = errors.New("unknown statement format")
+)
+
+type StatementService interface {
+	Write(ctx context.Context, accountID uuid.UUID, from, to time.Time, enc statement.Encoder) error
+}
+
+type StatementHandler struct {
+	service StatementService
+}
+
+// NewStatementHandler returns a new StatementHandler.
+func NewStatementHandler(service StatementService) *StatementHandler {
+	return &StatementHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *StatementHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *StatementHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		statementRoute: h.statement,
+	}
+}
+
+// statement streams the statement of an account. The status code is sent with the first bytes of the statement, so
+// failures past them abort the response rather than report an error, letting clients tell it is incomplete.
+func (h *StatementHandler) statement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	from, to, err := statementPeriod(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	name := r.URL.Query().Get(queryFormat)
+	if name == "" {
+		name = statement.FormatJSON
+	}
+
+	format, ok := statement.Formats[name]
+	if !ok {
+		handleError(w, fmt.Errorf("%w: %q", errUnknownStatementFormat, name), http.StatusBadRequest)
+
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%s-%s-%s.%s",
+		accountID, from.Format(statement.DateLayout), to.Format(statement.DateLayout), format.Extension)
+
+	enc := &httpStatementEncoder{
+		Encoder:  format.NewEncoder(w),
+		w:        w,
+		format:   format,
+		filename: filename,
+	}
+
+	if err := h.service.Write(ctx, accountID, from, to, enc); err != nil {
+		if enc.begun {
+			panic(http.ErrAbortHandler)
+		}
+
+		handleError(w, err, http.StatusBadRequest)
+	}
+}
+
+// statementPeriod parses the from and to query parameters, the first and the last day of a statement.
+func statementPeriod(r *http.Request) (time.Time, time.Time, error) {
+	from, err := time.Parse(statement.DateLayout, r.URL.Query().Get(queryFrom))
+	if err != nil {
+		return time.Time{}, time.Time{}, errInvalidStatementPeriod
+	}
+
+	to, err := time.Parse(statement.DateLayout, r.URL.Query().Get(queryTo))
+	if err != nil || to.Before(from) {
+		return time.Time{}, time.Time{}, errInvalidStatementPeriod
+	}
+
+	return from, to, nil
+}
+
+// httpStatementEncoder sets the headers of the response when the statement begins.
+type httpStatementEncoder struct {
+	statement.Encoder
+	w        http.ResponseWriter
+	format   statement.Format
+	filename string
+	begun    bool
+}
+
+func (e *httpStatementEncoder) Begin(h statement.Header) error {
+	e.begun = true
+
+	e.w.Header().Set("Content-Type", e.format.ContentType)
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename))
+
+	return e.Encoder.Begin(h) //nolint:wrapcheck // wrapped by the service.
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/statement"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestNewStatementHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewStatementHandler(mocks.NewMockStatementService(t))
+	assert.NotNil(t, got)
+}
+
+func TestStatementHandler_statement(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	writeStatement := func(_ context.Context, _ uuid.UUID, _, _ time.Time, enc statement.Encoder) {
+		_ = enc.Begin(statement.Header{
+			Account:        types.Account{ID: wantAccountID, CurrencyCode: "EUR"},
+			From:           from,
+			To:             to,
+			OpeningBalance: 100,
+			ClosingBalance: 100,
+		})
+		_ = enc.End()
+	}
+
+	tests := []struct {
+		name            string
+		accountID       string
+		query           string
+		mock            func(*mocks.MockStatementService)
+		wantStatusCode  int
+		wantContentType string
+		wantFilename    string
+		want            string
+	}{
+		{
+			name:            "failed when account id is invalid",
+			accountID:       "one",
+			query:           "?from=2024-05-01&to=2024-05-31",
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:            "failed when the period is missing",
+			accountID:       wantAccountID.String(),
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"from and to must be dates formatted as 2006-01-02, from not after to"}
+`,
+		},
+		{
+			name:            "failed when the period ends before it starts",
+			accountID:       wantAccountID.String(),
+			query:           "?from=2024-05-31&to=2024-05-01",
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"from and to must be dates formatted as 2006-01-02, from not after to"}
+`,
+		},
+		{
+			name:            "failed when the format is unknown",
+			accountID:       wantAccountID.String(),
+			query:           "?from=2024-05-01&to=2024-05-31&format=pdf",
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"unknown statement format: \"pdf\""}
+`,
+		},
+		{
+			name:      "failed when account not found",
+			accountID: wantAccountID.String(),
+			query:     "?from=2024-05-01&to=2024-05-31",
+			mock: func(mss *mocks.MockStatementService) {
+				mss.EXPECT().Write(mock.Anything, wantAccountID, from, to, mock.Anything).
+					Return(ErrAccountNotFound).Once()
+			},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "success with json by default",
+			accountID: wantAccountID.String(),
+			query:     "?from=2024-05-01&to=2024-05-31",
+			mock: func(mss *mocks.MockStatementService) {
+				mss.EXPECT().Write(mock.Anything, wantAccountID, from, to, mock.Anything).
+					Run(writeStatement).Return(nil).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+			wantFilename:    `attachment; filename="statement-12345678-1234-1234-1234-123456789001-2024-05-01-2024-05-31.json"`,
+			want: `{"accountId":"12345678-1234-1234-1234-123456789001","currencyCode":"EUR","from":"2024-05-01",` +
+				`"to":"2024-05-31","openingBalance":100,"closingBalance":100,"entries":[]}` + "\n",
+		},
+		{
+			name:      "success with csv",
+			accountID: wantAccountID.String(),
+			query:     "?from=2024-05-01&to=2024-05-31&format=csv",
+			mock: func(mss *mocks.MockStatementService) {
+				mss.EXPECT().Write(mock.Anything, wantAccountID, from, to, mock.Anything).
+					Run(writeStatement).Return(nil).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    `attachment; filename="statement-12345678-1234-1234-1234-123456789001-2024-05-01-2024-05-31.csv"`,
+			want: `type,date,transaction_id,source_id,amount,balance,transaction_type
+opening_balance,2024-05-01,,,,€1.00,
+closing_balance,2024-05-31,,,,€1.00,
+`,
+		},
+		{
+			name:      "success with mt940",
+			accountID: wantAccountID.String(),
+			query:     "?from=2024-05-01&to=2024-05-31&format=mt940",
+			mock: func(mss *mocks.MockStatementService) {
+				mss.EXPECT().Write(mock.Anything, wantAccountID, from, to, mock.Anything).
+					Run(writeStatement).Return(nil).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantFilename:    `attachment; filename="statement-12345678-1234-1234-1234-123456789001-2024-05-01-2024-05-31.sta"`,
+			want: ":20:240501-240531\r\n:25:12345678123412341234123456789001\r\n:28C:24152/1\r\n" +
+				":60F:C240501EUR1,00\r\n:62F:C240531EUR1,00\r\n-\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/accounts/"+tt.accountID+"/statements"+tt.query, nil)
+			r.SetPathValue(pathValueID, tt.accountID)
+
+			w := httptest.NewRecorder()
+
+			statementServiceMock := mocks.NewMockStatementService(t)
+
+			if tt.mock != nil {
+				tt.mock(statementServiceMock)
+			}
+
+			NewStatementHandler(statementServiceMock).statement(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+			assert.Equal(t, tt.wantContentType, res.Header.Get("Content-Type"))
+			assert.Equal(t, tt.wantFilename, res.Header.Get("Content-Disposition"))
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestStatementHandler_statement_aborted(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet,
+		"/accounts/"+wantAccountID.String()+"/statements?from=2024-05-01&to=2024-05-31", nil)
+	r.SetPathValue(pathValueID, wantAccountID.String())
+
+	statementServiceMock := mocks.NewMockStatementService(t)
+	statementServiceMock.EXPECT().Write(mock.Anything, wantAccountID, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ uuid.UUID, _, _ time.Time, enc statement.Encoder) error {
+			_ = enc.Begin(statement.Header{})
+
+			return ErrInternal
+		}).Once()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		NewStatementHandler(statementServiceMock).statement(httptest.NewRecorder(), r)
+	})
+}
+
+func statementContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "get statement",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/statements?from=2024-05-01&to=2024-05-31",
+			wantStatusCode: http.StatusOK,
+			handler: statementContract(func(mss *mocks.MockStatementService) {
+				mss.EXPECT().Write(mock.Anything, wantAccountID, mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, _ uuid.UUID, from, to time.Time, enc statement.Encoder) error {
+						_ = enc.Begin(statement.Header{
+							Account: types.Account{ID: wantAccountID, CurrencyCode: "EUR"}, From: from, To: to,
+						})
+						_ = enc.Entry(statement.Entry{Transaction: types.Transaction{ID: wantTrnasactionID}})
+
+						return enc.End()
+					}).Once()
+			}),
+		},
+		{
+			name:           "get statement rejected by the contract",
+			method:         http.MethodGet,
+			path:           "/accounts/" + wantAccountID.String() + "/statements?from=2024-05-01&to=2024-05-31&format=pdf",
+			wantStatusCode: http.StatusBadRequest,
+			handler:        statementContract(nil),
+		},
+	}
+}
+
+// statementContract returns the statement handler of a contract test, its service mocked with expectations.
+func statementContract(expectations func(*mocks.MockStatementService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewStatementHandler(expect(mocks.NewMockStatementService(t), expectations))
+	}
+}
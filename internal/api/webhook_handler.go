@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	createWebhookRoute            = "POST /webhooks"
+	listWebhookDeliveriesRoute    = "GET /webhooks/{id}/deliveries"
+	getWebhookDeliveryRoute       = "GET /webhooks/{id}/deliveries/{deliveryId}"
+	redeliverWebhookDeliveryRoute = "POST /webhooks/{id}/deliveries/{deliveryId}/redeliver"
+
+	pathValueDeliveryID = "deliveryId"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *types.CreateWebhookRequest) (types.CreateWebhookResponse, error)
+	ListWebhookDeliveries(
+		ctx context.Context, webhookID uuid.UUID, limit, offset int32) (types.ListWebhookDeliveriesResponse, error)
+	GetWebhookDelivery(
+		ctx context.Context, webhookID, deliveryID uuid.UUID) (types.GetWebhookDeliveryResponse, error)
+	RedeliverWebhookDelivery(
+		ctx context.Context, webhookID, deliveryID uuid.UUID) (types.RedeliverWebhookDeliveryResponse, error)
+}
+
+type WebhookHandler struct {
+	service WebhookService
+}
+
+// NewWebhookHandler returns a new WebhookHandler.
+func NewWebhookHandler(service WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// Register routes.
+func (h *WebhookHandler) Register(mux *http.ServeMux) {
+	for pattern, handler := range h.routes() {
+		mux.HandleFunc(pattern, handler)
+	}
+}
+
+func (h *WebhookHandler) routes() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		createWebhookRoute:            requireAdmin(h.createWebhook),
+		listWebhookDeliveriesRoute:    requireAdmin(h.listWebhookDeliveries),
+		getWebhookDeliveryRoute:       requireAdmin(h.getWebhookDelivery),
+		redeliverWebhookDeliveryRoute: requireAdmin(h.redeliverWebhookDelivery),
+	}
+}
+
+func (h *WebhookHandler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &types.CreateWebhookRequest{}
+
+	if err := decode(r, req); err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if v := validate.Struct(req); !v.Validate() {
+		handleError(w, v.Errors, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.CreateWebhook(ctx, req)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *WebhookHandler) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.ListWebhookDeliveries(ctx, webhookID, limit, offset)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *WebhookHandler) getWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, deliveryID, err := deliveryPath(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.GetWebhookDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+func (h *WebhookHandler) redeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID, deliveryID, err := deliveryPath(r)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	res, err := h.service.RedeliverWebhookDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	encode(w, res)
+}
+
+// deliveryPath parses the webhook and delivery IDs of the path.
+func deliveryPath(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	webhookID, err := uuid.Parse(r.PathValue(pathValueID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	deliveryID, err := uuid.Parse(r.PathValue(pathValueDeliveryID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return webhookID, deliveryID, nil
+}
@@ -0,0 +1,405 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantWebhookID  = uuid.MustParse("12345678-1234-1234-1234-123456789005")
+	wantDeliveryID = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+)
+
+func TestNewWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	got := NewWebhookHandler(mocks.NewMockWebhookService(t))
+	assert.NotNil(t, got)
+}
+
+func TestWebhookHandler_createWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		body           types.CreateWebhookRequest
+		admin          bool
+		mock           func(*mocks.MockWebhookService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name: "failed when not the admin",
+			body: types.CreateWebhookRequest{
+				URL:    "https://example.com/events",
+				Secret: "0123456789abcdef",
+			},
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:  "failed when url is invalid",
+			admin: true,
+			body: types.CreateWebhookRequest{
+				URL:    "example.com",
+				Secret: "0123456789abcdef",
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"url":{"fullUrl":"url must be a valid full URL address"}}`,
+		},
+		{
+			name:  "failed when an event type is unknown",
+			admin: true,
+			body: types.CreateWebhookRequest{
+				URL:        "https://example.com/events",
+				EventTypes: []string{"MoneyAdded", "MoneyLost"},
+				Secret:     "0123456789abcdef",
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"eventTypes":{"event_types":"eventTypes field did not pass validation"}}`,
+		},
+		{
+			name:  "failed when secret is too short",
+			admin: true,
+			body: types.CreateWebhookRequest{
+				URL:    "https://example.com/events",
+				Secret: "secret",
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want:           `{"secret":{"minLen":"secret min length is 16"}}`,
+		},
+		{
+			name:  "failed when account not found",
+			admin: true,
+			body: types.CreateWebhookRequest{
+				URL:       "https://example.com/events",
+				AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				Secret:    "0123456789abcdef",
+			},
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().CreateWebhook(mock.Anything, mock.Anything).
+					Return(types.CreateWebhookResponse{}, types.ErrAccountNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"account not found","code":"ACCOUNT_NOT_FOUND"}
+`,
+		},
+		{
+			name:  "success when creating a webhook",
+			admin: true,
+			body: types.CreateWebhookRequest{
+				URL:        "https://example.com/events",
+				EventTypes: []string{"MoneyReceived"},
+				AccountID:  uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				Secret:     "0123456789abcdef",
+			},
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().CreateWebhook(mock.Anything, &types.CreateWebhookRequest{
+					URL:        "https://example.com/events",
+					EventTypes: []string{"MoneyReceived"},
+					AccountID:  uuid.NullUUID{UUID: wantAccountID, Valid: true},
+					Secret:     "0123456789abcdef",
+				}).Return(types.CreateWebhookResponse{
+					Webhook: types.Webhook{
+						ID:         wantWebhookID,
+						URL:        "https://example.com/events",
+						EventTypes: []string{"MoneyReceived"},
+						AccountID:  uuid.NullUUID{UUID: wantAccountID, Valid: true},
+						CreatedAt:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"12345678-1234-1234-1234-123456789005","url":"https://example.com/events",` +
+				`"eventTypes":["MoneyReceived"],"accountId":"12345678-1234-1234-1234-123456789001",` +
+				`"createdAt":"2024-05-01T10:00:00Z"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(tt.body)
+			assert.NoError(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			webhookServiceMock := mocks.NewMockWebhookService(t)
+
+			if tt.mock != nil {
+				tt.mock(webhookServiceMock)
+			}
+
+			NewWebhookHandler(webhookServiceMock).routes()[createWebhookRoute](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestWebhookHandler_deliveries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		route          string
+		webhookID      string
+		deliveryID     string
+		query          string
+		admin          bool
+		mock           func(*mocks.MockWebhookService)
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "list failed when not the admin",
+			route:          listWebhookDeliveriesRoute,
+			webhookID:      wantWebhookID.String(),
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "redeliver failed when not the admin",
+			route:          redeliverWebhookDeliveryRoute,
+			webhookID:      wantWebhookID.String(),
+			deliveryID:     wantDeliveryID.String(),
+			wantStatusCode: http.StatusForbidden,
+			want: `{"message":"admin credentials are required","code":"FORBIDDEN"}
+`,
+		},
+		{
+			name:           "list failed when webhook id is invalid",
+			admin:          true,
+			route:          listWebhookDeliveriesRoute,
+			webhookID:      "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:      "list failed when webhook not found",
+			admin:     true,
+			route:     listWebhookDeliveriesRoute,
+			webhookID: wantWebhookID.String(),
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().ListWebhookDeliveries(mock.Anything, wantWebhookID, int32(50), int32(0)).
+					Return(types.ListWebhookDeliveriesResponse{}, types.ErrWebhookNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"webhook not found","code":"WEBHOOK_NOT_FOUND"}
+`,
+		},
+		{
+			name:      "list success with pagination",
+			admin:     true,
+			route:     listWebhookDeliveriesRoute,
+			webhookID: wantWebhookID.String(),
+			query:     "?limit=1&offset=2",
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().ListWebhookDeliveries(mock.Anything, wantWebhookID, int32(1), int32(2)).
+					Return(types.ListWebhookDeliveriesResponse{Deliveries: []types.WebhookDelivery{}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"deliveries":[]}
+`,
+		},
+		{
+			name:           "get failed when delivery id is invalid",
+			admin:          true,
+			route:          getWebhookDeliveryRoute,
+			webhookID:      wantWebhookID.String(),
+			deliveryID:     "one",
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"invalid UUID length: 3"}
+`,
+		},
+		{
+			name:       "get failed when delivery not found",
+			admin:      true,
+			route:      getWebhookDeliveryRoute,
+			webhookID:  wantWebhookID.String(),
+			deliveryID: wantDeliveryID.String(),
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().GetWebhookDelivery(mock.Anything, wantWebhookID, wantDeliveryID).
+					Return(types.GetWebhookDeliveryResponse{}, types.ErrWebhookDeliveryNotFound).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"webhook delivery not found","code":"WEBHOOK_DELIVERY_NOT_FOUND"}
+`,
+		},
+		{
+			name:       "get success",
+			admin:      true,
+			route:      getWebhookDeliveryRoute,
+			webhookID:  wantWebhookID.String(),
+			deliveryID: wantDeliveryID.String(),
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().GetWebhookDelivery(mock.Anything, wantWebhookID, wantDeliveryID).
+					Return(types.GetWebhookDeliveryResponse{
+						WebhookDelivery: types.WebhookDelivery{ID: wantDeliveryID, Status: types.WebhookDeliveryDead},
+						Log: []types.WebhookDeliveryAttempt{{
+							AttemptedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+							StatusCode:  http.StatusServiceUnavailable,
+							Error:       "unexpected status code: 503",
+							DurationMs:  12,
+						}},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"12345678-1234-1234-1234-123456789006","webhookId":"00000000-0000-0000-0000-000000000000",` +
+				`"eventId":"00000000-0000-0000-0000-000000000000","eventType":"","payload":null,"status":"dead",` +
+				`"attempts":0,"nextAttemptAt":"0001-01-01T00:00:00Z","createdAt":"0001-01-01T00:00:00Z",` +
+				`"updatedAt":"0001-01-01T00:00:00Z","log":[{"attemptedAt":"2024-05-01T10:00:00Z","statusCode":503,` +
+				`"error":"unexpected status code: 503","durationMs":12}]}
+`,
+		},
+		{
+			name:       "redeliver success",
+			admin:      true,
+			route:      redeliverWebhookDeliveryRoute,
+			webhookID:  wantWebhookID.String(),
+			deliveryID: wantDeliveryID.String(),
+			mock: func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().RedeliverWebhookDelivery(mock.Anything, wantWebhookID, wantDeliveryID).
+					Return(types.RedeliverWebhookDeliveryResponse{
+						WebhookDelivery: types.WebhookDelivery{ID: wantDeliveryID, Status: types.WebhookDeliveryPending},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			want: `{"id":"12345678-1234-1234-1234-123456789006","webhookId":"00000000-0000-0000-0000-000000000000",` +
+				`"eventId":"00000000-0000-0000-0000-000000000000","eventType":"","payload":null,"status":"pending",` +
+				`"attempts":0,"nextAttemptAt":"0001-01-01T00:00:00Z","createdAt":"0001-01-01T00:00:00Z",` +
+				`"updatedAt":"0001-01-01T00:00:00Z"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/webhooks"+tt.query, nil)
+			r.SetPathValue(pathValueID, tt.webhookID)
+			r.SetPathValue(pathValueDeliveryID, tt.deliveryID)
+
+			if tt.admin {
+				r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Admin: true}))
+			}
+
+			w := httptest.NewRecorder()
+
+			webhookServiceMock := mocks.NewMockWebhookService(t)
+
+			if tt.mock != nil {
+				tt.mock(webhookServiceMock)
+			}
+
+			NewWebhookHandler(webhookServiceMock).routes()[tt.route](w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func webhookContractTests() []contractTest {
+	return []contractTest{
+		{
+			name:           "create webhook",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           `{"url":"https://example.com/events","eventTypes":["MoneyReceived"],"secret":"0123456789abcdef"}`,
+			wantStatusCode: http.StatusOK,
+			admin:          true,
+			handler: webhookContract(func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().CreateWebhook(mock.Anything, mock.Anything).Return(types.CreateWebhookResponse{
+					Webhook: types.Webhook{
+						ID:         wantWebhookID,
+						URL:        "https://example.com/events",
+						EventTypes: []string{"MoneyReceived"},
+						CreatedAt:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					},
+				}, nil).Once()
+			}),
+		},
+		{
+			name:           "create webhook rejected by the contract",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           `{"url":"https://example.com/events","eventTypes":["MoneyLost"],"secret":"0123456789abcdef"}`,
+			wantStatusCode: http.StatusBadRequest,
+			admin:          true,
+			handler:        webhookContract(nil),
+		},
+		{
+			name:           "get webhook delivery",
+			method:         http.MethodGet,
+			path:           "/webhooks/" + wantWebhookID.String() + "/deliveries/" + wantDeliveryID.String(),
+			wantStatusCode: http.StatusOK,
+			admin:          true,
+			handler: webhookContract(func(mws *mocks.MockWebhookService) {
+				mws.EXPECT().GetWebhookDelivery(mock.Anything, wantWebhookID, wantDeliveryID).
+					Return(types.GetWebhookDeliveryResponse{
+						WebhookDelivery: types.WebhookDelivery{
+							ID:        wantDeliveryID,
+							WebhookID: wantWebhookID,
+							EventID:   wantTrnasactionID,
+							EventType: "MoneyReceived",
+							Payload:   []byte(`{"id":"12345678-1234-1234-1234-123456789002"}`),
+							Status:    types.WebhookDeliverySucceeded,
+							Attempts:  1,
+						},
+						Log: []types.WebhookDeliveryAttempt{{StatusCode: http.StatusOK, DurationMs: 3}},
+					}, nil).Once()
+			}),
+		},
+	}
+}
+
+// webhookContract returns the webhook handler of a contract test, its service mocked with expectations.
+func webhookContract(expectations func(*mocks.MockWebhookService)) func(*testing.T) contractHandler {
+	return func(t *testing.T) contractHandler {
+		t.Helper()
+
+		return NewWebhookHandler(expect(mocks.NewMockWebhookService(t), expectations))
+	}
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	HeaderPrincipal = "X-Principal"
+	HeaderRequestID = "X-Request-ID"
+
+	PrincipalAnonymous = "anonymous"
+	PrincipalAdmin     = "admin"
+	// PrincipalSystem is the principal of the operations of the background jobs.
+	PrincipalSystem = "system"
+
+	maxHeaderLength = 255
+)
+
+type (
+	// Actor is who made a request.
+	Actor struct {
+		Principal string
+		RequestID string
+		ClientIP  string
+		// Admin is set when the request is authenticated with the admin token.
+		Admin bool
+	}
+
+	actorCtxKey struct{}
+)
+
+// ContextWithActor returns a context carrying the actor.
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor of ctx, an anonymous one if ctx carries none.
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorCtxKey{}).(Actor); ok {
+		return actor
+	}
+
+	return Actor{Principal: PrincipalAnonymous}
+}
+
+type Identifier struct {
+	adminToken string
+}
+
+// NewIdentifier returns a new Identifier. Requests carrying the admin token as a bearer token are
+// made by the admin, no request is when the token is empty.
+func NewIdentifier(adminToken string) *Identifier {
+	return &Identifier{
+		adminToken: adminToken,
+	}
+}
+
+// Handler identifies the actor of every request. The principal is the admin or the one set in
+// the X-Principal header by the gateway in front of the service, the request ID is the one of the
+// X-Request-ID header or a new one, which is sent back.
+func (i *Identifier) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := Actor{
+			Principal: headerOr(r, HeaderPrincipal, PrincipalAnonymous),
+			RequestID: headerOr(r, HeaderRequestID, uuid.NewString()),
+			ClientIP:  r.RemoteAddr,
+		}
+
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			actor.ClientIP = host
+		}
+
+		if i.isAdmin(r) {
+			actor.Principal = PrincipalAdmin
+			actor.Admin = true
+		}
+
+		w.Header().Set(HeaderRequestID, actor.RequestID)
+
+		next.ServeHTTP(w, r.WithContext(ContextWithActor(r.Context(), actor)))
+	})
+}
+
+// UnaryServerInterceptor identifies the actor of every gRPC call the same way Handler does for HTTP requests,
+// from the x-principal, x-request-id and authorization metadata. The request ID is sent back as header metadata.
+func (i *Identifier) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		actor := Actor{
+			Principal: metadataOr(md, HeaderPrincipal, PrincipalAnonymous),
+			RequestID: metadataOr(md, HeaderRequestID, uuid.NewString()),
+		}
+
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			actor.ClientIP = p.Addr.String()
+
+			if host, _, err := net.SplitHostPort(actor.ClientIP); err == nil {
+				actor.ClientIP = host
+			}
+		}
+
+		if i.isAdminToken(metadataOr(md, "Authorization", "")) {
+			actor.Principal = PrincipalAdmin
+			actor.Admin = true
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderRequestID, actor.RequestID))
+
+		return handler(ContextWithActor(ctx, actor), req)
+	}
+}
+
+func (i *Identifier) isAdmin(r *http.Request) bool {
+	return i.isAdminToken(r.Header.Get("Authorization"))
+}
+
+func (i *Identifier) isAdminToken(authorization string) bool {
+	if i.adminToken == "" {
+		return false
+	}
+
+	want := []byte("Bearer " + i.adminToken)
+
+	return subtle.ConstantTimeCompare([]byte(authorization), want) == 1
+}
+
+func headerOr(r *http.Request, key, fallback string) string {
+	if v := r.Header.Get(key); v != "" && len(v) <= maxHeaderLength {
+		return v
+	}
+
+	return fallback
+}
+
+func metadataOr(md metadata.MD, key, fallback string) string {
+	if values := md.Get(key); len(values) > 0 && values[0] != "" && len(values[0]) <= maxHeaderLength {
+		return values[0]
+	}
+
+	return fallback
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestActorFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Actor{Principal: PrincipalAnonymous}, ActorFromContext(context.Background()))
+
+	actor := Actor{Principal: "alice", RequestID: "request"}
+	assert.Equal(t, actor, ActorFromContext(ContextWithActor(context.Background(), actor)))
+}
+
+func TestIdentifier_Handler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		adminToken string
+		header     http.Header
+		want       Actor
+	}{
+		{
+			name: "anonymous without headers",
+			want: Actor{Principal: PrincipalAnonymous, ClientIP: "192.0.2.1"},
+		},
+		{
+			name:   "principal and request id from headers",
+			header: http.Header{HeaderPrincipal: {"alice"}, HeaderRequestID: {"request"}},
+			want:   Actor{Principal: "alice", RequestID: "request", ClientIP: "192.0.2.1"},
+		},
+		{
+			name:       "admin with the admin token",
+			adminToken: "secret",
+			header:     http.Header{"Authorization": {"Bearer secret"}, HeaderPrincipal: {"alice"}},
+			want:       Actor{Principal: PrincipalAdmin, ClientIP: "192.0.2.1", Admin: true},
+		},
+		{
+			name:       "not admin with another token",
+			adminToken: "secret",
+			header:     http.Header{"Authorization": {"Bearer other"}},
+			want:       Actor{Principal: PrincipalAnonymous, ClientIP: "192.0.2.1"},
+		},
+		{
+			name:   "not admin when the admin token is not set",
+			header: http.Header{"Authorization": {"Bearer "}},
+			want:   Actor{Principal: PrincipalAnonymous, ClientIP: "192.0.2.1"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got Actor
+
+			h := NewIdentifier(tt.adminToken).Handler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = ActorFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+
+			for key, values := range tt.header {
+				r.Header.Set(key, values[0])
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.NotEmpty(t, got.RequestID)
+			assert.Equal(t, got.RequestID, w.Header().Get(HeaderRequestID))
+
+			if tt.want.RequestID == "" {
+				tt.want.RequestID = got.RequestID
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentifier_UnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		adminToken string
+		md         metadata.MD
+		want       Actor
+	}{
+		{
+			name: "anonymous without metadata",
+			want: Actor{Principal: PrincipalAnonymous, ClientIP: "192.0.2.1"},
+		},
+		{
+			name: "principal and request id from metadata",
+			md:   metadata.Pairs(HeaderPrincipal, "alice", HeaderRequestID, "request"),
+			want: Actor{Principal: "alice", RequestID: "request", ClientIP: "192.0.2.1"},
+		},
+		{
+			name:       "admin with the admin token",
+			adminToken: "secret",
+			md:         metadata.Pairs("Authorization", "Bearer secret", HeaderPrincipal, "alice"),
+			want:       Actor{Principal: PrincipalAdmin, ClientIP: "192.0.2.1", Admin: true},
+		},
+		{
+			name:       "not admin with another token",
+			adminToken: "secret",
+			md:         metadata.Pairs("Authorization", "Bearer other"),
+			want:       Actor{Principal: PrincipalAnonymous, ClientIP: "192.0.2.1"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+			})
+			ctx = metadata.NewIncomingContext(ctx, tt.md)
+
+			var got Actor
+
+			_, err := NewIdentifier(tt.adminToken).UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, _ any) (any, error) {
+					got = ActorFromContext(ctx)
+
+					return struct{}{}, nil
+				})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.RequestID)
+
+			if tt.want.RequestID == "" {
+				tt.want.RequestID = got.RequestID
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
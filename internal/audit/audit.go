@@ -0,0 +1,341 @@
+// Package audit keeps an append-only log of the state-changing operations, chained by hash
+// so that tampering with any event is detectable.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	ActionCreateAccount = "account.create"
+	ActionAddMoney      = "account.deposit"
+	ActionTransferMoney = "account.transfer"
+
+	ActionSetAccountLimits = "account.limits.set"
+	ActionSetLimitTier     = "limit_tier.set"
+
+	ActionSetProduct        = "product.set"
+	ActionSetAccountProduct = "account.product.set"
+
+	ActionSetFeeSchedule    = "fee_schedule.set"
+	ActionDeleteFeeSchedule = "fee_schedule.delete"
+	ActionChargeFee         = "fee.charge"
+
+	ActionGrantOverdraft          = "overdraft.grant"
+	ActionRevokeOverdraft         = "overdraft.revoke"
+	ActionChargeOverdraftInterest = "overdraft.interest"
+
+	ActionCapitalizeInterest = "interest.capitalize"
+
+	ActionSetHolder     = "holder.set"
+	ActionRemoveHolder  = "holder.remove"
+	ActionSetMandate    = "mandate.set"
+	ActionDeleteMandate = "mandate.delete"
+
+	ActionApproveTransfer = "transfer_approval.approve"
+	ActionRejectTransfer  = "transfer_approval.reject"
+
+	ActionApprovePendingTransfer = "pending_transfer.approve"
+	ActionRejectPendingTransfer  = "pending_transfer.reject"
+
+	ActionResolveScreening = "sanctions_screening.resolve"
+
+	ActionCreateCustomer = "customer.create"
+	ActionSetKYCStatus   = "customer.kyc_status.set"
+
+	ActionCreateBeneficiary = "beneficiary.create"
+	ActionUpdateBeneficiary = "beneficiary.update"
+	ActionDeleteBeneficiary = "beneficiary.delete"
+
+	ActionCreateWebhook            = "webhook.create"
+	ActionRedeliverWebhookDelivery = "webhook_delivery.redeliver"
+
+	ActionImportPaymentFile   = "payment_file.import"
+	ActionImportSanctionsList = "sanctions_list.import"
+
+	ActionCreatePocket     = "pocket.create"
+	ActionSetPocketGoal    = "pocket.goal.set"
+	ActionDeletePocketGoal = "pocket.goal.delete"
+	ActionMoveToPocket     = "pocket.move_in"
+	ActionMoveFromPocket   = "pocket.move_out"
+
+	OutcomeSuccess = "success"
+
+	verifyBatchSize = 500
+)
+
+type (
+	// Event is a state-changing operation to record.
+	Event struct {
+		Action    string
+		AccountID uuid.NullUUID
+		// Outcome is OutcomeSuccess or, see Outcome, the reason the operation failed.
+		Outcome string
+		// Before and After are snapshots of the target of the action, encoded as JSON.
+		Before any
+		After  any
+	}
+
+	// Filter selects audit events, zero fields select all.
+	Filter struct {
+		AccountID uuid.NullUUID
+		Principal string
+		Action    string
+		From      time.Time
+		To        time.Time
+		Limit     int32
+		Offset    int32
+	}
+
+	Log struct {
+		logger      logger.Logger
+		store       storage.AuditStore
+		storeWithTx func(tx pgx.Tx) storage.AuditStore
+		now         func() time.Time
+	}
+
+	// hashedEvent holds the fields covered by the hash of an event, in a stable order.
+	hashedEvent struct {
+		OccurredAt string          `json:"occurredAt"`
+		Principal  string          `json:"principal"`
+		Action     string          `json:"action"`
+		AccountID  string          `json:"accountId"`
+		RequestID  string          `json:"requestId"`
+		ClientIP   string          `json:"clientIp"`
+		Outcome    string          `json:"outcome"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+	}
+)
+
+// Outcome returns the outcome of an operation which returned err: OutcomeSuccess when err is nil,
+// or "failure: " followed by the error code.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+
+	code := types.ErrorCode(err)
+	if code == "" {
+		code = types.ErrorCodeInternal
+	}
+
+	return "failure: " + code
+}
+
+// New returns a new Log.
+func New(store storage.AuditStore, logger logger.Logger) *Log {
+	return &Log{
+		logger:      logger,
+		store:       store,
+		storeWithTx: storage.AuditStoreWithTx,
+		now:         time.Now,
+	}
+}
+
+// Record appends the event, made by the actor of ctx, to the log within tx, so it is only kept
+// if the operation is committed. Audited transactions are serialized to keep the chain linear: the
+// lock of the chain is held from the first event recorded until tx ends, so that audited
+// transactions commit one at a time across all instances, and their throughput is at most one over
+// the time tx takes from then on. Events are best recorded once the changes of tx are made.
+func (l *Log) Record(ctx context.Context, tx pgx.Tx, event Event) error {
+	store := l.storeWithTx(tx)
+
+	if err := store.LockAuditChain(ctx); err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+
+	prevHash, err := store.GetLastAuditEventHash(ctx)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get last audit event hash: %w", err)
+		}
+
+		prevHash = make([]byte, sha256.Size)
+	}
+
+	before, err := encode(event.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := encode(event.After)
+	if err != nil {
+		return err
+	}
+
+	actor := ActorFromContext(ctx)
+
+	params := storage.AddAuditEventParams{
+		// Postgres keeps microseconds, the hash must cover the time as it is stored.
+		OccurredAt: pgtype.Timestamptz{Time: l.now().UTC().Truncate(time.Microsecond), Valid: true},
+		Principal:  actor.Principal,
+		Action:     event.Action,
+		AccountID:  event.AccountID,
+		RequestID:  actor.RequestID,
+		ClientIp:   actor.ClientIP,
+		Outcome:    event.Outcome,
+		Before:     before,
+		After:      after,
+		PrevHash:   prevHash,
+	}
+
+	params.Hash = hash(prevHash, storage.AuditEvent{
+		OccurredAt: params.OccurredAt,
+		Principal:  params.Principal,
+		Action:     params.Action,
+		AccountID:  params.AccountID,
+		RequestID:  params.RequestID,
+		ClientIp:   params.ClientIp,
+		Outcome:    params.Outcome,
+		Before:     params.Before,
+		After:      params.After,
+	})
+
+	if _, err := store.AddAuditEvent(ctx, params); err != nil {
+		return fmt.Errorf("failed to add audit event: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents lists the audit events selected by the filter, latest first.
+// returns ListAuditEventsResponse.
+func (l *Log) ListAuditEvents(ctx context.Context, filter Filter) (types.ListAuditEventsResponse, error) {
+	events, err := l.store.ListAuditEvents(ctx, storage.ListAuditEventsParams{
+		AccountID: filter.AccountID,
+		Principal: pgtype.Text{String: filter.Principal, Valid: filter.Principal != ""},
+		Action:    pgtype.Text{String: filter.Action, Valid: filter.Action != ""},
+		From:      pgtype.Timestamptz{Time: filter.From, Valid: !filter.From.IsZero()},
+		To:        pgtype.Timestamptz{Time: filter.To, Valid: !filter.To.IsZero()},
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	})
+	if err != nil {
+		l.logger.ErrorContext(ctx, "failed to list audit events", "error", err)
+
+		return types.ListAuditEventsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListAuditEventsResponse{
+		Events: make([]types.AuditEvent, 0, len(events)),
+	}
+
+	for _, e := range events {
+		res.Events = append(res.Events, types.AuditEvent{
+			ID:         e.AuditEventID,
+			OccurredAt: e.OccurredAt.Time,
+			Principal:  e.Principal,
+			Action:     e.Action,
+			AccountID:  e.AccountID,
+			RequestID:  e.RequestID,
+			ClientIP:   e.ClientIp,
+			Outcome:    e.Outcome,
+			Before:     e.Before,
+			After:      e.After,
+			PrevHash:   hex.EncodeToString(e.PrevHash),
+			Hash:       hex.EncodeToString(e.Hash),
+		})
+	}
+
+	return res, nil
+}
+
+// VerifyAuditChain walks the whole log checking every event is linked to the previous one and
+// still matches its hash.
+// returns VerifyAuditChainResponse.
+func (l *Log) VerifyAuditChain(ctx context.Context) (types.VerifyAuditChainResponse, error) {
+	res := types.VerifyAuditChainResponse{Valid: true}
+	prevHash := make([]byte, sha256.Size)
+
+	var lastID int64
+
+	for {
+		events, err := l.store.ListAuditEventsAfter(ctx, storage.ListAuditEventsAfterParams{
+			AuditEventID: lastID,
+			Limit:        verifyBatchSize,
+		})
+		if err != nil {
+			l.logger.ErrorContext(ctx, "failed to list audit events", "error", err)
+
+			return types.VerifyAuditChainResponse{}, types.ErrInternal
+		}
+
+		for _, e := range events {
+			res.Checked++
+
+			if !bytes.Equal(e.PrevHash, prevHash) || !bytes.Equal(e.Hash, hash(e.PrevHash, e)) {
+				brokenAt := e.AuditEventID
+
+				res.Valid = false
+				res.BrokenAt = &brokenAt
+
+				return res, nil
+			}
+
+			prevHash = e.Hash
+			lastID = e.AuditEventID
+		}
+
+		if len(events) < verifyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+// hash returns the hash chaining the event to the previous one.
+func hash(prevHash []byte, e storage.AuditEvent) []byte {
+	h := sha256.New()
+	_, _ = h.Write(prevHash)
+
+	var accountID string
+	if e.AccountID.Valid {
+		accountID = e.AccountID.UUID.String()
+	}
+
+	// Snapshots are stored in json columns, a snapshot which is not valid JSON was tampered with and
+	// matches no hash.
+	err := json.NewEncoder(h).Encode(hashedEvent{
+		OccurredAt: e.OccurredAt.Time.UTC().Format(time.RFC3339Nano),
+		Principal:  e.Principal,
+		Action:     e.Action,
+		AccountID:  accountID,
+		RequestID:  e.RequestID,
+		ClientIP:   e.ClientIp,
+		Outcome:    e.Outcome,
+		Before:     e.Before,
+		After:      e.After,
+	})
+	if err != nil {
+		return nil
+	}
+
+	return h.Sum(nil)
+}
+
+func encode(snapshot any) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+
+	return data, nil
+}
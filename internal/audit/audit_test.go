@@ -0,0 +1,247 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	errAnything   = errors.New("any")
+)
+
+func TestOutcome(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, OutcomeSuccess, Outcome(nil))
+	assert.Equal(t, "failure: ACCOUNT_NOT_FOUND", Outcome(types.ErrAccountNotFound))
+	assert.Equal(t, "failure: INTERNAL", Outcome(errAnything))
+}
+
+// newTestLog returns a Log over the store, recording events at 2024-05-01 10:00.
+func newTestLog(store *storageMocks.MockAuditStore) *Log {
+	l := New(store, slog.Default())
+	l.storeWithTx = func(pgx.Tx) storage.AuditStore { return store }
+	l.now = func() time.Time { return time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC) }
+
+	return l
+}
+
+// recordEvents records n events, returning them as they were stored.
+func recordEvents(t *testing.T, l *Log, store *storageMocks.MockAuditStore, n int) []storage.AuditEvent {
+	t.Helper()
+
+	var events []storage.AuditEvent
+
+	store.EXPECT().LockAuditChain(mock.Anything).Return(nil).Times(n)
+	store.EXPECT().GetLastAuditEventHash(mock.Anything).RunAndReturn(func(context.Context) ([]byte, error) {
+		if len(events) == 0 {
+			return nil, pgx.ErrNoRows
+		}
+
+		return events[len(events)-1].Hash, nil
+	}).Times(n)
+	store.EXPECT().AddAuditEvent(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, p storage.AddAuditEventParams) (storage.AuditEvent, error) {
+			e := storage.AuditEvent{
+				AuditEventID: int64(len(events) + 1),
+				OccurredAt:   p.OccurredAt,
+				Principal:    p.Principal,
+				Action:       p.Action,
+				AccountID:    p.AccountID,
+				RequestID:    p.RequestID,
+				ClientIp:     p.ClientIp,
+				Outcome:      p.Outcome,
+				Before:       p.Before,
+				After:        p.After,
+				PrevHash:     p.PrevHash,
+				Hash:         p.Hash,
+			}
+			events = append(events, e)
+
+			return e, nil
+		}).Times(n)
+
+	ctx := ContextWithActor(context.Background(), Actor{Principal: "alice", RequestID: "request", ClientIP: "192.0.2.1"})
+
+	for i := range n {
+		require.NoError(t, l.Record(ctx, nil, Event{
+			Action:    ActionAddMoney,
+			AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+			Outcome:   OutcomeSuccess,
+			Before:    map[string]int{"balance": i * 100},
+			After:     map[string]int{"balance": (i + 1) * 100},
+		}))
+	}
+
+	return events
+}
+
+func TestLog_Record(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockAuditStore(t)
+	l := newTestLog(store)
+
+	events := recordEvents(t, l, store, 2)
+
+	assert.Equal(t, make([]byte, sha256.Size), events[0].PrevHash)
+	assert.Equal(t, events[0].Hash, events[1].PrevHash)
+	assert.NotEqual(t, events[0].Hash, events[1].Hash)
+	assert.Equal(t, "alice", events[0].Principal)
+	assert.Equal(t, "request", events[0].RequestID)
+	assert.Equal(t, "192.0.2.1", events[0].ClientIp)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 123456000, time.UTC), events[0].OccurredAt.Time)
+	assert.JSONEq(t, `{"balance":0}`, string(events[0].Before))
+	assert.JSONEq(t, `{"balance":100}`, string(events[0].After))
+}
+
+func TestLog_Record_failed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(*storageMocks.MockAuditStore)
+	}{
+		{
+			name: "failed when lock audit chain returns an error",
+			mock: func(store *storageMocks.MockAuditStore) {
+				store.EXPECT().LockAuditChain(mock.Anything).Return(errAnything).Once()
+			},
+		},
+		{
+			name: "failed when get last audit event hash returns an error",
+			mock: func(store *storageMocks.MockAuditStore) {
+				store.EXPECT().LockAuditChain(mock.Anything).Return(nil).Once()
+				store.EXPECT().GetLastAuditEventHash(mock.Anything).Return(nil, errAnything).Once()
+			},
+		},
+		{
+			name: "failed when add audit event returns an error",
+			mock: func(store *storageMocks.MockAuditStore) {
+				store.EXPECT().LockAuditChain(mock.Anything).Return(nil).Once()
+				store.EXPECT().GetLastAuditEventHash(mock.Anything).Return(nil, pgx.ErrNoRows).Once()
+				store.EXPECT().AddAuditEvent(mock.Anything, mock.Anything).
+					Return(storage.AuditEvent{}, errAnything).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockAuditStore(t)
+			tt.mock(store)
+
+			err := newTestLog(store).Record(context.Background(), nil, Event{Action: ActionCreateAccount})
+			assert.ErrorIs(t, err, errAnything)
+		})
+	}
+}
+
+func TestLog_VerifyAuditChain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		tamper func([]storage.AuditEvent)
+		want   types.VerifyAuditChainResponse
+	}{
+		{
+			name: "valid when no event was tampered with",
+			want: types.VerifyAuditChainResponse{Valid: true, Checked: 3},
+		},
+		{
+			name: "broken when a snapshot was tampered with",
+			tamper: func(events []storage.AuditEvent) {
+				events[1].After = []byte(`{"balance":1000000}`)
+			},
+			want: types.VerifyAuditChainResponse{Checked: 2, BrokenAt: ptr(int64(2))},
+		},
+		{
+			name: "broken when an event was removed",
+			tamper: func(events []storage.AuditEvent) {
+				events[1] = events[2]
+			},
+			want: types.VerifyAuditChainResponse{Checked: 2, BrokenAt: ptr(int64(3))},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockAuditStore(t)
+			l := newTestLog(store)
+
+			events := recordEvents(t, l, store, 3)
+			if tt.tamper != nil {
+				tt.tamper(events)
+			}
+
+			store.EXPECT().ListAuditEventsAfter(mock.Anything, storage.ListAuditEventsAfterParams{
+				AuditEventID: 0,
+				Limit:        verifyBatchSize,
+			}).Return(events, nil).Once()
+
+			got, err := l.VerifyAuditChain(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLog_VerifyAuditChain_failed(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockAuditStore(t)
+	store.EXPECT().ListAuditEventsAfter(mock.Anything, mock.Anything).Return(nil, errAnything).Once()
+
+	_, err := newTestLog(store).VerifyAuditChain(context.Background())
+	assert.ErrorIs(t, err, types.ErrInternal)
+}
+
+func TestLog_ListAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockAuditStore(t)
+	l := newTestLog(store)
+
+	events := recordEvents(t, l, store, 1)
+
+	store.EXPECT().ListAuditEvents(mock.Anything, mock.MatchedBy(func(p storage.ListAuditEventsParams) bool {
+		return p.AccountID.Valid && p.Principal.String == "alice" && p.Principal.Valid &&
+			!p.Action.Valid && !p.From.Valid && !p.To.Valid && p.Limit == 10 && p.Offset == 0
+	})).Return(events, nil).Once()
+
+	got, err := l.ListAuditEvents(context.Background(), Filter{
+		AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+		Principal: "alice",
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, got.Events, 1)
+	assert.Equal(t, int64(1), got.Events[0].ID)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", got.Events[0].PrevHash)
+	assert.Len(t, got.Events[0].Hash, 2*sha256.Size)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,395 @@
+// Package beneficiary manages the beneficiaries of accounts, the receivers their customers save to transfer money to
+// without typing their account again. New beneficiaries are in a cooling-off period, during which they can only receive
+// small amounts, so that a beneficiary added by someone who took over an account cannot be sent its balance.
+package beneficiary
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/iban"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	// DefaultCoolingOff is how long new beneficiaries are in their cooling-off period.
+	DefaultCoolingOff = 24 * time.Hour
+	// DefaultCoolingOffLimit is the maximum amount of a transfer to a beneficiary in its cooling-off period, in minor
+	// units of the currency.
+	DefaultCoolingOffLimit money.Amount = 10000
+
+	pqErrorForeignKeyViolation = "23503"
+	pqErrorAlreadyExist        = "23505"
+)
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+// Auditor records the changes of beneficiaries within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn            storage.DBConnection
+	store           storage.BeneficiaryStore
+	storeWithTx     func(tx pgx.Tx) storage.BeneficiaryStore
+	auditor         Auditor
+	holders         Holders
+	logger          logger.Logger
+	coolingOff      time.Duration
+	coolingOffLimit money.Amount
+	now             func() time.Time
+}
+
+// New returns a new Service, whose beneficiaries cannot receive more than coolingOffLimit during coolingOff.
+func New(
+	conn storage.DBConnection,
+	store storage.BeneficiaryStore,
+	auditor Auditor,
+	holders Holders,
+	logger logger.Logger,
+	coolingOff time.Duration,
+	coolingOffLimit money.Amount,
+) *Service {
+	return &Service{
+		conn:            conn,
+		store:           store,
+		storeWithTx:     storage.BeneficiaryStoreWithTx,
+		auditor:         auditor,
+		holders:         holders,
+		logger:          logger,
+		coolingOff:      coolingOff,
+		coolingOffLimit: coolingOffLimit,
+		now:             time.Now,
+	}
+}
+
+// CreateBeneficiary saves a beneficiary of an account, whose receiver account is given by its ID or by its IBAN. The
+// holder making the request must be permitted to transfer from the account.
+// returns CreateBeneficiaryResponse.
+func (s *Service) CreateBeneficiary(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.CreateBeneficiaryRequest,
+) (types.CreateBeneficiaryResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionTransfer); err != nil {
+		return types.CreateBeneficiaryResponse{}, err
+	}
+
+	reciverAccountID, err := s.reciverAccountID(ctx, req)
+	if err != nil {
+		return types.CreateBeneficiaryResponse{}, err
+	}
+
+	if reciverAccountID == accountID {
+		return types.CreateBeneficiaryResponse{}, types.ErrInvalidBeneficiary
+	}
+
+	var res types.Beneficiary
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.BeneficiaryStore) error {
+		beneficiary, err := store.CreateBeneficiary(ctx, storage.CreateBeneficiaryParams{
+			AccountID:        accountID,
+			Nickname:         req.Nickname,
+			ReciverAccountID: reciverAccountID,
+			TransferLimit:    transferLimit(req.TransferLimit),
+			CreatedAt:        pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		})
+		if err != nil {
+			return s.createError(ctx, err)
+		}
+
+		res = s.toBeneficiary(beneficiary)
+		res.ReciverIBAN = iban.Normalize(req.ReciverIBAN)
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionCreateBeneficiary,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			After:     res,
+		})
+	})
+	if err != nil {
+		return types.CreateBeneficiaryResponse{}, err
+	}
+
+	return types.CreateBeneficiaryResponse{Beneficiary: res}, nil
+}
+
+// createError returns the error of a beneficiary that cannot be created.
+func (s *Service) createError(ctx context.Context, err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pqErrorAlreadyExist:
+			return types.ErrBeneficiaryAlreadyExist
+		case pgErr.Code == pqErrorForeignKeyViolation && pgErr.ConstraintName == "beneficiary_account_id_fkey":
+			return types.ErrAccountNotFound
+		case pgErr.Code == pqErrorForeignKeyViolation:
+			return types.ErrRecieverAccountNotFound
+		}
+	}
+
+	s.logger.ErrorContext(ctx, "failed to create beneficiary", "error", err)
+
+	return types.ErrInternal
+}
+
+// ListBeneficiaries lists the beneficiaries of an account by nickname, for the holders permitted to view it.
+// returns ListBeneficiariesResponse.
+func (s *Service) ListBeneficiaries(
+	ctx context.Context,
+	accountID uuid.UUID,
+) (types.ListBeneficiariesResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.ListBeneficiariesResponse{}, err
+	}
+
+	beneficiaries, err := s.store.ListBeneficiaries(ctx, accountID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list beneficiaries", "error", err)
+
+		return types.ListBeneficiariesResponse{}, types.ErrInternal
+	}
+
+	res := types.ListBeneficiariesResponse{
+		Beneficiaries: make([]types.Beneficiary, 0, len(beneficiaries)),
+	}
+
+	for _, b := range beneficiaries {
+		beneficiary := s.toBeneficiary(b.Beneficiary)
+		beneficiary.ReciverIBAN = b.ReciverIBAN.String
+
+		res.Beneficiaries = append(res.Beneficiaries, beneficiary)
+	}
+
+	return res, nil
+}
+
+// GetBeneficiary returns a beneficiary of an account, for the holders permitted to view it.
+// returns GetBeneficiaryResponse.
+func (s *Service) GetBeneficiary(
+	ctx context.Context,
+	accountID, beneficiaryID uuid.UUID,
+) (types.GetBeneficiaryResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.GetBeneficiaryResponse{}, err
+	}
+
+	b, err := s.getBeneficiary(ctx, s.store, accountID, beneficiaryID)
+	if err != nil {
+		return types.GetBeneficiaryResponse{}, err
+	}
+
+	beneficiary := s.toBeneficiary(b.Beneficiary)
+	beneficiary.ReciverIBAN = b.ReciverIBAN.String
+
+	return types.GetBeneficiaryResponse{Beneficiary: beneficiary}, nil
+}
+
+// UpdateBeneficiary updates the nickname and the transfer limit of a beneficiary of an account, its receiver account
+// cannot be changed as it would skip the cooling-off period. The holder making the request must be permitted to
+// transfer from the account.
+// returns UpdateBeneficiaryResponse.
+func (s *Service) UpdateBeneficiary(
+	ctx context.Context,
+	accountID, beneficiaryID uuid.UUID,
+	req *types.UpdateBeneficiaryRequest,
+) (types.UpdateBeneficiaryResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionTransfer); err != nil {
+		return types.UpdateBeneficiaryResponse{}, err
+	}
+
+	var res types.Beneficiary
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.BeneficiaryStore) error {
+		before, err := s.getBeneficiary(ctx, store, accountID, beneficiaryID)
+		if err != nil {
+			return err
+		}
+
+		b, err := store.UpdateBeneficiary(ctx, storage.UpdateBeneficiaryParams{
+			AccountID:     accountID,
+			BeneficiaryID: beneficiaryID,
+			Nickname:      req.Nickname,
+			TransferLimit: transferLimit(req.TransferLimit),
+			UpdatedAt:     pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return types.ErrBeneficiaryNotFound
+			}
+
+			s.logger.ErrorContext(ctx, "failed to update beneficiary", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res = s.toBeneficiary(b)
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionUpdateBeneficiary,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    s.toBeneficiary(before.Beneficiary),
+			After:     res,
+		})
+	})
+	if err != nil {
+		return types.UpdateBeneficiaryResponse{}, err
+	}
+
+	return types.UpdateBeneficiaryResponse{Beneficiary: res}, nil
+}
+
+// DeleteBeneficiary deletes a beneficiary of an account, for the holders permitted to transfer from it.
+func (s *Service) DeleteBeneficiary(ctx context.Context, accountID, beneficiaryID uuid.UUID) error {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionTransfer); err != nil {
+		return err
+	}
+
+	return storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.BeneficiaryStore) error {
+		before, err := s.getBeneficiary(ctx, store, accountID, beneficiaryID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := store.DeleteBeneficiary(ctx, storage.DeleteBeneficiaryParams{
+			AccountID:     accountID,
+			BeneficiaryID: beneficiaryID,
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to delete beneficiary", "error", err)
+
+			return types.ErrInternal
+		}
+
+		if deleted == 0 {
+			return types.ErrBeneficiaryNotFound
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionDeleteBeneficiary,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    s.toBeneficiary(before.Beneficiary),
+		})
+	})
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// Resolve returns the receiver account of a beneficiary of an account, failing when the amount transferred to it
+// exceeds its transfer limit, or the cooling-off limit while it is in its cooling-off period.
+func (s *Service) Resolve(
+	ctx context.Context,
+	accountID, beneficiaryID uuid.UUID,
+	amount money.Amount,
+) (uuid.UUID, error) {
+	b, err := s.getBeneficiary(ctx, s.store, accountID, beneficiaryID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	beneficiary := s.toBeneficiary(b.Beneficiary)
+
+	if beneficiary.TransferLimit > 0 && amount > beneficiary.TransferLimit {
+		return uuid.Nil, types.ErrBeneficiaryLimitExceeded
+	}
+
+	if s.now().Before(beneficiary.CoolingOffEndsAt) && amount > s.coolingOffLimit {
+		return uuid.Nil, types.ErrBeneficiaryCoolingOff
+	}
+
+	return beneficiary.ReciverAccountID, nil
+}
+
+func (s *Service) getBeneficiary(
+	ctx context.Context,
+	store storage.BeneficiaryStore,
+	accountID, beneficiaryID uuid.UUID,
+) (storage.GetBeneficiaryRow, error) {
+	b, err := store.GetBeneficiary(ctx, storage.GetBeneficiaryParams{
+		AccountID:     accountID,
+		BeneficiaryID: beneficiaryID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.GetBeneficiaryRow{}, types.ErrBeneficiaryNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get beneficiary", "error", err)
+
+		return storage.GetBeneficiaryRow{}, types.ErrInternal
+	}
+
+	return b, nil
+}
+
+// reciverAccountID returns the receiver account of a beneficiary to create.
+func (s *Service) reciverAccountID(ctx context.Context, req *types.CreateBeneficiaryRequest) (uuid.UUID, error) {
+	if req.ReciverIBAN == "" {
+		return req.ReciverAccountID, nil
+	}
+
+	if req.ReciverAccountID != uuid.Nil {
+		return uuid.Nil, types.ErrAmbiguousReceiver
+	}
+
+	account, err := s.store.GetAccountByIBAN(ctx, pgtype.Text{String: iban.Normalize(req.ReciverIBAN), Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, types.ErrRecieverAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return uuid.Nil, types.ErrInternal
+	}
+
+	return account.AccountID, nil
+}
+
+func (s *Service) toBeneficiary(b storage.Beneficiary) types.Beneficiary {
+	return types.Beneficiary{
+		ID:               b.BeneficiaryID,
+		AccountID:        b.AccountID,
+		Nickname:         b.Nickname,
+		ReciverAccountID: b.ReciverAccountID,
+		TransferLimit:    storage.AmountFromNumeric(b.TransferLimit, storage.NoCurrency),
+		CoolingOffEndsAt: b.CreatedAt.Time.Add(s.coolingOff),
+		CreatedAt:        b.CreatedAt.Time,
+		UpdatedAt:        b.UpdatedAt.Time,
+	}
+}
+
+// transferLimit returns the transfer limit stored of a beneficiary, null when there is none. It is stored as the limits
+// of the account are, in the minor unit of the account whatever its currency.
+func transferLimit(limit money.Amount) pgtype.Numeric {
+	if limit == 0 {
+		return pgtype.Numeric{}
+	}
+
+	return storage.NumericFromAmount(limit, storage.NoCurrency)
+}
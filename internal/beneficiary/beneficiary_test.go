@@ -0,0 +1,480 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID        = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantReciverAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantBeneficiaryID    = uuid.MustParse("12345678-1234-1234-1234-123456789050")
+	wantNow              = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	errAnything          = errors.New("any")
+)
+
+// newTestService returns a Service whose beneficiaries are in their cooling-off period for a day, during which they
+// cannot receive more than 100.00.
+func newTestService(store storage.BeneficiaryStore) *Service {
+	s := New(nil, store, nil, holdertest.Allow(), slog.Default(), DefaultCoolingOff, DefaultCoolingOffLimit)
+	s.storeWithTx = func(pgx.Tx) storage.BeneficiaryStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+// newTxTestService returns the Service of newTestService whose changes are made within a transaction, which is
+// committed when commit is set, and recorded as action.
+func newTxTestService(t *testing.T, store storage.BeneficiaryStore, commit bool, action string) *Service {
+	t.Helper()
+
+	s := newTestService(store)
+	s.conn = storagetest.NewConn(t, commit)
+	s.auditor = recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+		return nil
+	})
+
+	return s
+}
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func testBeneficiary(createdAt time.Time) storage.Beneficiary {
+	return storage.Beneficiary{
+		BeneficiaryID:    wantBeneficiaryID,
+		AccountID:        wantAccountID,
+		Nickname:         "rent",
+		ReciverAccountID: wantReciverAccountID,
+		TransferLimit:    storage.NumericFromAmount(100000, storage.NoCurrency),
+		CreatedAt:        pgtype.Timestamptz{Time: createdAt, Valid: true},
+		UpdatedAt:        pgtype.Timestamptz{Time: createdAt, Valid: true},
+	}
+}
+
+func TestService_CreateBeneficiary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *types.CreateBeneficiaryRequest
+		mock    func(*storageMocks.MockBeneficiaryStore)
+		inTx    bool
+		want    types.CreateBeneficiaryResponse
+		wantErr error
+	}{
+		{
+			name: "failed when both the receiver account id and iban are set",
+			req: &types.CreateBeneficiaryRequest{
+				Nickname:         "rent",
+				ReciverAccountID: wantReciverAccountID,
+				ReciverIBAN:      "DE89370400440532013000",
+			},
+			wantErr: types.ErrAmbiguousReceiver,
+		},
+		{
+			name: "failed when the receiver iban is not the iban of an account",
+			req:  &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverIBAN: "DE89370400440532013000"},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().GetAccountByIBAN(mock.Anything, mock.Anything).Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrRecieverAccountNotFound,
+		},
+		{
+			name:    "failed when the receiver account is the account",
+			req:     &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverAccountID: wantAccountID},
+			wantErr: types.ErrInvalidBeneficiary,
+		},
+		{
+			name: "failed when the receiver account does not exist",
+			req:  &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverAccountID: wantReciverAccountID},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().CreateBeneficiary(mock.Anything, mock.Anything).Return(storage.Beneficiary{},
+					&pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: "beneficiary_reciver_account_id_fkey"}).
+					Once()
+			},
+			inTx:    true,
+			wantErr: types.ErrRecieverAccountNotFound,
+		},
+		{
+			name: "failed when the account does not exist",
+			req:  &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverAccountID: wantReciverAccountID},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().CreateBeneficiary(mock.Anything, mock.Anything).Return(storage.Beneficiary{},
+					&pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: "beneficiary_account_id_fkey"}).Once()
+			},
+			inTx:    true,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "failed when the receiver account already is a beneficiary",
+			req:  &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverAccountID: wantReciverAccountID},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().CreateBeneficiary(mock.Anything, mock.Anything).
+					Return(storage.Beneficiary{}, &pgconn.PgError{Code: pqErrorAlreadyExist}).Once()
+			},
+			inTx:    true,
+			wantErr: types.ErrBeneficiaryAlreadyExist,
+		},
+		{
+			name: "failed when the store fails",
+			req:  &types.CreateBeneficiaryRequest{Nickname: "rent", ReciverAccountID: wantReciverAccountID},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().CreateBeneficiary(mock.Anything, mock.Anything).Return(storage.Beneficiary{}, errAnything).Once()
+			},
+			inTx:    true,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when the receiver is given by its iban",
+			req: &types.CreateBeneficiaryRequest{
+				Nickname:      "rent",
+				ReciverIBAN:   "de89 3704 0044 0532 0130 00",
+				TransferLimit: 100000,
+			},
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().GetAccountByIBAN(mock.Anything, pgtype.Text{String: "DE89370400440532013000", Valid: true}).
+					Return(storage.Account{AccountID: wantReciverAccountID}, nil).Once()
+				ms.EXPECT().CreateBeneficiary(mock.Anything, storage.CreateBeneficiaryParams{
+					AccountID:        wantAccountID,
+					Nickname:         "rent",
+					ReciverAccountID: wantReciverAccountID,
+					TransferLimit:    storage.NumericFromAmount(100000, storage.NoCurrency),
+					CreatedAt:        pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(testBeneficiary(wantNow), nil).Once()
+			},
+			inTx: true,
+			want: types.CreateBeneficiaryResponse{
+				Beneficiary: types.Beneficiary{
+					ID:               wantBeneficiaryID,
+					AccountID:        wantAccountID,
+					Nickname:         "rent",
+					ReciverAccountID: wantReciverAccountID,
+					ReciverIBAN:      "DE89370400440532013000",
+					TransferLimit:    100000,
+					CoolingOffEndsAt: wantNow.Add(DefaultCoolingOff),
+					CreatedAt:        wantNow,
+					UpdatedAt:        wantNow,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockBeneficiaryStore(t)
+			if tt.mock != nil {
+				tt.mock(store)
+			}
+
+			s := newTestService(store)
+			if tt.inTx {
+				s = newTxTestService(t, store, tt.wantErr == nil, audit.ActionCreateBeneficiary)
+			}
+
+			got, err := s.CreateBeneficiary(context.Background(), wantAccountID, tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListBeneficiaries(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockBeneficiaryStore(t)
+	store.EXPECT().ListBeneficiaries(mock.Anything, wantAccountID).Return([]storage.ListBeneficiariesRow{{
+		Beneficiary: testBeneficiary(wantNow),
+		ReciverIBAN: pgtype.Text{String: "DE89370400440532013000", Valid: true},
+	}}, nil).Once()
+
+	got, err := newTestService(store).ListBeneficiaries(context.Background(), wantAccountID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.ListBeneficiariesResponse{
+		Beneficiaries: []types.Beneficiary{{
+			ID:               wantBeneficiaryID,
+			AccountID:        wantAccountID,
+			Nickname:         "rent",
+			ReciverAccountID: wantReciverAccountID,
+			ReciverIBAN:      "DE89370400440532013000",
+			TransferLimit:    100000,
+			CoolingOffEndsAt: wantNow.Add(DefaultCoolingOff),
+			CreatedAt:        wantNow,
+			UpdatedAt:        wantNow,
+		}},
+	}, got)
+}
+
+func TestService_UpdateBeneficiary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mock    func(*storageMocks.MockBeneficiaryStore)
+		wantErr error
+	}{
+		{
+			name: "failed when beneficiary not found",
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().GetBeneficiary(mock.Anything, mock.Anything).
+					Return(storage.GetBeneficiaryRow{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrBeneficiaryNotFound,
+		},
+		{
+			name: "failed when the beneficiary is deleted meanwhile",
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().GetBeneficiary(mock.Anything, mock.Anything).
+					Return(storage.GetBeneficiaryRow{Beneficiary: testBeneficiary(wantNow)}, nil).Once()
+				ms.EXPECT().UpdateBeneficiary(mock.Anything, mock.Anything).
+					Return(storage.Beneficiary{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrBeneficiaryNotFound,
+		},
+		{
+			name: "success when the transfer limit is removed",
+			mock: func(ms *storageMocks.MockBeneficiaryStore) {
+				ms.EXPECT().GetBeneficiary(mock.Anything, storage.GetBeneficiaryParams{
+					AccountID:     wantAccountID,
+					BeneficiaryID: wantBeneficiaryID,
+				}).Return(storage.GetBeneficiaryRow{Beneficiary: testBeneficiary(wantNow)}, nil).Once()
+				ms.EXPECT().UpdateBeneficiary(mock.Anything, storage.UpdateBeneficiaryParams{
+					AccountID:     wantAccountID,
+					BeneficiaryID: wantBeneficiaryID,
+					Nickname:      "landlord",
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(testBeneficiary(wantNow), nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockBeneficiaryStore(t)
+			tt.mock(store)
+
+			_, err := newTxTestService(t, store, tt.wantErr == nil, audit.ActionUpdateBeneficiary).UpdateBeneficiary(
+				context.Background(), wantAccountID, wantBeneficiaryID, &types.UpdateBeneficiaryRequest{Nickname: "landlord"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_DeleteBeneficiary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		getErr  error
+		deleted int64
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when beneficiary not found",
+			getErr:  pgx.ErrNoRows,
+			wantErr: types.ErrBeneficiaryNotFound,
+		},
+		{
+			name:    "failed when the beneficiary is deleted meanwhile",
+			wantErr: types.ErrBeneficiaryNotFound,
+		},
+		{
+			name:    "failed when the store fails",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "success",
+			deleted: 1,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockBeneficiaryStore(t)
+			store.EXPECT().GetBeneficiary(mock.Anything, storage.GetBeneficiaryParams{
+				AccountID:     wantAccountID,
+				BeneficiaryID: wantBeneficiaryID,
+			}).Return(storage.GetBeneficiaryRow{Beneficiary: testBeneficiary(wantNow)}, tt.getErr).Once()
+
+			if tt.getErr == nil {
+				store.EXPECT().DeleteBeneficiary(mock.Anything, storage.DeleteBeneficiaryParams{
+					AccountID:     wantAccountID,
+					BeneficiaryID: wantBeneficiaryID,
+				}).Return(tt.deleted, tt.err).Once()
+			}
+
+			err := newTxTestService(t, store, tt.wantErr == nil, audit.ActionDeleteBeneficiary).
+				DeleteBeneficiary(context.Background(), wantAccountID, wantBeneficiaryID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_notPermitted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		permission holder.Permission
+		call       func(s *Service) error
+	}{
+		{
+			name:       "create",
+			permission: holder.PermissionTransfer,
+			call: func(s *Service) error {
+				_, err := s.CreateBeneficiary(ctx, wantAccountID, &types.CreateBeneficiaryRequest{})
+
+				return err
+			},
+		},
+		{
+			name:       "list",
+			permission: holder.PermissionView,
+			call: func(s *Service) error {
+				_, err := s.ListBeneficiaries(ctx, wantAccountID)
+
+				return err
+			},
+		},
+		{
+			name:       "get",
+			permission: holder.PermissionView,
+			call: func(s *Service) error {
+				_, err := s.GetBeneficiary(ctx, wantAccountID, wantBeneficiaryID)
+
+				return err
+			},
+		},
+		{
+			name:       "update",
+			permission: holder.PermissionTransfer,
+			call: func(s *Service) error {
+				_, err := s.UpdateBeneficiary(ctx, wantAccountID, wantBeneficiaryID, &types.UpdateBeneficiaryRequest{})
+
+				return err
+			},
+		},
+		{
+			name:       "delete",
+			permission: holder.PermissionTransfer,
+			call: func(s *Service) error {
+				return s.DeleteBeneficiary(ctx, wantAccountID, wantBeneficiaryID)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newTestService(storageMocks.NewMockBeneficiaryStore(t))
+			s.holders = holdertest.Authorize(t, wantAccountID, tt.permission, types.ErrNotPermitted)
+
+			assert.ErrorIs(t, tt.call(s), types.ErrNotPermitted)
+		})
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		amount    money.Amount
+		err       error
+		want      uuid.UUID
+		wantErr   error
+	}{
+		{
+			name:    "failed when beneficiary not found",
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrBeneficiaryNotFound,
+		},
+		{
+			name:      "failed when the amount exceeds the transfer limit",
+			createdAt: wantNow.Add(-2 * DefaultCoolingOff),
+			amount:    100001,
+			wantErr:   types.ErrBeneficiaryLimitExceeded,
+		},
+		{
+			name:      "failed when the amount exceeds the cooling-off limit",
+			createdAt: wantNow.Add(-time.Hour),
+			amount:    DefaultCoolingOffLimit + 1,
+			wantErr:   types.ErrBeneficiaryCoolingOff,
+		},
+		{
+			name:      "success when the amount is within the cooling-off limit",
+			createdAt: wantNow.Add(-time.Hour),
+			amount:    DefaultCoolingOffLimit,
+			want:      wantReciverAccountID,
+		},
+		{
+			name:      "success when the cooling-off period is over",
+			createdAt: wantNow.Add(-DefaultCoolingOff),
+			amount:    100000,
+			want:      wantReciverAccountID,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockBeneficiaryStore(t)
+			store.EXPECT().GetBeneficiary(mock.Anything, storage.GetBeneficiaryParams{
+				AccountID:     wantAccountID,
+				BeneficiaryID: wantBeneficiaryID,
+			}).Return(storage.GetBeneficiaryRow{Beneficiary: testBeneficiary(tt.createdAt)}, tt.err).Once()
+
+			got, err := newTestService(store).Resolve(context.Background(), wantAccountID, wantBeneficiaryID, tt.amount)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
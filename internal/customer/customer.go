@@ -0,0 +1,270 @@
+// Package customer manages the customers accounts are opened for: their profile, contact details and the status of
+// their KYC checks. A customer has many accounts, opened through the account service with the email of the customer,
+// unless the KYC checks of the customer were rejected. Only the customer and the admin may act for a customer.
+package customer
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/accountview"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const pqErrorAlreadyExist = "23505"
+
+// AccountService opens the accounts of customers.
+type AccountService interface {
+	CreateCustomerAccount(
+		ctx context.Context, customerID uuid.UUID, req *types.CreateAccountRequest,
+	) (types.CreateAccountResponse, error)
+}
+
+// Holders authorizes the actors of requests made for a customer, failing with types.ErrNotPermitted.
+type Holders interface {
+	AuthorizeCustomer(ctx context.Context, customerID uuid.UUID) error
+}
+
+// Auditor records the customers created and the changes of their KYC status within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.CustomerStore
+	storeWithTx func(tx pgx.Tx) storage.CustomerStore
+	auditor     Auditor
+	accounts    AccountService
+	holders     Holders
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(
+	conn storage.DBConnection,
+	store storage.CustomerStore,
+	auditor Auditor,
+	accounts AccountService,
+	holders Holders,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.CustomerStoreWithTx,
+		auditor:     auditor,
+		accounts:    accounts,
+		holders:     holders,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// CreateCustomer creates a customer, whose KYC checks are pending.
+// returns CreateCustomerResponse.
+func (s *Service) CreateCustomer(
+	ctx context.Context,
+	req *types.CreateCustomerRequest,
+) (types.CreateCustomerResponse, error) {
+	var res types.CreateCustomerResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.CustomerStore) error {
+		c, err := store.CreateCustomer(ctx, storage.CreateCustomerParams{
+			Name:  req.Name,
+			Email: req.Email,
+			Phone: pgtype.Text{String: req.Phone, Valid: req.Phone != ""},
+		})
+		if err != nil {
+			pgErr := &pgconn.PgError{}
+			if errors.As(err, &pgErr) && pgErr.Code == pqErrorAlreadyExist {
+				return types.ErrCustomerAlreadyExist
+			}
+
+			s.logger.ErrorContext(ctx, "failed to create customer", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res.Customer = toCustomer(c)
+
+		if err := s.auditor.Record(ctx, tx, audit.Event{
+			Action:  audit.ActionCreateCustomer,
+			Outcome: audit.OutcomeSuccess,
+			After:   res.Customer,
+		}); err != nil {
+			s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+			return types.ErrInternal
+		}
+
+		return nil
+	})
+	if err != nil {
+		return types.CreateCustomerResponse{}, err
+	}
+
+	return res, nil
+}
+
+// CreateAccount opens an account for a customer, named after the customer unless the request names it, failing with
+// types.ErrKYCRejected when the KYC checks of the customer were rejected.
+// returns CreateAccountResponse.
+func (s *Service) CreateAccount(
+	ctx context.Context,
+	customerID uuid.UUID,
+	req *types.CreateCustomerAccountRequest,
+) (types.CreateAccountResponse, error) {
+	c, err := s.getAuthorizedCustomer(ctx, customerID)
+	if err != nil {
+		return types.CreateAccountResponse{}, err
+	}
+
+	if c.KYCStatus == types.KYCStatusRejected {
+		return types.CreateAccountResponse{}, types.ErrKYCRejected
+	}
+
+	return s.accounts.CreateCustomerAccount(ctx, c.CustomerID, &types.CreateAccountRequest{
+		Name:         cmp.Or(req.Name, c.Name),
+		Email:        c.Email,
+		CurrencyCode: req.CurrencyCode,
+		ProductCode:  req.ProductCode,
+	})
+}
+
+// ListAccounts lists the accounts a customer holds with their balances, by account number: the accounts opened for it
+// and the ones it is a co-holder of, in any role. The pockets of an account are listed with it, as by the account
+// service, and not as accounts of their own.
+// returns ListCustomerAccountsResponse.
+func (s *Service) ListAccounts(ctx context.Context, customerID uuid.UUID) (types.ListCustomerAccountsResponse, error) {
+	if _, err := s.getAuthorizedCustomer(ctx, customerID); err != nil {
+		return types.ListCustomerAccountsResponse{}, err
+	}
+
+	accounts, err := s.store.ListCustomerAccounts(ctx, customerID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list customer accounts", "error", err)
+
+		return types.ListCustomerAccountsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListCustomerAccountsResponse{
+		Accounts: make([]types.GetAccountResponse, 0, len(accounts)),
+	}
+
+	pockets := make(map[uuid.UUID][]types.Pocket)
+
+	for _, a := range accounts {
+		if parentID := a.Account.ParentAccountID; parentID.Valid {
+			pockets[parentID.UUID] = append(pockets[parentID.UUID], accountview.Pocket(a.Account, a.Balance))
+		}
+	}
+
+	for _, a := range accounts {
+		if !a.Account.ParentAccountID.Valid {
+			res.Accounts = append(res.Accounts,
+				accountview.WithPockets(accountview.Response(a.Account, a.Balance), pockets[a.Account.AccountID]))
+		}
+	}
+
+	return res, nil
+}
+
+// SetKYCStatus sets the status of the KYC checks of a customer within a transaction, recorded in the audit log.
+// returns SetKYCStatusResponse.
+func (s *Service) SetKYCStatus(
+	ctx context.Context,
+	customerID uuid.UUID,
+	req *types.SetKYCStatusRequest,
+) (types.SetKYCStatusResponse, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return types.SetKYCStatusResponse{}, types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	c, err := s.storeWithTx(tx).SetCustomerKYCStatus(ctx, storage.SetCustomerKYCStatusParams{
+		CustomerID: customerID,
+		KYCStatus:  req.Status,
+		UpdatedAt:  pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.SetKYCStatusResponse{}, types.ErrCustomerNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to set customer kyc status", "error", err)
+
+		return types.SetKYCStatusResponse{}, types.ErrInternal
+	}
+
+	res := types.SetKYCStatusResponse{Customer: toCustomer(c)}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:  audit.ActionSetKYCStatus,
+		Outcome: audit.OutcomeSuccess,
+		After:   res.Customer,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.SetKYCStatusResponse{}, types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return types.SetKYCStatusResponse{}, types.ErrInternal
+	}
+
+	return res, nil
+}
+
+// getAuthorizedCustomer gets a customer, failing with types.ErrNotPermitted when the actor making the request may not
+// act for it.
+func (s *Service) getAuthorizedCustomer(ctx context.Context, customerID uuid.UUID) (storage.Customer, error) {
+	if err := s.holders.AuthorizeCustomer(ctx, customerID); err != nil {
+		return storage.Customer{}, err
+	}
+
+	return s.getCustomer(ctx, customerID)
+}
+
+func (s *Service) getCustomer(ctx context.Context, customerID uuid.UUID) (storage.Customer, error) {
+	c, err := s.store.GetCustomer(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.Customer{}, types.ErrCustomerNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch customer", "error", err)
+
+		return storage.Customer{}, types.ErrInternal
+	}
+
+	return c, nil
+}
+
+func toCustomer(c storage.Customer) types.Customer {
+	return types.Customer{
+		ID:        c.CustomerID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Phone:     c.Phone.String,
+		KYCStatus: c.KYCStatus,
+		CreatedAt: c.CreatedAt.Time,
+		UpdatedAt: c.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,425 @@
+package customer
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantCustomerID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantAccountID  = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantPocketID   = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantNow        = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	errAnything    = errors.New("any")
+
+	testCustomer = storage.Customer{
+		CustomerID: wantCustomerID,
+		Name:       "John Doe",
+		Email:      "john@example.com",
+		Phone:      pgtype.Text{String: "+4930123456", Valid: true},
+		KYCStatus:  types.KYCStatusPending,
+		CreatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}
+	wantCustomer = types.Customer{
+		ID:        wantCustomerID,
+		Name:      "John Doe",
+		Email:     "john@example.com",
+		Phone:     "+4930123456",
+		KYCStatus: types.KYCStatusPending,
+		CreatedAt: wantNow,
+		UpdatedAt: wantNow,
+	}
+)
+
+// createAccountFunc is an AccountService made of a function.
+type createAccountFunc func(
+	ctx context.Context, customerID uuid.UUID, req *types.CreateAccountRequest,
+) (types.CreateAccountResponse, error)
+
+func (f createAccountFunc) CreateCustomerAccount(
+	ctx context.Context, customerID uuid.UUID, req *types.CreateAccountRequest,
+) (types.CreateAccountResponse, error) {
+	return f(ctx, customerID, req)
+}
+
+// noAccount fails the test when an account is created.
+func noAccount(t *testing.T) createAccountFunc {
+	t.Helper()
+
+	return func(context.Context, uuid.UUID, *types.CreateAccountRequest) (types.CreateAccountResponse, error) {
+		t.Error("unexpected account")
+
+		return types.CreateAccountResponse{}, nil
+	}
+}
+
+// authorizeFunc is a Holders made of a function.
+type authorizeFunc func(ctx context.Context, customerID uuid.UUID) error
+
+func (f authorizeFunc) AuthorizeCustomer(ctx context.Context, customerID uuid.UUID) error {
+	return f(ctx, customerID)
+}
+
+// authorizeWith returns Holders failing with err, permitting the requests when it is nil.
+func authorizeWith(err error) authorizeFunc {
+	return func(context.Context, uuid.UUID) error { return err }
+}
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func newTestService(store storage.CustomerStore, accounts AccountService, holders Holders) *Service {
+	s := New(nil, store, nil, accounts, holders, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.CustomerStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func TestService_CreateCustomer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.CreateCustomerResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the customer cannot be created",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when the email is taken",
+			err:     &pgconn.PgError{Code: pqErrorAlreadyExist},
+			wantErr: types.ErrCustomerAlreadyExist,
+		},
+		{
+			name: "success",
+			want: types.CreateCustomerResponse{Customer: wantCustomer},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockCustomerStore(t)
+			store.EXPECT().CreateCustomer(mock.Anything, storage.CreateCustomerParams{
+				Name:  "John Doe",
+				Email: "john@example.com",
+				Phone: pgtype.Text{String: "+4930123456", Valid: true},
+			}).Return(testCustomer, tt.err).Once()
+
+			s := newTestService(store, noAccount(t), authorizeWith(nil))
+			s.conn = storagetest.NewConn(t, tt.wantErr == nil)
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionCreateCustomer, event.Action)
+				assert.Equal(t, wantCustomer, event.After)
+
+				return nil
+			})
+
+			got, err := s.CreateCustomer(context.Background(),
+				&types.CreateCustomerRequest{Name: "John Doe", Email: "john@example.com", Phone: "+4930123456"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_CreateAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		req         types.CreateCustomerAccountRequest
+		authErr     error
+		kycStatus   string
+		customerErr error
+		wantReq     *types.CreateAccountRequest
+		accountErr  error
+		want        types.CreateAccountResponse
+		wantErr     error
+	}{
+		{
+			name:    "failed when not permitted",
+			req:     types.CreateCustomerAccountRequest{CurrencyCode: "EUR"},
+			authErr: types.ErrNotPermitted,
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name:        "failed when customer not found",
+			req:         types.CreateCustomerAccountRequest{CurrencyCode: "EUR"},
+			customerErr: pgx.ErrNoRows,
+			wantErr:     types.ErrCustomerNotFound,
+		},
+		{
+			name:        "failed when the customer cannot be fetched",
+			req:         types.CreateCustomerAccountRequest{CurrencyCode: "EUR"},
+			customerErr: errAnything,
+			wantErr:     types.ErrInternal,
+		},
+		{
+			name:      "failed when the KYC checks of the customer were rejected",
+			req:       types.CreateCustomerAccountRequest{CurrencyCode: "EUR"},
+			kycStatus: types.KYCStatusRejected,
+			wantErr:   types.ErrKYCRejected,
+		},
+		{
+			name: "failed when the account cannot be created",
+			req:  types.CreateCustomerAccountRequest{CurrencyCode: "USD"},
+			wantReq: &types.CreateAccountRequest{
+				Name: "John Doe", Email: "john@example.com", CurrencyCode: "USD",
+			},
+			accountErr: types.ErrCurrencyNotAllowed,
+			wantErr:    types.ErrCurrencyNotAllowed,
+		},
+		{
+			name: "success named after the customer",
+			req:  types.CreateCustomerAccountRequest{CurrencyCode: "EUR"},
+			wantReq: &types.CreateAccountRequest{
+				Name: "John Doe", Email: "john@example.com", CurrencyCode: "EUR",
+			},
+			want: types.CreateAccountResponse{Account: types.Account{ID: wantAccountID}},
+		},
+		{
+			name: "success with a name and product",
+			req:  types.CreateCustomerAccountRequest{Name: "Holidays", CurrencyCode: "EUR", ProductCode: "savings"},
+			wantReq: &types.CreateAccountRequest{
+				Name: "Holidays", Email: "john@example.com", CurrencyCode: "EUR", ProductCode: "savings",
+			},
+			want: types.CreateAccountResponse{Account: types.Account{ID: wantAccountID}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testCustomer
+			c.KYCStatus = cmp.Or(tt.kycStatus, c.KYCStatus)
+
+			store := storageMocks.NewMockCustomerStore(t)
+			if tt.authErr == nil {
+				store.EXPECT().GetCustomer(mock.Anything, wantCustomerID).Return(c, tt.customerErr).Once()
+			}
+
+			accounts := noAccount(t)
+			if tt.wantReq != nil {
+				accounts = func(
+					_ context.Context, customerID uuid.UUID, req *types.CreateAccountRequest,
+				) (types.CreateAccountResponse, error) {
+					assert.Equal(t, wantCustomerID, customerID)
+					assert.Equal(t, tt.wantReq, req)
+
+					return tt.want, tt.accountErr
+				}
+			}
+
+			got, err := newTestService(store, accounts, authorizeWith(tt.authErr)).
+				CreateAccount(context.Background(), wantCustomerID, &tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListAccounts(t *testing.T) {
+	t.Parallel()
+
+	account := storage.Account{
+		AccountID:       wantAccountID,
+		Email:           "john@example.com",
+		Name:            "John Doe",
+		CurrencyCode:    "EUR",
+		IBAN:            pgtype.Text{String: "DE89370400440532013000", Valid: true},
+		ProductCode:     "current",
+		ScreeningStatus: types.ScreeningStatusClear,
+		OverdraftLimit:  storage.NumericFromAmount(5000, "EUR"),
+	}
+
+	pocket := storage.Account{
+		AccountID:       wantPocketID,
+		Email:           "john@example.com",
+		Name:            "Holiday",
+		CurrencyCode:    "EUR",
+		ProductCode:     "current",
+		ScreeningStatus: types.ScreeningStatusClear,
+		ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+		GoalAmount:      storage.NumericFromAmount(50000, "EUR"),
+	}
+	totalBalance := money.Amount(1500)
+
+	tests := []struct {
+		name        string
+		authErr     error
+		customerErr error
+		err         error
+		want        types.ListCustomerAccountsResponse
+		wantErr     error
+	}{
+		{
+			name:    "failed when not permitted",
+			authErr: types.ErrNotPermitted,
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name:        "failed when customer not found",
+			customerErr: pgx.ErrNoRows,
+			wantErr:     types.ErrCustomerNotFound,
+		},
+		{
+			name:    "failed when the accounts cannot be listed",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			want: types.ListCustomerAccountsResponse{Accounts: []types.GetAccountResponse{{
+				Account: types.Account{
+					ID:              wantAccountID,
+					Name:            "John Doe",
+					Email:           "john@example.com",
+					CurrencyCode:    "EUR",
+					IBAN:            "DE89370400440532013000",
+					ProductCode:     "current",
+					ScreeningStatus: types.ScreeningStatusClear,
+				},
+				Balance:          -1000,
+				AvailableBalance: 4000,
+				OverdraftLimit:   5000,
+				Pockets: []types.Pocket{{
+					ID:              wantPocketID,
+					ParentAccountID: wantAccountID,
+					Name:            "Holiday",
+					CurrencyCode:    "EUR",
+					Balance:         2500,
+					GoalAmount:      50000,
+				}},
+				TotalBalance: &totalBalance,
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockCustomerStore(t)
+			if tt.authErr == nil {
+				store.EXPECT().GetCustomer(mock.Anything, wantCustomerID).Return(testCustomer, tt.customerErr).Once()
+			}
+
+			if tt.authErr == nil && tt.customerErr == nil {
+				store.EXPECT().ListCustomerAccounts(mock.Anything, wantCustomerID).Return(
+					[]storage.ListCustomerAccountsRow{
+						{Account: account, Balance: storage.NumericFromAmount(-1000, "EUR")},
+						{Account: pocket, Balance: storage.NumericFromAmount(2500, "EUR")},
+					}, tt.err,
+				).Once()
+			}
+
+			got, err := newTestService(store, noAccount(t), authorizeWith(tt.authErr)).
+				ListAccounts(context.Background(), wantCustomerID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_SetKYCStatus(t *testing.T) {
+	t.Parallel()
+
+	verified := testCustomer
+	verified.KYCStatus = types.KYCStatusVerified
+
+	wantVerified := wantCustomer
+	wantVerified.KYCStatus = types.KYCStatusVerified
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.SetKYCStatusResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when customer not found",
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrCustomerNotFound,
+		},
+		{
+			name:    "failed when the status cannot be set",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			want: types.SetKYCStatusResponse{Customer: wantVerified},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockCustomerStore(t)
+			store.EXPECT().SetCustomerKYCStatus(mock.Anything, storage.SetCustomerKYCStatusParams{
+				CustomerID: wantCustomerID,
+				KYCStatus:  types.KYCStatusVerified,
+				UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+			}).Return(verified, tt.err).Once()
+
+			s := newTestService(store, noAccount(t), authorizeWith(nil))
+			s.conn = storagetest.NewConn(t, tt.wantErr == nil)
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionSetKYCStatus, event.Action)
+				assert.Equal(t, wantVerified, event.After)
+
+				return nil
+			})
+
+			got, err := s.SetKYCStatus(
+				context.Background(), wantCustomerID, &types.SetKYCStatusRequest{Status: types.KYCStatusVerified})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
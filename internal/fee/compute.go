@@ -0,0 +1,81 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// compute returns the fee of an amount by a schedule, percentages being rounded half up to the minor unit. Negative
+// amounts, such as the balances of overdrawn accounts, are charged as 0.
+func compute(schedule storage.FeeSchedule, amount money.Amount) (money.Amount, error) {
+	amount = max(amount, 0)
+
+	switch schedule.Kind {
+	case types.FeeKindPercentage:
+		fee := new(big.Rat).Mul(storage.RatFromNumeric(schedule.Rate), new(big.Rat).SetInt64(amount))
+		charged := storage.NumericFromRat(fee, 0).Int.Int64()
+
+		charged = max(charged, storage.AmountFromNumeric(schedule.MinAmount, storage.NoCurrency))
+		if maxAmount := storage.AmountFromNumeric(schedule.MaxAmount, storage.NoCurrency); maxAmount > 0 {
+			charged = min(charged, maxAmount)
+		}
+
+		return charged, nil
+	case types.FeeKindTiered:
+		var tiers []types.FeeTier
+		if err := json.Unmarshal(schedule.Tiers, &tiers); err != nil {
+			return 0, fmt.Errorf("failed to decode fee tiers: %w", err)
+		}
+
+		var charged money.Amount
+
+		for _, tier := range tiers {
+			if amount < tier.From {
+				break
+			}
+
+			charged = tier.Amount
+		}
+
+		return charged, nil
+	default:
+		return storage.AmountFromNumeric(schedule.Amount, storage.NoCurrency), nil
+	}
+}
+
+// validate fails with types.ErrInvalidFeeSchedule unless the schedule has what its kind needs: a rate and a maximum
+// not less than the minimum for percentage fees, and tiers from 0 by increasing amounts for tiered fees.
+func validate(req *types.SetFeeScheduleRequest) error {
+	switch req.Kind {
+	case types.FeeKindPercentage:
+		if req.Rate == "" || (req.MaxAmount > 0 && req.MaxAmount < req.MinAmount) {
+			return types.ErrInvalidFeeSchedule
+		}
+	case types.FeeKindTiered:
+		if !validTiers(req.Tiers) {
+			return types.ErrInvalidFeeSchedule
+		}
+	}
+
+	return nil
+}
+
+// validTiers reports whether tiers start from 0 and go by increasing amounts, with no negative fee.
+func validTiers(tiers []types.FeeTier) bool {
+	if len(tiers) == 0 || tiers[0].From != 0 {
+		return false
+	}
+
+	for i, tier := range tiers {
+		if tier.Amount < 0 || (i > 0 && tier.From <= tiers[i-1].From) {
+			return false
+		}
+	}
+
+	return true
+}
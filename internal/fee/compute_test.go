@@ -0,0 +1,190 @@
+package fee
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/stretchr/testify/assert"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	percentage := storage.FeeSchedule{
+		Kind:      types.FeeKindPercentage,
+		Rate:      storage.NumericFromRat(big.NewRat(5, 1000), 3),
+		MinAmount: storage.NumericFromAmount(50, storage.NoCurrency),
+		MaxAmount: storage.NumericFromAmount(1000, storage.NoCurrency),
+	}
+
+	tiered := storage.FeeSchedule{
+		Kind:  types.FeeKindTiered,
+		Tiers: []byte(`[{"from":0,"amount":0},{"from":10000,"amount":25},{"from":100000,"amount":100}]`),
+	}
+
+	tests := []struct {
+		name     string
+		schedule storage.FeeSchedule
+		amount   money.Amount
+		want     money.Amount
+		wantErr  bool
+	}{
+		{
+			name:     "flat",
+			schedule: storage.FeeSchedule{Kind: types.FeeKindFlat, Amount: storage.NumericFromAmount(150, storage.NoCurrency)},
+			amount:   20000,
+			want:     150,
+		},
+		{
+			// 0.5% of 200.00 is 1.00.
+			name:     "percentage",
+			schedule: percentage,
+			amount:   20000,
+			want:     100,
+		},
+		{
+			// 0.5% of 301.00 is 1.505.
+			name:     "percentage rounded half up",
+			schedule: percentage,
+			amount:   30100,
+			want:     151,
+		},
+		{
+			name:     "percentage below the minimum",
+			schedule: percentage,
+			amount:   100,
+			want:     50,
+		},
+		{
+			name:     "percentage above the maximum",
+			schedule: percentage,
+			amount:   1000000,
+			want:     1000,
+		},
+		{
+			name: "percentage without maximum",
+			schedule: storage.FeeSchedule{
+				Kind:      types.FeeKindPercentage,
+				Rate:      storage.NumericFromRat(big.NewRat(5, 1000), 3),
+				MinAmount: storage.NumericFromAmount(0, storage.NoCurrency),
+				MaxAmount: storage.NumericFromAmount(0, storage.NoCurrency),
+			},
+			amount: 1000000,
+			want:   5000,
+		},
+		{
+			name:     "percentage of a negative balance",
+			schedule: percentage,
+			amount:   -20000,
+			want:     50,
+		},
+		{
+			name:     "first tier",
+			schedule: tiered,
+			amount:   9999,
+		},
+		{
+			name:     "middle tier",
+			schedule: tiered,
+			amount:   10000,
+			want:     25,
+		},
+		{
+			name:     "last tier",
+			schedule: tiered,
+			amount:   500000,
+			want:     100,
+		},
+		{
+			name:     "tiers cannot be decoded",
+			schedule: storage.FeeSchedule{Kind: types.FeeKindTiered, Tiers: []byte(`{`)},
+			amount:   20000,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := compute(tt.schedule, tt.amount)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     types.SetFeeScheduleRequest
+		wantErr error
+	}{
+		{
+			name: "flat",
+			req:  types.SetFeeScheduleRequest{Kind: types.FeeKindFlat, Amount: 150},
+		},
+		{
+			name: "percentage",
+			req:  types.SetFeeScheduleRequest{Kind: types.FeeKindPercentage, Rate: "0.005", MinAmount: 50, MaxAmount: 1000},
+		},
+		{
+			name:    "percentage without rate",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindPercentage, MinAmount: 50},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name:    "percentage with a maximum less than the minimum",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindPercentage, Rate: "0.005", MinAmount: 50, MaxAmount: 10},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name: "tiered",
+			req: types.SetFeeScheduleRequest{Kind: types.FeeKindTiered, Tiers: []types.FeeTier{
+				{From: 0, Amount: 0}, {From: 10000, Amount: 25},
+			}},
+		},
+		{
+			name:    "tiered without tiers",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindTiered},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name: "tiered not from 0",
+			req: types.SetFeeScheduleRequest{Kind: types.FeeKindTiered, Tiers: []types.FeeTier{
+				{From: 100, Amount: 25},
+			}},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name: "tiered with decreasing amounts",
+			req: types.SetFeeScheduleRequest{Kind: types.FeeKindTiered, Tiers: []types.FeeTier{
+				{From: 0, Amount: 0}, {From: 10000, Amount: 25}, {From: 10000, Amount: 50},
+			}},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name: "tiered with a negative fee",
+			req: types.SetFeeScheduleRequest{Kind: types.FeeKindTiered, Tiers: []types.FeeTier{
+				{From: 0, Amount: -1},
+			}},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, validate(&tt.req), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,510 @@
+// Package fee charges the fees of the accounts of a product, by its fee schedule: transfer fees on each transfer from
+// an account, and maintenance fees once a month. Fees are booked as a transaction from the account and one to the fee
+// income account of its currency, linked to the transfer they are charged for.
+package fee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	pqErrorForeignKeyViolation = "23503"
+
+	defaultInterval = time.Hour
+)
+
+var errNoIncomeAccount = errors.New("no fee income account for the currency")
+
+// Auditor records the changes of fee schedules and the maintenance fees charged within their transactions. The
+// transfer fees are recorded with their transfers.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+type Service struct {
+	conn             storage.DBConnection
+	store            storage.FeeStore
+	storeWithTx      func(tx pgx.Tx) storage.FeeStore
+	auditor          Auditor
+	holders          Holders
+	incomeAccountIDs map[string]uuid.UUID
+	logger           logger.Logger
+	interval         time.Duration
+	now              func() time.Time
+}
+
+// New returns a new Service, fees being credited to the account of incomeAccountIDs of the currency code of the
+// account charged, which must be in that currency. No fee is charged to accounts in a currency without one.
+func New(
+	conn storage.DBConnection,
+	store storage.FeeStore,
+	auditor Auditor,
+	holders Holders,
+	incomeAccountIDs map[string]uuid.UUID,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:             conn,
+		store:            store,
+		storeWithTx:      storage.FeeStoreWithTx,
+		auditor:          auditor,
+		holders:          holders,
+		incomeAccountIDs: incomeAccountIDs,
+		logger:           logger,
+		interval:         defaultInterval,
+		now:              time.Now,
+	}
+}
+
+// ListFeeSchedules lists the fee schedules of a product, by type.
+// returns ListFeeSchedulesResponse.
+func (s *Service) ListFeeSchedules(ctx context.Context, productCode string) (types.ListFeeSchedulesResponse, error) {
+	schedules, err := s.store.ListFeeSchedules(ctx, productCode)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list fee schedules", "error", err)
+
+		return types.ListFeeSchedulesResponse{}, types.ErrInternal
+	}
+
+	res := types.ListFeeSchedulesResponse{
+		Schedules: make([]types.FeeSchedule, 0, len(schedules)),
+	}
+
+	for _, schedule := range schedules {
+		res.Schedules = append(res.Schedules, toSchedule(schedule))
+	}
+
+	return res, nil
+}
+
+// SetFeeSchedule sets how the fees of a type are computed for the accounts of a product, replacing the schedule it
+// had.
+// returns SetFeeScheduleResponse.
+func (s *Service) SetFeeSchedule(
+	ctx context.Context,
+	productCode, feeType string,
+	req *types.SetFeeScheduleRequest,
+) (types.SetFeeScheduleResponse, error) {
+	if err := validate(req); err != nil {
+		return types.SetFeeScheduleResponse{}, err
+	}
+
+	params, err := scheduleParams(productCode, feeType, req)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to encode fee schedule", "error", err)
+
+		return types.SetFeeScheduleResponse{}, types.ErrInternal
+	}
+
+	params.CreatedAt = pgtype.Timestamptz{Time: s.now().UTC(), Valid: true}
+
+	var res types.SetFeeScheduleResponse
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.FeeStore) error {
+		schedule, err := store.UpsertFeeSchedule(ctx, params)
+		if err != nil {
+			pgErr := &pgconn.PgError{}
+			if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+				return types.ErrProductNotFound
+			}
+
+			s.logger.ErrorContext(ctx, "failed to set fee schedule", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res = types.SetFeeScheduleResponse{FeeSchedule: toSchedule(schedule)}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:  audit.ActionSetFeeSchedule,
+			Outcome: audit.OutcomeSuccess,
+			After:   res.FeeSchedule,
+		})
+	})
+	if err != nil {
+		return types.SetFeeScheduleResponse{}, err
+	}
+
+	return res, nil
+}
+
+// DeleteFeeSchedule deletes the fee schedule of a type of a product, whose accounts are no longer charged these fees.
+func (s *Service) DeleteFeeSchedule(ctx context.Context, productCode, feeType string) error {
+	return storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.FeeStore) error {
+		n, err := store.DeleteFeeSchedule(ctx, storage.DeleteFeeScheduleParams{
+			ProductCode: productCode,
+			FeeType:     feeType,
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to delete fee schedule", "error", err)
+
+			return types.ErrInternal
+		}
+
+		if n == 0 {
+			return types.ErrFeeScheduleNotFound
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:  audit.ActionDeleteFeeSchedule,
+			Outcome: audit.OutcomeSuccess,
+			Before:  types.FeeSchedule{ProductCode: productCode, Type: feeType},
+		})
+	})
+}
+
+// PreviewTransferFee returns the fee a transfer of amount from an account would be charged, for the holders permitted
+// to view it.
+// returns TransferFeePreview.
+func (s *Service) PreviewTransferFee(
+	ctx context.Context,
+	accountID uuid.UUID,
+	amount money.Amount,
+) (types.TransferFeePreview, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.TransferFeePreview{}, err
+	}
+
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.TransferFeePreview{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return types.TransferFeePreview{}, types.ErrInternal
+	}
+
+	fee, err := s.TransferFee(ctx, account.AccountID, account.ProductCode, account.CurrencyCode, amount)
+	if err != nil {
+		return types.TransferFeePreview{}, err
+	}
+
+	return types.TransferFeePreview{
+		Amount:       amount,
+		Fee:          fee,
+		Total:        amount + fee,
+		CurrencyCode: account.CurrencyCode,
+	}, nil
+}
+
+// TransferFee returns the fee of a transfer of amount from an account of a product in a currency, 0 when the product
+// has no transfer fees or when there is no fee income account of the currency.
+func (s *Service) TransferFee(
+	ctx context.Context,
+	accountID uuid.UUID,
+	productCode, currencyCode string,
+	amount money.Amount,
+) (money.Amount, error) {
+	incomeAccountID, ok := s.incomeAccountIDs[currencyCode]
+	if !ok || accountID == incomeAccountID {
+		return 0, nil
+	}
+
+	schedule, err := s.store.GetFeeSchedule(ctx, storage.GetFeeScheduleParams{
+		ProductCode: productCode,
+		FeeType:     types.FeeTypeTransfer,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch fee schedule", "error", err)
+
+		return 0, types.ErrInternal
+	}
+
+	fee, err := compute(schedule, amount)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to compute fee", "error", err)
+
+		return 0, types.ErrInternal
+	}
+
+	return fee, nil
+}
+
+// Charge charges the fee of a transfer from an account in a currency within tx, linked to the transaction of the
+// transfer.
+func (s *Service) Charge(
+	ctx context.Context,
+	tx pgx.Tx,
+	accountID uuid.UUID,
+	currencyCode string,
+	transactionID uuid.UUID,
+	fee money.Amount,
+) error {
+	if _, err := s.book(ctx, s.storeWithTx(tx), &storage.AddFeeParams{
+		AccountID:            accountID,
+		FeeType:              types.FeeTypeTransfer,
+		ChargedTransactionID: uuid.NullUUID{UUID: transactionID, Valid: true},
+	}, currencyCode, fee); err != nil {
+		s.logger.ErrorContext(ctx, "failed to charge fee", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// Run charges the maintenance fees of the previous month, in UTC, every interval until ctx is done. Accounts already
+// charged for the month are skipped, and those which fail to be charged are retried at the next interval.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		now := s.now().UTC()
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+
+		if err := s.ChargeMaintenance(ctx, month); err != nil && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "failed to charge maintenance fees", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ChargeMaintenance charges the maintenance fees of a month, in UTC, to the accounts of the products which have
+// maintenance fees and which were not charged for it yet, on their balance at its end. Only the accounts in a currency
+// with a fee income account are charged.
+func (s *Service) ChargeMaintenance(ctx context.Context, month time.Time) error {
+	if len(s.incomeAccountIDs) == 0 {
+		return nil
+	}
+
+	month = month.UTC()
+	period := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	params := storage.ListMaintenanceFeeAccountsParams{
+		PeriodEnd: pgtype.Timestamptz{Time: period.AddDate(0, 1, 0), Valid: true},
+		Period:    pgtype.Date{Time: period, Valid: true},
+	}
+
+	for currencyCode, incomeAccountID := range s.incomeAccountIDs {
+		params.CurrencyCodes = append(params.CurrencyCodes, currencyCode)
+		params.IncomeAccountIds = append(params.IncomeAccountIds, incomeAccountID)
+	}
+
+	slices.Sort(params.CurrencyCodes)
+	slices.SortFunc(params.IncomeAccountIds, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+
+	accounts, err := s.store.ListMaintenanceFeeAccounts(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts with maintenance fees: %w", err)
+	}
+
+	var errs []error
+
+	for _, account := range accounts {
+		fee, err := compute(account.FeeSchedule, storage.AmountFromNumeric(account.Balance, account.CurrencyCode))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to compute fee of account %s: %w", account.AccountID, err))
+
+			continue
+		}
+
+		if fee == 0 {
+			continue
+		}
+
+		if err := s.chargeMaintenance(ctx, account.AccountID, account.CurrencyCode, period, fee); err != nil {
+			errs = append(errs, fmt.Errorf("failed to charge account %s: %w", account.AccountID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// chargeMaintenance charges the maintenance fee of a month to an account within a transaction, unless the account was
+// already charged for it.
+func (s *Service) chargeMaintenance(
+	ctx context.Context,
+	accountID uuid.UUID,
+	currencyCode string,
+	period time.Time,
+	fee money.Amount,
+) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	params := storage.AddFeeParams{
+		AccountID: accountID,
+		FeeType:   types.FeeTypeMaintenance,
+		Period:    pgtype.Date{Time: period, Valid: true},
+	}
+
+	n, err := s.book(ctx, s.storeWithTx(tx), &params, currencyCode, fee)
+	if err != nil {
+		return err
+	}
+
+	// Another instance charged the account for the month meanwhile.
+	if n == 0 {
+		return nil
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    audit.ActionChargeFee,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After: types.Transaction{
+			ID: params.TransactionID, AccountID: accountID, Amount: -fee, Type: types.TransactionTypeFee,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "maintenance fee charged",
+		"account_id", accountID, "month", period.Format("2006-01"), "amount", fee)
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// book adds the transactions of a fee, from the account and to the fee income account of its currency, and records the
+// fee with them set in params.
+// returns the number of fees recorded, 0 when the account was already charged for the period.
+func (s *Service) book(
+	ctx context.Context,
+	store storage.FeeStore,
+	params *storage.AddFeeParams,
+	currencyCode string,
+	fee money.Amount,
+) (int64, error) {
+	incomeAccountID, ok := s.incomeAccountIDs[currencyCode]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errNoIncomeAccount, currencyCode)
+	}
+
+	charged, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: params.AccountID,
+		Amount:    storage.NumericFromAmount(-fee, currencyCode),
+		Type:      types.TransactionTypeFee,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add transaction: %w", err)
+	}
+
+	income, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: incomeAccountID,
+		Amount:    storage.NumericFromAmount(fee, currencyCode),
+		SourceID:  uuid.NullUUID{UUID: charged.TransactionID, Valid: true},
+		Type:      types.TransactionTypeFee,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add income transaction: %w", err)
+	}
+
+	params.TransactionID = charged.TransactionID
+	params.IncomeTransactionID = income.TransactionID
+	params.Amount = storage.NumericFromAmount(fee, currencyCode)
+
+	n, err := store.AddFee(ctx, *params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add fee: %w", err)
+	}
+
+	return n, nil
+}
+
+// scheduleParams returns the parameters storing a fee schedule, whose rate was validated as a decimal. Its amounts
+// apply to the accounts of every currency of the product, in the minor unit of theirs.
+func scheduleParams(
+	productCode, feeType string,
+	req *types.SetFeeScheduleRequest,
+) (storage.UpsertFeeScheduleParams, error) {
+	rate := req.Rate
+	if rate == "" {
+		rate = "0"
+	}
+
+	params := storage.UpsertFeeScheduleParams{
+		ProductCode: productCode,
+		FeeType:     feeType,
+		Kind:        req.Kind,
+		Amount:      storage.NumericFromAmount(req.Amount, storage.NoCurrency),
+		MinAmount:   storage.NumericFromAmount(req.MinAmount, storage.NoCurrency),
+		MaxAmount:   storage.NumericFromAmount(req.MaxAmount, storage.NoCurrency),
+	}
+
+	if err := params.Rate.Scan(rate); err != nil {
+		return storage.UpsertFeeScheduleParams{}, fmt.Errorf("failed to parse rate: %w", err)
+	}
+
+	tiers := req.Tiers
+	if tiers == nil {
+		tiers = []types.FeeTier{}
+	}
+
+	var err error
+	if params.Tiers, err = json.Marshal(tiers); err != nil {
+		return storage.UpsertFeeScheduleParams{}, fmt.Errorf("failed to encode tiers: %w", err)
+	}
+
+	return params, nil
+}
+
+func toSchedule(s storage.FeeSchedule) types.FeeSchedule {
+	schedule := types.FeeSchedule{
+		ProductCode: s.ProductCode,
+		Type:        s.FeeType,
+		Kind:        s.Kind,
+		Amount:      storage.AmountFromNumeric(s.Amount, storage.NoCurrency),
+		Rate:        storage.DecimalFromNumeric(s.Rate),
+		MinAmount:   storage.AmountFromNumeric(s.MinAmount, storage.NoCurrency),
+		MaxAmount:   storage.AmountFromNumeric(s.MaxAmount, storage.NoCurrency),
+		Tiers:       []types.FeeTier{},
+		CreatedAt:   s.CreatedAt.Time,
+		UpdatedAt:   s.UpdatedAt.Time,
+	}
+
+	// The tiers were encoded by SetFeeSchedule.
+	_ = json.Unmarshal(s.Tiers, &schedule.Tiers)
+
+	return schedule
+}
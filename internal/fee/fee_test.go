@@ -0,0 +1,472 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID           = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantIncomeAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789009")
+	wantTransactionID       = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantFeeTransactionID    = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantIncomeTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789004")
+	wantNow                 = time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	wantPeriod              = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	errAnything             = errors.New("any")
+
+	wantFlatSchedule = storage.FeeSchedule{
+		ProductCode: "current",
+		FeeType:     types.FeeTypeTransfer,
+		Kind:        types.FeeKindFlat,
+		Amount:      storage.NumericFromAmount(50, storage.NoCurrency),
+		Tiers:       []byte(`[]`),
+	}
+
+	wantListParams = storage.ListMaintenanceFeeAccountsParams{
+		PeriodEnd:        pgtype.Timestamptz{Time: wantPeriod.AddDate(0, 1, 0), Valid: true},
+		CurrencyCodes:    []string{"EUR"},
+		IncomeAccountIds: []uuid.UUID{wantIncomeAccountID},
+		Period:           pgtype.Date{Time: wantPeriod, Valid: true},
+	}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting successful events of action.
+func wantAction(t *testing.T, action string) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+
+		return nil
+	})
+}
+
+func newTestService(conn storage.DBConnection, store storage.FeeStore, auditor Auditor) *Service {
+	s := New(conn, store, auditor, holdertest.Allow(), map[string]uuid.UUID{"EUR": wantIncomeAccountID}, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.FeeStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+// expectBook expects the transactions of a fee and the fee to be added.
+func expectBook(store *storageMocks.MockFeeStore, params storage.AddFeeParams, rows int64, err error) {
+	store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+		AccountID: wantAccountID,
+		Amount:    storage.NumericFromAmount(-50, "EUR"),
+		Type:      types.TransactionTypeFee,
+	}).Return(storage.Transaction{TransactionID: wantFeeTransactionID}, nil).Once()
+	store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+		AccountID: wantIncomeAccountID,
+		Amount:    storage.NumericFromAmount(50, "EUR"),
+		SourceID:  uuid.NullUUID{UUID: wantFeeTransactionID, Valid: true},
+		Type:      types.TransactionTypeFee,
+	}).Return(storage.Transaction{TransactionID: wantIncomeTransactionID}, nil).Once()
+
+	params.TransactionID = wantFeeTransactionID
+	params.IncomeTransactionID = wantIncomeTransactionID
+	params.Amount = storage.NumericFromAmount(50, "EUR")
+
+	store.EXPECT().AddFee(mock.Anything, params).Return(rows, err).Once()
+}
+
+func TestService_ListFeeSchedules(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockFeeStore(t)
+	store.EXPECT().ListFeeSchedules(mock.Anything, "current").Return([]storage.FeeSchedule{wantFlatSchedule}, nil).Once()
+
+	got, err := newTestService(nil, store, nil).ListFeeSchedules(context.Background(), "current")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.ListFeeSchedulesResponse{Schedules: []types.FeeSchedule{{
+		ProductCode: "current",
+		Type:        types.FeeTypeTransfer,
+		Kind:        types.FeeKindFlat,
+		Amount:      50,
+		Rate:        "0",
+		Tiers:       []types.FeeTier{},
+	}}}, got)
+}
+
+func TestService_SetFeeSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     types.SetFeeScheduleRequest
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when the schedule is invalid",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindTiered},
+			wantErr: types.ErrInvalidFeeSchedule,
+		},
+		{
+			name:    "failed when product not found",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindFlat, Amount: 50},
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation},
+			wantErr: types.ErrProductNotFound,
+		},
+		{
+			name:    "failed when the schedule cannot be set",
+			req:     types.SetFeeScheduleRequest{Kind: types.FeeKindFlat, Amount: 50},
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			req:  types.SetFeeScheduleRequest{Kind: types.FeeKindFlat, Amount: 50},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var conn storage.DBConnection
+
+			store := storageMocks.NewMockFeeStore(t)
+
+			if !errors.Is(tt.wantErr, types.ErrInvalidFeeSchedule) {
+				conn = storagetest.NewConn(t, tt.wantErr == nil)
+
+				store.EXPECT().UpsertFeeSchedule(mock.Anything, storage.UpsertFeeScheduleParams{
+					ProductCode: "current",
+					FeeType:     types.FeeTypeTransfer,
+					Kind:        types.FeeKindFlat,
+					Amount:      storage.NumericFromAmount(50, storage.NoCurrency),
+					Rate:        pgtype.Numeric{Int: big.NewInt(0), Valid: true},
+					MinAmount:   storage.NumericFromAmount(0, storage.NoCurrency),
+					MaxAmount:   storage.NumericFromAmount(0, storage.NoCurrency),
+					Tiers:       []byte(`[]`),
+					CreatedAt:   pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(wantFlatSchedule, tt.err).Once()
+			}
+
+			got, err := newTestService(conn, store, wantAction(t, audit.ActionSetFeeSchedule)).SetFeeSchedule(
+				context.Background(), "current", types.FeeTypeTransfer, &tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, types.FeeKindFlat, got.Kind)
+				assert.Equal(t, money.Amount(50), got.Amount)
+			}
+		})
+	}
+}
+
+func TestService_DeleteFeeSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rows    int64
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when the schedule cannot be deleted",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when schedule not found",
+			wantErr: types.ErrFeeScheduleNotFound,
+		},
+		{
+			name: "success",
+			rows: 1,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockFeeStore(t)
+			store.EXPECT().DeleteFeeSchedule(mock.Anything, storage.DeleteFeeScheduleParams{
+				ProductCode: "current",
+				FeeType:     types.FeeTypeMaintenance,
+			}).Return(tt.rows, tt.err).Once()
+
+			s := newTestService(storagetest.NewConn(t, tt.wantErr == nil), store, wantAction(t, audit.ActionDeleteFeeSchedule))
+
+			err := s.DeleteFeeSchedule(context.Background(), "current", types.FeeTypeMaintenance)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_PreviewTransferFee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		permitErr   error
+		accountErr  error
+		scheduleErr error
+		want        types.TransferFeePreview
+		wantErr     error
+	}{
+		{
+			name:      "failed when not permitted",
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name:       "failed when account not found",
+			accountErr: pgx.ErrNoRows,
+			wantErr:    types.ErrAccountNotFound,
+		},
+		{
+			name:        "failed when the schedule cannot be fetched",
+			scheduleErr: errAnything,
+			wantErr:     types.ErrInternal,
+		},
+		{
+			name:        "success when the product has no transfer fees",
+			scheduleErr: pgx.ErrNoRows,
+			want:        types.TransferFeePreview{Amount: 20000, Total: 20000, CurrencyCode: "EUR"},
+		},
+		{
+			name: "success",
+			want: types.TransferFeePreview{Amount: 20000, Fee: 50, Total: 20050, CurrencyCode: "EUR"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockFeeStore(t)
+
+			if tt.permitErr == nil {
+				store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{
+					AccountID: wantAccountID, CurrencyCode: "EUR", ProductCode: "current",
+				}, tt.accountErr).Once()
+			}
+
+			if tt.permitErr == nil && tt.accountErr == nil {
+				store.EXPECT().GetFeeSchedule(mock.Anything, storage.GetFeeScheduleParams{
+					ProductCode: "current",
+					FeeType:     types.FeeTypeTransfer,
+				}).Return(wantFlatSchedule, tt.scheduleErr).Once()
+			}
+
+			s := newTestService(nil, store, nil)
+			s.holders = holdertest.Authorize(t, wantAccountID, holder.PermissionView, tt.permitErr)
+
+			got, err := s.PreviewTransferFee(context.Background(), wantAccountID, 20000)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_TransferFee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success when there is no fee income account", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestService(nil, storageMocks.NewMockFeeStore(t), nil)
+		s.incomeAccountIDs = nil
+
+		got, err := s.TransferFee(context.Background(), wantAccountID, "current", "EUR", 20000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.Amount(0), got)
+	})
+
+	t.Run("success when there is no fee income account of the currency", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newTestService(nil, storageMocks.NewMockFeeStore(t), nil).
+			TransferFee(context.Background(), wantAccountID, "current", "USD", 20000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.Amount(0), got)
+	})
+
+	t.Run("success when the account is the fee income account", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newTestService(nil, storageMocks.NewMockFeeStore(t), nil).
+			TransferFee(context.Background(), wantIncomeAccountID, "current", "EUR", 20000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.Amount(0), got)
+	})
+}
+
+func TestService_Charge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when the fee cannot be added",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockFeeStore(t)
+			expectBook(store, storage.AddFeeParams{
+				AccountID:            wantAccountID,
+				FeeType:              types.FeeTypeTransfer,
+				ChargedTransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+			}, 1, tt.err)
+
+			err := newTestService(nil, store, nil).Charge(
+				context.Background(), txMocks.NewMockTx(t), wantAccountID, "EUR", wantTransactionID, 50)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_ChargeMaintenance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		amount  money.Amount
+		charged int64
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "success when the fee is zero",
+		},
+		{
+			name:    "success when the account is charged",
+			amount:  50,
+			charged: 1,
+		},
+		{
+			name:   "success when another instance charged the account meanwhile",
+			amount: 50,
+		},
+		{
+			name:    "failed when the fee cannot be added",
+			amount:  50,
+			err:     errAnything,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockFeeStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			schedule := wantFlatSchedule
+			schedule.FeeType = types.FeeTypeMaintenance
+			schedule.Amount = storage.NumericFromAmount(tt.amount, storage.NoCurrency)
+
+			store.EXPECT().ListMaintenanceFeeAccounts(mock.Anything, wantListParams).Return(
+				[]storage.ListMaintenanceFeeAccountsRow{
+					{
+						AccountID: wantAccountID, CurrencyCode: "EUR", FeeSchedule: schedule,
+						Balance: storage.NumericFromAmount(20000, "EUR"),
+					},
+				}, nil).Once()
+
+			if tt.amount != 0 {
+				conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+				expectBook(store, storage.AddFeeParams{
+					AccountID: wantAccountID,
+					FeeType:   types.FeeTypeMaintenance,
+					Period:    pgtype.Date{Time: wantPeriod, Valid: true},
+				}, tt.charged, tt.err)
+				tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			}
+
+			if tt.charged == 1 {
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			}
+
+			s := newTestService(conn, store, recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionChargeFee, event.Action)
+				assert.Equal(t, types.Transaction{
+					ID: wantFeeTransactionID, AccountID: wantAccountID, Amount: -50, Type: types.TransactionTypeFee,
+				}, event.After)
+
+				return nil
+			}))
+
+			err := s.ChargeMaintenance(context.Background(), wantPeriod.AddDate(0, 0, 10))
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store := storageMocks.NewMockFeeStore(t)
+	store.EXPECT().ListMaintenanceFeeAccounts(mock.Anything, wantListParams).
+		Return(nil, nil).Run(func(context.Context, storage.ListMaintenanceFeeAccountsParams) { cancel() }).Once()
+
+	assert.NoError(t, newTestService(nil, store, nil).Run(ctx))
+}
@@ -0,0 +1,320 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/internal/api"
+	xbankapiv1 "github.com/zaidsasa/xbankapi/proto/xbankapi/v1"
+	"github.com/zaidsasa/xbankapi/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	errorDomain = "xbankapi"
+
+	defaultListLimit = 50
+	maxListLimit     = 100
+)
+
+var errInvalidPagination = errors.New("limit must be between 0 and 100 and offset must not be negative")
+
+// AccountService serves the account service over gRPC, delegating to api.AccountService.
+type AccountService struct {
+	xbankapiv1.UnimplementedAccountServiceServer
+
+	service api.AccountService
+}
+
+// NewAccountService returns a new AccountService.
+func NewAccountService(service api.AccountService) *AccountService {
+	return &AccountService{
+		service: service,
+	}
+}
+
+// Register the service.
+func (s *AccountService) Register(server *grpc.Server) {
+	xbankapiv1.RegisterAccountServiceServer(server, s)
+}
+
+// CreateAccount creates a bank account.
+func (s *AccountService) CreateAccount(
+	ctx context.Context,
+	in *xbankapiv1.CreateAccountRequest,
+) (*xbankapiv1.CreateAccountResponse, error) {
+	req := &types.CreateAccountRequest{
+		Name:         in.GetName(),
+		Email:        in.GetEmail(),
+		CurrencyCode: in.GetCurrencyCode(),
+		ProductCode:  in.GetProductCode(),
+	}
+
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.service.CreateAccount(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &xbankapiv1.CreateAccountResponse{Account: toAccount(res.Account)}, nil
+}
+
+// AddMoney adds money to a bank account.
+func (s *AccountService) AddMoney(
+	ctx context.Context,
+	in *xbankapiv1.AddMoneyRequest,
+) (*xbankapiv1.AddMoneyResponse, error) {
+	accountID, err := parseID(in.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	req := &types.AddMoneyRequest{Amount: in.GetAmount()}
+
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.service.AddMoney(ctx, req, accountID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &xbankapiv1.AddMoneyResponse{TransactionId: res.TransactionID.String()}, nil
+}
+
+// TransferMoney transfers money from a bank account to another.
+func (s *AccountService) TransferMoney(
+	ctx context.Context,
+	in *xbankapiv1.TransferMoneyRequest,
+) (*xbankapiv1.TransferMoneyResponse, error) {
+	accountID, err := parseID(in.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	req := &types.TransferMoneyRequest{
+		ReciverIBAN: in.GetReciverIban(),
+		Amount:      in.GetAmount(),
+	}
+
+	// The receiver account is given by its ID, by its IBAN or by a beneficiary.
+	if in.GetReciverAccountId() != "" || in.GetReciverIban() == "" && in.GetBeneficiaryId() == "" {
+		if req.ReciverAccountID, err = parseID(in.GetReciverAccountId()); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.GetBeneficiaryId() != "" {
+		if req.BeneficiaryID.UUID, err = parseID(in.GetBeneficiaryId()); err != nil {
+			return nil, err
+		}
+
+		req.BeneficiaryID.Valid = true
+	}
+
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.service.TransferMoney(ctx, req, accountID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &xbankapiv1.TransferMoneyResponse{TransactionId: res.TransactionID.String(), Fee: res.Fee}, nil
+}
+
+// GetAccount returns a bank account and its balance.
+func (s *AccountService) GetAccount(
+	ctx context.Context,
+	in *xbankapiv1.GetAccountRequest,
+) (*xbankapiv1.GetAccountResponse, error) {
+	accountID, err := parseID(in.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &xbankapiv1.GetAccountResponse{
+		Account:           toAccount(res.Account),
+		Balance:           res.Balance,
+		AvailableBalance:  res.AvailableBalance,
+		OverdraftLimit:    res.OverdraftLimit,
+		ApprovalThreshold: res.ApprovalThreshold,
+		Pockets:           toPockets(res.Pockets),
+		TotalBalance:      res.TotalBalance,
+	}, nil
+}
+
+// ListTransactions lists the transactions of a bank account, latest first.
+func (s *AccountService) ListTransactions(
+	ctx context.Context,
+	in *xbankapiv1.ListTransactionsRequest,
+) (*xbankapiv1.ListTransactionsResponse, error) {
+	accountID, err := parseID(in.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := in.GetLimit(), in.GetOffset()
+	if limit < 0 || limit > maxListLimit || offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, errInvalidPagination.Error())
+	}
+
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	res, err := s.service.ListTransactions(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := &xbankapiv1.ListTransactionsResponse{
+		Transactions: make([]*xbankapiv1.Transaction, 0, len(res.Transactions)),
+	}
+
+	for _, t := range res.Transactions {
+		transaction := &xbankapiv1.Transaction{
+			Id:        t.ID.String(),
+			AccountId: t.AccountID.String(),
+			Amount:    t.Amount,
+			CreatedAt: timestamppb.New(t.CreatedAt),
+			Type:      t.Type,
+		}
+
+		if t.SourceID.Valid {
+			transaction.SourceId = t.SourceID.UUID.String()
+		}
+
+		out.Transactions = append(out.Transactions, transaction)
+	}
+
+	return out, nil
+}
+
+func toAccount(account types.Account) *xbankapiv1.Account {
+	res := &xbankapiv1.Account{
+		Id:              account.ID.String(),
+		Name:            account.Name,
+		Email:           account.Email,
+		CurrencyCode:    account.CurrencyCode,
+		Iban:            account.IBAN,
+		ScreeningStatus: account.ScreeningStatus,
+		ProductCode:     account.ProductCode,
+	}
+
+	if account.ParentAccountID != nil {
+		res.ParentAccountId = account.ParentAccountID.String()
+	}
+
+	return res
+}
+
+func toPockets(pockets []types.Pocket) []*xbankapiv1.Pocket {
+	res := make([]*xbankapiv1.Pocket, 0, len(pockets))
+
+	for _, pocket := range pockets {
+		res = append(res, &xbankapiv1.Pocket{
+			Id:              pocket.ID.String(),
+			ParentAccountId: pocket.ParentAccountID.String(),
+			Name:            pocket.Name,
+			CurrencyCode:    pocket.CurrencyCode,
+			Balance:         pocket.Balance,
+			GoalAmount:      pocket.GoalAmount,
+			GoalDate:        pocket.GoalDate,
+		})
+	}
+
+	return res
+}
+
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.UUID{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return parsed, nil
+}
+
+// validateStruct validates a request the same way the HTTP API does, reporting
+// each violation as a field violation.
+func validateStruct(req any) error {
+	v := validate.Struct(req)
+	if v.Validate() {
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+
+	for field, rules := range v.Errors {
+		for _, msg := range rules {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: msg,
+			})
+		}
+	}
+
+	st, err := status.New(codes.InvalidArgument, v.Errors.Error()).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, v.Errors.Error())
+	}
+
+	return st.Err()
+}
+
+// toStatus maps an error returned by api.AccountService to a gRPC status.
+// The API error code is attached as the reason of an ErrorInfo detail.
+func toStatus(err error) error {
+	var code codes.Code
+
+	switch {
+	case errors.Is(err, api.ErrAccountNotFound), errors.Is(err, api.ErrRecieverAccountNotFound),
+		errors.Is(err, types.ErrBeneficiaryNotFound), errors.Is(err, types.ErrProductNotFound):
+		code = codes.NotFound
+	case errors.Is(err, api.ErrAccountAlreadyExist), errors.Is(err, types.ErrCustomerAlreadyExist):
+		code = codes.AlreadyExists
+	case errors.Is(err, api.ErrInsufficientAccountBalance), errors.Is(err, types.ErrBeneficiaryLimitExceeded),
+		errors.Is(err, types.ErrBeneficiaryCoolingOff), errors.Is(err, types.ErrTransferPendingReview),
+		errors.Is(err, types.ErrTransferPendingApproval), errors.Is(err, types.ErrKYCRejected):
+		code = codes.FailedPrecondition
+	case errors.Is(err, types.ErrTransferDenied), errors.Is(err, types.ErrSanctionsMatch),
+		errors.Is(err, types.ErrNotPermitted):
+		code = codes.PermissionDenied
+	case errors.Is(err, types.ErrLimitExceeded):
+		code = codes.ResourceExhausted
+	case errors.Is(err, api.ErrInternal):
+		return status.Error(codes.Internal, "internal server error")
+	default:
+		code = codes.InvalidArgument
+	}
+
+	st := status.New(code, err.Error())
+
+	if reason := types.ErrorCode(err); reason != "" {
+		if withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: errorDomain,
+		}); detailsErr == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
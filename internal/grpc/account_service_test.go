@@ -0,0 +1,465 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/api"
+	"github.com/zaidsasa/xbankapi/internal/api/mocks"
+	xbankapiv1 "github.com/zaidsasa/xbankapi/proto/xbankapi/v1"
+	"github.com/zaidsasa/xbankapi/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	wantAccountID        = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantTransactionID    = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantBeneficiaryID    = uuid.MustParse("12345678-1234-1234-1234-123456789050")
+	wantReciverAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+
+	errAnything = errors.New("any error")
+)
+
+func TestAccountService_CreateAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       *xbankapiv1.CreateAccountRequest
+		mock     func(*mocks.MockAccountService)
+		want     *xbankapiv1.CreateAccountResponse
+		wantCode codes.Code
+	}{
+		{
+			name:     "failed when request is invalid",
+			in:       &xbankapiv1.CreateAccountRequest{Name: "name", Email: "wrong", CurrencyCode: "EUR"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "failed when account already exists",
+			in:   &xbankapiv1.CreateAccountRequest{Name: "name", Email: "test@mail.com", CurrencyCode: "EUR"},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+					Return(types.CreateAccountResponse{}, api.ErrAccountAlreadyExist).Once()
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "failed when product not found",
+			in: &xbankapiv1.CreateAccountRequest{
+				Name: "name", Email: "test@mail.com", CurrencyCode: "EUR", ProductCode: "business",
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+					Return(types.CreateAccountResponse{}, types.ErrProductNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "failed when the name matches a sanctions entry",
+			in:   &xbankapiv1.CreateAccountRequest{Name: "John Doe", Email: "test@mail.com", CurrencyCode: "EUR"},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, mock.Anything).
+					Return(types.CreateAccountResponse{}, types.ErrSanctionsMatch).Once()
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "success when account is created",
+			in: &xbankapiv1.CreateAccountRequest{
+				Name: "name", Email: "test@mail.com", CurrencyCode: "EUR", ProductCode: "savings",
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().CreateAccount(mock.Anything, &types.CreateAccountRequest{
+					Name: "name", Email: "test@mail.com", CurrencyCode: "EUR", ProductCode: "savings",
+				}).Return(types.CreateAccountResponse{Account: types.Account{
+					ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR",
+				}}, nil).Once()
+			},
+			want: &xbankapiv1.CreateAccountResponse{Account: &xbankapiv1.Account{
+				Id: wantAccountID.String(), Name: "name", Email: "test@mail.com", CurrencyCode: "EUR",
+			}},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			got, err := NewAccountService(accountServiceMock).CreateAccount(context.Background(), tt.in)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
+
+func TestAccountService_CreateAccount_fieldViolations(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAccountService(mocks.NewMockAccountService(t)).CreateAccount(
+		context.Background(),
+		&xbankapiv1.CreateAccountRequest{Name: "name", Email: "wrong", CurrencyCode: "EUR"},
+	)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "email", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestAccountService_AddMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       *xbankapiv1.AddMoneyRequest
+		mock     func(*mocks.MockAccountService)
+		want     *xbankapiv1.AddMoneyResponse
+		wantCode codes.Code
+	}{
+		{
+			name:     "failed when account id is invalid",
+			in:       &xbankapiv1.AddMoneyRequest{AccountId: "invalid", Amount: 100},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "failed when account not found",
+			in:   &xbankapiv1.AddMoneyRequest{AccountId: wantAccountID.String(), Amount: 100},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().AddMoney(mock.Anything, &types.AddMoneyRequest{Amount: 100}, wantAccountID).
+					Return(types.AddMoneyResponse{}, api.ErrAccountNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "failed when service returns an internal error",
+			in:   &xbankapiv1.AddMoneyRequest{AccountId: wantAccountID.String(), Amount: 100},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().AddMoney(mock.Anything, &types.AddMoneyRequest{Amount: 100}, wantAccountID).
+					Return(types.AddMoneyResponse{}, api.ErrInternal).Once()
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "success when money is added",
+			in:   &xbankapiv1.AddMoneyRequest{AccountId: wantAccountID.String(), Amount: 100},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().AddMoney(mock.Anything, &types.AddMoneyRequest{Amount: 100}, wantAccountID).
+					Return(types.AddMoneyResponse{TransactionID: wantTransactionID}, nil).Once()
+			},
+			want:     &xbankapiv1.AddMoneyResponse{TransactionId: wantTransactionID.String()},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			got, err := NewAccountService(accountServiceMock).AddMoney(context.Background(), tt.in)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
+
+func TestAccountService_TransferMoney(t *testing.T) {
+	t.Parallel()
+
+	req := &types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 100}
+
+	tests := []struct {
+		name     string
+		in       *xbankapiv1.TransferMoneyRequest
+		mock     func(*mocks.MockAccountService)
+		want     *xbankapiv1.TransferMoneyResponse
+		wantCode codes.Code
+	}{
+		{
+			name: "failed when reciver account id is invalid",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: "invalid", Amount: 100,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "failed when insufficient account balance",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+					Return(types.TransferMoneyResponse{}, api.ErrInsufficientAccountBalance).Once()
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "failed when reciver account not found",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+					Return(types.TransferMoneyResponse{}, api.ErrRecieverAccountNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "success when money is transferred",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+					Return(types.TransferMoneyResponse{TransactionID: wantTransactionID, Fee: 50}, nil).Once()
+			},
+			want:     &xbankapiv1.TransferMoneyResponse{TransactionId: wantTransactionID.String(), Fee: 50},
+			wantCode: codes.OK,
+		},
+		{
+			name: "failed when reciver iban is invalid",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverIban: "DE00370400440532013000", Amount: 100,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "success when money is transferred to an iban",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverIban: "DE89370400440532013000", Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, &types.TransferMoneyRequest{
+					ReciverIBAN: "DE89370400440532013000", Amount: 100,
+				}, wantAccountID).Return(types.TransferMoneyResponse{TransactionID: wantTransactionID}, nil).Once()
+			},
+			want:     &xbankapiv1.TransferMoneyResponse{TransactionId: wantTransactionID.String()},
+			wantCode: codes.OK,
+		},
+		{
+			name: "failed when beneficiary is in its cooling-off period",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), BeneficiaryId: wantBeneficiaryID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, &types.TransferMoneyRequest{
+					BeneficiaryID: uuid.NullUUID{UUID: wantBeneficiaryID, Valid: true}, Amount: 100,
+				}, wantAccountID).Return(types.TransferMoneyResponse{}, types.ErrBeneficiaryCoolingOff).Once()
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "failed when a limit of the account is exceeded",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).Return(types.TransferMoneyResponse{},
+					&types.LimitExceededError{Limit: types.LimitDailyCount}).Once()
+			},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name: "failed when the transfer is denied by the risk engine",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+					Return(types.TransferMoneyResponse{}, types.ErrTransferDenied).Once()
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "failed when the transfer is held for review",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), ReciverAccountId: wantReciverAccountID.String(), Amount: 100,
+			},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().TransferMoney(mock.Anything, req, wantAccountID).
+					Return(types.TransferMoneyResponse{}, &types.PendingReviewError{PendingTransferID: uuid.New()}).Once()
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "failed when beneficiary id is invalid",
+			in: &xbankapiv1.TransferMoneyRequest{
+				AccountId: wantAccountID.String(), BeneficiaryId: "one", Amount: 100,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			got, err := NewAccountService(accountServiceMock).TransferMoney(context.Background(), tt.in)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
+
+func TestAccountService_GetAccount(t *testing.T) {
+	t.Parallel()
+
+	pocketID := uuid.New()
+
+	accountServiceMock := mocks.NewMockAccountService(t)
+	accountServiceMock.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(types.GetAccountResponse{
+		Account: types.Account{
+			ID: wantAccountID, Name: "name", Email: "test@mail.com", CurrencyCode: "EUR",
+			ScreeningStatus: types.ScreeningStatusClear, ProductCode: "current",
+		},
+		Balance:           -100,
+		AvailableBalance:  49900,
+		OverdraftLimit:    50000,
+		ApprovalThreshold: proto.Int64(100000),
+		Pockets: []types.Pocket{{
+			ID: pocketID, ParentAccountID: wantAccountID, Name: "holiday", CurrencyCode: "EUR",
+			Balance: 2000, GoalAmount: 50000, GoalDate: "2027-06-30",
+		}},
+		TotalBalance: proto.Int64(1900),
+	}, nil).Once()
+	accountServiceMock.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).
+		Return(types.GetAccountResponse{}, api.ErrAccountNotFound).Once()
+
+	service := NewAccountService(accountServiceMock)
+
+	got, err := service.GetAccount(context.Background(), &xbankapiv1.GetAccountRequest{AccountId: wantAccountID.String()})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&xbankapiv1.GetAccountResponse{
+		Account: &xbankapiv1.Account{
+			Id: wantAccountID.String(), Name: "name", Email: "test@mail.com", CurrencyCode: "EUR",
+			ScreeningStatus: types.ScreeningStatusClear, ProductCode: "current",
+		},
+		Balance:           -100,
+		AvailableBalance:  49900,
+		OverdraftLimit:    50000,
+		ApprovalThreshold: proto.Int64(100000),
+		Pockets: []*xbankapiv1.Pocket{{
+			Id: pocketID.String(), ParentAccountId: wantAccountID.String(), Name: "holiday", CurrencyCode: "EUR",
+			Balance: 2000, GoalAmount: 50000, GoalDate: "2027-06-30",
+		}},
+		TotalBalance: proto.Int64(1900),
+	}, got), "got %v", got)
+
+	_, err = service.GetAccount(context.Background(), &xbankapiv1.GetAccountRequest{
+		AccountId: wantReciverAccountID.String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	st, _ := status.FromError(err)
+	require.Len(t, st.Details(), 1)
+
+	errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, types.ErrorCodeAccountNotFound, errorInfo.GetReason())
+}
+
+func TestAccountService_ListTransactions(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		in       *xbankapiv1.ListTransactionsRequest
+		mock     func(*mocks.MockAccountService)
+		want     *xbankapiv1.ListTransactionsResponse
+		wantCode codes.Code
+	}{
+		{
+			name:     "failed when limit is too high",
+			in:       &xbankapiv1.ListTransactionsRequest{AccountId: wantAccountID.String(), Limit: 1000},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "failed when service returns an error",
+			in:   &xbankapiv1.ListTransactionsRequest{AccountId: wantAccountID.String()},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(defaultListLimit), int32(0)).
+					Return(types.ListTransactionsResponse{}, errAnything).Once()
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "success when account has transactions",
+			in:   &xbankapiv1.ListTransactionsRequest{AccountId: wantAccountID.String(), Limit: 10, Offset: 5},
+			mock: func(mas *mocks.MockAccountService) {
+				mas.EXPECT().ListTransactions(mock.Anything, wantAccountID, int32(10), int32(5)).
+					Return(types.ListTransactionsResponse{Transactions: []types.Transaction{{
+						ID:        wantTransactionID,
+						AccountID: wantAccountID,
+						Amount:    100,
+						SourceID:  uuid.NullUUID{UUID: wantReciverAccountID, Valid: true},
+						Type:      types.TransactionTypeTransfer,
+						CreatedAt: createdAt,
+					}}}, nil).Once()
+			},
+			want: &xbankapiv1.ListTransactionsResponse{Transactions: []*xbankapiv1.Transaction{{
+				Id:        wantTransactionID.String(),
+				AccountId: wantAccountID.String(),
+				Amount:    100,
+				SourceId:  wantReciverAccountID.String(),
+				CreatedAt: timestamppb.New(createdAt),
+				Type:      types.TransactionTypeTransfer,
+			}}},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			accountServiceMock := mocks.NewMockAccountService(t)
+			if tt.mock != nil {
+				tt.mock(accountServiceMock)
+			}
+
+			got, err := NewAccountService(accountServiceMock).ListTransactions(context.Background(), tt.in)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			assert.True(t, proto.Equal(tt.want, got), "got %v", got)
+		})
+	}
+}
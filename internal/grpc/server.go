@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+type (
+	// Service registers itself with a gRPC server.
+	Service interface {
+		Register(server *grpc.Server)
+	}
+
+	// Instrumentation observes every call served, e.g. to trace it.
+	Instrumentation interface {
+		Handler() stats.Handler
+	}
+
+	Server struct {
+		logger          logger.Logger
+		services        []Service
+		interceptors    []grpc.UnaryServerInterceptor
+		instrumentation []Instrumentation
+	}
+)
+
+// NewServer returns a new Server.
+func NewServer(logger logger.Logger, services ...Service) *Server {
+	return &Server{
+		logger:   logger,
+		services: services,
+	}
+}
+
+// Use adds interceptors to the unary calls, the first one added is the outermost.
+func (s *Server) Use(interceptors ...grpc.UnaryServerInterceptor) {
+	s.interceptors = append(s.interceptors, interceptors...)
+}
+
+// Instrument observes calls before any interceptor runs.
+func (s *Server) Instrument(instrumentation ...Instrumentation) {
+	s.instrumentation = append(s.instrumentation, instrumentation...)
+}
+
+// Start serving with the provided address until ctx is done.
+func (s *Server) Start(ctx context.Context, addr string) error {
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	opts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(s.interceptors...)}
+	for _, instrumentation := range s.instrumentation {
+		opts = append(opts, grpc.StatsHandler(instrumentation.Handler()))
+	}
+
+	server := grpc.NewServer(opts...)
+
+	for _, service := range s.services {
+		service.Register(server)
+	}
+
+	s.logger.Info("grpc server started", "address", addr)
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			errChan <- fmt.Errorf("failed to serve grpc server: %w", err)
+		}
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("shutting down grpc server, please wait...")
+
+	server.GracefulStop()
+
+	s.logger.Info("a graceful grpc shutdown")
+
+	return nil
+}
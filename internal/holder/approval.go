@@ -0,0 +1,274 @@
+package holder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// AccountService makes the transfers the holders approve.
+type AccountService interface {
+	TransferMoney(
+		ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+}
+
+// Approvals lists the transfers held by the mandates of accounts, and approves or rejects them.
+type Approvals struct {
+	conn        storage.DBConnection
+	store       storage.TransferApprovalStore
+	storeWithTx func(tx pgx.Tx) storage.TransferApprovalStore
+	auditor     Auditor
+	holders     *Service
+	accounts    AccountService
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// NewApprovals returns a new Approvals.
+func NewApprovals(
+	conn storage.DBConnection,
+	store storage.TransferApprovalStore,
+	auditor Auditor,
+	holders *Service,
+	accounts AccountService,
+	logger logger.Logger,
+) *Approvals {
+	return &Approvals{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.TransferApprovalStoreWithTx,
+		auditor:     auditor,
+		holders:     holders,
+		accounts:    accounts,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// ListTransferApprovals lists the transfer approvals of an account of a status, oldest first.
+// returns ListTransferApprovalsResponse.
+func (s *Approvals) ListTransferApprovals(
+	ctx context.Context,
+	accountID uuid.UUID,
+	status string,
+	limit, offset int32,
+) (types.ListTransferApprovalsResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, PermissionView); err != nil {
+		return types.ListTransferApprovalsResponse{}, err
+	}
+
+	ok, err := s.store.HasAccount(ctx, accountID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to check account", "error", err)
+
+		return types.ListTransferApprovalsResponse{}, types.ErrInternal
+	}
+
+	if !ok {
+		return types.ListTransferApprovalsResponse{}, types.ErrAccountNotFound
+	}
+
+	approvals, err := s.store.ListTransferApprovals(ctx, storage.ListTransferApprovalsParams{
+		AccountID: accountID,
+		Status:    status,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list transfer approvals", "error", err)
+
+		return types.ListTransferApprovalsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListTransferApprovalsResponse{
+		TransferApprovals: make([]types.TransferApproval, 0, len(approvals)),
+	}
+
+	for _, a := range approvals {
+		res.TransferApprovals = append(res.TransferApprovals, toTransferApproval(a))
+	}
+
+	return res, nil
+}
+
+// ApproveTransfer approves a transfer held by the mandate of an account and makes it, screened as any other transfer.
+// The customer approving it must hold the account with a role permitting transfers and must not be the one who
+// initiated it, the admin cannot approve it. The transfer is pending again when it fails, e.g. when the balance of the
+// account is no longer sufficient, unless it is held for review by the admin.
+// returns ApproveTransferResponse.
+func (s *Approvals) ApproveTransfer(
+	ctx context.Context,
+	accountID, transferApprovalID uuid.UUID,
+) (types.ApproveTransferResponse, error) {
+	a, err := s.get(ctx, accountID, transferApprovalID)
+	if err != nil {
+		return types.ApproveTransferResponse{}, err
+	}
+
+	approverID, ok := customer(ctx)
+	if !ok {
+		return types.ApproveTransferResponse{}, types.ErrNotPermitted
+	}
+
+	if initiatorID, err := uuid.Parse(a.InitiatedBy); err == nil && initiatorID == approverID {
+		return types.ApproveTransferResponse{}, types.ErrSameApprover
+	}
+
+	// The transfer is approved first, so that it is made once when approved concurrently.
+	a, err = s.decide(ctx, transferApprovalID, types.TransferApprovalStatusApproved, audit.ActionApproveTransfer)
+	if err != nil {
+		return types.ApproveTransferResponse{}, err
+	}
+
+	res, err := s.accounts.TransferMoney(ContextWithApproval(ctx), &types.TransferMoneyRequest{
+		ReciverAccountID: a.ReciverAccountID,
+		Amount:           storage.AmountFromNumeric(a.Amount, a.CurrencyCode),
+	}, a.AccountID)
+	if err != nil {
+		// The transfer held for review is decided by the admin from now on.
+		if !errors.Is(err, types.ErrTransferPendingReview) {
+			if err := s.store.ReopenTransferApproval(ctx, transferApprovalID); err != nil {
+				s.logger.ErrorContext(ctx, "failed to reopen transfer approval", "error", err)
+			}
+		}
+
+		return types.ApproveTransferResponse{}, err
+	}
+
+	a.TransactionID = uuid.NullUUID{UUID: res.TransactionID, Valid: true}
+
+	// The transfer is made, it is reported even if its transaction cannot be saved.
+	if err := s.store.SetTransferApprovalTransaction(ctx, storage.SetTransferApprovalTransactionParams{
+		TransferApprovalID: transferApprovalID,
+		TransactionID:      a.TransactionID,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to set transfer approval transaction", "error", err)
+	}
+
+	return types.ApproveTransferResponse{TransferApproval: toTransferApproval(a)}, nil
+}
+
+// RejectTransfer rejects a transfer held by the mandate of an account, which is never made. The holder who initiated
+// it can reject it, to cancel it.
+// returns RejectTransferResponse.
+func (s *Approvals) RejectTransfer(
+	ctx context.Context,
+	accountID, transferApprovalID uuid.UUID,
+) (types.RejectTransferResponse, error) {
+	if _, err := s.get(ctx, accountID, transferApprovalID); err != nil {
+		return types.RejectTransferResponse{}, err
+	}
+
+	a, err := s.decide(ctx, transferApprovalID, types.TransferApprovalStatusRejected, audit.ActionRejectTransfer)
+	if err != nil {
+		return types.RejectTransferResponse{}, err
+	}
+
+	return types.RejectTransferResponse{TransferApproval: toTransferApproval(a)}, nil
+}
+
+// get returns a transfer approval of an account, checking that the actor of ctx may decide it.
+func (s *Approvals) get(
+	ctx context.Context,
+	accountID, transferApprovalID uuid.UUID,
+) (storage.TransferApproval, error) {
+	if err := s.holders.Authorize(ctx, accountID, PermissionTransfer); err != nil {
+		return storage.TransferApproval{}, err
+	}
+
+	a, err := s.store.GetTransferApproval(ctx, storage.GetTransferApprovalParams{
+		TransferApprovalID: transferApprovalID,
+		AccountID:          accountID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.TransferApproval{}, types.ErrTransferApprovalNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get transfer approval", "error", err)
+
+		return storage.TransferApproval{}, types.ErrInternal
+	}
+
+	return a, nil
+}
+
+// decide sets the status of a transfer approval within a transaction, recording the decision as action, failing when
+// it is no longer pending.
+func (s *Approvals) decide(
+	ctx context.Context,
+	transferApprovalID uuid.UUID,
+	status, action string,
+) (storage.TransferApproval, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return storage.TransferApproval{}, types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	a, err := s.storeWithTx(tx).DecideTransferApproval(ctx, storage.DecideTransferApprovalParams{
+		TransferApprovalID: transferApprovalID,
+		Status:             status,
+		DecidedBy:          pgtype.Text{String: audit.ActorFromContext(ctx).Principal, Valid: true},
+		DecidedAt:          pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.TransferApproval{}, types.ErrTransferApprovalDecided
+		}
+
+		s.logger.ErrorContext(ctx, "failed to decide transfer approval", "error", err)
+
+		return storage.TransferApproval{}, types.ErrInternal
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    action,
+		AccountID: uuid.NullUUID{UUID: a.AccountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After:     toTransferApproval(a),
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return storage.TransferApproval{}, types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return storage.TransferApproval{}, types.ErrInternal
+	}
+
+	return a, nil
+}
+
+func toTransferApproval(a storage.TransferApproval) types.TransferApproval {
+	t := types.TransferApproval{
+		ID:               a.TransferApprovalID,
+		AccountID:        a.AccountID,
+		ReciverAccountID: a.ReciverAccountID,
+		Amount:           storage.AmountFromNumeric(a.Amount, a.CurrencyCode),
+		Status:           a.Status,
+		InitiatedBy:      a.InitiatedBy,
+		DecidedBy:        a.DecidedBy.String,
+		TransactionID:    a.TransactionID,
+		CreatedAt:        a.CreatedAt.Time,
+	}
+
+	if a.DecidedAt.Valid {
+		t.DecidedAt = &a.DecidedAt.Time
+	}
+
+	return t
+}
@@ -0,0 +1,315 @@
+package holder
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// transferFunc is an AccountService made of a function.
+type transferFunc func(
+	ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+
+func (f transferFunc) TransferMoney(
+	ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID,
+) (types.TransferMoneyResponse, error) {
+	return f(ctx, req, accountID)
+}
+
+// noTransfer fails the test when a transfer is made.
+func noTransfer(t *testing.T) transferFunc {
+	t.Helper()
+
+	return func(context.Context, *types.TransferMoneyRequest, uuid.UUID) (types.TransferMoneyResponse, error) {
+		t.Error("unexpected transfer")
+
+		return types.TransferMoneyResponse{}, nil
+	}
+}
+
+func testTransferApproval(status string) storage.TransferApproval {
+	a := storage.TransferApproval{
+		TransferApprovalID: wantTransferApprovalID,
+		AccountID:          wantAccountID,
+		ReciverAccountID:   wantReciverAccountID,
+		Amount:             storage.NumericFromAmount(500000, "EUR"),
+		CurrencyCode:       "EUR",
+		Status:             status,
+		InitiatedBy:        wantCustomerID.String(),
+		CreatedAt:          pgtype.Timestamptz{Time: wantNow.Add(-time.Hour), Valid: true},
+	}
+
+	if status != types.TransferApprovalStatusPending {
+		a.DecidedBy = pgtype.Text{String: wantCoOwnerID.String(), Valid: true}
+		a.DecidedAt = pgtype.Timestamptz{Time: wantNow, Valid: true}
+	}
+
+	return a
+}
+
+// newTestApprovals returns an Approvals whose holders are all co-owners.
+func newTestApprovals(
+	t *testing.T,
+	conn storage.DBConnection,
+	store storage.TransferApprovalStore,
+	auditor Auditor,
+	accounts AccountService,
+) *Approvals {
+	t.Helper()
+
+	holders := storageMocks.NewMockHolderStore(t)
+	holders.EXPECT().GetAccountHolderRole(mock.Anything, mock.Anything).Return(types.HolderRoleCoOwner, nil).Maybe()
+
+	a := NewApprovals(conn, store, auditor, newTestService(holders), accounts, slog.Default())
+	a.storeWithTx = func(pgx.Tx) storage.TransferApprovalStore { return store }
+	a.now = func() time.Time { return wantNow }
+
+	return a
+}
+
+func TestApprovals_ListTransferApprovals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		hasAccount bool
+		err        error
+		want       types.ListTransferApprovalsResponse
+		wantErr    error
+	}{
+		{
+			name:    "failed when account not found",
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:       "failed when the transfer approvals cannot be listed",
+			hasAccount: true,
+			err:        errAnything,
+			wantErr:    types.ErrInternal,
+		},
+		{
+			name:       "success",
+			hasAccount: true,
+			want: types.ListTransferApprovalsResponse{TransferApprovals: []types.TransferApproval{{
+				ID:               wantTransferApprovalID,
+				AccountID:        wantAccountID,
+				ReciverAccountID: wantReciverAccountID,
+				Amount:           500000,
+				Status:           types.TransferApprovalStatusPending,
+				InitiatedBy:      wantCustomerID.String(),
+				CreatedAt:        wantNow.Add(-time.Hour),
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockTransferApprovalStore(t)
+			store.EXPECT().HasAccount(mock.Anything, wantAccountID).Return(tt.hasAccount, nil).Once()
+
+			if tt.hasAccount {
+				store.EXPECT().ListTransferApprovals(mock.Anything, storage.ListTransferApprovalsParams{
+					AccountID: wantAccountID,
+					Status:    types.TransferApprovalStatusPending,
+					Offset:    5,
+					Limit:     10,
+				}).Return([]storage.TransferApproval{testTransferApproval(types.TransferApprovalStatusPending)}, tt.err).
+					Once()
+			}
+
+			got, err := newTestApprovals(t, nil, store, nil, noTransfer(t)).ListTransferApprovals(
+				customerContext(wantCoOwnerID), wantAccountID, types.TransferApprovalStatusPending, 10, 5)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApprovals_ApproveTransfer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		// initiatedBy is the principal who initiated the transfer, the owner when not set.
+		initiatedBy string
+		getErr      error
+		decide      bool
+		decideErr   error
+		// transfer is whether the transfer is made, failing with transferErr, and reopened when reopen is set.
+		transfer    bool
+		transferErr error
+		reopen      bool
+		wantErr     error
+	}{
+		{
+			name:    "failed when transfer approval not found",
+			ctx:     customerContext(wantCoOwnerID),
+			getErr:  pgx.ErrNoRows,
+			wantErr: types.ErrTransferApprovalNotFound,
+		},
+		{
+			name:    "failed when approved by the holder who initiated it",
+			ctx:     customerContext(wantCustomerID),
+			wantErr: types.ErrSameApprover,
+		},
+		{
+			name:    "failed when approved by the holder who initiated it, spelling their ID differently",
+			ctx:     principalContext("urn:uuid:" + strings.ToUpper(wantCustomerID.String())),
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name:        "failed when approved by the holder who initiated it with another spelling of their ID",
+			ctx:         customerContext(wantCustomerID),
+			initiatedBy: "{" + strings.ToUpper(wantCustomerID.String()) + "}",
+			wantErr:     types.ErrNotPermitted,
+		},
+		{
+			name:      "failed when already decided",
+			ctx:       customerContext(wantCoOwnerID),
+			decide:    true,
+			decideErr: pgx.ErrNoRows,
+			wantErr:   types.ErrTransferApprovalDecided,
+		},
+		{
+			name:        "failed when the transfer fails, which is pending again",
+			ctx:         customerContext(wantCoOwnerID),
+			decide:      true,
+			transfer:    true,
+			transferErr: types.ErrInsufficientAccountBalance,
+			reopen:      true,
+			wantErr:     types.ErrInsufficientAccountBalance,
+		},
+		{
+			name:        "failed when the transfer is held for review, which stays approved",
+			ctx:         customerContext(wantCoOwnerID),
+			decide:      true,
+			transfer:    true,
+			transferErr: &types.PendingReviewError{PendingTransferID: wantTransactionID},
+			wantErr:     types.ErrTransferPendingReview,
+		},
+		{
+			name:     "success",
+			ctx:      customerContext(wantCoOwnerID),
+			decide:   true,
+			transfer: true,
+		},
+		{
+			name:    "failed when approved by the admin",
+			ctx:     adminContext(),
+			wantErr: types.ErrNotPermitted,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pending := testTransferApproval(types.TransferApprovalStatusPending)
+			pending.InitiatedBy = cmp.Or(tt.initiatedBy, pending.InitiatedBy)
+
+			store := storageMocks.NewMockTransferApprovalStore(t)
+			store.EXPECT().GetTransferApproval(mock.Anything, storage.GetTransferApprovalParams{
+				TransferApprovalID: wantTransferApprovalID,
+				AccountID:          wantAccountID,
+			}).Return(pending, tt.getErr).Once()
+
+			var conn storage.DBConnection
+
+			if tt.decide {
+				conn = storagetest.NewConn(t, tt.decideErr == nil)
+
+				store.EXPECT().DecideTransferApproval(mock.Anything, mock.MatchedBy(
+					func(arg storage.DecideTransferApprovalParams) bool {
+						return arg.TransferApprovalID == wantTransferApprovalID &&
+							arg.Status == types.TransferApprovalStatusApproved && arg.DecidedBy.Valid
+					})).Return(testTransferApproval(types.TransferApprovalStatusApproved), tt.decideErr).Once()
+			}
+
+			accounts := noTransfer(t)
+			if tt.transfer {
+				accounts = func(ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID,
+				) (types.TransferMoneyResponse, error) {
+					assert.True(t, approved(ctx))
+					assert.Equal(t, wantAccountID, accountID)
+					assert.Equal(t, &types.TransferMoneyRequest{
+						ReciverAccountID: wantReciverAccountID,
+						Amount:           500000,
+					}, req)
+
+					return types.TransferMoneyResponse{TransactionID: wantTransactionID}, tt.transferErr
+				}
+			}
+
+			if tt.reopen {
+				store.EXPECT().ReopenTransferApproval(mock.Anything, wantTransferApprovalID).Return(nil).Once()
+			}
+
+			if tt.transfer && tt.transferErr == nil {
+				store.EXPECT().SetTransferApprovalTransaction(mock.Anything, storage.SetTransferApprovalTransactionParams{
+					TransferApprovalID: wantTransferApprovalID,
+					TransactionID:      uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+				}).Return(nil).Once()
+			}
+
+			a := newTestApprovals(t, conn, store, wantAction(t, audit.ActionApproveTransfer), accounts)
+
+			got, err := a.ApproveTransfer(tt.ctx, wantAccountID, wantTransferApprovalID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, types.TransferApprovalStatusApproved, got.Status)
+				assert.Equal(t, uuid.NullUUID{UUID: wantTransactionID, Valid: true}, got.TransactionID)
+			}
+		})
+	}
+}
+
+func TestApprovals_RejectTransfer(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockTransferApprovalStore(t)
+	store.EXPECT().GetTransferApproval(mock.Anything, storage.GetTransferApprovalParams{
+		TransferApprovalID: wantTransferApprovalID,
+		AccountID:          wantAccountID,
+	}).Return(testTransferApproval(types.TransferApprovalStatusPending), nil).Once()
+	store.EXPECT().DecideTransferApproval(mock.Anything, storage.DecideTransferApprovalParams{
+		Status:             types.TransferApprovalStatusRejected,
+		DecidedBy:          pgtype.Text{String: wantCustomerID.String(), Valid: true},
+		DecidedAt:          pgtype.Timestamptz{Time: wantNow, Valid: true},
+		TransferApprovalID: wantTransferApprovalID,
+	}).Return(testTransferApproval(types.TransferApprovalStatusRejected), nil).Once()
+
+	// The holder who initiated the transfer can reject it.
+	a := newTestApprovals(t, storagetest.NewConn(t, true), store, wantAction(t, audit.ActionRejectTransfer), noTransfer(t))
+
+	got, err := a.RejectTransfer(
+		customerContext(wantCustomerID), wantAccountID, wantTransferApprovalID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.TransferApprovalStatusRejected, got.Status)
+	assert.Equal(t, wantNow, *got.DecidedAt)
+}
@@ -0,0 +1,452 @@
+// Package holder manages the holders of accounts and their mandates. An account is held by the customer it was opened
+// for, its owner, and by the customers the owner adds as co-owners, signatories or viewers, whose role decides what
+// they may do on the account. The principal of requests made by customers is their customer ID, requests made by the
+// admin are not checked and requests made by other principals are not permitted. A mandate holds the transfers above
+// its threshold until a holder other than the one who initiated them approves them.
+package holder
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Permission is what a holder may do on an account.
+type Permission string
+
+const (
+	// PermissionView allows viewing an account, its transactions, statements, limits, fees, interest and beneficiaries,
+	// and its holders.
+	PermissionView Permission = "view"
+	// PermissionTransfer allows making transfers from an account and deposits to it, importing payment files, managing
+	// its beneficiaries, and approving or rejecting transfers.
+	PermissionTransfer Permission = "transfer"
+	// PermissionManage allows changing the holders and the mandate of an account.
+	PermissionManage Permission = "manage"
+)
+
+const (
+	pqErrorForeignKeyViolation = "23503"
+	customerForeignKey         = "account_holder_customer_id_fkey"
+)
+
+var permissions = map[string][]Permission{
+	types.HolderRoleOwner:     {PermissionView, PermissionTransfer, PermissionManage},
+	types.HolderRoleCoOwner:   {PermissionView, PermissionTransfer, PermissionManage},
+	types.HolderRoleSignatory: {PermissionView, PermissionTransfer},
+	types.HolderRoleViewer:    {PermissionView},
+}
+
+type approvedCtxKey struct{}
+
+// ContextWithApproval returns a context whose transfers are not held by the mandate of their account, as they were
+// approved by a second holder.
+func ContextWithApproval(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedCtxKey{}, true)
+}
+
+func approved(ctx context.Context) bool {
+	ok, _ := ctx.Value(approvedCtxKey{}).(bool)
+
+	return ok
+}
+
+// Auditor records the changes of holders and mandates within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.HolderStore
+	storeWithTx func(tx pgx.Tx) storage.HolderStore
+	auditor     Auditor
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(conn storage.DBConnection, store storage.HolderStore, auditor Auditor, logger logger.Logger) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.HolderStoreWithTx,
+		auditor:     auditor,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Authorize checks that the actor of ctx may do what permission allows on an account, failing with
+// types.ErrNotPermitted when it is neither the admin nor a customer holding the account whose role permits it.
+func (s *Service) Authorize(ctx context.Context, accountID uuid.UUID, permission Permission) error {
+	if audit.ActorFromContext(ctx).Admin {
+		return nil
+	}
+
+	customerID, ok := customer(ctx)
+	if !ok {
+		return types.ErrNotPermitted
+	}
+
+	role, err := s.store.GetAccountHolderRole(ctx, storage.GetAccountHolderRoleParams{
+		AccountID:  accountID,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrNotPermitted
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get account holder role", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if !slices.Contains(permissions[role], permission) {
+		return types.ErrNotPermitted
+	}
+
+	return nil
+}
+
+// AuthorizeCustomer checks that the actor of ctx may act for a customer, failing with types.ErrNotPermitted when it is
+// neither the admin nor the customer.
+func (s *Service) AuthorizeCustomer(ctx context.Context, customerID uuid.UUID) error {
+	if audit.ActorFromContext(ctx).Admin {
+		return nil
+	}
+
+	if actorID, ok := customer(ctx); !ok || actorID != customerID {
+		return types.ErrNotPermitted
+	}
+
+	return nil
+}
+
+// CheckKYC checks that money may be transferred from the accounts of a customer, failing with types.ErrKYCRejected
+// when the KYC checks of the customer were rejected.
+func (s *Service) CheckKYC(ctx context.Context, customerID uuid.UUID) error {
+	c, err := s.store.GetCustomer(ctx, customerID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get customer", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if c.KYCStatus == types.KYCStatusRejected {
+		return types.ErrKYCRejected
+	}
+
+	return nil
+}
+
+// ListHolders lists the holders of an account, the owner first.
+// returns ListAccountHoldersResponse.
+func (s *Service) ListHolders(ctx context.Context, accountID uuid.UUID) (types.ListAccountHoldersResponse, error) {
+	if err := s.Authorize(ctx, accountID, PermissionView); err != nil {
+		return types.ListAccountHoldersResponse{}, err
+	}
+
+	holders, err := s.store.ListAccountHolders(ctx, accountID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list account holders", "error", err)
+
+		return types.ListAccountHoldersResponse{}, types.ErrInternal
+	}
+
+	// Every account has an owner.
+	if len(holders) == 0 {
+		return types.ListAccountHoldersResponse{}, types.ErrAccountNotFound
+	}
+
+	res := types.ListAccountHoldersResponse{
+		Holders: make([]types.AccountHolder, 0, len(holders)),
+	}
+
+	for _, h := range holders {
+		res.Holders = append(res.Holders, toAccountHolder(h))
+	}
+
+	return res, nil
+}
+
+// SetHolder adds a customer as a holder of an account, or changes their role. The role of the owner cannot be
+// changed.
+// returns SetAccountHolderResponse.
+func (s *Service) SetHolder(
+	ctx context.Context,
+	accountID, customerID uuid.UUID,
+	req *types.SetAccountHolderRequest,
+) (types.SetAccountHolderResponse, error) {
+	if err := s.Authorize(ctx, accountID, PermissionManage); err != nil {
+		return types.SetAccountHolderResponse{}, err
+	}
+
+	var res types.SetAccountHolderResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.HolderStore) error {
+		h, err := store.UpsertAccountHolder(ctx, storage.UpsertAccountHolderParams{
+			AccountID:  accountID,
+			CustomerID: customerID,
+			Role:       req.Role,
+			CreatedAt:  pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		})
+		if err != nil {
+			return s.setHolderError(ctx, err)
+		}
+
+		res = types.SetAccountHolderResponse{AccountHolder: toAccountHolder(h)}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionSetHolder,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			After:     res.AccountHolder,
+		})
+	})
+	if err != nil {
+		return types.SetAccountHolderResponse{}, err
+	}
+
+	return res, nil
+}
+
+// setHolderError returns the error of a holder which could not be set.
+func (s *Service) setHolderError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return types.ErrOwnerImmutable
+	}
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+		if pgErr.ConstraintName == customerForeignKey {
+			return types.ErrCustomerNotFound
+		}
+
+		return types.ErrAccountNotFound
+	}
+
+	s.logger.ErrorContext(ctx, "failed to set account holder", "error", err)
+
+	return types.ErrInternal
+}
+
+// RemoveHolder removes a holder from an account. The owner cannot be removed.
+func (s *Service) RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID) error {
+	if err := s.Authorize(ctx, accountID, PermissionManage); err != nil {
+		return err
+	}
+
+	role, err := s.store.GetAccountHolderRole(ctx, storage.GetAccountHolderRoleParams{
+		AccountID:  accountID,
+		CustomerID: customerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrHolderNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get account holder role", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if role == types.HolderRoleOwner {
+		return types.ErrOwnerImmutable
+	}
+
+	return storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.HolderStore) error {
+		n, err := store.DeleteAccountHolder(ctx, storage.DeleteAccountHolderParams{
+			AccountID:  accountID,
+			CustomerID: customerID,
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to delete account holder", "error", err)
+
+			return types.ErrInternal
+		}
+
+		if n == 0 {
+			return types.ErrHolderNotFound
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionRemoveHolder,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    types.AccountHolder{CustomerID: customerID, Role: role},
+		})
+	})
+}
+
+// SetMandate sets the amount above which the transfers from an account need the approval of a second holder.
+// returns SetMandateResponse.
+func (s *Service) SetMandate(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.SetMandateRequest,
+) (types.SetMandateResponse, error) {
+	if err := s.setApprovalThreshold(ctx, audit.ActionSetMandate, accountID, &req.ApprovalThreshold); err != nil {
+		return types.SetMandateResponse{}, err
+	}
+
+	return types.SetMandateResponse{Mandate: types.Mandate{
+		AccountID:         accountID,
+		ApprovalThreshold: req.ApprovalThreshold,
+	}}, nil
+}
+
+// DeleteMandate deletes the mandate of an account, whose transfers no longer need a second approval. The transfers
+// already held stay pending until they are approved or rejected.
+func (s *Service) DeleteMandate(ctx context.Context, accountID uuid.UUID) error {
+	return s.setApprovalThreshold(ctx, audit.ActionDeleteMandate, accountID, nil)
+}
+
+// setApprovalThreshold sets the approval threshold of an account in its currency, none when threshold is nil, recording
+// the change as action.
+func (s *Service) setApprovalThreshold(
+	ctx context.Context,
+	action string,
+	accountID uuid.UUID,
+	threshold *money.Amount,
+) error {
+	if err := s.Authorize(ctx, accountID, PermissionManage); err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get account", "error", err)
+
+		return types.ErrInternal
+	}
+
+	params := storage.SetAccountApprovalThresholdParams{AccountID: accountID}
+	if threshold != nil {
+		params.ApprovalThreshold = storage.NumericFromAmount(*threshold, account.CurrencyCode)
+	}
+
+	return storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.HolderStore) error {
+		n, err := store.SetAccountApprovalThreshold(ctx, params)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to set account approval threshold", "error", err)
+
+			return types.ErrInternal
+		}
+
+		if n == 0 {
+			return types.ErrAccountNotFound
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    action,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    toMandate(account.AccountID, account.ApprovalThreshold, account.CurrencyCode),
+			After:     toMandate(accountID, params.ApprovalThreshold, account.CurrencyCode),
+		})
+	})
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// Hold holds a transfer of amount from account when it is above the threshold of its mandate, failing with a
+// types.PendingApprovalError, in which case the transfer approval is saved within tx, which must be committed
+// nonetheless. Transfers made by the admin or approved by a second holder, see ContextWithApproval, are not held.
+func (s *Service) Hold(
+	ctx context.Context,
+	tx pgx.Tx,
+	account storage.Account,
+	reciverAccountID uuid.UUID,
+	amount money.Amount,
+) error {
+	if !account.ApprovalThreshold.Valid || audit.ActorFromContext(ctx).Admin || approved(ctx) {
+		return nil
+	}
+
+	if amount <= storage.AmountFromNumeric(account.ApprovalThreshold, account.CurrencyCode) {
+		return nil
+	}
+
+	// The initiator is the customer ID rather than the principal, which may spell it differently.
+	initiatorID, ok := customer(ctx)
+	if !ok {
+		return types.ErrNotPermitted
+	}
+
+	a, err := s.storeWithTx(tx).CreateTransferApproval(ctx, storage.CreateTransferApprovalParams{
+		AccountID:        account.AccountID,
+		ReciverAccountID: reciverAccountID,
+		Amount:           storage.NumericFromAmount(amount, account.CurrencyCode),
+		CurrencyCode:     account.CurrencyCode,
+		InitiatedBy:      initiatorID.String(),
+		CreatedAt:        pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to create transfer approval", "error", err)
+
+		return types.ErrInternal
+	}
+
+	s.logger.InfoContext(ctx, "transfer held for approval", "account_id", account.AccountID,
+		"transfer_approval_id", a.TransferApprovalID)
+
+	return &types.PendingApprovalError{TransferApprovalID: a.TransferApprovalID}
+}
+
+// customer returns the customer ID of the actor of ctx, false when it is the admin or not a customer.
+func customer(ctx context.Context) (uuid.UUID, bool) {
+	actor := audit.ActorFromContext(ctx)
+	if actor.Admin {
+		return uuid.Nil, false
+	}
+
+	customerID, err := uuid.Parse(actor.Principal)
+
+	return customerID, err == nil
+}
+
+// toMandate returns the audit snapshot of the mandate of an account with an approval threshold, nil when it has none.
+func toMandate(accountID uuid.UUID, threshold pgtype.Numeric, currencyCode string) any {
+	if !threshold.Valid {
+		return nil
+	}
+
+	return types.Mandate{
+		AccountID:         accountID,
+		ApprovalThreshold: storage.AmountFromNumeric(threshold, currencyCode),
+	}
+}
+
+func toAccountHolder(h storage.AccountHolder) types.AccountHolder {
+	return types.AccountHolder{
+		CustomerID: h.CustomerID,
+		Role:       h.Role,
+		CreatedAt:  h.CreatedAt.Time,
+		UpdatedAt:  h.UpdatedAt.Time,
+	}
+}
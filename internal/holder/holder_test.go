@@ -0,0 +1,638 @@
+package holder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID          = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantReciverAccountID   = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantCustomerID         = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantCoOwnerID          = uuid.MustParse("12345678-1234-1234-1234-123456789004")
+	wantTransferApprovalID = uuid.MustParse("12345678-1234-1234-1234-123456789005")
+	wantTransactionID      = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+	wantNow                = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	errAnything            = errors.New("any")
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting successful events of action on the account.
+func wantAction(t *testing.T, action string) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+		assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+		return nil
+	})
+}
+
+func newTestService(store storage.HolderStore) *Service {
+	s := New(nil, store, nil, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.HolderStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+// customerContext returns a context whose actor is a customer.
+func customerContext(customerID uuid.UUID) context.Context {
+	return principalContext(customerID.String())
+}
+
+// principalContext returns a context whose actor is a principal, e.g. a customer ID spelled differently.
+func principalContext(principal string) context.Context {
+	return audit.ContextWithActor(context.Background(), audit.Actor{Principal: principal})
+}
+
+// adminContext returns a context whose actor is the admin, who is not checked.
+func adminContext() context.Context {
+	return audit.ContextWithActor(context.Background(), audit.Actor{Principal: audit.PrincipalAdmin, Admin: true})
+}
+
+func TestService_Authorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		permission Permission
+		role       string
+		err        error
+		wantErr    error
+	}{
+		{
+			name:       "allowed when made by the admin",
+			ctx:        adminContext(),
+			permission: PermissionManage,
+		},
+		{
+			name:       "failed when made by an anonymous principal",
+			ctx:        context.Background(),
+			permission: PermissionView,
+			wantErr:    types.ErrNotPermitted,
+		},
+		{
+			name:       "failed when made by a principal who is not a customer",
+			ctx:        audit.ContextWithActor(context.Background(), audit.Actor{Principal: "alice"}),
+			permission: PermissionView,
+			wantErr:    types.ErrNotPermitted,
+		},
+		{
+			name:       "allowed when the role permits it",
+			ctx:        customerContext(wantCustomerID),
+			permission: PermissionTransfer,
+			role:       types.HolderRoleSignatory,
+		},
+		{
+			name:       "failed when the role does not permit it",
+			ctx:        customerContext(wantCustomerID),
+			permission: PermissionTransfer,
+			role:       types.HolderRoleViewer,
+			wantErr:    types.ErrNotPermitted,
+		},
+		{
+			name:       "failed when the customer does not hold the account",
+			ctx:        customerContext(wantCustomerID),
+			permission: PermissionView,
+			err:        pgx.ErrNoRows,
+			wantErr:    types.ErrNotPermitted,
+		},
+		{
+			name:       "failed when the role cannot be fetched",
+			ctx:        customerContext(wantCustomerID),
+			permission: PermissionView,
+			err:        errAnything,
+			wantErr:    types.ErrInternal,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			if tt.role != "" || tt.err != nil {
+				store.EXPECT().GetAccountHolderRole(mock.Anything, storage.GetAccountHolderRoleParams{
+					AccountID:  wantAccountID,
+					CustomerID: wantCustomerID,
+				}).Return(tt.role, tt.err).Once()
+			}
+
+			err := newTestService(store).Authorize(tt.ctx, wantAccountID, tt.permission)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_AuthorizeCustomer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{
+			name: "allowed when made by the admin",
+			ctx:  adminContext(),
+		},
+		{
+			name: "allowed when made by the customer",
+			ctx:  customerContext(wantCustomerID),
+		},
+		{
+			name:    "failed when made by another customer",
+			ctx:     customerContext(wantCoOwnerID),
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name:    "failed when made by an anonymous principal",
+			ctx:     context.Background(),
+			wantErr: types.ErrNotPermitted,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newTestService(storageMocks.NewMockHolderStore(t)).AuthorizeCustomer(tt.ctx, wantCustomerID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_CheckKYC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		kycStatus string
+		err       error
+		wantErr   error
+	}{
+		{
+			name:      "allowed when the KYC checks are pending",
+			kycStatus: types.KYCStatusPending,
+		},
+		{
+			name:      "allowed when the KYC checks are verified",
+			kycStatus: types.KYCStatusVerified,
+		},
+		{
+			name:      "failed when the KYC checks were rejected",
+			kycStatus: types.KYCStatusRejected,
+			wantErr:   types.ErrKYCRejected,
+		},
+		{
+			name:    "failed when the customer cannot be fetched",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			store.EXPECT().GetCustomer(mock.Anything, wantCustomerID).
+				Return(storage.Customer{CustomerID: wantCustomerID, KYCStatus: tt.kycStatus}, tt.err).Once()
+
+			err := newTestService(store).CheckKYC(context.Background(), wantCustomerID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_ListHolders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		holders []storage.AccountHolder
+		err     error
+		want    types.ListAccountHoldersResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the holders cannot be listed",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when account not found",
+			holders: []storage.AccountHolder{},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "success",
+			holders: []storage.AccountHolder{{
+				AccountID:  wantAccountID,
+				CustomerID: wantCustomerID,
+				Role:       types.HolderRoleOwner,
+				CreatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+				UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+			}},
+			want: types.ListAccountHoldersResponse{Holders: []types.AccountHolder{{
+				CustomerID: wantCustomerID,
+				Role:       types.HolderRoleOwner,
+				CreatedAt:  wantNow,
+				UpdatedAt:  wantNow,
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			store.EXPECT().ListAccountHolders(mock.Anything, wantAccountID).Return(tt.holders, tt.err).Once()
+
+			got, err := newTestService(store).ListHolders(adminContext(), wantAccountID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SetHolder(t *testing.T) {
+	t.Parallel()
+
+	holder := storage.AccountHolder{
+		AccountID:  wantAccountID,
+		CustomerID: wantCoOwnerID,
+		Role:       types.HolderRoleCoOwner,
+		CreatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		role    string
+		err     error
+		want    types.SetAccountHolderResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the holder is not permitted to manage the account",
+			ctx:     customerContext(wantCustomerID),
+			role:    types.HolderRoleSignatory,
+			wantErr: types.ErrNotPermitted,
+		},
+		{
+			name:    "failed when the customer is the owner",
+			ctx:     adminContext(),
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrOwnerImmutable,
+		},
+		{
+			name:    "failed when customer not found",
+			ctx:     adminContext(),
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: customerForeignKey},
+			wantErr: types.ErrCustomerNotFound,
+		},
+		{
+			name:    "failed when account not found",
+			ctx:     adminContext(),
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: "account_holder_account_id_fkey"},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when the holder cannot be set",
+			ctx:     adminContext(),
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when set by a co-owner",
+			ctx:  customerContext(wantCustomerID),
+			role: types.HolderRoleCoOwner,
+			want: types.SetAccountHolderResponse{AccountHolder: types.AccountHolder{
+				CustomerID: wantCoOwnerID,
+				Role:       types.HolderRoleCoOwner,
+				CreatedAt:  wantNow,
+				UpdatedAt:  wantNow,
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			s := newTestService(store)
+
+			if tt.role != "" {
+				store.EXPECT().GetAccountHolderRole(mock.Anything, storage.GetAccountHolderRoleParams{
+					AccountID:  wantAccountID,
+					CustomerID: wantCustomerID,
+				}).Return(tt.role, nil).Once()
+			}
+
+			if !errors.Is(tt.wantErr, types.ErrNotPermitted) {
+				s.conn = storagetest.NewConn(t, tt.wantErr == nil)
+				s.auditor = wantAction(t, audit.ActionSetHolder)
+
+				store.EXPECT().UpsertAccountHolder(mock.Anything, storage.UpsertAccountHolderParams{
+					AccountID:  wantAccountID,
+					CustomerID: wantCoOwnerID,
+					Role:       types.HolderRoleCoOwner,
+					CreatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(holder, tt.err).Once()
+			}
+
+			got, err := s.SetHolder(tt.ctx, wantAccountID, wantCoOwnerID,
+				&types.SetAccountHolderRequest{Role: types.HolderRoleCoOwner})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_RemoveHolder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		role    string
+		err     error
+		deleted int64
+		wantErr error
+	}{
+		{
+			name:    "failed when holder not found",
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrHolderNotFound,
+		},
+		{
+			name:    "failed when the holder is the owner",
+			role:    types.HolderRoleOwner,
+			wantErr: types.ErrOwnerImmutable,
+		},
+		{
+			name:    "failed when the holder was removed concurrently",
+			role:    types.HolderRoleViewer,
+			wantErr: types.ErrHolderNotFound,
+		},
+		{
+			name:    "success",
+			role:    types.HolderRoleViewer,
+			deleted: 1,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			s := newTestService(store)
+
+			store.EXPECT().GetAccountHolderRole(mock.Anything, storage.GetAccountHolderRoleParams{
+				AccountID:  wantAccountID,
+				CustomerID: wantCoOwnerID,
+			}).Return(tt.role, tt.err).Once()
+
+			if tt.role == types.HolderRoleViewer {
+				s.conn = storagetest.NewConn(t, tt.wantErr == nil)
+				s.auditor = wantAction(t, audit.ActionRemoveHolder)
+
+				store.EXPECT().DeleteAccountHolder(mock.Anything, storage.DeleteAccountHolderParams{
+					AccountID:  wantAccountID,
+					CustomerID: wantCoOwnerID,
+				}).Return(tt.deleted, nil).Once()
+			}
+
+			err := s.RemoveHolder(adminContext(), wantAccountID, wantCoOwnerID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_SetMandate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		getErr  error
+		updated int64
+		err     error
+		want    types.SetMandateResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the account cannot be fetched",
+			getErr:  errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when account not found",
+			getErr:  pgx.ErrNoRows,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when the mandate cannot be set",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when account deleted meanwhile",
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:    "success",
+			updated: 1,
+			want: types.SetMandateResponse{Mandate: types.Mandate{
+				AccountID:         wantAccountID,
+				ApprovalThreshold: 100000,
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			s := newTestService(store)
+
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).
+				Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "JPY"}, tt.getErr).Once()
+
+			if tt.getErr == nil {
+				s.conn = storagetest.NewConn(t, tt.wantErr == nil)
+				s.auditor = wantAction(t, audit.ActionSetMandate)
+
+				store.EXPECT().SetAccountApprovalThreshold(mock.Anything, storage.SetAccountApprovalThresholdParams{
+					AccountID:         wantAccountID,
+					ApprovalThreshold: storage.NumericFromAmount(100000, "JPY"),
+				}).Return(tt.updated, tt.err).Once()
+			}
+
+			got, err := s.SetMandate(adminContext(), wantAccountID,
+				&types.SetMandateRequest{ApprovalThreshold: 100000})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_DeleteMandate(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockHolderStore(t)
+	store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{AccountID: wantAccountID}, nil).Once()
+	store.EXPECT().SetAccountApprovalThreshold(mock.Anything, storage.SetAccountApprovalThresholdParams{
+		AccountID: wantAccountID,
+	}).Return(1, nil).Once()
+
+	s := newTestService(store)
+	s.conn = storagetest.NewConn(t, true)
+	s.auditor = wantAction(t, audit.ActionDeleteMandate)
+
+	err := s.DeleteMandate(adminContext(), wantAccountID)
+
+	assert.NoError(t, err)
+}
+
+func TestService_Hold(t *testing.T) {
+	t.Parallel()
+
+	account := storage.Account{
+		AccountID: wantAccountID, CurrencyCode: "EUR", ApprovalThreshold: storage.NumericFromAmount(100000, "EUR"),
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		account storage.Account
+		amount  money.Amount
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "not held when the account has no mandate",
+			ctx:     customerContext(wantCustomerID),
+			account: storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"},
+			amount:  500000,
+		},
+		{
+			name:    "not held when the amount is not above the threshold",
+			ctx:     customerContext(wantCustomerID),
+			account: account,
+			amount:  100000,
+		},
+		{
+			name:    "not held when made by the admin",
+			ctx:     adminContext(),
+			account: account,
+			amount:  500000,
+		},
+		{
+			name:    "not held when approved by a second holder",
+			ctx:     ContextWithApproval(customerContext(wantCoOwnerID)),
+			account: account,
+			amount:  500000,
+		},
+		{
+			name:    "failed when the transfer approval cannot be created",
+			ctx:     customerContext(wantCustomerID),
+			account: account,
+			amount:  500000,
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "held when the amount is above the threshold",
+			ctx:     customerContext(wantCustomerID),
+			account: account,
+			amount:  500000,
+			wantErr: &types.PendingApprovalError{TransferApprovalID: wantTransferApprovalID},
+		},
+		{
+			name:    "held with the ID of the customer when the principal spells it differently",
+			ctx:     principalContext(strings.ToUpper(wantCustomerID.String())),
+			account: account,
+			amount:  500000,
+			wantErr: &types.PendingApprovalError{TransferApprovalID: wantTransferApprovalID},
+		},
+		{
+			name:    "failed when not made by a customer",
+			ctx:     principalContext(audit.PrincipalAnonymous),
+			account: account,
+			amount:  500000,
+			wantErr: types.ErrNotPermitted,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockHolderStore(t)
+			if tt.wantErr != nil && !errors.Is(tt.wantErr, types.ErrNotPermitted) {
+				store.EXPECT().CreateTransferApproval(mock.Anything, storage.CreateTransferApprovalParams{
+					AccountID:        wantAccountID,
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           storage.NumericFromAmount(tt.amount, "EUR"),
+					CurrencyCode:     "EUR",
+					InitiatedBy:      wantCustomerID.String(),
+					CreatedAt:        pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(storage.TransferApproval{TransferApprovalID: wantTransferApprovalID}, tt.err).Once()
+			}
+
+			err := newTestService(store).Hold(tt.ctx, nil, tt.account, wantReciverAccountID, tt.amount)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Package holdertest provides the account holder test doubles shared by the tests of the services authorizing them.
+package holdertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+)
+
+// AuthorizeFunc authorizes the holders of accounts with a function.
+type AuthorizeFunc func(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+
+func (f AuthorizeFunc) Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error {
+	return f(ctx, accountID, permission)
+}
+
+// Authorize returns holders checking that permission is asked on the account, failing with err.
+func Authorize(t *testing.T, wantAccountID uuid.UUID, want holder.Permission, err error) AuthorizeFunc {
+	t.Helper()
+
+	return func(_ context.Context, accountID uuid.UUID, permission holder.Permission) error {
+		assert.Equal(t, wantAccountID, accountID)
+		assert.Equal(t, want, permission)
+
+		return err
+	}
+}
+
+// Allow returns holders permitting everything.
+func Allow() AuthorizeFunc {
+	return func(context.Context, uuid.UUID, holder.Permission) error {
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -19,12 +20,34 @@ type (
 		Register(mux *http.ServeMux)
 	}
 
+	// Middleware wraps the handler serving all routes.
+	Middleware func(http.Handler) http.Handler
+
+	// Instrumentation observes every request served. route returns the pattern
+	// matched by a request, or an empty string when none is matched.
+	Instrumentation interface {
+		Instrument(next http.Handler, route func(r *http.Request) string) http.Handler
+	}
+
 	Server struct {
-		logger   logger.Logger
-		handlers []Handler
+		logger          logger.Logger
+		handlers        []Handler
+		middlewares     []Middleware
+		instrumentation []Instrumentation
 	}
 )
 
+type shuttingDownKey struct{}
+
+// ShuttingDown returns a channel closed when the server serving the request of ctx shuts down. Shutting down waits
+// for the requests being served, so long-lived responses such as event streams end when it is closed. It returns nil
+// outside of a request served by a Server.
+func ShuttingDown(ctx context.Context) <-chan struct{} {
+	done, _ := ctx.Value(shuttingDownKey{}).(<-chan struct{})
+
+	return done
+}
+
 // NewServer returns a new Server.
 func NewServer(logger logger.Logger, handlers ...Handler) *Server {
 	return &Server{
@@ -33,6 +56,16 @@ func NewServer(logger logger.Logger, handlers ...Handler) *Server {
 	}
 }
 
+// Use adds middlewares, the first one added is the outermost.
+func (s *Server) Use(middlewares ...Middleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+// Instrument observes requests before any middleware runs, the first one added is the outermost.
+func (s *Server) Instrument(instrumentation ...Instrumentation) {
+	s.instrumentation = append(s.instrumentation, instrumentation...)
+}
+
 // Start serving with the provided address.
 func (s *Server) Start(ctx context.Context, addr string) error {
 	mux := http.NewServeMux()
@@ -41,10 +74,28 @@ func (s *Server) Start(ctx context.Context, addr string) error {
 		handler.Register(mux)
 	}
 
+	var h http.Handler = mux
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		h = s.middlewares[i](h)
+	}
+
+	route := func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+
+		return pattern
+	}
+
+	for i := len(s.instrumentation) - 1; i >= 0; i-- {
+		h = s.instrumentation[i].Instrument(h, route)
+	}
+
 	server := &http.Server{
 		Addr:              addr,
 		ReadHeaderTimeout: httpServerReadHeaderTimeout,
-		Handler:           mux,
+		Handler:           h,
+		BaseContext: func(net.Listener) context.Context {
+			return context.WithValue(context.Background(), shuttingDownKey{}, ctx.Done())
+		},
 	}
 
 	s.logger.Info("server started", "address", addr)
@@ -0,0 +1,40 @@
+package iban
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// assignBatchSize is the number of accounts listed at once to be assigned an IBAN.
+const assignBatchSize = 100
+
+// Assign assigns an IBAN to the accounts which have none, those created before accounts were assigned one, returning
+// how many were.
+func Assign(ctx context.Context, store storage.IBANStore, g *Generator) (int, error) {
+	var assigned int
+
+	for {
+		accounts, err := store.ListAccountsWithoutIBAN(ctx, assignBatchSize)
+		if err != nil {
+			return assigned, fmt.Errorf("failed to list accounts without iban: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			return assigned, nil
+		}
+
+		for _, account := range accounts {
+			if err := store.SetAccountIBAN(ctx, storage.SetAccountIBANParams{
+				AccountID: account.AccountID,
+				IBAN:      pgtype.Text{String: g.Generate(account.AccountNumber), Valid: true},
+			}); err != nil {
+				return assigned, fmt.Errorf("failed to set account iban: %w", err)
+			}
+
+			assigned++
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package iban generates and validates International Bank Account Numbers, ISO 13616: a country code, two check
+// digits and a Basic Bank Account Number, the BBAN, which is the bank code followed by the account number.
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultCountryCode = "DE"
+	DefaultBankCode    = "10000000"
+
+	// accountNumberLength is the number of digits of account numbers in BBANs, padded with zeros.
+	accountNumberLength = 10
+	maxBBANLength       = 30
+)
+
+var (
+	ErrInvalidCountryCode = errors.New("iban country code must be two capital letters")
+	ErrInvalidBankCode    = errors.New("iban bank code must be 1 to 20 capital letters or digits")
+
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	bankCodePattern    = regexp.MustCompile(`^[A-Z0-9]{1,20}$`)
+	ibanPattern        = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{1,30}$`)
+)
+
+// Generator generates the IBANs of the accounts of a bank.
+type Generator struct {
+	countryCode string
+	bankCode    string
+}
+
+// NewGenerator returns a new Generator of IBANs of the country, whose BBANs start with the bank code.
+func NewGenerator(countryCode, bankCode string) (*Generator, error) {
+	if !countryCodePattern.MatchString(countryCode) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCountryCode, countryCode)
+	}
+
+	if !bankCodePattern.MatchString(bankCode) || len(bankCode)+accountNumberLength > maxBBANLength {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBankCode, bankCode)
+	}
+
+	return &Generator{countryCode: countryCode, bankCode: bankCode}, nil
+}
+
+// Generate returns the IBAN of an account number, whose BBAN is the bank code followed by the account number padded
+// with zeros to 10 digits.
+func (g *Generator) Generate(accountNumber int64) string {
+	bban := fmt.Sprintf("%s%0*d", g.bankCode, accountNumberLength, accountNumber)
+
+	return g.countryCode + checkDigits(g.countryCode, bban) + bban
+}
+
+// Normalize returns the electronic format of an IBAN, without spaces and in capital letters, e.g.
+// DE89370400440532013000 for "de89 3704 0044 0532 0130 00".
+func Normalize(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// Valid reports whether s is an IBAN, in electronic or print format, whose check digits are correct.
+func Valid(s string) bool {
+	s = Normalize(s)
+
+	return ibanPattern.MatchString(s) && mod97(s[4:]+s[:4]) == 1
+}
+
+// checkDigits returns the check digits of a BBAN of the country: 98 minus the remainder of the division by 97 of the
+// BBAN followed by the country code and 00, letters being replaced by numbers from 10 for A to 35 for Z.
+func checkDigits(countryCode, bban string) string {
+	return fmt.Sprintf("%02d", 98-mod97(bban+countryCode+"00")) //nolint:mnd // ISO 7064 MOD 97-10.
+}
+
+// mod97 returns the remainder of the division by 97 of the number s stands for, letters being replaced by numbers from
+// 10 for A to 35 for Z. The number is divided digit by digit, as it is too large for any integer.
+func mod97(s string) int {
+	remainder := 0
+
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97 //nolint:mnd // decimal.
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97 //nolint:mnd // letters are two digits numbers.
+		}
+	}
+
+	return remainder
+}
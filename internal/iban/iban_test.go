@@ -0,0 +1,194 @@
+package iban
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+)
+
+var errAnything = errors.New("any")
+
+func TestNewGenerator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		countryCode string
+		bankCode    string
+		wantErr     error
+	}{
+		{
+			name:        "failed when the country code is lower case",
+			countryCode: "de",
+			bankCode:    "37040044",
+			wantErr:     ErrInvalidCountryCode,
+		},
+		{
+			name:        "failed when the country code is too long",
+			countryCode: "DEU",
+			bankCode:    "37040044",
+			wantErr:     ErrInvalidCountryCode,
+		},
+		{
+			name:        "failed when the bank code is empty",
+			countryCode: "DE",
+			wantErr:     ErrInvalidBankCode,
+		},
+		{
+			name:        "failed when the bank code is not alphanumeric",
+			countryCode: "DE",
+			bankCode:    "3704-0044",
+			wantErr:     ErrInvalidBankCode,
+		},
+		{
+			name:        "failed when the bank code is too long",
+			countryCode: "DE",
+			bankCode:    "123456789012345678901",
+			wantErr:     ErrInvalidBankCode,
+		},
+		{
+			name:        "success",
+			countryCode: DefaultCountryCode,
+			bankCode:    DefaultBankCode,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewGenerator(tt.countryCode, tt.bankCode)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantErr == nil, got != nil)
+		})
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		countryCode   string
+		bankCode      string
+		accountNumber int64
+		want          string
+	}{
+		{
+			name:          "german iban",
+			countryCode:   "DE",
+			bankCode:      "37040044",
+			accountNumber: 532013000,
+			want:          "DE89370400440532013000",
+		},
+		{
+			name:          "check digits below 10",
+			countryCode:   "DE",
+			bankCode:      "10000000",
+			accountNumber: 17,
+			want:          "DE06100000000000000017",
+		},
+		{
+			name:          "alphanumeric bank code",
+			countryCode:   "GB",
+			bankCode:      "WEST123456",
+			accountNumber: 98765432,
+			want:          "GB95WEST1234560098765432",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGenerator(tt.countryCode, tt.bankCode)
+			require.NoError(t, err)
+
+			got := g.Generate(tt.accountNumber)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, Valid(got))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		iban string
+		want bool
+	}{
+		{name: "electronic format", iban: "DE89370400440532013000", want: true},
+		{name: "print format", iban: "GB82 WEST 1234 5698 7654 32", want: true},
+		{name: "lower case", iban: "gb82west12345698765432", want: true},
+		{name: "wrong check digits", iban: "DE88370400440532013000"},
+		{name: "mistyped digit", iban: "DE89370400440532013001"},
+		{name: "swapped digits", iban: "DE89370400440523013000"},
+		{name: "no bban", iban: "DE89"},
+		{name: "not alphanumeric", iban: "DE89-3704-0044-0532-0130-00"},
+		{name: "too long", iban: "DE89370400440532013000370400440532013000"},
+		{name: "empty"},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Valid(tt.iban))
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGenerator("DE", "37040044")
+	require.NoError(t, err)
+
+	account := storage.Account{
+		AccountID:     uuid.MustParse("12345678-1234-1234-1234-123456789001"),
+		AccountNumber: 532013000,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		store := storageMocks.NewMockIBANStore(t)
+		store.EXPECT().ListAccountsWithoutIBAN(mock.Anything, int32(assignBatchSize)).
+			Return([]storage.Account{account}, nil).Once()
+		store.EXPECT().SetAccountIBAN(mock.Anything, storage.SetAccountIBANParams{
+			AccountID: account.AccountID,
+			IBAN:      pgtype.Text{String: "DE89370400440532013000", Valid: true},
+		}).Return(nil).Once()
+		store.EXPECT().ListAccountsWithoutIBAN(mock.Anything, int32(assignBatchSize)).Return(nil, nil).Once()
+
+		got, err := Assign(context.Background(), store, g)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got)
+	})
+
+	t.Run("failed when the iban fails to be set", func(t *testing.T) {
+		t.Parallel()
+
+		store := storageMocks.NewMockIBANStore(t)
+		store.EXPECT().ListAccountsWithoutIBAN(mock.Anything, int32(assignBatchSize)).
+			Return([]storage.Account{account}, nil).Once()
+		store.EXPECT().SetAccountIBAN(mock.Anything, mock.Anything).Return(errAnything).Once()
+
+		got, err := Assign(context.Background(), store, g)
+		require.ErrorIs(t, err, errAnything)
+		assert.Equal(t, 0, got)
+	})
+}
@@ -0,0 +1,270 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// DefaultLease is how long a key stays claimed by a request without a response, after which it can be claimed
+	// again, e.g. when the process serving the request crashed.
+	DefaultLease = time.Minute
+	// DefaultTTL is how long a key is kept, after which it can be used again.
+	DefaultTTL = 24 * time.Hour
+
+	cleanupInterval = time.Hour
+)
+
+var errInvalidKey = errors.New("idempotency key must not be longer than 255 characters")
+
+type Middleware struct {
+	logger logger.Logger
+	store  storage.IdempotencyStore
+	lease  time.Duration
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// New returns a new Middleware.
+func New(store storage.IdempotencyStore, logger logger.Logger) *Middleware {
+	return &Middleware{
+		logger: logger,
+		store:  store,
+		lease:  DefaultLease,
+		ttl:    DefaultTTL,
+		now:    time.Now,
+	}
+}
+
+// Handler makes requests carrying an Idempotency-Key header safe to retry.
+// The first response to a key is stored and replayed for later requests of the same principal with the same key,
+// unless it was a server error, in which case the request can be retried. Keys are kept for the TTL of the middleware.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderIdempotencyKey)
+		if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			writeError(w, errInvalidKey, http.StatusBadRequest)
+
+			return
+		}
+
+		hash, err := requestHash(r)
+		if err != nil {
+			m.logger.ErrorContext(r.Context(), "failed to read request", "error", err)
+			writeError(w, types.ErrInternal, http.StatusInternalServerError)
+
+			return
+		}
+
+		ctx := r.Context()
+		principal := audit.ActorFromContext(ctx).Principal
+		now := m.now()
+
+		claimed, err := m.store.ClaimIdempotencyKey(ctx, storage.ClaimIdempotencyKeyParams{
+			Principal:     principal,
+			Key:           key,
+			RequestHash:   hash,
+			ClaimedBefore: pgtype.Timestamptz{Time: now.Add(-m.lease), Valid: true},
+			ExpiredBefore: pgtype.Timestamptz{Time: now.Add(-m.ttl), Valid: true},
+		})
+		if err != nil {
+			m.logger.ErrorContext(ctx, "failed to claim idempotency key", "error", err)
+			writeError(w, types.ErrInternal, http.StatusInternalServerError)
+
+			return
+		}
+
+		if claimed == 0 {
+			m.replay(w, r, principal, key, hash)
+
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		m.save(r, principal, key, rec)
+	})
+}
+
+// Run deletes the expired keys every hour until ctx is done.
+func (m *Middleware) Run(ctx context.Context) error {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			m.logger.ErrorContext(ctx, "failed to delete expired idempotency keys", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteExpired deletes the keys older than the TTL.
+func (m *Middleware) DeleteExpired(ctx context.Context) error {
+	deleted, err := m.store.DeleteExpiredIdempotencyKeys(ctx,
+		pgtype.Timestamptz{Time: m.now().Add(-m.ttl), Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	if deleted > 0 {
+		m.logger.InfoContext(ctx, "deleted expired idempotency keys", "count", deleted)
+	}
+
+	return nil
+}
+
+func (m *Middleware) replay(w http.ResponseWriter, r *http.Request, principal, key string, hash []byte) {
+	stored, err := m.store.GetIdempotencyKey(r.Context(), storage.GetIdempotencyKeyParams{
+		Principal: principal,
+		Key:       key,
+	})
+	if err != nil {
+		m.logger.ErrorContext(r.Context(), "failed to get idempotency key", "error", err)
+		writeError(w, types.ErrInternal, http.StatusInternalServerError)
+
+		return
+	}
+
+	if !bytes.Equal(stored.RequestHash, hash) {
+		writeError(w, types.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity)
+
+		return
+	}
+
+	if !stored.StatusCode.Valid {
+		// The request can be retried once the claim of the key is over.
+		retryAfter := stored.CreatedAt.Time.Add(m.lease).Sub(m.now())
+		w.Header().Set("Retry-After", strconv.Itoa(max(1, int(retryAfter.Round(time.Second).Seconds()))))
+		writeError(w, types.ErrIdempotencyKeyInUse, http.StatusConflict)
+
+		return
+	}
+
+	if stored.ContentType.Valid {
+		w.Header().Set("Content-Type", stored.ContentType.String)
+	}
+
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(int(stored.StatusCode.Int32))
+	_, _ = w.Write(stored.ResponseBody)
+}
+
+func (m *Middleware) save(r *http.Request, principal, key string, rec *recorder) {
+	// The context of the request may be canceled by now, the outcome must be recorded regardless.
+	ctx := context.WithoutCancel(r.Context())
+
+	if rec.statusCode >= http.StatusInternalServerError {
+		if err := m.store.DeleteIdempotencyKey(ctx, storage.DeleteIdempotencyKeyParams{
+			Principal: principal,
+			Key:       key,
+		}); err != nil {
+			m.logger.ErrorContext(ctx, "failed to release idempotency key", "error", err)
+		}
+
+		return
+	}
+
+	contentType := rec.Header().Get("Content-Type")
+
+	if err := m.store.SaveIdempotencyKeyResponse(ctx, storage.SaveIdempotencyKeyResponseParams{
+		Principal:    principal,
+		Key:          key,
+		StatusCode:   pgtype.Int4{Int32: int32(rec.statusCode), Valid: true}, //nolint:gosec // status codes fit in int32.
+		ContentType:  pgtype.Text{String: contentType, Valid: contentType != ""},
+		ResponseBody: rec.body.Bytes(),
+	}); err != nil {
+		m.logger.ErrorContext(ctx, "failed to save idempotency key response", "error", err)
+	}
+}
+
+// requestHash fingerprints a request so a key cannot be reused for a different request.
+func requestHash(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	_, _ = h.Write(body)
+
+	return h.Sum(nil), nil
+}
+
+func writeError(w http.ResponseWriter, err error, code int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+
+	jsonErr := types.Error{Message: err.Error(), Code: types.ErrorCode(err)}
+	if errors.Is(err, types.ErrInternal) {
+		jsonErr.Message = "internal server error"
+	}
+
+	if err := json.NewEncoder(w).Encode(jsonErr); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// recorder passes a response through while keeping a copy of it.
+type recorder struct {
+	http.ResponseWriter
+	body        bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+
+	n, err := r.ResponseWriter.Write(b)
+	if err != nil {
+		return n, fmt.Errorf("failed to write response: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,254 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+)
+
+const (
+	testKey       = "key"
+	testBody      = `{"amount":100}`
+	testPrincipal = "12345678-1234-1234-1234-123456789001"
+)
+
+var (
+	errAnything = errors.New("any")
+	testNow     = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	wantKeyParams = storage.GetIdempotencyKeyParams{Principal: testPrincipal, Key: testKey}
+)
+
+func newTestMiddleware(store storage.IdempotencyStore) *Middleware {
+	m := New(store, slog.Default())
+	m.now = func() time.Time { return testNow }
+
+	return m
+}
+
+func TestMiddleware_Handler(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(testBody))
+
+	wantHash, err := requestHash(r)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		key            string
+		nextStatusCode int
+		mock           func(*storageMocks.MockIdempotencyStore)
+		wantCalled     bool
+		wantStatusCode int
+		wantReplayed   bool
+		wantRetryAfter string
+		want           string
+	}{
+		{
+			name:           "passes through requests without a key",
+			nextStatusCode: http.StatusOK,
+			wantCalled:     true,
+			wantStatusCode: http.StatusOK,
+			want:           `{"id":"1"}`,
+		},
+		{
+			name:           "failed when key is too long",
+			key:            strings.Repeat("k", maxKeyLength+1),
+			wantStatusCode: http.StatusBadRequest,
+			want: `{"message":"idempotency key must not be longer than 255 characters"}
+`,
+		},
+		{
+			name: "failed when claiming the key returns an error",
+			key:  testKey,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything).Return(0, errAnything).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			want: `{"message":"internal server error","code":"INTERNAL"}
+`,
+		},
+		{
+			name:           "stores the response of the first request",
+			key:            testKey,
+			nextStatusCode: http.StatusOK,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, storage.ClaimIdempotencyKeyParams{
+					Principal:     testPrincipal,
+					Key:           testKey,
+					RequestHash:   wantHash,
+					ClaimedBefore: pgtype.Timestamptz{Time: testNow.Add(-DefaultLease), Valid: true},
+					ExpiredBefore: pgtype.Timestamptz{Time: testNow.Add(-DefaultTTL), Valid: true},
+				}).Return(1, nil).Once()
+				m.EXPECT().SaveIdempotencyKeyResponse(mock.Anything, storage.SaveIdempotencyKeyResponseParams{
+					Principal:    testPrincipal,
+					Key:          testKey,
+					StatusCode:   pgtype.Int4{Int32: http.StatusOK, Valid: true},
+					ContentType:  pgtype.Text{String: "application/json", Valid: true},
+					ResponseBody: []byte(`{"id":"1"}`),
+				}).Return(nil).Once()
+			},
+			wantCalled:     true,
+			wantStatusCode: http.StatusOK,
+			want:           `{"id":"1"}`,
+		},
+		{
+			name:           "releases the key when the first request fails",
+			key:            testKey,
+			nextStatusCode: http.StatusInternalServerError,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything).Return(1, nil).Once()
+				m.EXPECT().DeleteIdempotencyKey(mock.Anything, storage.DeleteIdempotencyKeyParams{
+					Principal: testPrincipal, Key: testKey,
+				}).Return(nil).Once()
+			},
+			wantCalled:     true,
+			wantStatusCode: http.StatusInternalServerError,
+			want:           `{"id":"1"}`,
+		},
+		{
+			name: "replays the stored response",
+			key:  testKey,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything).Return(0, nil).Once()
+				m.EXPECT().GetIdempotencyKey(mock.Anything, wantKeyParams).Return(storage.IdempotencyKey{
+					Key:          testKey,
+					RequestHash:  wantHash,
+					StatusCode:   pgtype.Int4{Int32: http.StatusOK, Valid: true},
+					ContentType:  pgtype.Text{String: "application/json", Valid: true},
+					ResponseBody: []byte(`{"id":"1"}`),
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantReplayed:   true,
+			want:           `{"id":"1"}`,
+		},
+		{
+			name: "failed when the first request is in progress",
+			key:  testKey,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything).Return(0, nil).Once()
+				m.EXPECT().GetIdempotencyKey(mock.Anything, wantKeyParams).Return(storage.IdempotencyKey{
+					Principal:   testPrincipal,
+					Key:         testKey,
+					RequestHash: wantHash,
+					CreatedAt:   pgtype.Timestamptz{Time: testNow.Add(-20 * time.Second), Valid: true},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusConflict,
+			wantRetryAfter: "40",
+			want: `{"message":"a request with the same idempotency key is in progress","code":"IDEMPOTENCY_KEY_IN_USE"}
+`,
+		},
+		{
+			name: "failed when the key was used for a different request",
+			key:  testKey,
+			mock: func(m *storageMocks.MockIdempotencyStore) {
+				m.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything).Return(0, nil).Once()
+				m.EXPECT().GetIdempotencyKey(mock.Anything, wantKeyParams).Return(storage.IdempotencyKey{
+					Key:         testKey,
+					RequestHash: []byte("other"),
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			want: `{"message":"idempotency key was used for a different request","code":"IDEMPOTENCY_KEY_REUSED"}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockIdempotencyStore(t)
+			if tt.mock != nil {
+				tt.mock(store)
+			}
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, testBody, string(body))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.nextStatusCode)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(testBody))
+			r = r.WithContext(audit.ContextWithActor(r.Context(), audit.Actor{Principal: testPrincipal}))
+
+			if tt.key != "" {
+				r.Header.Set(HeaderIdempotencyKey, tt.key)
+			}
+
+			w := httptest.NewRecorder()
+
+			newTestMiddleware(store).Handler(next).ServeHTTP(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Equal(t, tt.wantReplayed, res.Header.Get(HeaderReplayed) == "true")
+			assert.Equal(t, tt.wantRetryAfter, res.Header.Get("Retry-After"))
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMiddleware_DeleteExpired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when the keys cannot be deleted",
+			err:     errAnything,
+			wantErr: errAnything,
+		},
+		{
+			name: "success",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockIdempotencyStore(t)
+			store.EXPECT().DeleteExpiredIdempotencyKeys(mock.Anything,
+				pgtype.Timestamptz{Time: testNow.Add(-DefaultTTL), Valid: true}).Return(2, tt.err).Once()
+
+			err := newTestMiddleware(store).DeleteExpired(context.Background())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package interest
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	daysPerYearACT365   = 365
+	daysPerYear30360    = 360
+	daysPerMonth30360   = 30
+	lastDayOfMonth30360 = 31
+)
+
+// dayFraction returns the fraction of a year a day counts for in a day count convention: 1/365 for ACT/365, and
+// 1/360 for 30/360 but for the 31st of a month, which counts for nothing, and the last day of February, which counts
+// for the days up to the 30th.
+func dayFraction(dayCount string, day time.Time) *big.Rat {
+	if dayCount != types.DayCount30360 {
+		return big.NewRat(1, daysPerYearACT365)
+	}
+
+	days := 1
+
+	switch {
+	case day.Day() == lastDayOfMonth30360:
+		days = 0
+	case day.AddDate(0, 0, 1).Month() != day.Month():
+		days = daysPerMonth30360 - day.Day() + 1
+	}
+
+	return big.NewRat(int64(days), daysPerYear30360)
+}
@@ -0,0 +1,67 @@
+package interest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestDayFraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dayCount string
+		day      time.Time
+		want     *big.Rat
+	}{
+		{
+			name:     "ACT/365 on the 31st",
+			dayCount: types.DayCountACT365,
+			day:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:     big.NewRat(1, 365),
+		},
+		{
+			name:     "30/360 on a day",
+			dayCount: types.DayCount30360,
+			day:      time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			want:     big.NewRat(1, 360),
+		},
+		{
+			name:     "30/360 on the 31st",
+			dayCount: types.DayCount30360,
+			day:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:     new(big.Rat),
+		},
+		{
+			name:     "30/360 on the 30th of a 30 days month",
+			dayCount: types.DayCount30360,
+			day:      time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+			want:     big.NewRat(1, 360),
+		},
+		{
+			name:     "30/360 on the last day of February of a leap year",
+			dayCount: types.DayCount30360,
+			day:      time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			want:     big.NewRat(2, 360),
+		},
+		{
+			name:     "30/360 on the last day of February",
+			dayCount: types.DayCount30360,
+			day:      time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC),
+			want:     big.NewRat(3, 360),
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, 0, tt.want.Cmp(dayFraction(tt.dayCount, tt.day)))
+		})
+	}
+}
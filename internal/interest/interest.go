@@ -0,0 +1,308 @@
+// Package interest accrues the interest of the positive balances of accounts every day, at the interest rate and
+// following the day count convention of their product, and capitalizes the interest accrued in a month at its end.
+// Interest is computed with exact decimal arithmetic, and only rounded to the minor unit when capitalized.
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	// accrualScale is the number of decimal places the interest accrued in a day is stored with.
+	accrualScale = 10
+
+	defaultInterval = time.Hour
+	day             = 24 * time.Hour
+)
+
+// Auditor records the interest capitalized within its transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.InterestStore
+	storeWithTx func(tx pgx.Tx) storage.InterestStore
+	auditor     Auditor
+	holders     Holders
+	logger      logger.Logger
+	interval    time.Duration
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(
+	conn storage.DBConnection,
+	store storage.InterestStore,
+	auditor Auditor,
+	holders Holders,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.InterestStoreWithTx,
+		auditor:     auditor,
+		holders:     holders,
+		logger:      logger,
+		interval:    defaultInterval,
+		now:         time.Now,
+	}
+}
+
+// Run processes the previous day, in UTC, every interval until ctx is done, see Process.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		yesterday := s.now().UTC().Truncate(day).Add(-day)
+
+		if err := s.Process(ctx, yesterday); err != nil && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "failed to process interest", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process accrues the interest of a day, in UTC, then capitalizes the interest accrued until the end of its month
+// when it is the last day of the month, along with the interest of the previous months which was not capitalized.
+// Processing a day again only accrues the interest of the accounts which were not accrued yet.
+func (s *Service) Process(ctx context.Context, date time.Time) error {
+	date = date.UTC().Truncate(day)
+
+	if err := s.Accrue(ctx, date); err != nil {
+		return err
+	}
+
+	next := date.Add(day)
+
+	return s.Capitalize(ctx, time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Accrue accrues the interest of a day, in UTC, on the balances at its end of the accounts whose product bears
+// interest and which were not accrued for it yet.
+func (s *Service) Accrue(ctx context.Context, date time.Time) error {
+	date = date.UTC().Truncate(day)
+
+	accounts, err := s.store.ListAccruingAccounts(ctx, storage.ListAccruingAccountsParams{
+		DayEnd: pgtype.Timestamptz{Time: date.Add(day), Valid: true},
+		Day:    pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list accruing accounts: %w", err)
+	}
+
+	var errs []error
+
+	for _, account := range accounts {
+		if _, err := s.store.AddInterestAccrual(ctx, storage.AddInterestAccrualParams{
+			AccountID:    account.AccountID,
+			Day:          pgtype.Date{Time: date, Valid: true},
+			ProductCode:  account.ProductCode,
+			Balance:      account.Balance,
+			InterestRate: account.InterestRate,
+			DayCount:     account.DayCount,
+			Amount:       storage.NumericFromRat(accrue(account, date), accrualScale),
+			CreatedAt:    pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to accrue interest of account %s: %w", account.AccountID, err))
+		}
+	}
+
+	if len(accounts) > 0 {
+		s.logger.InfoContext(ctx, "interest accrued", "day", date.Format(time.DateOnly), "accounts", len(accounts))
+	}
+
+	return errors.Join(errs...)
+}
+
+// accrue returns the interest of a day on the balance of an account: balance × rate × the fraction of the year the
+// day counts for.
+func accrue(account storage.ListAccruingAccountsRow, date time.Time) *big.Rat {
+	interest := new(big.Rat).Mul(storage.RatFromNumeric(account.Balance), storage.RatFromNumeric(account.InterestRate))
+
+	return interest.Mul(interest, dayFraction(account.DayCount, date))
+}
+
+// Capitalize books the interest accrued before a day, in UTC, which was not capitalized yet as a transaction to each
+// account, rounded half up to the minor unit. Interest rounding to zero is left to be capitalized with that of the
+// next month.
+func (s *Service) Capitalize(ctx context.Context, before time.Time) error {
+	date := pgtype.Date{Time: before.UTC().Truncate(day), Valid: true}
+
+	accounts, err := s.store.ListUncapitalizedAccounts(ctx, date)
+	if err != nil {
+		return fmt.Errorf("failed to list accounts with uncapitalized interest: %w", err)
+	}
+
+	var errs []error
+
+	for _, account := range accounts {
+		if err := s.capitalize(ctx, account.AccountID, account.CurrencyCode, date); err != nil {
+			errs = append(errs, fmt.Errorf("failed to capitalize interest of account %s: %w", account.AccountID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// capitalize books the interest of an account accrued before a day within a transaction, which locks the accruals so
+// that they are capitalized once. The interest is rounded to the minor unit of the currency of the account.
+func (s *Service) capitalize(ctx context.Context, accountID uuid.UUID, currencyCode string, before pgtype.Date) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	store := s.storeWithTx(tx)
+
+	accruals, err := store.LockUncapitalizedInterest(ctx, storage.LockUncapitalizedInterestParams{
+		AccountID: accountID,
+		Before:    before,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock interest accruals: %w", err)
+	}
+
+	amount := sum(accruals, currencyCode)
+
+	// Another instance capitalized the interest meanwhile, or it is less than the minor unit.
+	if amount.Int.Sign() == 0 {
+		return nil
+	}
+
+	t, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: accountID,
+		Amount:    amount,
+		Type:      types.TransactionTypeInterest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add transaction: %w", err)
+	}
+
+	if err := store.CapitalizeInterest(ctx, storage.CapitalizeInterestParams{
+		TransactionID: uuid.NullUUID{UUID: t.TransactionID, Valid: true},
+		AccountID:     accountID,
+		Before:        before,
+	}); err != nil {
+		return fmt.Errorf("failed to capitalize interest accruals: %w", err)
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    audit.ActionCapitalizeInterest,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After: types.Transaction{
+			ID:        t.TransactionID,
+			AccountID: accountID,
+			Amount:    storage.AmountFromNumeric(amount, currencyCode),
+			Type:      types.TransactionTypeInterest,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "interest capitalized", "account_id", accountID, "transaction_id", t.TransactionID,
+		"amount", storage.AmountFromNumeric(amount, currencyCode))
+
+	return nil
+}
+
+// ListAccruals lists the interest accrued on the balance of an account, latest first, for the holders permitted to
+// view it.
+// returns ListInterestAccrualsResponse.
+func (s *Service) ListAccruals(
+	ctx context.Context,
+	accountID uuid.UUID,
+	limit, offset int32,
+) (types.ListInterestAccrualsResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.ListInterestAccrualsResponse{}, err
+	}
+
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ListInterestAccrualsResponse{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get account", "error", err)
+
+		return types.ListInterestAccrualsResponse{}, types.ErrInternal
+	}
+
+	accruals, err := s.store.ListInterestAccruals(ctx, storage.ListInterestAccrualsParams{
+		AccountID: accountID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list interest accruals", "error", err)
+
+		return types.ListInterestAccrualsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListInterestAccrualsResponse{
+		Accruals: make([]types.InterestAccrual, 0, len(accruals)),
+	}
+
+	for _, a := range accruals {
+		res.Accruals = append(res.Accruals, toAccrual(a, account.CurrencyCode))
+	}
+
+	return res, nil
+}
+
+func toAccrual(a storage.InterestAccrual, currencyCode string) types.InterestAccrual {
+	return types.InterestAccrual{
+		Day:           a.Day.Time.Format(time.DateOnly),
+		ProductCode:   a.ProductCode,
+		Balance:       storage.AmountFromNumeric(a.Balance, currencyCode),
+		InterestRate:  storage.DecimalFromNumeric(a.InterestRate),
+		DayCount:      a.DayCount,
+		Amount:        storage.DecimalFromNumeric(a.Amount),
+		TransactionID: a.TransactionID,
+		CreatedAt:     a.CreatedAt.Time,
+	}
+}
+
+// sum returns the sum of interest accruals, rounded to the minor unit of a currency.
+func sum(accruals []pgtype.Numeric, currencyCode string) pgtype.Numeric {
+	total := new(big.Rat)
+	for _, amount := range accruals {
+		total.Add(total, storage.RatFromNumeric(amount))
+	}
+
+	return storage.NumericFromRat(total, storage.MinorUnitScale(currencyCode))
+}
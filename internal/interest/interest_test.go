@@ -0,0 +1,347 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantNow           = time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	wantDay           = time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	wantMonthEnd      = pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	wantRate          = pgtype.Numeric{Int: big.NewInt(5), Exp: -2, Valid: true}
+	errAnything       = errors.New("any")
+
+	wantListParams = storage.ListAccruingAccountsParams{
+		DayEnd: pgtype.Timestamptz{Time: wantDay.Add(day), Valid: true},
+		Day:    pgtype.Date{Time: wantDay, Valid: true},
+	}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func newTestService(conn storage.DBConnection, store storage.InterestStore) *Service {
+	s := New(conn, store, nil, holdertest.Allow(), slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.InterestStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func numeric(n int64, exp int32) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(n), Exp: exp, Valid: true}
+}
+
+func TestService_Accrue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dayCount string
+		want     pgtype.Numeric
+		err      error
+	}{
+		{
+			// 5% a year of 1000.00 is 0.136986301369... a day.
+			name:     "success with ACT/365",
+			dayCount: types.DayCountACT365,
+			want:     numeric(1369863014, -accrualScale),
+		},
+		{
+			name:     "success with 30/360 on the 31st",
+			dayCount: types.DayCount30360,
+			want:     numeric(0, -accrualScale),
+		},
+		{
+			name:     "failed when the accrual cannot be added",
+			dayCount: types.DayCountACT365,
+			want:     numeric(1369863014, -accrualScale),
+			err:      errAnything,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockInterestStore(t)
+			store.EXPECT().ListAccruingAccounts(mock.Anything, wantListParams).Return(
+				[]storage.ListAccruingAccountsRow{{
+					AccountID:    wantAccountID,
+					ProductCode:  "savings",
+					InterestRate: wantRate,
+					DayCount:     tt.dayCount,
+					Balance:      storage.NumericFromAmount(100000, "EUR"),
+				}}, nil).Once()
+			store.EXPECT().AddInterestAccrual(mock.Anything, storage.AddInterestAccrualParams{
+				AccountID:    wantAccountID,
+				Day:          pgtype.Date{Time: wantDay, Valid: true},
+				ProductCode:  "savings",
+				Balance:      storage.NumericFromAmount(100000, "EUR"),
+				InterestRate: wantRate,
+				DayCount:     tt.dayCount,
+				Amount:       tt.want,
+				CreatedAt:    pgtype.Timestamptz{Time: wantNow, Valid: true},
+			}).Return(1, tt.err).Once()
+
+			err := newTestService(nil, store).Accrue(context.Background(), wantDay.Add(time.Hour))
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_Capitalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		currencyCode string
+		accruals     []pgtype.Numeric
+		want         pgtype.Numeric
+		err          error
+		wantErr      bool
+	}{
+		{
+			name:         "success when the interest rounds up to the minor unit",
+			currencyCode: "EUR",
+			accruals:     []pgtype.Numeric{numeric(25, -4), numeric(25, -4)},
+			want:         numeric(1, -2),
+		},
+		{
+			name:         "success when the interest rounds up to the minor unit of a currency without decimals",
+			currencyCode: "JPY",
+			accruals:     []pgtype.Numeric{numeric(25, -2), numeric(25, -2)},
+			want:         numeric(1, 0),
+		},
+		{
+			name:         "success when the interest rounds to zero",
+			currencyCode: "EUR",
+			accruals:     []pgtype.Numeric{numeric(20, -4)},
+		},
+		{
+			name:         "success when another instance capitalized the interest meanwhile",
+			currencyCode: "EUR",
+		},
+		{
+			name:         "failed when the accruals cannot be capitalized",
+			currencyCode: "EUR",
+			accruals:     []pgtype.Numeric{numeric(1369863014, -accrualScale)},
+			want:         numeric(14, -2),
+			err:          errAnything,
+			wantErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockInterestStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			store.EXPECT().ListUncapitalizedAccounts(mock.Anything, wantMonthEnd).Return(
+				[]storage.ListUncapitalizedAccountsRow{{AccountID: wantAccountID, CurrencyCode: tt.currencyCode}}, nil,
+			).Once()
+			conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+			tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			store.EXPECT().LockUncapitalizedInterest(mock.Anything, storage.LockUncapitalizedInterestParams{
+				AccountID: wantAccountID,
+				Before:    wantMonthEnd,
+			}).Return(tt.accruals, nil).Once()
+
+			if tt.want.Valid {
+				store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+					AccountID: wantAccountID,
+					Amount:    tt.want,
+					Type:      types.TransactionTypeInterest,
+				}).Return(storage.Transaction{TransactionID: wantTransactionID}, nil).Once()
+				store.EXPECT().CapitalizeInterest(mock.Anything, storage.CapitalizeInterestParams{
+					TransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+					AccountID:     wantAccountID,
+					Before:        wantMonthEnd,
+				}).Return(tt.err).Once()
+			}
+
+			if tt.want.Valid && !tt.wantErr {
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			}
+
+			s := newTestService(conn, store)
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionCapitalizeInterest, event.Action)
+				assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+				return nil
+			})
+
+			err := s.Capitalize(context.Background(), wantMonthEnd.Time)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Process(t *testing.T) {
+	t.Parallel()
+
+	t.Run("capitalizes at the end of the month", func(t *testing.T) {
+		t.Parallel()
+
+		store := storageMocks.NewMockInterestStore(t)
+		store.EXPECT().ListAccruingAccounts(mock.Anything, wantListParams).Return(nil, nil).Once()
+		store.EXPECT().ListUncapitalizedAccounts(mock.Anything, wantMonthEnd).Return(nil, nil).Once()
+
+		assert.NoError(t, newTestService(nil, store).Process(context.Background(), wantDay))
+	})
+
+	t.Run("capitalizes the previous months within the month", func(t *testing.T) {
+		t.Parallel()
+
+		date := wantDay.AddDate(0, 0, -1)
+
+		store := storageMocks.NewMockInterestStore(t)
+		store.EXPECT().ListAccruingAccounts(mock.Anything, storage.ListAccruingAccountsParams{
+			DayEnd: pgtype.Timestamptz{Time: wantDay, Valid: true},
+			Day:    pgtype.Date{Time: date, Valid: true},
+		}).Return(nil, nil).Once()
+		store.EXPECT().ListUncapitalizedAccounts(mock.Anything, pgtype.Date{
+			Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true,
+		}).Return(nil, nil).Once()
+
+		assert.NoError(t, newTestService(nil, store).Process(context.Background(), date))
+	})
+
+	t.Run("failed when the accounts cannot be listed", func(t *testing.T) {
+		t.Parallel()
+
+		store := storageMocks.NewMockInterestStore(t)
+		store.EXPECT().ListAccruingAccounts(mock.Anything, wantListParams).Return(nil, errAnything).Once()
+
+		assert.ErrorIs(t, newTestService(nil, store).Process(context.Background(), wantDay), errAnything)
+	})
+}
+
+func TestService_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store := storageMocks.NewMockInterestStore(t)
+	store.EXPECT().ListAccruingAccounts(mock.Anything, wantListParams).Return(nil, nil).Once()
+	store.EXPECT().ListUncapitalizedAccounts(mock.Anything, wantMonthEnd).
+		Return(nil, nil).Run(func(context.Context, pgtype.Date) { cancel() }).Once()
+
+	assert.NoError(t, newTestService(nil, store).Run(ctx))
+}
+
+func TestService_ListAccruals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		permitErr error
+		err       error
+		want      types.ListInterestAccrualsResponse
+		wantErr   error
+	}{
+		{
+			name:      "failed when not permitted",
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name:    "failed when the account cannot be fetched",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when account not found",
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "success",
+			want: types.ListInterestAccrualsResponse{
+				Accruals: []types.InterestAccrual{{
+					Day:          "2024-05-31",
+					ProductCode:  "savings",
+					Balance:      100000,
+					InterestRate: "0.05",
+					DayCount:     types.DayCountACT365,
+					Amount:       "0.1369863014",
+					CreatedAt:    wantNow,
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockInterestStore(t)
+
+			if tt.permitErr == nil {
+				store.EXPECT().GetAccount(mock.Anything, wantAccountID).
+					Return(storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}, tt.err).Once()
+			}
+
+			if tt.permitErr == nil && tt.err == nil {
+				store.EXPECT().ListInterestAccruals(mock.Anything, storage.ListInterestAccrualsParams{
+					AccountID: wantAccountID,
+					Limit:     10,
+				}).Return([]storage.InterestAccrual{{
+					AccountID:    wantAccountID,
+					Day:          pgtype.Date{Time: wantDay, Valid: true},
+					ProductCode:  "savings",
+					Balance:      storage.NumericFromAmount(100000, "EUR"),
+					InterestRate: wantRate,
+					DayCount:     types.DayCountACT365,
+					Amount:       numeric(1369863014, -accrualScale),
+					CreatedAt:    pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}}, nil).Once()
+			}
+
+			s := newTestService(nil, store)
+			s.holders = holdertest.Authorize(t, wantAccountID, holder.PermissionView, tt.permitErr)
+
+			got, err := s.ListAccruals(context.Background(), wantAccountID, 10, 0)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
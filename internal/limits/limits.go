@@ -0,0 +1,340 @@
+// Package limits caps the transfers from accounts: the amount of a single transfer, the amounts transferred in a day
+// and in a month, and the number of transfers in a day. Accounts are of a tier, whose limits apply unless the account
+// overrides them.
+package limits
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	// DefaultTier is the tier of the accounts whose tier was not set.
+	DefaultTier = "standard"
+
+	pqErrorForeignKeyViolation = "23503"
+)
+
+// Auditor records the changes of limits within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.LimitStore
+	storeWithTx func(tx pgx.Tx) storage.LimitStore
+	auditor     Auditor
+	holders     Holders
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(
+	conn storage.DBConnection,
+	store storage.LimitStore,
+	auditor Auditor,
+	holders Holders,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.LimitStoreWithTx,
+		auditor:     auditor,
+		holders:     holders,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Check fails with a types.LimitExceededError when transferring amount from an account would exceed one of its limits,
+// given the transfers already made within tx. The account must be locked so that concurrent transfers are counted.
+func (s *Service) Check(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, amount money.Amount) error {
+	store := s.storeWithTx(tx)
+
+	_, limits, err := s.limits(ctx, store, accountID)
+	if err != nil {
+		return err
+	}
+
+	if limits == (types.Limits{}) {
+		return nil
+	}
+
+	usage, err := s.usage(ctx, store, accountID)
+	if err != nil {
+		return err
+	}
+
+	if err := exceeded(limits, usage, amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAccountLimits returns the limits of an account and how much of them is used, for the holders permitted to view it.
+// returns GetAccountLimitsResponse.
+func (s *Service) GetAccountLimits(ctx context.Context, accountID uuid.UUID) (types.GetAccountLimitsResponse, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.GetAccountLimitsResponse{}, err
+	}
+
+	ok, err := s.store.HasAccount(ctx, accountID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to check account", "error", err)
+
+		return types.GetAccountLimitsResponse{}, types.ErrInternal
+	}
+
+	if !ok {
+		return types.GetAccountLimitsResponse{}, types.ErrAccountNotFound
+	}
+
+	tier, limits, err := s.limits(ctx, s.store, accountID)
+	if err != nil {
+		return types.GetAccountLimitsResponse{}, err
+	}
+
+	usage, err := s.usage(ctx, s.store, accountID)
+	if err != nil {
+		return types.GetAccountLimitsResponse{}, err
+	}
+
+	return types.GetAccountLimitsResponse{
+		AccountID: accountID,
+		Tier:      tier,
+		Limits:    limits,
+		Usage:     usage,
+	}, nil
+}
+
+// SetLimitTier sets the limits of a tier, creating it if it does not exist.
+// returns SetLimitTierResponse.
+func (s *Service) SetLimitTier(
+	ctx context.Context,
+	tier string,
+	req *types.SetLimitTierRequest,
+) (types.SetLimitTierResponse, error) {
+	var res types.SetLimitTierResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.LimitStore) error {
+		t, err := store.SetLimitTier(ctx, storage.SetLimitTierParams{
+			Tier:          tier,
+			MaxTransfer:   limitAmount(req.MaxTransfer),
+			DailyAmount:   limitAmount(req.DailyAmount),
+			MonthlyAmount: limitAmount(req.MonthlyAmount),
+			DailyCount:    limitCount(req.DailyCount),
+			UpdatedAt:     pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to set limit tier", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res = types.SetLimitTierResponse{
+			Tier: t.Tier,
+			Limits: types.Limits{
+				MaxTransfer:   storage.AmountFromNumeric(t.MaxTransfer, storage.NoCurrency),
+				DailyAmount:   storage.AmountFromNumeric(t.DailyAmount, storage.NoCurrency),
+				MonthlyAmount: storage.AmountFromNumeric(t.MonthlyAmount, storage.NoCurrency),
+				DailyCount:    t.DailyCount.Int32,
+			},
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:  audit.ActionSetLimitTier,
+			Outcome: audit.OutcomeSuccess,
+			After:   res,
+		})
+	})
+	if err != nil {
+		return types.SetLimitTierResponse{}, err
+	}
+
+	return res, nil
+}
+
+// SetAccountLimits sets the tier of an account and the limits overriding those of its tier.
+// returns SetAccountLimitsResponse.
+func (s *Service) SetAccountLimits(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.SetAccountLimitsRequest,
+) (types.SetAccountLimitsResponse, error) {
+	tier := req.Tier
+	if tier == "" {
+		tier = DefaultTier
+	}
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.LimitStore) error {
+		if err := s.setAccountLimits(ctx, store, accountID, tier, req); err != nil {
+			return err
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionSetAccountLimits,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			After:     types.SetAccountLimitsRequest{Tier: tier, Limits: req.Limits},
+		})
+	})
+	if err != nil {
+		return types.SetAccountLimitsResponse{}, err
+	}
+
+	res, err := s.GetAccountLimits(ctx, accountID)
+	if err != nil {
+		return types.SetAccountLimitsResponse{}, err
+	}
+
+	return types.SetAccountLimitsResponse{GetAccountLimitsResponse: res}, nil
+}
+
+func (s *Service) setAccountLimits(
+	ctx context.Context,
+	store storage.LimitStore,
+	accountID uuid.UUID,
+	tier string,
+	req *types.SetAccountLimitsRequest,
+) error {
+	err := store.SetAccountLimits(ctx, storage.SetAccountLimitsParams{
+		AccountID:     accountID,
+		Tier:          tier,
+		MaxTransfer:   limitAmount(req.MaxTransfer),
+		DailyAmount:   limitAmount(req.DailyAmount),
+		MonthlyAmount: limitAmount(req.MonthlyAmount),
+		DailyCount:    limitCount(req.DailyCount),
+		UpdatedAt:     pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		pgErr := &pgconn.PgError{}
+		if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+			if pgErr.ConstraintName == "account_limit_tier_fkey" {
+				return types.ErrLimitTierNotFound
+			}
+
+			return types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to set account limits", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// limits returns the tier of an account and its limits.
+func (s *Service) limits(
+	ctx context.Context,
+	store storage.LimitStore,
+	accountID uuid.UUID,
+) (string, types.Limits, error) {
+	l, err := store.GetAccountLimits(ctx, accountID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get account limits", "error", err)
+
+		return "", types.Limits{}, types.ErrInternal
+	}
+
+	return l.Tier, types.Limits{
+		MaxTransfer:   storage.AmountFromNumeric(l.MaxTransfer, storage.NoCurrency),
+		DailyAmount:   storage.AmountFromNumeric(l.DailyAmount, storage.NoCurrency),
+		MonthlyAmount: storage.AmountFromNumeric(l.MonthlyAmount, storage.NoCurrency),
+		DailyCount:    l.DailyCount.Int32,
+	}, nil
+}
+
+// usage returns the amounts and the number of the transfers from an account in the current day and month, in UTC.
+func (s *Service) usage(
+	ctx context.Context,
+	store storage.LimitStore,
+	accountID uuid.UUID,
+) (types.LimitUsage, error) {
+	now := s.now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	u, err := store.GetTransferUsage(ctx, storage.GetTransferUsageParams{
+		AccountID:  accountID,
+		DayStart:   pgtype.Timestamptz{Time: dayStart, Valid: true},
+		MonthStart: pgtype.Timestamptz{Time: monthStart, Valid: true},
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get transfer usage", "error", err)
+
+		return types.LimitUsage{}, types.ErrInternal
+	}
+
+	return types.LimitUsage{
+		DailyAmount:   storage.AmountFromNumeric(u.DailyAmount, u.CurrencyCode),
+		MonthlyAmount: storage.AmountFromNumeric(u.MonthlyAmount, u.CurrencyCode),
+		DailyCount:    u.DailyCount,
+	}, nil
+}
+
+// exceeded returns the first limit a transfer of amount exceeds given the usage, if any.
+func exceeded(limits types.Limits, usage types.LimitUsage, amount money.Amount) *types.LimitExceededError {
+	switch {
+	case limits.MaxTransfer > 0 && amount > limits.MaxTransfer:
+		return &types.LimitExceededError{Limit: types.LimitMaxTransfer, Remaining: limits.MaxTransfer}
+	case limits.DailyCount > 0 && usage.DailyCount >= limits.DailyCount:
+		return &types.LimitExceededError{Limit: types.LimitDailyCount, Remaining: 0}
+	case limits.DailyAmount > 0 && usage.DailyAmount+amount > limits.DailyAmount:
+		return &types.LimitExceededError{
+			Limit: types.LimitDailyAmount, Remaining: max(limits.DailyAmount-usage.DailyAmount, 0),
+		}
+	case limits.MonthlyAmount > 0 && usage.MonthlyAmount+amount > limits.MonthlyAmount:
+		return &types.LimitExceededError{
+			Limit: types.LimitMonthlyAmount, Remaining: max(limits.MonthlyAmount-usage.MonthlyAmount, 0),
+		}
+	default:
+		return nil
+	}
+}
+
+// limitAmount returns the stored amount of a limit, null when there is none. Limits apply to the accounts of every
+// currency, in the minor unit of theirs.
+func limitAmount(limit money.Amount) pgtype.Numeric {
+	if limit == 0 {
+		return pgtype.Numeric{}
+	}
+
+	return storage.NumericFromAmount(limit, storage.NoCurrency)
+}
+
+// limitCount returns the stored count of a limit, null when there is none.
+func limitCount(limit int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: limit, Valid: limit != 0}
+}
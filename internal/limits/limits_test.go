@@ -0,0 +1,330 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantNow       = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	errAnything   = errors.New("any")
+
+	// standardLimits are the limits of the standard tier: 100.00 a transfer, 200.00 and 3 transfers a day and
+	// 1000.00 a month.
+	standardLimits = storage.GetAccountLimitsRow{
+		Tier:          DefaultTier,
+		MaxTransfer:   storage.NumericFromAmount(10000, storage.NoCurrency),
+		DailyAmount:   storage.NumericFromAmount(20000, storage.NoCurrency),
+		MonthlyAmount: storage.NumericFromAmount(100000, storage.NoCurrency),
+		DailyCount:    pgtype.Int4{Int32: 3, Valid: true},
+	}
+
+	wantUsageParams = storage.GetTransferUsageParams{
+		AccountID:  wantAccountID,
+		DayStart:   pgtype.Timestamptz{Time: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), Valid: true},
+		MonthStart: pgtype.Timestamptz{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting successful events of action.
+func wantAction(t *testing.T, action string) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+
+		return nil
+	})
+}
+
+func newTestService(conn storage.DBConnection, store storage.LimitStore, auditor Auditor) *Service {
+	s := New(conn, store, auditor, holdertest.Allow(), slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.LimitStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func usage(daily, monthly money.Amount, count int32) storage.GetTransferUsageRow {
+	return storage.GetTransferUsageRow{
+		DailyAmount:   storage.NumericFromAmount(daily, "EUR"),
+		DailyCount:    count,
+		MonthlyAmount: storage.NumericFromAmount(monthly, "EUR"),
+		CurrencyCode:  "EUR",
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		amount  money.Amount
+		limits  storage.GetAccountLimitsRow
+		usage   storage.GetTransferUsageRow
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when the limits cannot be read",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when the amount exceeds the maximum transfer",
+			amount:  10001,
+			limits:  standardLimits,
+			usage:   usage(0, 0, 0),
+			wantErr: &types.LimitExceededError{Limit: types.LimitMaxTransfer, Remaining: 10000},
+		},
+		{
+			name:    "failed when the daily count is reached",
+			amount:  100,
+			limits:  standardLimits,
+			usage:   usage(300, 300, 3),
+			wantErr: &types.LimitExceededError{Limit: types.LimitDailyCount, Remaining: 0},
+		},
+		{
+			name:    "failed when the amount exceeds what is left of the daily amount",
+			amount:  5001,
+			limits:  standardLimits,
+			usage:   usage(15000, 15000, 2),
+			wantErr: &types.LimitExceededError{Limit: types.LimitDailyAmount, Remaining: 5000},
+		},
+		{
+			name:    "failed when the amount exceeds what is left of the monthly amount",
+			amount:  1000,
+			limits:  standardLimits,
+			usage:   usage(0, 99500, 0),
+			wantErr: &types.LimitExceededError{Limit: types.LimitMonthlyAmount, Remaining: 500},
+		},
+		{
+			name:   "success when the amount uses up the daily amount",
+			amount: 5000,
+			limits: standardLimits,
+			usage:  usage(15000, 15000, 2),
+		},
+		{
+			name:   "success when the account has no limits",
+			amount: 1000000,
+			limits: storage.GetAccountLimitsRow{Tier: "unlimited"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockLimitStore(t)
+			store.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(tt.limits, tt.err).Once()
+
+			if tt.limits.MaxTransfer.Valid {
+				store.EXPECT().GetTransferUsage(mock.Anything, wantUsageParams).Return(tt.usage, nil).Once()
+			}
+
+			err := newTestService(nil, store, nil).Check(context.Background(), nil, wantAccountID, tt.amount)
+
+			if limitErr := (&types.LimitExceededError{}); errors.As(tt.wantErr, &limitErr) {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_GetAccountLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mock      func(*storageMocks.MockLimitStore)
+		permitErr error
+		want      types.GetAccountLimitsResponse
+		wantErr   error
+	}{
+		{
+			name:      "failed when not permitted",
+			mock:      func(*storageMocks.MockLimitStore) {},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name: "failed when account not found",
+			mock: func(ms *storageMocks.MockLimitStore) {
+				ms.EXPECT().HasAccount(mock.Anything, wantAccountID).Return(false, nil).Once()
+			},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "failed when the usage cannot be read",
+			mock: func(ms *storageMocks.MockLimitStore) {
+				ms.EXPECT().HasAccount(mock.Anything, wantAccountID).Return(true, nil).Once()
+				ms.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(standardLimits, nil).Once()
+				ms.EXPECT().GetTransferUsage(mock.Anything, wantUsageParams).
+					Return(storage.GetTransferUsageRow{}, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockLimitStore) {
+				ms.EXPECT().HasAccount(mock.Anything, wantAccountID).Return(true, nil).Once()
+				ms.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(standardLimits, nil).Once()
+				ms.EXPECT().GetTransferUsage(mock.Anything, wantUsageParams).Return(usage(500, 1500, 2), nil).Once()
+			},
+			want: types.GetAccountLimitsResponse{
+				AccountID: wantAccountID,
+				Tier:      DefaultTier,
+				Limits:    types.Limits{MaxTransfer: 10000, DailyAmount: 20000, MonthlyAmount: 100000, DailyCount: 3},
+				Usage:     types.LimitUsage{DailyAmount: 500, MonthlyAmount: 1500, DailyCount: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockLimitStore(t)
+			tt.mock(store)
+
+			s := newTestService(nil, store, nil)
+			s.holders = holdertest.Authorize(t, wantAccountID, holder.PermissionView, tt.permitErr)
+
+			got, err := s.GetAccountLimits(context.Background(), wantAccountID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SetAccountLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *types.SetAccountLimitsRequest
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "failed when tier not found",
+			req:     &types.SetAccountLimitsRequest{Tier: "gold"},
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: "account_limit_tier_fkey"},
+			wantErr: types.ErrLimitTierNotFound,
+		},
+		{
+			name:    "failed when account not found",
+			req:     &types.SetAccountLimitsRequest{Tier: "gold"},
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation, ConstraintName: "account_limit_account_id_fkey"},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when the store fails",
+			req:     &types.SetAccountLimitsRequest{Tier: "gold"},
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockLimitStore(t)
+			store.EXPECT().SetAccountLimits(mock.Anything, mock.Anything).Return(tt.err).Once()
+
+			s := newTestService(storagetest.NewConn(t, false), store, nil)
+
+			_, err := s.SetAccountLimits(context.Background(), wantAccountID, tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_SetAccountLimits_defaultTier(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockLimitStore(t)
+	store.EXPECT().SetAccountLimits(mock.Anything, storage.SetAccountLimitsParams{
+		AccountID:  wantAccountID,
+		Tier:       DefaultTier,
+		DailyCount: pgtype.Int4{Int32: 10, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}).Return(nil).Once()
+	store.EXPECT().HasAccount(mock.Anything, wantAccountID).Return(true, nil).Once()
+	store.EXPECT().GetAccountLimits(mock.Anything, wantAccountID).Return(storage.GetAccountLimitsRow{
+		Tier:       DefaultTier,
+		DailyCount: pgtype.Int4{Int32: 10, Valid: true},
+	}, nil).Once()
+	store.EXPECT().GetTransferUsage(mock.Anything, wantUsageParams).Return(usage(0, 0, 0), nil).Once()
+
+	s := newTestService(storagetest.NewConn(t, true), store, wantAction(t, audit.ActionSetAccountLimits))
+
+	got, err := s.SetAccountLimits(context.Background(), wantAccountID,
+		&types.SetAccountLimitsRequest{Limits: types.Limits{DailyCount: 10}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.SetAccountLimitsResponse{
+		GetAccountLimitsResponse: types.GetAccountLimitsResponse{
+			AccountID: wantAccountID,
+			Tier:      DefaultTier,
+			Limits:    types.Limits{DailyCount: 10},
+		},
+	}, got)
+}
+
+func TestService_SetLimitTier(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockLimitStore(t)
+	store.EXPECT().SetLimitTier(mock.Anything, storage.SetLimitTierParams{
+		Tier:        "gold",
+		MaxTransfer: storage.NumericFromAmount(500000, storage.NoCurrency),
+		UpdatedAt:   pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}).Return(storage.LimitTier{
+		Tier:        "gold",
+		MaxTransfer: storage.NumericFromAmount(500000, storage.NoCurrency),
+	}, nil).Once()
+
+	s := newTestService(storagetest.NewConn(t, true), store, wantAction(t, audit.ActionSetLimitTier))
+
+	got, err := s.SetLimitTier(context.Background(), "gold",
+		&types.SetLimitTierRequest{Limits: types.Limits{MaxTransfer: 500000}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.SetLimitTierResponse{Tier: "gold", Limits: types.Limits{MaxTransfer: 500000}}, got)
+}
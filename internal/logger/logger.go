@@ -1,8 +1,14 @@
 package logger
 
+import "context"
+
 type Logger interface {
 	Error(msg string, keysAndValues ...any)
 	Info(msg string, keysAndValues ...any)
 	Debug(msg string, keysAndValues ...any)
 	Warn(msg string, keysAndValues ...any)
+	ErrorContext(ctx context.Context, msg string, keysAndValues ...any)
+	InfoContext(ctx context.Context, msg string, keysAndValues ...any)
+	DebugContext(ctx context.Context, msg string, keysAndValues ...any)
+	WarnContext(ctx context.Context, msg string, keysAndValues ...any)
 }
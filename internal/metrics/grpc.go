@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor observes the gRPC calls served, by method, e.g. "/xbankapi.v1.AccountService/GetAccount",
+// and status code.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		inFlight := m.grpcRequestsActive.WithLabelValues(info.FullMethod)
+		inFlight.Inc()
+
+		defer inFlight.Dec()
+
+		start := time.Now()
+
+		res, err := handler(ctx, req)
+
+		code := status.Code(err).String()
+
+		m.grpcRequests.WithLabelValues(info.FullMethod, code).Inc()
+		m.grpcRequestDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+
+		return res, err
+	}
+}
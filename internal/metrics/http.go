@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unmatchedRoute labels requests not matching any route, so unknown paths cannot blow up the cardinality.
+const unmatchedRoute = "unmatched"
+
+// Instrument observes the requests served by next. route returns the pattern matched by a request,
+// e.g. "GET /accounts/{id}", or an empty string when none is matched.
+func (m *Metrics) Instrument(next http.Handler, route func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := routePath(route(r))
+
+		inFlight := m.httpRequestsActive.WithLabelValues(r.Method, path)
+		inFlight.Inc()
+
+		defer inFlight.Dec()
+
+		rec := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		start := time.Now()
+
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.statusCode)
+
+		m.httpRequests.WithLabelValues(r.Method, path, status).Inc()
+		m.httpRequestDuration.WithLabelValues(r.Method, path, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// routePath strips the method from a pattern, e.g. "GET /accounts/{id}" becomes "/accounts/{id}".
+func routePath(pattern string) string {
+	if pattern == "" {
+		return unmatchedRoute
+	}
+
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return path
+	}
+
+	return pattern
+}
+
+// statusRecorder passes a response through while keeping its status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush it.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,145 @@
+// Package metrics collects the Prometheus metrics of xbankAPI.
+package metrics
+
+import (
+	"github.com/Rhymond/go-money"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "xbankapi"
+
+// Metrics records what the service does, it implements api.Metrics.
+type Metrics struct {
+	accountsCreated   prometheus.Counter
+	deposits          prometheus.Counter
+	transfers         prometheus.Counter
+	transfersFailed   *prometheus.CounterVec
+	transferredAmount *prometheus.CounterVec
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	httpRequestsActive  *prometheus.GaugeVec
+
+	grpcRequests        *prometheus.CounterVec
+	grpcRequestDuration *prometheus.HistogramVec
+	grpcRequestsActive  *prometheus.GaugeVec
+}
+
+// NewRegistry returns a registry collecting the Go runtime and process metrics.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return reg
+}
+
+// New returns a new Metrics registered with reg.
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		accountsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "accounts_created_total",
+			Help:      "Number of bank accounts created.",
+		}),
+		deposits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deposits_total",
+			Help:      "Number of deposits made.",
+		}),
+		transfers: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfers_total",
+			Help:      "Number of money transfers made.",
+		}),
+		transfersFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transfers_failed_total",
+			Help:      "Number of money transfers that failed, by the code of the error.",
+		}, []string{"reason"}),
+		transferredAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transferred_amount_total",
+			Help:      "Amount of money transferred in major units, e.g. euros, by currency.",
+		}, []string{"currency"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests served, by route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests, by route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpRequestsActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests being served, by route.",
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of gRPC calls served, by method and status code.",
+		}, []string{"method", "code"}),
+		grpcRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of gRPC calls, by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		grpcRequestsActive: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_in_flight",
+			Help:      "Number of gRPC calls being served, by method.",
+		}, []string{"method"}),
+	}
+
+	reg.MustRegister(
+		m.accountsCreated,
+		m.deposits,
+		m.transfers,
+		m.transfersFailed,
+		m.transferredAmount,
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.httpRequestsActive,
+		m.grpcRequests,
+		m.grpcRequestDuration,
+		m.grpcRequestsActive,
+	)
+
+	return m
+}
+
+// AccountCreated records a bank account was created.
+func (m *Metrics) AccountCreated() {
+	m.accountsCreated.Inc()
+}
+
+// MoneyAdded records money was added to a bank account.
+func (m *Metrics) MoneyAdded() {
+	m.deposits.Inc()
+}
+
+// MoneyTransferred records the amount, in the minor unit of the currency, was transferred.
+func (m *Metrics) MoneyTransferred(amount money.Amount, currencyCode string) {
+	m.transfers.Inc()
+	m.transferredAmount.WithLabelValues(currencyCode).Add(money.New(amount, currencyCode).AsMajorUnits())
+}
+
+// TransferFailed records a money transfer failed for the reason, an error code.
+func (m *Metrics) TransferFailed(reason string) {
+	m.transfersFailed.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics_business(t *testing.T) {
+	t.Parallel()
+
+	m := New(prometheus.NewRegistry())
+
+	m.AccountCreated()
+	m.MoneyAdded()
+	m.MoneyAdded()
+	m.MoneyTransferred(150, "EUR")
+	m.MoneyTransferred(25, "EUR")
+	m.TransferFailed("INSUFFICIENT_ACCOUNT_BALANCE")
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.accountsCreated), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.deposits), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.transfers), 0)
+	assert.InDelta(t, 1.75, testutil.ToFloat64(m.transferredAmount.WithLabelValues("EUR")), 0.0001)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.transfersFailed.WithLabelValues("INSUFFICIENT_ACCOUNT_BALANCE")), 0)
+}
+
+func TestMetrics_Instrument(t *testing.T) {
+	t.Parallel()
+
+	m := New(prometheus.NewRegistry())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		assert.InDelta(t, 1, testutil.ToFloat64(m.httpRequestsActive.WithLabelValues("GET", "/accounts/{id}")), 0)
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	route := func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+
+		return pattern
+	}
+
+	h := m.Instrument(mux, route)
+
+	for _, path := range []string{"/accounts/1", "/accounts/2", "/unknown"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/accounts/{id}", "404")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", unmatchedRoute, "404")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(m.httpRequestsActive.WithLabelValues("GET", "/accounts/{id}")), 0)
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpRequestDuration))
+}
+
+func TestMetrics_UnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	m := New(prometheus.NewRegistry())
+
+	const method = "/xbankapi.v1.AccountService/GetAccount"
+
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	handler := func(context.Context, any) (any, error) {
+		assert.InDelta(t, 1, testutil.ToFloat64(m.grpcRequestsActive.WithLabelValues(method)), 0)
+
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	for range 2 {
+		_, err := m.UnaryServerInterceptor()(context.Background(), nil, info, handler)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.grpcRequests.WithLabelValues(method, "NotFound")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(m.grpcRequestsActive.WithLabelValues(method)), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.grpcRequestDuration))
+}
+
+func TestPoolCollector(t *testing.T) {
+	t.Parallel()
+
+	// The pool connects lazily, so no database is needed to collect its statistics.
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:5432/test")
+	require.NoError(t, err)
+
+	t.Cleanup(pool.Close)
+
+	c := NewPoolCollector(pool)
+
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP xbankapi_db_pool_acquired_connections Number of connections currently acquired from the pool.
+# TYPE xbankapi_db_pool_acquired_connections gauge
+xbankapi_db_pool_acquired_connections 0
+`), "xbankapi_db_pool_acquired_connections"))
+	assert.Equal(t, 9, testutil.CollectAndCount(c))
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Pool is a database connection pool, e.g. *pgxpool.Pool.
+	Pool interface {
+		Stat() *pgxpool.Stat
+	}
+
+	// PoolCollector collects the statistics of a database connection pool when scraped.
+	PoolCollector struct {
+		pool Pool
+
+		acquiredConns     *prometheus.Desc
+		idleConns         *prometheus.Desc
+		constructingConns *prometheus.Desc
+		totalConns        *prometheus.Desc
+		maxConns          *prometheus.Desc
+		acquires          *prometheus.Desc
+		emptyAcquires     *prometheus.Desc
+		canceledAcquires  *prometheus.Desc
+		acquireDuration   *prometheus.Desc
+	}
+)
+
+// NewPoolCollector returns a new PoolCollector.
+func NewPoolCollector(pool Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_connections", "Number of connections currently acquired from the pool."),
+		idleConns:         desc("idle_connections", "Number of idle connections in the pool."),
+		constructingConns: desc("constructing_connections", "Number of connections being established."),
+		totalConns:        desc("connections", "Number of connections in the pool."),
+		maxConns:          desc("max_connections", "Maximum number of connections in the pool."),
+		acquires:          desc("acquires_total", "Number of connections acquired from the pool."),
+		emptyAcquires: desc("empty_acquires_total",
+			"Number of acquires that waited for a connection because the pool was empty."),
+		canceledAcquires: desc("canceled_acquires_total", "Number of acquires canceled by their context."),
+		acquireDuration: desc("acquire_wait_seconds_total",
+			"Total time spent acquiring connections from the pool."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.canceledAcquires
+	ch <- c.acquireDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(
+		c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(
+		c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
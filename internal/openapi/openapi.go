@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	componentsSchemasPrefix    = "#/components/schemas/"
+	componentsParametersPrefix = "#/components/parameters/"
+	componentsResponsesPrefix  = "#/components/responses/"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+type (
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+	}
+
+	// PathItem maps a lower-case HTTP method to its operation.
+	PathItem map[string]*Operation
+
+	Operation struct {
+		OperationID string               `json:"operationId"`
+		Parameters  []*Parameter         `json:"parameters"`
+		RequestBody *RequestBody         `json:"requestBody"`
+		Responses   map[string]*Response `json:"responses"`
+	}
+
+	Parameter struct {
+		Ref      string  `json:"$ref"`
+		Name     string  `json:"name"`
+		In       string  `json:"in"`
+		Required bool    `json:"required"`
+		Schema   *Schema `json:"schema"`
+	}
+
+	RequestBody struct {
+		Required bool                  `json:"required"`
+		Content  map[string]*MediaType `json:"content"`
+	}
+
+	Response struct {
+		Ref     string                `json:"$ref"`
+		Content map[string]*MediaType `json:"content"`
+	}
+
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+		Responses  map[string]*Response  `json:"responses"`
+	}
+
+	Schema struct {
+		Ref                  string             `json:"$ref"`
+		Type                 any                `json:"type"`
+		Format               string             `json:"format"`
+		Properties           map[string]*Schema `json:"properties"`
+		Required             []string           `json:"required"`
+		AdditionalProperties *Additional        `json:"additionalProperties"`
+		Items                *Schema            `json:"items"`
+		OneOf                []*Schema          `json:"oneOf"`
+		AllOf                []*Schema          `json:"allOf"`
+		Enum                 []any              `json:"enum"`
+		Const                any                `json:"const"`
+		MinLength            *int               `json:"minLength"`
+		MaxLength            *int               `json:"maxLength"`
+		Minimum              *float64           `json:"minimum"`
+		Maximum              *float64           `json:"maximum"`
+	}
+
+	// Additional describes additionalProperties, which is either a boolean or a schema.
+	Additional struct {
+		Forbidden bool
+		Schema    *Schema
+	}
+)
+
+// Spec returns the raw OpenAPI document describing the API.
+func Spec() []byte {
+	return spec
+}
+
+// Load parses the OpenAPI document describing the API.
+func Load() (*Document, error) {
+	return Parse(spec)
+}
+
+// Parse parses an OpenAPI document.
+func Parse(data []byte) (*Document, error) {
+	doc := &Document{}
+
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Operation returns the operation registered for the route pattern, e.g. "POST /accounts".
+func (d *Document) Operation(pattern string) (*Operation, bool) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		return nil, false
+	}
+
+	item, ok := d.Paths[path]
+	if !ok {
+		return nil, false
+	}
+
+	op, ok := item[strings.ToLower(method)]
+
+	return op, ok
+}
+
+// Schema returns the named component schema with its references resolved.
+func (d *Document) Schema(name string) (*Schema, bool) {
+	schema, ok := d.Components.Schemas[name]
+	if !ok {
+		return nil, false
+	}
+
+	return d.resolve(schema), true
+}
+
+// PropertyNames returns the names of all properties the schema declares,
+// following references and allOf compositions.
+func (d *Document) PropertyNames(schema *Schema) []string {
+	schema = d.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	for _, s := range schema.AllOf {
+		names = append(names, d.PropertyNames(s)...)
+	}
+
+	return names
+}
+
+func (d *Document) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsSchemasPrefix)]
+	}
+
+	return schema
+}
+
+func (d *Document) resolveParameter(parameter *Parameter) *Parameter {
+	for parameter != nil && parameter.Ref != "" {
+		parameter = d.Components.Parameters[strings.TrimPrefix(parameter.Ref, componentsParametersPrefix)]
+	}
+
+	return parameter
+}
+
+func (d *Document) resolveResponse(response *Response) *Response {
+	for response != nil && response.Ref != "" {
+		response = d.Components.Responses[strings.TrimPrefix(response.Ref, componentsResponsesPrefix)]
+	}
+
+	return response
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Forbidden = !allowed
+
+		return nil
+	}
+
+	a.Schema = &Schema{}
+	if err := json.Unmarshal(data, a.Schema); err != nil {
+		return fmt.Errorf("failed to parse additionalProperties: %w", err)
+	}
+
+	return nil
+}
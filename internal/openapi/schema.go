@@ -0,0 +1,306 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	typeString  = "string"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+	typeObject  = "object"
+	typeArray   = "array"
+	typeNull    = "null"
+
+	formatUUID     = "uuid"
+	formatEmail    = "email"
+	formatDate     = "date"
+	formatDateTime = "date-time"
+	dateLayout     = "2006-01-02"
+)
+
+// ViolationError reports a value that does not conform to a schema.
+type ViolationError struct {
+	Location string
+	Message  string
+}
+
+func (e *ViolationError) Error() string {
+	if e.Location == "" {
+		return "value " + e.Message
+	}
+
+	return e.Location + " " + e.Message
+}
+
+// ValidateValue validates a decoded JSON value against the schema.
+// Numbers must be decoded as json.Number.
+func (d *Document) ValidateValue(schema *Schema, value any) error {
+	return d.validate(schema, value, "")
+}
+
+func (d *Document) validate(schema *Schema, value any, path string) error {
+	schema = d.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if err := d.validateComposition(schema, value, path); err != nil {
+		return err
+	}
+
+	if err := validateType(schema, value, path); err != nil {
+		return err
+	}
+
+	if err := validateEnum(schema, value, path); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return validateString(schema, v, path)
+	case json.Number:
+		return validateNumber(schema, v, path)
+	case map[string]any:
+		return d.validateObject(schema, v, path)
+	case []any:
+		return d.validateArray(schema, v, path)
+	}
+
+	return nil
+}
+
+func (d *Document) validateComposition(schema *Schema, value any, path string) error {
+	for _, s := range schema.AllOf {
+		if err := d.validate(s, value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.OneOf) == 0 {
+		return nil
+	}
+
+	matches := 0
+
+	for _, s := range schema.OneOf {
+		if d.validate(s, value, path) == nil {
+			matches++
+		}
+	}
+
+	if matches != 1 {
+		return violation(path, "must match exactly one schema")
+	}
+
+	return nil
+}
+
+func (d *Document) validateObject(schema *Schema, value map[string]any, path string) error {
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			return violation(join(path, name), "is required")
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := schema.Properties[name]; ok {
+			if err := d.validate(property, value[name], join(path, name)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := d.validateAdditional(schema.AdditionalProperties, value[name], join(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Document) validateAdditional(additional *Additional, value any, path string) error {
+	if additional == nil {
+		return nil
+	}
+
+	if additional.Forbidden {
+		return violation(path, "is not allowed")
+	}
+
+	return d.validate(additional.Schema, value, path)
+}
+
+func (d *Document) validateArray(schema *Schema, value []any, path string) error {
+	if schema.Items == nil {
+		return nil
+	}
+
+	for i, item := range value {
+		if err := d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateType(schema *Schema, value any, path string) error {
+	var allowed []string
+
+	switch t := schema.Type.(type) {
+	case string:
+		allowed = []string{t}
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				allowed = append(allowed, s)
+			}
+		}
+	default:
+		return nil
+	}
+
+	actual := typeOf(value)
+
+	if slices.Contains(allowed, actual) {
+		return nil
+	}
+
+	if actual == typeInteger && slices.Contains(allowed, typeNumber) {
+		return nil
+	}
+
+	return violation(path, fmt.Sprintf("must be of type %v", schema.Type))
+}
+
+func validateEnum(schema *Schema, value any, path string) error {
+	if schema.Const != nil && !equal(schema.Const, value) {
+		return violation(path, fmt.Sprintf("must be %v", schema.Const))
+	}
+
+	if len(schema.Enum) == 0 {
+		return nil
+	}
+
+	for _, v := range schema.Enum {
+		if equal(v, value) {
+			return nil
+		}
+	}
+
+	return violation(path, fmt.Sprintf("must be one of %v", schema.Enum))
+}
+
+func validateString(schema *Schema, value string, path string) error {
+	length := utf8.RuneCountInString(value)
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return violation(path, fmt.Sprintf("must be at least %d characters long", *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return violation(path, fmt.Sprintf("must be at most %d characters long", *schema.MaxLength))
+	}
+
+	var err error
+
+	switch schema.Format {
+	case formatUUID:
+		_, err = uuid.Parse(value)
+	case formatEmail:
+		_, err = mail.ParseAddress(value)
+	case formatDate:
+		_, err = time.Parse(dateLayout, value)
+	case formatDateTime:
+		_, err = time.Parse(time.RFC3339, value)
+	}
+
+	if err != nil {
+		return violation(path, fmt.Sprintf("must be a valid %s", schema.Format))
+	}
+
+	return nil
+}
+
+func validateNumber(schema *Schema, value json.Number, path string) error {
+	f, err := value.Float64()
+	if err != nil {
+		return violation(path, "must be a number")
+	}
+
+	if schema.Minimum != nil && f < *schema.Minimum {
+		return violation(path, fmt.Sprintf("must be greater than or equal to %v", *schema.Minimum))
+	}
+
+	if schema.Maximum != nil && f > *schema.Maximum {
+		return violation(path, fmt.Sprintf("must be less than or equal to %v", *schema.Maximum))
+	}
+
+	return nil
+}
+
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return typeNull
+	case string:
+		return typeString
+	case bool:
+		return typeBoolean
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return typeInteger
+		}
+
+		return typeNumber
+	case map[string]any:
+		return typeObject
+	case []any:
+		return typeArray
+	default:
+		return reflect.TypeOf(value).String()
+	}
+}
+
+// equal compares a value decoded from the document with a value decoded from a message.
+func equal(expected, actual any) bool {
+	if n, ok := actual.(json.Number); ok {
+		f, err := n.Float64()
+
+		return err == nil && reflect.DeepEqual(expected, f)
+	}
+
+	return reflect.DeepEqual(expected, actual)
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func violation(path, msg string) error {
+	return &ViolationError{Location: path, Message: msg}
+}
@@ -0,0 +1,369 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaTypeJSON = "application/json"
+
+	parameterInPath   = "path"
+	parameterInQuery  = "query"
+	parameterInHeader = "header"
+
+	responseDefault = "default"
+
+	// defaultMaxBodySize is the largest request body read by default, that of a payment file.
+	defaultMaxBodySize = 10 << 20
+)
+
+var (
+	errRequestBodyRequired     = errors.New("request body is required")
+	errMissingParameter        = errors.New("missing parameter")
+	errUnsupportedMediaType    = errors.New("unsupported media type")
+	errUndocumentedStatusCode  = errors.New("undocumented status code")
+	errUndocumentedContentType = errors.New("undocumented content type")
+)
+
+type (
+	// Validator validates HTTP messages against an OpenAPI document.
+	Validator struct {
+		doc               *Document
+		mux               *http.ServeMux
+		validateResponses bool
+		maxBodySize       int64
+		handleError       ErrorHandler
+	}
+
+	ValidatorOption func(*Validator)
+
+	// ErrorHandler writes the response of a request failing validation, with the status code.
+	ErrorHandler func(w http.ResponseWriter, err error, code int)
+)
+
+// WithResponseValidation enables validating responses, which is meant to be used in tests.
+// A response that does not conform to the document is replaced by an internal server error.
+func WithResponseValidation() ValidatorOption {
+	return func(v *Validator) {
+		v.validateResponses = true
+	}
+}
+
+// WithMaxBodySize sets the largest request body that is read, larger ones are rejected.
+func WithMaxBodySize(size int64) ValidatorOption {
+	return func(v *Validator) {
+		v.maxBodySize = size
+	}
+}
+
+// WithErrorHandler sets how the errors are written, so they are written the same way as those of the api.
+// Errors are written as plain text by default.
+func WithErrorHandler(handleError ErrorHandler) ValidatorOption {
+	return func(v *Validator) {
+		v.handleError = handleError
+	}
+}
+
+// NewValidator returns a new Validator.
+func NewValidator(doc *Document, opts ...ValidatorOption) *Validator {
+	v := &Validator{
+		doc:         doc,
+		mux:         http.NewServeMux(),
+		maxBodySize: defaultMaxBodySize,
+		handleError: func(w http.ResponseWriter, err error, code int) {
+			http.Error(w, err.Error(), code)
+		},
+	}
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			v.mux.Handle(strings.ToUpper(method)+" "+path, http.NotFoundHandler())
+		}
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Middleware validates requests matching a documented operation before passing them to next.
+// Requests to undocumented routes are passed through untouched.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, pathValues, ok := v.find(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if err := v.validateRequest(w, r, op, pathValues); err != nil {
+			code := http.StatusBadRequest
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				code = http.StatusRequestEntityTooLarge
+			}
+
+			v.handleError(w, err, code)
+
+			return
+		}
+
+		if !v.validateResponses {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		rec := newRecorder()
+		next.ServeHTTP(rec, r)
+
+		if err := v.validateResponse(op, rec); err != nil {
+			v.handleError(w, fmt.Errorf("response does not conform to the api contract: %w", err),
+				http.StatusInternalServerError)
+
+			return
+		}
+
+		rec.flush(w)
+	})
+}
+
+func (v *Validator) find(r *http.Request) (*Operation, map[string]string, bool) {
+	_, pattern := v.mux.Handler(r)
+	if pattern == "" {
+		return nil, nil, false
+	}
+
+	op, ok := v.doc.Operation(pattern)
+	if !ok {
+		return nil, nil, false
+	}
+
+	_, path, _ := strings.Cut(pattern, " ")
+
+	return op, matchPath(path, r.URL.Path), true
+}
+
+func (v *Validator) validateRequest(
+	w http.ResponseWriter, r *http.Request, op *Operation, pathValues map[string]string,
+) error {
+	for _, p := range op.Parameters {
+		if err := v.validateParameter(r, v.doc.resolveParameter(p), pathValues); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodySize))
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return errRequestBodyRequired
+		}
+
+		return nil
+	}
+
+	mediaType := mediaTypeJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ = mime.ParseMediaType(contentType)
+	}
+
+	content, ok := op.RequestBody.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnsupportedMediaType, mediaType)
+	}
+
+	if err := v.validateContent(content, mediaType, body); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateParameter(r *http.Request, p *Parameter, pathValues map[string]string) error {
+	if p == nil {
+		return nil
+	}
+
+	var (
+		value string
+		found bool
+	)
+
+	switch p.In {
+	case parameterInPath:
+		value, found = pathValues[p.Name]
+	case parameterInQuery:
+		found = r.URL.Query().Has(p.Name)
+		value = r.URL.Query().Get(p.Name)
+	case parameterInHeader:
+		value = r.Header.Get(p.Name)
+		found = value != ""
+	default:
+		return nil
+	}
+
+	if !found {
+		if p.Required {
+			return fmt.Errorf("%w: %s %s", errMissingParameter, p.In, p.Name)
+		}
+
+		return nil
+	}
+
+	if err := v.doc.validate(p.Schema, parameterValue(v.doc.resolve(p.Schema), value), p.Name); err != nil {
+		return fmt.Errorf("invalid %s parameter: %w", p.In, err)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateResponse(op *Operation, rec *recorder) error {
+	response, ok := op.Responses[strconv.Itoa(rec.statusCode)]
+	if !ok {
+		response, ok = op.Responses[responseDefault]
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %d", errUndocumentedStatusCode, rec.statusCode)
+	}
+
+	response = v.doc.resolveResponse(response)
+	if response == nil || len(response.Content) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+
+	content, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("%w: %q for status code %d", errUndocumentedContentType, mediaType, rec.statusCode)
+	}
+
+	return v.validateContent(content, mediaType, rec.body.Bytes())
+}
+
+func (v *Validator) validateContent(content *MediaType, mediaType string, body []byte) error {
+	if content == nil || content.Schema == nil {
+		return nil
+	}
+
+	if mediaType != mediaTypeJSON {
+		return v.doc.validate(content.Schema, strings.TrimSpace(string(body)), "")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("unable to decode: %w", err)
+	}
+
+	return v.doc.validate(content.Schema, value, "")
+}
+
+// matchPath extracts the values of the wildcards of a path template, e.g. /accounts/{id}.
+func matchPath(template, path string) map[string]string {
+	values := make(map[string]string)
+
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range templateSegments {
+		if i >= len(pathSegments) {
+			break
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			values[strings.TrimSuffix(strings.Trim(segment, "{}"), "...")] = pathSegments[i]
+		}
+	}
+
+	return values
+}
+
+// parameterValue converts a raw parameter value to the JSON type its schema expects.
+func parameterValue(schema *Schema, raw string) any {
+	if schema == nil {
+		return raw
+	}
+
+	switch schema.Type {
+	case typeInteger, typeNumber:
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case typeBoolean:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+
+	return raw
+}
+
+// recorder buffers a response so it can be validated before it is sent.
+type recorder struct {
+	header     http.Header
+	body       bytes.Buffer
+	statusCode int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.header.Get("Content-Type") == "" {
+		r.header.Set("Content-Type", http.DetectContentType(b))
+	}
+
+	n, err := r.body.Write(b)
+	if err != nil {
+		return n, fmt.Errorf("failed to buffer response: %w", err)
+	}
+
+	return n, nil
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+}
+
+func (r *recorder) flush(w http.ResponseWriter) {
+	for key, values := range r.header {
+		w.Header()[key] = values
+	}
+
+	w.WriteHeader(r.statusCode)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDocument = `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/items/{id}": {
+      "put": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": "string", "format": "uuid"}},
+          {"name": "X-Request-Id", "in": "header", "required": true, "schema": {"type": "string"}},
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}
+        },
+        "responses": {
+          "200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Error": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+    },
+    "schemas": {
+      "Error": {"type": "object", "required": ["message"], "properties": {"message": {"type": "string"}}},
+      "Item": {
+        "type": "object",
+        "required": ["name", "amount"],
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string", "minLength": 3},
+          "amount": {"type": "integer", "minimum": 1},
+          "currency": {"type": "string", "enum": ["EUR"]}
+        }
+      }
+    }
+  }
+}`
+
+const validItemID = "12345678-1234-1234-1234-123456789001"
+
+func TestValidator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse([]byte(testDocument))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		path           string
+		header         http.Header
+		body           string
+		opts           []ValidatorOption
+		response       string
+		responseCode   int
+		wantStatusCode int
+		want           string
+	}{
+		{
+			name:           "passes through undocumented routes",
+			path:           "/unknown",
+			response:       `anything`,
+			responseCode:   http.StatusTeapot,
+			wantStatusCode: http.StatusTeapot,
+			want:           `anything`,
+		},
+		{
+			name:           "failed when path parameter is invalid",
+			path:           "/items/invalid",
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid path parameter: id must be a valid uuid
+`,
+		},
+		{
+			name:           "failed when request body is too large",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1}`,
+			opts:           []ValidatorOption{WithMaxBodySize(8)},
+			wantStatusCode: http.StatusRequestEntityTooLarge,
+			want: `failed to read request body: http: request body too large
+`,
+		},
+		{
+			name:           "failed when required header is missing",
+			path:           "/items/" + validItemID,
+			body:           `{"name":"name","amount":1}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `missing parameter: header X-Request-Id
+`,
+		},
+		{
+			name:           "failed when query parameter is invalid",
+			path:           "/items/" + validItemID + "?limit=0",
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid query parameter: limit must be greater than or equal to 1
+`,
+		},
+		{
+			name:           "failed when request body is missing",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			wantStatusCode: http.StatusBadRequest,
+			want: `request body is required
+`,
+		},
+		{
+			name:           "failed when request body has a missing field",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid request body: amount is required
+`,
+		},
+		{
+			name:           "failed when request body has an unknown field",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1,"other":true}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid request body: other is not allowed
+`,
+		},
+		{
+			name:           "failed when request body has a field of the wrong type",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1.5}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid request body: amount must be of type integer
+`,
+		},
+		{
+			name:           "failed when request body has a value not in enum",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1,"currency":"USD"}`,
+			wantStatusCode: http.StatusBadRequest,
+			want: `invalid request body: currency must be one of [EUR]
+`,
+		},
+		{
+			name:           "failed when response does not conform",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1}`,
+			response:       `{"name":"n","amount":1}`,
+			responseCode:   http.StatusOK,
+			wantStatusCode: http.StatusInternalServerError,
+			want: `response does not conform to the api contract: name must be at least 3 characters long
+`,
+		},
+		{
+			name:           "failed when response status code is undocumented",
+			path:           "/items/" + validItemID,
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1}`,
+			response:       `{}`,
+			responseCode:   http.StatusCreated,
+			wantStatusCode: http.StatusInternalServerError,
+			want: `response does not conform to the api contract: undocumented status code: 201
+`,
+		},
+		{
+			name:           "success when request and response conform",
+			path:           "/items/" + validItemID + "?limit=10",
+			header:         http.Header{"X-Request-Id": {"1"}},
+			body:           `{"name":"name","amount":1,"currency":"EUR"}`,
+			response:       `{"name":"name","amount":1}`,
+			responseCode:   http.StatusOK,
+			wantStatusCode: http.StatusOK,
+			want:           `{"name":"name","amount":1}`,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.body, string(body))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.responseCode)
+				_, _ = w.Write([]byte(tt.response))
+			})
+
+			r := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			for key, values := range tt.header {
+				r.Header[key] = values
+			}
+
+			w := httptest.NewRecorder()
+
+			NewValidator(doc, append(tt.opts, WithResponseValidation())...).Middleware(next).ServeHTTP(w, r)
+
+			res := w.Result()
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			defer res.Body.Close()
+
+			got, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Package outbox publishes domain events reliably: events are added to the outbox table in the transaction of the
+// change which raised them, and a relay publishes them once the transaction is committed.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	EventAccountCreated   = "AccountCreated"
+	EventMoneyAdded       = "MoneyAdded"
+	EventMoneyTransferred = "MoneyTransferred"
+	EventMoneyReceived    = "MoneyReceived"
+)
+
+// EventTypes are the types of the events raised.
+var EventTypes = []string{EventAccountCreated, EventMoneyAdded, EventMoneyTransferred, EventMoneyReceived}
+
+type (
+	// Event is a domain event raised by a change to an account.
+	Event struct {
+		Type      string
+		AccountID uuid.UUID
+		// Payload is one of AccountCreated, MoneyAdded, MoneyTransferred or MoneyReceived, encoded as JSON.
+		Payload any
+	}
+
+	// Message is an event as it is published.
+	Message struct {
+		ID        uuid.UUID `json:"id"`
+		Type      string    `json:"type"`
+		AccountID uuid.UUID `json:"accountId"`
+		// Sequence numbers the events of the account from 1, in the order they were committed.
+		Sequence   int64           `json:"sequence"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Payload    json.RawMessage `json:"payload"`
+	}
+
+	AccountCreated struct {
+		Account types.Account `json:"account"`
+	}
+
+	MoneyAdded struct {
+		TransactionID uuid.UUID    `json:"transactionId"`
+		Amount        money.Amount `json:"amount"`
+	}
+
+	MoneyTransferred struct {
+		TransactionID         uuid.UUID    `json:"transactionId"`
+		ReceiverAccountID     uuid.UUID    `json:"receiverAccountId"`
+		ReceiverTransactionID uuid.UUID    `json:"receiverTransactionId"`
+		Amount                money.Amount `json:"amount"`
+		CurrencyCode          string       `json:"currencyCode"`
+	}
+
+	// MoneyReceived is raised for the receiver of a transfer, along with MoneyTransferred for the sender.
+	MoneyReceived struct {
+		TransactionID       uuid.UUID    `json:"transactionId"`
+		SenderAccountID     uuid.UUID    `json:"senderAccountId"`
+		SenderTransactionID uuid.UUID    `json:"senderTransactionId"`
+		Amount              money.Amount `json:"amount"`
+		CurrencyCode        string       `json:"currencyCode"`
+	}
+
+	Outbox struct {
+		storeWithTx func(tx pgx.Tx) storage.OutboxStore
+		now         func() time.Time
+	}
+)
+
+// New returns a new Outbox.
+func New() *Outbox {
+	return &Outbox{
+		storeWithTx: storage.OutboxStoreWithTx,
+		now:         time.Now,
+	}
+}
+
+// Add adds the event to the outbox within tx, so it is only published if the change is committed. The account of the
+// event is locked until the end of tx, so that its events are numbered in the order they are committed.
+func (o *Outbox) Add(ctx context.Context, tx pgx.Tx, event Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	if _, err := o.storeWithTx(tx).AddOutboxEvent(ctx, storage.AddOutboxEventParams{
+		EventType:  event.Type,
+		AccountID:  event.AccountID,
+		Payload:    payload,
+		OccurredAt: pgtype.Timestamptz{Time: o.now(), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to add %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+func toMessage(e storage.Outbox) Message {
+	return Message{
+		ID:         e.EventID,
+		Type:       e.EventType,
+		AccountID:  e.AccountID,
+		Sequence:   e.AccountSequence,
+		OccurredAt: e.OccurredAt.Time,
+		Payload:    e.Payload,
+	}
+}
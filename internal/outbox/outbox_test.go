@@ -0,0 +1,93 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+)
+
+var (
+	wantAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantOccurredAt    = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	errAnything       = errors.New("any")
+)
+
+func TestOutbox_Add(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		event   Event
+		mock    func(*storageMocks.MockOutboxStore)
+		wantErr error
+	}{
+		{
+			name: "success when the event is added",
+			event: Event{
+				Type:      EventMoneyAdded,
+				AccountID: wantAccountID,
+				Payload:   MoneyAdded{TransactionID: wantTransactionID, Amount: 100},
+			},
+			mock: func(store *storageMocks.MockOutboxStore) {
+				store.EXPECT().AddOutboxEvent(mock.Anything, storage.AddOutboxEventParams{
+					EventType:  EventMoneyAdded,
+					AccountID:  wantAccountID,
+					Payload:    []byte(`{"transactionId":"12345678-1234-1234-1234-123456789002","amount":100}`),
+					OccurredAt: pgtype.Timestamptz{Time: wantOccurredAt, Valid: true},
+				}).Return(storage.Outbox{}, nil).Once()
+			},
+		},
+		{
+			name: "failed when the payload cannot be encoded",
+			event: Event{
+				Type:    EventMoneyAdded,
+				Payload: func() {},
+			},
+			mock:    func(*storageMocks.MockOutboxStore) {},
+			wantErr: errAnything,
+		},
+		{
+			name: "failed when add outbox event returns an error",
+			event: Event{
+				Type:    EventAccountCreated,
+				Payload: AccountCreated{},
+			},
+			mock: func(store *storageMocks.MockOutboxStore) {
+				store.EXPECT().AddOutboxEvent(mock.Anything, mock.Anything).Return(storage.Outbox{}, errAnything).Once()
+			},
+			wantErr: errAnything,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockOutboxStore(t)
+			tt.mock(store)
+
+			o := New()
+			o.storeWithTx = func(pgx.Tx) storage.OutboxStore { return store }
+			o.now = func() time.Time { return wantOccurredAt }
+
+			err := o.Add(context.Background(), nil, tt.event)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
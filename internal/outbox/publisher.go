@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+const (
+	PublisherLog     = "log"
+	PublisherWebhook = "webhook"
+	PublisherNotify  = "notify"
+
+	// DefaultNotifyChannel is the channel events are notified on by default.
+	DefaultNotifyChannel = "xbankapi_events"
+
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+)
+
+var errUnexpectedStatus = errors.New("unexpected status code")
+
+// Publisher publishes events to downstream systems. Events may be published more than once, consumers
+// deduplicate them by ID.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Publishers publishes events with every publisher in turn, stopping at the first which fails.
+type Publishers []Publisher
+
+// Publish publishes the event with every publisher.
+func (p Publishers) Publish(ctx context.Context, msg Message) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, msg); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LogPublisher logs events, which is useful in development.
+type LogPublisher struct {
+	logger logger.Logger
+}
+
+// NewLogPublisher returns a new LogPublisher.
+func NewLogPublisher(logger logger.Logger) *LogPublisher {
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+// Publish logs the event.
+func (p *LogPublisher) Publish(ctx context.Context, msg Message) error {
+	p.logger.InfoContext(ctx, "event published",
+		"id", msg.ID, "type", msg.Type, "account_id", msg.AccountID, "payload", string(msg.Payload))
+
+	return nil
+}
+
+// HTTPPublisher posts events as JSON to a webhook sink.
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher returns a new HTTPPublisher posting events to url.
+func NewHTTPPublisher(url string, client *http.Client) *HTTPPublisher {
+	return &HTTPPublisher{
+		url:    url,
+		client: client,
+	}
+}
+
+// Publish posts the event, which is published once the sink responds with a 2xx status code.
+func (p *HTTPPublisher) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, msg.ID.String())
+	req.Header.Set(HeaderEventType, msg.Type)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, res.StatusCode)
+	}
+
+	return nil
+}
+
+// NotifyPublisher notifies events on a Postgres channel, listeners receive them as JSON.
+type NotifyPublisher struct {
+	notifier storage.Notifier
+	channel  string
+}
+
+// NewNotifyPublisher returns a new NotifyPublisher.
+func NewNotifyPublisher(notifier storage.Notifier, channel string) *NotifyPublisher {
+	return &NotifyPublisher{
+		notifier: notifier,
+		channel:  channel,
+	}
+}
+
+// Publish notifies the event.
+func (p *NotifyPublisher) Publish(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if err := p.notifier.Notify(ctx, storage.NotifyParams{Channel: p.channel, Payload: string(payload)}); err != nil {
+		return fmt.Errorf("failed to notify event: %w", err)
+	}
+
+	return nil
+}
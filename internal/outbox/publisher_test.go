@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+)
+
+const wantMessage = `{"id":"12345678-1234-1234-1234-123456789003","type":"MoneyAdded",` +
+	`"accountId":"12345678-1234-1234-1234-123456789001","sequence":1,"occurredAt":"2024-05-01T10:00:00Z",` +
+	`"payload":{"transactionId":"12345678-1234-1234-1234-123456789002","amount":100}}`
+
+func testMessage() Message {
+	return toMessage(testEvent(1))
+}
+
+func TestLogPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewLogPublisher(slog.Default()).Publish(context.Background(), testMessage()))
+}
+
+func TestHTTPPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "success when the sink accepts the event",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "failed when the sink rejects the event",
+			statusCode: http.StatusServiceUnavailable,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "12345678-1234-1234-1234-123456789003", r.Header.Get(HeaderEventID))
+				assert.Equal(t, EventMoneyAdded, r.Header.Get(HeaderEventType))
+				assert.JSONEq(t, wantMessage, string(body))
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			err := NewHTTPPublisher(srv.URL, srv.Client()).Publish(context.Background(), testMessage())
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnexpectedStatus)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotifyPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	notifier := storageMocks.NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, mock.MatchedBy(func(p storage.NotifyParams) bool {
+		return p.Channel == DefaultNotifyChannel && json.Valid([]byte(p.Payload))
+	})).RunAndReturn(func(_ context.Context, p storage.NotifyParams) error {
+		assert.JSONEq(t, wantMessage, p.Payload)
+
+		return nil
+	}).Once()
+
+	require.NoError(t, NewNotifyPublisher(notifier, DefaultNotifyChannel).Publish(context.Background(), testMessage()))
+}
+
+func TestNotifyPublisher_Publish_failed(t *testing.T) {
+	t.Parallel()
+
+	notifier := storageMocks.NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, mock.Anything).Return(errAnything).Once()
+
+	err := NewNotifyPublisher(notifier, DefaultNotifyChannel).Publish(context.Background(), testMessage())
+	assert.ErrorIs(t, err, errAnything)
+}
+
+func TestPublishers_Publish(t *testing.T) {
+	t.Parallel()
+
+	var published []string
+
+	record := func(name string, err error) Publisher {
+		return publisherFunc(func(context.Context, Message) error {
+			published = append(published, name)
+
+			return err
+		})
+	}
+
+	err := Publishers{record("first", nil), record("second", errAnything), record("third", nil)}.
+		Publish(context.Background(), testMessage())
+
+	assert.ErrorIs(t, err, errAnything)
+	assert.Equal(t, []string{"first", "second"}, published)
+}
@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+const (
+	defaultRelayInterval = time.Second
+	defaultBatchSize     = 100
+)
+
+// Relay publishes the events of the outbox at least once, in the order of their outbox IDs. Events of different
+// accounts may be published out of the order they were committed, but the events of an account are published in the
+// order of their sequence: an event is added with its account locked until its transaction ends, so the events added
+// to the same account after it are only numbered, and their outbox IDs only drawn, once it is committed.
+type Relay struct {
+	conn        storage.DBConnection
+	storeWithTx func(tx pgx.Tx) storage.OutboxStore
+	publisher   Publisher
+	logger      logger.Logger
+	interval    time.Duration
+	batchSize   int32
+	now         func() time.Time
+}
+
+// NewRelay returns a new Relay.
+func NewRelay(conn storage.DBConnection, publisher Publisher, logger logger.Logger) *Relay {
+	return &Relay{
+		conn:        conn,
+		storeWithTx: storage.OutboxStoreWithTx,
+		publisher:   publisher,
+		logger:      logger,
+		interval:    defaultRelayInterval,
+		batchSize:   defaultBatchSize,
+		now:         time.Now,
+	}
+}
+
+// Run relays the events every interval until ctx is done. Events which fail to be published are retried at the
+// next interval.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Relay(ctx); err != nil && ctx.Err() == nil {
+			r.logger.ErrorContext(ctx, "failed to relay outbox events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay publishes the unpublished events, by outbox ID, until none is left or one fails to be published.
+func (r *Relay) Relay(ctx context.Context) error {
+	for {
+		n, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n < int(r.batchSize) {
+			return nil
+		}
+	}
+}
+
+// relayBatch publishes a batch of events and marks the published ones, returning the size of the batch.
+// The events are locked until the batch is done, so that concurrent relays do not publish the same events.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer storage.Rollback(ctx, tx, r.logger)
+
+	store := r.storeWithTx(tx)
+
+	events, err := store.ListUnpublishedOutboxEvents(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unpublished outbox events: %w", err)
+	}
+
+	published := make([]int64, 0, len(events))
+
+	var publishErr error
+
+	for _, e := range events {
+		if publishErr = r.publisher.Publish(ctx, toMessage(e)); publishErr != nil {
+			publishErr = fmt.Errorf("failed to publish event %s: %w", e.EventID, publishErr)
+
+			break
+		}
+
+		published = append(published, e.OutboxEventID)
+	}
+
+	if err := r.markPublished(ctx, tx, store, published); err != nil {
+		return 0, err
+	}
+
+	return len(events), publishErr
+}
+
+func (r *Relay) markPublished(ctx context.Context, tx pgx.Tx, store storage.OutboxStore, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := store.MarkOutboxEventsPublished(ctx, storage.MarkOutboxEventsPublishedParams{
+		PublishedAt:    pgtype.Timestamptz{Time: r.now(), Valid: true},
+		OutboxEventIds: ids,
+	}); err != nil {
+		return fmt.Errorf("failed to mark outbox events published: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
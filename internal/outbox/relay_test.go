@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+)
+
+// publisherFunc publishes events with a function.
+type publisherFunc func(ctx context.Context, msg Message) error
+
+func (f publisherFunc) Publish(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// testEvent returns the outbox event of id, the first one having the ID 12345678-1234-1234-1234-123456789003.
+func testEvent(id int64) storage.Outbox {
+	return storage.Outbox{
+		OutboxEventID:   id,
+		EventID:         uuid.MustParse(fmt.Sprintf("12345678-1234-1234-1234-%012d", 123456789002+id)),
+		EventType:       EventMoneyAdded,
+		AccountID:       wantAccountID,
+		AccountSequence: id,
+		Payload:         []byte(`{"transactionId":"12345678-1234-1234-1234-123456789002","amount":100}`),
+		OccurredAt:      pgtype.Timestamptz{Time: wantOccurredAt, Valid: true},
+	}
+}
+
+func TestRelay_Relay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		batches       [][]storage.Outbox
+		failAt        int64
+		wantPublished [][]int64
+		wantErr       bool
+	}{
+		{
+			name:    "success when no event is unpublished",
+			batches: [][]storage.Outbox{{}},
+		},
+		{
+			name:          "success when every event is published",
+			batches:       [][]storage.Outbox{{testEvent(1), testEvent(2)}, {testEvent(3)}},
+			wantPublished: [][]int64{{1, 2}, {3}},
+		},
+		{
+			name:          "failed when an event fails to be published",
+			batches:       [][]storage.Outbox{{testEvent(1), testEvent(2)}},
+			failAt:        2,
+			wantPublished: [][]int64{{1}},
+			wantErr:       true,
+		},
+		{
+			name:    "failed when the first event fails to be published",
+			batches: [][]storage.Outbox{{testEvent(1), testEvent(2)}},
+			failAt:  1,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockOutboxStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Times(len(tt.batches))
+			tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Times(len(tt.batches))
+
+			ids := make(map[uuid.UUID]int64)
+
+			for _, batch := range tt.batches {
+				for _, e := range batch {
+					ids[e.EventID] = e.OutboxEventID
+				}
+
+				store.EXPECT().ListUnpublishedOutboxEvents(mock.Anything, int32(2)).Return(batch, nil).Once()
+			}
+
+			for _, ids := range tt.wantPublished {
+				store.EXPECT().MarkOutboxEventsPublished(mock.Anything, storage.MarkOutboxEventsPublishedParams{
+					PublishedAt:    pgtype.Timestamptz{Time: wantOccurredAt, Valid: true},
+					OutboxEventIds: ids,
+				}).Return(nil).Once()
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			}
+
+			var published []int64
+
+			r := NewRelay(conn, publisherFunc(func(_ context.Context, msg Message) error {
+				if ids[msg.ID] == tt.failAt {
+					return errAnything
+				}
+
+				published = append(published, ids[msg.ID])
+
+				return nil
+			}), slog.Default())
+			r.storeWithTx = func(pgx.Tx) storage.OutboxStore { return store }
+			r.batchSize = 2
+			r.now = func() time.Time { return wantOccurredAt }
+
+			err := r.Relay(context.Background())
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errAnything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var want []int64
+			for _, ids := range tt.wantPublished {
+				want = append(want, ids...)
+			}
+
+			assert.Equal(t, want, published)
+		})
+	}
+}
+
+func TestRelay_Run(t *testing.T) {
+	t.Parallel()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	conn.EXPECT().Begin(mock.Anything).Return(nil, errAnything)
+
+	r := NewRelay(conn, NewLogPublisher(slog.Default()), slog.Default())
+	r.interval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, r.Run(ctx))
+}
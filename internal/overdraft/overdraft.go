@@ -0,0 +1,343 @@
+// Package overdraft manages the overdrafts agreed for accounts whose product is eligible, which let money be
+// transferred from them until their balance is the negative of their limit, and charges the interest on the negative
+// balances of accounts at the end of every day.
+package overdraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	// DefaultInterestRate is the default annual interest rate charged on negative balances, 12%.
+	DefaultInterestRate = "0.12"
+
+	// daysPerYear is the number of days the annual interest rate is divided by to charge the interest of a day.
+	daysPerYear = 365
+
+	defaultInterval = time.Hour
+	day             = 24 * time.Hour
+)
+
+// Auditor records the changes of overdrafts and the interest charged within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn         storage.DBConnection
+	store        storage.OverdraftStore
+	storeWithTx  func(tx pgx.Tx) storage.OverdraftStore
+	auditor      Auditor
+	interestRate pgtype.Numeric
+	logger       logger.Logger
+	interval     time.Duration
+	now          func() time.Time
+}
+
+// New returns a new Service, negative balances being charged interestRate a year, e.g. 0.12 for 12%.
+func New(
+	conn storage.DBConnection,
+	store storage.OverdraftStore,
+	auditor Auditor,
+	interestRate pgtype.Numeric,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:         conn,
+		store:        store,
+		storeWithTx:  storage.OverdraftStoreWithTx,
+		auditor:      auditor,
+		interestRate: interestRate,
+		logger:       logger,
+		interval:     defaultInterval,
+		now:          time.Now,
+	}
+}
+
+// GrantOverdraft sets the overdraft limit of an account, replacing the one it had. The product of the account must be
+// eligible to overdrafts, up to its maximum limit.
+// returns GrantOverdraftResponse.
+func (s *Service) GrantOverdraft(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.GrantOverdraftRequest,
+) (types.GrantOverdraftResponse, error) {
+	currencyCode, err := s.checkTerms(ctx, accountID, req.Limit)
+	if err != nil {
+		return types.GrantOverdraftResponse{}, err
+	}
+
+	if err := s.setLimit(ctx, audit.ActionGrantOverdraft, accountID, req.Limit, currencyCode); err != nil {
+		return types.GrantOverdraftResponse{}, err
+	}
+
+	s.logger.InfoContext(ctx, "overdraft granted", "account_id", accountID, "limit", req.Limit)
+
+	return types.GrantOverdraftResponse{
+		Overdraft: types.Overdraft{AccountID: accountID, Limit: req.Limit},
+	}, nil
+}
+
+// RevokeOverdraft removes the overdraft of an account. An account already overdrawn stays so until money is added,
+// but no more money can be transferred from it.
+func (s *Service) RevokeOverdraft(ctx context.Context, accountID uuid.UUID) error {
+	// No limit is the same in every currency.
+	if err := s.setLimit(ctx, audit.ActionRevokeOverdraft, accountID, 0, storage.NoCurrency); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "overdraft revoked", "account_id", accountID)
+
+	return nil
+}
+
+// checkTerms fails with types.ErrOverdraftNotAllowed unless the product of an account allows an overdraft of limit.
+// returns the currency of the account.
+func (s *Service) checkTerms(ctx context.Context, accountID uuid.UUID, limit money.Amount) (string, error) {
+	terms, err := s.store.GetOverdraftTerms(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch overdraft terms", "error", err)
+
+		return "", types.ErrInternal
+	}
+
+	// The maximum limit of the product applies to its accounts of every currency, in the minor unit of theirs.
+	maxLimit := storage.AmountFromNumeric(terms.MaxOverdraftLimit, storage.NoCurrency)
+
+	if !terms.OverdraftEligible || (maxLimit > 0 && limit > maxLimit) {
+		return "", types.ErrOverdraftNotAllowed
+	}
+
+	return terms.CurrencyCode, nil
+}
+
+// setLimit sets the overdraft limit of an account within a transaction, recording the change as action.
+func (s *Service) setLimit(
+	ctx context.Context,
+	action string,
+	accountID uuid.UUID,
+	limit money.Amount,
+	currencyCode string,
+) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	n, err := s.storeWithTx(tx).SetAccountOverdraft(ctx, storage.SetAccountOverdraftParams{
+		AccountID:      accountID,
+		OverdraftLimit: storage.NumericFromAmount(limit, currencyCode),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to set account overdraft", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if n == 0 {
+		return types.ErrAccountNotFound
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    action,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After:     types.Overdraft{AccountID: accountID, Limit: limit},
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// Run charges the interest of every day up to the previous one, in UTC, every interval until ctx is done, starting
+// from the day after the last one charged, so that the days the service was stopped are charged as well. Accounts
+// already charged for a day are skipped, and a day which fails to be charged is retried, with the days after it, at
+// the next interval.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var next time.Time
+
+	for {
+		next = s.catchUp(ctx, next)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// catchUp charges the interest of every day from next to the previous one, in UTC, in order, next being the day after
+// the last one charged when zero, and yesterday when none was. It returns the first day left to charge.
+func (s *Service) catchUp(ctx context.Context, next time.Time) time.Time {
+	yesterday := s.now().UTC().Truncate(day).Add(-day)
+
+	if next.IsZero() {
+		last, err := s.store.GetLastOverdraftInterestDay(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.ErrorContext(ctx, "failed to get last overdraft interest day", "error", err)
+			}
+
+			return next
+		}
+
+		next = yesterday
+		if last.Valid {
+			next = last.Time.UTC().Add(day)
+		}
+	}
+
+	for ; !next.After(yesterday); next = next.Add(day) {
+		if err := s.ChargeInterest(ctx, next); err != nil {
+			if ctx.Err() == nil {
+				s.logger.ErrorContext(ctx, "failed to charge overdraft interest", "error", err)
+			}
+
+			return next
+		}
+	}
+
+	return next
+}
+
+// ChargeInterest charges the interest of a day, in UTC, on the accounts whose balance was negative at its end and
+// which were not charged for it yet. The interest of each account is computed with exact decimal arithmetic and booked
+// as a transaction from it, rounded half up to the minor unit.
+func (s *Service) ChargeInterest(ctx context.Context, date time.Time) error {
+	date = date.UTC().Truncate(day)
+
+	accounts, err := s.store.ListOverdrawnAccounts(ctx, storage.ListOverdrawnAccountsParams{
+		DayEnd: pgtype.Timestamptz{Time: date.Add(day), Valid: true},
+		Day:    pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list overdrawn accounts: %w", err)
+	}
+
+	var errs []error
+
+	for _, account := range accounts {
+		balance := storage.AmountFromNumeric(account.Balance, account.CurrencyCode)
+
+		interest := s.interest(account.Balance, account.CurrencyCode)
+		if interest == 0 {
+			continue
+		}
+
+		if err := s.charge(ctx, account.AccountID, account.CurrencyCode, date, balance, interest); err != nil {
+			errs = append(errs, fmt.Errorf("failed to charge account %s: %w", account.AccountID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// interest returns the interest of a day on a negative balance, -balance × rate / 365, rounded half up to the minor
+// unit of its currency.
+func (s *Service) interest(balance pgtype.Numeric, currencyCode string) money.Amount {
+	interest := new(big.Rat).Mul(storage.RatFromNumeric(balance), storage.RatFromNumeric(s.interestRate))
+	interest.Quo(interest.Neg(interest), big.NewRat(daysPerYear, 1))
+
+	return storage.AmountFromNumeric(
+		storage.NumericFromRat(interest, storage.MinorUnitScale(currencyCode)), currencyCode)
+}
+
+// charge books the interest of a day on the balance of an account within a transaction, unless the account was
+// already charged for it.
+func (s *Service) charge(
+	ctx context.Context,
+	accountID uuid.UUID,
+	currencyCode string,
+	date time.Time,
+	balance, interest money.Amount,
+) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	store := s.storeWithTx(tx)
+
+	t, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: accountID,
+		Amount:    storage.NumericFromAmount(-interest, currencyCode),
+		Type:      types.TransactionTypeInterest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add transaction: %w", err)
+	}
+
+	n, err := store.AddOverdraftInterest(ctx, storage.AddOverdraftInterestParams{
+		AccountID:     accountID,
+		Day:           pgtype.Date{Time: date, Valid: true},
+		TransactionID: t.TransactionID,
+		Balance:       storage.NumericFromAmount(balance, currencyCode),
+		InterestRate:  s.interestRate,
+		Amount:        storage.NumericFromAmount(interest, currencyCode),
+		CreatedAt:     pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add overdraft interest: %w", err)
+	}
+
+	// Another instance charged the account for the day meanwhile.
+	if n == 0 {
+		return nil
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    audit.ActionChargeOverdraftInterest,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After: types.Transaction{
+			ID: t.TransactionID, AccountID: accountID, Amount: -interest, Type: types.TransactionTypeInterest,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "overdraft interest charged",
+		"account_id", accountID, "day", date.Format(time.DateOnly), "amount", interest)
+
+	return nil
+}
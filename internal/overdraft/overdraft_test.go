@@ -0,0 +1,345 @@
+package overdraft
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantNow           = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	wantDay           = time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)
+	errAnything       = errors.New("any")
+	wantInterestRate  = pgtype.Numeric{Int: big.NewInt(12), Exp: -2, Valid: true}
+
+	wantListParams = storage.ListOverdrawnAccountsParams{
+		DayEnd: pgtype.Timestamptz{Time: wantDay.Add(day), Valid: true},
+		Day:    pgtype.Date{Time: wantDay, Valid: true},
+	}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting events of action, failing with err.
+func wantAction(t *testing.T, action string, err error) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+		assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+		return err
+	})
+}
+
+func newTestService(conn storage.DBConnection, store storage.OverdraftStore, auditor Auditor) *Service {
+	s := New(conn, store, auditor, wantInterestRate, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.OverdraftStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func TestService_GrantOverdraft(t *testing.T) {
+	t.Parallel()
+
+	eligible := storage.GetOverdraftTermsRow{
+		CurrencyCode:      "EUR",
+		OverdraftEligible: true,
+		MaxOverdraftLimit: storage.NumericFromAmount(0, storage.NoCurrency),
+	}
+
+	tests := []struct {
+		name     string
+		terms    storage.GetOverdraftTermsRow
+		termsErr error
+		rows     int64
+		err      error
+		auditErr error
+		want     types.GrantOverdraftResponse
+		wantErr  error
+	}{
+		{
+			name:     "failed when account not found",
+			termsErr: pgx.ErrNoRows,
+			wantErr:  types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when the product of the account is not eligible",
+			terms:   storage.GetOverdraftTermsRow{MaxOverdraftLimit: storage.NumericFromAmount(0, storage.NoCurrency)},
+			wantErr: types.ErrOverdraftNotAllowed,
+		},
+		{
+			name: "failed when the limit exceeds the maximum of the product",
+			terms: storage.GetOverdraftTermsRow{
+				OverdraftEligible: true, MaxOverdraftLimit: storage.NumericFromAmount(10000, storage.NoCurrency),
+			},
+			wantErr: types.ErrOverdraftNotAllowed,
+		},
+		{
+			name:    "failed when the overdraft cannot be set",
+			terms:   eligible,
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when the account was deleted meanwhile",
+			terms:   eligible,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:     "failed when the grant cannot be recorded",
+			terms:    eligible,
+			rows:     1,
+			auditErr: errAnything,
+			wantErr:  types.ErrInternal,
+		},
+		{
+			name: "success within the maximum of the product",
+			terms: storage.GetOverdraftTermsRow{
+				CurrencyCode:      "JPY",
+				OverdraftEligible: true,
+				MaxOverdraftLimit: storage.NumericFromAmount(50000, storage.NoCurrency),
+			},
+			rows: 1,
+			want: types.GrantOverdraftResponse{
+				Overdraft: types.Overdraft{AccountID: wantAccountID, Limit: 50000},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockOverdraftStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			store.EXPECT().GetOverdraftTerms(mock.Anything, wantAccountID).Return(tt.terms, tt.termsErr).Once()
+
+			if tt.terms.OverdraftEligible && !errors.Is(tt.wantErr, types.ErrOverdraftNotAllowed) {
+				conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+				store.EXPECT().SetAccountOverdraft(mock.Anything, storage.SetAccountOverdraftParams{
+					AccountID:      wantAccountID,
+					OverdraftLimit: storage.NumericFromAmount(50000, tt.terms.CurrencyCode),
+				}).Return(tt.rows, tt.err).Once()
+				tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			}
+
+			if tt.wantErr == nil {
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			}
+
+			auditor := wantAction(t, audit.ActionGrantOverdraft, tt.auditErr)
+
+			got, err := newTestService(conn, store, auditor).GrantOverdraft(
+				context.Background(), wantAccountID, &types.GrantOverdraftRequest{Limit: 50000})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RevokeOverdraft(t *testing.T) {
+	t.Parallel()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	store := storageMocks.NewMockOverdraftStore(t)
+	tx := txMocks.NewMockTx(t)
+
+	conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+	store.EXPECT().SetAccountOverdraft(mock.Anything, storage.SetAccountOverdraftParams{
+		AccountID:      wantAccountID,
+		OverdraftLimit: storage.NumericFromAmount(0, storage.NoCurrency),
+	}).Return(1, nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+
+	s := newTestService(conn, store, wantAction(t, audit.ActionRevokeOverdraft, nil))
+
+	assert.NoError(t, s.RevokeOverdraft(context.Background(), wantAccountID))
+}
+
+func TestService_ChargeInterest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		balance money.Amount
+		charged int64
+		err     error
+		want    money.Amount
+		wantErr bool
+	}{
+		{
+			name:    "success when the interest rounds to zero",
+			balance: -1000,
+		},
+		{
+			// 12% a year of 1000.00 is 0.32876... a day.
+			name:    "success when the account is charged",
+			balance: -100000,
+			charged: 1,
+			want:    33,
+		},
+		{
+			// 12% a year of 15.21 is 0.0050005... a day, the interest of 15.20 rounding to zero.
+			name:    "success when the interest is rounded up to the minor unit",
+			balance: -1521,
+			charged: 1,
+			want:    1,
+		},
+		{
+			name:    "success when another instance charged the account meanwhile",
+			balance: -100000,
+			want:    33,
+		},
+		{
+			name:    "failed when the interest cannot be added",
+			balance: -100000,
+			err:     errAnything,
+			want:    33,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockOverdraftStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			store.EXPECT().ListOverdrawnAccounts(mock.Anything, wantListParams).Return(
+				[]storage.ListOverdrawnAccountsRow{
+					{AccountID: wantAccountID, Balance: storage.NumericFromAmount(tt.balance, "EUR")},
+				}, nil).Once()
+
+			if tt.want != 0 {
+				conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+				store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+					AccountID: wantAccountID,
+					Amount:    storage.NumericFromAmount(-tt.want, "EUR"),
+					Type:      types.TransactionTypeInterest,
+				}).Return(storage.Transaction{TransactionID: wantTransactionID}, nil).Once()
+				store.EXPECT().AddOverdraftInterest(mock.Anything, storage.AddOverdraftInterestParams{
+					AccountID:     wantAccountID,
+					Day:           pgtype.Date{Time: wantDay, Valid: true},
+					TransactionID: wantTransactionID,
+					Balance:       storage.NumericFromAmount(tt.balance, "EUR"),
+					InterestRate:  wantInterestRate,
+					Amount:        storage.NumericFromAmount(tt.want, "EUR"),
+					CreatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(tt.charged, tt.err).Once()
+				tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			}
+
+			if tt.charged == 1 {
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			}
+
+			auditor := wantAction(t, audit.ActionChargeOverdraftInterest, nil)
+
+			err := newTestService(conn, store, auditor).ChargeInterest(context.Background(), wantDay.Add(time.Hour))
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lastDay  pgtype.Date
+		wantDays []time.Time
+		err      error
+	}{
+		{
+			name:     "success when no interest was charged yet",
+			wantDays: []time.Time{wantDay},
+		},
+		{
+			name:     "success when the previous day was charged",
+			lastDay:  pgtype.Date{Time: wantDay, Valid: true},
+			wantDays: nil,
+		},
+		{
+			name:     "success when days were not charged since the last charge",
+			lastDay:  pgtype.Date{Time: wantDay.Add(-3 * day), Valid: true},
+			wantDays: []time.Time{wantDay.Add(-2 * day), wantDay.Add(-day), wantDay},
+		},
+		{
+			name:     "failed when a day fails to be charged",
+			lastDay:  pgtype.Date{Time: wantDay.Add(-2 * day), Valid: true},
+			wantDays: []time.Time{wantDay.Add(-day)},
+			err:      errAnything,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			store := storageMocks.NewMockOverdraftStore(t)
+			store.EXPECT().GetLastOverdraftInterestDay(mock.Anything).Return(tt.lastDay, nil).Once()
+
+			// The days are charged in order, and the days after one failing to be charged are not.
+			for i, d := range tt.wantDays {
+				call := store.EXPECT().ListOverdrawnAccounts(mock.Anything, storage.ListOverdrawnAccountsParams{
+					DayEnd: pgtype.Timestamptz{Time: d.Add(day), Valid: true},
+					Day:    pgtype.Date{Time: d, Valid: true},
+				}).Return(nil, tt.err)
+
+				if i == len(tt.wantDays)-1 {
+					call.Run(func(context.Context, storage.ListOverdrawnAccountsParams) { cancel() })
+				}
+
+				call.Once()
+			}
+
+			if len(tt.wantDays) == 0 {
+				cancel()
+			}
+
+			assert.NoError(t, newTestService(nil, store, nil).Run(ctx))
+		})
+	}
+}
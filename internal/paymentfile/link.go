@@ -0,0 +1,102 @@
+package paymentfile
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// ErrPaymentExecuted is returned by the transfer of a payment executed already, which is not made.
+var ErrPaymentExecuted = errors.New("payment already executed")
+
+type paymentCtxKey struct{}
+
+// ContextWithPayment returns a context whose transfer executes the payment, which is linked to it by Links.
+func ContextWithPayment(ctx context.Context, paymentID uuid.UUID) context.Context {
+	return context.WithValue(ctx, paymentCtxKey{}, paymentID)
+}
+
+func paymentFromContext(ctx context.Context) (uuid.UUID, bool) {
+	paymentID, ok := ctx.Value(paymentCtxKey{}).(uuid.UUID)
+
+	return paymentID, ok
+}
+
+// Links links the transfers executing payments, see ContextWithPayment, to their payments within the transactions of
+// the transfers, so that a payment whose transfer was made or held is never executed again, even when the import of
+// its file stops before saving its status.
+type Links struct {
+	storeWithTx func(tx pgx.Tx) storage.PaymentFileStore
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// NewLinks returns a new Links.
+func NewLinks(logger logger.Logger) *Links {
+	return &Links{
+		storeWithTx: storage.PaymentFileStoreWithTx,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// LinkTransfer accepts the payment of ctx, if any, with the transaction received by its transfer, within tx. It fails
+// with ErrPaymentExecuted when the payment was executed already, in which case the transfer must not be made.
+func (l *Links) LinkTransfer(ctx context.Context, tx pgx.Tx, transactionID uuid.UUID) error {
+	return l.link(ctx, tx, storage.ExecutePaymentParams{
+		Status:        StatusAccepted,
+		TransactionID: uuid.NullUUID{UUID: transactionID, Valid: true},
+	})
+}
+
+// LinkHold links the payment of ctx, if any, to the transfer approval or the pending transfer its transfer is held
+// as, within tx. The payment is pending until they are decided. It fails with ErrPaymentExecuted when the payment was
+// executed already, in which case the transfer must not be held.
+func (l *Links) LinkHold(ctx context.Context, tx pgx.Tx, held error) error {
+	params := storage.ExecutePaymentParams{Status: StatusPending}
+	params.ReasonCode, params.Reason = reasonColumns(&Reason{Code: reasonNarrative, Info: held.Error()})
+
+	var (
+		approval *types.PendingApprovalError
+		review   *types.PendingReviewError
+	)
+
+	switch {
+	case errors.As(held, &approval):
+		params.TransferApprovalID = uuid.NullUUID{UUID: approval.TransferApprovalID, Valid: true}
+	case errors.As(held, &review):
+		params.PendingTransferID = uuid.NullUUID{UUID: review.PendingTransferID, Valid: true}
+	}
+
+	return l.link(ctx, tx, params)
+}
+
+func (l *Links) link(ctx context.Context, tx pgx.Tx, params storage.ExecutePaymentParams) error {
+	paymentID, ok := paymentFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	params.PaymentID = paymentID
+	params.UpdatedAt = pgtype.Timestamptz{Time: l.now().UTC(), Valid: true}
+
+	n, err := l.storeWithTx(tx).ExecutePayment(ctx, params)
+	if err != nil {
+		l.logger.ErrorContext(ctx, "failed to link payment", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if n == 0 {
+		return ErrPaymentExecuted
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package paymentfile
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestLinks(t *testing.T) {
+	t.Parallel()
+
+	wantPaymentID := uuid.MustParse("12345678-1234-1234-1234-123456789050")
+	wantApprovalID := uuid.MustParse("12345678-1234-1234-1234-123456789006")
+	paymentCtx := ContextWithPayment(context.Background(), wantPaymentID)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		link    func(ctx context.Context, l *Links) error
+		mock    func(*storageMocks.MockPaymentFileStore)
+		wantErr error
+	}{
+		{
+			name: "success when the transfer executes no payment",
+			ctx:  context.Background(),
+			link: func(ctx context.Context, l *Links) error {
+				return l.LinkTransfer(ctx, nil, wantTransactionID)
+			},
+			mock: func(*storageMocks.MockPaymentFileStore) {},
+		},
+		{
+			name: "failed when the payment fails to be linked",
+			ctx:  paymentCtx,
+			link: func(ctx context.Context, l *Links) error {
+				return l.LinkTransfer(ctx, nil, wantTransactionID)
+			},
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().ExecutePayment(mock.Anything, mock.Anything).Return(0, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "failed when the payment was executed already",
+			ctx:  paymentCtx,
+			link: func(ctx context.Context, l *Links) error {
+				return l.LinkTransfer(ctx, nil, wantTransactionID)
+			},
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().ExecutePayment(mock.Anything, mock.Anything).Return(0, nil).Once()
+			},
+			wantErr: ErrPaymentExecuted,
+		},
+		{
+			name: "success when the transfer is made",
+			ctx:  paymentCtx,
+			link: func(ctx context.Context, l *Links) error {
+				return l.LinkTransfer(ctx, nil, wantTransactionID)
+			},
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().ExecutePayment(mock.Anything, storage.ExecutePaymentParams{
+					PaymentID:     wantPaymentID,
+					Status:        StatusAccepted,
+					TransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(1, nil).Once()
+			},
+		},
+		{
+			name: "success when the transfer is held for approval",
+			ctx:  paymentCtx,
+			link: func(ctx context.Context, l *Links) error {
+				return l.LinkHold(ctx, nil, &types.PendingApprovalError{TransferApprovalID: wantApprovalID})
+			},
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().ExecutePayment(mock.Anything, mock.MatchedBy(func(p storage.ExecutePaymentParams) bool {
+					return p.PaymentID == wantPaymentID && p.Status == StatusPending &&
+						p.ReasonCode.String == reasonNarrative && p.TransferApprovalID.UUID == wantApprovalID &&
+						!p.PendingTransferID.Valid && !p.TransactionID.Valid
+				})).Return(1, nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPaymentFileStore(t)
+
+			tt.mock(store)
+
+			l := NewLinks(slog.Default())
+			l.storeWithTx = func(pgx.Tx) storage.PaymentFileStore { return store }
+			l.now = func() time.Time { return wantNow }
+
+			assert.ErrorIs(t, tt.link(tt.ctx, l), tt.wantErr)
+		})
+	}
+}
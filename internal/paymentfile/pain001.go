@@ -0,0 +1,195 @@
+package paymentfile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	pain001Namespace   = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"
+	pain001MessageName = "pain.001.001.09"
+
+	// methodTransfer is the payment method of credit transfers.
+	methodTransfer = "TRF"
+
+	maxTextLength = 35
+)
+
+var (
+	decimalPattern = regexp.MustCompile(`^[0-9]{1,18}(\.[0-9]{1,17})?$`)
+
+	errUnsupportedMessage = errors.New("only pain.001.001.09 messages are supported")
+)
+
+type (
+	pain001Document struct {
+		XMLName    xml.Name          `xml:"Document"`
+		Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+	}
+
+	pain001Initiation struct {
+		MessageID            string                      `xml:"GrpHdr>MsgId"`
+		CreatedAt            string                      `xml:"GrpHdr>CreDtTm"`
+		NumberOfTransactions string                      `xml:"GrpHdr>NbOfTxs"`
+		ControlSum           string                      `xml:"GrpHdr>CtrlSum"`
+		PaymentInformations  []pain001PaymentInformation `xml:"PmtInf"`
+	}
+
+	pain001PaymentInformation struct {
+		ID                   string               `xml:"PmtInfId"`
+		Method               string               `xml:"PmtMtd"`
+		NumberOfTransactions string               `xml:"NbOfTxs"`
+		ControlSum           string               `xml:"CtrlSum"`
+		ExecutionDate        string               `xml:"ReqdExctnDt>Dt"`
+		ExecutionDateTime    string               `xml:"ReqdExctnDt>DtTm"`
+		DebtorAccount        pain001Account       `xml:"DbtrAcct"`
+		Transactions         []pain001Transaction `xml:"CdtTrfTxInf"`
+	}
+
+	pain001Account struct {
+		IBAN  string `xml:"Id>IBAN"`
+		Other string `xml:"Id>Othr>Id"`
+	}
+
+	pain001Transaction struct {
+		InstructionID   string         `xml:"PmtId>InstrId"`
+		EndToEndID      string         `xml:"PmtId>EndToEndId"`
+		Amount          pain001Amount  `xml:"Amt>InstdAmt"`
+		CreditorAccount pain001Account `xml:"CdtrAcct"`
+	}
+
+	pain001Amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+)
+
+// parse decodes a pain.001.001.09 customer credit transfer initiation, checking that it has the elements payments
+// are made of. Errors are types.ErrInvalidPaymentFile.
+func parse(r io.Reader) (pain001Document, error) {
+	var doc pain001Document
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return pain001Document{}, invalid("%s", err)
+	}
+
+	if doc.XMLName.Space != pain001Namespace {
+		return pain001Document{}, invalid("%s", errUnsupportedMessage)
+	}
+
+	if err := doc.Initiation.check(); err != nil {
+		return pain001Document{}, err
+	}
+
+	return doc, nil
+}
+
+func (i *pain001Initiation) check() error {
+	if err := checkText("MsgId", i.MessageID, true); err != nil {
+		return err
+	}
+
+	if _, err := parseDateTime(i.CreatedAt); err != nil {
+		return invalid("CreDtTm %q is not a date time", i.CreatedAt)
+	}
+
+	if count, err := parseCount(i.NumberOfTransactions); err != nil || count < 0 {
+		return invalid("NbOfTxs %q is not a number", i.NumberOfTransactions)
+	}
+
+	if i.ControlSum != "" && !decimalPattern.MatchString(i.ControlSum) {
+		return invalid("CtrlSum %q is not a decimal", i.ControlSum)
+	}
+
+	if len(i.PaymentInformations) == 0 {
+		return invalid("PmtInf is missing")
+	}
+
+	for _, p := range i.PaymentInformations {
+		if err := p.check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *pain001PaymentInformation) check() error {
+	if err := checkText("PmtInfId", p.ID, true); err != nil {
+		return err
+	}
+
+	if p.ExecutionDate == "" && p.ExecutionDateTime == "" {
+		return invalid("ReqdExctnDt of %q is missing", p.ID)
+	}
+
+	if _, err := p.executionDate(); err != nil {
+		return invalid("ReqdExctnDt of %q is not a date", p.ID)
+	}
+
+	if len(p.Transactions) == 0 {
+		return invalid("CdtTrfTxInf of %q is missing", p.ID)
+	}
+
+	for _, t := range p.Transactions {
+		if err := checkText("InstrId", t.InstructionID, false); err != nil {
+			return err
+		}
+
+		if err := checkText("EndToEndId", t.EndToEndID, true); err != nil {
+			return err
+		}
+
+		if !decimalPattern.MatchString(t.Amount.Value) {
+			return invalid("InstdAmt of %q is not a decimal", t.EndToEndID)
+		}
+	}
+
+	return nil
+}
+
+// executionDate returns the day the payments are requested to be executed.
+func (p *pain001PaymentInformation) executionDate() (time.Time, error) {
+	if p.ExecutionDate != "" {
+		return time.Parse(time.DateOnly, p.ExecutionDate) //nolint:wrapcheck // reported as invalid.
+	}
+
+	t, err := parseDateTime(p.ExecutionDateTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC().Truncate(24 * time.Hour), nil //nolint:mnd // a day.
+}
+
+// parseDateTime parses an ISO date time, which is in UTC without a time zone.
+func parseDateTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02T15:04:05.999999999", s) //nolint:wrapcheck // reported as invalid.
+}
+
+// checkText checks that the text is 35 characters at most, and that it is set if required.
+func checkText(name, value string, required bool) error {
+	if required && value == "" {
+		return invalid("%s is missing", name)
+	}
+
+	if len([]rune(value)) > maxTextLength {
+		return invalid("%s %q is longer than %d characters", name, value, maxTextLength)
+	}
+
+	return nil
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", types.ErrInvalidPaymentFile, fmt.Sprintf(format, args...))
+}
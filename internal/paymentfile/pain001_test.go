@@ -0,0 +1,130 @@
+package paymentfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// testInitiation returns a pain.001.001.09 document made of the group header and payment information given.
+func testInitiation(groupHeader, paymentInformation string) string {
+	return `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"><CstmrCdtTrfInitn>` +
+		`<GrpHdr>` + groupHeader + `</GrpHdr>` + paymentInformation + `</CstmrCdtTrfInitn></Document>`
+}
+
+const (
+	testGroupHeader        = `<MsgId>MSG-1</MsgId><CreDtTm>2024-05-01T09:00:00</CreDtTm><NbOfTxs>1</NbOfTxs>`
+	testPaymentInformation = `<PmtInf><PmtInfId>PMT-1</PmtInfId><PmtMtd>TRF</PmtMtd>` +
+		`<ReqdExctnDt><Dt>2024-05-01</Dt></ReqdExctnDt>` +
+		`<CdtTrfTxInf><PmtId><EndToEndId>E2E-1</EndToEndId></PmtId>` +
+		`<Amt><InstdAmt Ccy="EUR">10.00</InstdAmt></Amt></CdtTrfTxInf></PmtInf>`
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "failed when the file is not xml",
+			input:   "name,amount",
+			wantErr: "invalid payment file: EOF",
+		},
+		{
+			name: "failed when the message is not a pain.001.001.09",
+			input: strings.Replace(testInitiation(testGroupHeader, testPaymentInformation),
+				"pain.001.001.09", "pain.001.001.03", 1),
+			wantErr: "invalid payment file: only pain.001.001.09 messages are supported",
+		},
+		{
+			name:    "failed when the message identification is missing",
+			input:   testInitiation(`<CreDtTm>2024-05-01T09:00:00</CreDtTm><NbOfTxs>1</NbOfTxs>`, testPaymentInformation),
+			wantErr: "invalid payment file: MsgId is missing",
+		},
+		{
+			name:    "failed when the creation date time is invalid",
+			input:   testInitiation(`<MsgId>MSG-1</MsgId><CreDtTm>today</CreDtTm><NbOfTxs>1</NbOfTxs>`, testPaymentInformation),
+			wantErr: `invalid payment file: CreDtTm "today" is not a date time`,
+		},
+		{
+			name: "failed when the control sum is not a decimal",
+			input: testInitiation(testGroupHeader+`<CtrlSum>1,00</CtrlSum>`,
+				testPaymentInformation),
+			wantErr: `invalid payment file: CtrlSum "1,00" is not a decimal`,
+		},
+		{
+			name:    "failed when there are no payments",
+			input:   testInitiation(testGroupHeader, ""),
+			wantErr: "invalid payment file: PmtInf is missing",
+		},
+		{
+			name: "failed when the end to end identification is too long",
+			input: testInitiation(testGroupHeader,
+				strings.Replace(testPaymentInformation, "E2E-1", strings.Repeat("E", 36), 1)),
+			wantErr: `invalid payment file: EndToEndId "` + strings.Repeat("E", 36) +
+				`" is longer than 35 characters`,
+		},
+		{
+			name: "failed when the amount is not a decimal",
+			input: testInitiation(testGroupHeader,
+				strings.Replace(testPaymentInformation, "10.00", "-10.00", 1)),
+			wantErr: `invalid payment file: InstdAmt of "E2E-1" is not a decimal`,
+		},
+		{
+			name: "failed when the execution date is missing",
+			input: testInitiation(testGroupHeader,
+				strings.Replace(testPaymentInformation, "<ReqdExctnDt><Dt>2024-05-01</Dt></ReqdExctnDt>", "", 1)),
+			wantErr: `invalid payment file: ReqdExctnDt of "PMT-1" is missing`,
+		},
+		{
+			name:  "success",
+			input: testInitiation(testGroupHeader, testPaymentInformation),
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parse(strings.NewReader(tt.input))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, types.ErrInvalidPaymentFile)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParse_file(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("testdata", "payments.pain001.xml"))
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	doc, err := parse(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MSG-1", doc.Initiation.MessageID)
+	assert.Equal(t, "160.75", doc.Initiation.ControlSum)
+	require.Len(t, doc.Initiation.PaymentInformations, 2)
+	assert.Len(t, doc.Initiation.PaymentInformations[0].Transactions, 2)
+	assert.Equal(t, pain001Amount{Currency: "USD", Value: "10"},
+		doc.Initiation.PaymentInformations[1].Transactions[0].Amount)
+	assert.Equal(t, "DE89370400440532013000",
+		doc.Initiation.PaymentInformations[1].Transactions[0].CreditorAccount.IBAN)
+}
@@ -0,0 +1,168 @@
+package paymentfile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Report is the status of a payment file and of its payments, written as a pain.002 payment status report.
+	Report struct {
+		ID                   uuid.UUID
+		CreatedAt            time.Time
+		MessageID            string
+		MessageCreatedAt     time.Time
+		NumberOfTransactions int32
+		ControlSum           string
+		Status               string
+		Reason               *Reason
+		PaymentInformations  []PaymentInformationReport
+	}
+
+	// PaymentInformationReport is the status of the payments of a payment information block of a file.
+	PaymentInformationReport struct {
+		ID       string
+		Status   string
+		Payments []PaymentReport
+	}
+
+	// PaymentReport is the status of a payment, and the transaction made for it once accepted.
+	PaymentReport struct {
+		InstructionID string
+		EndToEndID    string
+		Status        string
+		Reason        *Reason
+		TransactionID uuid.NullUUID
+	}
+
+	// Reason is the reason of a status, an ISO 20022 status reason code and additional information.
+	Reason struct {
+		Code string
+		Info string
+	}
+)
+
+type (
+	pain002Document struct {
+		XMLName xml.Name      `xml:"urn:iso:std:iso:20022:tech:xsd:pain.002.001.10 Document"`
+		Report  pain002Report `xml:"CstmrPmtStsRpt"`
+	}
+
+	pain002Report struct {
+		MessageID           string                      `xml:"GrpHdr>MsgId"`
+		CreatedAt           string                      `xml:"GrpHdr>CreDtTm"`
+		Group               pain002Group                `xml:"OrgnlGrpInfAndSts"`
+		PaymentInformations []pain002PaymentInformation `xml:"OrgnlPmtInfAndSts"`
+	}
+
+	pain002Group struct {
+		MessageID            string         `xml:"OrgnlMsgId"`
+		MessageName          string         `xml:"OrgnlMsgNmId"`
+		CreatedAt            string         `xml:"OrgnlCreDtTm"`
+		NumberOfTransactions string         `xml:"OrgnlNbOfTxs"`
+		ControlSum           string         `xml:"OrgnlCtrlSum,omitempty"`
+		Status               string         `xml:"GrpSts"`
+		Reason               *pain002Reason `xml:"StsRsnInf,omitempty"`
+	}
+
+	pain002PaymentInformation struct {
+		ID                   string               `xml:"OrgnlPmtInfId"`
+		NumberOfTransactions string               `xml:"OrgnlNbOfTxs"`
+		Status               string               `xml:"PmtInfSts"`
+		Transactions         []pain002Transaction `xml:"TxInfAndSts"`
+	}
+
+	pain002Transaction struct {
+		InstructionID     string         `xml:"OrgnlInstrId,omitempty"`
+		EndToEndID        string         `xml:"OrgnlEndToEndId"`
+		Status            string         `xml:"TxSts"`
+		Reason            *pain002Reason `xml:"StsRsnInf,omitempty"`
+		ServicerReference string         `xml:"AcctSvcrRef,omitempty"`
+	}
+
+	pain002Reason struct {
+		Code string `xml:"Rsn>Cd"`
+		Info string `xml:"AddtlInf,omitempty"`
+	}
+)
+
+// WriteXML writes the report as a pain.002.001.10 customer payment status report, whose message identification is
+// the ID of the payment file, and whose accepted transactions are referenced by the ID of their transaction.
+func (r *Report) WriteXML(w io.Writer) error {
+	doc := pain002Document{
+		Report: pain002Report{
+			MessageID: reference(r.ID),
+			CreatedAt: r.CreatedAt.Format(time.RFC3339),
+			Group: pain002Group{
+				MessageID:            r.MessageID,
+				MessageName:          pain001MessageName,
+				CreatedAt:            r.MessageCreatedAt.Format(time.RFC3339),
+				NumberOfTransactions: strconv.Itoa(int(r.NumberOfTransactions)),
+				ControlSum:           r.ControlSum,
+				Status:               r.Status,
+				Reason:               newPain002Reason(r.Reason),
+			},
+		},
+	}
+
+	for _, p := range r.PaymentInformations {
+		info := pain002PaymentInformation{
+			ID:                   p.ID,
+			NumberOfTransactions: strconv.Itoa(len(p.Payments)),
+			Status:               p.Status,
+		}
+
+		for _, payment := range p.Payments {
+			t := pain002Transaction{
+				InstructionID: payment.InstructionID,
+				EndToEndID:    payment.EndToEndID,
+				Status:        payment.Status,
+				Reason:        newPain002Reason(payment.Reason),
+			}
+
+			if payment.TransactionID.Valid {
+				t.ServicerReference = reference(payment.TransactionID.UUID)
+			}
+
+			info.Transactions = append(info.Transactions, t)
+		}
+
+		doc.Report.PaymentInformations = append(doc.Report.PaymentInformations, info)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	return nil
+}
+
+func newPain002Reason(r *Reason) *pain002Reason {
+	if r == nil {
+		return nil
+	}
+
+	return &pain002Reason{Code: r.Code, Info: r.Info}
+}
+
+// reference returns the ID as 32 hexadecimal digits, which fit the 35 characters identifications of ISO 20022.
+func reference(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
@@ -0,0 +1,751 @@
+// Package paymentfile imports ISO 20022 pain.001 customer credit transfer initiations, the payment files corporates
+// upload: every credit transfer is validated and executed as a transfer from the account, and the outcome of the file
+// is reported as a pain.002 payment status report.
+package paymentfile
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/iban"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Statuses of payment files and payments, ISO 20022 payment group and transaction status codes.
+const (
+	StatusPending           = "PDNG"
+	StatusAccepted          = "ACSC"
+	StatusPartiallyAccepted = "PART"
+	StatusRejected          = "RJCT"
+)
+
+// Reasons of rejections, ISO 20022 status reason codes.
+const (
+	reasonDebtorAccount        = "AC02"
+	reasonCreditorAccount      = "AC03"
+	reasonZeroAmount           = "AM01"
+	reasonCurrency             = "AM03"
+	reasonInsufficientFunds    = "AM04"
+	reasonControlSum           = "AM10"
+	reasonAmount               = "AM12"
+	reasonLimitExceeded        = "AM14"
+	reasonFraud                = "FRAD"
+	reasonNumberOfTransactions = "AM18"
+	reasonExecutionDate        = "DT01"
+	reasonNarrative            = "NARR"
+
+	maxReasonInfoLength = 105
+
+	pqErrorAlreadyExist = "23505"
+)
+
+// AccountService transfers money from an account to another.
+type AccountService interface {
+	TransferMoney(
+		ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+}
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+// Auditor records the payment files imported within their transactions. The payments are recorded as the transfers
+// executing them.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.PaymentFileStore
+	storeWithTx func(tx pgx.Tx) storage.PaymentFileStore
+	auditor     Auditor
+	accounts    AccountService
+	holders     Holders
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service, executing payments as transfers of accounts.
+func New(
+	conn storage.DBConnection,
+	store storage.PaymentFileStore,
+	auditor Auditor,
+	accounts AccountService,
+	holders Holders,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.PaymentFileStoreWithTx,
+		auditor:     auditor,
+		accounts:    accounts,
+		holders:     holders,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Import imports a pain.001 payment file of the account and executes its payments, in the order of the file, for the
+// holders permitted to transfer from the account. The file and its payments are saved before any is executed, so that
+// a file is imported once, and payments are executed even if ctx is canceled, so that it is imported in full. A file
+// imported already is resumed while it is pending: the payments it was stopped before executing are executed, the
+// payments whose transfer was made or held being linked to it when it was.
+// returns Report.
+func (s *Service) Import(ctx context.Context, accountID uuid.UUID, r io.Reader) (Report, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionTransfer); err != nil {
+		return Report{}, err
+	}
+
+	doc, err := parse(r)
+	if err != nil {
+		return Report{}, err
+	}
+
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Report{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return Report{}, types.ErrInternal
+	}
+
+	file, payments, err := s.create(ctx, doc, account)
+	if errors.Is(err, types.ErrPaymentFileAlreadyImported) {
+		file, payments, err = s.resume(ctx, accountID, doc.Initiation.MessageID)
+	}
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	for i, p := range payments {
+		if executable(p) {
+			payments[i] = s.execute(ctx, accountID, p)
+		}
+	}
+
+	return s.settle(ctx, file, payments), nil
+}
+
+// GetReport returns the status report of a payment file of the account, for the holders permitted to view it.
+// returns Report.
+func (s *Service) GetReport(ctx context.Context, accountID, paymentFileID uuid.UUID) (Report, error) {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return Report{}, err
+	}
+
+	file, err := s.store.GetPaymentFile(ctx, storage.GetPaymentFileParams{
+		AccountID:     accountID,
+		PaymentFileID: paymentFileID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Report{}, types.ErrPaymentFileNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get payment file", "error", err)
+
+		return Report{}, types.ErrInternal
+	}
+
+	payments, err := s.store.ListPayments(ctx, paymentFileID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list payments", "error", err)
+
+		return Report{}, types.ErrInternal
+	}
+
+	return s.settle(ctx, file, payments), nil
+}
+
+// resume returns a pending file of the account imported already, with its payments, failing with
+// types.ErrPaymentFileAlreadyImported when it is no longer pending.
+func (s *Service) resume(
+	ctx context.Context,
+	accountID uuid.UUID,
+	messageID string,
+) (storage.PaymentFile, []storage.Payment, error) {
+	file, err := s.store.GetPaymentFileByMessageID(ctx, storage.GetPaymentFileByMessageIDParams{
+		AccountID: accountID,
+		MessageID: messageID,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get payment file", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	if file.Status != StatusPending {
+		return storage.PaymentFile{}, nil, types.ErrPaymentFileAlreadyImported
+	}
+
+	payments, err := s.store.ListPayments(ctx, file.PaymentFileID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list payments", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	s.logger.InfoContext(ctx, "payment file resumed", "payment_file_id", file.PaymentFileID)
+
+	return file, payments, nil
+}
+
+// settle decides the pending payments of a pending file whose held transfer was decided since, and the file once none
+// of its payments is pending, returning its report. The decisions are reported even if they cannot be saved, they are
+// saved again the next time.
+func (s *Service) settle(ctx context.Context, file storage.PaymentFile, payments []storage.Payment) Report {
+	now := pgtype.Timestamptz{Time: s.now().UTC(), Valid: true}
+
+	if file.Status != StatusPending {
+		return newReport(file, payments, now.Time)
+	}
+
+	held, err := s.store.ListHeldPayments(ctx, file.PaymentFileID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list held payments", "error", err)
+	}
+
+	decided := make(map[uuid.UUID]storage.Payment, len(held))
+
+	for _, h := range held {
+		p, ok := decide(h)
+		if !ok {
+			continue
+		}
+
+		p.UpdatedAt = now
+		decided[p.PaymentID] = p
+
+		if err := s.store.UpdatePayment(ctx, storage.UpdatePaymentParams{
+			PaymentID:     p.PaymentID,
+			Status:        p.Status,
+			ReasonCode:    p.ReasonCode,
+			Reason:        p.Reason,
+			TransactionID: p.TransactionID,
+			UpdatedAt:     p.UpdatedAt,
+		}); err != nil {
+			s.logger.ErrorContext(ctx, "failed to update payment", "error", err)
+		}
+	}
+
+	for i, p := range payments {
+		if d, ok := decided[p.PaymentID]; ok {
+			payments[i] = d
+		}
+	}
+
+	if status := statusOf(payments); status != StatusPending {
+		file.Status = status
+		file.UpdatedAt = now
+
+		if err := s.store.UpdatePaymentFileStatus(ctx, storage.UpdatePaymentFileStatusParams{
+			PaymentFileID: file.PaymentFileID,
+			Status:        file.Status,
+			UpdatedAt:     file.UpdatedAt,
+		}); err != nil {
+			s.logger.ErrorContext(ctx, "failed to update payment file status", "error", err)
+		}
+	}
+
+	return newReport(file, payments, now.Time)
+}
+
+// create validates the file and saves it with its payments at once, recording its import.
+func (s *Service) create(
+	ctx context.Context,
+	doc pain001Document,
+	account storage.Account,
+) (storage.PaymentFile, []storage.Payment, error) {
+	file, payments := validate(doc, account, s.now().UTC())
+
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	store := s.storeWithTx(tx)
+
+	created, err := store.CreatePaymentFile(ctx, file)
+	if err != nil {
+		pgErr := &pgconn.PgError{}
+		if errors.As(err, &pgErr) && pgErr.Code == pqErrorAlreadyExist {
+			return storage.PaymentFile{}, nil, types.ErrPaymentFileAlreadyImported
+		}
+
+		s.logger.ErrorContext(ctx, "failed to create payment file", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	added := make([]storage.Payment, 0, len(payments))
+
+	for _, p := range payments {
+		p.PaymentFileID = created.PaymentFileID
+
+		payment, err := store.AddPayment(ctx, p)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to add payment", "error", err)
+
+			return storage.PaymentFile{}, nil, types.ErrInternal
+		}
+
+		added = append(added, payment)
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    audit.ActionImportPaymentFile,
+		AccountID: uuid.NullUUID{UUID: account.AccountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After:     newReport(created, added, created.CreatedAt.Time),
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return storage.PaymentFile{}, nil, types.ErrInternal
+	}
+
+	return created, added, nil
+}
+
+// execute transfers the amount of a pending payment to its creditor account. The transfer saves the payment when it is
+// made or held, see Links, and the payment is saved when it is rejected. The status is reported even if it cannot be
+// saved, as the transfer is not made regardless.
+func (s *Service) execute(ctx context.Context, accountID uuid.UUID, p storage.Payment) storage.Payment {
+	req := &types.TransferMoneyRequest{Amount: storage.AmountFromNumeric(p.Amount, p.CurrencyCode)}
+
+	// The creditor account was validated before the payment was saved, it is either an ID or an IBAN.
+	if creditorAccountID, err := uuid.Parse(p.CreditorAccount); err == nil {
+		req.ReciverAccountID = creditorAccountID
+	} else {
+		req.ReciverIBAN = p.CreditorAccount
+	}
+
+	res, err := s.accounts.TransferMoney(ContextWithPayment(ctx, p.PaymentID), req, accountID)
+
+	p.UpdatedAt = pgtype.Timestamptz{Time: s.now().UTC(), Valid: true}
+
+	var (
+		approval *types.PendingApprovalError
+		review   *types.PendingReviewError
+	)
+
+	switch {
+	case errors.Is(err, ErrPaymentExecuted):
+		// The payment is executed by another import of its file, whose report has its status.
+		s.logger.InfoContext(ctx, "payment already executed", "payment_id", p.PaymentID)
+	case errors.As(err, &approval), errors.As(err, &review):
+		// The transfer is made if the admin or a second holder approves it, the payment is pending until then.
+		p.ReasonCode, p.Reason = reasonColumns(&Reason{Code: reasonNarrative, Info: err.Error()})
+
+		if approval != nil {
+			p.TransferApprovalID = uuid.NullUUID{UUID: approval.TransferApprovalID, Valid: true}
+		} else {
+			p.PendingTransferID = uuid.NullUUID{UUID: review.PendingTransferID, Valid: true}
+		}
+	case err != nil:
+		p.Status = StatusRejected
+		p.ReasonCode, p.Reason = reasonColumns(transferReason(err))
+
+		if _, err := s.store.ExecutePayment(ctx, storage.ExecutePaymentParams{
+			PaymentID:  p.PaymentID,
+			Status:     p.Status,
+			ReasonCode: p.ReasonCode,
+			Reason:     p.Reason,
+			UpdatedAt:  p.UpdatedAt,
+		}); err != nil {
+			s.logger.ErrorContext(ctx, "failed to update payment", "error", err)
+		}
+	default:
+		p.Status = StatusAccepted
+		p.TransactionID = uuid.NullUUID{UUID: res.TransactionID, Valid: true}
+	}
+
+	return p
+}
+
+// executable reports whether a payment is to be executed: it is pending, and its transfer was neither made nor held.
+func executable(p storage.Payment) bool {
+	return p.Status == StatusPending && !p.TransactionID.Valid && !p.TransferApprovalID.Valid &&
+		!p.PendingTransferID.Valid
+}
+
+// decide returns a held payment decided as its transfer approval or pending transfer was: accepted with the
+// transaction received by its transfer once approved and made, rejected once rejected. It reports false while the
+// held transfer is undecided.
+func decide(h storage.ListHeldPaymentsRow) (storage.Payment, bool) {
+	p := h.Payment
+
+	switch {
+	case h.ApprovalTransactionID.Valid:
+		p.Status, p.TransactionID = StatusAccepted, h.ApprovalTransactionID
+	case h.ReviewTransactionID.Valid:
+		p.Status, p.TransactionID = StatusAccepted, h.ReviewTransactionID
+	case h.ApprovalStatus.String == types.TransferApprovalStatusRejected:
+		p.Status = StatusRejected
+		p.ReasonCode, p.Reason = reasonColumns(&Reason{Code: reasonNarrative, Info: "the transfer was rejected"})
+	case h.ReviewStatus.String == types.PendingTransferStatusRejected:
+		p.Status = StatusRejected
+		p.ReasonCode, p.Reason = reasonColumns(&Reason{Code: reasonFraud})
+	default:
+		return storage.Payment{}, false
+	}
+
+	if p.Status == StatusAccepted {
+		p.ReasonCode, p.Reason = pgtype.Text{}, pgtype.Text{}
+	}
+
+	return p, true
+}
+
+// validate returns the file and its payments to save, rejecting those which cannot be executed. The file is rejected
+// as a whole when its number of transactions or its control sum does not match its payments.
+func validate(
+	doc pain001Document,
+	account storage.Account,
+	now time.Time,
+) (storage.CreatePaymentFileParams, []storage.AddPaymentParams) {
+	initiation := doc.Initiation
+	createdAt, _ := parseDateTime(initiation.CreatedAt)     // checked when parsed.
+	count, _ := parseCount(initiation.NumberOfTransactions) // checked when parsed.
+
+	file := storage.CreatePaymentFileParams{
+		AccountID:            account.AccountID,
+		MessageID:            initiation.MessageID,
+		MessageCreatedAt:     pgtype.Timestamptz{Time: createdAt, Valid: true},
+		NumberOfTransactions: count,
+		Status:               StatusPending,
+		CreatedAt:            pgtype.Timestamptz{Time: now, Valid: true},
+	}
+
+	if initiation.ControlSum != "" {
+		_ = file.ControlSum.Scan(initiation.ControlSum) // checked when parsed.
+	}
+
+	var transactions []pain001Transaction
+	for _, p := range initiation.PaymentInformations {
+		transactions = append(transactions, p.Transactions...)
+	}
+
+	if reason := checkSums(initiation.NumberOfTransactions, initiation.ControlSum, transactions); reason != nil {
+		file.Status = StatusRejected
+		file.ReasonCode, file.Reason = reasonColumns(reason)
+
+		return file, nil
+	}
+
+	var payments []storage.AddPaymentParams
+
+	for _, p := range initiation.PaymentInformations {
+		rejection := checkPaymentInformation(p, account.AccountID, now)
+
+		for _, t := range p.Transactions {
+			payment := storage.AddPaymentParams{
+				Position:             int32(len(payments)), //nolint:gosec // bounded by the number of transactions.
+				PaymentInformationID: p.ID,
+				InstructionID:        pgtype.Text{String: t.InstructionID, Valid: t.InstructionID != ""},
+				EndToEndID:           t.EndToEndID,
+				CurrencyCode:         t.Amount.Currency,
+				CreditorAccount:      creditorAccount(t.CreditorAccount),
+				Status:               StatusPending,
+				CreatedAt:            pgtype.Timestamptz{Time: now, Valid: true},
+			}
+
+			amount, reason := checkTransaction(t, account)
+			if reason == nil {
+				payment.Amount = storage.NumericFromAmount(amount, account.CurrencyCode)
+			}
+
+			if rejection != nil {
+				reason = rejection
+			}
+
+			if reason != nil {
+				payment.Status = StatusRejected
+				payment.ReasonCode, payment.Reason = reasonColumns(reason)
+			}
+
+			payments = append(payments, payment)
+		}
+	}
+
+	return file, payments
+}
+
+// checkSums checks the number of transactions and the control sum, if any, of a group of transactions.
+func checkSums(numberOfTransactions, controlSum string, transactions []pain001Transaction) *Reason {
+	if count, err := parseCount(numberOfTransactions); err != nil || int(count) != len(transactions) {
+		return &Reason{
+			Code: reasonNumberOfTransactions,
+			Info: "the number of transactions does not match the transactions",
+		}
+	}
+
+	if controlSum == "" {
+		return nil
+	}
+
+	sum := new(big.Rat)
+
+	for _, t := range transactions {
+		sum.Add(sum, parseDecimal(t.Amount.Value))
+	}
+
+	if parseDecimal(controlSum).Cmp(sum) != 0 {
+		return &Reason{Code: reasonControlSum, Info: "the control sum does not match the amounts of the transactions"}
+	}
+
+	return nil
+}
+
+// checkPaymentInformation returns why the payments of a payment information block are rejected, if they are.
+func checkPaymentInformation(p pain001PaymentInformation, accountID uuid.UUID, now time.Time) *Reason {
+	if p.Method != methodTransfer {
+		return &Reason{Code: reasonNarrative, Info: "only credit transfers are supported"}
+	}
+
+	if debtorAccountID, err := uuid.Parse(p.DebtorAccount.Other); err != nil || debtorAccountID != accountID {
+		return &Reason{Code: reasonDebtorAccount, Info: "the debtor account is not the account of the file"}
+	}
+
+	if date, _ := p.executionDate(); date.After(now) { // checked when parsed.
+		return &Reason{Code: reasonExecutionDate, Info: "payments cannot be scheduled"}
+	}
+
+	if p.NumberOfTransactions != "" || p.ControlSum != "" {
+		numberOfTransactions := p.NumberOfTransactions
+		if numberOfTransactions == "" {
+			numberOfTransactions = formatCount(len(p.Transactions))
+		}
+
+		return checkSums(numberOfTransactions, p.ControlSum, p.Transactions)
+	}
+
+	return nil
+}
+
+// checkTransaction returns the amount of a credit transfer, in minor units of the currency of the account, or why it
+// is rejected.
+func checkTransaction(t pain001Transaction, account storage.Account) (money.Amount, *Reason) {
+	if t.Amount.Currency != account.CurrencyCode {
+		return 0, &Reason{Code: reasonCurrency, Info: "the currency is not the currency of the account"}
+	}
+
+	if reason := checkCreditorAccount(t.CreditorAccount, account); reason != nil {
+		return 0, reason
+	}
+
+	amount := parseDecimal(t.Amount.Value)
+	if amount.Sign() == 0 {
+		return 0, &Reason{Code: reasonZeroAmount}
+	}
+
+	// Amounts are in minor units of the currency, e.g. cents.
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(money.GetCurrency(account.CurrencyCode).Fraction)), nil) //nolint:mnd,lll // decimal.
+	amount.Mul(amount, new(big.Rat).SetInt(unit))
+
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return 0, &Reason{Code: reasonAmount, Info: "the amount has more decimals than the currency"}
+	}
+
+	return amount.Num().Int64(), nil
+}
+
+// transferReason returns why a transfer failed.
+func transferReason(err error) *Reason {
+	switch {
+	case errors.Is(err, types.ErrInsufficientAccountBalance):
+		return &Reason{Code: reasonInsufficientFunds}
+	case errors.Is(err, types.ErrRecieverAccountNotFound):
+		return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is not an account of the bank"}
+	case errors.Is(err, types.ErrPocketTransfer):
+		return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is a pocket"}
+	case errors.Is(err, types.ErrCurrencyMismatch):
+		return &Reason{Code: reasonCurrency, Info: "the currency is not the currency of the creditor account"}
+	case errors.Is(err, types.ErrLimitExceeded):
+		return &Reason{Code: reasonLimitExceeded, Info: err.Error()}
+	case errors.Is(err, types.ErrTransferDenied):
+		return &Reason{Code: reasonFraud}
+	case errors.Is(err, types.ErrInternal):
+		return &Reason{Code: reasonNarrative, Info: "internal error"}
+	default:
+		return &Reason{Code: reasonNarrative, Info: err.Error()}
+	}
+}
+
+// checkCreditorAccount returns why a creditor account is rejected, if it is: it is identified by its IBAN or by its
+// ID, and it is not the debtor account.
+func checkCreditorAccount(a pain001Account, debtor storage.Account) *Reason {
+	if a.IBAN != "" {
+		if !iban.Valid(a.IBAN) {
+			return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is not a valid iban"}
+		}
+
+		if iban.Normalize(a.IBAN) == debtor.IBAN.String {
+			return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is the debtor account"}
+		}
+
+		return nil
+	}
+
+	creditorAccountID, err := uuid.Parse(a.Other)
+	if err != nil {
+		return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is not an account of the bank"}
+	}
+
+	if creditorAccountID == debtor.AccountID {
+		return &Reason{Code: reasonCreditorAccount, Info: "the creditor account is the debtor account"}
+	}
+
+	return nil
+}
+
+// creditorAccount returns the identification of the creditor account, its IBAN in electronic format or another
+// identification.
+func creditorAccount(a pain001Account) string {
+	if a.IBAN != "" {
+		return iban.Normalize(a.IBAN)
+	}
+
+	return a.Other
+}
+
+// parseCount parses a number of transactions.
+func parseCount(s string) (int32, error) {
+	count, err := strconv.ParseInt(s, 10, 32)
+
+	return int32(count), err
+}
+
+// parseDecimal parses a decimal checked when the file was parsed, which is 36 characters at most.
+func parseDecimal(s string) *big.Rat {
+	r, _ := new(big.Rat).SetString(s) //nolint:gosec // bounded by decimalPattern.
+
+	return r
+}
+
+func formatCount(count int) string {
+	return strconv.Itoa(count)
+}
+
+func reasonColumns(r *Reason) (pgtype.Text, pgtype.Text) {
+	info := []rune(r.Info)
+	if len(info) > maxReasonInfoLength {
+		info = info[:maxReasonInfoLength]
+	}
+
+	return pgtype.Text{String: r.Code, Valid: true}, pgtype.Text{String: string(info), Valid: len(info) > 0}
+}
+
+// statusOf returns the status of a group of payments: pending while any of them is, accepted or rejected when all of
+// them are, partially accepted otherwise.
+func statusOf(payments []storage.Payment) string {
+	var accepted, rejected int
+
+	for _, p := range payments {
+		switch p.Status {
+		case StatusAccepted:
+			accepted++
+		case StatusRejected:
+			rejected++
+		}
+	}
+
+	switch {
+	case accepted+rejected < len(payments):
+		return StatusPending
+	case accepted == len(payments):
+		return StatusAccepted
+	case rejected == len(payments):
+		return StatusRejected
+	default:
+		return StatusPartiallyAccepted
+	}
+}
+
+func newReport(file storage.PaymentFile, payments []storage.Payment, now time.Time) Report {
+	report := Report{
+		ID:                   file.PaymentFileID,
+		CreatedAt:            now,
+		MessageID:            file.MessageID,
+		MessageCreatedAt:     file.MessageCreatedAt.Time.UTC(),
+		NumberOfTransactions: file.NumberOfTransactions,
+		Status:               file.Status,
+		Reason:               reasonOf(file.ReasonCode, file.Reason),
+	}
+
+	if file.ControlSum.Valid {
+		if v, err := file.ControlSum.Value(); err == nil {
+			report.ControlSum, _ = v.(string)
+		}
+	}
+
+	// Payments are grouped by payment information block, in the order of the file.
+	group := make([]storage.Payment, 0, len(payments))
+
+	for i, p := range payments {
+		group = append(group, p)
+
+		if i+1 < len(payments) && payments[i+1].PaymentInformationID == p.PaymentInformationID {
+			continue
+		}
+
+		info := PaymentInformationReport{
+			ID:     p.PaymentInformationID,
+			Status: statusOf(group),
+		}
+
+		for _, payment := range group {
+			info.Payments = append(info.Payments, PaymentReport{
+				InstructionID: payment.InstructionID.String,
+				EndToEndID:    payment.EndToEndID,
+				Status:        payment.Status,
+				Reason:        reasonOf(payment.ReasonCode, payment.Reason),
+				TransactionID: payment.TransactionID,
+			})
+		}
+
+		report.PaymentInformations = append(report.PaymentInformations, info)
+		group = group[:0]
+	}
+
+	return report
+}
+
+func reasonOf(code, info pgtype.Text) *Reason {
+	if !code.Valid {
+		return nil
+	}
+
+	return &Reason{Code: code.String, Info: info.String}
+}
@@ -0,0 +1,629 @@
+package paymentfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var (
+	wantAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantPaymentFileID = uuid.MustParse("12345678-1234-1234-1234-123456789030")
+	wantTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789040")
+	wantNow           = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	errAnything       = errors.New("any")
+)
+
+// transferFunc is an AccountService made of a function.
+type transferFunc func(req *types.TransferMoneyRequest) (types.TransferMoneyResponse, error)
+
+func (f transferFunc) TransferMoney(
+	_ context.Context, req *types.TransferMoneyRequest, _ uuid.UUID,
+) (types.TransferMoneyResponse, error) {
+	return f(req)
+}
+
+// assertGolden asserts that got is the content of the golden file, which is written instead with -update.
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func testFile(t *testing.T) string {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "payments.pain001.xml"))
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func testAccount() storage.Account {
+	return storage.Account{AccountID: wantAccountID, Name: "name", CurrencyCode: "EUR"}
+}
+
+// expectCreate expects the file and its payments to be saved as they are given.
+func expectCreate(store *storageMocks.MockPaymentFileStore) {
+	store.EXPECT().CreatePaymentFile(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, p storage.CreatePaymentFileParams) (storage.PaymentFile, error) {
+			return storage.PaymentFile{
+				PaymentFileID:        wantPaymentFileID,
+				AccountID:            p.AccountID,
+				MessageID:            p.MessageID,
+				MessageCreatedAt:     p.MessageCreatedAt,
+				NumberOfTransactions: p.NumberOfTransactions,
+				ControlSum:           p.ControlSum,
+				Status:               p.Status,
+				ReasonCode:           p.ReasonCode,
+				Reason:               p.Reason,
+				CreatedAt:            p.CreatedAt,
+				UpdatedAt:            p.CreatedAt,
+			}, nil
+		}).Once()
+	store.EXPECT().AddPayment(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, p storage.AddPaymentParams) (storage.Payment, error) {
+			return storage.Payment{
+				PaymentID:            uuid.New(),
+				PaymentFileID:        p.PaymentFileID,
+				Position:             p.Position,
+				PaymentInformationID: p.PaymentInformationID,
+				InstructionID:        p.InstructionID,
+				EndToEndID:           p.EndToEndID,
+				Amount:               p.Amount,
+				CurrencyCode:         p.CurrencyCode,
+				CreditorAccount:      p.CreditorAccount,
+				Status:               p.Status,
+				ReasonCode:           p.ReasonCode,
+				Reason:               p.Reason,
+				CreatedAt:            p.CreatedAt,
+				UpdatedAt:            p.CreatedAt,
+			}, nil
+		}).Maybe()
+}
+
+type importTest struct {
+	name       string
+	input      func(t *testing.T) string
+	mock       func(*storageMocks.MockPaymentFileStore, *storageMocks.MockDBConnection, *txMocks.MockTx)
+	transfer   transferFunc
+	permitErr  error
+	wantErr    error
+	wantGolden string
+}
+
+// importResumeTests are the imports of files imported already.
+func importResumeTests() []importTest {
+	return []importTest{
+		{
+			name:  "failed when the file was already imported",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				ms.EXPECT().CreatePaymentFile(mock.Anything, mock.Anything).
+					Return(storage.PaymentFile{}, &pgconn.PgError{Code: pqErrorAlreadyExist}).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+				ms.EXPECT().GetPaymentFileByMessageID(mock.Anything, storage.GetPaymentFileByMessageIDParams{
+					AccountID: wantAccountID,
+					MessageID: "MSG-1",
+				}).Return(storage.PaymentFile{Status: StatusPartiallyAccepted}, nil).Once()
+			},
+			wantErr: types.ErrPaymentFileAlreadyImported,
+		},
+		{
+			name:  "success when a pending file is resumed",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				pending := storage.Payment{
+					PaymentID:            uuid.MustParse("12345678-1234-1234-1234-123456789053"),
+					PaymentInformationID: "PMT-2",
+					EndToEndID:           "E2E-3",
+					Amount:               storage.NumericFromAmount(2000, "EUR"),
+					CurrencyCode:         "EUR",
+					CreditorAccount:      "DE89370400440532013000",
+					Status:               StatusPending,
+				}
+
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				ms.EXPECT().CreatePaymentFile(mock.Anything, mock.Anything).
+					Return(storage.PaymentFile{}, &pgconn.PgError{Code: pqErrorAlreadyExist}).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+				ms.EXPECT().GetPaymentFileByMessageID(mock.Anything, mock.Anything).Return(storage.PaymentFile{
+					PaymentFileID:        wantPaymentFileID,
+					MessageID:            "MSG-1",
+					NumberOfTransactions: 3,
+					Status:               StatusPending,
+				}, nil).Once()
+				// Only the payment whose transfer was neither made nor held before the import stopped is executed.
+				ms.EXPECT().ListPayments(mock.Anything, wantPaymentFileID).Return([]storage.Payment{
+					{
+						PaymentInformationID: "PMT-1",
+						EndToEndID:           "E2E-1",
+						Status:               StatusAccepted,
+						TransactionID:        uuid.NullUUID{UUID: uuid.New(), Valid: true},
+					},
+					{
+						PaymentInformationID: "PMT-1",
+						EndToEndID:           "E2E-2",
+						Status:               StatusPending,
+						TransferApprovalID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+					},
+					pending,
+				}, nil).Once()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+			},
+			transfer: func(req *types.TransferMoneyRequest) (types.TransferMoneyResponse, error) {
+				if req.ReciverIBAN != "DE89370400440532013000" {
+					return types.TransferMoneyResponse{}, errAnything
+				}
+
+				return types.TransferMoneyResponse{TransactionID: wantTransactionID}, nil
+			},
+		},
+		{
+			name:  "success when a payment is executed by another import of the file",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				expectCreate(ms)
+				mt.EXPECT().Commit(mock.Anything).Return(nil).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+			},
+			transfer: func(*types.TransferMoneyRequest) (types.TransferMoneyResponse, error) {
+				return types.TransferMoneyResponse{}, ErrPaymentExecuted
+			},
+		},
+	}
+}
+
+func TestService_Import(t *testing.T) {
+	t.Parallel()
+
+	tests := append([]importTest{
+		{
+			name:      "failed when not permitted",
+			input:     testFile,
+			mock:      func(*storageMocks.MockPaymentFileStore, *storageMocks.MockDBConnection, *txMocks.MockTx) {},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name:    "failed when the file is invalid",
+			input:   func(*testing.T) string { return "<Document/>" },
+			mock:    func(*storageMocks.MockPaymentFileStore, *storageMocks.MockDBConnection, *txMocks.MockTx) {},
+			wantErr: types.ErrInvalidPaymentFile,
+		},
+		{
+			name:  "failed when account not found",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, _ *storageMocks.MockDBConnection, _ *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:  "failed when a payment fails to be saved",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				ms.EXPECT().CreatePaymentFile(mock.Anything, mock.Anything).
+					Return(storage.PaymentFile{PaymentFileID: wantPaymentFileID}, nil).Once()
+				ms.EXPECT().AddPayment(mock.Anything, mock.Anything).Return(storage.Payment{}, errAnything).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when the file is rejected as a whole",
+			input: func(t *testing.T) string {
+				t.Helper()
+
+				return strings.Replace(testFile(t), "<NbOfTxs>3</NbOfTxs>", "<NbOfTxs>4</NbOfTxs>", 1)
+			},
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				expectCreate(ms)
+				mt.EXPECT().Commit(mock.Anything).Return(nil).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			},
+			wantGolden: "rejected.pain002.xml",
+		},
+		{
+			name:  "success when some payments are accepted",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				expectCreate(ms)
+				mt.EXPECT().Commit(mock.Anything).Return(nil).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+				ms.EXPECT().ExecutePayment(mock.Anything, mock.MatchedBy(func(p storage.ExecutePaymentParams) bool {
+					return p.Status == StatusRejected && p.ReasonCode.String == reasonInsufficientFunds
+				})).Return(1, nil).Once()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+				ms.EXPECT().UpdatePaymentFileStatus(mock.Anything, storage.UpdatePaymentFileStatusParams{
+					PaymentFileID: wantPaymentFileID,
+					Status:        StatusPartiallyAccepted,
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+			},
+			transfer: func(req *types.TransferMoneyRequest) (types.TransferMoneyResponse, error) {
+				if req.ReciverAccountID == uuid.MustParse("12345678-1234-1234-1234-123456789002") && req.Amount == 10050 {
+					return types.TransferMoneyResponse{TransactionID: wantTransactionID}, nil
+				}
+
+				return types.TransferMoneyResponse{}, types.ErrInsufficientAccountBalance
+			},
+			wantGolden: "report.pain002.xml",
+		},
+		{
+			name:  "success when a creditor account is in another currency",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				expectCreate(ms)
+				mt.EXPECT().Commit(mock.Anything).Return(nil).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+				ms.EXPECT().ExecutePayment(mock.Anything, mock.MatchedBy(func(p storage.ExecutePaymentParams) bool {
+					return p.Status == StatusRejected && p.ReasonCode.String == reasonCurrency
+				})).Return(1, nil).Twice()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+				ms.EXPECT().UpdatePaymentFileStatus(mock.Anything, storage.UpdatePaymentFileStatusParams{
+					PaymentFileID: wantPaymentFileID,
+					Status:        StatusRejected,
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+			},
+			transfer: func(*types.TransferMoneyRequest) (types.TransferMoneyResponse, error) {
+				return types.TransferMoneyResponse{}, types.ErrCurrencyMismatch
+			},
+		},
+		{
+			name:  "success when a payment is held for review",
+			input: testFile,
+			mock: func(ms *storageMocks.MockPaymentFileStore, mc *storageMocks.MockDBConnection, mt *txMocks.MockTx) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount(), nil).Once()
+				mc.EXPECT().Begin(mock.Anything).Return(mt, nil).Once()
+				expectCreate(ms)
+				mt.EXPECT().Commit(mock.Anything).Return(nil).Once()
+				mt.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+				// The file is pending until the held transfer is decided.
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+			},
+			transfer: func(req *types.TransferMoneyRequest) (types.TransferMoneyResponse, error) {
+				if req.Amount == 10050 {
+					return types.TransferMoneyResponse{TransactionID: wantTransactionID}, nil
+				}
+
+				return types.TransferMoneyResponse{}, &types.PendingReviewError{
+					PendingTransferID: uuid.MustParse("12345678-1234-1234-1234-123456789005"),
+				}
+			},
+			wantGolden: "pending.pain002.xml",
+		},
+	}, importResumeTests()...)
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockPaymentFileStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			tt.mock(store, conn, tx)
+
+			holders := holdertest.Authorize(t, wantAccountID, holder.PermissionTransfer, tt.permitErr)
+
+			auditor := recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionImportPaymentFile, event.Action)
+				assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+				return nil
+			})
+
+			s := New(conn, store, auditor, tt.transfer, holders, slog.Default())
+			s.storeWithTx = func(pgx.Tx) storage.PaymentFileStore { return store }
+			s.now = func() time.Time { return wantNow }
+
+			got, err := s.Import(context.Background(), wantAccountID, strings.NewReader(tt.input(t)))
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantGolden == "" {
+				return
+			}
+
+			var b bytes.Buffer
+
+			require.NoError(t, got.WriteXML(&b))
+			assertGolden(t, tt.wantGolden, b.Bytes())
+		})
+	}
+}
+
+func TestService_GetReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mock      func(*storageMocks.MockPaymentFileStore)
+		permitErr error
+		want      Report
+		wantErr   error
+	}{
+		{
+			name:      "failed when not permitted",
+			mock:      func(*storageMocks.MockPaymentFileStore) {},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name: "failed when payment file not found",
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().GetPaymentFile(mock.Anything, storage.GetPaymentFileParams{
+					AccountID:     wantAccountID,
+					PaymentFileID: wantPaymentFileID,
+				}).Return(storage.PaymentFile{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrPaymentFileNotFound,
+		},
+		{
+			name: "failed when payments fail to be listed",
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().GetPaymentFile(mock.Anything, mock.Anything).
+					Return(storage.PaymentFile{PaymentFileID: wantPaymentFileID}, nil).Once()
+				ms.EXPECT().ListPayments(mock.Anything, wantPaymentFileID).Return(nil, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				ms.EXPECT().GetPaymentFile(mock.Anything, mock.Anything).Return(storage.PaymentFile{
+					PaymentFileID:        wantPaymentFileID,
+					MessageID:            "MSG-1",
+					NumberOfTransactions: 2,
+					Status:               StatusPending,
+				}, nil).Once()
+				ms.EXPECT().ListPayments(mock.Anything, wantPaymentFileID).Return([]storage.Payment{
+					{PaymentInformationID: "PMT-1", EndToEndID: "E2E-1", Status: StatusPending},
+					{
+						PaymentInformationID: "PMT-2",
+						EndToEndID:           "E2E-2",
+						Status:               StatusRejected,
+						ReasonCode:           pgtype.Text{String: reasonCurrency, Valid: true},
+					},
+				}, nil).Once()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return(nil, nil).Once()
+			},
+			want: Report{
+				ID:                   wantPaymentFileID,
+				CreatedAt:            wantNow,
+				MessageID:            "MSG-1",
+				MessageCreatedAt:     time.Time{}.UTC(),
+				NumberOfTransactions: 2,
+				Status:               StatusPending,
+				PaymentInformations: []PaymentInformationReport{
+					{
+						ID:       "PMT-1",
+						Status:   StatusPending,
+						Payments: []PaymentReport{{EndToEndID: "E2E-1", Status: StatusPending}},
+					},
+					{
+						ID:     "PMT-2",
+						Status: StatusRejected,
+						Payments: []PaymentReport{
+							{EndToEndID: "E2E-2", Status: StatusRejected, Reason: &Reason{Code: reasonCurrency}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success when the held payments are decided",
+			mock: func(ms *storageMocks.MockPaymentFileStore) {
+				approved := storage.Payment{
+					PaymentID:            uuid.MustParse("12345678-1234-1234-1234-123456789051"),
+					PaymentInformationID: "PMT-1",
+					EndToEndID:           "E2E-1",
+					Status:               StatusPending,
+					ReasonCode:           pgtype.Text{String: reasonNarrative, Valid: true},
+					TransferApprovalID:   uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}
+				rejected := storage.Payment{
+					PaymentID:            uuid.MustParse("12345678-1234-1234-1234-123456789052"),
+					PaymentInformationID: "PMT-1",
+					EndToEndID:           "E2E-2",
+					Status:               StatusPending,
+					ReasonCode:           pgtype.Text{String: reasonNarrative, Valid: true},
+					PendingTransferID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}
+
+				ms.EXPECT().GetPaymentFile(mock.Anything, mock.Anything).Return(storage.PaymentFile{
+					PaymentFileID:        wantPaymentFileID,
+					MessageID:            "MSG-1",
+					NumberOfTransactions: 2,
+					Status:               StatusPending,
+				}, nil).Once()
+				ms.EXPECT().ListPayments(mock.Anything, wantPaymentFileID).
+					Return([]storage.Payment{approved, rejected}, nil).Once()
+				ms.EXPECT().ListHeldPayments(mock.Anything, wantPaymentFileID).Return([]storage.ListHeldPaymentsRow{
+					{
+						Payment:               approved,
+						ApprovalStatus:        pgtype.Text{String: types.TransferApprovalStatusApproved, Valid: true},
+						ApprovalTransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+					},
+					{
+						Payment:      rejected,
+						ReviewStatus: pgtype.Text{String: types.PendingTransferStatusRejected, Valid: true},
+					},
+				}, nil).Once()
+				ms.EXPECT().UpdatePayment(mock.Anything, storage.UpdatePaymentParams{
+					PaymentID:     approved.PaymentID,
+					Status:        StatusAccepted,
+					TransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+				ms.EXPECT().UpdatePayment(mock.Anything, storage.UpdatePaymentParams{
+					PaymentID:  rejected.PaymentID,
+					Status:     StatusRejected,
+					ReasonCode: pgtype.Text{String: reasonFraud, Valid: true},
+					UpdatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+				ms.EXPECT().UpdatePaymentFileStatus(mock.Anything, storage.UpdatePaymentFileStatusParams{
+					PaymentFileID: wantPaymentFileID,
+					Status:        StatusPartiallyAccepted,
+					UpdatedAt:     pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+			},
+			want: Report{
+				ID:                   wantPaymentFileID,
+				CreatedAt:            wantNow,
+				MessageID:            "MSG-1",
+				MessageCreatedAt:     time.Time{}.UTC(),
+				NumberOfTransactions: 2,
+				Status:               StatusPartiallyAccepted,
+				PaymentInformations: []PaymentInformationReport{
+					{
+						ID:     "PMT-1",
+						Status: StatusPartiallyAccepted,
+						Payments: []PaymentReport{
+							{
+								EndToEndID:    "E2E-1",
+								Status:        StatusAccepted,
+								TransactionID: uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+							},
+							{EndToEndID: "E2E-2", Status: StatusRejected, Reason: &Reason{Code: reasonFraud}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPaymentFileStore(t)
+
+			tt.mock(store)
+
+			holders := holdertest.Authorize(t, wantAccountID, holder.PermissionView, tt.permitErr)
+
+			s := New(storageMocks.NewMockDBConnection(t), store, nil, nil, holders, slog.Default())
+			s.now = func() time.Time { return wantNow }
+
+			got, err := s.GetReport(context.Background(), wantAccountID, wantPaymentFileID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckCreditorAccount(t *testing.T) {
+	t.Parallel()
+
+	debtor := testAccount()
+	debtor.IBAN = pgtype.Text{String: "DE89370400440532013000", Valid: true}
+
+	tests := []struct {
+		name     string
+		account  pain001Account
+		wantCode string
+	}{
+		{
+			name:     "failed when the iban is not valid",
+			account:  pain001Account{IBAN: "DE00370400440532013000"},
+			wantCode: reasonCreditorAccount,
+		},
+		{
+			name:     "failed when the iban is the iban of the debtor account",
+			account:  pain001Account{IBAN: "de89 3704 0044 0532 0130 00"},
+			wantCode: reasonCreditorAccount,
+		},
+		{
+			name:     "failed when the id is not an account id",
+			account:  pain001Account{Other: "ACCOUNT-2"},
+			wantCode: reasonCreditorAccount,
+		},
+		{
+			name:     "failed when the id is the id of the debtor account",
+			account:  pain001Account{Other: "12345678123412341234123456789001"},
+			wantCode: reasonCreditorAccount,
+		},
+		{
+			name:    "success with an iban",
+			account: pain001Account{IBAN: "DE06100000000000000017"},
+		},
+		{
+			name:    "success with an id",
+			account: pain001Account{Other: "12345678123412341234123456789002"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := checkCreditorAccount(tt.account, debtor)
+			if tt.wantCode == "" {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantCode, got.Code)
+		})
+	}
+}
@@ -0,0 +1,422 @@
+// Package pocket manages the pockets of accounts, which ring-fence money inside them. A pocket is an account of its
+// parent account, opened for its owner, in its currency and for its product. Money is moved between an account and its
+// pockets instantly and free of fees, limits and screening, as it does not leave the account. A pocket may have a goal,
+// an amount to save by an optional date. Pockets are operated through their parent account, by its holders.
+package pocket
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/accountview"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+// Auditor records the pockets created, their goals and the money moved between accounts and their pockets within their
+// transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.PocketStore
+	storeWithTx func(tx pgx.Tx) storage.PocketStore
+	auditor     Auditor
+	holders     Holders
+	logger      logger.Logger
+}
+
+// New returns a new Service.
+func New(
+	conn storage.DBConnection,
+	store storage.PocketStore,
+	auditor Auditor,
+	holders Holders,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.PocketStoreWithTx,
+		auditor:     auditor,
+		holders:     holders,
+		logger:      logger,
+	}
+}
+
+// CreatePocket opens a pocket in an account, which cannot be a pocket itself.
+// returns CreatePocketResponse.
+func (s *Service) CreatePocket(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.CreatePocketRequest,
+) (types.CreatePocketResponse, error) {
+	if _, err := s.authorize(ctx, accountID, holder.PermissionManage); err != nil {
+		return types.CreatePocketResponse{}, err
+	}
+
+	number, err := s.store.NextAccountNumber(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get next account number", "error", err)
+
+		return types.CreatePocketResponse{}, types.ErrInternal
+	}
+
+	var p storage.Account
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.PocketStore) error {
+		var err error
+
+		p, err = store.CreatePocket(ctx, storage.CreatePocketParams{
+			Name:            req.Name,
+			AccountNumber:   number,
+			ParentAccountID: accountID,
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to create pocket", "error", err)
+
+			return types.ErrInternal
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionCreatePocket,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			After:     accountview.Pocket(p, pgtype.Numeric{}),
+		})
+	})
+	if err != nil {
+		return types.CreatePocketResponse{}, err
+	}
+
+	s.logger.InfoContext(ctx, "pocket created", "account_id", accountID, "pocket_id", p.AccountID)
+
+	return types.CreatePocketResponse{Pocket: accountview.Pocket(p, pgtype.Numeric{})}, nil
+}
+
+// ListPockets lists the pockets of an account with their balances, by account number.
+// returns ListPocketsResponse.
+func (s *Service) ListPockets(ctx context.Context, accountID uuid.UUID) (types.ListPocketsResponse, error) {
+	if _, err := s.authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return types.ListPocketsResponse{}, err
+	}
+
+	pockets, err := s.Pockets(ctx, accountID)
+	if err != nil {
+		return types.ListPocketsResponse{}, err
+	}
+
+	return types.ListPocketsResponse{Pockets: pockets}, nil
+}
+
+// Pockets returns the pockets of an account with their balances, by account number, the holder making the request
+// being already authorized to view the account.
+func (s *Service) Pockets(ctx context.Context, accountID uuid.UUID) ([]types.Pocket, error) {
+	rows, err := s.store.ListPockets(ctx, uuid.NullUUID{UUID: accountID, Valid: true})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list pockets", "error", err)
+
+		return nil, types.ErrInternal
+	}
+
+	pockets := make([]types.Pocket, 0, len(rows))
+
+	for _, row := range rows {
+		pockets = append(pockets, accountview.Pocket(row.Account, row.Balance))
+	}
+
+	return pockets, nil
+}
+
+// SetPocketGoal sets the goal of a pocket, replacing the one it had.
+// returns SetPocketGoalResponse.
+func (s *Service) SetPocketGoal(
+	ctx context.Context,
+	accountID, pocketID uuid.UUID,
+	req *types.SetPocketGoalRequest,
+) (types.SetPocketGoalResponse, error) {
+	p, err := s.setGoal(ctx, accountID, pocketID, &req.Amount, goalDate(req.Date))
+	if err != nil {
+		return types.SetPocketGoalResponse{}, err
+	}
+
+	return types.SetPocketGoalResponse{Pocket: p}, nil
+}
+
+// DeletePocketGoal removes the goal of a pocket.
+func (s *Service) DeletePocketGoal(ctx context.Context, accountID, pocketID uuid.UUID) error {
+	_, err := s.setGoal(ctx, accountID, pocketID, nil, pgtype.Date{})
+
+	return err
+}
+
+// setGoal sets the goal of a pocket, or removes it when amount is nil, and returns the pocket.
+func (s *Service) setGoal(
+	ctx context.Context,
+	accountID, pocketID uuid.UUID,
+	amount *money.Amount,
+	date pgtype.Date,
+) (types.Pocket, error) {
+	account, err := s.authorize(ctx, accountID, holder.PermissionManage)
+	if err != nil {
+		return types.Pocket{}, err
+	}
+
+	params := storage.SetPocketGoalParams{
+		GoalDate:        date,
+		PocketID:        pocketID,
+		ParentAccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+	}
+
+	action := audit.ActionDeletePocketGoal
+
+	// Pockets are in the currency of their account.
+	if amount != nil {
+		params.GoalAmount = storage.NumericFromAmount(*amount, account.CurrencyCode)
+		action = audit.ActionSetPocketGoal
+	}
+
+	var p types.Pocket
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.PocketStore) error {
+		before, err := s.getPocket(ctx, store, accountID, pocketID)
+		if err != nil {
+			return err
+		}
+
+		n, err := store.SetPocketGoal(ctx, params)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to set pocket goal", "error", err)
+
+			return types.ErrInternal
+		}
+
+		if n == 0 {
+			return types.ErrPocketNotFound
+		}
+
+		if p, err = s.getPocket(ctx, store, accountID, pocketID); err != nil {
+			return err
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    action,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    before,
+			After:     p,
+		})
+	})
+	if err != nil {
+		return types.Pocket{}, err
+	}
+
+	return p, nil
+}
+
+// MoveToPocket moves money from an account to one of its pockets, failing with types.ErrInsufficientAccountBalance
+// when the balance of the account, its overdraft excluded, is less than the amount.
+// returns MovePocketMoneyResponse.
+func (s *Service) MoveToPocket(
+	ctx context.Context,
+	accountID, pocketID uuid.UUID,
+	req *types.MovePocketMoneyRequest,
+) (types.MovePocketMoneyResponse, error) {
+	return s.move(ctx, accountID, pocketID, req.Amount)
+}
+
+// MoveFromPocket moves money from a pocket back to its account, failing with types.ErrInsufficientAccountBalance when
+// the balance of the pocket is less than the amount.
+// returns MovePocketMoneyResponse.
+func (s *Service) MoveFromPocket(
+	ctx context.Context,
+	accountID, pocketID uuid.UUID,
+	req *types.MovePocketMoneyRequest,
+) (types.MovePocketMoneyResponse, error) {
+	return s.move(ctx, accountID, pocketID, -req.Amount)
+}
+
+// move moves amount from an account to one of its pockets, or from the pocket to the account when negative.
+func (s *Service) move(
+	ctx context.Context,
+	accountID, pocketID uuid.UUID,
+	amount money.Amount,
+) (types.MovePocketMoneyResponse, error) {
+	if _, err := s.authorize(ctx, accountID, holder.PermissionTransfer); err != nil {
+		return types.MovePocketMoneyResponse{}, err
+	}
+
+	pocket, err := s.getPocket(ctx, s.store, accountID, pocketID)
+	if err != nil {
+		return types.MovePocketMoneyResponse{}, err
+	}
+
+	from, to, action := accountID, pocketID, audit.ActionMoveToPocket
+	if amount < 0 {
+		from, to, action, amount = pocketID, accountID, audit.ActionMoveFromPocket, -amount
+	}
+
+	var p types.Pocket
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.PocketStore) error {
+		if err := s.book(ctx, store, from, to, pocket.CurrencyCode, amount); err != nil {
+			return err
+		}
+
+		var err error
+
+		if p, err = s.getPocket(ctx, store, accountID, pocketID); err != nil {
+			return err
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    action,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    pocket,
+			After:     p,
+		})
+	})
+	if err != nil {
+		return types.MovePocketMoneyResponse{}, err
+	}
+
+	s.logger.InfoContext(ctx, "pocket money moved", "from", from, "to", to, "amount", amount)
+
+	return types.MovePocketMoneyResponse{Pocket: p}, nil
+}
+
+// book adds the transactions of a move of amount in a currency, the accounts money is moved between being locked until
+// the end of the transaction of store so that the balance of the one it is moved from covers the moves made at the same
+// time.
+func (s *Service) book(
+	ctx context.Context,
+	store storage.PocketStore,
+	from, to uuid.UUID,
+	currencyCode string,
+	amount money.Amount,
+) error {
+	if err := store.LockAccounts(ctx, []uuid.UUID{from, to}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to lock account", "error", err)
+
+		return types.ErrInternal
+	}
+
+	balance, err := store.GetAccountTotalAmount(ctx, from)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get account total amount", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if storage.AmountFromNumeric(balance, currencyCode) < amount {
+		return types.ErrInsufficientAccountBalance
+	}
+
+	t, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: from,
+		Amount:    storage.NumericFromAmount(-amount, currencyCode),
+		Type:      types.TransactionTypePocket,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to add transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if _, err := store.AddTransaction(ctx, storage.AddTransactionParams{
+		AccountID: to,
+		Amount:    storage.NumericFromAmount(amount, currencyCode),
+		SourceID:  uuid.NullUUID{UUID: t.TransactionID, Valid: true},
+		Type:      types.TransactionTypePocket,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to add transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// authorize checks that the holder making the request may do what permission allows on an account, failing with
+// types.ErrPocketNotAllowed when the account is a pocket.
+func (s *Service) authorize(
+	ctx context.Context,
+	accountID uuid.UUID,
+	permission holder.Permission,
+) (storage.Account, error) {
+	if err := s.holders.Authorize(ctx, accountID, permission); err != nil {
+		return storage.Account{}, err
+	}
+
+	a, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.Account{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return storage.Account{}, types.ErrInternal
+	}
+
+	if a.ParentAccountID.Valid {
+		return storage.Account{}, types.ErrPocketNotAllowed
+	}
+
+	return a, nil
+}
+
+func (s *Service) getPocket(
+	ctx context.Context,
+	store storage.PocketStore,
+	accountID, pocketID uuid.UUID,
+) (types.Pocket, error) {
+	row, err := store.GetPocket(ctx, storage.GetPocketParams{
+		PocketID:        pocketID,
+		ParentAccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.Pocket{}, types.ErrPocketNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch pocket", "error", err)
+
+		return types.Pocket{}, types.ErrInternal
+	}
+
+	return accountview.Pocket(row.Account, row.Balance), nil
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// goalDate returns the date of a goal, validated as YYYY-MM-DD, which is absent when empty.
+func goalDate(date string) pgtype.Date {
+	d, err := time.Parse(time.DateOnly, date)
+
+	return pgtype.Date{Time: d, Valid: err == nil}
+}
@@ -0,0 +1,472 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID     = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantPocketID      = uuid.MustParse("12345678-1234-1234-1234-123456789002")
+	wantTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantGoalDate      = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	errAnything       = errors.New("any")
+
+	testAccount = storage.Account{AccountID: wantAccountID, Name: "John Doe", CurrencyCode: "EUR"}
+	testPocket  = storage.Account{
+		AccountID:       wantPocketID,
+		Name:            "Holidays",
+		CurrencyCode:    "EUR",
+		ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+		GoalAmount:      storage.NumericFromAmount(200000, "EUR"),
+		GoalDate:        pgtype.Date{Time: wantGoalDate, Valid: true},
+	}
+	wantPocket = types.Pocket{
+		ID:              wantPocketID,
+		ParentAccountID: wantAccountID,
+		Name:            "Holidays",
+		CurrencyCode:    "EUR",
+		Balance:         2000,
+		GoalAmount:      200000,
+		GoalDate:        "2025-07-01",
+	}
+	wantGetPocketParams = storage.GetPocketParams{
+		PocketID:        wantPocketID,
+		ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+	}
+)
+
+// authorize returns the Holders checking permission on the account, failing with err.
+func authorize(t *testing.T, want holder.Permission, err error) holdertest.AuthorizeFunc {
+	t.Helper()
+
+	return holdertest.Authorize(t, wantAccountID, want, err)
+}
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func newTestService(conn storage.DBConnection, store storage.PocketStore, holders Holders) *Service {
+	s := New(conn, store, nil, holders, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.PocketStore { return store }
+
+	return s
+}
+
+func TestService_CreatePocket(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		authorized error
+		account    storage.Account
+		accountErr error
+		err        error
+		want       types.CreatePocketResponse
+		wantErr    error
+	}{
+		{
+			name:       "failed when the holder is not permitted",
+			authorized: types.ErrNotPermitted,
+			wantErr:    types.ErrNotPermitted,
+		},
+		{
+			name:       "failed when account not found",
+			accountErr: pgx.ErrNoRows,
+			wantErr:    types.ErrAccountNotFound,
+		},
+		{
+			name:    "failed when the account is a pocket",
+			account: testPocket,
+			wantErr: types.ErrPocketNotAllowed,
+		},
+		{
+			name:    "failed when the pocket cannot be created",
+			account: testAccount,
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "success",
+			account: testAccount,
+			want: types.CreatePocketResponse{Pocket: types.Pocket{
+				ID: wantPocketID, ParentAccountID: wantAccountID, Name: "Holidays", CurrencyCode: "EUR",
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPocketStore(t)
+
+			if tt.authorized == nil {
+				store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(tt.account, tt.accountErr).Once()
+			}
+
+			var conn storage.DBConnection
+
+			if tt.account.AccountID == wantAccountID {
+				conn = storagetest.NewConn(t, tt.err == nil)
+				store.EXPECT().NextAccountNumber(mock.Anything).Return(42, nil).Once()
+				store.EXPECT().CreatePocket(mock.Anything, storage.CreatePocketParams{
+					Name: "Holidays", AccountNumber: 42, ParentAccountID: wantAccountID,
+				}).Return(storage.Account{
+					AccountID:       wantPocketID,
+					Name:            "Holidays",
+					CurrencyCode:    "EUR",
+					ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				}, tt.err).Once()
+			}
+
+			s := newTestService(conn, store, authorize(t, holder.PermissionManage, tt.authorized))
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionCreatePocket, event.Action)
+				assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+				assert.Equal(t, tt.want.Pocket, event.After)
+
+				return nil
+			})
+
+			got, err := s.CreatePocket(context.Background(), wantAccountID, &types.CreatePocketRequest{Name: "Holidays"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListPockets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.ListPocketsResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the pockets cannot be listed",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			want: types.ListPocketsResponse{Pockets: []types.Pocket{wantPocket}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPocketStore(t)
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+			store.EXPECT().ListPockets(mock.Anything, uuid.NullUUID{UUID: wantAccountID, Valid: true}).Return(
+				[]storage.ListPocketsRow{{Account: testPocket, Balance: storage.NumericFromAmount(2000, "EUR")}}, tt.err,
+			).Once()
+
+			got, err := newTestService(nil, store, authorize(t, holder.PermissionView, nil)).
+				ListPockets(context.Background(), wantAccountID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SetPocketGoal(t *testing.T) {
+	t.Parallel()
+
+	pocketRow := storage.GetPocketRow{Account: testPocket, Balance: storage.NumericFromAmount(2000, "EUR")}
+
+	tests := []struct {
+		name      string
+		req       types.SetPocketGoalRequest
+		wantDate  pgtype.Date
+		pocketErr error
+		rows      int64
+		err       error
+		want      types.SetPocketGoalResponse
+		wantErr   error
+	}{
+		{
+			name:      "failed when pocket not found",
+			req:       types.SetPocketGoalRequest{Amount: 200000},
+			pocketErr: pgx.ErrNoRows,
+			wantErr:   types.ErrPocketNotFound,
+		},
+		{
+			name:    "failed when the goal cannot be set",
+			req:     types.SetPocketGoalRequest{Amount: 200000},
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when no pocket is updated",
+			req:     types.SetPocketGoalRequest{Amount: 200000},
+			wantErr: types.ErrPocketNotFound,
+		},
+		{
+			name:     "success with a date",
+			req:      types.SetPocketGoalRequest{Amount: 200000, Date: "2025-07-01"},
+			wantDate: pgtype.Date{Time: wantGoalDate, Valid: true},
+			rows:     1,
+			want:     types.SetPocketGoalResponse{Pocket: wantPocket},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPocketStore(t)
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+			store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).Return(pocketRow, tt.pocketErr).Once()
+
+			if tt.pocketErr == nil {
+				store.EXPECT().SetPocketGoal(mock.Anything, storage.SetPocketGoalParams{
+					GoalAmount:      storage.NumericFromAmount(200000, "EUR"),
+					GoalDate:        tt.wantDate,
+					PocketID:        wantPocketID,
+					ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				}).Return(tt.rows, tt.err).Once()
+			}
+
+			if tt.rows == 1 {
+				store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).Return(pocketRow, nil).Once()
+			}
+
+			s := newTestService(storagetest.NewConn(t, tt.wantErr == nil), store,
+				authorize(t, holder.PermissionManage, nil))
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionSetPocketGoal, event.Action)
+				assert.Equal(t, wantPocket, event.Before)
+				assert.Equal(t, wantPocket, event.After)
+
+				return nil
+			})
+
+			got, err := s.SetPocketGoal(context.Background(), wantAccountID, wantPocketID, &tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_DeletePocketGoal(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockPocketStore(t)
+	store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+	store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).Return(
+		storage.GetPocketRow{Account: testPocket, Balance: storage.NumericFromAmount(2000, "EUR")}, nil).Twice()
+	store.EXPECT().SetPocketGoal(mock.Anything, storage.SetPocketGoalParams{
+		PocketID:        wantPocketID,
+		ParentAccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+	}).Return(1, nil).Once()
+
+	s := newTestService(storagetest.NewConn(t, true), store, authorize(t, holder.PermissionManage, nil))
+	s.auditor = recordFunc(func(event audit.Event) error {
+		assert.Equal(t, audit.ActionDeletePocketGoal, event.Action)
+
+		return nil
+	})
+
+	assert.NoError(t, s.DeletePocketGoal(context.Background(), wantAccountID, wantPocketID))
+}
+
+func TestService_move(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		amount    money.Amount
+		from, to  uuid.UUID
+		pocketErr error
+		balance   money.Amount
+		err       error
+		action    string
+		want      types.MovePocketMoneyResponse
+		wantErr   error
+	}{
+		{
+			name:      "failed when pocket not found",
+			amount:    2000,
+			pocketErr: pgx.ErrNoRows,
+			wantErr:   types.ErrPocketNotFound,
+		},
+		{
+			name:    "failed when the balance of the account is insufficient",
+			amount:  2000,
+			from:    wantAccountID,
+			to:      wantPocketID,
+			balance: 1999,
+			wantErr: types.ErrInsufficientAccountBalance,
+		},
+		{
+			name:    "failed when the transactions cannot be added",
+			amount:  2000,
+			from:    wantAccountID,
+			to:      wantPocketID,
+			balance: 2000,
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "success to the pocket",
+			amount:  2000,
+			from:    wantAccountID,
+			to:      wantPocketID,
+			balance: 2000,
+			action:  audit.ActionMoveToPocket,
+			want:    types.MovePocketMoneyResponse{Pocket: wantPocket},
+		},
+		{
+			name:    "success from the pocket",
+			amount:  -2000,
+			from:    wantPocketID,
+			to:      wantAccountID,
+			balance: 4000,
+			action:  audit.ActionMoveFromPocket,
+			want:    types.MovePocketMoneyResponse{Pocket: wantPocket},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockPocketStore(t)
+			pocketRow := storage.GetPocketRow{Account: testPocket, Balance: storage.NumericFromAmount(2000, "EUR")}
+
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+			store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).Return(pocketRow, tt.pocketErr).Once()
+
+			if tt.from != uuid.Nil {
+				expectMove(t, conn, store, tt.from, tt.to, tt.balance, tt.err)
+			}
+
+			if tt.wantErr == nil {
+				store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).Return(pocketRow, nil).Once()
+			}
+
+			s := newTestService(conn, store, authorize(t, holder.PermissionTransfer, nil))
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, tt.action, event.Action)
+				assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+				assert.Equal(t, wantPocket, event.After)
+
+				return nil
+			})
+
+			got, err := s.move(context.Background(), wantAccountID, wantPocketID, tt.amount)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// expectMove sets the expectations of a move of 2000 within a transaction, which is committed unless the balance is
+// insufficient or the transactions of the move cannot be added with err.
+func expectMove(
+	t *testing.T,
+	conn *storageMocks.MockDBConnection,
+	store *storageMocks.MockPocketStore,
+	from, to uuid.UUID,
+	balance money.Amount,
+	err error,
+) {
+	t.Helper()
+
+	tx := txMocks.NewMockTx(t)
+
+	conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+	store.EXPECT().LockAccounts(mock.Anything, []uuid.UUID{from, to}).Return(nil).Once()
+	store.EXPECT().GetAccountTotalAmount(mock.Anything, from).Return(storage.NumericFromAmount(balance, "EUR"), nil).Once()
+
+	if balance < 2000 {
+		return
+	}
+
+	store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+		AccountID: from,
+		Amount:    storage.NumericFromAmount(-2000, "EUR"),
+		Type:      types.TransactionTypePocket,
+	}).Return(storage.Transaction{TransactionID: wantTransactionID}, err).Once()
+
+	if err != nil {
+		return
+	}
+
+	store.EXPECT().AddTransaction(mock.Anything, storage.AddTransactionParams{
+		AccountID: to,
+		Amount:    storage.NumericFromAmount(2000, "EUR"),
+		SourceID:  uuid.NullUUID{UUID: wantTransactionID, Valid: true},
+		Type:      types.TransactionTypePocket,
+	}).Return(storage.Transaction{}, nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+}
+
+func TestService_MoveToPocket(t *testing.T) {
+	t.Parallel()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	store := storageMocks.NewMockPocketStore(t)
+	store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+	store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).
+		Return(storage.GetPocketRow{Account: testPocket}, nil).Once()
+	expectMove(t, conn, store, wantAccountID, wantPocketID, 0, nil)
+
+	_, err := newTestService(conn, store, authorize(t, holder.PermissionTransfer, nil)).MoveToPocket(
+		context.Background(), wantAccountID, wantPocketID, &types.MovePocketMoneyRequest{Amount: 2000})
+
+	assert.ErrorIs(t, err, types.ErrInsufficientAccountBalance)
+}
+
+func TestService_MoveFromPocket(t *testing.T) {
+	t.Parallel()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	store := storageMocks.NewMockPocketStore(t)
+	store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(testAccount, nil).Once()
+	store.EXPECT().GetPocket(mock.Anything, wantGetPocketParams).
+		Return(storage.GetPocketRow{Account: testPocket}, nil).Once()
+	expectMove(t, conn, store, wantPocketID, wantAccountID, 0, nil)
+
+	_, err := newTestService(conn, store, authorize(t, holder.PermissionTransfer, nil)).MoveFromPocket(
+		context.Background(), wantAccountID, wantPocketID, &types.MovePocketMoneyRequest{Amount: 2000})
+
+	assert.ErrorIs(t, err, types.ErrInsufficientAccountBalance)
+}
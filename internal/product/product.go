@@ -0,0 +1,263 @@
+// Package product manages the products accounts are opened for, such as current, savings and business accounts: the
+// currencies they can be opened in, the limit tier of their transfers, their overdraft eligibility, and the interest
+// rate and day count convention of their positive balances. Their fees are set by their fee schedules.
+package product
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/limits"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	// DefaultCode is the product of the accounts whose product was not set.
+	DefaultCode = "current"
+	// DefaultCurrencyCode is the currency of the products whose currencies were not set.
+	DefaultCurrencyCode = "EUR"
+
+	pqErrorForeignKeyViolation = "23503"
+)
+
+// Auditor records the changes of products within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.ProductStore
+	storeWithTx func(tx pgx.Tx) storage.ProductStore
+	auditor     Auditor
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(conn storage.DBConnection, store storage.ProductStore, auditor Auditor, logger logger.Logger) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.ProductStoreWithTx,
+		auditor:     auditor,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// ListProducts lists the products, by code.
+// returns ListProductsResponse.
+func (s *Service) ListProducts(ctx context.Context) (types.ListProductsResponse, error) {
+	products, err := s.store.ListAccountProducts(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list products", "error", err)
+
+		return types.ListProductsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListProductsResponse{
+		Products: make([]types.Product, 0, len(products)),
+	}
+
+	for _, p := range products {
+		res.Products = append(res.Products, toProduct(p))
+	}
+
+	return res, nil
+}
+
+// SetProduct sets the attributes of a product, creating it if it does not exist. The interest accrued from then on
+// follows them, as do the accounts opened, the transfers made and the overdrafts granted, but existing accounts and
+// overdrafts are kept.
+// returns SetProductResponse.
+func (s *Service) SetProduct(
+	ctx context.Context,
+	code string,
+	req *types.SetProductRequest,
+) (types.SetProductResponse, error) {
+	var rate pgtype.Numeric
+
+	// The rate was validated as a decimal.
+	if err := rate.Scan(req.InterestRate); err != nil {
+		s.logger.ErrorContext(ctx, "failed to parse interest rate", "error", err)
+
+		return types.SetProductResponse{}, types.ErrInternal
+	}
+
+	currencyCodes := req.CurrencyCodes
+	if len(currencyCodes) == 0 {
+		currencyCodes = []string{DefaultCurrencyCode}
+	}
+
+	limitTier := req.LimitTier
+	if limitTier == "" {
+		limitTier = limits.DefaultTier
+	}
+
+	var res types.SetProductResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.ProductStore) error {
+		p, err := store.UpsertAccountProduct(ctx, storage.UpsertAccountProductParams{
+			ProductCode:       code,
+			Name:              req.Name,
+			InterestRate:      rate,
+			DayCount:          req.DayCount,
+			CurrencyCodes:     currencyCodes,
+			LimitTier:         limitTier,
+			OverdraftEligible: req.OverdraftEligible,
+			MaxOverdraftLimit: storage.NumericFromAmount(req.MaxOverdraftLimit, storage.NoCurrency),
+			CreatedAt:         pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+		})
+		if err != nil {
+			pgErr := &pgconn.PgError{}
+			if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+				return types.ErrLimitTierNotFound
+			}
+
+			s.logger.ErrorContext(ctx, "failed to set product", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res = types.SetProductResponse{Product: toProduct(p)}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:  audit.ActionSetProduct,
+			Outcome: audit.OutcomeSuccess,
+			After:   res.Product,
+		})
+	})
+	if err != nil {
+		return types.SetProductResponse{}, err
+	}
+
+	return res, nil
+}
+
+// SetAccountProduct sets the product of an account, which must allow the currency of the account.
+// returns SetAccountProductResponse.
+func (s *Service) SetAccountProduct(
+	ctx context.Context,
+	accountID uuid.UUID,
+	req *types.SetAccountProductRequest,
+) (types.SetAccountProductResponse, error) {
+	account, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.SetAccountProductResponse{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return types.SetAccountProductResponse{}, types.ErrInternal
+	}
+
+	if err := s.CheckCurrency(ctx, req.ProductCode, account.CurrencyCode); err != nil {
+		return types.SetAccountProductResponse{}, err
+	}
+
+	res := types.SetAccountProductResponse{AccountID: accountID, ProductCode: req.ProductCode}
+
+	err = storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.ProductStore) error {
+		if err := s.setAccountProduct(ctx, store, accountID, req.ProductCode); err != nil {
+			return err
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionSetAccountProduct,
+			AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+			Outcome:   audit.OutcomeSuccess,
+			Before:    types.SetAccountProductResponse{AccountID: accountID, ProductCode: account.ProductCode},
+			After:     res,
+		})
+	})
+	if err != nil {
+		return types.SetAccountProductResponse{}, err
+	}
+
+	return res, nil
+}
+
+func (s *Service) setAccountProduct(
+	ctx context.Context,
+	store storage.ProductStore,
+	accountID uuid.UUID,
+	productCode string,
+) error {
+	n, err := store.SetAccountProduct(ctx, storage.SetAccountProductParams{
+		AccountID:   accountID,
+		ProductCode: productCode,
+	})
+	if err != nil {
+		pgErr := &pgconn.PgError{}
+		if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+			return types.ErrProductNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to set account product", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if n == 0 {
+		return types.ErrAccountNotFound
+	}
+
+	return nil
+}
+
+// CheckCurrency fails with types.ErrProductNotFound unless a product exists, and with types.ErrCurrencyNotAllowed
+// unless it allows accounts in a currency.
+func (s *Service) CheckCurrency(ctx context.Context, productCode, currencyCode string) error {
+	p, err := s.store.GetAccountProduct(ctx, productCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrProductNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch product", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if !slices.Contains(p.CurrencyCodes, currencyCode) {
+		return types.ErrCurrencyNotAllowed
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+func toProduct(p storage.AccountProduct) types.Product {
+	return types.Product{
+		Code:              p.ProductCode,
+		Name:              p.Name,
+		InterestRate:      storage.DecimalFromNumeric(p.InterestRate),
+		DayCount:          p.DayCount,
+		CurrencyCodes:     p.CurrencyCodes,
+		LimitTier:         p.LimitTier,
+		OverdraftEligible: p.OverdraftEligible,
+		MaxOverdraftLimit: storage.AmountFromNumeric(p.MaxOverdraftLimit, storage.NoCurrency),
+		CreatedAt:         p.CreatedAt.Time,
+		UpdatedAt:         p.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,258 @@
+package product
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantNow       = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	wantRate      = pgtype.Numeric{Int: big.NewInt(25), Exp: -3, Valid: true}
+	errAnything   = errors.New("any")
+
+	savings = storage.AccountProduct{
+		ProductCode:       "savings",
+		Name:              "Savings account",
+		InterestRate:      wantRate,
+		DayCount:          types.DayCount30360,
+		CurrencyCodes:     []string{"EUR"},
+		LimitTier:         "standard",
+		MaxOverdraftLimit: storage.NumericFromAmount(0, storage.NoCurrency),
+		CreatedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+		UpdatedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}
+	wantSavings = types.Product{
+		Code:          "savings",
+		Name:          "Savings account",
+		InterestRate:  "0.025",
+		DayCount:      types.DayCount30360,
+		CurrencyCodes: []string{"EUR"},
+		LimitTier:     "standard",
+		CreatedAt:     wantNow,
+		UpdatedAt:     wantNow,
+	}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting successful events of action.
+func wantAction(t *testing.T, action string) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+
+		return nil
+	})
+}
+
+func newTestService(conn storage.DBConnection, store storage.ProductStore, auditor Auditor) *Service {
+	s := New(conn, store, auditor, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.ProductStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func TestService_ListProducts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.ListProductsResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the products cannot be listed",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			want: types.ListProductsResponse{Products: []types.Product{wantSavings}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockProductStore(t)
+			store.EXPECT().ListAccountProducts(mock.Anything).
+				Return([]storage.AccountProduct{savings}, tt.err).Once()
+
+			got, err := newTestService(nil, store, nil).ListProducts(context.Background())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_SetProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.SetProductResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when the product cannot be set",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when limit tier not found",
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation},
+			wantErr: types.ErrLimitTierNotFound,
+		},
+		{
+			name: "success with the default currency and limit tier",
+			want: types.SetProductResponse{Product: wantSavings},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockProductStore(t)
+			store.EXPECT().UpsertAccountProduct(mock.Anything, storage.UpsertAccountProductParams{
+				ProductCode:       "savings",
+				Name:              "Savings account",
+				InterestRate:      wantRate,
+				DayCount:          types.DayCount30360,
+				CurrencyCodes:     []string{"EUR"},
+				LimitTier:         "standard",
+				MaxOverdraftLimit: storage.NumericFromAmount(0, storage.NoCurrency),
+				CreatedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+			}).Return(savings, tt.err).Once()
+
+			s := newTestService(storagetest.NewConn(t, tt.wantErr == nil), store, wantAction(t, audit.ActionSetProduct))
+
+			got, err := s.SetProduct(context.Background(), "savings", &types.SetProductRequest{
+				Name:         "Savings account",
+				InterestRate: "0.025",
+				DayCount:     types.DayCount30360,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SetAccountProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		accountErr   error
+		productErr   error
+		currencyCode string
+		rows         int64
+		err          error
+		want         types.SetAccountProductResponse
+		wantErr      error
+	}{
+		{
+			name:       "failed when account not found",
+			accountErr: pgx.ErrNoRows,
+			wantErr:    types.ErrAccountNotFound,
+		},
+		{
+			name:       "failed when product not found",
+			productErr: pgx.ErrNoRows,
+			wantErr:    types.ErrProductNotFound,
+		},
+		{
+			name:         "failed when the product does not allow the currency of the account",
+			currencyCode: "USD",
+			wantErr:      types.ErrCurrencyNotAllowed,
+		},
+		{
+			name:    "failed when the product cannot be set",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:    "failed when the product was deleted meanwhile",
+			err:     &pgconn.PgError{Code: pqErrorForeignKeyViolation},
+			wantErr: types.ErrProductNotFound,
+		},
+		{
+			name:    "failed when the account was deleted meanwhile",
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "success",
+			rows: 1,
+			want: types.SetAccountProductResponse{AccountID: wantAccountID, ProductCode: "savings"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockProductStore(t)
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{
+				AccountID: wantAccountID, CurrencyCode: cmp.Or(tt.currencyCode, "EUR"),
+			}, tt.accountErr).Once()
+
+			if tt.accountErr == nil {
+				store.EXPECT().GetAccountProduct(mock.Anything, "savings").Return(savings, tt.productErr).Once()
+			}
+
+			var conn storage.DBConnection
+
+			if tt.accountErr == nil && tt.productErr == nil && tt.currencyCode == "" {
+				conn = storagetest.NewConn(t, tt.wantErr == nil)
+
+				store.EXPECT().SetAccountProduct(mock.Anything, storage.SetAccountProductParams{
+					AccountID:   wantAccountID,
+					ProductCode: "savings",
+				}).Return(tt.rows, tt.err).Once()
+			}
+
+			got, err := newTestService(conn, store, wantAction(t, audit.ActionSetAccountProduct)).SetAccountProduct(
+				context.Background(), wantAccountID, &types.SetAccountProductRequest{ProductCode: "savings"})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
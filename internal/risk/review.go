@@ -0,0 +1,222 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// AccountService makes the transfers the admin approves.
+type AccountService interface {
+	TransferMoney(
+		ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+}
+
+// Auditor records the decisions on the transfers held for review within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Service lists the transfers held for review, and approves or rejects them.
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.PendingTransferStore
+	storeWithTx func(tx pgx.Tx) storage.PendingTransferStore
+	auditor     Auditor
+	accounts    AccountService
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// NewService returns a new Service.
+func NewService(
+	conn storage.DBConnection,
+	store storage.PendingTransferStore,
+	auditor Auditor,
+	accounts AccountService,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.PendingTransferStoreWithTx,
+		auditor:     auditor,
+		accounts:    accounts,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// ListPendingTransfers lists the pending transfers of a status, oldest first.
+// returns ListPendingTransfersResponse.
+func (s *Service) ListPendingTransfers(
+	ctx context.Context,
+	status string,
+	limit, offset int32,
+) (types.ListPendingTransfersResponse, error) {
+	transfers, err := s.store.ListPendingTransfers(ctx, storage.ListPendingTransfersParams{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list pending transfers", "error", err)
+
+		return types.ListPendingTransfersResponse{}, types.ErrInternal
+	}
+
+	res := types.ListPendingTransfersResponse{
+		PendingTransfers: make([]types.PendingTransfer, 0, len(transfers)),
+	}
+
+	for _, t := range transfers {
+		res.PendingTransfers = append(res.PendingTransfers, toPendingTransfer(t))
+	}
+
+	return res, nil
+}
+
+// ApprovePendingTransfer approves a pending transfer and makes it, without screening it again. The transfer is pending
+// again when it fails, e.g. when the balance of the account is no longer sufficient.
+// returns ApprovePendingTransferResponse.
+func (s *Service) ApprovePendingTransfer(
+	ctx context.Context,
+	pendingTransferID uuid.UUID,
+) (types.ApprovePendingTransferResponse, error) {
+	// The transfer is approved first, so that it is made once when approved concurrently.
+	p, err := s.decide(ctx, pendingTransferID, types.PendingTransferStatusApproved, audit.ActionApprovePendingTransfer)
+	if err != nil {
+		return types.ApprovePendingTransferResponse{}, err
+	}
+
+	res, err := s.accounts.TransferMoney(ContextWithApproval(ctx), &types.TransferMoneyRequest{
+		ReciverAccountID: p.ReciverAccountID,
+		Amount:           storage.AmountFromNumeric(p.Amount, p.CurrencyCode),
+	}, p.AccountID)
+	if err != nil {
+		if err := s.store.ReopenPendingTransfer(ctx, pendingTransferID); err != nil {
+			s.logger.ErrorContext(ctx, "failed to reopen pending transfer", "error", err)
+		}
+
+		return types.ApprovePendingTransferResponse{}, err
+	}
+
+	p.TransactionID = uuid.NullUUID{UUID: res.TransactionID, Valid: true}
+
+	// The transfer is made, it is reported even if its transaction cannot be saved.
+	if err := s.store.SetPendingTransferTransaction(ctx, storage.SetPendingTransferTransactionParams{
+		PendingTransferID: pendingTransferID,
+		TransactionID:     p.TransactionID,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to set pending transfer transaction", "error", err)
+	}
+
+	return types.ApprovePendingTransferResponse{PendingTransfer: toPendingTransfer(p)}, nil
+}
+
+// RejectPendingTransfer rejects a pending transfer, which is never made.
+// returns RejectPendingTransferResponse.
+func (s *Service) RejectPendingTransfer(
+	ctx context.Context,
+	pendingTransferID uuid.UUID,
+) (types.RejectPendingTransferResponse, error) {
+	p, err := s.decide(ctx, pendingTransferID, types.PendingTransferStatusRejected, audit.ActionRejectPendingTransfer)
+	if err != nil {
+		return types.RejectPendingTransferResponse{}, err
+	}
+
+	return types.RejectPendingTransferResponse{PendingTransfer: toPendingTransfer(p)}, nil
+}
+
+// decide sets the status of a pending transfer within a transaction, recording the decision as action, failing when it
+// is no longer pending.
+func (s *Service) decide(
+	ctx context.Context,
+	pendingTransferID uuid.UUID,
+	status, action string,
+) (storage.PendingTransfer, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return storage.PendingTransfer{}, types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	p, err := s.storeWithTx(tx).DecidePendingTransfer(ctx, storage.DecidePendingTransferParams{
+		PendingTransferID: pendingTransferID,
+		Status:            status,
+		DecidedAt:         pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return storage.PendingTransfer{}, s.decideError(ctx, pendingTransferID, err)
+	}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    action,
+		AccountID: uuid.NullUUID{UUID: p.AccountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After:     toPendingTransfer(p),
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return storage.PendingTransfer{}, types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return storage.PendingTransfer{}, types.ErrInternal
+	}
+
+	return p, nil
+}
+
+// decideError returns the error of a pending transfer which could not be decided.
+func (s *Service) decideError(ctx context.Context, pendingTransferID uuid.UUID, err error) error {
+	if !errors.Is(err, pgx.ErrNoRows) {
+		s.logger.ErrorContext(ctx, "failed to decide pending transfer", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if _, err := s.store.GetPendingTransfer(ctx, pendingTransferID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.ErrPendingTransferNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get pending transfer", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return types.ErrPendingTransferDecided
+}
+
+func toPendingTransfer(p storage.PendingTransfer) types.PendingTransfer {
+	t := types.PendingTransfer{
+		ID:               p.PendingTransferID,
+		AccountID:        p.AccountID,
+		ReciverAccountID: p.ReciverAccountID,
+		Amount:           storage.AmountFromNumeric(p.Amount, p.CurrencyCode),
+		Status:           p.Status,
+		Rules:            p.Rules,
+		TransactionID:    p.TransactionID,
+		CreatedAt:        p.CreatedAt.Time,
+	}
+
+	if p.DecidedAt.Valid {
+		t.DecidedAt = &p.DecidedAt.Time
+	}
+
+	return t
+}
@@ -0,0 +1,234 @@
+package risk
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var wantReciverTransactionID = uuid.MustParse("12345678-1234-1234-1234-123456789004")
+
+// transferFunc is an AccountService made of a function.
+type transferFunc func(
+	ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID) (types.TransferMoneyResponse, error)
+
+func (f transferFunc) TransferMoney(
+	ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID,
+) (types.TransferMoneyResponse, error) {
+	return f(ctx, req, accountID)
+}
+
+// noTransfer fails the test when a transfer is made.
+func noTransfer(t *testing.T) transferFunc {
+	t.Helper()
+
+	return func(context.Context, *types.TransferMoneyRequest, uuid.UUID) (types.TransferMoneyResponse, error) {
+		t.Error("unexpected transfer")
+
+		return types.TransferMoneyResponse{}, nil
+	}
+}
+
+func testPendingTransfer(status string) storage.PendingTransfer {
+	return storage.PendingTransfer{
+		PendingTransferID: wantPendingTransferID,
+		AccountID:         wantAccountID,
+		ReciverAccountID:  wantReciverAccountID,
+		Amount:            storage.NumericFromAmount(500000, "EUR"),
+		CurrencyCode:      "EUR",
+		Status:            status,
+		Rules:             []string{"new-receiver"},
+		CreatedAt:         pgtype.Timestamptz{Time: wantNow.Add(-time.Hour), Valid: true},
+		DecidedAt:         pgtype.Timestamptz{Time: wantNow, Valid: status != types.PendingTransferStatusPending},
+	}
+}
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// wantAction returns an Auditor expecting successful events of action on the account.
+func wantAction(t *testing.T, action string) recordFunc {
+	t.Helper()
+
+	return recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+		assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+
+		return nil
+	})
+}
+
+func newTestService(
+	conn storage.DBConnection,
+	store storage.PendingTransferStore,
+	auditor Auditor,
+	accounts AccountService,
+) *Service {
+	s := NewService(conn, store, auditor, accounts, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.PendingTransferStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func TestService_ApprovePendingTransfer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mock     func(*storageMocks.MockPendingTransferStore)
+		decided  bool
+		transfer transferFunc
+		want     types.ApprovePendingTransferResponse
+		wantErr  error
+	}{
+		{
+			name: "failed when the pending transfer is not found",
+			mock: func(ms *storageMocks.MockPendingTransferStore) {
+				ms.EXPECT().DecidePendingTransfer(mock.Anything, mock.Anything).
+					Return(storage.PendingTransfer{}, pgx.ErrNoRows).Once()
+				ms.EXPECT().GetPendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(storage.PendingTransfer{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrPendingTransferNotFound,
+		},
+		{
+			name: "failed when the pending transfer was already decided",
+			mock: func(ms *storageMocks.MockPendingTransferStore) {
+				ms.EXPECT().DecidePendingTransfer(mock.Anything, mock.Anything).
+					Return(storage.PendingTransfer{}, pgx.ErrNoRows).Once()
+				ms.EXPECT().GetPendingTransfer(mock.Anything, wantPendingTransferID).
+					Return(testPendingTransfer(types.PendingTransferStatusRejected), nil).Once()
+			},
+			wantErr: types.ErrPendingTransferDecided,
+		},
+		{
+			name: "failed and pending again when the transfer fails",
+			mock: func(ms *storageMocks.MockPendingTransferStore) {
+				ms.EXPECT().DecidePendingTransfer(mock.Anything, mock.Anything).
+					Return(testPendingTransfer(types.PendingTransferStatusApproved), nil).Once()
+				ms.EXPECT().ReopenPendingTransfer(mock.Anything, wantPendingTransferID).Return(nil).Once()
+			},
+			decided: true,
+			transfer: func(context.Context, *types.TransferMoneyRequest, uuid.UUID) (types.TransferMoneyResponse, error) {
+				return types.TransferMoneyResponse{}, types.ErrInsufficientAccountBalance
+			},
+			wantErr: types.ErrInsufficientAccountBalance,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockPendingTransferStore) {
+				ms.EXPECT().DecidePendingTransfer(mock.Anything, storage.DecidePendingTransferParams{
+					PendingTransferID: wantPendingTransferID,
+					Status:            types.PendingTransferStatusApproved,
+					DecidedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(testPendingTransfer(types.PendingTransferStatusApproved), nil).Once()
+				ms.EXPECT().SetPendingTransferTransaction(mock.Anything, storage.SetPendingTransferTransactionParams{
+					PendingTransferID: wantPendingTransferID,
+					TransactionID:     uuid.NullUUID{UUID: wantReciverTransactionID, Valid: true},
+				}).Return(nil).Once()
+			},
+			decided: true,
+			transfer: func(
+				ctx context.Context, req *types.TransferMoneyRequest, accountID uuid.UUID,
+			) (types.TransferMoneyResponse, error) {
+				if !approved(ctx) || accountID != wantAccountID ||
+					*req != (types.TransferMoneyRequest{ReciverAccountID: wantReciverAccountID, Amount: 500000}) {
+					return types.TransferMoneyResponse{}, errAnything
+				}
+
+				return types.TransferMoneyResponse{TransactionID: wantReciverTransactionID}, nil
+			},
+			want: types.ApprovePendingTransferResponse{PendingTransfer: types.PendingTransfer{
+				ID:               wantPendingTransferID,
+				AccountID:        wantAccountID,
+				ReciverAccountID: wantReciverAccountID,
+				Amount:           500000,
+				Status:           types.PendingTransferStatusApproved,
+				Rules:            []string{"new-receiver"},
+				TransactionID:    uuid.NullUUID{UUID: wantReciverTransactionID, Valid: true},
+				CreatedAt:        wantNow.Add(-time.Hour),
+				DecidedAt:        &wantNow,
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockPendingTransferStore(t)
+			tt.mock(store)
+
+			accounts := tt.transfer
+			if accounts == nil {
+				accounts = noTransfer(t)
+			}
+
+			auditor := wantAction(t, audit.ActionApprovePendingTransfer)
+			s := newTestService(storagetest.NewConn(t, tt.decided), store, auditor, accounts)
+
+			got, err := s.ApprovePendingTransfer(context.Background(), wantPendingTransferID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RejectPendingTransfer(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockPendingTransferStore(t)
+	store.EXPECT().DecidePendingTransfer(mock.Anything, storage.DecidePendingTransferParams{
+		PendingTransferID: wantPendingTransferID,
+		Status:            types.PendingTransferStatusRejected,
+		DecidedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}).Return(testPendingTransfer(types.PendingTransferStatusRejected), nil).Once()
+
+	auditor := wantAction(t, audit.ActionRejectPendingTransfer)
+	s := newTestService(storagetest.NewConn(t, true), store, auditor, noTransfer(t))
+
+	got, err := s.RejectPendingTransfer(
+		context.Background(), wantPendingTransferID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.PendingTransferStatusRejected, got.Status)
+	assert.Equal(t, &wantNow, got.DecidedAt)
+}
+
+func TestService_ListPendingTransfers(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockPendingTransferStore(t)
+	store.EXPECT().ListPendingTransfers(mock.Anything, storage.ListPendingTransfersParams{
+		Status: types.PendingTransferStatusPending,
+		Limit:  10,
+		Offset: 20,
+	}).Return([]storage.PendingTransfer{testPendingTransfer(types.PendingTransferStatusPending)}, nil).Once()
+
+	got, err := newTestService(nil, store, nil, noTransfer(t)).ListPendingTransfers(
+		context.Background(), types.PendingTransferStatusPending, 10, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, got.PendingTransfers, 1)
+	assert.Nil(t, got.PendingTransfers[0].DecidedAt)
+}
@@ -0,0 +1,228 @@
+// Package risk screens transfers before they are made. Rules, defined in a configuration file, flag transfers made too
+// often, of unusual amounts, of large amounts to new receivers, or moving money back and forth between the same
+// accounts. Each rule decides what becomes of the transfers it flags: they are held for review by the admin, or
+// denied. Other checks, e.g. the sanctions screening, can be added to the rules.
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Decision is what becomes of a transfer, the most severe decision of the rules it matches.
+type Decision string
+
+const pqErrorForeignKeyViolation = "23503"
+
+const (
+	DecisionAllow  Decision = "allow"
+	DecisionReview Decision = "review"
+	DecisionDeny   Decision = "deny"
+)
+
+var severity = []Decision{DecisionAllow, DecisionReview, DecisionDeny}
+
+func (d *Decision) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("failed to decode decision: %w", err)
+	}
+
+	if !slices.Contains(severity, Decision(s)) {
+		return fmt.Errorf("%w: decision %q", errInvalidRule, s)
+	}
+
+	*d = Decision(s)
+
+	return nil
+}
+
+type approvedCtxKey struct{}
+
+// ContextWithApproval returns a context whose transfers are not screened, as they were approved by the admin.
+func ContextWithApproval(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedCtxKey{}, true)
+}
+
+func approved(ctx context.Context) bool {
+	ok, _ := ctx.Value(approvedCtxKey{}).(bool)
+
+	return ok
+}
+
+// namedRule is a rule with the name and decision it is configured with.
+type namedRule struct {
+	name     string
+	decision Decision
+	rule     Rule
+}
+
+// Check screens transfers along with the rules, e.g. against the sanctions lists, reading what it needs within tx.
+type Check interface {
+	Check(ctx context.Context, tx pgx.Tx, t Transfer) (Decision, error)
+}
+
+// namedCheck is a check with the name the transfers it does not allow match.
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Engine screens transfers with its rules and checks.
+type Engine struct {
+	rules       []namedRule
+	checks      []namedCheck
+	storeWithTx func(tx pgx.Tx) storage.RiskStore
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Engine screening transfers with the rules of config, which allows every transfer when it has
+// none.
+func New(config Config, logger logger.Logger) (*Engine, error) {
+	e := &Engine{
+		storeWithTx: storage.RiskStoreWithTx,
+		logger:      logger,
+		now:         time.Now,
+	}
+
+	for _, c := range config.Rules {
+		rule, err := NewRule(c)
+		if err != nil {
+			return nil, err
+		}
+
+		decision := c.Decision
+		if decision == "" {
+			decision = DecisionReview
+		}
+
+		e.rules = append(e.rules, namedRule{name: c.Name, decision: decision, rule: rule})
+	}
+
+	return e, nil
+}
+
+// Use adds a check to the engine, the transfers it does not allow matching name as they match a rule. It must be
+// called before transfers are screened.
+func (e *Engine) Use(name string, check Check) {
+	e.checks = append(e.checks, namedCheck{name: name, check: check})
+}
+
+// Evaluate returns the decision for a transfer and the names of the rules and checks it matched, reading the past
+// transfers within tx.
+func (e *Engine) Evaluate(ctx context.Context, tx pgx.Tx, t Transfer) (Decision, []string, error) {
+	store := e.storeWithTx(tx)
+	decision := DecisionAllow
+
+	matched := make([]string, 0, len(e.rules)+len(e.checks))
+
+	add := func(name string, d Decision) {
+		matched = append(matched, name)
+
+		if slices.Index(severity, d) > slices.Index(severity, decision) {
+			decision = d
+		}
+	}
+
+	for _, r := range e.rules {
+		ok, err := r.rule.Match(ctx, store, t)
+		if err != nil {
+			return "", nil, fmt.Errorf("rule %q: %w", r.name, err)
+		}
+
+		if ok {
+			add(r.name, r.decision)
+		}
+	}
+
+	for _, c := range e.checks {
+		d, err := c.check.Check(ctx, tx, t)
+		if err != nil {
+			return "", nil, fmt.Errorf("check %q: %w", c.name, err)
+		}
+
+		if d != DecisionAllow {
+			add(c.name, d)
+		}
+	}
+
+	return decision, matched, nil
+}
+
+// Screen screens a transfer of amount, in the currency of account, from account within tx. It fails with
+// types.ErrTransferDenied when the transfer is denied, and with a types.PendingReviewError when it is held for review,
+// in which case the pending transfer is saved within tx, which must be committed nonetheless. Transfers approved by the
+// admin, see ContextWithApproval, are not screened again.
+func (e *Engine) Screen(
+	ctx context.Context,
+	tx pgx.Tx,
+	account storage.Account,
+	reciverAccountID uuid.UUID,
+	amount money.Amount,
+) error {
+	if len(e.rules)+len(e.checks) == 0 || approved(ctx) {
+		return nil
+	}
+
+	now := e.now().UTC()
+
+	decision, rules, err := e.Evaluate(ctx, tx, Transfer{
+		AccountID:        account.AccountID,
+		ReciverAccountID: reciverAccountID,
+		CurrencyCode:     account.CurrencyCode,
+		Amount:           amount,
+		Time:             now,
+	})
+	if err != nil {
+		e.logger.ErrorContext(ctx, "failed to evaluate risk rules", "error", err)
+
+		return types.ErrInternal
+	}
+
+	switch decision {
+	case DecisionDeny:
+		e.logger.WarnContext(ctx, "transfer denied", "account_id", account.AccountID, "rules", rules)
+
+		return types.ErrTransferDenied
+	case DecisionReview:
+		p, err := e.storeWithTx(tx).CreatePendingTransfer(ctx, storage.CreatePendingTransferParams{
+			AccountID:        account.AccountID,
+			ReciverAccountID: reciverAccountID,
+			Amount:           storage.NumericFromAmount(amount, account.CurrencyCode),
+			CurrencyCode:     account.CurrencyCode,
+			Rules:            rules,
+			CreatedAt:        pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			pgErr := &pgconn.PgError{}
+			if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+				return types.ErrRecieverAccountNotFound
+			}
+
+			e.logger.ErrorContext(ctx, "failed to create pending transfer", "error", err)
+
+			return types.ErrInternal
+		}
+
+		e.logger.InfoContext(ctx, "transfer held for review", "pending_transfer_id", p.PendingTransferID, "rules", rules)
+
+		return &types.PendingReviewError{PendingTransferID: p.PendingTransferID}
+	case DecisionAllow:
+	}
+
+	return nil
+}
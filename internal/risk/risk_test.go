@@ -0,0 +1,275 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID         = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantAccount           = storage.Account{AccountID: wantAccountID, CurrencyCode: "EUR"}
+	wantReciverAccountID  = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantPendingTransferID = uuid.MustParse("12345678-1234-1234-1234-123456789005")
+	wantNow               = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	errAnything           = errors.New("any")
+
+	// testConfig reviews the transfers to new receivers and denies the round trips.
+	testConfig = Config{Rules: []RuleConfig{
+		{Name: "new-receiver", Type: RuleNewReceiver},
+		{Name: "round-trip", Type: RuleRoundTrip, Decision: DecisionDeny, Window: Duration(time.Hour)},
+	}}
+)
+
+func newTestEngine(t *testing.T, store storage.RiskStore) *Engine {
+	t.Helper()
+
+	e, err := New(testConfig, slog.Default())
+	require.NoError(t, err)
+
+	e.storeWithTx = func(pgx.Tx) storage.RiskStore { return store }
+	e.now = func() time.Time { return wantNow }
+
+	return e
+}
+
+// expectTransfers sets whether the account transferred money to the receiver, and the receiver to the account.
+func expectTransfers(store *storageMocks.MockRiskStore, toReceiver, fromReceiver bool) {
+	store.EXPECT().HasTransferredTo(mock.Anything, mock.MatchedBy(func(arg storage.HasTransferredToParams) bool {
+		return arg.AccountID == wantAccountID
+	})).Return(toReceiver, nil).Once()
+	store.EXPECT().HasTransferredTo(mock.Anything, mock.MatchedBy(func(arg storage.HasTransferredToParams) bool {
+		return arg.AccountID == wantReciverAccountID
+	})).Return(fromReceiver, nil).Once()
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Rules: []RuleConfig{{Name: "geo", Type: "country"}}}, slog.Default())
+	assert.ErrorIs(t, err, errUnknownRuleType)
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		toReceiver   bool
+		fromReceiver bool
+		want         Decision
+		wantRules    []string
+	}{
+		{
+			name:       "allow when no rule matches",
+			toReceiver: true,
+			want:       DecisionAllow,
+			wantRules:  []string{},
+		},
+		{
+			name:      "review when a review rule matches",
+			want:      DecisionReview,
+			wantRules: []string{"new-receiver"},
+		},
+		{
+			name:         "deny when the most severe rule matched denies",
+			fromReceiver: true,
+			want:         DecisionDeny,
+			wantRules:    []string{"new-receiver", "round-trip"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockRiskStore(t)
+			expectTransfers(store, tt.toReceiver, tt.fromReceiver)
+
+			got, rules, err := newTestEngine(t, store).Evaluate(context.Background(), nil, Transfer{
+				AccountID:        wantAccountID,
+				ReciverAccountID: wantReciverAccountID,
+				Amount:           100,
+				Time:             wantNow,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestEngine_Screen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		mock    func(*storageMocks.MockRiskStore)
+		wantErr error
+	}{
+		{
+			name: "failed when a rule cannot be evaluated",
+			ctx:  context.Background(),
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().HasTransferredTo(mock.Anything, mock.Anything).Return(false, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "failed when the transfer is denied",
+			ctx:  context.Background(),
+			mock: func(ms *storageMocks.MockRiskStore) {
+				expectTransfers(ms, true, true)
+			},
+			wantErr: types.ErrTransferDenied,
+		},
+		{
+			name: "failed when the receiver account of a transfer held for review is not found",
+			ctx:  context.Background(),
+			mock: func(ms *storageMocks.MockRiskStore) {
+				expectTransfers(ms, false, false)
+				ms.EXPECT().CreatePendingTransfer(mock.Anything, mock.Anything).
+					Return(storage.PendingTransfer{}, &pgconn.PgError{Code: pqErrorForeignKeyViolation}).Once()
+			},
+			wantErr: types.ErrRecieverAccountNotFound,
+		},
+		{
+			name: "failed when the transfer is held for review",
+			ctx:  context.Background(),
+			mock: func(ms *storageMocks.MockRiskStore) {
+				expectTransfers(ms, false, false)
+				ms.EXPECT().CreatePendingTransfer(mock.Anything, storage.CreatePendingTransferParams{
+					AccountID:        wantAccountID,
+					ReciverAccountID: wantReciverAccountID,
+					Amount:           storage.NumericFromAmount(100, "EUR"),
+					CurrencyCode:     "EUR",
+					Rules:            []string{"new-receiver"},
+					CreatedAt:        pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(storage.PendingTransfer{PendingTransferID: wantPendingTransferID}, nil).Once()
+			},
+			wantErr: &types.PendingReviewError{PendingTransferID: wantPendingTransferID},
+		},
+		{
+			name: "success when the transfer is allowed",
+			ctx:  context.Background(),
+			mock: func(ms *storageMocks.MockRiskStore) {
+				expectTransfers(ms, true, false)
+			},
+		},
+		{
+			name: "success when the transfer was approved by the admin",
+			ctx:  ContextWithApproval(context.Background()),
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockRiskStore(t)
+			if tt.mock != nil {
+				tt.mock(store)
+			}
+
+			err := newTestEngine(t, store).Screen(tt.ctx, nil, wantAccount, wantReciverAccountID, 100)
+
+			if pendingErr := (&types.PendingReviewError{}); errors.As(tt.wantErr, &pendingErr) {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEngine_Screen_withoutRules(t *testing.T) {
+	t.Parallel()
+
+	e, err := New(Config{}, slog.Default())
+	require.NoError(t, err)
+
+	assert.NoError(t, e.Screen(context.Background(), nil, wantAccount, wantReciverAccountID, 100))
+}
+
+// checkFunc is a Check returning a decision.
+type checkFunc func(t Transfer) (Decision, error)
+
+func (f checkFunc) Check(_ context.Context, _ pgx.Tx, t Transfer) (Decision, error) {
+	return f(t)
+}
+
+func TestEngine_Evaluate_withChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		check     checkFunc
+		want      Decision
+		wantRules []string
+		wantErr   error
+	}{
+		{
+			name:    "failed when a check fails",
+			check:   func(Transfer) (Decision, error) { return "", errAnything },
+			wantErr: errAnything,
+		},
+		{
+			name:      "allow when the checks allow the transfer",
+			check:     func(Transfer) (Decision, error) { return DecisionAllow, nil },
+			want:      DecisionAllow,
+			wantRules: []string{},
+		},
+		{
+			name: "deny when a check denies the transfer",
+			check: func(t Transfer) (Decision, error) {
+				if t.ReciverAccountID != wantReciverAccountID {
+					return "", errAnything
+				}
+
+				return DecisionDeny, nil
+			},
+			want:      DecisionDeny,
+			wantRules: []string{"sanctions"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := New(Config{}, slog.Default())
+			require.NoError(t, err)
+
+			e.Use("sanctions", tt.check)
+
+			got, rules, err := e.Evaluate(context.Background(), nil, Transfer{
+				AccountID:        wantAccountID,
+				ReciverAccountID: wantReciverAccountID,
+				Amount:           100,
+				Time:             wantNow,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// Types of rules.
+const (
+	RuleVelocity      = "velocity"
+	RuleUnusualAmount = "unusual_amount"
+	RuleNewReceiver   = "new_receiver"
+	RuleRoundTrip     = "round_trip"
+)
+
+var (
+	errUnknownRuleType = errors.New("unknown rule type")
+	errInvalidRule     = errors.New("invalid rule")
+)
+
+// Transfer is a transfer screened by the rules.
+type Transfer struct {
+	AccountID        uuid.UUID
+	ReciverAccountID uuid.UUID
+	// CurrencyCode is the currency of the account, and of Amount.
+	CurrencyCode string
+	Amount       money.Amount
+	// Time is when the transfer is made, the windows of the rules end then.
+	Time time.Time
+}
+
+// Rule matches the transfers it flags, reading the past transfers from store.
+type Rule interface {
+	Match(ctx context.Context, store storage.RiskStore, transfer Transfer) (bool, error)
+}
+
+// Config is the configuration of the rules, read from a JSON file.
+type Config struct {
+	Rules []RuleConfig `json:"rules"`
+}
+
+// RuleConfig configures a rule, the fields used depend on its type.
+type RuleConfig struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Decision Decision `json:"decision"`
+	// Window is how far back the past transfers are read, e.g. "1h".
+	Window Duration `json:"window"`
+	// MaxCount is the number of transfers in the window a velocity rule allows.
+	MaxCount int32 `json:"maxCount"`
+	// Factor is how many times the average amount of the past transfers an unusual amount exceeds, when the account
+	// made at least MinTransfers of them.
+	Factor       float64 `json:"factor"`
+	MinTransfers int32   `json:"minTransfers"`
+	// MinAmount is the amount from which a transfer to a new receiver is flagged.
+	MinAmount money.Amount `json:"minAmount"`
+}
+
+// Duration is a time.Duration read from a string such as "24h".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("failed to decode duration: %w", err)
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration: %w", err)
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// LoadConfig reads the configuration of the rules from a JSON file.
+func LoadConfig(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read risk rules: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(b, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to decode risk rules: %w", err)
+	}
+
+	return config, nil
+}
+
+// ruleTypes are the constructors of the rules by type.
+var ruleTypes = map[string]func(c RuleConfig) (Rule, error){
+	RuleVelocity:      newVelocity,
+	RuleUnusualAmount: newUnusualAmount,
+	RuleNewReceiver:   newNewReceiver,
+	RuleRoundTrip:     newRoundTrip,
+}
+
+// NewRule returns the rule a RuleConfig configures.
+//
+//nolint:ireturn // rules are of as many types as there are rules.
+func NewRule(c RuleConfig) (Rule, error) {
+	newRule, ok := ruleTypes[c.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w %q of rule %q", errUnknownRuleType, c.Type, c.Name)
+	}
+
+	return newRule(c)
+}
+
+// velocity matches transfers made when the account already made maxCount transfers in the window.
+type velocity struct {
+	window   time.Duration
+	maxCount int32
+}
+
+//nolint:ireturn // returned as a Rule by NewRule.
+func newVelocity(c RuleConfig) (Rule, error) {
+	if c.Window <= 0 || c.MaxCount < 1 {
+		return nil, fmt.Errorf("%w %q: window and maxCount are required", errInvalidRule, c.Name)
+	}
+
+	return &velocity{window: time.Duration(c.Window), maxCount: c.MaxCount}, nil
+}
+
+func (r *velocity) Match(ctx context.Context, store storage.RiskStore, t Transfer) (bool, error) {
+	count, err := store.CountTransfersSince(ctx, storage.CountTransfersSinceParams{
+		AccountID: t.AccountID,
+		Since:     since(t, r.window),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count transfers: %w", err)
+	}
+
+	return count >= r.maxCount, nil
+}
+
+// unusualAmount matches transfers of more than factor times the average amount of the transfers of the account in the
+// window, once it made minTransfers of them.
+type unusualAmount struct {
+	window       time.Duration
+	factor       float64
+	minTransfers int32
+}
+
+//nolint:ireturn // returned as a Rule by NewRule.
+func newUnusualAmount(c RuleConfig) (Rule, error) {
+	if c.Window <= 0 || c.Factor <= 1 || c.MinTransfers < 1 {
+		return nil, fmt.Errorf("%w %q: window, a factor above 1 and minTransfers are required", errInvalidRule, c.Name)
+	}
+
+	return &unusualAmount{window: time.Duration(c.Window), factor: c.Factor, minTransfers: c.MinTransfers}, nil
+}
+
+func (r *unusualAmount) Match(ctx context.Context, store storage.RiskStore, t Transfer) (bool, error) {
+	avg, err := store.GetTransferAverage(ctx, storage.GetTransferAverageParams{
+		AccountID: t.AccountID,
+		Since:     since(t, r.window),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer average: %w", err)
+	}
+
+	if avg.Transfers < r.minTransfers {
+		return false, nil
+	}
+
+	return float64(t.Amount) > r.factor*float64(storage.AmountFromNumeric(avg.Average, t.CurrencyCode)), nil
+}
+
+// newReceiver matches transfers of at least minAmount to receivers the account never transferred money to.
+type newReceiver struct {
+	minAmount money.Amount
+}
+
+//nolint:ireturn,unparam // returned as a Rule by NewRule.
+func newNewReceiver(c RuleConfig) (Rule, error) {
+	return &newReceiver{minAmount: c.MinAmount}, nil
+}
+
+func (r *newReceiver) Match(ctx context.Context, store storage.RiskStore, t Transfer) (bool, error) {
+	if t.Amount < r.minAmount {
+		return false, nil
+	}
+
+	known, err := store.HasTransferredTo(ctx, storage.HasTransferredToParams{
+		AccountID:        t.AccountID,
+		ReciverAccountID: t.ReciverAccountID,
+		Since:            pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check receiver: %w", err)
+	}
+
+	return !known, nil
+}
+
+// roundTrip matches transfers of at least minAmount to a receiver which transferred money to the account in the
+// window, moving money back and forth between the same accounts.
+type roundTrip struct {
+	window    time.Duration
+	minAmount money.Amount
+}
+
+//nolint:ireturn // returned as a Rule by NewRule.
+func newRoundTrip(c RuleConfig) (Rule, error) {
+	if c.Window <= 0 {
+		return nil, fmt.Errorf("%w %q: window is required", errInvalidRule, c.Name)
+	}
+
+	return &roundTrip{window: time.Duration(c.Window), minAmount: c.MinAmount}, nil
+}
+
+func (r *roundTrip) Match(ctx context.Context, store storage.RiskStore, t Transfer) (bool, error) {
+	if t.Amount < r.minAmount {
+		return false, nil
+	}
+
+	returned, err := store.HasTransferredTo(ctx, storage.HasTransferredToParams{
+		AccountID:        t.ReciverAccountID,
+		ReciverAccountID: t.AccountID,
+		Since:            since(t, r.window),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check transfers from receiver: %w", err)
+	}
+
+	return returned, nil
+}
+
+func since(t Transfer, window time.Duration) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t.Time.Add(-window), Valid: true}
+}
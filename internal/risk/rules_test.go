@@ -0,0 +1,227 @@
+package risk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadConfig(filepath.Join("..", "..", "config", "risk_rules.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, RuleConfig{
+		Name:     "velocity",
+		Type:     RuleVelocity,
+		Decision: DecisionReview,
+		Window:   Duration(time.Hour),
+		MaxCount: 10,
+	}, config.Rules[0])
+
+	for _, c := range config.Rules {
+		_, err := NewRule(c)
+		assert.NoError(t, err, c.Name)
+	}
+}
+
+func TestLoadConfig_invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "failed when the decision is unknown", content: `{"rules":[{"decision":"block"}]}`},
+		{name: "failed when the window is not a duration", content: `{"rules":[{"window":"1 day"}]}`},
+		{name: "failed when the file is not json", content: `rules: []`},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "risk_rules.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := LoadConfig(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  RuleConfig
+		wantErr error
+	}{
+		{
+			name:    "failed when the type is unknown",
+			config:  RuleConfig{Name: "geo", Type: "country"},
+			wantErr: errUnknownRuleType,
+		},
+		{
+			name:    "failed when a velocity rule has no count",
+			config:  RuleConfig{Name: "velocity", Type: RuleVelocity, Window: Duration(time.Hour)},
+			wantErr: errInvalidRule,
+		},
+		{
+			name: "failed when an unusual amount rule has a factor of 1",
+			config: RuleConfig{
+				Name: "unusual", Type: RuleUnusualAmount, Window: Duration(time.Hour), Factor: 1, MinTransfers: 1,
+			},
+			wantErr: errInvalidRule,
+		},
+		{
+			name:    "failed when a round trip rule has no window",
+			config:  RuleConfig{Name: "round-trip", Type: RuleRoundTrip},
+			wantErr: errInvalidRule,
+		},
+		{
+			name:   "success when a new receiver rule has no minimum amount",
+			config: RuleConfig{Name: "new-receiver", Type: RuleNewReceiver},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewRule(tt.config)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRule_Match(t *testing.T) {
+	t.Parallel()
+
+	transfer := Transfer{
+		AccountID:        wantAccountID,
+		ReciverAccountID: wantReciverAccountID,
+		Amount:           50000,
+		Time:             wantNow,
+	}
+	hourAgo := pgtype.Timestamptz{Time: wantNow.Add(-time.Hour), Valid: true}
+
+	tests := []struct {
+		name string
+		rule Rule
+		mock func(*storageMocks.MockRiskStore)
+		want bool
+	}{
+		{
+			name: "velocity matches when the account made as many transfers as allowed",
+			rule: &velocity{window: time.Hour, maxCount: 3},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().CountTransfersSince(mock.Anything, storage.CountTransfersSinceParams{
+					AccountID: wantAccountID, Since: hourAgo,
+				}).Return(3, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "velocity does not match below the count",
+			rule: &velocity{window: time.Hour, maxCount: 3},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().CountTransfersSince(mock.Anything, mock.Anything).Return(2, nil).Once()
+			},
+		},
+		{
+			name: "unusual amount matches above factor times the average",
+			rule: &unusualAmount{window: time.Hour, factor: 4, minTransfers: 3},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().GetTransferAverage(mock.Anything, storage.GetTransferAverageParams{
+					AccountID: wantAccountID, Since: hourAgo,
+				}).Return(storage.GetTransferAverageRow{
+					Transfers: 3, Average: storage.NumericFromAmount(12499, "EUR"),
+				}, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "unusual amount does not match without enough history",
+			rule: &unusualAmount{window: time.Hour, factor: 4, minTransfers: 3},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().GetTransferAverage(mock.Anything, mock.Anything).
+					Return(storage.GetTransferAverageRow{
+						Transfers: 2, Average: storage.NumericFromAmount(100, "EUR"),
+					}, nil).Once()
+			},
+		},
+		{
+			name: "new receiver matches a large amount to a receiver never paid",
+			rule: &newReceiver{minAmount: 50000},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().HasTransferredTo(mock.Anything, storage.HasTransferredToParams{
+					AccountID:        wantAccountID,
+					ReciverAccountID: wantReciverAccountID,
+					Since:            pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+				}).Return(false, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "new receiver does not match a small amount",
+			rule: &newReceiver{minAmount: 50001},
+		},
+		{
+			name: "round trip matches when the receiver transferred to the account in the window",
+			rule: &roundTrip{window: time.Hour},
+			mock: func(ms *storageMocks.MockRiskStore) {
+				ms.EXPECT().HasTransferredTo(mock.Anything, storage.HasTransferredToParams{
+					AccountID:        wantReciverAccountID,
+					ReciverAccountID: wantAccountID,
+					Since:            hourAgo,
+				}).Return(true, nil).Once()
+			},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockRiskStore(t)
+			if tt.mock != nil {
+				tt.mock(store)
+			}
+
+			got, err := tt.rule.Match(context.Background(), store, transfer)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRule_Match_failed(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockRiskStore(t)
+	store.EXPECT().CountTransfersSince(mock.Anything, mock.Anything).Return(0, errAnything).Once()
+
+	_, err := (&velocity{window: time.Hour, maxCount: 1}).Match(context.Background(), store, Transfer{
+		AccountID: uuid.New(),
+	})
+
+	assert.ErrorIs(t, err, errAnything)
+}
@@ -0,0 +1,199 @@
+package sanctions
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatXML = "xml"
+
+	columnReference = "reference"
+	columnName      = "name"
+)
+
+var (
+	ErrInvalidList   = errors.New("invalid sanctions list")
+	ErrUnknownFormat = errors.New("unknown sanctions list format, must be one of csv or xml")
+)
+
+// Entry is an entry of a sanctions list: the name of a sanctioned person or entity, and its reference in the list.
+type Entry struct {
+	Reference string `xml:"reference,attr"`
+	Name      string `xml:"name"`
+}
+
+// xmlList is a sanctions list in XML:
+//
+//	<sanctionsList>
+//	  <entry reference="..."><name>...</name></entry>
+//	</sanctionsList>
+type xmlList struct {
+	XMLName xml.Name `xml:"sanctionsList"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Parse parses a sanctions list in format, csv or xml.
+func Parse(r io.Reader, format string) ([]Entry, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatXML:
+		return ParseXML(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// ParseCSV parses a sanctions list in CSV, whose header names the reference and name columns. Other columns are
+// ignored.
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %w", ErrInvalidList, err)
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	reference, name := slices.Index(header, columnReference), slices.Index(header, columnName)
+	if reference < 0 || name < 0 {
+		return nil, fmt.Errorf("%w: the header must name the %s and %s columns", ErrInvalidList, columnReference, columnName)
+	}
+
+	var entries []Entry
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidList, err)
+		}
+
+		if len(record) <= max(reference, name) {
+			line, _ := reader.FieldPos(0)
+
+			return nil, fmt.Errorf("%w: line %d: missing columns", ErrInvalidList, line)
+		}
+
+		entries = append(entries, Entry{Reference: record[reference], Name: record[name]})
+	}
+
+	return validate(entries)
+}
+
+// ParseXML parses a sanctions list in XML, see xmlList.
+func ParseXML(r io.Reader) ([]Entry, error) {
+	var list xmlList
+
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidList, err)
+	}
+
+	return validate(list.Entries)
+}
+
+// validate trims the entries, which must all have a reference and a name.
+func validate(entries []Entry) ([]Entry, error) {
+	for i, e := range entries {
+		e.Reference, e.Name = strings.TrimSpace(e.Reference), strings.TrimSpace(e.Name)
+		if e.Reference == "" || e.Name == "" {
+			return nil, fmt.Errorf("%w: entry %d: missing reference or name", ErrInvalidList, i+1)
+		}
+
+		entries[i] = e
+	}
+
+	return entries, nil
+}
+
+// Importer imports sanctions lists.
+type Importer struct {
+	conn        storage.DBConnection
+	storeWithTx func(tx pgx.Tx) storage.SanctionsImportStore
+	auditor     Auditor
+	now         func() time.Time
+}
+
+// NewImporter returns a new Importer, recording the lists imported with auditor.
+func NewImporter(conn storage.DBConnection, auditor Auditor) *Importer {
+	return &Importer{
+		conn:        conn,
+		storeWithTx: storage.SanctionsImportStoreWithTx,
+		auditor:     auditor,
+		now:         time.Now,
+	}
+}
+
+// Import replaces the entries of a sanctions list within a transaction, in which the import is recorded. The lists are
+// reloaded by the screening services once imported.
+func (i *Importer) Import(ctx context.Context, list string, entries []Entry) error {
+	tx, err := i.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// The transaction is rolled back unless it was committed, the error is that of the failed step.
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	store := i.storeWithTx(tx)
+
+	imported := storage.UpsertSanctionsListParams{
+		List:       list,
+		Entries:    int32(len(entries)), //nolint:gosec // lists are far from having 2^31 entries.
+		ImportedAt: pgtype.Timestamptz{Time: i.now().UTC(), Valid: true},
+	}
+
+	if err := store.UpsertSanctionsList(ctx, imported); err != nil {
+		return fmt.Errorf("failed to save sanctions list: %w", err)
+	}
+
+	if err := store.DeleteSanctionsEntries(ctx, list); err != nil {
+		return fmt.Errorf("failed to delete sanctions entries: %w", err)
+	}
+
+	rows := make([]storage.AddSanctionsEntriesParams, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, storage.AddSanctionsEntriesParams{List: list, Reference: e.Reference, Name: e.Name})
+	}
+
+	if _, err := store.AddSanctionsEntries(ctx, rows); err != nil {
+		return fmt.Errorf("failed to add sanctions entries: %w", err)
+	}
+
+	if err := i.auditor.Record(ctx, tx, audit.Event{
+		Action:  audit.ActionImportSanctionsList,
+		Outcome: audit.OutcomeSuccess,
+		After:   imported,
+	}); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package sanctions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+)
+
+var (
+	wantNow     = time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+	errAnything = errors.New("any")
+
+	wantEntries = []Entry{{Reference: "EU-1", Name: "Doe, John"}, {Reference: "EU-2", Name: "Žofia Nováková"}}
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		format  string
+		want    []Entry
+		wantErr error
+	}{
+		{name: "csv", file: "testdata/list.csv", format: FormatCSV, want: wantEntries},
+		{name: "xml", file: "testdata/list.xml", format: FormatXML, want: wantEntries},
+		{name: "failed when the format is unknown", file: "testdata/list.csv", format: "json", wantErr: ErrUnknownFormat},
+		{
+			name:    "failed when the format is not that of the file",
+			file:    "testdata/list.csv",
+			format:  FormatXML,
+			wantErr: ErrInvalidList,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(tt.file)
+			require.NoError(t, err)
+
+			defer f.Close()
+
+			got, err := Parse(f, tt.format)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCSV_invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "empty", in: ""},
+		{name: "missing name column", in: "reference,program\nEU-1,CYBER\n"},
+		{name: "missing columns", in: "reference,name\nEU-1\n"},
+		{name: "missing name", in: "reference,name\nEU-1, \n"},
+		{name: "unterminated quote", in: "reference,name\nEU-1,\"Doe\n"},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseCSV(strings.NewReader(tt.in))
+			assert.ErrorIs(t, err, ErrInvalidList)
+		})
+	}
+}
+
+func TestImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mock    func(*storageMocks.MockSanctionsImportStore, *txMocks.MockTx)
+		wantErr error
+	}{
+		{
+			name: "failed when the entries cannot be added",
+			mock: func(ms *storageMocks.MockSanctionsImportStore, _ *txMocks.MockTx) {
+				ms.EXPECT().UpsertSanctionsList(mock.Anything, mock.Anything).Return(nil).Once()
+				ms.EXPECT().DeleteSanctionsEntries(mock.Anything, "eu").Return(nil).Once()
+				ms.EXPECT().AddSanctionsEntries(mock.Anything, mock.Anything).Return(0, errAnything).Once()
+			},
+			wantErr: errAnything,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockSanctionsImportStore, tx *txMocks.MockTx) {
+				ms.EXPECT().UpsertSanctionsList(mock.Anything, storage.UpsertSanctionsListParams{
+					List:       "eu",
+					Entries:    2,
+					ImportedAt: pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(nil).Once()
+				ms.EXPECT().DeleteSanctionsEntries(mock.Anything, "eu").Return(nil).Once()
+				ms.EXPECT().AddSanctionsEntries(mock.Anything, []storage.AddSanctionsEntriesParams{
+					{List: "eu", Reference: "EU-1", Name: "Doe, John"},
+					{List: "eu", Reference: "EU-2", Name: "Žofia Nováková"},
+				}).Return(2, nil).Once()
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockSanctionsImportStore(t)
+			conn := storageMocks.NewMockDBConnection(t)
+			tx := txMocks.NewMockTx(t)
+
+			conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+			tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			tt.mock(store, tx)
+
+			importer := NewImporter(conn, recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionImportSanctionsList, event.Action)
+
+				return nil
+			}))
+			importer.storeWithTx = func(pgx.Tx) storage.SanctionsImportStore { return store }
+			importer.now = func() time.Time { return wantNow }
+
+			err := importer.Import(context.Background(), "eu", wantEntries)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
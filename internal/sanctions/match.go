@@ -0,0 +1,181 @@
+package sanctions
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/zaidsasa/xbankapi/types"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultThreshold is the default similarity from which names nearly match.
+	DefaultThreshold = 0.9
+
+	// maxMatches is the maximum number of matches kept in a result, the most similar first.
+	maxMatches = 10
+
+	// winklerPrefix is the length of the common prefix the Winkler adjustment rewards, and winklerScale by how much.
+	winklerPrefix = 4
+	winklerScale  = 0.1
+)
+
+// Result is the result of screening a name: its screening status, types.ScreeningStatusBlocked for an exact match
+// and types.ScreeningStatusReview for a near match, and the entries it matched.
+type Result struct {
+	Status  string
+	Matches []types.SanctionsMatch
+}
+
+// entry is a sanctions entry with its normalized name.
+type entry struct {
+	list       string
+	reference  string
+	name       string
+	normalized string
+}
+
+// normalize returns name in lower case without diacritics nor punctuation, its words sorted so that the order of
+// the given names and family names does not matter.
+func normalize(name string) string {
+	words := strings.FieldsFunc(norm.NFD.String(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+
+	for i, w := range words {
+		words[i] = strings.ToLower(strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Mn, r) {
+				return -1
+			}
+
+			return r
+		}, w))
+	}
+
+	slices.Sort(words)
+
+	return strings.Join(words, " ")
+}
+
+// match screens name against the entries: names equal once normalized match exactly, and those whose similarity is
+// at least threshold nearly match.
+func match(name string, entries []entry, threshold float64) Result {
+	normalized := normalize(name)
+	result := Result{Status: types.ScreeningStatusClear, Matches: []types.SanctionsMatch{}}
+
+	if normalized == "" {
+		return result
+	}
+
+	for _, e := range entries {
+		score := 1.0
+		if e.normalized != normalized {
+			score = jaroWinkler(normalized, e.normalized)
+		}
+
+		if score < threshold {
+			continue
+		}
+
+		switch {
+		case score == 1:
+			result.Status = types.ScreeningStatusBlocked
+		case result.Status == types.ScreeningStatusClear:
+			result.Status = types.ScreeningStatusReview
+		}
+
+		result.Matches = append(result.Matches, types.SanctionsMatch{
+			List:      e.list,
+			Reference: e.reference,
+			Name:      e.name,
+			Score:     score,
+		})
+	}
+
+	slices.SortStableFunc(result.Matches, func(a, b types.SanctionsMatch) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	if len(result.Matches) > maxMatches {
+		result.Matches = result.Matches[:maxMatches]
+	}
+
+	return result
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 to 1 when they are equal.
+func jaroWinkler(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+
+	sim := jaro(s, t)
+
+	prefix := 0
+	for prefix < min(len(s), len(t), winklerPrefix) && s[prefix] == t[prefix] {
+		prefix++
+	}
+
+	return sim + float64(prefix)*winklerScale*(1-sim)
+}
+
+// jaro returns the Jaro similarity of s and t: the runes they have in common within a window, and how many of them
+// are transposed.
+func jaro(s, t []rune) float64 {
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	sMatched, tMatched, matches := jaroMatches(s, t)
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+
+		for !tMatched[j] {
+			j++
+		}
+
+		if s[i] != t[j] {
+			transpositions++
+		}
+
+		j++
+	}
+
+	m := float64(matches)
+
+	//nolint:mnd // the mean of the three ratios, and half of the transpositions, by definition.
+	return (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// jaroMatches returns which runes of s and t match a rune of the other within the window of the Jaro similarity, and
+// how many do.
+func jaroMatches(s, t []rune) ([]bool, []bool, int) {
+	window := max(max(len(s), len(t))/2-1, 0)
+
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if tMatched[j] || s[i] != t[j] {
+				continue
+			}
+
+			sMatched[i], tMatched[j] = true, true
+			matches++
+
+			break
+		}
+	}
+
+	return sMatched, tMatched, matches
+}
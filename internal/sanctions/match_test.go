@@ -0,0 +1,119 @@
+package sanctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "lower case", in: "JOHN DOE", want: "doe john"},
+		{name: "words sorted", in: "Doe, John", want: "doe john"},
+		{name: "diacritics removed", in: "Žofia  Nováková", want: "novakova zofia"},
+		{name: "punctuation removed", in: "O'Brien-Smith Ltd.", want: "brien ltd o smith"},
+		{name: "empty", in: " - ", want: ""},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, normalize(tt.in))
+		})
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "martha", b: "marhta", want: 0.9611},
+		{a: "dwayne", b: "duane", want: 0.84},
+		{a: "dixon", b: "dicksonx", want: 0.8133},
+		{a: "doe john", b: "doe john", want: 1},
+		{a: "abc", b: "xyz", want: 0},
+		{a: "", b: "xyz", want: 0},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.want, jaroWinkler(tt.a, tt.b), 0.0001)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	entries := []entry{
+		{list: "eu", reference: "EU-1", name: "Doe, John", normalized: "doe john"},
+		{list: "eu", reference: "EU-2", name: "Žofia Nováková", normalized: "novakova zofia"},
+		{list: "un", reference: "UN-1", name: "Jon Doe", normalized: "doe jon"},
+	}
+
+	tests := []struct {
+		name       string
+		in         string
+		want       string
+		wantRefs   []string
+		wantScores []float64
+	}{
+		{name: "clear", in: "Jane Smith", want: types.ScreeningStatusClear, wantRefs: []string{}},
+		{
+			name:       "blocked when a name matches exactly",
+			in:         "John DOE",
+			want:       types.ScreeningStatusBlocked,
+			wantRefs:   []string{"EU-1", "UN-1"},
+			wantScores: []float64{1, 0.9750},
+		},
+		{
+			name:       "blocked when a name matches once normalized",
+			in:         "Zofia Novakova-",
+			want:       types.ScreeningStatusBlocked,
+			wantRefs:   []string{"EU-2"},
+			wantScores: []float64{1},
+		},
+		{
+			name:       "review when names nearly match",
+			in:         "Johnny Doe",
+			want:       types.ScreeningStatusReview,
+			wantRefs:   []string{"EU-1", "UN-1"},
+			wantScores: []float64{0.9600, 0.9400},
+		},
+		{name: "clear when the name is empty", in: "", want: types.ScreeningStatusClear, wantRefs: []string{}},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := match(tt.in, entries, DefaultThreshold)
+
+			assert.Equal(t, tt.want, got.Status)
+
+			refs := make([]string, 0, len(got.Matches))
+			for i, m := range got.Matches {
+				refs = append(refs, m.Reference)
+				assert.InDelta(t, tt.wantScores[i], m.Score, 0.0001)
+			}
+
+			assert.Equal(t, tt.wantRefs, refs)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+// Package sanctions screens the names of accounts against the sanctions lists imported in the database, when the
+// accounts are created and when money is transferred from or to them. Names matching an entry exactly are blocked,
+// and names nearly matching one, whose similarity is at least the configured threshold, are held for review by the
+// admin, who clears or blocks their accounts.
+package sanctions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/risk"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// CheckName is the name transfers held for review or denied by the sanctions screening match.
+const CheckName = "sanctions"
+
+// version identifies the imported sanctions lists, which are reloaded when it changes.
+type version struct {
+	lists      int32
+	entries    int32
+	importedAt time.Time
+}
+
+// Auditor records the resolutions of the screenings held for review and the lists imported within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+// Service screens names against the sanctions lists, which it keeps in memory, and lets the admin resolve the
+// screenings held for review.
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.SanctionsStore
+	storeWithTx func(tx pgx.Tx) storage.SanctionsStore
+	auditor     Auditor
+	threshold   float64
+	logger      logger.Logger
+	now         func() time.Time
+
+	mu      sync.Mutex
+	loaded  bool
+	version version
+	entries []entry
+}
+
+// NewService returns a new Service, names nearly matching the sanctions entries whose similarity, from 0 to 1, is at
+// least threshold.
+func NewService(
+	conn storage.DBConnection,
+	store storage.SanctionsStore,
+	auditor Auditor,
+	threshold float64,
+	logger logger.Logger,
+) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.SanctionsStoreWithTx,
+		auditor:     auditor,
+		threshold:   threshold,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Screen screens a name against the sanctions lists.
+func (s *Service) Screen(ctx context.Context, name string) (Result, error) {
+	entries, err := s.load(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load sanctions lists", "error", err)
+
+		return Result{}, types.ErrInternal
+	}
+
+	return match(name, entries, s.threshold), nil
+}
+
+// load returns the entries of the sanctions lists, reloading them when lists were imported since they were loaded.
+func (s *Service) load(ctx context.Context) ([]entry, error) {
+	row, err := s.store.GetSanctionsVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sanctions lists version: %w", err)
+	}
+
+	v := version{lists: row.Lists, entries: row.Entries, importedAt: row.ImportedAt.Time}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && s.version == v {
+		return s.entries, nil
+	}
+
+	rows, err := s.store.ListSanctionsEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sanctions entries: %w", err)
+	}
+
+	entries := make([]entry, 0, len(rows))
+	for _, r := range rows {
+		entries = append(entries, entry{
+			list:       r.List,
+			reference:  r.Reference,
+			name:       r.Name,
+			normalized: normalize(r.Name),
+		})
+	}
+
+	s.loaded, s.version, s.entries = true, v, entries
+
+	s.logger.InfoContext(ctx, "loaded sanctions lists", "lists", v.lists, "entries", len(entries))
+
+	return entries, nil
+}
+
+// Record saves the result of screening the name of an account within tx, which puts the account under review when
+// the name nearly matched an entry.
+func (s *Service) Record(ctx context.Context, tx pgx.Tx, accountID uuid.UUID, name string, result Result) error {
+	store := s.storeWithTx(tx)
+
+	matches, err := json.Marshal(result.Matches)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to encode sanctions matches", "error", err)
+
+		return types.ErrInternal
+	}
+
+	screening, err := store.AddSanctionsScreening(ctx, storage.AddSanctionsScreeningParams{
+		AccountID: accountID,
+		Name:      name,
+		Status:    result.Status,
+		Matches:   matches,
+		CreatedAt: pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to save sanctions screening", "error", err)
+
+		return types.ErrInternal
+	}
+
+	if result.Status == types.ScreeningStatusClear {
+		return nil
+	}
+
+	if err := store.SetAccountScreeningStatus(ctx, storage.SetAccountScreeningStatusParams{
+		AccountID:       accountID,
+		ScreeningStatus: result.Status,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to set account screening status", "error", err)
+
+		return types.ErrInternal
+	}
+
+	s.logger.WarnContext(ctx, "account held for sanctions review",
+		"account_id", accountID, "screening_id", screening.ScreeningID)
+
+	return nil
+}
+
+// Check screens the sender and the receiver of a transfer: transfers from or to blocked accounts or names matching a
+// sanctions entry exactly are denied, and those from or to accounts under review or names nearly matching an entry,
+// unless the admin cleared their accounts, are held for review. It implements risk.Check.
+func (s *Service) Check(ctx context.Context, tx pgx.Tx, t risk.Transfer) (risk.Decision, error) {
+	decision := risk.DecisionAllow
+
+	for _, accountID := range []uuid.UUID{t.AccountID, t.ReciverAccountID} {
+		account, err := s.storeWithTx(tx).GetAccount(ctx, accountID)
+		if err != nil {
+			// The transfer fails on its own when the receiver does not exist.
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+
+			return "", fmt.Errorf("failed to get account: %w", err)
+		}
+
+		d, err := s.decide(ctx, account)
+		if err != nil {
+			return "", err
+		}
+
+		if d == risk.DecisionDeny {
+			return d, nil
+		}
+
+		if d == risk.DecisionReview {
+			decision = d
+		}
+	}
+
+	return decision, nil
+}
+
+// decide returns the decision for a transfer from or to an account.
+func (s *Service) decide(ctx context.Context, account storage.Account) (risk.Decision, error) {
+	switch account.ScreeningStatus {
+	case types.ScreeningStatusBlocked:
+		return risk.DecisionDeny, nil
+	case types.ScreeningStatusReview:
+		return risk.DecisionReview, nil
+	}
+
+	// The name is screened again, as the sanctions lists may have changed since the account was created.
+	result, err := s.Screen(ctx, account.Name)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case result.Status == types.ScreeningStatusBlocked:
+		return risk.DecisionDeny, nil
+	case result.Status == types.ScreeningStatusReview && account.ScreeningStatus != types.ScreeningStatusCleared:
+		return risk.DecisionReview, nil
+	default:
+		return risk.DecisionAllow, nil
+	}
+}
+
+// ListScreenings lists the screenings of a status, oldest first.
+// returns ListSanctionsScreeningsResponse.
+func (s *Service) ListScreenings(
+	ctx context.Context,
+	status string,
+	limit, offset int32,
+) (types.ListSanctionsScreeningsResponse, error) {
+	screenings, err := s.store.ListSanctionsScreenings(ctx, storage.ListSanctionsScreeningsParams{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list sanctions screenings", "error", err)
+
+		return types.ListSanctionsScreeningsResponse{}, types.ErrInternal
+	}
+
+	res := types.ListSanctionsScreeningsResponse{
+		Screenings: make([]types.SanctionsScreening, 0, len(screenings)),
+	}
+
+	for _, screening := range screenings {
+		res.Screenings = append(res.Screenings, toScreening(screening))
+	}
+
+	return res, nil
+}
+
+// ResolveScreening clears or blocks the account of a screening held for review.
+// returns ResolveSanctionsScreeningResponse.
+func (s *Service) ResolveScreening(
+	ctx context.Context,
+	screeningID uuid.UUID,
+	req *types.ResolveSanctionsScreeningRequest,
+) (types.ResolveSanctionsScreeningResponse, error) {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return types.ResolveSanctionsScreeningResponse{}, types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	screening, err := s.resolve(ctx, s.storeWithTx(tx), screeningID, req.Status)
+	if err != nil {
+		return types.ResolveSanctionsScreeningResponse{}, err
+	}
+
+	res := types.ResolveSanctionsScreeningResponse{SanctionsScreening: toScreening(screening)}
+
+	if err := s.auditor.Record(ctx, tx, audit.Event{
+		Action:    audit.ActionResolveScreening,
+		AccountID: uuid.NullUUID{UUID: screening.AccountID, Valid: true},
+		Outcome:   audit.OutcomeSuccess,
+		After:     res.SanctionsScreening,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ResolveSanctionsScreeningResponse{}, types.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return types.ResolveSanctionsScreeningResponse{}, types.ErrInternal
+	}
+
+	s.logger.InfoContext(ctx, "sanctions screening resolved",
+		"screening_id", screeningID, "account_id", screening.AccountID, "status", req.Status)
+
+	return res, nil
+}
+
+// resolve sets the status of a screening held for review and of its account, failing with
+// types.ErrScreeningNotFound or types.ErrScreeningResolved when there is none.
+func (s *Service) resolve(
+	ctx context.Context,
+	store storage.SanctionsStore,
+	screeningID uuid.UUID,
+	status string,
+) (storage.SanctionsScreening, error) {
+	screening, err := store.ResolveSanctionsScreening(ctx, storage.ResolveSanctionsScreeningParams{
+		ScreeningID: screeningID,
+		Status:      status,
+		ResolvedAt:  pgtype.Timestamptz{Time: s.now().UTC(), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		_, err = store.GetSanctionsScreening(ctx, screeningID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.SanctionsScreening{}, types.ErrScreeningNotFound
+		}
+
+		if err == nil {
+			return storage.SanctionsScreening{}, types.ErrScreeningResolved
+		}
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to resolve sanctions screening", "error", err)
+
+		return storage.SanctionsScreening{}, types.ErrInternal
+	}
+
+	if err := store.SetAccountScreeningStatus(ctx, storage.SetAccountScreeningStatusParams{
+		AccountID:       screening.AccountID,
+		ScreeningStatus: status,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to set account screening status", "error", err)
+
+		return storage.SanctionsScreening{}, types.ErrInternal
+	}
+
+	return screening, nil
+}
+
+func toScreening(s storage.SanctionsScreening) types.SanctionsScreening {
+	screening := types.SanctionsScreening{
+		ID:        s.ScreeningID,
+		AccountID: s.AccountID,
+		Name:      s.Name,
+		Status:    s.Status,
+		Matches:   []types.SanctionsMatch{},
+		CreatedAt: s.CreatedAt.Time,
+	}
+
+	// The matches were encoded by Record.
+	_ = json.Unmarshal(s.Matches, &screening.Matches)
+
+	if s.ResolvedAt.Valid {
+		screening.ResolvedAt = &s.ResolvedAt.Time
+	}
+
+	return screening
+}
@@ -0,0 +1,374 @@
+package sanctions
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/risk"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID        = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantReciverAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantScreeningID      = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+
+	testVersion = storage.GetSanctionsVersionRow{
+		Lists: 1, Entries: 1, ImportedAt: pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}
+	testEntries = []storage.SanctionsEntry{{List: "eu", Reference: "EU-1", Name: "Doe, John"}}
+
+	reviewResult = Result{Status: types.ScreeningStatusReview, Matches: []types.SanctionsMatch{
+		{List: "eu", Reference: "EU-1", Name: "Doe, John", Score: 0.94},
+	}}
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+func newTestService(store storage.SanctionsStore, conn storage.DBConnection) *Service {
+	s := NewService(conn, store, nil, DefaultThreshold, slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.SanctionsStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+// expectEntries expects the sanctions lists to be loaded once.
+func expectEntries(store *storageMocks.MockSanctionsStore) {
+	store.EXPECT().GetSanctionsVersion(mock.Anything).Return(testVersion, nil)
+	store.EXPECT().ListSanctionsEntries(mock.Anything).Return(testEntries, nil).Once()
+}
+
+func TestService_Screen(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockSanctionsStore(t)
+	store.EXPECT().GetSanctionsVersion(mock.Anything).Return(testVersion, nil).Twice()
+	store.EXPECT().ListSanctionsEntries(mock.Anything).Return(testEntries, nil).Once()
+
+	s := newTestService(store, nil)
+
+	got, err := s.Screen(context.Background(), "Johnny Doe")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScreeningStatusReview, got.Status)
+
+	// The entries are not loaded again until the lists change.
+	got, err = s.Screen(context.Background(), "Jane Smith")
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Status: types.ScreeningStatusClear, Matches: []types.SanctionsMatch{}}, got)
+
+	reimported := testVersion
+	reimported.ImportedAt.Time = wantNow.Add(time.Hour)
+
+	store.EXPECT().GetSanctionsVersion(mock.Anything).Return(reimported, nil).Once()
+	store.EXPECT().ListSanctionsEntries(mock.Anything).Return(nil, nil).Once()
+
+	got, err = s.Screen(context.Background(), "John Doe")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScreeningStatusClear, got.Status)
+}
+
+func TestService_Screen_failed(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockSanctionsStore(t)
+	store.EXPECT().GetSanctionsVersion(mock.Anything).Return(testVersion, nil).Once()
+	store.EXPECT().ListSanctionsEntries(mock.Anything).Return(nil, errAnything).Once()
+
+	_, err := newTestService(store, nil).Screen(context.Background(), "John Doe")
+	assert.ErrorIs(t, err, types.ErrInternal)
+}
+
+func TestService_Record(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		result  Result
+		mock    func(*storageMocks.MockSanctionsStore)
+		wantErr error
+	}{
+		{
+			name:   "failed when the screening cannot be saved",
+			result: reviewResult,
+			mock: func(ms *storageMocks.MockSanctionsStore) {
+				ms.EXPECT().AddSanctionsScreening(mock.Anything, mock.Anything).
+					Return(storage.SanctionsScreening{}, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name:   "success when the name is clear",
+			result: Result{Status: types.ScreeningStatusClear, Matches: []types.SanctionsMatch{}},
+			mock: func(ms *storageMocks.MockSanctionsStore) {
+				ms.EXPECT().AddSanctionsScreening(mock.Anything, storage.AddSanctionsScreeningParams{
+					AccountID: wantAccountID,
+					Name:      "Jane Smith",
+					Status:    types.ScreeningStatusClear,
+					Matches:   []byte(`[]`),
+					CreatedAt: pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(storage.SanctionsScreening{ScreeningID: wantScreeningID}, nil).Once()
+			},
+		},
+		{
+			name:   "success when the account is put under review",
+			result: reviewResult,
+			mock: func(ms *storageMocks.MockSanctionsStore) {
+				ms.EXPECT().AddSanctionsScreening(mock.Anything, storage.AddSanctionsScreeningParams{
+					AccountID: wantAccountID,
+					Name:      "Jane Smith",
+					Status:    types.ScreeningStatusReview,
+					Matches:   []byte(`[{"list":"eu","reference":"EU-1","name":"Doe, John","score":0.94}]`),
+					CreatedAt: pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(storage.SanctionsScreening{ScreeningID: wantScreeningID}, nil).Once()
+				ms.EXPECT().SetAccountScreeningStatus(mock.Anything, storage.SetAccountScreeningStatusParams{
+					AccountID:       wantAccountID,
+					ScreeningStatus: types.ScreeningStatusReview,
+				}).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockSanctionsStore(t)
+			tt.mock(store)
+
+			err := newTestService(store, nil).Record(context.Background(), nil, wantAccountID, "Jane Smith", tt.result)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		sender   storage.Account
+		receiver storage.Account
+		want     risk.Decision
+	}{
+		{
+			name:     "allow when both accounts are clear",
+			sender:   storage.Account{Name: "Jane Smith", ScreeningStatus: types.ScreeningStatusClear},
+			receiver: storage.Account{Name: "Max Mustermann", ScreeningStatus: types.ScreeningStatusClear},
+			want:     risk.DecisionAllow,
+		},
+		{
+			name:     "deny when the receiver is blocked",
+			sender:   storage.Account{Name: "Jane Smith", ScreeningStatus: types.ScreeningStatusClear},
+			receiver: storage.Account{Name: "Max Mustermann", ScreeningStatus: types.ScreeningStatusBlocked},
+			want:     risk.DecisionDeny,
+		},
+		{
+			name:     "deny when a name matches exactly since the lists changed",
+			sender:   storage.Account{Name: "John Doe", ScreeningStatus: types.ScreeningStatusCleared},
+			receiver: storage.Account{Name: "Max Mustermann", ScreeningStatus: types.ScreeningStatusClear},
+			want:     risk.DecisionDeny,
+		},
+		{
+			name:     "review when the sender is under review",
+			sender:   storage.Account{Name: "Johnny Doe", ScreeningStatus: types.ScreeningStatusReview},
+			receiver: storage.Account{Name: "Max Mustermann", ScreeningStatus: types.ScreeningStatusClear},
+			want:     risk.DecisionReview,
+		},
+		{
+			name:     "review when a name nearly matches since the lists changed",
+			sender:   storage.Account{Name: "Jane Smith", ScreeningStatus: types.ScreeningStatusClear},
+			receiver: storage.Account{Name: "Johnny Doe", ScreeningStatus: types.ScreeningStatusClear},
+			want:     risk.DecisionReview,
+		},
+		{
+			name:     "allow when the account nearly matching was cleared",
+			sender:   storage.Account{Name: "Johnny Doe", ScreeningStatus: types.ScreeningStatusCleared},
+			receiver: storage.Account{Name: "Max Mustermann", ScreeningStatus: types.ScreeningStatusClear},
+			want:     risk.DecisionAllow,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockSanctionsStore(t)
+			store.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(tt.sender, nil).Once()
+			store.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).Return(tt.receiver, nil).Maybe()
+			store.EXPECT().GetSanctionsVersion(mock.Anything).Return(testVersion, nil).Maybe()
+			store.EXPECT().ListSanctionsEntries(mock.Anything).Return(testEntries, nil).Maybe()
+
+			got, err := newTestService(store, nil).Check(context.Background(), nil, risk.Transfer{
+				AccountID:        wantAccountID,
+				ReciverAccountID: wantReciverAccountID,
+				Amount:           100,
+				Time:             wantNow,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Check_receiverNotFound(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockSanctionsStore(t)
+	store.EXPECT().GetAccount(mock.Anything, wantAccountID).
+		Return(storage.Account{Name: "Jane Smith", ScreeningStatus: types.ScreeningStatusClear}, nil).Once()
+	store.EXPECT().GetAccount(mock.Anything, wantReciverAccountID).Return(storage.Account{}, pgx.ErrNoRows).Once()
+	expectEntries(store)
+
+	got, err := newTestService(store, nil).Check(context.Background(), nil, risk.Transfer{
+		AccountID:        wantAccountID,
+		ReciverAccountID: wantReciverAccountID,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, risk.DecisionAllow, got)
+}
+
+func TestService_ListScreenings(t *testing.T) {
+	t.Parallel()
+
+	store := storageMocks.NewMockSanctionsStore(t)
+	store.EXPECT().ListSanctionsScreenings(mock.Anything, storage.ListSanctionsScreeningsParams{
+		Status: types.ScreeningStatusReview,
+		Limit:  10,
+		Offset: 5,
+	}).Return([]storage.SanctionsScreening{{
+		ScreeningID: wantScreeningID,
+		AccountID:   wantAccountID,
+		Name:        "Johnny Doe",
+		Status:      types.ScreeningStatusReview,
+		Matches:     []byte(`[{"list":"eu","reference":"EU-1","name":"Doe, John","score":0.94}]`),
+		CreatedAt:   pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}}, nil).Once()
+
+	got, err := newTestService(store, nil).ListScreenings(context.Background(), types.ScreeningStatusReview, 10, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.ListSanctionsScreeningsResponse{Screenings: []types.SanctionsScreening{{
+		ID:        wantScreeningID,
+		AccountID: wantAccountID,
+		Name:      "Johnny Doe",
+		Status:    types.ScreeningStatusReview,
+		Matches:   reviewResult.Matches,
+		CreatedAt: wantNow,
+	}}}, got)
+}
+
+func TestService_ResolveScreening(t *testing.T) {
+	t.Parallel()
+
+	resolved := storage.SanctionsScreening{
+		ScreeningID: wantScreeningID,
+		AccountID:   wantAccountID,
+		Name:        "Johnny Doe",
+		Status:      types.ScreeningStatusCleared,
+		Matches:     []byte(`[]`),
+		CreatedAt:   pgtype.Timestamptz{Time: wantNow, Valid: true},
+		ResolvedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(*storageMocks.MockSanctionsStore, *txMocks.MockTx)
+		want    types.ResolveSanctionsScreeningResponse
+		wantErr error
+	}{
+		{
+			name: "failed when the screening is not found",
+			mock: func(ms *storageMocks.MockSanctionsStore, _ *txMocks.MockTx) {
+				ms.EXPECT().ResolveSanctionsScreening(mock.Anything, mock.Anything).
+					Return(storage.SanctionsScreening{}, pgx.ErrNoRows).Once()
+				ms.EXPECT().GetSanctionsScreening(mock.Anything, wantScreeningID).
+					Return(storage.SanctionsScreening{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrScreeningNotFound,
+		},
+		{
+			name: "failed when the screening was already resolved",
+			mock: func(ms *storageMocks.MockSanctionsStore, _ *txMocks.MockTx) {
+				ms.EXPECT().ResolveSanctionsScreening(mock.Anything, mock.Anything).
+					Return(storage.SanctionsScreening{}, pgx.ErrNoRows).Once()
+				ms.EXPECT().GetSanctionsScreening(mock.Anything, wantScreeningID).Return(resolved, nil).Once()
+			},
+			wantErr: types.ErrScreeningResolved,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockSanctionsStore, tx *txMocks.MockTx) {
+				ms.EXPECT().ResolveSanctionsScreening(mock.Anything, storage.ResolveSanctionsScreeningParams{
+					ScreeningID: wantScreeningID,
+					Status:      types.ScreeningStatusCleared,
+					ResolvedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}).Return(resolved, nil).Once()
+				ms.EXPECT().SetAccountScreeningStatus(mock.Anything, storage.SetAccountScreeningStatusParams{
+					AccountID:       wantAccountID,
+					ScreeningStatus: types.ScreeningStatusCleared,
+				}).Return(nil).Once()
+				tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+			},
+			want: types.ResolveSanctionsScreeningResponse{SanctionsScreening: types.SanctionsScreening{
+				ID:         wantScreeningID,
+				AccountID:  wantAccountID,
+				Name:       "Johnny Doe",
+				Status:     types.ScreeningStatusCleared,
+				Matches:    []types.SanctionsMatch{},
+				CreatedAt:  wantNow,
+				ResolvedAt: &wantNow,
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockSanctionsStore(t)
+			conn := storageMocks.NewMockDBConnection(t)
+			tx := txMocks.NewMockTx(t)
+
+			conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+			tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			tt.mock(store, tx)
+
+			s := newTestService(store, conn)
+			s.auditor = recordFunc(func(event audit.Event) error {
+				assert.Equal(t, audit.ActionResolveScreening, event.Action)
+				assert.Equal(t, uuid.NullUUID{UUID: wantAccountID, Valid: true}, event.AccountID)
+				assert.Equal(t, tt.want.SanctionsScreening, event.After)
+
+				return nil
+			})
+
+			got, err := s.ResolveScreening(context.Background(), wantScreeningID,
+				&types.ResolveSanctionsScreeningRequest{Status: types.ScreeningStatusCleared})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
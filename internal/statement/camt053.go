@@ -0,0 +1,260 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+const (
+	camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+	camtCredit = "CRDT"
+	camtDebit  = "DBIT"
+
+	camtOpeningBooked = "OPBD"
+	camtClosingBooked = "CLBD"
+	camtBooked        = "BOOK"
+
+	// camtNotProvided is the end to end identification of entries without one, as is the convention.
+	camtNotProvided = "NOTPROVIDED"
+
+	camtMaxNameLength = 70
+)
+
+type (
+	camtAmount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+
+	camtDate struct {
+		Date     string `xml:"Dt,omitempty"`
+		DateTime string `xml:"DtTm,omitempty"`
+	}
+
+	camtGroupHeader struct {
+		MessageID string `xml:"MsgId"`
+		CreatedAt string `xml:"CreDtTm"`
+	}
+
+	camtPeriod struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	}
+
+	camtAccount struct {
+		ID       string `xml:"Id>Othr>Id"`
+		Currency string `xml:"Ccy"`
+		Name     string `xml:"Nm"`
+	}
+
+	camtBalance struct {
+		Type        string     `xml:"Tp>CdOrPrtry>Cd"`
+		Amount      camtAmount `xml:"Amt"`
+		CreditDebit string     `xml:"CdtDbtInd"`
+		Date        camtDate   `xml:"Dt"`
+	}
+
+	camtEntry struct {
+		Reference         string           `xml:"NtryRef"`
+		Amount            camtAmount       `xml:"Amt"`
+		CreditDebit       string           `xml:"CdtDbtInd"`
+		Status            string           `xml:"Sts>Cd"`
+		BookingDate       camtDate         `xml:"BookgDt"`
+		ValueDate         camtDate         `xml:"ValDt"`
+		ServicerReference string           `xml:"AcctSvcrRef"`
+		Domain            string           `xml:"BkTxCd>Domn>Cd"`
+		Family            string           `xml:"BkTxCd>Domn>Fmly>Cd"`
+		SubFamily         string           `xml:"BkTxCd>Domn>Fmly>SubFmlyCd"`
+		Proprietary       string           `xml:"BkTxCd>Prtry>Cd,omitempty"`
+		Details           camtEntryDetails `xml:"NtryDtls>TxDtls"`
+		Information       string           `xml:"AddtlNtryInf"`
+	}
+
+	camtEntryDetails struct {
+		ServicerReference string     `xml:"Refs>AcctSvcrRef"`
+		EndToEndID        string     `xml:"Refs>EndToEndId"`
+		Amount            camtAmount `xml:"Amt"`
+		CreditDebit       string     `xml:"CdtDbtInd"`
+	}
+)
+
+// Camt053Encoder writes statements as ISO 20022 camt.053.001.08 bank to customer statements. Entries are booked when
+// their transaction is made, both their booking and value dates being the time of the transaction, and are referenced
+// by the ID of the transaction, transfers received being identified end to end by the ID of the transaction sent. The
+// type of the transaction is the proprietary bank transaction code of its entry.
+type Camt053Encoder struct {
+	w      io.Writer
+	enc    *xml.Encoder
+	header Header
+}
+
+// NewCamt053Encoder returns a new Camt053Encoder.
+func NewCamt053Encoder(w io.Writer) *Camt053Encoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	return &Camt053Encoder{
+		w:   w,
+		enc: enc,
+	}
+}
+
+// Begin writes the group header, then the statement up to its entries. Both balances precede the entries, as the
+// schema requires.
+func (e *Camt053Encoder) Begin(h Header) error {
+	e.header = h
+
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	if err := e.start("Document", xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: camt053Namespace}); err != nil {
+		return err
+	}
+
+	if err := e.start("BkToCstmrStmt"); err != nil {
+		return err
+	}
+
+	if err := e.element("GrpHdr", camtGroupHeader{
+		MessageID: "STMT-" + h.CreatedAt.Format("20060102150405"),
+		CreatedAt: h.CreatedAt.Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+
+	if err := e.start("Stmt"); err != nil {
+		return err
+	}
+
+	for _, el := range []struct {
+		name  string
+		value any
+	}{
+		{name: "Id", value: statementID(h)},
+		{name: "CreDtTm", value: h.CreatedAt.Format(time.RFC3339)},
+		{name: "FrToDt", value: camtPeriod{
+			From: h.From.Format(time.RFC3339),
+			To:   h.To.Add(day - time.Second).Format(time.RFC3339),
+		}},
+		{name: "Acct", value: camtAccount{
+			ID:       reference(h.Account.ID),
+			Currency: h.Account.CurrencyCode,
+			Name:     truncate(h.Account.Name, camtMaxNameLength),
+		}},
+		{name: "Bal", value: e.balance(camtOpeningBooked, h.OpeningBalance, h.From)},
+		{name: "Bal", value: e.balance(camtClosingBooked, h.ClosingBalance, h.To)},
+	} {
+		if err := e.element(el.name, el.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Entry writes an entry of the statement.
+func (e *Camt053Encoder) Entry(entry Entry) error {
+	code := entry.code()
+
+	endToEndID := camtNotProvided
+	if entry.SourceID.Valid {
+		endToEndID = reference(entry.SourceID.UUID)
+	}
+
+	amount := e.amount(entry.Amount)
+
+	return e.element("Ntry", camtEntry{
+		Reference:         reference(entry.ID),
+		Amount:            amount,
+		CreditDebit:       camtCreditDebit(entry.Amount),
+		Status:            camtBooked,
+		BookingDate:       camtDate{DateTime: entry.CreatedAt.UTC().Format(time.RFC3339)},
+		ValueDate:         camtDate{Date: entry.CreatedAt.UTC().Format(DateLayout)},
+		ServicerReference: reference(entry.ID),
+		Domain:            code.domain,
+		Family:            code.family,
+		SubFamily:         code.subFamily,
+		Proprietary:       entry.Type,
+		Details: camtEntryDetails{
+			ServicerReference: reference(entry.ID),
+			EndToEndID:        endToEndID,
+			Amount:            amount,
+			CreditDebit:       camtCreditDebit(entry.Amount),
+		},
+		Information: code.description,
+	})
+}
+
+// End closes the statement.
+func (e *Camt053Encoder) End() error {
+	for _, name := range []string{"Stmt", "BkToCstmrStmt", "Document"} {
+		if err := e.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return fmt.Errorf("failed to write xml: %w", err)
+		}
+	}
+
+	if err := e.enc.Flush(); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	if _, err := io.WriteString(e.w, "\n"); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Camt053Encoder) balance(balanceType string, amount money.Amount, date time.Time) camtBalance {
+	return camtBalance{
+		Type:        balanceType,
+		Amount:      e.amount(amount),
+		CreditDebit: camtCreditDebit(amount),
+		Date:        camtDate{Date: date.Format(DateLayout)},
+	}
+}
+
+func (e *Camt053Encoder) amount(amount money.Amount) camtAmount {
+	return camtAmount{
+		Currency: e.header.Account.CurrencyCode,
+		Value:    decimal(amount, e.header.Account.CurrencyCode, "."),
+	}
+}
+
+func (e *Camt053Encoder) start(name string, attrs ...xml.Attr) error {
+	if err := e.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs}); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Camt053Encoder) element(name string, v any) error {
+	if err := e.enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	return nil
+}
+
+func camtCreditDebit(amount money.Amount) string {
+	if amount < 0 {
+		return camtDebit
+	}
+
+	return camtCredit
+}
+
+// truncate truncates s to n runes.
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+
+	return s
+}
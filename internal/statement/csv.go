@@ -0,0 +1,87 @@
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	rowOpeningBalance = "opening_balance"
+	rowTransaction    = "transaction"
+	rowClosingBalance = "closing_balance"
+)
+
+// CSVEncoder writes statements as CSV: a row for the opening balance, a row for every transaction, with its type in the
+// last column, and a row for the closing balance, amounts being formatted in the currency of the account.
+type CSVEncoder struct {
+	w      *csv.Writer
+	header Header
+}
+
+// NewCSVEncoder returns a new CSVEncoder.
+func NewCSVEncoder(w io.Writer) *CSVEncoder {
+	return &CSVEncoder{
+		w: csv.NewWriter(w),
+	}
+}
+
+// Begin writes the column names and the opening balance.
+func (e *CSVEncoder) Begin(h Header) error {
+	e.header = h
+
+	return e.write(
+		[]string{"type", "date", "transaction_id", "source_id", "amount", "balance", "transaction_type"},
+		[]string{
+			rowOpeningBalance, h.From.Format(DateLayout), "", "", "",
+			display(h.OpeningBalance, h.Account.CurrencyCode), "",
+		},
+	)
+}
+
+// Entry writes a transaction.
+func (e *CSVEncoder) Entry(entry Entry) error {
+	sourceID := ""
+	if entry.SourceID.Valid {
+		sourceID = entry.SourceID.UUID.String()
+	}
+
+	return e.write([]string{
+		rowTransaction,
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		entry.ID.String(),
+		sourceID,
+		display(entry.Amount, e.header.Account.CurrencyCode),
+		display(entry.Balance, e.header.Account.CurrencyCode),
+		entry.Type,
+	})
+}
+
+// End writes the closing balance.
+func (e *CSVEncoder) End() error {
+	if err := e.write([]string{
+		rowClosingBalance, e.header.To.Format(DateLayout), "", "", "",
+		display(e.header.ClosingBalance, e.header.Account.CurrencyCode), "",
+	}); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
+
+func (e *CSVEncoder) write(records ...[]string) error {
+	for _, record := range records {
+		if err := e.w.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package statement
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// assertGolden asserts that got is the content of the golden file, which is written instead with -update.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got))
+}
+
+// goldenEntries are a transfer received, a transfer sent, a deposit, interest paid, overdraft interest, a fee, and a
+// pocket transfer sent and received back, from the opening to the closing balance of testHeader.
+func goldenEntries() []Entry {
+	received := testEntry(1, 500, 1500)
+	received.SourceID = uuid.NullUUID{UUID: uuid.MustParse("12345678-1234-1234-1234-123456789020"), Valid: true}
+
+	return []Entry{
+		received,
+		testEntry(2, -300, 1200),
+		withType(testEntry(3, 50, 1250), types.TransactionTypeDeposit),
+		withType(testEntry(4, 10, 1260), types.TransactionTypeInterest),
+		withType(testEntry(5, -4, 1256), types.TransactionTypeInterest),
+		withType(testEntry(6, -6, 1250), types.TransactionTypeFee),
+		withType(testEntry(7, -100, 1150), types.TransactionTypePocket),
+		withType(testEntry(8, 100, 1250), types.TransactionTypePocket),
+	}
+}
+
+func withType(e Entry, transactionType string) Entry {
+	e.Type = transactionType
+
+	return e
+}
+
+func encode(t *testing.T, enc Encoder, entries ...Entry) {
+	t.Helper()
+
+	require.NoError(t, enc.Begin(testHeader()))
+
+	for _, e := range entries {
+		require.NoError(t, enc.Entry(e))
+	}
+
+	require.NoError(t, enc.End())
+}
+
+func TestCSVEncoder(t *testing.T) {
+	t.Parallel()
+
+	received := testEntry(1, 500, 1500)
+	received.SourceID = uuid.NullUUID{UUID: uuid.MustParse("12345678-1234-1234-1234-123456789020"), Valid: true}
+
+	var b bytes.Buffer
+
+	encode(t, NewCSVEncoder(&b), received, withType(testEntry(2, -200, 1300), types.TransactionTypeFee))
+
+	assert.Equal(t, `type,date,transaction_id,source_id,amount,balance,transaction_type
+opening_balance,2024-05-01,,,,€10.00,
+transaction,2024-05-01T01:00:00Z,12345678-1234-1234-1234-123456789011,`+
+		`12345678-1234-1234-1234-123456789020,€5.00,€15.00,transfer
+transaction,2024-05-01T02:00:00Z,12345678-1234-1234-1234-123456789012,,-€2.00,€13.00,fee
+closing_balance,2024-05-31,,,,€12.50,
+`, b.String())
+}
+
+func TestJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    string
+	}{
+		{
+			name: "without entries",
+			want: `{"accountId":"12345678-1234-1234-1234-123456789001","currencyCode":"EUR","from":"2024-05-01",` +
+				`"to":"2024-05-31","openingBalance":1000,"closingBalance":1250,"entries":[]}` + "\n",
+		},
+		{
+			name:    "with entries",
+			entries: []Entry{testEntry(1, 500, 1500), withType(testEntry(2, -200, 1300), types.TransactionTypeInterest)},
+			want: `{"accountId":"12345678-1234-1234-1234-123456789001","currencyCode":"EUR","from":"2024-05-01",` +
+				`"to":"2024-05-31","openingBalance":1000,"closingBalance":1250,"entries":[` +
+				`{"id":"12345678-1234-1234-1234-123456789011","accountId":"12345678-1234-1234-1234-123456789001",` +
+				`"amount":500,"type":"transfer","sourceId":null,"createdAt":"2024-05-01T01:00:00Z","balance":1500},` +
+				`{"id":"12345678-1234-1234-1234-123456789012","accountId":"12345678-1234-1234-1234-123456789001",` +
+				`"amount":-200,"type":"interest","sourceId":null,"createdAt":"2024-05-01T02:00:00Z","balance":1300}]}` +
+				"\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+
+			encode(t, NewJSONEncoder(&b), tt.entries...)
+
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func TestCamt053Encoder(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	encode(t, NewCamt053Encoder(&b), goldenEntries()...)
+
+	assertGolden(t, "statement.camt053.xml", b.Bytes())
+}
+
+func TestMT940Encoder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		maxMessageLength int
+		golden           string
+	}{
+		{
+			name:             "in a message",
+			maxMessageLength: mt940MaxMessageLength,
+			golden:           "statement.mt940",
+		},
+		{
+			name:             "split into messages",
+			maxMessageLength: 400,
+			golden:           "statement_messages.mt940",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+
+			enc := NewMT940Encoder(&b)
+			enc.maxMessageLength = tt.maxMessageLength
+
+			encode(t, enc, goldenEntries()...)
+
+			assertGolden(t, tt.golden, b.Bytes())
+
+			for _, message := range bytes.SplitAfter(b.Bytes(), []byte("-\r\n")) {
+				assert.LessOrEqual(t, len(message), tt.maxMessageLength)
+			}
+		})
+	}
+}
+
+func TestEntry_code(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		transactionType string
+		amount          int64
+		want            transactionCode
+	}{
+		{name: "transfer sent", transactionType: types.TransactionTypeTransfer, amount: -1, want: codeTransferSent},
+		{
+			name:            "transfer received",
+			transactionType: types.TransactionTypeTransfer,
+			amount:          1,
+			want:            codeTransferReceived,
+		},
+		{name: "deposit", transactionType: types.TransactionTypeDeposit, amount: 1, want: codeDeposit},
+		{name: "interest paid", transactionType: types.TransactionTypeInterest, amount: 1, want: codeInterestPaid},
+		{
+			name:            "overdraft interest",
+			transactionType: types.TransactionTypeInterest,
+			amount:          -1,
+			want:            codeInterestCharged,
+		},
+		{name: "fee charged", transactionType: types.TransactionTypeFee, amount: -1, want: codeFeeCharged},
+		{name: "fee received", transactionType: types.TransactionTypeFee, amount: 1, want: codeFeeReceived},
+		{name: "pocket transfer sent", transactionType: types.TransactionTypePocket, amount: -1, want: codePocketSent},
+		{
+			name:            "pocket transfer received",
+			transactionType: types.TransactionTypePocket,
+			amount:          1,
+			want:            codePocketReceived,
+		},
+		{name: "transfer of no known type", transactionType: "", amount: -1, want: codeTransferSent},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, withType(testEntry(1, tt.amount, 0), tt.transactionType).code())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// JSONEncoder writes statements as a types.Statement, its entries being written one at a time.
+type JSONEncoder struct {
+	w       io.Writer
+	entries int
+}
+
+// NewJSONEncoder returns a new JSONEncoder.
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return &JSONEncoder{
+		w: w,
+	}
+}
+
+// Begin writes the statement up to its entries.
+func (e *JSONEncoder) Begin(h Header) error {
+	b, err := json.Marshal(types.Statement{
+		AccountID:      h.Account.ID,
+		CurrencyCode:   h.Account.CurrencyCode,
+		From:           h.From.Format(DateLayout),
+		To:             h.To.Format(DateLayout),
+		OpeningBalance: h.OpeningBalance,
+		ClosingBalance: h.ClosingBalance,
+		Entries:        []types.StatementEntry{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode statement: %w", err)
+	}
+
+	// Entries is the last field, the statement is left open after the start of its array.
+	return e.write(bytes.TrimSuffix(b, []byte("]}")))
+}
+
+// Entry writes an entry of the statement.
+func (e *JSONEncoder) Entry(entry Entry) error {
+	b, err := json.Marshal(types.StatementEntry{
+		Transaction: entry.Transaction,
+		Balance:     entry.Balance,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode statement entry: %w", err)
+	}
+
+	if e.entries > 0 {
+		b = append([]byte(","), b...)
+	}
+
+	e.entries++
+
+	return e.write(b)
+}
+
+// End closes the statement.
+func (e *JSONEncoder) End() error {
+	return e.write([]byte("]}\n"))
+}
+
+func (e *JSONEncoder) write(b []byte) error {
+	if _, err := e.w.Write(b); err != nil {
+		return fmt.Errorf("failed to write json: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+const (
+	mt940LineBreak = "\r\n"
+
+	// mt940MaxMessageLength is the length of SWIFT messages, longer statements being split into several messages.
+	mt940MaxMessageLength = 2000
+	// mt940TrailerLength is the length of the closing balance and the end of a message at most.
+	mt940TrailerLength = len(":62F:C060102EUR") + mt940MaxAmountLength + 2*len(mt940LineBreak) + len("-")
+
+	mt940MaxAmountLength    = 15
+	mt940MaxReferenceLength = 16
+	mt940NoReference        = "NONREF"
+
+	mt940Credit = "C"
+	mt940Debit  = "D"
+
+	mt940DateLayout      = "060102"
+	mt940EntryDateLayout = "0102"
+)
+
+// MT940Encoder writes statements as SWIFT MT940 customer statement messages, without the blocks of the SWIFT
+// network, the way they are imported from files. Statements longer than a message are split into several messages,
+// numbered in sequence, the balance in between being the closing balance of a message and the opening balance of the
+// next one. Entries have the same value and entry dates, the date of the transaction, and are referenced by the ID of
+// the transaction, which the information to the account owner has in full with the type of the transaction.
+type MT940Encoder struct {
+	w                io.Writer
+	header           Header
+	maxMessageLength int
+	message          bytes.Buffer
+	sequence         int
+	entries          int
+}
+
+// NewMT940Encoder returns a new MT940Encoder.
+func NewMT940Encoder(w io.Writer) *MT940Encoder {
+	return &MT940Encoder{
+		w:                w,
+		maxMessageLength: mt940MaxMessageLength,
+	}
+}
+
+// Begin begins the first message with the opening balance.
+func (e *MT940Encoder) Begin(h Header) error {
+	e.header = h
+
+	e.open("60F", h.OpeningBalance, h.From)
+
+	return nil
+}
+
+// Entry writes an entry, the message being written first when the entry would not fit in it.
+func (e *MT940Encoder) Entry(entry Entry) error {
+	code := entry.code()
+	ref := reference(entry.ID)
+
+	lines := []string{
+		":61:" + entry.CreatedAt.UTC().Format(mt940DateLayout) + entry.CreatedAt.UTC().Format(mt940EntryDateLayout) +
+			mt940CreditDebit(entry.Amount) + decimal(entry.Amount, e.header.Account.CurrencyCode, ",") +
+			code.swift + mt940NoReference + "//" + ref[:mt940MaxReferenceLength],
+		ref,
+		":86:" + code.description,
+		"Transaction " + ref,
+	}
+
+	if entry.Type != "" {
+		lines = append(lines, "Type "+entry.Type)
+	}
+
+	if entry.SourceID.Valid {
+		lines = append(lines, "Source "+reference(entry.SourceID.UUID))
+	}
+
+	var b bytes.Buffer
+	for _, line := range lines {
+		b.WriteString(line + mt940LineBreak)
+	}
+
+	if e.entries > 0 && e.message.Len()+b.Len()+mt940TrailerLength > e.maxMessageLength {
+		balance := entry.Balance - entry.Amount
+
+		if err := e.close("62M", balance, entry.CreatedAt.UTC()); err != nil {
+			return err
+		}
+
+		e.open("60M", balance, entry.CreatedAt.UTC())
+	}
+
+	e.entries++
+	e.message.Write(b.Bytes())
+
+	return nil
+}
+
+// End writes the last message with the closing balance.
+func (e *MT940Encoder) End() error {
+	return e.close("62F", e.header.ClosingBalance, e.header.To)
+}
+
+// open begins the next message of the statement with its opening balance.
+func (e *MT940Encoder) open(tag string, balance money.Amount, date time.Time) {
+	e.sequence++
+	e.entries = 0
+
+	e.line(":20:" + statementID(e.header))
+	e.line(":25:" + reference(e.header.Account.ID))
+	e.line(fmt.Sprintf(":28C:%s/%d", mt940StatementNumber(e.header), e.sequence))
+	e.line(":" + tag + ":" + e.balance(balance, date))
+}
+
+// close ends the message with its closing balance and writes it.
+func (e *MT940Encoder) close(tag string, balance money.Amount, date time.Time) error {
+	e.line(":" + tag + ":" + e.balance(balance, date))
+	e.line("-")
+
+	if _, err := e.w.Write(e.message.Bytes()); err != nil {
+		return fmt.Errorf("failed to write mt940: %w", err)
+	}
+
+	e.message.Reset()
+
+	return nil
+}
+
+func (e *MT940Encoder) line(line string) {
+	e.message.WriteString(line + mt940LineBreak)
+}
+
+// balance formats a balance field: credit or debit, date, currency and amount.
+func (e *MT940Encoder) balance(amount money.Amount, date time.Time) string {
+	return mt940CreditDebit(amount) + date.Format(mt940DateLayout) + e.header.Account.CurrencyCode +
+		decimal(amount, e.header.Account.CurrencyCode, ",")
+}
+
+func mt940CreditDebit(amount money.Amount) string {
+	if amount < 0 {
+		return mt940Debit
+	}
+
+	return mt940Credit
+}
+
+// mt940StatementNumber numbers the statement by the day of the year of its last day, e.g. 24152 for 2024-05-31, as
+// statements of a period are not numbered otherwise.
+func mt940StatementNumber(h Header) string {
+	return fmt.Sprintf("%02d%03d", h.To.Year()%100, h.To.YearDay()) //nolint:mnd // two digits of the year.
+}
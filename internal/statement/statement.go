@@ -0,0 +1,365 @@
+// Package statement writes the statements of accounts for a period: the opening balance, every transaction with the
+// balance after it, and the closing balance, in several formats. Statements are read from a consistent snapshot and
+// written as they are read, so they are never loaded in memory at once.
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatJSON    = "json"
+	FormatCamt053 = "camt053"
+	FormatMT940   = "mt940"
+
+	// DateLayout is the layout of the dates of a period.
+	DateLayout = "2006-01-02"
+
+	defaultPageSize = 500
+	day             = 24 * time.Hour
+)
+
+type (
+	// Header is what is known of a statement before its entries.
+	Header struct {
+		Account types.Account
+		// From and To are the first and the last day of the period, in UTC.
+		From           time.Time
+		To             time.Time
+		OpeningBalance money.Amount
+		ClosingBalance money.Amount
+		CreatedAt      time.Time
+	}
+
+	// Entry is a transaction and the balance of the account after it.
+	Entry struct {
+		types.Transaction
+		Balance money.Amount
+	}
+
+	// Encoder writes a statement in a format: its header, then every entry in order, then the end.
+	Encoder interface {
+		Begin(h Header) error
+		Entry(e Entry) error
+		End() error
+	}
+
+	// Format is a format statements are written in, Extension being the extension of their files.
+	Format struct {
+		ContentType string
+		Extension   string
+		NewEncoder  func(w io.Writer) Encoder
+	}
+
+	// transactionCode is the bank transaction code of an entry: its ISO 20022 domain, family and sub-family, its
+	// SWIFT transaction type, and its description.
+	transactionCode struct {
+		domain      string
+		family      string
+		subFamily   string
+		swift       string
+		description string
+	}
+
+	// transactionCodes are the bank transaction codes of the entries of a type of transaction, debited or credited.
+	transactionCodes struct {
+		debit  transactionCode
+		credit transactionCode
+	}
+)
+
+// Formats are the formats statements are written in, by name.
+var Formats = map[string]Format{
+	FormatCSV: {
+		ContentType: "text/csv; charset=utf-8",
+		Extension:   "csv",
+		NewEncoder:  func(w io.Writer) Encoder { return NewCSVEncoder(w) },
+	},
+	FormatJSON: {
+		ContentType: "application/json; charset=utf-8",
+		Extension:   "json",
+		NewEncoder:  func(w io.Writer) Encoder { return NewJSONEncoder(w) },
+	},
+	FormatCamt053: {
+		ContentType: "application/xml; charset=utf-8",
+		Extension:   "xml",
+		NewEncoder:  func(w io.Writer) Encoder { return NewCamt053Encoder(w) },
+	},
+	FormatMT940: {
+		ContentType: "text/plain; charset=utf-8",
+		Extension:   "sta",
+		NewEncoder:  func(w io.Writer) Encoder { return NewMT940Encoder(w) },
+	},
+}
+
+var (
+	codeTransferSent = transactionCode{
+		domain: "PMNT", family: "ICDT", subFamily: "BOOK", swift: "NTRF", description: "Transfer sent",
+	}
+	codeTransferReceived = transactionCode{
+		domain: "PMNT", family: "RCDT", subFamily: "BOOK", swift: "NTRF", description: "Transfer received",
+	}
+	codeDeposit = transactionCode{
+		domain: "PMNT", family: "MCOP", subFamily: "OTHR", swift: "NMSC", description: "Deposit",
+	}
+	codeInterestPaid = transactionCode{
+		domain: "ACMT", family: "MCOP", subFamily: "INTR", swift: "NINT", description: "Interest",
+	}
+	codeInterestCharged = transactionCode{
+		domain: "ACMT", family: "MDOP", subFamily: "INTR", swift: "NINT", description: "Overdraft interest",
+	}
+	codeFeeCharged = transactionCode{
+		domain: "ACMT", family: "MDOP", subFamily: "CHRG", swift: "NCHG", description: "Fee",
+	}
+	codeFeeReceived = transactionCode{
+		domain: "ACMT", family: "MCOP", subFamily: "CHRG", swift: "NCHG", description: "Fee income",
+	}
+	codePocketSent = transactionCode{
+		domain: "PMNT", family: "ICDT", subFamily: "BOOK", swift: "NTRF", description: "Pocket transfer sent",
+	}
+	codePocketReceived = transactionCode{
+		domain: "PMNT", family: "RCDT", subFamily: "BOOK", swift: "NTRF", description: "Pocket transfer received",
+	}
+
+	// codesByType are the bank transaction codes of the entries by the type of their transaction. Interest is debited
+	// when charged on an overdraft, and fees are credited to the income accounts.
+	codesByType = map[string]transactionCodes{
+		types.TransactionTypeTransfer: {debit: codeTransferSent, credit: codeTransferReceived},
+		types.TransactionTypeDeposit:  {debit: codeDeposit, credit: codeDeposit},
+		types.TransactionTypeInterest: {debit: codeInterestCharged, credit: codeInterestPaid},
+		types.TransactionTypeFee:      {debit: codeFeeCharged, credit: codeFeeReceived},
+		types.TransactionTypePocket:   {debit: codePocketSent, credit: codePocketReceived},
+	}
+)
+
+// Holders authorizes the holders of accounts by their role, failing with types.ErrNotPermitted.
+type Holders interface {
+	Authorize(ctx context.Context, accountID uuid.UUID, permission holder.Permission) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	storeWithTx func(tx pgx.Tx) storage.StatementStore
+	holders     Holders
+	logger      logger.Logger
+	pageSize    int32
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(conn storage.DBConnection, holders Holders, logger logger.Logger) *Service {
+	return &Service{
+		conn:        conn,
+		storeWithTx: storage.StatementStoreWithTx,
+		holders:     holders,
+		logger:      logger,
+		pageSize:    defaultPageSize,
+		now:         time.Now,
+	}
+}
+
+// Write writes the statement of the account from the day from to the day to, both included, with enc, for the
+// holders permitted to view the account. The encoder is not used when the account is not found or not permitted.
+// Errors returned after the encoder began are failures to read the rest of the statement or to write it.
+func (s *Service) Write(ctx context.Context, accountID uuid.UUID, from, to time.Time, enc Encoder) error {
+	if err := s.holders.Authorize(ctx, accountID, holder.PermissionView); err != nil {
+		return err
+	}
+
+	// The statement is read in pages, from a snapshot so that the balances match the transactions in between.
+	tx, err := s.conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	defer storage.Rollback(ctx, tx, s.logger)
+
+	store := s.storeWithTx(tx)
+
+	header, err := s.header(ctx, store, accountID, from, to)
+	if err != nil {
+		return err
+	}
+
+	if err := enc.Begin(header); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+
+	if err := s.entries(ctx, store, header, enc); err != nil {
+		return err
+	}
+
+	if err := enc.End(); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) header(
+	ctx context.Context,
+	store storage.StatementStore,
+	accountID uuid.UUID,
+	from, to time.Time,
+) (Header, error) {
+	account, err := store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Header{}, types.ErrAccountNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to fetch account", "error", err)
+
+		return Header{}, types.ErrInternal
+	}
+
+	opening, err := s.balanceBefore(ctx, store, account, from)
+	if err != nil {
+		return Header{}, err
+	}
+
+	closing, err := s.balanceBefore(ctx, store, account, to.Add(day))
+	if err != nil {
+		return Header{}, err
+	}
+
+	return Header{
+		Account: types.Account{
+			ID:           account.AccountID,
+			Name:         account.Name,
+			Email:        account.Email,
+			CurrencyCode: account.CurrencyCode,
+		},
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		CreatedAt:      s.now().UTC(),
+	}, nil
+}
+
+func (s *Service) balanceBefore(
+	ctx context.Context,
+	store storage.StatementStore,
+	account storage.Account,
+	before time.Time,
+) (money.Amount, error) {
+	balance, err := store.GetAccountBalanceBefore(ctx, storage.GetAccountBalanceBeforeParams{
+		AccountID: account.AccountID,
+		Before:    pgtype.Timestamptz{Time: before, Valid: true},
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get account balance", "error", err)
+
+		return 0, types.ErrInternal
+	}
+
+	return storage.AmountFromNumeric(balance, account.CurrencyCode), nil
+}
+
+// entries writes the transactions of the period with the running balance, a page at a time.
+func (s *Service) entries(ctx context.Context, store storage.StatementStore, header Header, enc Encoder) error {
+	balance := header.OpeningBalance
+
+	params := storage.ListTransactionsBetweenParams{
+		AccountID: header.Account.ID,
+		From:      pgtype.Timestamptz{Time: header.From, Valid: true},
+		To:        pgtype.Timestamptz{Time: header.To.Add(day), Valid: true},
+		Limit:     s.pageSize,
+	}
+
+	for {
+		transactions, err := store.ListTransactionsBetween(ctx, params)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to list transactions", "error", err)
+
+			return types.ErrInternal
+		}
+
+		for _, t := range transactions {
+			amount := storage.AmountFromNumeric(t.Amount, header.Account.CurrencyCode)
+			balance += amount
+
+			if err := enc.Entry(Entry{
+				Transaction: types.Transaction{
+					ID:        t.TransactionID,
+					AccountID: t.AccountID,
+					Amount:    amount,
+					Type:      t.Type,
+					SourceID:  t.SourceID,
+					CreatedAt: t.CreatedAt.Time,
+				},
+				Balance: balance,
+			}); err != nil {
+				return fmt.Errorf("failed to write statement: %w", err)
+			}
+		}
+
+		if len(transactions) < int(s.pageSize) {
+			return nil
+		}
+
+		last := transactions[len(transactions)-1]
+		params.AfterCreatedAt = last.CreatedAt
+		params.AfterTransactionID = uuid.NullUUID{UUID: last.TransactionID, Valid: true}
+	}
+}
+
+// code returns the bank transaction code of the entry by the type of its transaction, debited or credited, e.g.
+// INTR for interest and CHRG for fees. Transactions of no known type are transfers.
+func (e Entry) code() transactionCode {
+	codes, ok := codesByType[e.Type]
+	if !ok {
+		codes = codesByType[types.TransactionTypeTransfer]
+	}
+
+	if e.Amount < 0 {
+		return codes.debit
+	}
+
+	return codes.credit
+}
+
+// display formats the amount in the currency, e.g. €1.50.
+func display(amount money.Amount, currencyCode string) string {
+	return money.New(amount, currencyCode).Display()
+}
+
+// decimal formats the absolute value of the amount with the digits of the currency and no thousand separator, e.g.
+// 1234.50, the way bank statement formats do, which tell credits from debits apart.
+func decimal(amount money.Amount, currencyCode, separator string) string {
+	if amount < 0 {
+		amount = -amount
+	}
+
+	currency := money.New(amount, currencyCode).Currency()
+
+	return money.NewFormatter(currency.Fraction, separator, "", "", "1").Format(amount)
+}
+
+// reference returns the ID as 32 hexadecimal digits, which fit the 35 characters references of bank statement formats.
+func reference(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+// statementID identifies the statement of an account by its period, e.g. 240501-240531.
+func statementID(h Header) string {
+	return h.From.Format("060102") + "-" + h.To.Format("060102")
+}
@@ -0,0 +1,208 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/holder"
+	"github.com/zaidsasa/xbankapi/internal/holder/holdertest"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantFrom      = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	wantTo        = time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	wantCreatedAt = time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	errAnything   = errors.New("any")
+)
+
+// recorder records what a statement is encoded to.
+type recorder struct {
+	header  *Header
+	entries []Entry
+	ended   bool
+}
+
+func (r *recorder) Begin(h Header) error {
+	r.header = &h
+
+	return nil
+}
+
+func (r *recorder) Entry(e Entry) error {
+	r.entries = append(r.entries, e)
+
+	return nil
+}
+
+func (r *recorder) End() error {
+	r.ended = true
+
+	return nil
+}
+
+func testHeader() Header {
+	return Header{
+		Account: types.Account{
+			ID:           wantAccountID,
+			Name:         "name",
+			Email:        "test@mail.com",
+			CurrencyCode: "EUR",
+		},
+		From:           wantFrom,
+		To:             wantTo,
+		OpeningBalance: 1000,
+		ClosingBalance: 1250,
+		CreatedAt:      wantCreatedAt,
+	}
+}
+
+func testTransaction(n int, amount int64) storage.Transaction {
+	return storage.Transaction{
+		TransactionID: uuid.MustParse("12345678-1234-1234-1234-12345678901" + string(rune('0'+n))),
+		AccountID:     wantAccountID,
+		Amount:        pgtype.Numeric{Int: big.NewInt(amount), Exp: -2, Valid: true},
+		CreatedAt:     pgtype.Timestamptz{Time: wantFrom.Add(time.Duration(n) * time.Hour), Valid: true},
+		Type:          types.TransactionTypeTransfer,
+	}
+}
+
+func testEntry(n int, amount, balance int64) Entry {
+	t := testTransaction(n, amount)
+
+	return Entry{
+		Transaction: types.Transaction{
+			ID:        t.TransactionID,
+			AccountID: wantAccountID,
+			Amount:    amount,
+			Type:      t.Type,
+			CreatedAt: t.CreatedAt.Time,
+		},
+		Balance: balance,
+	}
+}
+
+func balance(amount int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(amount), Exp: -2, Valid: true}
+}
+
+func TestService_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mock        func(*storageMocks.MockStatementStore)
+		permitErr   error
+		wantHeader  *Header
+		wantEntries []Entry
+		wantErr     error
+	}{
+		{
+			name:      "failed when not permitted",
+			mock:      func(*storageMocks.MockStatementStore) {},
+			permitErr: types.ErrNotPermitted,
+			wantErr:   types.ErrNotPermitted,
+		},
+		{
+			name: "failed when account not found",
+			mock: func(ms *storageMocks.MockStatementStore) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "failed when the balance fails to be read",
+			mock: func(ms *storageMocks.MockStatementStore) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{}, nil).Once()
+				ms.EXPECT().GetAccountBalanceBefore(mock.Anything, mock.Anything).
+					Return(pgtype.Numeric{}, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when the statement has pages of transactions",
+			mock: func(ms *storageMocks.MockStatementStore) {
+				ms.EXPECT().GetAccount(mock.Anything, wantAccountID).Return(storage.Account{
+					AccountID:    wantAccountID,
+					Name:         "name",
+					Email:        "test@mail.com",
+					CurrencyCode: "EUR",
+				}, nil).Once()
+				ms.EXPECT().GetAccountBalanceBefore(mock.Anything, storage.GetAccountBalanceBeforeParams{
+					AccountID: wantAccountID,
+					Before:    pgtype.Timestamptz{Time: wantFrom, Valid: true},
+				}).Return(balance(1000), nil).Once()
+				ms.EXPECT().GetAccountBalanceBefore(mock.Anything, storage.GetAccountBalanceBeforeParams{
+					AccountID: wantAccountID,
+					Before:    pgtype.Timestamptz{Time: wantTo.Add(day), Valid: true},
+				}).Return(balance(1250), nil).Once()
+
+				params := storage.ListTransactionsBetweenParams{
+					AccountID: wantAccountID,
+					From:      pgtype.Timestamptz{Time: wantFrom, Valid: true},
+					To:        pgtype.Timestamptz{Time: wantTo.Add(day), Valid: true},
+					Limit:     2,
+				}
+				ms.EXPECT().ListTransactionsBetween(mock.Anything, params).
+					Return([]storage.Transaction{testTransaction(1, 500), testTransaction(2, -200)}, nil).Once()
+
+				params.AfterCreatedAt = testTransaction(2, 0).CreatedAt
+				params.AfterTransactionID = uuid.NullUUID{UUID: testTransaction(2, 0).TransactionID, Valid: true}
+				ms.EXPECT().ListTransactionsBetween(mock.Anything, params).
+					Return([]storage.Transaction{testTransaction(3, -50)}, nil).Once()
+			},
+			wantHeader: func() *Header {
+				h := testHeader()
+
+				return &h
+			}(),
+			wantEntries: []Entry{testEntry(1, 500, 1500), testEntry(2, -200, 1300), testEntry(3, -50, 1250)},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockStatementStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			if tt.permitErr == nil {
+				conn.EXPECT().BeginTx(mock.Anything, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}).
+					Return(tx, nil).Once()
+				tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+			}
+
+			tt.mock(store)
+
+			s := New(conn, holdertest.Authorize(t, wantAccountID, holder.PermissionView, tt.permitErr), slog.Default())
+			s.storeWithTx = func(pgx.Tx) storage.StatementStore { return store }
+			s.pageSize = 2
+			s.now = func() time.Time { return wantCreatedAt }
+
+			enc := &recorder{}
+
+			err := s.Write(context.Background(), wantAccountID, wantFrom, wantTo, enc)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantHeader, enc.header)
+			assert.Equal(t, tt.wantEntries, enc.entries)
+			assert.Equal(t, tt.wantErr == nil, enc.ended)
+		})
+	}
+}
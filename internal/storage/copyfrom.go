@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: copyfrom.go
+
+package storage
+
+import (
+	"context"
+)
+
+// iteratorForAddSanctionsEntries implements pgx.CopyFromSource.
+type iteratorForAddSanctionsEntries struct {
+	rows                 []AddSanctionsEntriesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForAddSanctionsEntries) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForAddSanctionsEntries) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].List,
+		r.rows[0].Reference,
+		r.rows[0].Name,
+	}, nil
+}
+
+func (r iteratorForAddSanctionsEntries) Err() error {
+	return nil
+}
+
+func (q *Queries) AddSanctionsEntries(ctx context.Context, arg []AddSanctionsEntriesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"sanctions_entry"}, []string{"list", "reference", "name"}, &iteratorForAddSanctionsEntries{rows: arg})
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// testMigrate runs a migration file of db/migrations with the queries, within their transaction.
+func testMigrate(t *testing.T, q *Queries, name string) {
+	t.Helper()
+
+	sql, err := os.ReadFile(filepath.Join("..", "..", "db", "migrations", name))
+	require.NoError(t, err)
+
+	_, err = q.db.Exec(context.Background(), string(sql))
+	require.NoError(t, err)
+}
+
+func TestMigration_amountCurrency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	q := testQueries(t)
+
+	// The amounts are stored as they were before the migration, with 2 decimals whatever their currency.
+	testMigrate(t, q, "20261027090000_amount_currency.down.sql")
+
+	accountIDs := map[string]uuid.UUID{}
+
+	for _, currencyCode := range []string{money.EUR, money.JPY, money.KWD} {
+		account, err := q.CreateAccount(ctx, CreateAccountParams{
+			Email:         uuid.NewString() + "@mail.com",
+			Name:          "name",
+			CurrencyCode:  currencyCode,
+			AccountNumber: rand.Int64N(1e10), //nolint:gosec // not a secret.
+			ProductCode:   "current",
+		})
+		require.NoError(t, err)
+
+		_, err = q.AddTransaction(ctx, AddTransactionParams{
+			AccountID: account.AccountID, Amount: NumericFromAmount(1050, NoCurrency), Type: types.TransactionTypeDeposit,
+		})
+		require.NoError(t, err)
+
+		_, err = q.SetAccountOverdraft(ctx, SetAccountOverdraftParams{
+			AccountID: account.AccountID, OverdraftLimit: NumericFromAmount(5000, NoCurrency),
+		})
+		require.NoError(t, err)
+
+		accountIDs[currencyCode] = account.AccountID
+	}
+
+	testMigrate(t, q, "20261027090000_amount_currency.up.sql")
+
+	for currencyCode, accountID := range accountIDs {
+		balance, err := q.GetAccountTotalAmount(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, money.Amount(1050), AmountFromNumeric(balance, currencyCode), currencyCode)
+		assert.Equal(t, MinorUnitScale(currencyCode), -balance.Exp, currencyCode)
+
+		account, err := q.GetAccount(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, money.Amount(5000), AmountFromNumeric(account.OverdraftLimit, currencyCode), currencyCode)
+	}
+}
@@ -197,6 +197,63 @@ func (_c *MockAccountStore_GetAccount_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetAccountByIBAN provides a mock function with given fields: ctx, iban
+func (_m *MockAccountStore) GetAccountByIBAN(ctx context.Context, iban pgtype.Text) (storage.Account, error) {
+	ret := _m.Called(ctx, iban)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountByIBAN")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) (storage.Account, error)); ok {
+		return rf(ctx, iban)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) storage.Account); ok {
+		r0 = rf(ctx, iban)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Text) error); ok {
+		r1 = rf(ctx, iban)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountStore_GetAccountByIBAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountByIBAN'
+type MockAccountStore_GetAccountByIBAN_Call struct {
+	*mock.Call
+}
+
+// GetAccountByIBAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - iban pgtype.Text
+func (_e *MockAccountStore_Expecter) GetAccountByIBAN(ctx interface{}, iban interface{}) *MockAccountStore_GetAccountByIBAN_Call {
+	return &MockAccountStore_GetAccountByIBAN_Call{Call: _e.mock.On("GetAccountByIBAN", ctx, iban)}
+}
+
+func (_c *MockAccountStore_GetAccountByIBAN_Call) Run(run func(ctx context.Context, iban pgtype.Text)) *MockAccountStore_GetAccountByIBAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Text))
+	})
+	return _c
+}
+
+func (_c *MockAccountStore_GetAccountByIBAN_Call) Return(_a0 storage.Account, _a1 error) *MockAccountStore_GetAccountByIBAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountStore_GetAccountByIBAN_Call) RunAndReturn(run func(context.Context, pgtype.Text) (storage.Account, error)) *MockAccountStore_GetAccountByIBAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAccountTotalAmount provides a mock function with given fields: ctx, accountID
 func (_m *MockAccountStore) GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error) {
 	ret := _m.Called(ctx, accountID)
@@ -254,27 +311,27 @@ func (_c *MockAccountStore_GetAccountTotalAmount_Call) RunAndReturn(run func(con
 	return _c
 }
 
-// HasAccount provides a mock function with given fields: ctx, accountID
-func (_m *MockAccountStore) HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error) {
-	ret := _m.Called(ctx, accountID)
+// HasAccountTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockAccountStore) HasAccountTransaction(ctx context.Context, arg storage.HasAccountTransactionParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasAccount")
+		panic("no return value specified for HasAccountTransaction")
 	}
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
-		return rf(ctx, accountID)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.HasAccountTransactionParams) (bool, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
-		r0 = rf(ctx, accountID)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.HasAccountTransactionParams) bool); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, accountID)
+	if rf, ok := ret.Get(1).(func(context.Context, storage.HasAccountTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -282,31 +339,252 @@ func (_m *MockAccountStore) HasAccount(ctx context.Context, accountID uuid.UUID)
 	return r0, r1
 }
 
-// MockAccountStore_HasAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAccount'
-type MockAccountStore_HasAccount_Call struct {
+// MockAccountStore_HasAccountTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAccountTransaction'
+type MockAccountStore_HasAccountTransaction_Call struct {
 	*mock.Call
 }
 
-// HasAccount is a helper method to define mock.On call
+// HasAccountTransaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - accountID uuid.UUID
-func (_e *MockAccountStore_Expecter) HasAccount(ctx interface{}, accountID interface{}) *MockAccountStore_HasAccount_Call {
-	return &MockAccountStore_HasAccount_Call{Call: _e.mock.On("HasAccount", ctx, accountID)}
+//   - arg storage.HasAccountTransactionParams
+func (_e *MockAccountStore_Expecter) HasAccountTransaction(ctx interface{}, arg interface{}) *MockAccountStore_HasAccountTransaction_Call {
+	return &MockAccountStore_HasAccountTransaction_Call{Call: _e.mock.On("HasAccountTransaction", ctx, arg)}
 }
 
-func (_c *MockAccountStore_HasAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockAccountStore_HasAccount_Call {
+func (_c *MockAccountStore_HasAccountTransaction_Call) Run(run func(ctx context.Context, arg storage.HasAccountTransactionParams)) *MockAccountStore_HasAccountTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(storage.HasAccountTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockAccountStore_HasAccountTransaction_Call) Return(_a0 bool, _a1 error) *MockAccountStore_HasAccountTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountStore_HasAccountTransaction_Call) RunAndReturn(run func(context.Context, storage.HasAccountTransactionParams) (bool, error)) *MockAccountStore_HasAccountTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactions provides a mock function with given fields: ctx, arg
+func (_m *MockAccountStore) ListTransactions(ctx context.Context, arg storage.ListTransactionsParams) ([]storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 []storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsParams) ([]storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsParams) []storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListTransactionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountStore_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockAccountStore_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListTransactionsParams
+func (_e *MockAccountStore_Expecter) ListTransactions(ctx interface{}, arg interface{}) *MockAccountStore_ListTransactions_Call {
+	return &MockAccountStore_ListTransactions_Call{Call: _e.mock.On("ListTransactions", ctx, arg)}
+}
+
+func (_c *MockAccountStore_ListTransactions_Call) Run(run func(ctx context.Context, arg storage.ListTransactionsParams)) *MockAccountStore_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListTransactionsParams))
+	})
+	return _c
+}
+
+func (_c *MockAccountStore_ListTransactions_Call) Return(_a0 []storage.Transaction, _a1 error) *MockAccountStore_ListTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountStore_ListTransactions_Call) RunAndReturn(run func(context.Context, storage.ListTransactionsParams) ([]storage.Transaction, error)) *MockAccountStore_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactionsAfter provides a mock function with given fields: ctx, arg
+func (_m *MockAccountStore) ListTransactionsAfter(ctx context.Context, arg storage.ListTransactionsAfterParams) ([]storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactionsAfter")
+	}
+
+	var r0 []storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsAfterParams) ([]storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsAfterParams) []storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListTransactionsAfterParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountStore_ListTransactionsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactionsAfter'
+type MockAccountStore_ListTransactionsAfter_Call struct {
+	*mock.Call
+}
+
+// ListTransactionsAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListTransactionsAfterParams
+func (_e *MockAccountStore_Expecter) ListTransactionsAfter(ctx interface{}, arg interface{}) *MockAccountStore_ListTransactionsAfter_Call {
+	return &MockAccountStore_ListTransactionsAfter_Call{Call: _e.mock.On("ListTransactionsAfter", ctx, arg)}
+}
+
+func (_c *MockAccountStore_ListTransactionsAfter_Call) Run(run func(ctx context.Context, arg storage.ListTransactionsAfterParams)) *MockAccountStore_ListTransactionsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListTransactionsAfterParams))
+	})
+	return _c
+}
+
+func (_c *MockAccountStore_ListTransactionsAfter_Call) Return(_a0 []storage.Transaction, _a1 error) *MockAccountStore_ListTransactionsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountStore_ListTransactionsAfter_Call) RunAndReturn(run func(context.Context, storage.ListTransactionsAfterParams) ([]storage.Transaction, error)) *MockAccountStore_ListTransactionsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAccounts provides a mock function with given fields: ctx, accountIds
+func (_m *MockAccountStore) LockAccounts(ctx context.Context, accountIds []uuid.UUID) error {
+	ret := _m.Called(ctx, accountIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAccounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) error); ok {
+		r0 = rf(ctx, accountIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAccountStore_LockAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAccounts'
+type MockAccountStore_LockAccounts_Call struct {
+	*mock.Call
+}
+
+// LockAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountIds []uuid.UUID
+func (_e *MockAccountStore_Expecter) LockAccounts(ctx interface{}, accountIds interface{}) *MockAccountStore_LockAccounts_Call {
+	return &MockAccountStore_LockAccounts_Call{Call: _e.mock.On("LockAccounts", ctx, accountIds)}
+}
+
+func (_c *MockAccountStore_LockAccounts_Call) Run(run func(ctx context.Context, accountIds []uuid.UUID)) *MockAccountStore_LockAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAccountStore_LockAccounts_Call) Return(_a0 error) *MockAccountStore_LockAccounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAccountStore_LockAccounts_Call) RunAndReturn(run func(context.Context, []uuid.UUID) error) *MockAccountStore_LockAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NextAccountNumber provides a mock function with given fields: ctx
+func (_m *MockAccountStore) NextAccountNumber(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextAccountNumber")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountStore_NextAccountNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextAccountNumber'
+type MockAccountStore_NextAccountNumber_Call struct {
+	*mock.Call
+}
+
+// NextAccountNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAccountStore_Expecter) NextAccountNumber(ctx interface{}) *MockAccountStore_NextAccountNumber_Call {
+	return &MockAccountStore_NextAccountNumber_Call{Call: _e.mock.On("NextAccountNumber", ctx)}
+}
+
+func (_c *MockAccountStore_NextAccountNumber_Call) Run(run func(ctx context.Context)) *MockAccountStore_NextAccountNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockAccountStore_HasAccount_Call) Return(_a0 bool, _a1 error) *MockAccountStore_HasAccount_Call {
+func (_c *MockAccountStore_NextAccountNumber_Call) Return(_a0 int64, _a1 error) *MockAccountStore_NextAccountNumber_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockAccountStore_HasAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockAccountStore_HasAccount_Call {
+func (_c *MockAccountStore_NextAccountNumber_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockAccountStore_NextAccountNumber_Call {
 	_c.Call.Return(run)
 	return _c
 }
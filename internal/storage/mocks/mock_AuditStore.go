@@ -0,0 +1,316 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockAuditStore is an autogenerated mock type for the AuditStore type
+type MockAuditStore struct {
+	mock.Mock
+}
+
+type MockAuditStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditStore) EXPECT() *MockAuditStore_Expecter {
+	return &MockAuditStore_Expecter{mock: &_m.Mock}
+}
+
+// AddAuditEvent provides a mock function with given fields: ctx, arg
+func (_m *MockAuditStore) AddAuditEvent(ctx context.Context, arg storage.AddAuditEventParams) (storage.AuditEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuditEvent")
+	}
+
+	var r0 storage.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddAuditEventParams) (storage.AuditEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddAuditEventParams) storage.AuditEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.AuditEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddAuditEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditStore_AddAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEvent'
+type MockAuditStore_AddAuditEvent_Call struct {
+	*mock.Call
+}
+
+// AddAuditEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddAuditEventParams
+func (_e *MockAuditStore_Expecter) AddAuditEvent(ctx interface{}, arg interface{}) *MockAuditStore_AddAuditEvent_Call {
+	return &MockAuditStore_AddAuditEvent_Call{Call: _e.mock.On("AddAuditEvent", ctx, arg)}
+}
+
+func (_c *MockAuditStore_AddAuditEvent_Call) Run(run func(ctx context.Context, arg storage.AddAuditEventParams)) *MockAuditStore_AddAuditEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddAuditEventParams))
+	})
+	return _c
+}
+
+func (_c *MockAuditStore_AddAuditEvent_Call) Return(_a0 storage.AuditEvent, _a1 error) *MockAuditStore_AddAuditEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditStore_AddAuditEvent_Call) RunAndReturn(run func(context.Context, storage.AddAuditEventParams) (storage.AuditEvent, error)) *MockAuditStore_AddAuditEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastAuditEventHash provides a mock function with given fields: ctx
+func (_m *MockAuditStore) GetLastAuditEventHash(ctx context.Context) ([]byte, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastAuditEventHash")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditStore_GetLastAuditEventHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastAuditEventHash'
+type MockAuditStore_GetLastAuditEventHash_Call struct {
+	*mock.Call
+}
+
+// GetLastAuditEventHash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuditStore_Expecter) GetLastAuditEventHash(ctx interface{}) *MockAuditStore_GetLastAuditEventHash_Call {
+	return &MockAuditStore_GetLastAuditEventHash_Call{Call: _e.mock.On("GetLastAuditEventHash", ctx)}
+}
+
+func (_c *MockAuditStore_GetLastAuditEventHash_Call) Run(run func(ctx context.Context)) *MockAuditStore_GetLastAuditEventHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAuditStore_GetLastAuditEventHash_Call) Return(_a0 []byte, _a1 error) *MockAuditStore_GetLastAuditEventHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditStore_GetLastAuditEventHash_Call) RunAndReturn(run func(context.Context) ([]byte, error)) *MockAuditStore_GetLastAuditEventHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, arg
+func (_m *MockAuditStore) ListAuditEvents(ctx context.Context, arg storage.ListAuditEventsParams) ([]storage.AuditEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 []storage.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAuditEventsParams) ([]storage.AuditEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAuditEventsParams) []storage.AuditEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListAuditEventsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditStore_ListAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEvents'
+type MockAuditStore_ListAuditEvents_Call struct {
+	*mock.Call
+}
+
+// ListAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListAuditEventsParams
+func (_e *MockAuditStore_Expecter) ListAuditEvents(ctx interface{}, arg interface{}) *MockAuditStore_ListAuditEvents_Call {
+	return &MockAuditStore_ListAuditEvents_Call{Call: _e.mock.On("ListAuditEvents", ctx, arg)}
+}
+
+func (_c *MockAuditStore_ListAuditEvents_Call) Run(run func(ctx context.Context, arg storage.ListAuditEventsParams)) *MockAuditStore_ListAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListAuditEventsParams))
+	})
+	return _c
+}
+
+func (_c *MockAuditStore_ListAuditEvents_Call) Return(_a0 []storage.AuditEvent, _a1 error) *MockAuditStore_ListAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditStore_ListAuditEvents_Call) RunAndReturn(run func(context.Context, storage.ListAuditEventsParams) ([]storage.AuditEvent, error)) *MockAuditStore_ListAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditEventsAfter provides a mock function with given fields: ctx, arg
+func (_m *MockAuditStore) ListAuditEventsAfter(ctx context.Context, arg storage.ListAuditEventsAfterParams) ([]storage.AuditEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEventsAfter")
+	}
+
+	var r0 []storage.AuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAuditEventsAfterParams) ([]storage.AuditEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAuditEventsAfterParams) []storage.AuditEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListAuditEventsAfterParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditStore_ListAuditEventsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditEventsAfter'
+type MockAuditStore_ListAuditEventsAfter_Call struct {
+	*mock.Call
+}
+
+// ListAuditEventsAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListAuditEventsAfterParams
+func (_e *MockAuditStore_Expecter) ListAuditEventsAfter(ctx interface{}, arg interface{}) *MockAuditStore_ListAuditEventsAfter_Call {
+	return &MockAuditStore_ListAuditEventsAfter_Call{Call: _e.mock.On("ListAuditEventsAfter", ctx, arg)}
+}
+
+func (_c *MockAuditStore_ListAuditEventsAfter_Call) Run(run func(ctx context.Context, arg storage.ListAuditEventsAfterParams)) *MockAuditStore_ListAuditEventsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListAuditEventsAfterParams))
+	})
+	return _c
+}
+
+func (_c *MockAuditStore_ListAuditEventsAfter_Call) Return(_a0 []storage.AuditEvent, _a1 error) *MockAuditStore_ListAuditEventsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditStore_ListAuditEventsAfter_Call) RunAndReturn(run func(context.Context, storage.ListAuditEventsAfterParams) ([]storage.AuditEvent, error)) *MockAuditStore_ListAuditEventsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAuditChain provides a mock function with given fields: ctx
+func (_m *MockAuditStore) LockAuditChain(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAuditChain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditStore_LockAuditChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAuditChain'
+type MockAuditStore_LockAuditChain_Call struct {
+	*mock.Call
+}
+
+// LockAuditChain is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuditStore_Expecter) LockAuditChain(ctx interface{}) *MockAuditStore_LockAuditChain_Call {
+	return &MockAuditStore_LockAuditChain_Call{Call: _e.mock.On("LockAuditChain", ctx)}
+}
+
+func (_c *MockAuditStore_LockAuditChain_Call) Run(run func(ctx context.Context)) *MockAuditStore_LockAuditChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAuditStore_LockAuditChain_Call) Return(_a0 error) *MockAuditStore_LockAuditChain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditStore_LockAuditChain_Call) RunAndReturn(run func(context.Context) error) *MockAuditStore_LockAuditChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditStore creates a new instance of MockAuditStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditStore {
+	mock := &MockAuditStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
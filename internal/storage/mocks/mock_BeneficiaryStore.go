@@ -0,0 +1,385 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockBeneficiaryStore is an autogenerated mock type for the BeneficiaryStore type
+type MockBeneficiaryStore struct {
+	mock.Mock
+}
+
+type MockBeneficiaryStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBeneficiaryStore) EXPECT() *MockBeneficiaryStore_Expecter {
+	return &MockBeneficiaryStore_Expecter{mock: &_m.Mock}
+}
+
+// CreateBeneficiary provides a mock function with given fields: ctx, arg
+func (_m *MockBeneficiaryStore) CreateBeneficiary(ctx context.Context, arg storage.CreateBeneficiaryParams) (storage.Beneficiary, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBeneficiary")
+	}
+
+	var r0 storage.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateBeneficiaryParams) (storage.Beneficiary, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateBeneficiaryParams) storage.Beneficiary); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Beneficiary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreateBeneficiaryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_CreateBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBeneficiary'
+type MockBeneficiaryStore_CreateBeneficiary_Call struct {
+	*mock.Call
+}
+
+// CreateBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreateBeneficiaryParams
+func (_e *MockBeneficiaryStore_Expecter) CreateBeneficiary(ctx interface{}, arg interface{}) *MockBeneficiaryStore_CreateBeneficiary_Call {
+	return &MockBeneficiaryStore_CreateBeneficiary_Call{Call: _e.mock.On("CreateBeneficiary", ctx, arg)}
+}
+
+func (_c *MockBeneficiaryStore_CreateBeneficiary_Call) Run(run func(ctx context.Context, arg storage.CreateBeneficiaryParams)) *MockBeneficiaryStore_CreateBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreateBeneficiaryParams))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_CreateBeneficiary_Call) Return(_a0 storage.Beneficiary, _a1 error) *MockBeneficiaryStore_CreateBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_CreateBeneficiary_Call) RunAndReturn(run func(context.Context, storage.CreateBeneficiaryParams) (storage.Beneficiary, error)) *MockBeneficiaryStore_CreateBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBeneficiary provides a mock function with given fields: ctx, arg
+func (_m *MockBeneficiaryStore) DeleteBeneficiary(ctx context.Context, arg storage.DeleteBeneficiaryParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBeneficiary")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteBeneficiaryParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteBeneficiaryParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DeleteBeneficiaryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_DeleteBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBeneficiary'
+type MockBeneficiaryStore_DeleteBeneficiary_Call struct {
+	*mock.Call
+}
+
+// DeleteBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DeleteBeneficiaryParams
+func (_e *MockBeneficiaryStore_Expecter) DeleteBeneficiary(ctx interface{}, arg interface{}) *MockBeneficiaryStore_DeleteBeneficiary_Call {
+	return &MockBeneficiaryStore_DeleteBeneficiary_Call{Call: _e.mock.On("DeleteBeneficiary", ctx, arg)}
+}
+
+func (_c *MockBeneficiaryStore_DeleteBeneficiary_Call) Run(run func(ctx context.Context, arg storage.DeleteBeneficiaryParams)) *MockBeneficiaryStore_DeleteBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DeleteBeneficiaryParams))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_DeleteBeneficiary_Call) Return(_a0 int64, _a1 error) *MockBeneficiaryStore_DeleteBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_DeleteBeneficiary_Call) RunAndReturn(run func(context.Context, storage.DeleteBeneficiaryParams) (int64, error)) *MockBeneficiaryStore_DeleteBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountByIBAN provides a mock function with given fields: ctx, iban
+func (_m *MockBeneficiaryStore) GetAccountByIBAN(ctx context.Context, iban pgtype.Text) (storage.Account, error) {
+	ret := _m.Called(ctx, iban)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountByIBAN")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) (storage.Account, error)); ok {
+		return rf(ctx, iban)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Text) storage.Account); ok {
+		r0 = rf(ctx, iban)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Text) error); ok {
+		r1 = rf(ctx, iban)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_GetAccountByIBAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountByIBAN'
+type MockBeneficiaryStore_GetAccountByIBAN_Call struct {
+	*mock.Call
+}
+
+// GetAccountByIBAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - iban pgtype.Text
+func (_e *MockBeneficiaryStore_Expecter) GetAccountByIBAN(ctx interface{}, iban interface{}) *MockBeneficiaryStore_GetAccountByIBAN_Call {
+	return &MockBeneficiaryStore_GetAccountByIBAN_Call{Call: _e.mock.On("GetAccountByIBAN", ctx, iban)}
+}
+
+func (_c *MockBeneficiaryStore_GetAccountByIBAN_Call) Run(run func(ctx context.Context, iban pgtype.Text)) *MockBeneficiaryStore_GetAccountByIBAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Text))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_GetAccountByIBAN_Call) Return(_a0 storage.Account, _a1 error) *MockBeneficiaryStore_GetAccountByIBAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_GetAccountByIBAN_Call) RunAndReturn(run func(context.Context, pgtype.Text) (storage.Account, error)) *MockBeneficiaryStore_GetAccountByIBAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBeneficiary provides a mock function with given fields: ctx, arg
+func (_m *MockBeneficiaryStore) GetBeneficiary(ctx context.Context, arg storage.GetBeneficiaryParams) (storage.GetBeneficiaryRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeneficiary")
+	}
+
+	var r0 storage.GetBeneficiaryRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetBeneficiaryParams) (storage.GetBeneficiaryRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetBeneficiaryParams) storage.GetBeneficiaryRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.GetBeneficiaryRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetBeneficiaryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_GetBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBeneficiary'
+type MockBeneficiaryStore_GetBeneficiary_Call struct {
+	*mock.Call
+}
+
+// GetBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetBeneficiaryParams
+func (_e *MockBeneficiaryStore_Expecter) GetBeneficiary(ctx interface{}, arg interface{}) *MockBeneficiaryStore_GetBeneficiary_Call {
+	return &MockBeneficiaryStore_GetBeneficiary_Call{Call: _e.mock.On("GetBeneficiary", ctx, arg)}
+}
+
+func (_c *MockBeneficiaryStore_GetBeneficiary_Call) Run(run func(ctx context.Context, arg storage.GetBeneficiaryParams)) *MockBeneficiaryStore_GetBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetBeneficiaryParams))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_GetBeneficiary_Call) Return(_a0 storage.GetBeneficiaryRow, _a1 error) *MockBeneficiaryStore_GetBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_GetBeneficiary_Call) RunAndReturn(run func(context.Context, storage.GetBeneficiaryParams) (storage.GetBeneficiaryRow, error)) *MockBeneficiaryStore_GetBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBeneficiaries provides a mock function with given fields: ctx, accountID
+func (_m *MockBeneficiaryStore) ListBeneficiaries(ctx context.Context, accountID uuid.UUID) ([]storage.ListBeneficiariesRow, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBeneficiaries")
+	}
+
+	var r0 []storage.ListBeneficiariesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.ListBeneficiariesRow, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.ListBeneficiariesRow); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListBeneficiariesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_ListBeneficiaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBeneficiaries'
+type MockBeneficiaryStore_ListBeneficiaries_Call struct {
+	*mock.Call
+}
+
+// ListBeneficiaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockBeneficiaryStore_Expecter) ListBeneficiaries(ctx interface{}, accountID interface{}) *MockBeneficiaryStore_ListBeneficiaries_Call {
+	return &MockBeneficiaryStore_ListBeneficiaries_Call{Call: _e.mock.On("ListBeneficiaries", ctx, accountID)}
+}
+
+func (_c *MockBeneficiaryStore_ListBeneficiaries_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockBeneficiaryStore_ListBeneficiaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_ListBeneficiaries_Call) Return(_a0 []storage.ListBeneficiariesRow, _a1 error) *MockBeneficiaryStore_ListBeneficiaries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_ListBeneficiaries_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.ListBeneficiariesRow, error)) *MockBeneficiaryStore_ListBeneficiaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBeneficiary provides a mock function with given fields: ctx, arg
+func (_m *MockBeneficiaryStore) UpdateBeneficiary(ctx context.Context, arg storage.UpdateBeneficiaryParams) (storage.Beneficiary, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBeneficiary")
+	}
+
+	var r0 storage.Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpdateBeneficiaryParams) (storage.Beneficiary, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpdateBeneficiaryParams) storage.Beneficiary); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Beneficiary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.UpdateBeneficiaryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBeneficiaryStore_UpdateBeneficiary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBeneficiary'
+type MockBeneficiaryStore_UpdateBeneficiary_Call struct {
+	*mock.Call
+}
+
+// UpdateBeneficiary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpdateBeneficiaryParams
+func (_e *MockBeneficiaryStore_Expecter) UpdateBeneficiary(ctx interface{}, arg interface{}) *MockBeneficiaryStore_UpdateBeneficiary_Call {
+	return &MockBeneficiaryStore_UpdateBeneficiary_Call{Call: _e.mock.On("UpdateBeneficiary", ctx, arg)}
+}
+
+func (_c *MockBeneficiaryStore_UpdateBeneficiary_Call) Run(run func(ctx context.Context, arg storage.UpdateBeneficiaryParams)) *MockBeneficiaryStore_UpdateBeneficiary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpdateBeneficiaryParams))
+	})
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_UpdateBeneficiary_Call) Return(_a0 storage.Beneficiary, _a1 error) *MockBeneficiaryStore_UpdateBeneficiary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBeneficiaryStore_UpdateBeneficiary_Call) RunAndReturn(run func(context.Context, storage.UpdateBeneficiaryParams) (storage.Beneficiary, error)) *MockBeneficiaryStore_UpdateBeneficiary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBeneficiaryStore creates a new instance of MockBeneficiaryStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBeneficiaryStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBeneficiaryStore {
+	mock := &MockBeneficiaryStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,269 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockCustomerStore is an autogenerated mock type for the CustomerStore type
+type MockCustomerStore struct {
+	mock.Mock
+}
+
+type MockCustomerStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCustomerStore) EXPECT() *MockCustomerStore_Expecter {
+	return &MockCustomerStore_Expecter{mock: &_m.Mock}
+}
+
+// CreateCustomer provides a mock function with given fields: ctx, arg
+func (_m *MockCustomerStore) CreateCustomer(ctx context.Context, arg storage.CreateCustomerParams) (storage.Customer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomer")
+	}
+
+	var r0 storage.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateCustomerParams) (storage.Customer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateCustomerParams) storage.Customer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Customer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreateCustomerParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerStore_CreateCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCustomer'
+type MockCustomerStore_CreateCustomer_Call struct {
+	*mock.Call
+}
+
+// CreateCustomer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreateCustomerParams
+func (_e *MockCustomerStore_Expecter) CreateCustomer(ctx interface{}, arg interface{}) *MockCustomerStore_CreateCustomer_Call {
+	return &MockCustomerStore_CreateCustomer_Call{Call: _e.mock.On("CreateCustomer", ctx, arg)}
+}
+
+func (_c *MockCustomerStore_CreateCustomer_Call) Run(run func(ctx context.Context, arg storage.CreateCustomerParams)) *MockCustomerStore_CreateCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreateCustomerParams))
+	})
+	return _c
+}
+
+func (_c *MockCustomerStore_CreateCustomer_Call) Return(_a0 storage.Customer, _a1 error) *MockCustomerStore_CreateCustomer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerStore_CreateCustomer_Call) RunAndReturn(run func(context.Context, storage.CreateCustomerParams) (storage.Customer, error)) *MockCustomerStore_CreateCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomer provides a mock function with given fields: ctx, customerID
+func (_m *MockCustomerStore) GetCustomer(ctx context.Context, customerID uuid.UUID) (storage.Customer, error) {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomer")
+	}
+
+	var r0 storage.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Customer, error)); ok {
+		return rf(ctx, customerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Customer); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		r0 = ret.Get(0).(storage.Customer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerStore_GetCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomer'
+type MockCustomerStore_GetCustomer_Call struct {
+	*mock.Call
+}
+
+// GetCustomer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+func (_e *MockCustomerStore_Expecter) GetCustomer(ctx interface{}, customerID interface{}) *MockCustomerStore_GetCustomer_Call {
+	return &MockCustomerStore_GetCustomer_Call{Call: _e.mock.On("GetCustomer", ctx, customerID)}
+}
+
+func (_c *MockCustomerStore_GetCustomer_Call) Run(run func(ctx context.Context, customerID uuid.UUID)) *MockCustomerStore_GetCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCustomerStore_GetCustomer_Call) Return(_a0 storage.Customer, _a1 error) *MockCustomerStore_GetCustomer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerStore_GetCustomer_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Customer, error)) *MockCustomerStore_GetCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCustomerAccounts provides a mock function with given fields: ctx, customerID
+func (_m *MockCustomerStore) ListCustomerAccounts(ctx context.Context, customerID uuid.UUID) ([]storage.ListCustomerAccountsRow, error) {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCustomerAccounts")
+	}
+
+	var r0 []storage.ListCustomerAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.ListCustomerAccountsRow, error)); ok {
+		return rf(ctx, customerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.ListCustomerAccountsRow); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListCustomerAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerStore_ListCustomerAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCustomerAccounts'
+type MockCustomerStore_ListCustomerAccounts_Call struct {
+	*mock.Call
+}
+
+// ListCustomerAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+func (_e *MockCustomerStore_Expecter) ListCustomerAccounts(ctx interface{}, customerID interface{}) *MockCustomerStore_ListCustomerAccounts_Call {
+	return &MockCustomerStore_ListCustomerAccounts_Call{Call: _e.mock.On("ListCustomerAccounts", ctx, customerID)}
+}
+
+func (_c *MockCustomerStore_ListCustomerAccounts_Call) Run(run func(ctx context.Context, customerID uuid.UUID)) *MockCustomerStore_ListCustomerAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCustomerStore_ListCustomerAccounts_Call) Return(_a0 []storage.ListCustomerAccountsRow, _a1 error) *MockCustomerStore_ListCustomerAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerStore_ListCustomerAccounts_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.ListCustomerAccountsRow, error)) *MockCustomerStore_ListCustomerAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCustomerKYCStatus provides a mock function with given fields: ctx, arg
+func (_m *MockCustomerStore) SetCustomerKYCStatus(ctx context.Context, arg storage.SetCustomerKYCStatusParams) (storage.Customer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCustomerKYCStatus")
+	}
+
+	var r0 storage.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetCustomerKYCStatusParams) (storage.Customer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetCustomerKYCStatusParams) storage.Customer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Customer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetCustomerKYCStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCustomerStore_SetCustomerKYCStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCustomerKYCStatus'
+type MockCustomerStore_SetCustomerKYCStatus_Call struct {
+	*mock.Call
+}
+
+// SetCustomerKYCStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetCustomerKYCStatusParams
+func (_e *MockCustomerStore_Expecter) SetCustomerKYCStatus(ctx interface{}, arg interface{}) *MockCustomerStore_SetCustomerKYCStatus_Call {
+	return &MockCustomerStore_SetCustomerKYCStatus_Call{Call: _e.mock.On("SetCustomerKYCStatus", ctx, arg)}
+}
+
+func (_c *MockCustomerStore_SetCustomerKYCStatus_Call) Run(run func(ctx context.Context, arg storage.SetCustomerKYCStatusParams)) *MockCustomerStore_SetCustomerKYCStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetCustomerKYCStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockCustomerStore_SetCustomerKYCStatus_Call) Return(_a0 storage.Customer, _a1 error) *MockCustomerStore_SetCustomerKYCStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCustomerStore_SetCustomerKYCStatus_Call) RunAndReturn(run func(context.Context, storage.SetCustomerKYCStatusParams) (storage.Customer, error)) *MockCustomerStore_SetCustomerKYCStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCustomerStore creates a new instance of MockCustomerStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCustomerStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCustomerStore {
+	mock := &MockCustomerStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,499 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockFeeStore is an autogenerated mock type for the FeeStore type
+type MockFeeStore struct {
+	mock.Mock
+}
+
+type MockFeeStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFeeStore) EXPECT() *MockFeeStore_Expecter {
+	return &MockFeeStore_Expecter{mock: &_m.Mock}
+}
+
+// AddFee provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) AddFee(ctx context.Context, arg storage.AddFeeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFee")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddFeeParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddFeeParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddFeeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_AddFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFee'
+type MockFeeStore_AddFee_Call struct {
+	*mock.Call
+}
+
+// AddFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddFeeParams
+func (_e *MockFeeStore_Expecter) AddFee(ctx interface{}, arg interface{}) *MockFeeStore_AddFee_Call {
+	return &MockFeeStore_AddFee_Call{Call: _e.mock.On("AddFee", ctx, arg)}
+}
+
+func (_c *MockFeeStore_AddFee_Call) Run(run func(ctx context.Context, arg storage.AddFeeParams)) *MockFeeStore_AddFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddFeeParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_AddFee_Call) Return(_a0 int64, _a1 error) *MockFeeStore_AddFee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_AddFee_Call) RunAndReturn(run func(context.Context, storage.AddFeeParams) (int64, error)) *MockFeeStore_AddFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) AddTransaction(ctx context.Context, arg storage.AddTransactionParams) (storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTransaction")
+	}
+
+	var r0 storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
+type MockFeeStore_AddTransaction_Call struct {
+	*mock.Call
+}
+
+// AddTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddTransactionParams
+func (_e *MockFeeStore_Expecter) AddTransaction(ctx interface{}, arg interface{}) *MockFeeStore_AddTransaction_Call {
+	return &MockFeeStore_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, arg)}
+}
+
+func (_c *MockFeeStore_AddTransaction_Call) Run(run func(ctx context.Context, arg storage.AddTransactionParams)) *MockFeeStore_AddTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_AddTransaction_Call) Return(_a0 storage.Transaction, _a1 error) *MockFeeStore_AddTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_AddTransaction_Call) RunAndReturn(run func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)) *MockFeeStore_AddTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) DeleteFeeSchedule(ctx context.Context, arg storage.DeleteFeeScheduleParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeeSchedule")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteFeeScheduleParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteFeeScheduleParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DeleteFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_DeleteFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeeSchedule'
+type MockFeeStore_DeleteFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DeleteFeeScheduleParams
+func (_e *MockFeeStore_Expecter) DeleteFeeSchedule(ctx interface{}, arg interface{}) *MockFeeStore_DeleteFeeSchedule_Call {
+	return &MockFeeStore_DeleteFeeSchedule_Call{Call: _e.mock.On("DeleteFeeSchedule", ctx, arg)}
+}
+
+func (_c *MockFeeStore_DeleteFeeSchedule_Call) Run(run func(ctx context.Context, arg storage.DeleteFeeScheduleParams)) *MockFeeStore_DeleteFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DeleteFeeScheduleParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_DeleteFeeSchedule_Call) Return(_a0 int64, _a1 error) *MockFeeStore_DeleteFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_DeleteFeeSchedule_Call) RunAndReturn(run func(context.Context, storage.DeleteFeeScheduleParams) (int64, error)) *MockFeeStore_DeleteFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockFeeStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockFeeStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockFeeStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockFeeStore_GetAccount_Call {
+	return &MockFeeStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockFeeStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockFeeStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockFeeStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockFeeStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) GetFeeSchedule(ctx context.Context, arg storage.GetFeeScheduleParams) (storage.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeeSchedule")
+	}
+
+	var r0 storage.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetFeeScheduleParams) (storage.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetFeeScheduleParams) storage.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_GetFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeeSchedule'
+type MockFeeStore_GetFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// GetFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetFeeScheduleParams
+func (_e *MockFeeStore_Expecter) GetFeeSchedule(ctx interface{}, arg interface{}) *MockFeeStore_GetFeeSchedule_Call {
+	return &MockFeeStore_GetFeeSchedule_Call{Call: _e.mock.On("GetFeeSchedule", ctx, arg)}
+}
+
+func (_c *MockFeeStore_GetFeeSchedule_Call) Run(run func(ctx context.Context, arg storage.GetFeeScheduleParams)) *MockFeeStore_GetFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetFeeScheduleParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_GetFeeSchedule_Call) Return(_a0 storage.FeeSchedule, _a1 error) *MockFeeStore_GetFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_GetFeeSchedule_Call) RunAndReturn(run func(context.Context, storage.GetFeeScheduleParams) (storage.FeeSchedule, error)) *MockFeeStore_GetFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFeeSchedules provides a mock function with given fields: ctx, productCode
+func (_m *MockFeeStore) ListFeeSchedules(ctx context.Context, productCode string) ([]storage.FeeSchedule, error) {
+	ret := _m.Called(ctx, productCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFeeSchedules")
+	}
+
+	var r0 []storage.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]storage.FeeSchedule, error)); ok {
+		return rf(ctx, productCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []storage.FeeSchedule); ok {
+		r0 = rf(ctx, productCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.FeeSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_ListFeeSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFeeSchedules'
+type MockFeeStore_ListFeeSchedules_Call struct {
+	*mock.Call
+}
+
+// ListFeeSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+func (_e *MockFeeStore_Expecter) ListFeeSchedules(ctx interface{}, productCode interface{}) *MockFeeStore_ListFeeSchedules_Call {
+	return &MockFeeStore_ListFeeSchedules_Call{Call: _e.mock.On("ListFeeSchedules", ctx, productCode)}
+}
+
+func (_c *MockFeeStore_ListFeeSchedules_Call) Run(run func(ctx context.Context, productCode string)) *MockFeeStore_ListFeeSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_ListFeeSchedules_Call) Return(_a0 []storage.FeeSchedule, _a1 error) *MockFeeStore_ListFeeSchedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_ListFeeSchedules_Call) RunAndReturn(run func(context.Context, string) ([]storage.FeeSchedule, error)) *MockFeeStore_ListFeeSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMaintenanceFeeAccounts provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) ListMaintenanceFeeAccounts(ctx context.Context, arg storage.ListMaintenanceFeeAccountsParams) ([]storage.ListMaintenanceFeeAccountsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMaintenanceFeeAccounts")
+	}
+
+	var r0 []storage.ListMaintenanceFeeAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListMaintenanceFeeAccountsParams) ([]storage.ListMaintenanceFeeAccountsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListMaintenanceFeeAccountsParams) []storage.ListMaintenanceFeeAccountsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListMaintenanceFeeAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListMaintenanceFeeAccountsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_ListMaintenanceFeeAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMaintenanceFeeAccounts'
+type MockFeeStore_ListMaintenanceFeeAccounts_Call struct {
+	*mock.Call
+}
+
+// ListMaintenanceFeeAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListMaintenanceFeeAccountsParams
+func (_e *MockFeeStore_Expecter) ListMaintenanceFeeAccounts(ctx interface{}, arg interface{}) *MockFeeStore_ListMaintenanceFeeAccounts_Call {
+	return &MockFeeStore_ListMaintenanceFeeAccounts_Call{Call: _e.mock.On("ListMaintenanceFeeAccounts", ctx, arg)}
+}
+
+func (_c *MockFeeStore_ListMaintenanceFeeAccounts_Call) Run(run func(ctx context.Context, arg storage.ListMaintenanceFeeAccountsParams)) *MockFeeStore_ListMaintenanceFeeAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListMaintenanceFeeAccountsParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_ListMaintenanceFeeAccounts_Call) Return(_a0 []storage.ListMaintenanceFeeAccountsRow, _a1 error) *MockFeeStore_ListMaintenanceFeeAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_ListMaintenanceFeeAccounts_Call) RunAndReturn(run func(context.Context, storage.ListMaintenanceFeeAccountsParams) ([]storage.ListMaintenanceFeeAccountsRow, error)) *MockFeeStore_ListMaintenanceFeeAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertFeeSchedule provides a mock function with given fields: ctx, arg
+func (_m *MockFeeStore) UpsertFeeSchedule(ctx context.Context, arg storage.UpsertFeeScheduleParams) (storage.FeeSchedule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFeeSchedule")
+	}
+
+	var r0 storage.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertFeeScheduleParams) (storage.FeeSchedule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertFeeScheduleParams) storage.FeeSchedule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.FeeSchedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.UpsertFeeScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeStore_UpsertFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertFeeSchedule'
+type MockFeeStore_UpsertFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// UpsertFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpsertFeeScheduleParams
+func (_e *MockFeeStore_Expecter) UpsertFeeSchedule(ctx interface{}, arg interface{}) *MockFeeStore_UpsertFeeSchedule_Call {
+	return &MockFeeStore_UpsertFeeSchedule_Call{Call: _e.mock.On("UpsertFeeSchedule", ctx, arg)}
+}
+
+func (_c *MockFeeStore_UpsertFeeSchedule_Call) Run(run func(ctx context.Context, arg storage.UpsertFeeScheduleParams)) *MockFeeStore_UpsertFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpsertFeeScheduleParams))
+	})
+	return _c
+}
+
+func (_c *MockFeeStore_UpsertFeeSchedule_Call) Return(_a0 storage.FeeSchedule, _a1 error) *MockFeeStore_UpsertFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeStore_UpsertFeeSchedule_Call) RunAndReturn(run func(context.Context, storage.UpsertFeeScheduleParams) (storage.FeeSchedule, error)) *MockFeeStore_UpsertFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFeeStore creates a new instance of MockFeeStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeStore {
+	mock := &MockFeeStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,497 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHolderStore is an autogenerated mock type for the HolderStore type
+type MockHolderStore struct {
+	mock.Mock
+}
+
+type MockHolderStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHolderStore) EXPECT() *MockHolderStore_Expecter {
+	return &MockHolderStore_Expecter{mock: &_m.Mock}
+}
+
+// CreateTransferApproval provides a mock function with given fields: ctx, arg
+func (_m *MockHolderStore) CreateTransferApproval(ctx context.Context, arg storage.CreateTransferApprovalParams) (storage.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransferApproval")
+	}
+
+	var r0 storage.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateTransferApprovalParams) (storage.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateTransferApprovalParams) storage.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreateTransferApprovalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_CreateTransferApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransferApproval'
+type MockHolderStore_CreateTransferApproval_Call struct {
+	*mock.Call
+}
+
+// CreateTransferApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreateTransferApprovalParams
+func (_e *MockHolderStore_Expecter) CreateTransferApproval(ctx interface{}, arg interface{}) *MockHolderStore_CreateTransferApproval_Call {
+	return &MockHolderStore_CreateTransferApproval_Call{Call: _e.mock.On("CreateTransferApproval", ctx, arg)}
+}
+
+func (_c *MockHolderStore_CreateTransferApproval_Call) Run(run func(ctx context.Context, arg storage.CreateTransferApprovalParams)) *MockHolderStore_CreateTransferApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreateTransferApprovalParams))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_CreateTransferApproval_Call) Return(_a0 storage.TransferApproval, _a1 error) *MockHolderStore_CreateTransferApproval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_CreateTransferApproval_Call) RunAndReturn(run func(context.Context, storage.CreateTransferApprovalParams) (storage.TransferApproval, error)) *MockHolderStore_CreateTransferApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccountHolder provides a mock function with given fields: ctx, arg
+func (_m *MockHolderStore) DeleteAccountHolder(ctx context.Context, arg storage.DeleteAccountHolderParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccountHolder")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteAccountHolderParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteAccountHolderParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DeleteAccountHolderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_DeleteAccountHolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccountHolder'
+type MockHolderStore_DeleteAccountHolder_Call struct {
+	*mock.Call
+}
+
+// DeleteAccountHolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DeleteAccountHolderParams
+func (_e *MockHolderStore_Expecter) DeleteAccountHolder(ctx interface{}, arg interface{}) *MockHolderStore_DeleteAccountHolder_Call {
+	return &MockHolderStore_DeleteAccountHolder_Call{Call: _e.mock.On("DeleteAccountHolder", ctx, arg)}
+}
+
+func (_c *MockHolderStore_DeleteAccountHolder_Call) Run(run func(ctx context.Context, arg storage.DeleteAccountHolderParams)) *MockHolderStore_DeleteAccountHolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DeleteAccountHolderParams))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_DeleteAccountHolder_Call) Return(_a0 int64, _a1 error) *MockHolderStore_DeleteAccountHolder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_DeleteAccountHolder_Call) RunAndReturn(run func(context.Context, storage.DeleteAccountHolderParams) (int64, error)) *MockHolderStore_DeleteAccountHolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockHolderStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockHolderStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockHolderStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockHolderStore_GetAccount_Call {
+	return &MockHolderStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockHolderStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockHolderStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockHolderStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockHolderStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountHolderRole provides a mock function with given fields: ctx, arg
+func (_m *MockHolderStore) GetAccountHolderRole(ctx context.Context, arg storage.GetAccountHolderRoleParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountHolderRole")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetAccountHolderRoleParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetAccountHolderRoleParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetAccountHolderRoleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_GetAccountHolderRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountHolderRole'
+type MockHolderStore_GetAccountHolderRole_Call struct {
+	*mock.Call
+}
+
+// GetAccountHolderRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetAccountHolderRoleParams
+func (_e *MockHolderStore_Expecter) GetAccountHolderRole(ctx interface{}, arg interface{}) *MockHolderStore_GetAccountHolderRole_Call {
+	return &MockHolderStore_GetAccountHolderRole_Call{Call: _e.mock.On("GetAccountHolderRole", ctx, arg)}
+}
+
+func (_c *MockHolderStore_GetAccountHolderRole_Call) Run(run func(ctx context.Context, arg storage.GetAccountHolderRoleParams)) *MockHolderStore_GetAccountHolderRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetAccountHolderRoleParams))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_GetAccountHolderRole_Call) Return(_a0 string, _a1 error) *MockHolderStore_GetAccountHolderRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_GetAccountHolderRole_Call) RunAndReturn(run func(context.Context, storage.GetAccountHolderRoleParams) (string, error)) *MockHolderStore_GetAccountHolderRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomer provides a mock function with given fields: ctx, customerID
+func (_m *MockHolderStore) GetCustomer(ctx context.Context, customerID uuid.UUID) (storage.Customer, error) {
+	ret := _m.Called(ctx, customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomer")
+	}
+
+	var r0 storage.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Customer, error)); ok {
+		return rf(ctx, customerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Customer); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		r0 = ret.Get(0).(storage.Customer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_GetCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomer'
+type MockHolderStore_GetCustomer_Call struct {
+	*mock.Call
+}
+
+// GetCustomer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - customerID uuid.UUID
+func (_e *MockHolderStore_Expecter) GetCustomer(ctx interface{}, customerID interface{}) *MockHolderStore_GetCustomer_Call {
+	return &MockHolderStore_GetCustomer_Call{Call: _e.mock.On("GetCustomer", ctx, customerID)}
+}
+
+func (_c *MockHolderStore_GetCustomer_Call) Run(run func(ctx context.Context, customerID uuid.UUID)) *MockHolderStore_GetCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_GetCustomer_Call) Return(_a0 storage.Customer, _a1 error) *MockHolderStore_GetCustomer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_GetCustomer_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Customer, error)) *MockHolderStore_GetCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccountHolders provides a mock function with given fields: ctx, accountID
+func (_m *MockHolderStore) ListAccountHolders(ctx context.Context, accountID uuid.UUID) ([]storage.AccountHolder, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountHolders")
+	}
+
+	var r0 []storage.AccountHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.AccountHolder, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.AccountHolder); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.AccountHolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_ListAccountHolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountHolders'
+type MockHolderStore_ListAccountHolders_Call struct {
+	*mock.Call
+}
+
+// ListAccountHolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockHolderStore_Expecter) ListAccountHolders(ctx interface{}, accountID interface{}) *MockHolderStore_ListAccountHolders_Call {
+	return &MockHolderStore_ListAccountHolders_Call{Call: _e.mock.On("ListAccountHolders", ctx, accountID)}
+}
+
+func (_c *MockHolderStore_ListAccountHolders_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockHolderStore_ListAccountHolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_ListAccountHolders_Call) Return(_a0 []storage.AccountHolder, _a1 error) *MockHolderStore_ListAccountHolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_ListAccountHolders_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.AccountHolder, error)) *MockHolderStore_ListAccountHolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountApprovalThreshold provides a mock function with given fields: ctx, arg
+func (_m *MockHolderStore) SetAccountApprovalThreshold(ctx context.Context, arg storage.SetAccountApprovalThresholdParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountApprovalThreshold")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountApprovalThresholdParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountApprovalThresholdParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetAccountApprovalThresholdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_SetAccountApprovalThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountApprovalThreshold'
+type MockHolderStore_SetAccountApprovalThreshold_Call struct {
+	*mock.Call
+}
+
+// SetAccountApprovalThreshold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountApprovalThresholdParams
+func (_e *MockHolderStore_Expecter) SetAccountApprovalThreshold(ctx interface{}, arg interface{}) *MockHolderStore_SetAccountApprovalThreshold_Call {
+	return &MockHolderStore_SetAccountApprovalThreshold_Call{Call: _e.mock.On("SetAccountApprovalThreshold", ctx, arg)}
+}
+
+func (_c *MockHolderStore_SetAccountApprovalThreshold_Call) Run(run func(ctx context.Context, arg storage.SetAccountApprovalThresholdParams)) *MockHolderStore_SetAccountApprovalThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountApprovalThresholdParams))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_SetAccountApprovalThreshold_Call) Return(_a0 int64, _a1 error) *MockHolderStore_SetAccountApprovalThreshold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_SetAccountApprovalThreshold_Call) RunAndReturn(run func(context.Context, storage.SetAccountApprovalThresholdParams) (int64, error)) *MockHolderStore_SetAccountApprovalThreshold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertAccountHolder provides a mock function with given fields: ctx, arg
+func (_m *MockHolderStore) UpsertAccountHolder(ctx context.Context, arg storage.UpsertAccountHolderParams) (storage.AccountHolder, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertAccountHolder")
+	}
+
+	var r0 storage.AccountHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertAccountHolderParams) (storage.AccountHolder, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertAccountHolderParams) storage.AccountHolder); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.AccountHolder)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.UpsertAccountHolderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolderStore_UpsertAccountHolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertAccountHolder'
+type MockHolderStore_UpsertAccountHolder_Call struct {
+	*mock.Call
+}
+
+// UpsertAccountHolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpsertAccountHolderParams
+func (_e *MockHolderStore_Expecter) UpsertAccountHolder(ctx interface{}, arg interface{}) *MockHolderStore_UpsertAccountHolder_Call {
+	return &MockHolderStore_UpsertAccountHolder_Call{Call: _e.mock.On("UpsertAccountHolder", ctx, arg)}
+}
+
+func (_c *MockHolderStore_UpsertAccountHolder_Call) Run(run func(ctx context.Context, arg storage.UpsertAccountHolderParams)) *MockHolderStore_UpsertAccountHolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpsertAccountHolderParams))
+	})
+	return _c
+}
+
+func (_c *MockHolderStore_UpsertAccountHolder_Call) Return(_a0 storage.AccountHolder, _a1 error) *MockHolderStore_UpsertAccountHolder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolderStore_UpsertAccountHolder_Call) RunAndReturn(run func(context.Context, storage.UpsertAccountHolderParams) (storage.AccountHolder, error)) *MockHolderStore_UpsertAccountHolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHolderStore creates a new instance of MockHolderStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHolderStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHolderStore {
+	mock := &MockHolderStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
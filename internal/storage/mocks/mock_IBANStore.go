@@ -0,0 +1,143 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockIBANStore is an autogenerated mock type for the IBANStore type
+type MockIBANStore struct {
+	mock.Mock
+}
+
+type MockIBANStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIBANStore) EXPECT() *MockIBANStore_Expecter {
+	return &MockIBANStore_Expecter{mock: &_m.Mock}
+}
+
+// ListAccountsWithoutIBAN provides a mock function with given fields: ctx, limit
+func (_m *MockIBANStore) ListAccountsWithoutIBAN(ctx context.Context, limit int32) ([]storage.Account, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountsWithoutIBAN")
+	}
+
+	var r0 []storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]storage.Account, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []storage.Account); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIBANStore_ListAccountsWithoutIBAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountsWithoutIBAN'
+type MockIBANStore_ListAccountsWithoutIBAN_Call struct {
+	*mock.Call
+}
+
+// ListAccountsWithoutIBAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockIBANStore_Expecter) ListAccountsWithoutIBAN(ctx interface{}, limit interface{}) *MockIBANStore_ListAccountsWithoutIBAN_Call {
+	return &MockIBANStore_ListAccountsWithoutIBAN_Call{Call: _e.mock.On("ListAccountsWithoutIBAN", ctx, limit)}
+}
+
+func (_c *MockIBANStore_ListAccountsWithoutIBAN_Call) Run(run func(ctx context.Context, limit int32)) *MockIBANStore_ListAccountsWithoutIBAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockIBANStore_ListAccountsWithoutIBAN_Call) Return(_a0 []storage.Account, _a1 error) *MockIBANStore_ListAccountsWithoutIBAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIBANStore_ListAccountsWithoutIBAN_Call) RunAndReturn(run func(context.Context, int32) ([]storage.Account, error)) *MockIBANStore_ListAccountsWithoutIBAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountIBAN provides a mock function with given fields: ctx, arg
+func (_m *MockIBANStore) SetAccountIBAN(ctx context.Context, arg storage.SetAccountIBANParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountIBAN")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountIBANParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIBANStore_SetAccountIBAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountIBAN'
+type MockIBANStore_SetAccountIBAN_Call struct {
+	*mock.Call
+}
+
+// SetAccountIBAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountIBANParams
+func (_e *MockIBANStore_Expecter) SetAccountIBAN(ctx interface{}, arg interface{}) *MockIBANStore_SetAccountIBAN_Call {
+	return &MockIBANStore_SetAccountIBAN_Call{Call: _e.mock.On("SetAccountIBAN", ctx, arg)}
+}
+
+func (_c *MockIBANStore_SetAccountIBAN_Call) Run(run func(ctx context.Context, arg storage.SetAccountIBANParams)) *MockIBANStore_SetAccountIBAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountIBANParams))
+	})
+	return _c
+}
+
+func (_c *MockIBANStore_SetAccountIBAN_Call) Return(_a0 error) *MockIBANStore_SetAccountIBAN_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIBANStore_SetAccountIBAN_Call) RunAndReturn(run func(context.Context, storage.SetAccountIBANParams) error) *MockIBANStore_SetAccountIBAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIBANStore creates a new instance of MockIBANStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIBANStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIBANStore {
+	mock := &MockIBANStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,304 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockIdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type MockIdempotencyStore struct {
+	mock.Mock
+}
+
+type MockIdempotencyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyStore) EXPECT() *MockIdempotencyStore_Expecter {
+	return &MockIdempotencyStore_Expecter{mock: &_m.Mock}
+}
+
+// ClaimIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *MockIdempotencyStore) ClaimIdempotencyKey(ctx context.Context, arg storage.ClaimIdempotencyKeyParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimIdempotencyKey")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ClaimIdempotencyKeyParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ClaimIdempotencyKeyParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ClaimIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_ClaimIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimIdempotencyKey'
+type MockIdempotencyStore_ClaimIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// ClaimIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ClaimIdempotencyKeyParams
+func (_e *MockIdempotencyStore_Expecter) ClaimIdempotencyKey(ctx interface{}, arg interface{}) *MockIdempotencyStore_ClaimIdempotencyKey_Call {
+	return &MockIdempotencyStore_ClaimIdempotencyKey_Call{Call: _e.mock.On("ClaimIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockIdempotencyStore_ClaimIdempotencyKey_Call) Run(run func(ctx context.Context, arg storage.ClaimIdempotencyKeyParams)) *MockIdempotencyStore_ClaimIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ClaimIdempotencyKeyParams))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_ClaimIdempotencyKey_Call) Return(_a0 int64, _a1 error) *MockIdempotencyStore_ClaimIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_ClaimIdempotencyKey_Call) RunAndReturn(run func(context.Context, storage.ClaimIdempotencyKeyParams) (int64, error)) *MockIdempotencyStore_ClaimIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredIdempotencyKeys provides a mock function with given fields: ctx, expiredBefore
+func (_m *MockIdempotencyStore) DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore pgtype.Timestamptz) (int64, error) {
+	ret := _m.Called(ctx, expiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredIdempotencyKeys")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Timestamptz) (int64, error)); ok {
+		return rf(ctx, expiredBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Timestamptz) int64); ok {
+		r0 = rf(ctx, expiredBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Timestamptz) error); ok {
+		r1 = rf(ctx, expiredBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredIdempotencyKeys'
+type MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredIdempotencyKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - expiredBefore pgtype.Timestamptz
+func (_e *MockIdempotencyStore_Expecter) DeleteExpiredIdempotencyKeys(ctx interface{}, expiredBefore interface{}) *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call {
+	return &MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call{Call: _e.mock.On("DeleteExpiredIdempotencyKeys", ctx, expiredBefore)}
+}
+
+func (_c *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call) Run(run func(ctx context.Context, expiredBefore pgtype.Timestamptz)) *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Timestamptz))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call) Return(_a0 int64, _a1 error) *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call) RunAndReturn(run func(context.Context, pgtype.Timestamptz) (int64, error)) *MockIdempotencyStore_DeleteExpiredIdempotencyKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *MockIdempotencyStore) DeleteIdempotencyKey(ctx context.Context, arg storage.DeleteIdempotencyKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DeleteIdempotencyKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_DeleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKey'
+type MockIdempotencyStore_DeleteIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DeleteIdempotencyKeyParams
+func (_e *MockIdempotencyStore_Expecter) DeleteIdempotencyKey(ctx interface{}, arg interface{}) *MockIdempotencyStore_DeleteIdempotencyKey_Call {
+	return &MockIdempotencyStore_DeleteIdempotencyKey_Call{Call: _e.mock.On("DeleteIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockIdempotencyStore_DeleteIdempotencyKey_Call) Run(run func(ctx context.Context, arg storage.DeleteIdempotencyKeyParams)) *MockIdempotencyStore_DeleteIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DeleteIdempotencyKeyParams))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteIdempotencyKey_Call) Return(_a0 error) *MockIdempotencyStore_DeleteIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_DeleteIdempotencyKey_Call) RunAndReturn(run func(context.Context, storage.DeleteIdempotencyKeyParams) error) *MockIdempotencyStore_DeleteIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *MockIdempotencyStore) GetIdempotencyKey(ctx context.Context, arg storage.GetIdempotencyKeyParams) (storage.IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 storage.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetIdempotencyKeyParams) (storage.IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetIdempotencyKeyParams) storage.IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyStore_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type MockIdempotencyStore_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetIdempotencyKeyParams
+func (_e *MockIdempotencyStore_Expecter) GetIdempotencyKey(ctx interface{}, arg interface{}) *MockIdempotencyStore_GetIdempotencyKey_Call {
+	return &MockIdempotencyStore_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockIdempotencyStore_GetIdempotencyKey_Call) Run(run func(ctx context.Context, arg storage.GetIdempotencyKeyParams)) *MockIdempotencyStore_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetIdempotencyKeyParams))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_GetIdempotencyKey_Call) Return(_a0 storage.IdempotencyKey, _a1 error) *MockIdempotencyStore_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_GetIdempotencyKey_Call) RunAndReturn(run func(context.Context, storage.GetIdempotencyKeyParams) (storage.IdempotencyKey, error)) *MockIdempotencyStore_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveIdempotencyKeyResponse provides a mock function with given fields: ctx, arg
+func (_m *MockIdempotencyStore) SaveIdempotencyKeyResponse(ctx context.Context, arg storage.SaveIdempotencyKeyResponseParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdempotencyKeyResponse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SaveIdempotencyKeyResponseParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyStore_SaveIdempotencyKeyResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveIdempotencyKeyResponse'
+type MockIdempotencyStore_SaveIdempotencyKeyResponse_Call struct {
+	*mock.Call
+}
+
+// SaveIdempotencyKeyResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SaveIdempotencyKeyResponseParams
+func (_e *MockIdempotencyStore_Expecter) SaveIdempotencyKeyResponse(ctx interface{}, arg interface{}) *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call {
+	return &MockIdempotencyStore_SaveIdempotencyKeyResponse_Call{Call: _e.mock.On("SaveIdempotencyKeyResponse", ctx, arg)}
+}
+
+func (_c *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call) Run(run func(ctx context.Context, arg storage.SaveIdempotencyKeyResponseParams)) *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SaveIdempotencyKeyResponseParams))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call) Return(_a0 error) *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call) RunAndReturn(run func(context.Context, storage.SaveIdempotencyKeyResponseParams) error) *MockIdempotencyStore_SaveIdempotencyKeyResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyStore creates a new instance of MockIdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,495 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockInterestStore is an autogenerated mock type for the InterestStore type
+type MockInterestStore struct {
+	mock.Mock
+}
+
+type MockInterestStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterestStore) EXPECT() *MockInterestStore_Expecter {
+	return &MockInterestStore_Expecter{mock: &_m.Mock}
+}
+
+// AddInterestAccrual provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) AddInterestAccrual(ctx context.Context, arg storage.AddInterestAccrualParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddInterestAccrual")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddInterestAccrualParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddInterestAccrualParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddInterestAccrualParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_AddInterestAccrual_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddInterestAccrual'
+type MockInterestStore_AddInterestAccrual_Call struct {
+	*mock.Call
+}
+
+// AddInterestAccrual is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddInterestAccrualParams
+func (_e *MockInterestStore_Expecter) AddInterestAccrual(ctx interface{}, arg interface{}) *MockInterestStore_AddInterestAccrual_Call {
+	return &MockInterestStore_AddInterestAccrual_Call{Call: _e.mock.On("AddInterestAccrual", ctx, arg)}
+}
+
+func (_c *MockInterestStore_AddInterestAccrual_Call) Run(run func(ctx context.Context, arg storage.AddInterestAccrualParams)) *MockInterestStore_AddInterestAccrual_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddInterestAccrualParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_AddInterestAccrual_Call) Return(_a0 int64, _a1 error) *MockInterestStore_AddInterestAccrual_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_AddInterestAccrual_Call) RunAndReturn(run func(context.Context, storage.AddInterestAccrualParams) (int64, error)) *MockInterestStore_AddInterestAccrual_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) AddTransaction(ctx context.Context, arg storage.AddTransactionParams) (storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTransaction")
+	}
+
+	var r0 storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
+type MockInterestStore_AddTransaction_Call struct {
+	*mock.Call
+}
+
+// AddTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddTransactionParams
+func (_e *MockInterestStore_Expecter) AddTransaction(ctx interface{}, arg interface{}) *MockInterestStore_AddTransaction_Call {
+	return &MockInterestStore_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, arg)}
+}
+
+func (_c *MockInterestStore_AddTransaction_Call) Run(run func(ctx context.Context, arg storage.AddTransactionParams)) *MockInterestStore_AddTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_AddTransaction_Call) Return(_a0 storage.Transaction, _a1 error) *MockInterestStore_AddTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_AddTransaction_Call) RunAndReturn(run func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)) *MockInterestStore_AddTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CapitalizeInterest provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) CapitalizeInterest(ctx context.Context, arg storage.CapitalizeInterestParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CapitalizeInterest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CapitalizeInterestParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInterestStore_CapitalizeInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CapitalizeInterest'
+type MockInterestStore_CapitalizeInterest_Call struct {
+	*mock.Call
+}
+
+// CapitalizeInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CapitalizeInterestParams
+func (_e *MockInterestStore_Expecter) CapitalizeInterest(ctx interface{}, arg interface{}) *MockInterestStore_CapitalizeInterest_Call {
+	return &MockInterestStore_CapitalizeInterest_Call{Call: _e.mock.On("CapitalizeInterest", ctx, arg)}
+}
+
+func (_c *MockInterestStore_CapitalizeInterest_Call) Run(run func(ctx context.Context, arg storage.CapitalizeInterestParams)) *MockInterestStore_CapitalizeInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CapitalizeInterestParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_CapitalizeInterest_Call) Return(_a0 error) *MockInterestStore_CapitalizeInterest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInterestStore_CapitalizeInterest_Call) RunAndReturn(run func(context.Context, storage.CapitalizeInterestParams) error) *MockInterestStore_CapitalizeInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockInterestStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockInterestStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockInterestStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockInterestStore_GetAccount_Call {
+	return &MockInterestStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockInterestStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockInterestStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockInterestStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockInterestStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccruingAccounts provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) ListAccruingAccounts(ctx context.Context, arg storage.ListAccruingAccountsParams) ([]storage.ListAccruingAccountsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccruingAccounts")
+	}
+
+	var r0 []storage.ListAccruingAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAccruingAccountsParams) ([]storage.ListAccruingAccountsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListAccruingAccountsParams) []storage.ListAccruingAccountsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListAccruingAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListAccruingAccountsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_ListAccruingAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccruingAccounts'
+type MockInterestStore_ListAccruingAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccruingAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListAccruingAccountsParams
+func (_e *MockInterestStore_Expecter) ListAccruingAccounts(ctx interface{}, arg interface{}) *MockInterestStore_ListAccruingAccounts_Call {
+	return &MockInterestStore_ListAccruingAccounts_Call{Call: _e.mock.On("ListAccruingAccounts", ctx, arg)}
+}
+
+func (_c *MockInterestStore_ListAccruingAccounts_Call) Run(run func(ctx context.Context, arg storage.ListAccruingAccountsParams)) *MockInterestStore_ListAccruingAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListAccruingAccountsParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_ListAccruingAccounts_Call) Return(_a0 []storage.ListAccruingAccountsRow, _a1 error) *MockInterestStore_ListAccruingAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_ListAccruingAccounts_Call) RunAndReturn(run func(context.Context, storage.ListAccruingAccountsParams) ([]storage.ListAccruingAccountsRow, error)) *MockInterestStore_ListAccruingAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInterestAccruals provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) ListInterestAccruals(ctx context.Context, arg storage.ListInterestAccrualsParams) ([]storage.InterestAccrual, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInterestAccruals")
+	}
+
+	var r0 []storage.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListInterestAccrualsParams) ([]storage.InterestAccrual, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListInterestAccrualsParams) []storage.InterestAccrual); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.InterestAccrual)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListInterestAccrualsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_ListInterestAccruals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInterestAccruals'
+type MockInterestStore_ListInterestAccruals_Call struct {
+	*mock.Call
+}
+
+// ListInterestAccruals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListInterestAccrualsParams
+func (_e *MockInterestStore_Expecter) ListInterestAccruals(ctx interface{}, arg interface{}) *MockInterestStore_ListInterestAccruals_Call {
+	return &MockInterestStore_ListInterestAccruals_Call{Call: _e.mock.On("ListInterestAccruals", ctx, arg)}
+}
+
+func (_c *MockInterestStore_ListInterestAccruals_Call) Run(run func(ctx context.Context, arg storage.ListInterestAccrualsParams)) *MockInterestStore_ListInterestAccruals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListInterestAccrualsParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_ListInterestAccruals_Call) Return(_a0 []storage.InterestAccrual, _a1 error) *MockInterestStore_ListInterestAccruals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_ListInterestAccruals_Call) RunAndReturn(run func(context.Context, storage.ListInterestAccrualsParams) ([]storage.InterestAccrual, error)) *MockInterestStore_ListInterestAccruals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUncapitalizedAccounts provides a mock function with given fields: ctx, before
+func (_m *MockInterestStore) ListUncapitalizedAccounts(ctx context.Context, before pgtype.Date) ([]storage.ListUncapitalizedAccountsRow, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUncapitalizedAccounts")
+	}
+
+	var r0 []storage.ListUncapitalizedAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) ([]storage.ListUncapitalizedAccountsRow, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Date) []storage.ListUncapitalizedAccountsRow); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListUncapitalizedAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Date) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_ListUncapitalizedAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUncapitalizedAccounts'
+type MockInterestStore_ListUncapitalizedAccounts_Call struct {
+	*mock.Call
+}
+
+// ListUncapitalizedAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before pgtype.Date
+func (_e *MockInterestStore_Expecter) ListUncapitalizedAccounts(ctx interface{}, before interface{}) *MockInterestStore_ListUncapitalizedAccounts_Call {
+	return &MockInterestStore_ListUncapitalizedAccounts_Call{Call: _e.mock.On("ListUncapitalizedAccounts", ctx, before)}
+}
+
+func (_c *MockInterestStore_ListUncapitalizedAccounts_Call) Run(run func(ctx context.Context, before pgtype.Date)) *MockInterestStore_ListUncapitalizedAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Date))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_ListUncapitalizedAccounts_Call) Return(_a0 []storage.ListUncapitalizedAccountsRow, _a1 error) *MockInterestStore_ListUncapitalizedAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_ListUncapitalizedAccounts_Call) RunAndReturn(run func(context.Context, pgtype.Date) ([]storage.ListUncapitalizedAccountsRow, error)) *MockInterestStore_ListUncapitalizedAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockUncapitalizedInterest provides a mock function with given fields: ctx, arg
+func (_m *MockInterestStore) LockUncapitalizedInterest(ctx context.Context, arg storage.LockUncapitalizedInterestParams) ([]pgtype.Numeric, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockUncapitalizedInterest")
+	}
+
+	var r0 []pgtype.Numeric
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.LockUncapitalizedInterestParams) ([]pgtype.Numeric, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.LockUncapitalizedInterestParams) []pgtype.Numeric); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pgtype.Numeric)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.LockUncapitalizedInterestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestStore_LockUncapitalizedInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockUncapitalizedInterest'
+type MockInterestStore_LockUncapitalizedInterest_Call struct {
+	*mock.Call
+}
+
+// LockUncapitalizedInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.LockUncapitalizedInterestParams
+func (_e *MockInterestStore_Expecter) LockUncapitalizedInterest(ctx interface{}, arg interface{}) *MockInterestStore_LockUncapitalizedInterest_Call {
+	return &MockInterestStore_LockUncapitalizedInterest_Call{Call: _e.mock.On("LockUncapitalizedInterest", ctx, arg)}
+}
+
+func (_c *MockInterestStore_LockUncapitalizedInterest_Call) Run(run func(ctx context.Context, arg storage.LockUncapitalizedInterestParams)) *MockInterestStore_LockUncapitalizedInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.LockUncapitalizedInterestParams))
+	})
+	return _c
+}
+
+func (_c *MockInterestStore_LockUncapitalizedInterest_Call) Return(_a0 []pgtype.Numeric, _a1 error) *MockInterestStore_LockUncapitalizedInterest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestStore_LockUncapitalizedInterest_Call) RunAndReturn(run func(context.Context, storage.LockUncapitalizedInterestParams) ([]pgtype.Numeric, error)) *MockInterestStore_LockUncapitalizedInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterestStore creates a new instance of MockInterestStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterestStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterestStore {
+	mock := &MockInterestStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
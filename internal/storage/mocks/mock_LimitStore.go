@@ -0,0 +1,314 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockLimitStore is an autogenerated mock type for the LimitStore type
+type MockLimitStore struct {
+	mock.Mock
+}
+
+type MockLimitStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLimitStore) EXPECT() *MockLimitStore_Expecter {
+	return &MockLimitStore_Expecter{mock: &_m.Mock}
+}
+
+// GetAccountLimits provides a mock function with given fields: ctx, accountID
+func (_m *MockLimitStore) GetAccountLimits(ctx context.Context, accountID uuid.UUID) (storage.GetAccountLimitsRow, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountLimits")
+	}
+
+	var r0 storage.GetAccountLimitsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.GetAccountLimitsRow, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.GetAccountLimitsRow); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.GetAccountLimitsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitStore_GetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountLimits'
+type MockLimitStore_GetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// GetAccountLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockLimitStore_Expecter) GetAccountLimits(ctx interface{}, accountID interface{}) *MockLimitStore_GetAccountLimits_Call {
+	return &MockLimitStore_GetAccountLimits_Call{Call: _e.mock.On("GetAccountLimits", ctx, accountID)}
+}
+
+func (_c *MockLimitStore_GetAccountLimits_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockLimitStore_GetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLimitStore_GetAccountLimits_Call) Return(_a0 storage.GetAccountLimitsRow, _a1 error) *MockLimitStore_GetAccountLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitStore_GetAccountLimits_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.GetAccountLimitsRow, error)) *MockLimitStore_GetAccountLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferUsage provides a mock function with given fields: ctx, arg
+func (_m *MockLimitStore) GetTransferUsage(ctx context.Context, arg storage.GetTransferUsageParams) (storage.GetTransferUsageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferUsage")
+	}
+
+	var r0 storage.GetTransferUsageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferUsageParams) (storage.GetTransferUsageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferUsageParams) storage.GetTransferUsageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.GetTransferUsageRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetTransferUsageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitStore_GetTransferUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferUsage'
+type MockLimitStore_GetTransferUsage_Call struct {
+	*mock.Call
+}
+
+// GetTransferUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetTransferUsageParams
+func (_e *MockLimitStore_Expecter) GetTransferUsage(ctx interface{}, arg interface{}) *MockLimitStore_GetTransferUsage_Call {
+	return &MockLimitStore_GetTransferUsage_Call{Call: _e.mock.On("GetTransferUsage", ctx, arg)}
+}
+
+func (_c *MockLimitStore_GetTransferUsage_Call) Run(run func(ctx context.Context, arg storage.GetTransferUsageParams)) *MockLimitStore_GetTransferUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetTransferUsageParams))
+	})
+	return _c
+}
+
+func (_c *MockLimitStore_GetTransferUsage_Call) Return(_a0 storage.GetTransferUsageRow, _a1 error) *MockLimitStore_GetTransferUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitStore_GetTransferUsage_Call) RunAndReturn(run func(context.Context, storage.GetTransferUsageParams) (storage.GetTransferUsageRow, error)) *MockLimitStore_GetTransferUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockLimitStore) HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasAccount")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitStore_HasAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAccount'
+type MockLimitStore_HasAccount_Call struct {
+	*mock.Call
+}
+
+// HasAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockLimitStore_Expecter) HasAccount(ctx interface{}, accountID interface{}) *MockLimitStore_HasAccount_Call {
+	return &MockLimitStore_HasAccount_Call{Call: _e.mock.On("HasAccount", ctx, accountID)}
+}
+
+func (_c *MockLimitStore_HasAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockLimitStore_HasAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLimitStore_HasAccount_Call) Return(_a0 bool, _a1 error) *MockLimitStore_HasAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitStore_HasAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockLimitStore_HasAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountLimits provides a mock function with given fields: ctx, arg
+func (_m *MockLimitStore) SetAccountLimits(ctx context.Context, arg storage.SetAccountLimitsParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountLimits")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountLimitsParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLimitStore_SetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountLimits'
+type MockLimitStore_SetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// SetAccountLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountLimitsParams
+func (_e *MockLimitStore_Expecter) SetAccountLimits(ctx interface{}, arg interface{}) *MockLimitStore_SetAccountLimits_Call {
+	return &MockLimitStore_SetAccountLimits_Call{Call: _e.mock.On("SetAccountLimits", ctx, arg)}
+}
+
+func (_c *MockLimitStore_SetAccountLimits_Call) Run(run func(ctx context.Context, arg storage.SetAccountLimitsParams)) *MockLimitStore_SetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountLimitsParams))
+	})
+	return _c
+}
+
+func (_c *MockLimitStore_SetAccountLimits_Call) Return(_a0 error) *MockLimitStore_SetAccountLimits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimitStore_SetAccountLimits_Call) RunAndReturn(run func(context.Context, storage.SetAccountLimitsParams) error) *MockLimitStore_SetAccountLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLimitTier provides a mock function with given fields: ctx, arg
+func (_m *MockLimitStore) SetLimitTier(ctx context.Context, arg storage.SetLimitTierParams) (storage.LimitTier, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLimitTier")
+	}
+
+	var r0 storage.LimitTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetLimitTierParams) (storage.LimitTier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetLimitTierParams) storage.LimitTier); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.LimitTier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetLimitTierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitStore_SetLimitTier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLimitTier'
+type MockLimitStore_SetLimitTier_Call struct {
+	*mock.Call
+}
+
+// SetLimitTier is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetLimitTierParams
+func (_e *MockLimitStore_Expecter) SetLimitTier(ctx interface{}, arg interface{}) *MockLimitStore_SetLimitTier_Call {
+	return &MockLimitStore_SetLimitTier_Call{Call: _e.mock.On("SetLimitTier", ctx, arg)}
+}
+
+func (_c *MockLimitStore_SetLimitTier_Call) Run(run func(ctx context.Context, arg storage.SetLimitTierParams)) *MockLimitStore_SetLimitTier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetLimitTierParams))
+	})
+	return _c
+}
+
+func (_c *MockLimitStore_SetLimitTier_Call) Return(_a0 storage.LimitTier, _a1 error) *MockLimitStore_SetLimitTier_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitStore_SetLimitTier_Call) RunAndReturn(run func(context.Context, storage.SetLimitTierParams) (storage.LimitTier, error)) *MockLimitStore_SetLimitTier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLimitStore creates a new instance of MockLimitStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLimitStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLimitStore {
+	mock := &MockLimitStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,200 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockOutboxStore is an autogenerated mock type for the OutboxStore type
+type MockOutboxStore struct {
+	mock.Mock
+}
+
+type MockOutboxStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxStore) EXPECT() *MockOutboxStore_Expecter {
+	return &MockOutboxStore_Expecter{mock: &_m.Mock}
+}
+
+// AddOutboxEvent provides a mock function with given fields: ctx, arg
+func (_m *MockOutboxStore) AddOutboxEvent(ctx context.Context, arg storage.AddOutboxEventParams) (storage.Outbox, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOutboxEvent")
+	}
+
+	var r0 storage.Outbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddOutboxEventParams) (storage.Outbox, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddOutboxEventParams) storage.Outbox); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Outbox)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddOutboxEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOutboxStore_AddOutboxEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOutboxEvent'
+type MockOutboxStore_AddOutboxEvent_Call struct {
+	*mock.Call
+}
+
+// AddOutboxEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddOutboxEventParams
+func (_e *MockOutboxStore_Expecter) AddOutboxEvent(ctx interface{}, arg interface{}) *MockOutboxStore_AddOutboxEvent_Call {
+	return &MockOutboxStore_AddOutboxEvent_Call{Call: _e.mock.On("AddOutboxEvent", ctx, arg)}
+}
+
+func (_c *MockOutboxStore_AddOutboxEvent_Call) Run(run func(ctx context.Context, arg storage.AddOutboxEventParams)) *MockOutboxStore_AddOutboxEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddOutboxEventParams))
+	})
+	return _c
+}
+
+func (_c *MockOutboxStore_AddOutboxEvent_Call) Return(_a0 storage.Outbox, _a1 error) *MockOutboxStore_AddOutboxEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOutboxStore_AddOutboxEvent_Call) RunAndReturn(run func(context.Context, storage.AddOutboxEventParams) (storage.Outbox, error)) *MockOutboxStore_AddOutboxEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnpublishedOutboxEvents provides a mock function with given fields: ctx, limit
+func (_m *MockOutboxStore) ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]storage.Outbox, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnpublishedOutboxEvents")
+	}
+
+	var r0 []storage.Outbox
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]storage.Outbox, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []storage.Outbox); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Outbox)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOutboxStore_ListUnpublishedOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnpublishedOutboxEvents'
+type MockOutboxStore_ListUnpublishedOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ListUnpublishedOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int32
+func (_e *MockOutboxStore_Expecter) ListUnpublishedOutboxEvents(ctx interface{}, limit interface{}) *MockOutboxStore_ListUnpublishedOutboxEvents_Call {
+	return &MockOutboxStore_ListUnpublishedOutboxEvents_Call{Call: _e.mock.On("ListUnpublishedOutboxEvents", ctx, limit)}
+}
+
+func (_c *MockOutboxStore_ListUnpublishedOutboxEvents_Call) Run(run func(ctx context.Context, limit int32)) *MockOutboxStore_ListUnpublishedOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockOutboxStore_ListUnpublishedOutboxEvents_Call) Return(_a0 []storage.Outbox, _a1 error) *MockOutboxStore_ListUnpublishedOutboxEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOutboxStore_ListUnpublishedOutboxEvents_Call) RunAndReturn(run func(context.Context, int32) ([]storage.Outbox, error)) *MockOutboxStore_ListUnpublishedOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOutboxEventsPublished provides a mock function with given fields: ctx, arg
+func (_m *MockOutboxStore) MarkOutboxEventsPublished(ctx context.Context, arg storage.MarkOutboxEventsPublishedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxEventsPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.MarkOutboxEventsPublishedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOutboxStore_MarkOutboxEventsPublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOutboxEventsPublished'
+type MockOutboxStore_MarkOutboxEventsPublished_Call struct {
+	*mock.Call
+}
+
+// MarkOutboxEventsPublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.MarkOutboxEventsPublishedParams
+func (_e *MockOutboxStore_Expecter) MarkOutboxEventsPublished(ctx interface{}, arg interface{}) *MockOutboxStore_MarkOutboxEventsPublished_Call {
+	return &MockOutboxStore_MarkOutboxEventsPublished_Call{Call: _e.mock.On("MarkOutboxEventsPublished", ctx, arg)}
+}
+
+func (_c *MockOutboxStore_MarkOutboxEventsPublished_Call) Run(run func(ctx context.Context, arg storage.MarkOutboxEventsPublishedParams)) *MockOutboxStore_MarkOutboxEventsPublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.MarkOutboxEventsPublishedParams))
+	})
+	return _c
+}
+
+func (_c *MockOutboxStore_MarkOutboxEventsPublished_Call) Return(_a0 error) *MockOutboxStore_MarkOutboxEventsPublished_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutboxStore_MarkOutboxEventsPublished_Call) RunAndReturn(run func(context.Context, storage.MarkOutboxEventsPublishedParams) error) *MockOutboxStore_MarkOutboxEventsPublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxStore creates a new instance of MockOutboxStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxStore {
+	mock := &MockOutboxStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,384 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockOverdraftStore is an autogenerated mock type for the OverdraftStore type
+type MockOverdraftStore struct {
+	mock.Mock
+}
+
+type MockOverdraftStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOverdraftStore) EXPECT() *MockOverdraftStore_Expecter {
+	return &MockOverdraftStore_Expecter{mock: &_m.Mock}
+}
+
+// AddOverdraftInterest provides a mock function with given fields: ctx, arg
+func (_m *MockOverdraftStore) AddOverdraftInterest(ctx context.Context, arg storage.AddOverdraftInterestParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOverdraftInterest")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddOverdraftInterestParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddOverdraftInterestParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddOverdraftInterestParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_AddOverdraftInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOverdraftInterest'
+type MockOverdraftStore_AddOverdraftInterest_Call struct {
+	*mock.Call
+}
+
+// AddOverdraftInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddOverdraftInterestParams
+func (_e *MockOverdraftStore_Expecter) AddOverdraftInterest(ctx interface{}, arg interface{}) *MockOverdraftStore_AddOverdraftInterest_Call {
+	return &MockOverdraftStore_AddOverdraftInterest_Call{Call: _e.mock.On("AddOverdraftInterest", ctx, arg)}
+}
+
+func (_c *MockOverdraftStore_AddOverdraftInterest_Call) Run(run func(ctx context.Context, arg storage.AddOverdraftInterestParams)) *MockOverdraftStore_AddOverdraftInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddOverdraftInterestParams))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_AddOverdraftInterest_Call) Return(_a0 int64, _a1 error) *MockOverdraftStore_AddOverdraftInterest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_AddOverdraftInterest_Call) RunAndReturn(run func(context.Context, storage.AddOverdraftInterestParams) (int64, error)) *MockOverdraftStore_AddOverdraftInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockOverdraftStore) AddTransaction(ctx context.Context, arg storage.AddTransactionParams) (storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTransaction")
+	}
+
+	var r0 storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
+type MockOverdraftStore_AddTransaction_Call struct {
+	*mock.Call
+}
+
+// AddTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddTransactionParams
+func (_e *MockOverdraftStore_Expecter) AddTransaction(ctx interface{}, arg interface{}) *MockOverdraftStore_AddTransaction_Call {
+	return &MockOverdraftStore_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, arg)}
+}
+
+func (_c *MockOverdraftStore_AddTransaction_Call) Run(run func(ctx context.Context, arg storage.AddTransactionParams)) *MockOverdraftStore_AddTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_AddTransaction_Call) Return(_a0 storage.Transaction, _a1 error) *MockOverdraftStore_AddTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_AddTransaction_Call) RunAndReturn(run func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)) *MockOverdraftStore_AddTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastOverdraftInterestDay provides a mock function with given fields: ctx
+func (_m *MockOverdraftStore) GetLastOverdraftInterestDay(ctx context.Context) (pgtype.Date, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastOverdraftInterestDay")
+	}
+
+	var r0 pgtype.Date
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (pgtype.Date, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) pgtype.Date); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(pgtype.Date)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_GetLastOverdraftInterestDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastOverdraftInterestDay'
+type MockOverdraftStore_GetLastOverdraftInterestDay_Call struct {
+	*mock.Call
+}
+
+// GetLastOverdraftInterestDay is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOverdraftStore_Expecter) GetLastOverdraftInterestDay(ctx interface{}) *MockOverdraftStore_GetLastOverdraftInterestDay_Call {
+	return &MockOverdraftStore_GetLastOverdraftInterestDay_Call{Call: _e.mock.On("GetLastOverdraftInterestDay", ctx)}
+}
+
+func (_c *MockOverdraftStore_GetLastOverdraftInterestDay_Call) Run(run func(ctx context.Context)) *MockOverdraftStore_GetLastOverdraftInterestDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_GetLastOverdraftInterestDay_Call) Return(_a0 pgtype.Date, _a1 error) *MockOverdraftStore_GetLastOverdraftInterestDay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_GetLastOverdraftInterestDay_Call) RunAndReturn(run func(context.Context) (pgtype.Date, error)) *MockOverdraftStore_GetLastOverdraftInterestDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOverdraftTerms provides a mock function with given fields: ctx, accountID
+func (_m *MockOverdraftStore) GetOverdraftTerms(ctx context.Context, accountID uuid.UUID) (storage.GetOverdraftTermsRow, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdraftTerms")
+	}
+
+	var r0 storage.GetOverdraftTermsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.GetOverdraftTermsRow, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.GetOverdraftTermsRow); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.GetOverdraftTermsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_GetOverdraftTerms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverdraftTerms'
+type MockOverdraftStore_GetOverdraftTerms_Call struct {
+	*mock.Call
+}
+
+// GetOverdraftTerms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockOverdraftStore_Expecter) GetOverdraftTerms(ctx interface{}, accountID interface{}) *MockOverdraftStore_GetOverdraftTerms_Call {
+	return &MockOverdraftStore_GetOverdraftTerms_Call{Call: _e.mock.On("GetOverdraftTerms", ctx, accountID)}
+}
+
+func (_c *MockOverdraftStore_GetOverdraftTerms_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockOverdraftStore_GetOverdraftTerms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_GetOverdraftTerms_Call) Return(_a0 storage.GetOverdraftTermsRow, _a1 error) *MockOverdraftStore_GetOverdraftTerms_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_GetOverdraftTerms_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.GetOverdraftTermsRow, error)) *MockOverdraftStore_GetOverdraftTerms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOverdrawnAccounts provides a mock function with given fields: ctx, arg
+func (_m *MockOverdraftStore) ListOverdrawnAccounts(ctx context.Context, arg storage.ListOverdrawnAccountsParams) ([]storage.ListOverdrawnAccountsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdrawnAccounts")
+	}
+
+	var r0 []storage.ListOverdrawnAccountsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListOverdrawnAccountsParams) ([]storage.ListOverdrawnAccountsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListOverdrawnAccountsParams) []storage.ListOverdrawnAccountsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListOverdrawnAccountsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListOverdrawnAccountsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_ListOverdrawnAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverdrawnAccounts'
+type MockOverdraftStore_ListOverdrawnAccounts_Call struct {
+	*mock.Call
+}
+
+// ListOverdrawnAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListOverdrawnAccountsParams
+func (_e *MockOverdraftStore_Expecter) ListOverdrawnAccounts(ctx interface{}, arg interface{}) *MockOverdraftStore_ListOverdrawnAccounts_Call {
+	return &MockOverdraftStore_ListOverdrawnAccounts_Call{Call: _e.mock.On("ListOverdrawnAccounts", ctx, arg)}
+}
+
+func (_c *MockOverdraftStore_ListOverdrawnAccounts_Call) Run(run func(ctx context.Context, arg storage.ListOverdrawnAccountsParams)) *MockOverdraftStore_ListOverdrawnAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListOverdrawnAccountsParams))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_ListOverdrawnAccounts_Call) Return(_a0 []storage.ListOverdrawnAccountsRow, _a1 error) *MockOverdraftStore_ListOverdrawnAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_ListOverdrawnAccounts_Call) RunAndReturn(run func(context.Context, storage.ListOverdrawnAccountsParams) ([]storage.ListOverdrawnAccountsRow, error)) *MockOverdraftStore_ListOverdrawnAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountOverdraft provides a mock function with given fields: ctx, arg
+func (_m *MockOverdraftStore) SetAccountOverdraft(ctx context.Context, arg storage.SetAccountOverdraftParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountOverdraft")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountOverdraftParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountOverdraftParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetAccountOverdraftParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOverdraftStore_SetAccountOverdraft_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountOverdraft'
+type MockOverdraftStore_SetAccountOverdraft_Call struct {
+	*mock.Call
+}
+
+// SetAccountOverdraft is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountOverdraftParams
+func (_e *MockOverdraftStore_Expecter) SetAccountOverdraft(ctx interface{}, arg interface{}) *MockOverdraftStore_SetAccountOverdraft_Call {
+	return &MockOverdraftStore_SetAccountOverdraft_Call{Call: _e.mock.On("SetAccountOverdraft", ctx, arg)}
+}
+
+func (_c *MockOverdraftStore_SetAccountOverdraft_Call) Run(run func(ctx context.Context, arg storage.SetAccountOverdraftParams)) *MockOverdraftStore_SetAccountOverdraft_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountOverdraftParams))
+	})
+	return _c
+}
+
+func (_c *MockOverdraftStore_SetAccountOverdraft_Call) Return(_a0 int64, _a1 error) *MockOverdraftStore_SetAccountOverdraft_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOverdraftStore_SetAccountOverdraft_Call) RunAndReturn(run func(context.Context, storage.SetAccountOverdraftParams) (int64, error)) *MockOverdraftStore_SetAccountOverdraft_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOverdraftStore creates a new instance of MockOverdraftStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOverdraftStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOverdraftStore {
+	mock := &MockOverdraftStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,593 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPaymentFileStore is an autogenerated mock type for the PaymentFileStore type
+type MockPaymentFileStore struct {
+	mock.Mock
+}
+
+type MockPaymentFileStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentFileStore) EXPECT() *MockPaymentFileStore_Expecter {
+	return &MockPaymentFileStore_Expecter{mock: &_m.Mock}
+}
+
+// AddPayment provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) AddPayment(ctx context.Context, arg storage.AddPaymentParams) (storage.Payment, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPayment")
+	}
+
+	var r0 storage.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddPaymentParams) (storage.Payment, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddPaymentParams) storage.Payment); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Payment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddPaymentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_AddPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPayment'
+type MockPaymentFileStore_AddPayment_Call struct {
+	*mock.Call
+}
+
+// AddPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddPaymentParams
+func (_e *MockPaymentFileStore_Expecter) AddPayment(ctx interface{}, arg interface{}) *MockPaymentFileStore_AddPayment_Call {
+	return &MockPaymentFileStore_AddPayment_Call{Call: _e.mock.On("AddPayment", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_AddPayment_Call) Run(run func(ctx context.Context, arg storage.AddPaymentParams)) *MockPaymentFileStore_AddPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddPaymentParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_AddPayment_Call) Return(_a0 storage.Payment, _a1 error) *MockPaymentFileStore_AddPayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_AddPayment_Call) RunAndReturn(run func(context.Context, storage.AddPaymentParams) (storage.Payment, error)) *MockPaymentFileStore_AddPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePaymentFile provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) CreatePaymentFile(ctx context.Context, arg storage.CreatePaymentFileParams) (storage.PaymentFile, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePaymentFile")
+	}
+
+	var r0 storage.PaymentFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePaymentFileParams) (storage.PaymentFile, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePaymentFileParams) storage.PaymentFile); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.PaymentFile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreatePaymentFileParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_CreatePaymentFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePaymentFile'
+type MockPaymentFileStore_CreatePaymentFile_Call struct {
+	*mock.Call
+}
+
+// CreatePaymentFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreatePaymentFileParams
+func (_e *MockPaymentFileStore_Expecter) CreatePaymentFile(ctx interface{}, arg interface{}) *MockPaymentFileStore_CreatePaymentFile_Call {
+	return &MockPaymentFileStore_CreatePaymentFile_Call{Call: _e.mock.On("CreatePaymentFile", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_CreatePaymentFile_Call) Run(run func(ctx context.Context, arg storage.CreatePaymentFileParams)) *MockPaymentFileStore_CreatePaymentFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreatePaymentFileParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_CreatePaymentFile_Call) Return(_a0 storage.PaymentFile, _a1 error) *MockPaymentFileStore_CreatePaymentFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_CreatePaymentFile_Call) RunAndReturn(run func(context.Context, storage.CreatePaymentFileParams) (storage.PaymentFile, error)) *MockPaymentFileStore_CreatePaymentFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecutePayment provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) ExecutePayment(ctx context.Context, arg storage.ExecutePaymentParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecutePayment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ExecutePaymentParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ExecutePaymentParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ExecutePaymentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_ExecutePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecutePayment'
+type MockPaymentFileStore_ExecutePayment_Call struct {
+	*mock.Call
+}
+
+// ExecutePayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ExecutePaymentParams
+func (_e *MockPaymentFileStore_Expecter) ExecutePayment(ctx interface{}, arg interface{}) *MockPaymentFileStore_ExecutePayment_Call {
+	return &MockPaymentFileStore_ExecutePayment_Call{Call: _e.mock.On("ExecutePayment", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_ExecutePayment_Call) Run(run func(ctx context.Context, arg storage.ExecutePaymentParams)) *MockPaymentFileStore_ExecutePayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ExecutePaymentParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ExecutePayment_Call) Return(_a0 int64, _a1 error) *MockPaymentFileStore_ExecutePayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ExecutePayment_Call) RunAndReturn(run func(context.Context, storage.ExecutePaymentParams) (int64, error)) *MockPaymentFileStore_ExecutePayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockPaymentFileStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockPaymentFileStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockPaymentFileStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockPaymentFileStore_GetAccount_Call {
+	return &MockPaymentFileStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockPaymentFileStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockPaymentFileStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockPaymentFileStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockPaymentFileStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentFile provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) GetPaymentFile(ctx context.Context, arg storage.GetPaymentFileParams) (storage.PaymentFile, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentFile")
+	}
+
+	var r0 storage.PaymentFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPaymentFileParams) (storage.PaymentFile, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPaymentFileParams) storage.PaymentFile); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.PaymentFile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetPaymentFileParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_GetPaymentFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentFile'
+type MockPaymentFileStore_GetPaymentFile_Call struct {
+	*mock.Call
+}
+
+// GetPaymentFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetPaymentFileParams
+func (_e *MockPaymentFileStore_Expecter) GetPaymentFile(ctx interface{}, arg interface{}) *MockPaymentFileStore_GetPaymentFile_Call {
+	return &MockPaymentFileStore_GetPaymentFile_Call{Call: _e.mock.On("GetPaymentFile", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFile_Call) Run(run func(ctx context.Context, arg storage.GetPaymentFileParams)) *MockPaymentFileStore_GetPaymentFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetPaymentFileParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFile_Call) Return(_a0 storage.PaymentFile, _a1 error) *MockPaymentFileStore_GetPaymentFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFile_Call) RunAndReturn(run func(context.Context, storage.GetPaymentFileParams) (storage.PaymentFile, error)) *MockPaymentFileStore_GetPaymentFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPaymentFileByMessageID provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) GetPaymentFileByMessageID(ctx context.Context, arg storage.GetPaymentFileByMessageIDParams) (storage.PaymentFile, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentFileByMessageID")
+	}
+
+	var r0 storage.PaymentFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPaymentFileByMessageIDParams) (storage.PaymentFile, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPaymentFileByMessageIDParams) storage.PaymentFile); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.PaymentFile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetPaymentFileByMessageIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_GetPaymentFileByMessageID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPaymentFileByMessageID'
+type MockPaymentFileStore_GetPaymentFileByMessageID_Call struct {
+	*mock.Call
+}
+
+// GetPaymentFileByMessageID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetPaymentFileByMessageIDParams
+func (_e *MockPaymentFileStore_Expecter) GetPaymentFileByMessageID(ctx interface{}, arg interface{}) *MockPaymentFileStore_GetPaymentFileByMessageID_Call {
+	return &MockPaymentFileStore_GetPaymentFileByMessageID_Call{Call: _e.mock.On("GetPaymentFileByMessageID", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFileByMessageID_Call) Run(run func(ctx context.Context, arg storage.GetPaymentFileByMessageIDParams)) *MockPaymentFileStore_GetPaymentFileByMessageID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetPaymentFileByMessageIDParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFileByMessageID_Call) Return(_a0 storage.PaymentFile, _a1 error) *MockPaymentFileStore_GetPaymentFileByMessageID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_GetPaymentFileByMessageID_Call) RunAndReturn(run func(context.Context, storage.GetPaymentFileByMessageIDParams) (storage.PaymentFile, error)) *MockPaymentFileStore_GetPaymentFileByMessageID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHeldPayments provides a mock function with given fields: ctx, paymentFileID
+func (_m *MockPaymentFileStore) ListHeldPayments(ctx context.Context, paymentFileID uuid.UUID) ([]storage.ListHeldPaymentsRow, error) {
+	ret := _m.Called(ctx, paymentFileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHeldPayments")
+	}
+
+	var r0 []storage.ListHeldPaymentsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.ListHeldPaymentsRow, error)); ok {
+		return rf(ctx, paymentFileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.ListHeldPaymentsRow); ok {
+		r0 = rf(ctx, paymentFileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListHeldPaymentsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, paymentFileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_ListHeldPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHeldPayments'
+type MockPaymentFileStore_ListHeldPayments_Call struct {
+	*mock.Call
+}
+
+// ListHeldPayments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - paymentFileID uuid.UUID
+func (_e *MockPaymentFileStore_Expecter) ListHeldPayments(ctx interface{}, paymentFileID interface{}) *MockPaymentFileStore_ListHeldPayments_Call {
+	return &MockPaymentFileStore_ListHeldPayments_Call{Call: _e.mock.On("ListHeldPayments", ctx, paymentFileID)}
+}
+
+func (_c *MockPaymentFileStore_ListHeldPayments_Call) Run(run func(ctx context.Context, paymentFileID uuid.UUID)) *MockPaymentFileStore_ListHeldPayments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ListHeldPayments_Call) Return(_a0 []storage.ListHeldPaymentsRow, _a1 error) *MockPaymentFileStore_ListHeldPayments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ListHeldPayments_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.ListHeldPaymentsRow, error)) *MockPaymentFileStore_ListHeldPayments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPayments provides a mock function with given fields: ctx, paymentFileID
+func (_m *MockPaymentFileStore) ListPayments(ctx context.Context, paymentFileID uuid.UUID) ([]storage.Payment, error) {
+	ret := _m.Called(ctx, paymentFileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPayments")
+	}
+
+	var r0 []storage.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.Payment, error)); ok {
+		return rf(ctx, paymentFileID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.Payment); ok {
+		r0 = rf(ctx, paymentFileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, paymentFileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentFileStore_ListPayments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPayments'
+type MockPaymentFileStore_ListPayments_Call struct {
+	*mock.Call
+}
+
+// ListPayments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - paymentFileID uuid.UUID
+func (_e *MockPaymentFileStore_Expecter) ListPayments(ctx interface{}, paymentFileID interface{}) *MockPaymentFileStore_ListPayments_Call {
+	return &MockPaymentFileStore_ListPayments_Call{Call: _e.mock.On("ListPayments", ctx, paymentFileID)}
+}
+
+func (_c *MockPaymentFileStore_ListPayments_Call) Run(run func(ctx context.Context, paymentFileID uuid.UUID)) *MockPaymentFileStore_ListPayments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ListPayments_Call) Return(_a0 []storage.Payment, _a1 error) *MockPaymentFileStore_ListPayments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_ListPayments_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.Payment, error)) *MockPaymentFileStore_ListPayments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePayment provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) UpdatePayment(ctx context.Context, arg storage.UpdatePaymentParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpdatePaymentParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentFileStore_UpdatePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePayment'
+type MockPaymentFileStore_UpdatePayment_Call struct {
+	*mock.Call
+}
+
+// UpdatePayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpdatePaymentParams
+func (_e *MockPaymentFileStore_Expecter) UpdatePayment(ctx interface{}, arg interface{}) *MockPaymentFileStore_UpdatePayment_Call {
+	return &MockPaymentFileStore_UpdatePayment_Call{Call: _e.mock.On("UpdatePayment", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_UpdatePayment_Call) Run(run func(ctx context.Context, arg storage.UpdatePaymentParams)) *MockPaymentFileStore_UpdatePayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpdatePaymentParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_UpdatePayment_Call) Return(_a0 error) *MockPaymentFileStore_UpdatePayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_UpdatePayment_Call) RunAndReturn(run func(context.Context, storage.UpdatePaymentParams) error) *MockPaymentFileStore_UpdatePayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePaymentFileStatus provides a mock function with given fields: ctx, arg
+func (_m *MockPaymentFileStore) UpdatePaymentFileStatus(ctx context.Context, arg storage.UpdatePaymentFileStatusParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentFileStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpdatePaymentFileStatusParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentFileStore_UpdatePaymentFileStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePaymentFileStatus'
+type MockPaymentFileStore_UpdatePaymentFileStatus_Call struct {
+	*mock.Call
+}
+
+// UpdatePaymentFileStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpdatePaymentFileStatusParams
+func (_e *MockPaymentFileStore_Expecter) UpdatePaymentFileStatus(ctx interface{}, arg interface{}) *MockPaymentFileStore_UpdatePaymentFileStatus_Call {
+	return &MockPaymentFileStore_UpdatePaymentFileStatus_Call{Call: _e.mock.On("UpdatePaymentFileStatus", ctx, arg)}
+}
+
+func (_c *MockPaymentFileStore_UpdatePaymentFileStatus_Call) Run(run func(ctx context.Context, arg storage.UpdatePaymentFileStatusParams)) *MockPaymentFileStore_UpdatePaymentFileStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpdatePaymentFileStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockPaymentFileStore_UpdatePaymentFileStatus_Call) Return(_a0 error) *MockPaymentFileStore_UpdatePaymentFileStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentFileStore_UpdatePaymentFileStatus_Call) RunAndReturn(run func(context.Context, storage.UpdatePaymentFileStatusParams) error) *MockPaymentFileStore_UpdatePaymentFileStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentFileStore creates a new instance of MockPaymentFileStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentFileStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentFileStore {
+	mock := &MockPaymentFileStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
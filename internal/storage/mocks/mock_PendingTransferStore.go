@@ -0,0 +1,306 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPendingTransferStore is an autogenerated mock type for the PendingTransferStore type
+type MockPendingTransferStore struct {
+	mock.Mock
+}
+
+type MockPendingTransferStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPendingTransferStore) EXPECT() *MockPendingTransferStore_Expecter {
+	return &MockPendingTransferStore_Expecter{mock: &_m.Mock}
+}
+
+// DecidePendingTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockPendingTransferStore) DecidePendingTransfer(ctx context.Context, arg storage.DecidePendingTransferParams) (storage.PendingTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecidePendingTransfer")
+	}
+
+	var r0 storage.PendingTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DecidePendingTransferParams) (storage.PendingTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DecidePendingTransferParams) storage.PendingTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.PendingTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DecidePendingTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingTransferStore_DecidePendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecidePendingTransfer'
+type MockPendingTransferStore_DecidePendingTransfer_Call struct {
+	*mock.Call
+}
+
+// DecidePendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DecidePendingTransferParams
+func (_e *MockPendingTransferStore_Expecter) DecidePendingTransfer(ctx interface{}, arg interface{}) *MockPendingTransferStore_DecidePendingTransfer_Call {
+	return &MockPendingTransferStore_DecidePendingTransfer_Call{Call: _e.mock.On("DecidePendingTransfer", ctx, arg)}
+}
+
+func (_c *MockPendingTransferStore_DecidePendingTransfer_Call) Run(run func(ctx context.Context, arg storage.DecidePendingTransferParams)) *MockPendingTransferStore_DecidePendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DecidePendingTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockPendingTransferStore_DecidePendingTransfer_Call) Return(_a0 storage.PendingTransfer, _a1 error) *MockPendingTransferStore_DecidePendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingTransferStore_DecidePendingTransfer_Call) RunAndReturn(run func(context.Context, storage.DecidePendingTransferParams) (storage.PendingTransfer, error)) *MockPendingTransferStore_DecidePendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransfer provides a mock function with given fields: ctx, pendingTransferID
+func (_m *MockPendingTransferStore) GetPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (storage.PendingTransfer, error) {
+	ret := _m.Called(ctx, pendingTransferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransfer")
+	}
+
+	var r0 storage.PendingTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.PendingTransfer, error)); ok {
+		return rf(ctx, pendingTransferID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.PendingTransfer); ok {
+		r0 = rf(ctx, pendingTransferID)
+	} else {
+		r0 = ret.Get(0).(storage.PendingTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pendingTransferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingTransferStore_GetPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransfer'
+type MockPendingTransferStore_GetPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingTransferID uuid.UUID
+func (_e *MockPendingTransferStore_Expecter) GetPendingTransfer(ctx interface{}, pendingTransferID interface{}) *MockPendingTransferStore_GetPendingTransfer_Call {
+	return &MockPendingTransferStore_GetPendingTransfer_Call{Call: _e.mock.On("GetPendingTransfer", ctx, pendingTransferID)}
+}
+
+func (_c *MockPendingTransferStore_GetPendingTransfer_Call) Run(run func(ctx context.Context, pendingTransferID uuid.UUID)) *MockPendingTransferStore_GetPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPendingTransferStore_GetPendingTransfer_Call) Return(_a0 storage.PendingTransfer, _a1 error) *MockPendingTransferStore_GetPendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingTransferStore_GetPendingTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.PendingTransfer, error)) *MockPendingTransferStore_GetPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPendingTransfers provides a mock function with given fields: ctx, arg
+func (_m *MockPendingTransferStore) ListPendingTransfers(ctx context.Context, arg storage.ListPendingTransfersParams) ([]storage.PendingTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPendingTransfers")
+	}
+
+	var r0 []storage.PendingTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListPendingTransfersParams) ([]storage.PendingTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListPendingTransfersParams) []storage.PendingTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.PendingTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListPendingTransfersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingTransferStore_ListPendingTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingTransfers'
+type MockPendingTransferStore_ListPendingTransfers_Call struct {
+	*mock.Call
+}
+
+// ListPendingTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListPendingTransfersParams
+func (_e *MockPendingTransferStore_Expecter) ListPendingTransfers(ctx interface{}, arg interface{}) *MockPendingTransferStore_ListPendingTransfers_Call {
+	return &MockPendingTransferStore_ListPendingTransfers_Call{Call: _e.mock.On("ListPendingTransfers", ctx, arg)}
+}
+
+func (_c *MockPendingTransferStore_ListPendingTransfers_Call) Run(run func(ctx context.Context, arg storage.ListPendingTransfersParams)) *MockPendingTransferStore_ListPendingTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListPendingTransfersParams))
+	})
+	return _c
+}
+
+func (_c *MockPendingTransferStore_ListPendingTransfers_Call) Return(_a0 []storage.PendingTransfer, _a1 error) *MockPendingTransferStore_ListPendingTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingTransferStore_ListPendingTransfers_Call) RunAndReturn(run func(context.Context, storage.ListPendingTransfersParams) ([]storage.PendingTransfer, error)) *MockPendingTransferStore_ListPendingTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReopenPendingTransfer provides a mock function with given fields: ctx, pendingTransferID
+func (_m *MockPendingTransferStore) ReopenPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) error {
+	ret := _m.Called(ctx, pendingTransferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenPendingTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, pendingTransferID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingTransferStore_ReopenPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReopenPendingTransfer'
+type MockPendingTransferStore_ReopenPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// ReopenPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pendingTransferID uuid.UUID
+func (_e *MockPendingTransferStore_Expecter) ReopenPendingTransfer(ctx interface{}, pendingTransferID interface{}) *MockPendingTransferStore_ReopenPendingTransfer_Call {
+	return &MockPendingTransferStore_ReopenPendingTransfer_Call{Call: _e.mock.On("ReopenPendingTransfer", ctx, pendingTransferID)}
+}
+
+func (_c *MockPendingTransferStore_ReopenPendingTransfer_Call) Run(run func(ctx context.Context, pendingTransferID uuid.UUID)) *MockPendingTransferStore_ReopenPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPendingTransferStore_ReopenPendingTransfer_Call) Return(_a0 error) *MockPendingTransferStore_ReopenPendingTransfer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingTransferStore_ReopenPendingTransfer_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockPendingTransferStore_ReopenPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPendingTransferTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockPendingTransferStore) SetPendingTransferTransaction(ctx context.Context, arg storage.SetPendingTransferTransactionParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPendingTransferTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetPendingTransferTransactionParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingTransferStore_SetPendingTransferTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPendingTransferTransaction'
+type MockPendingTransferStore_SetPendingTransferTransaction_Call struct {
+	*mock.Call
+}
+
+// SetPendingTransferTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetPendingTransferTransactionParams
+func (_e *MockPendingTransferStore_Expecter) SetPendingTransferTransaction(ctx interface{}, arg interface{}) *MockPendingTransferStore_SetPendingTransferTransaction_Call {
+	return &MockPendingTransferStore_SetPendingTransferTransaction_Call{Call: _e.mock.On("SetPendingTransferTransaction", ctx, arg)}
+}
+
+func (_c *MockPendingTransferStore_SetPendingTransferTransaction_Call) Run(run func(ctx context.Context, arg storage.SetPendingTransferTransactionParams)) *MockPendingTransferStore_SetPendingTransferTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetPendingTransferTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockPendingTransferStore_SetPendingTransferTransaction_Call) Return(_a0 error) *MockPendingTransferStore_SetPendingTransferTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingTransferStore_SetPendingTransferTransaction_Call) RunAndReturn(run func(context.Context, storage.SetPendingTransferTransactionParams) error) *MockPendingTransferStore_SetPendingTransferTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPendingTransferStore creates a new instance of MockPendingTransferStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPendingTransferStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPendingTransferStore {
+	mock := &MockPendingTransferStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
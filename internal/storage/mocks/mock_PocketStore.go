@@ -0,0 +1,545 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockPocketStore is an autogenerated mock type for the PocketStore type
+type MockPocketStore struct {
+	mock.Mock
+}
+
+type MockPocketStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPocketStore) EXPECT() *MockPocketStore_Expecter {
+	return &MockPocketStore_Expecter{mock: &_m.Mock}
+}
+
+// AddTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockPocketStore) AddTransaction(ctx context.Context, arg storage.AddTransactionParams) (storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTransaction")
+	}
+
+	var r0 storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddTransactionParams) storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_AddTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTransaction'
+type MockPocketStore_AddTransaction_Call struct {
+	*mock.Call
+}
+
+// AddTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddTransactionParams
+func (_e *MockPocketStore_Expecter) AddTransaction(ctx interface{}, arg interface{}) *MockPocketStore_AddTransaction_Call {
+	return &MockPocketStore_AddTransaction_Call{Call: _e.mock.On("AddTransaction", ctx, arg)}
+}
+
+func (_c *MockPocketStore_AddTransaction_Call) Run(run func(ctx context.Context, arg storage.AddTransactionParams)) *MockPocketStore_AddTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_AddTransaction_Call) Return(_a0 storage.Transaction, _a1 error) *MockPocketStore_AddTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_AddTransaction_Call) RunAndReturn(run func(context.Context, storage.AddTransactionParams) (storage.Transaction, error)) *MockPocketStore_AddTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePocket provides a mock function with given fields: ctx, arg
+func (_m *MockPocketStore) CreatePocket(ctx context.Context, arg storage.CreatePocketParams) (storage.Account, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePocket")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePocketParams) (storage.Account, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePocketParams) storage.Account); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreatePocketParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_CreatePocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePocket'
+type MockPocketStore_CreatePocket_Call struct {
+	*mock.Call
+}
+
+// CreatePocket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreatePocketParams
+func (_e *MockPocketStore_Expecter) CreatePocket(ctx interface{}, arg interface{}) *MockPocketStore_CreatePocket_Call {
+	return &MockPocketStore_CreatePocket_Call{Call: _e.mock.On("CreatePocket", ctx, arg)}
+}
+
+func (_c *MockPocketStore_CreatePocket_Call) Run(run func(ctx context.Context, arg storage.CreatePocketParams)) *MockPocketStore_CreatePocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreatePocketParams))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_CreatePocket_Call) Return(_a0 storage.Account, _a1 error) *MockPocketStore_CreatePocket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_CreatePocket_Call) RunAndReturn(run func(context.Context, storage.CreatePocketParams) (storage.Account, error)) *MockPocketStore_CreatePocket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockPocketStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockPocketStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockPocketStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockPocketStore_GetAccount_Call {
+	return &MockPocketStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockPocketStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockPocketStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockPocketStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockPocketStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountTotalAmount provides a mock function with given fields: ctx, accountID
+func (_m *MockPocketStore) GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountTotalAmount")
+	}
+
+	var r0 pgtype.Numeric
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (pgtype.Numeric, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) pgtype.Numeric); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(pgtype.Numeric)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_GetAccountTotalAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountTotalAmount'
+type MockPocketStore_GetAccountTotalAmount_Call struct {
+	*mock.Call
+}
+
+// GetAccountTotalAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockPocketStore_Expecter) GetAccountTotalAmount(ctx interface{}, accountID interface{}) *MockPocketStore_GetAccountTotalAmount_Call {
+	return &MockPocketStore_GetAccountTotalAmount_Call{Call: _e.mock.On("GetAccountTotalAmount", ctx, accountID)}
+}
+
+func (_c *MockPocketStore_GetAccountTotalAmount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockPocketStore_GetAccountTotalAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_GetAccountTotalAmount_Call) Return(_a0 pgtype.Numeric, _a1 error) *MockPocketStore_GetAccountTotalAmount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_GetAccountTotalAmount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (pgtype.Numeric, error)) *MockPocketStore_GetAccountTotalAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPocket provides a mock function with given fields: ctx, arg
+func (_m *MockPocketStore) GetPocket(ctx context.Context, arg storage.GetPocketParams) (storage.GetPocketRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPocket")
+	}
+
+	var r0 storage.GetPocketRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPocketParams) (storage.GetPocketRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetPocketParams) storage.GetPocketRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.GetPocketRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetPocketParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_GetPocket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPocket'
+type MockPocketStore_GetPocket_Call struct {
+	*mock.Call
+}
+
+// GetPocket is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetPocketParams
+func (_e *MockPocketStore_Expecter) GetPocket(ctx interface{}, arg interface{}) *MockPocketStore_GetPocket_Call {
+	return &MockPocketStore_GetPocket_Call{Call: _e.mock.On("GetPocket", ctx, arg)}
+}
+
+func (_c *MockPocketStore_GetPocket_Call) Run(run func(ctx context.Context, arg storage.GetPocketParams)) *MockPocketStore_GetPocket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetPocketParams))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_GetPocket_Call) Return(_a0 storage.GetPocketRow, _a1 error) *MockPocketStore_GetPocket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_GetPocket_Call) RunAndReturn(run func(context.Context, storage.GetPocketParams) (storage.GetPocketRow, error)) *MockPocketStore_GetPocket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPockets provides a mock function with given fields: ctx, parentAccountID
+func (_m *MockPocketStore) ListPockets(ctx context.Context, parentAccountID uuid.NullUUID) ([]storage.ListPocketsRow, error) {
+	ret := _m.Called(ctx, parentAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPockets")
+	}
+
+	var r0 []storage.ListPocketsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.NullUUID) ([]storage.ListPocketsRow, error)); ok {
+		return rf(ctx, parentAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.NullUUID) []storage.ListPocketsRow); ok {
+		r0 = rf(ctx, parentAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ListPocketsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.NullUUID) error); ok {
+		r1 = rf(ctx, parentAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_ListPockets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPockets'
+type MockPocketStore_ListPockets_Call struct {
+	*mock.Call
+}
+
+// ListPockets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentAccountID uuid.NullUUID
+func (_e *MockPocketStore_Expecter) ListPockets(ctx interface{}, parentAccountID interface{}) *MockPocketStore_ListPockets_Call {
+	return &MockPocketStore_ListPockets_Call{Call: _e.mock.On("ListPockets", ctx, parentAccountID)}
+}
+
+func (_c *MockPocketStore_ListPockets_Call) Run(run func(ctx context.Context, parentAccountID uuid.NullUUID)) *MockPocketStore_ListPockets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.NullUUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_ListPockets_Call) Return(_a0 []storage.ListPocketsRow, _a1 error) *MockPocketStore_ListPockets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_ListPockets_Call) RunAndReturn(run func(context.Context, uuid.NullUUID) ([]storage.ListPocketsRow, error)) *MockPocketStore_ListPockets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAccounts provides a mock function with given fields: ctx, accountIds
+func (_m *MockPocketStore) LockAccounts(ctx context.Context, accountIds []uuid.UUID) error {
+	ret := _m.Called(ctx, accountIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAccounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) error); ok {
+		r0 = rf(ctx, accountIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPocketStore_LockAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAccounts'
+type MockPocketStore_LockAccounts_Call struct {
+	*mock.Call
+}
+
+// LockAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountIds []uuid.UUID
+func (_e *MockPocketStore_Expecter) LockAccounts(ctx interface{}, accountIds interface{}) *MockPocketStore_LockAccounts_Call {
+	return &MockPocketStore_LockAccounts_Call{Call: _e.mock.On("LockAccounts", ctx, accountIds)}
+}
+
+func (_c *MockPocketStore_LockAccounts_Call) Run(run func(ctx context.Context, accountIds []uuid.UUID)) *MockPocketStore_LockAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_LockAccounts_Call) Return(_a0 error) *MockPocketStore_LockAccounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPocketStore_LockAccounts_Call) RunAndReturn(run func(context.Context, []uuid.UUID) error) *MockPocketStore_LockAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NextAccountNumber provides a mock function with given fields: ctx
+func (_m *MockPocketStore) NextAccountNumber(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextAccountNumber")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_NextAccountNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextAccountNumber'
+type MockPocketStore_NextAccountNumber_Call struct {
+	*mock.Call
+}
+
+// NextAccountNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPocketStore_Expecter) NextAccountNumber(ctx interface{}) *MockPocketStore_NextAccountNumber_Call {
+	return &MockPocketStore_NextAccountNumber_Call{Call: _e.mock.On("NextAccountNumber", ctx)}
+}
+
+func (_c *MockPocketStore_NextAccountNumber_Call) Run(run func(ctx context.Context)) *MockPocketStore_NextAccountNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_NextAccountNumber_Call) Return(_a0 int64, _a1 error) *MockPocketStore_NextAccountNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_NextAccountNumber_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockPocketStore_NextAccountNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPocketGoal provides a mock function with given fields: ctx, arg
+func (_m *MockPocketStore) SetPocketGoal(ctx context.Context, arg storage.SetPocketGoalParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPocketGoal")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetPocketGoalParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetPocketGoalParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetPocketGoalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPocketStore_SetPocketGoal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPocketGoal'
+type MockPocketStore_SetPocketGoal_Call struct {
+	*mock.Call
+}
+
+// SetPocketGoal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetPocketGoalParams
+func (_e *MockPocketStore_Expecter) SetPocketGoal(ctx interface{}, arg interface{}) *MockPocketStore_SetPocketGoal_Call {
+	return &MockPocketStore_SetPocketGoal_Call{Call: _e.mock.On("SetPocketGoal", ctx, arg)}
+}
+
+func (_c *MockPocketStore_SetPocketGoal_Call) Run(run func(ctx context.Context, arg storage.SetPocketGoalParams)) *MockPocketStore_SetPocketGoal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetPocketGoalParams))
+	})
+	return _c
+}
+
+func (_c *MockPocketStore_SetPocketGoal_Call) Return(_a0 int64, _a1 error) *MockPocketStore_SetPocketGoal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPocketStore_SetPocketGoal_Call) RunAndReturn(run func(context.Context, storage.SetPocketGoalParams) (int64, error)) *MockPocketStore_SetPocketGoal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPocketStore creates a new instance of MockPocketStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPocketStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPocketStore {
+	mock := &MockPocketStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
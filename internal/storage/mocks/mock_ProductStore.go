@@ -0,0 +1,325 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockProductStore is an autogenerated mock type for the ProductStore type
+type MockProductStore struct {
+	mock.Mock
+}
+
+type MockProductStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProductStore) EXPECT() *MockProductStore_Expecter {
+	return &MockProductStore_Expecter{mock: &_m.Mock}
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockProductStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockProductStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockProductStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockProductStore_GetAccount_Call {
+	return &MockProductStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockProductStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockProductStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockProductStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockProductStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockProductStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountProduct provides a mock function with given fields: ctx, productCode
+func (_m *MockProductStore) GetAccountProduct(ctx context.Context, productCode string) (storage.AccountProduct, error) {
+	ret := _m.Called(ctx, productCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountProduct")
+	}
+
+	var r0 storage.AccountProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (storage.AccountProduct, error)); ok {
+		return rf(ctx, productCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) storage.AccountProduct); ok {
+		r0 = rf(ctx, productCode)
+	} else {
+		r0 = ret.Get(0).(storage.AccountProduct)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductStore_GetAccountProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountProduct'
+type MockProductStore_GetAccountProduct_Call struct {
+	*mock.Call
+}
+
+// GetAccountProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productCode string
+func (_e *MockProductStore_Expecter) GetAccountProduct(ctx interface{}, productCode interface{}) *MockProductStore_GetAccountProduct_Call {
+	return &MockProductStore_GetAccountProduct_Call{Call: _e.mock.On("GetAccountProduct", ctx, productCode)}
+}
+
+func (_c *MockProductStore_GetAccountProduct_Call) Run(run func(ctx context.Context, productCode string)) *MockProductStore_GetAccountProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockProductStore_GetAccountProduct_Call) Return(_a0 storage.AccountProduct, _a1 error) *MockProductStore_GetAccountProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductStore_GetAccountProduct_Call) RunAndReturn(run func(context.Context, string) (storage.AccountProduct, error)) *MockProductStore_GetAccountProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccountProducts provides a mock function with given fields: ctx
+func (_m *MockProductStore) ListAccountProducts(ctx context.Context) ([]storage.AccountProduct, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountProducts")
+	}
+
+	var r0 []storage.AccountProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]storage.AccountProduct, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []storage.AccountProduct); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.AccountProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductStore_ListAccountProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountProducts'
+type MockProductStore_ListAccountProducts_Call struct {
+	*mock.Call
+}
+
+// ListAccountProducts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProductStore_Expecter) ListAccountProducts(ctx interface{}) *MockProductStore_ListAccountProducts_Call {
+	return &MockProductStore_ListAccountProducts_Call{Call: _e.mock.On("ListAccountProducts", ctx)}
+}
+
+func (_c *MockProductStore_ListAccountProducts_Call) Run(run func(ctx context.Context)) *MockProductStore_ListAccountProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockProductStore_ListAccountProducts_Call) Return(_a0 []storage.AccountProduct, _a1 error) *MockProductStore_ListAccountProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductStore_ListAccountProducts_Call) RunAndReturn(run func(context.Context) ([]storage.AccountProduct, error)) *MockProductStore_ListAccountProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountProduct provides a mock function with given fields: ctx, arg
+func (_m *MockProductStore) SetAccountProduct(ctx context.Context, arg storage.SetAccountProductParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountProduct")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountProductParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountProductParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SetAccountProductParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductStore_SetAccountProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountProduct'
+type MockProductStore_SetAccountProduct_Call struct {
+	*mock.Call
+}
+
+// SetAccountProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountProductParams
+func (_e *MockProductStore_Expecter) SetAccountProduct(ctx interface{}, arg interface{}) *MockProductStore_SetAccountProduct_Call {
+	return &MockProductStore_SetAccountProduct_Call{Call: _e.mock.On("SetAccountProduct", ctx, arg)}
+}
+
+func (_c *MockProductStore_SetAccountProduct_Call) Run(run func(ctx context.Context, arg storage.SetAccountProductParams)) *MockProductStore_SetAccountProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountProductParams))
+	})
+	return _c
+}
+
+func (_c *MockProductStore_SetAccountProduct_Call) Return(_a0 int64, _a1 error) *MockProductStore_SetAccountProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductStore_SetAccountProduct_Call) RunAndReturn(run func(context.Context, storage.SetAccountProductParams) (int64, error)) *MockProductStore_SetAccountProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertAccountProduct provides a mock function with given fields: ctx, arg
+func (_m *MockProductStore) UpsertAccountProduct(ctx context.Context, arg storage.UpsertAccountProductParams) (storage.AccountProduct, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertAccountProduct")
+	}
+
+	var r0 storage.AccountProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertAccountProductParams) (storage.AccountProduct, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertAccountProductParams) storage.AccountProduct); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.AccountProduct)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.UpsertAccountProductParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductStore_UpsertAccountProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertAccountProduct'
+type MockProductStore_UpsertAccountProduct_Call struct {
+	*mock.Call
+}
+
+// UpsertAccountProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpsertAccountProductParams
+func (_e *MockProductStore_Expecter) UpsertAccountProduct(ctx interface{}, arg interface{}) *MockProductStore_UpsertAccountProduct_Call {
+	return &MockProductStore_UpsertAccountProduct_Call{Call: _e.mock.On("UpsertAccountProduct", ctx, arg)}
+}
+
+func (_c *MockProductStore_UpsertAccountProduct_Call) Run(run func(ctx context.Context, arg storage.UpsertAccountProductParams)) *MockProductStore_UpsertAccountProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpsertAccountProductParams))
+	})
+	return _c
+}
+
+func (_c *MockProductStore_UpsertAccountProduct_Call) Return(_a0 storage.AccountProduct, _a1 error) *MockProductStore_UpsertAccountProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductStore_UpsertAccountProduct_Call) RunAndReturn(run func(context.Context, storage.UpsertAccountProductParams) (storage.AccountProduct, error)) *MockProductStore_UpsertAccountProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProductStore creates a new instance of MockProductStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProductStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProductStore {
+	mock := &MockProductStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,265 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockRiskStore is an autogenerated mock type for the RiskStore type
+type MockRiskStore struct {
+	mock.Mock
+}
+
+type MockRiskStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRiskStore) EXPECT() *MockRiskStore_Expecter {
+	return &MockRiskStore_Expecter{mock: &_m.Mock}
+}
+
+// CountTransfersSince provides a mock function with given fields: ctx, arg
+func (_m *MockRiskStore) CountTransfersSince(ctx context.Context, arg storage.CountTransfersSinceParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTransfersSince")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CountTransfersSinceParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CountTransfersSinceParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CountTransfersSinceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskStore_CountTransfersSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTransfersSince'
+type MockRiskStore_CountTransfersSince_Call struct {
+	*mock.Call
+}
+
+// CountTransfersSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CountTransfersSinceParams
+func (_e *MockRiskStore_Expecter) CountTransfersSince(ctx interface{}, arg interface{}) *MockRiskStore_CountTransfersSince_Call {
+	return &MockRiskStore_CountTransfersSince_Call{Call: _e.mock.On("CountTransfersSince", ctx, arg)}
+}
+
+func (_c *MockRiskStore_CountTransfersSince_Call) Run(run func(ctx context.Context, arg storage.CountTransfersSinceParams)) *MockRiskStore_CountTransfersSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CountTransfersSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockRiskStore_CountTransfersSince_Call) Return(_a0 int32, _a1 error) *MockRiskStore_CountTransfersSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskStore_CountTransfersSince_Call) RunAndReturn(run func(context.Context, storage.CountTransfersSinceParams) (int32, error)) *MockRiskStore_CountTransfersSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePendingTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockRiskStore) CreatePendingTransfer(ctx context.Context, arg storage.CreatePendingTransferParams) (storage.PendingTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePendingTransfer")
+	}
+
+	var r0 storage.PendingTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePendingTransferParams) (storage.PendingTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreatePendingTransferParams) storage.PendingTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.PendingTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreatePendingTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskStore_CreatePendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePendingTransfer'
+type MockRiskStore_CreatePendingTransfer_Call struct {
+	*mock.Call
+}
+
+// CreatePendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreatePendingTransferParams
+func (_e *MockRiskStore_Expecter) CreatePendingTransfer(ctx interface{}, arg interface{}) *MockRiskStore_CreatePendingTransfer_Call {
+	return &MockRiskStore_CreatePendingTransfer_Call{Call: _e.mock.On("CreatePendingTransfer", ctx, arg)}
+}
+
+func (_c *MockRiskStore_CreatePendingTransfer_Call) Run(run func(ctx context.Context, arg storage.CreatePendingTransferParams)) *MockRiskStore_CreatePendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreatePendingTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockRiskStore_CreatePendingTransfer_Call) Return(_a0 storage.PendingTransfer, _a1 error) *MockRiskStore_CreatePendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskStore_CreatePendingTransfer_Call) RunAndReturn(run func(context.Context, storage.CreatePendingTransferParams) (storage.PendingTransfer, error)) *MockRiskStore_CreatePendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferAverage provides a mock function with given fields: ctx, arg
+func (_m *MockRiskStore) GetTransferAverage(ctx context.Context, arg storage.GetTransferAverageParams) (storage.GetTransferAverageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferAverage")
+	}
+
+	var r0 storage.GetTransferAverageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferAverageParams) (storage.GetTransferAverageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferAverageParams) storage.GetTransferAverageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.GetTransferAverageRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetTransferAverageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskStore_GetTransferAverage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferAverage'
+type MockRiskStore_GetTransferAverage_Call struct {
+	*mock.Call
+}
+
+// GetTransferAverage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetTransferAverageParams
+func (_e *MockRiskStore_Expecter) GetTransferAverage(ctx interface{}, arg interface{}) *MockRiskStore_GetTransferAverage_Call {
+	return &MockRiskStore_GetTransferAverage_Call{Call: _e.mock.On("GetTransferAverage", ctx, arg)}
+}
+
+func (_c *MockRiskStore_GetTransferAverage_Call) Run(run func(ctx context.Context, arg storage.GetTransferAverageParams)) *MockRiskStore_GetTransferAverage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetTransferAverageParams))
+	})
+	return _c
+}
+
+func (_c *MockRiskStore_GetTransferAverage_Call) Return(_a0 storage.GetTransferAverageRow, _a1 error) *MockRiskStore_GetTransferAverage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskStore_GetTransferAverage_Call) RunAndReturn(run func(context.Context, storage.GetTransferAverageParams) (storage.GetTransferAverageRow, error)) *MockRiskStore_GetTransferAverage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasTransferredTo provides a mock function with given fields: ctx, arg
+func (_m *MockRiskStore) HasTransferredTo(ctx context.Context, arg storage.HasTransferredToParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasTransferredTo")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.HasTransferredToParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.HasTransferredToParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.HasTransferredToParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRiskStore_HasTransferredTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasTransferredTo'
+type MockRiskStore_HasTransferredTo_Call struct {
+	*mock.Call
+}
+
+// HasTransferredTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.HasTransferredToParams
+func (_e *MockRiskStore_Expecter) HasTransferredTo(ctx interface{}, arg interface{}) *MockRiskStore_HasTransferredTo_Call {
+	return &MockRiskStore_HasTransferredTo_Call{Call: _e.mock.On("HasTransferredTo", ctx, arg)}
+}
+
+func (_c *MockRiskStore_HasTransferredTo_Call) Run(run func(ctx context.Context, arg storage.HasTransferredToParams)) *MockRiskStore_HasTransferredTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.HasTransferredToParams))
+	})
+	return _c
+}
+
+func (_c *MockRiskStore_HasTransferredTo_Call) Return(_a0 bool, _a1 error) *MockRiskStore_HasTransferredTo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRiskStore_HasTransferredTo_Call) RunAndReturn(run func(context.Context, storage.HasTransferredToParams) (bool, error)) *MockRiskStore_HasTransferredTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRiskStore creates a new instance of MockRiskStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRiskStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRiskStore {
+	mock := &MockRiskStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
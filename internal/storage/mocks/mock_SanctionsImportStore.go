@@ -0,0 +1,188 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockSanctionsImportStore is an autogenerated mock type for the SanctionsImportStore type
+type MockSanctionsImportStore struct {
+	mock.Mock
+}
+
+type MockSanctionsImportStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSanctionsImportStore) EXPECT() *MockSanctionsImportStore_Expecter {
+	return &MockSanctionsImportStore_Expecter{mock: &_m.Mock}
+}
+
+// AddSanctionsEntries provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsImportStore) AddSanctionsEntries(ctx context.Context, arg []storage.AddSanctionsEntriesParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSanctionsEntries")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []storage.AddSanctionsEntriesParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []storage.AddSanctionsEntriesParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []storage.AddSanctionsEntriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsImportStore_AddSanctionsEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSanctionsEntries'
+type MockSanctionsImportStore_AddSanctionsEntries_Call struct {
+	*mock.Call
+}
+
+// AddSanctionsEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg []storage.AddSanctionsEntriesParams
+func (_e *MockSanctionsImportStore_Expecter) AddSanctionsEntries(ctx interface{}, arg interface{}) *MockSanctionsImportStore_AddSanctionsEntries_Call {
+	return &MockSanctionsImportStore_AddSanctionsEntries_Call{Call: _e.mock.On("AddSanctionsEntries", ctx, arg)}
+}
+
+func (_c *MockSanctionsImportStore_AddSanctionsEntries_Call) Run(run func(ctx context.Context, arg []storage.AddSanctionsEntriesParams)) *MockSanctionsImportStore_AddSanctionsEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]storage.AddSanctionsEntriesParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_AddSanctionsEntries_Call) Return(_a0 int64, _a1 error) *MockSanctionsImportStore_AddSanctionsEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_AddSanctionsEntries_Call) RunAndReturn(run func(context.Context, []storage.AddSanctionsEntriesParams) (int64, error)) *MockSanctionsImportStore_AddSanctionsEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSanctionsEntries provides a mock function with given fields: ctx, list
+func (_m *MockSanctionsImportStore) DeleteSanctionsEntries(ctx context.Context, list string) error {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSanctionsEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSanctionsImportStore_DeleteSanctionsEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSanctionsEntries'
+type MockSanctionsImportStore_DeleteSanctionsEntries_Call struct {
+	*mock.Call
+}
+
+// DeleteSanctionsEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - list string
+func (_e *MockSanctionsImportStore_Expecter) DeleteSanctionsEntries(ctx interface{}, list interface{}) *MockSanctionsImportStore_DeleteSanctionsEntries_Call {
+	return &MockSanctionsImportStore_DeleteSanctionsEntries_Call{Call: _e.mock.On("DeleteSanctionsEntries", ctx, list)}
+}
+
+func (_c *MockSanctionsImportStore_DeleteSanctionsEntries_Call) Run(run func(ctx context.Context, list string)) *MockSanctionsImportStore_DeleteSanctionsEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_DeleteSanctionsEntries_Call) Return(_a0 error) *MockSanctionsImportStore_DeleteSanctionsEntries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_DeleteSanctionsEntries_Call) RunAndReturn(run func(context.Context, string) error) *MockSanctionsImportStore_DeleteSanctionsEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertSanctionsList provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsImportStore) UpsertSanctionsList(ctx context.Context, arg storage.UpsertSanctionsListParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSanctionsList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpsertSanctionsListParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSanctionsImportStore_UpsertSanctionsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSanctionsList'
+type MockSanctionsImportStore_UpsertSanctionsList_Call struct {
+	*mock.Call
+}
+
+// UpsertSanctionsList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpsertSanctionsListParams
+func (_e *MockSanctionsImportStore_Expecter) UpsertSanctionsList(ctx interface{}, arg interface{}) *MockSanctionsImportStore_UpsertSanctionsList_Call {
+	return &MockSanctionsImportStore_UpsertSanctionsList_Call{Call: _e.mock.On("UpsertSanctionsList", ctx, arg)}
+}
+
+func (_c *MockSanctionsImportStore_UpsertSanctionsList_Call) Run(run func(ctx context.Context, arg storage.UpsertSanctionsListParams)) *MockSanctionsImportStore_UpsertSanctionsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpsertSanctionsListParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_UpsertSanctionsList_Call) Return(_a0 error) *MockSanctionsImportStore_UpsertSanctionsList_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSanctionsImportStore_UpsertSanctionsList_Call) RunAndReturn(run func(context.Context, storage.UpsertSanctionsListParams) error) *MockSanctionsImportStore_UpsertSanctionsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSanctionsImportStore creates a new instance of MockSanctionsImportStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSanctionsImportStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSanctionsImportStore {
+	mock := &MockSanctionsImportStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,487 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSanctionsStore is an autogenerated mock type for the SanctionsStore type
+type MockSanctionsStore struct {
+	mock.Mock
+}
+
+type MockSanctionsStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSanctionsStore) EXPECT() *MockSanctionsStore_Expecter {
+	return &MockSanctionsStore_Expecter{mock: &_m.Mock}
+}
+
+// AddSanctionsScreening provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsStore) AddSanctionsScreening(ctx context.Context, arg storage.AddSanctionsScreeningParams) (storage.SanctionsScreening, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSanctionsScreening")
+	}
+
+	var r0 storage.SanctionsScreening
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddSanctionsScreeningParams) (storage.SanctionsScreening, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddSanctionsScreeningParams) storage.SanctionsScreening); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.SanctionsScreening)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.AddSanctionsScreeningParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_AddSanctionsScreening_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSanctionsScreening'
+type MockSanctionsStore_AddSanctionsScreening_Call struct {
+	*mock.Call
+}
+
+// AddSanctionsScreening is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddSanctionsScreeningParams
+func (_e *MockSanctionsStore_Expecter) AddSanctionsScreening(ctx interface{}, arg interface{}) *MockSanctionsStore_AddSanctionsScreening_Call {
+	return &MockSanctionsStore_AddSanctionsScreening_Call{Call: _e.mock.On("AddSanctionsScreening", ctx, arg)}
+}
+
+func (_c *MockSanctionsStore_AddSanctionsScreening_Call) Run(run func(ctx context.Context, arg storage.AddSanctionsScreeningParams)) *MockSanctionsStore_AddSanctionsScreening_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddSanctionsScreeningParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_AddSanctionsScreening_Call) Return(_a0 storage.SanctionsScreening, _a1 error) *MockSanctionsStore_AddSanctionsScreening_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_AddSanctionsScreening_Call) RunAndReturn(run func(context.Context, storage.AddSanctionsScreeningParams) (storage.SanctionsScreening, error)) *MockSanctionsStore_AddSanctionsScreening_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockSanctionsStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockSanctionsStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockSanctionsStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockSanctionsStore_GetAccount_Call {
+	return &MockSanctionsStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockSanctionsStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockSanctionsStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockSanctionsStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockSanctionsStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSanctionsScreening provides a mock function with given fields: ctx, screeningID
+func (_m *MockSanctionsStore) GetSanctionsScreening(ctx context.Context, screeningID uuid.UUID) (storage.SanctionsScreening, error) {
+	ret := _m.Called(ctx, screeningID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSanctionsScreening")
+	}
+
+	var r0 storage.SanctionsScreening
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.SanctionsScreening, error)); ok {
+		return rf(ctx, screeningID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.SanctionsScreening); ok {
+		r0 = rf(ctx, screeningID)
+	} else {
+		r0 = ret.Get(0).(storage.SanctionsScreening)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, screeningID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_GetSanctionsScreening_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSanctionsScreening'
+type MockSanctionsStore_GetSanctionsScreening_Call struct {
+	*mock.Call
+}
+
+// GetSanctionsScreening is a helper method to define mock.On call
+//   - ctx context.Context
+//   - screeningID uuid.UUID
+func (_e *MockSanctionsStore_Expecter) GetSanctionsScreening(ctx interface{}, screeningID interface{}) *MockSanctionsStore_GetSanctionsScreening_Call {
+	return &MockSanctionsStore_GetSanctionsScreening_Call{Call: _e.mock.On("GetSanctionsScreening", ctx, screeningID)}
+}
+
+func (_c *MockSanctionsStore_GetSanctionsScreening_Call) Run(run func(ctx context.Context, screeningID uuid.UUID)) *MockSanctionsStore_GetSanctionsScreening_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetSanctionsScreening_Call) Return(_a0 storage.SanctionsScreening, _a1 error) *MockSanctionsStore_GetSanctionsScreening_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetSanctionsScreening_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.SanctionsScreening, error)) *MockSanctionsStore_GetSanctionsScreening_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSanctionsVersion provides a mock function with given fields: ctx
+func (_m *MockSanctionsStore) GetSanctionsVersion(ctx context.Context) (storage.GetSanctionsVersionRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSanctionsVersion")
+	}
+
+	var r0 storage.GetSanctionsVersionRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (storage.GetSanctionsVersionRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) storage.GetSanctionsVersionRow); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(storage.GetSanctionsVersionRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_GetSanctionsVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSanctionsVersion'
+type MockSanctionsStore_GetSanctionsVersion_Call struct {
+	*mock.Call
+}
+
+// GetSanctionsVersion is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSanctionsStore_Expecter) GetSanctionsVersion(ctx interface{}) *MockSanctionsStore_GetSanctionsVersion_Call {
+	return &MockSanctionsStore_GetSanctionsVersion_Call{Call: _e.mock.On("GetSanctionsVersion", ctx)}
+}
+
+func (_c *MockSanctionsStore_GetSanctionsVersion_Call) Run(run func(ctx context.Context)) *MockSanctionsStore_GetSanctionsVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetSanctionsVersion_Call) Return(_a0 storage.GetSanctionsVersionRow, _a1 error) *MockSanctionsStore_GetSanctionsVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_GetSanctionsVersion_Call) RunAndReturn(run func(context.Context) (storage.GetSanctionsVersionRow, error)) *MockSanctionsStore_GetSanctionsVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSanctionsEntries provides a mock function with given fields: ctx
+func (_m *MockSanctionsStore) ListSanctionsEntries(ctx context.Context) ([]storage.SanctionsEntry, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSanctionsEntries")
+	}
+
+	var r0 []storage.SanctionsEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]storage.SanctionsEntry, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []storage.SanctionsEntry); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.SanctionsEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_ListSanctionsEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSanctionsEntries'
+type MockSanctionsStore_ListSanctionsEntries_Call struct {
+	*mock.Call
+}
+
+// ListSanctionsEntries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSanctionsStore_Expecter) ListSanctionsEntries(ctx interface{}) *MockSanctionsStore_ListSanctionsEntries_Call {
+	return &MockSanctionsStore_ListSanctionsEntries_Call{Call: _e.mock.On("ListSanctionsEntries", ctx)}
+}
+
+func (_c *MockSanctionsStore_ListSanctionsEntries_Call) Run(run func(ctx context.Context)) *MockSanctionsStore_ListSanctionsEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_ListSanctionsEntries_Call) Return(_a0 []storage.SanctionsEntry, _a1 error) *MockSanctionsStore_ListSanctionsEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_ListSanctionsEntries_Call) RunAndReturn(run func(context.Context) ([]storage.SanctionsEntry, error)) *MockSanctionsStore_ListSanctionsEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSanctionsScreenings provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsStore) ListSanctionsScreenings(ctx context.Context, arg storage.ListSanctionsScreeningsParams) ([]storage.SanctionsScreening, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSanctionsScreenings")
+	}
+
+	var r0 []storage.SanctionsScreening
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListSanctionsScreeningsParams) ([]storage.SanctionsScreening, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListSanctionsScreeningsParams) []storage.SanctionsScreening); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.SanctionsScreening)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListSanctionsScreeningsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_ListSanctionsScreenings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSanctionsScreenings'
+type MockSanctionsStore_ListSanctionsScreenings_Call struct {
+	*mock.Call
+}
+
+// ListSanctionsScreenings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListSanctionsScreeningsParams
+func (_e *MockSanctionsStore_Expecter) ListSanctionsScreenings(ctx interface{}, arg interface{}) *MockSanctionsStore_ListSanctionsScreenings_Call {
+	return &MockSanctionsStore_ListSanctionsScreenings_Call{Call: _e.mock.On("ListSanctionsScreenings", ctx, arg)}
+}
+
+func (_c *MockSanctionsStore_ListSanctionsScreenings_Call) Run(run func(ctx context.Context, arg storage.ListSanctionsScreeningsParams)) *MockSanctionsStore_ListSanctionsScreenings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListSanctionsScreeningsParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_ListSanctionsScreenings_Call) Return(_a0 []storage.SanctionsScreening, _a1 error) *MockSanctionsStore_ListSanctionsScreenings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_ListSanctionsScreenings_Call) RunAndReturn(run func(context.Context, storage.ListSanctionsScreeningsParams) ([]storage.SanctionsScreening, error)) *MockSanctionsStore_ListSanctionsScreenings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveSanctionsScreening provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsStore) ResolveSanctionsScreening(ctx context.Context, arg storage.ResolveSanctionsScreeningParams) (storage.SanctionsScreening, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveSanctionsScreening")
+	}
+
+	var r0 storage.SanctionsScreening
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ResolveSanctionsScreeningParams) (storage.SanctionsScreening, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ResolveSanctionsScreeningParams) storage.SanctionsScreening); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.SanctionsScreening)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ResolveSanctionsScreeningParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSanctionsStore_ResolveSanctionsScreening_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveSanctionsScreening'
+type MockSanctionsStore_ResolveSanctionsScreening_Call struct {
+	*mock.Call
+}
+
+// ResolveSanctionsScreening is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ResolveSanctionsScreeningParams
+func (_e *MockSanctionsStore_Expecter) ResolveSanctionsScreening(ctx interface{}, arg interface{}) *MockSanctionsStore_ResolveSanctionsScreening_Call {
+	return &MockSanctionsStore_ResolveSanctionsScreening_Call{Call: _e.mock.On("ResolveSanctionsScreening", ctx, arg)}
+}
+
+func (_c *MockSanctionsStore_ResolveSanctionsScreening_Call) Run(run func(ctx context.Context, arg storage.ResolveSanctionsScreeningParams)) *MockSanctionsStore_ResolveSanctionsScreening_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ResolveSanctionsScreeningParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_ResolveSanctionsScreening_Call) Return(_a0 storage.SanctionsScreening, _a1 error) *MockSanctionsStore_ResolveSanctionsScreening_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSanctionsStore_ResolveSanctionsScreening_Call) RunAndReturn(run func(context.Context, storage.ResolveSanctionsScreeningParams) (storage.SanctionsScreening, error)) *MockSanctionsStore_ResolveSanctionsScreening_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountScreeningStatus provides a mock function with given fields: ctx, arg
+func (_m *MockSanctionsStore) SetAccountScreeningStatus(ctx context.Context, arg storage.SetAccountScreeningStatusParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountScreeningStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetAccountScreeningStatusParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSanctionsStore_SetAccountScreeningStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountScreeningStatus'
+type MockSanctionsStore_SetAccountScreeningStatus_Call struct {
+	*mock.Call
+}
+
+// SetAccountScreeningStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetAccountScreeningStatusParams
+func (_e *MockSanctionsStore_Expecter) SetAccountScreeningStatus(ctx interface{}, arg interface{}) *MockSanctionsStore_SetAccountScreeningStatus_Call {
+	return &MockSanctionsStore_SetAccountScreeningStatus_Call{Call: _e.mock.On("SetAccountScreeningStatus", ctx, arg)}
+}
+
+func (_c *MockSanctionsStore_SetAccountScreeningStatus_Call) Run(run func(ctx context.Context, arg storage.SetAccountScreeningStatusParams)) *MockSanctionsStore_SetAccountScreeningStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetAccountScreeningStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockSanctionsStore_SetAccountScreeningStatus_Call) Return(_a0 error) *MockSanctionsStore_SetAccountScreeningStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSanctionsStore_SetAccountScreeningStatus_Call) RunAndReturn(run func(context.Context, storage.SetAccountScreeningStatusParams) error) *MockSanctionsStore_SetAccountScreeningStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSanctionsStore creates a new instance of MockSanctionsStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSanctionsStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSanctionsStore {
+	mock := &MockSanctionsStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockStatementStore is an autogenerated mock type for the StatementStore type
+type MockStatementStore struct {
+	mock.Mock
+}
+
+type MockStatementStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatementStore) EXPECT() *MockStatementStore_Expecter {
+	return &MockStatementStore_Expecter{mock: &_m.Mock}
+}
+
+// GetAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockStatementStore) GetAccount(ctx context.Context, accountID uuid.UUID) (storage.Account, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 storage.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (storage.Account, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) storage.Account); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(storage.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatementStore_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockStatementStore_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockStatementStore_Expecter) GetAccount(ctx interface{}, accountID interface{}) *MockStatementStore_GetAccount_Call {
+	return &MockStatementStore_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, accountID)}
+}
+
+func (_c *MockStatementStore_GetAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockStatementStore_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStatementStore_GetAccount_Call) Return(_a0 storage.Account, _a1 error) *MockStatementStore_GetAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatementStore_GetAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (storage.Account, error)) *MockStatementStore_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountBalanceBefore provides a mock function with given fields: ctx, arg
+func (_m *MockStatementStore) GetAccountBalanceBefore(ctx context.Context, arg storage.GetAccountBalanceBeforeParams) (pgtype.Numeric, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalanceBefore")
+	}
+
+	var r0 pgtype.Numeric
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetAccountBalanceBeforeParams) (pgtype.Numeric, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetAccountBalanceBeforeParams) pgtype.Numeric); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(pgtype.Numeric)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetAccountBalanceBeforeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatementStore_GetAccountBalanceBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountBalanceBefore'
+type MockStatementStore_GetAccountBalanceBefore_Call struct {
+	*mock.Call
+}
+
+// GetAccountBalanceBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetAccountBalanceBeforeParams
+func (_e *MockStatementStore_Expecter) GetAccountBalanceBefore(ctx interface{}, arg interface{}) *MockStatementStore_GetAccountBalanceBefore_Call {
+	return &MockStatementStore_GetAccountBalanceBefore_Call{Call: _e.mock.On("GetAccountBalanceBefore", ctx, arg)}
+}
+
+func (_c *MockStatementStore_GetAccountBalanceBefore_Call) Run(run func(ctx context.Context, arg storage.GetAccountBalanceBeforeParams)) *MockStatementStore_GetAccountBalanceBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetAccountBalanceBeforeParams))
+	})
+	return _c
+}
+
+func (_c *MockStatementStore_GetAccountBalanceBefore_Call) Return(_a0 pgtype.Numeric, _a1 error) *MockStatementStore_GetAccountBalanceBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatementStore_GetAccountBalanceBefore_Call) RunAndReturn(run func(context.Context, storage.GetAccountBalanceBeforeParams) (pgtype.Numeric, error)) *MockStatementStore_GetAccountBalanceBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactionsBetween provides a mock function with given fields: ctx, arg
+func (_m *MockStatementStore) ListTransactionsBetween(ctx context.Context, arg storage.ListTransactionsBetweenParams) ([]storage.Transaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactionsBetween")
+	}
+
+	var r0 []storage.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsBetweenParams) ([]storage.Transaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransactionsBetweenParams) []storage.Transaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListTransactionsBetweenParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatementStore_ListTransactionsBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactionsBetween'
+type MockStatementStore_ListTransactionsBetween_Call struct {
+	*mock.Call
+}
+
+// ListTransactionsBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListTransactionsBetweenParams
+func (_e *MockStatementStore_Expecter) ListTransactionsBetween(ctx interface{}, arg interface{}) *MockStatementStore_ListTransactionsBetween_Call {
+	return &MockStatementStore_ListTransactionsBetween_Call{Call: _e.mock.On("ListTransactionsBetween", ctx, arg)}
+}
+
+func (_c *MockStatementStore_ListTransactionsBetween_Call) Run(run func(ctx context.Context, arg storage.ListTransactionsBetweenParams)) *MockStatementStore_ListTransactionsBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListTransactionsBetweenParams))
+	})
+	return _c
+}
+
+func (_c *MockStatementStore_ListTransactionsBetween_Call) Return(_a0 []storage.Transaction, _a1 error) *MockStatementStore_ListTransactionsBetween_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatementStore_ListTransactionsBetween_Call) RunAndReturn(run func(context.Context, storage.ListTransactionsBetweenParams) ([]storage.Transaction, error)) *MockStatementStore_ListTransactionsBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatementStore creates a new instance of MockStatementStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatementStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatementStore {
+	mock := &MockStatementStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,363 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockTransferApprovalStore is an autogenerated mock type for the TransferApprovalStore type
+type MockTransferApprovalStore struct {
+	mock.Mock
+}
+
+type MockTransferApprovalStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransferApprovalStore) EXPECT() *MockTransferApprovalStore_Expecter {
+	return &MockTransferApprovalStore_Expecter{mock: &_m.Mock}
+}
+
+// DecideTransferApproval provides a mock function with given fields: ctx, arg
+func (_m *MockTransferApprovalStore) DecideTransferApproval(ctx context.Context, arg storage.DecideTransferApprovalParams) (storage.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecideTransferApproval")
+	}
+
+	var r0 storage.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DecideTransferApprovalParams) (storage.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.DecideTransferApprovalParams) storage.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.DecideTransferApprovalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalStore_DecideTransferApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecideTransferApproval'
+type MockTransferApprovalStore_DecideTransferApproval_Call struct {
+	*mock.Call
+}
+
+// DecideTransferApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.DecideTransferApprovalParams
+func (_e *MockTransferApprovalStore_Expecter) DecideTransferApproval(ctx interface{}, arg interface{}) *MockTransferApprovalStore_DecideTransferApproval_Call {
+	return &MockTransferApprovalStore_DecideTransferApproval_Call{Call: _e.mock.On("DecideTransferApproval", ctx, arg)}
+}
+
+func (_c *MockTransferApprovalStore_DecideTransferApproval_Call) Run(run func(ctx context.Context, arg storage.DecideTransferApprovalParams)) *MockTransferApprovalStore_DecideTransferApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.DecideTransferApprovalParams))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_DecideTransferApproval_Call) Return(_a0 storage.TransferApproval, _a1 error) *MockTransferApprovalStore_DecideTransferApproval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_DecideTransferApproval_Call) RunAndReturn(run func(context.Context, storage.DecideTransferApprovalParams) (storage.TransferApproval, error)) *MockTransferApprovalStore_DecideTransferApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferApproval provides a mock function with given fields: ctx, arg
+func (_m *MockTransferApprovalStore) GetTransferApproval(ctx context.Context, arg storage.GetTransferApprovalParams) (storage.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferApproval")
+	}
+
+	var r0 storage.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferApprovalParams) (storage.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetTransferApprovalParams) storage.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.TransferApproval)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetTransferApprovalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalStore_GetTransferApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferApproval'
+type MockTransferApprovalStore_GetTransferApproval_Call struct {
+	*mock.Call
+}
+
+// GetTransferApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetTransferApprovalParams
+func (_e *MockTransferApprovalStore_Expecter) GetTransferApproval(ctx interface{}, arg interface{}) *MockTransferApprovalStore_GetTransferApproval_Call {
+	return &MockTransferApprovalStore_GetTransferApproval_Call{Call: _e.mock.On("GetTransferApproval", ctx, arg)}
+}
+
+func (_c *MockTransferApprovalStore_GetTransferApproval_Call) Run(run func(ctx context.Context, arg storage.GetTransferApprovalParams)) *MockTransferApprovalStore_GetTransferApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetTransferApprovalParams))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_GetTransferApproval_Call) Return(_a0 storage.TransferApproval, _a1 error) *MockTransferApprovalStore_GetTransferApproval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_GetTransferApproval_Call) RunAndReturn(run func(context.Context, storage.GetTransferApprovalParams) (storage.TransferApproval, error)) *MockTransferApprovalStore_GetTransferApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockTransferApprovalStore) HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasAccount")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalStore_HasAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAccount'
+type MockTransferApprovalStore_HasAccount_Call struct {
+	*mock.Call
+}
+
+// HasAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID uuid.UUID
+func (_e *MockTransferApprovalStore_Expecter) HasAccount(ctx interface{}, accountID interface{}) *MockTransferApprovalStore_HasAccount_Call {
+	return &MockTransferApprovalStore_HasAccount_Call{Call: _e.mock.On("HasAccount", ctx, accountID)}
+}
+
+func (_c *MockTransferApprovalStore_HasAccount_Call) Run(run func(ctx context.Context, accountID uuid.UUID)) *MockTransferApprovalStore_HasAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_HasAccount_Call) Return(_a0 bool, _a1 error) *MockTransferApprovalStore_HasAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_HasAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockTransferApprovalStore_HasAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransferApprovals provides a mock function with given fields: ctx, arg
+func (_m *MockTransferApprovalStore) ListTransferApprovals(ctx context.Context, arg storage.ListTransferApprovalsParams) ([]storage.TransferApproval, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransferApprovals")
+	}
+
+	var r0 []storage.TransferApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransferApprovalsParams) ([]storage.TransferApproval, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListTransferApprovalsParams) []storage.TransferApproval); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.TransferApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListTransferApprovalsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferApprovalStore_ListTransferApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransferApprovals'
+type MockTransferApprovalStore_ListTransferApprovals_Call struct {
+	*mock.Call
+}
+
+// ListTransferApprovals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListTransferApprovalsParams
+func (_e *MockTransferApprovalStore_Expecter) ListTransferApprovals(ctx interface{}, arg interface{}) *MockTransferApprovalStore_ListTransferApprovals_Call {
+	return &MockTransferApprovalStore_ListTransferApprovals_Call{Call: _e.mock.On("ListTransferApprovals", ctx, arg)}
+}
+
+func (_c *MockTransferApprovalStore_ListTransferApprovals_Call) Run(run func(ctx context.Context, arg storage.ListTransferApprovalsParams)) *MockTransferApprovalStore_ListTransferApprovals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListTransferApprovalsParams))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_ListTransferApprovals_Call) Return(_a0 []storage.TransferApproval, _a1 error) *MockTransferApprovalStore_ListTransferApprovals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_ListTransferApprovals_Call) RunAndReturn(run func(context.Context, storage.ListTransferApprovalsParams) ([]storage.TransferApproval, error)) *MockTransferApprovalStore_ListTransferApprovals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReopenTransferApproval provides a mock function with given fields: ctx, transferApprovalID
+func (_m *MockTransferApprovalStore) ReopenTransferApproval(ctx context.Context, transferApprovalID uuid.UUID) error {
+	ret := _m.Called(ctx, transferApprovalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenTransferApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, transferApprovalID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransferApprovalStore_ReopenTransferApproval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReopenTransferApproval'
+type MockTransferApprovalStore_ReopenTransferApproval_Call struct {
+	*mock.Call
+}
+
+// ReopenTransferApproval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transferApprovalID uuid.UUID
+func (_e *MockTransferApprovalStore_Expecter) ReopenTransferApproval(ctx interface{}, transferApprovalID interface{}) *MockTransferApprovalStore_ReopenTransferApproval_Call {
+	return &MockTransferApprovalStore_ReopenTransferApproval_Call{Call: _e.mock.On("ReopenTransferApproval", ctx, transferApprovalID)}
+}
+
+func (_c *MockTransferApprovalStore_ReopenTransferApproval_Call) Run(run func(ctx context.Context, transferApprovalID uuid.UUID)) *MockTransferApprovalStore_ReopenTransferApproval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_ReopenTransferApproval_Call) Return(_a0 error) *MockTransferApprovalStore_ReopenTransferApproval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_ReopenTransferApproval_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockTransferApprovalStore_ReopenTransferApproval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTransferApprovalTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockTransferApprovalStore) SetTransferApprovalTransaction(ctx context.Context, arg storage.SetTransferApprovalTransactionParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTransferApprovalTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SetTransferApprovalTransactionParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransferApprovalStore_SetTransferApprovalTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTransferApprovalTransaction'
+type MockTransferApprovalStore_SetTransferApprovalTransaction_Call struct {
+	*mock.Call
+}
+
+// SetTransferApprovalTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.SetTransferApprovalTransactionParams
+func (_e *MockTransferApprovalStore_Expecter) SetTransferApprovalTransaction(ctx interface{}, arg interface{}) *MockTransferApprovalStore_SetTransferApprovalTransaction_Call {
+	return &MockTransferApprovalStore_SetTransferApprovalTransaction_Call{Call: _e.mock.On("SetTransferApprovalTransaction", ctx, arg)}
+}
+
+func (_c *MockTransferApprovalStore_SetTransferApprovalTransaction_Call) Run(run func(ctx context.Context, arg storage.SetTransferApprovalTransactionParams)) *MockTransferApprovalStore_SetTransferApprovalTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SetTransferApprovalTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_SetTransferApprovalTransaction_Call) Return(_a0 error) *MockTransferApprovalStore_SetTransferApprovalTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransferApprovalStore_SetTransferApprovalTransaction_Call) RunAndReturn(run func(context.Context, storage.SetTransferApprovalTransactionParams) error) *MockTransferApprovalStore_SetTransferApprovalTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransferApprovalStore creates a new instance of MockTransferApprovalStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransferApprovalStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransferApprovalStore {
+	mock := &MockTransferApprovalStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
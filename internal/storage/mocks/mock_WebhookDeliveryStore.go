@@ -0,0 +1,190 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+)
+
+// MockWebhookDeliveryStore is an autogenerated mock type for the WebhookDeliveryStore type
+type MockWebhookDeliveryStore struct {
+	mock.Mock
+}
+
+type MockWebhookDeliveryStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookDeliveryStore) EXPECT() *MockWebhookDeliveryStore_Expecter {
+	return &MockWebhookDeliveryStore_Expecter{mock: &_m.Mock}
+}
+
+// AddWebhookDeliveryAttempt provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookDeliveryStore) AddWebhookDeliveryAttempt(ctx context.Context, arg storage.AddWebhookDeliveryAttemptParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWebhookDeliveryAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddWebhookDeliveryAttemptParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWebhookDeliveryAttempt'
+type MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call struct {
+	*mock.Call
+}
+
+// AddWebhookDeliveryAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddWebhookDeliveryAttemptParams
+func (_e *MockWebhookDeliveryStore_Expecter) AddWebhookDeliveryAttempt(ctx interface{}, arg interface{}) *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call {
+	return &MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call{Call: _e.mock.On("AddWebhookDeliveryAttempt", ctx, arg)}
+}
+
+func (_c *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call) Run(run func(ctx context.Context, arg storage.AddWebhookDeliveryAttemptParams)) *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddWebhookDeliveryAttemptParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call) Return(_a0 error) *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call) RunAndReturn(run func(context.Context, storage.AddWebhookDeliveryAttemptParams) error) *MockWebhookDeliveryStore_AddWebhookDeliveryAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDueWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookDeliveryStore) ClaimDueWebhookDeliveries(ctx context.Context, arg storage.ClaimDueWebhookDeliveriesParams) ([]storage.ClaimDueWebhookDeliveriesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueWebhookDeliveries")
+	}
+
+	var r0 []storage.ClaimDueWebhookDeliveriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ClaimDueWebhookDeliveriesParams) ([]storage.ClaimDueWebhookDeliveriesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ClaimDueWebhookDeliveriesParams) []storage.ClaimDueWebhookDeliveriesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ClaimDueWebhookDeliveriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ClaimDueWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueWebhookDeliveries'
+type MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDueWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ClaimDueWebhookDeliveriesParams
+func (_e *MockWebhookDeliveryStore_Expecter) ClaimDueWebhookDeliveries(ctx interface{}, arg interface{}) *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call {
+	return &MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call{Call: _e.mock.On("ClaimDueWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call) Run(run func(ctx context.Context, arg storage.ClaimDueWebhookDeliveriesParams)) *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ClaimDueWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call) Return(_a0 []storage.ClaimDueWebhookDeliveriesRow, _a1 error) *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call) RunAndReturn(run func(context.Context, storage.ClaimDueWebhookDeliveriesParams) ([]storage.ClaimDueWebhookDeliveriesRow, error)) *MockWebhookDeliveryStore_ClaimDueWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookDeliveryStore) UpdateWebhookDelivery(ctx context.Context, arg storage.UpdateWebhookDeliveryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UpdateWebhookDeliveryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookDeliveryStore_UpdateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookDelivery'
+type MockWebhookDeliveryStore_UpdateWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.UpdateWebhookDeliveryParams
+func (_e *MockWebhookDeliveryStore_Expecter) UpdateWebhookDelivery(ctx interface{}, arg interface{}) *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	return &MockWebhookDeliveryStore_UpdateWebhookDelivery_Call{Call: _e.mock.On("UpdateWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call) Run(run func(ctx context.Context, arg storage.UpdateWebhookDeliveryParams)) *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UpdateWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call) Return(_a0 error) *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call) RunAndReturn(run func(context.Context, storage.UpdateWebhookDeliveryParams) error) *MockWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookDeliveryStore creates a new instance of MockWebhookDeliveryStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookDeliveryStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookDeliveryStore {
+	mock := &MockWebhookDeliveryStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
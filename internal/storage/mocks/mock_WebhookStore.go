@@ -0,0 +1,491 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	storage "github.com/zaidsasa/xbankapi/internal/storage"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockWebhookStore is an autogenerated mock type for the WebhookStore type
+type MockWebhookStore struct {
+	mock.Mock
+}
+
+type MockWebhookStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookStore) EXPECT() *MockWebhookStore_Expecter {
+	return &MockWebhookStore_Expecter{mock: &_m.Mock}
+}
+
+// AddWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) AddWebhookDelivery(ctx context.Context, arg storage.AddWebhookDeliveryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.AddWebhookDeliveryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_AddWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWebhookDelivery'
+type MockWebhookStore_AddWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// AddWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.AddWebhookDeliveryParams
+func (_e *MockWebhookStore_Expecter) AddWebhookDelivery(ctx interface{}, arg interface{}) *MockWebhookStore_AddWebhookDelivery_Call {
+	return &MockWebhookStore_AddWebhookDelivery_Call{Call: _e.mock.On("AddWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_AddWebhookDelivery_Call) Run(run func(ctx context.Context, arg storage.AddWebhookDeliveryParams)) *MockWebhookStore_AddWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.AddWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_AddWebhookDelivery_Call) Return(_a0 error) *MockWebhookStore_AddWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_AddWebhookDelivery_Call) RunAndReturn(run func(context.Context, storage.AddWebhookDeliveryParams) error) *MockWebhookStore_AddWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) CreateWebhook(ctx context.Context, arg storage.CreateWebhookParams) (storage.Webhook, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 storage.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateWebhookParams) (storage.Webhook, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.CreateWebhookParams) storage.Webhook); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.CreateWebhookParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type MockWebhookStore_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.CreateWebhookParams
+func (_e *MockWebhookStore_Expecter) CreateWebhook(ctx interface{}, arg interface{}) *MockWebhookStore_CreateWebhook_Call {
+	return &MockWebhookStore_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_CreateWebhook_Call) Run(run func(ctx context.Context, arg storage.CreateWebhookParams)) *MockWebhookStore_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.CreateWebhookParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_CreateWebhook_Call) Return(_a0 storage.Webhook, _a1 error) *MockWebhookStore_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_CreateWebhook_Call) RunAndReturn(run func(context.Context, storage.CreateWebhookParams) (storage.Webhook, error)) *MockWebhookStore_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) GetWebhookDelivery(ctx context.Context, arg storage.GetWebhookDeliveryParams) (storage.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 storage.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetWebhookDeliveryParams) (storage.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.GetWebhookDeliveryParams) storage.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.GetWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type MockWebhookStore_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.GetWebhookDeliveryParams
+func (_e *MockWebhookStore_Expecter) GetWebhookDelivery(ctx interface{}, arg interface{}) *MockWebhookStore_GetWebhookDelivery_Call {
+	return &MockWebhookStore_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_GetWebhookDelivery_Call) Run(run func(ctx context.Context, arg storage.GetWebhookDeliveryParams)) *MockWebhookStore_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.GetWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_GetWebhookDelivery_Call) Return(_a0 storage.WebhookDelivery, _a1 error) *MockWebhookStore_GetWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_GetWebhookDelivery_Call) RunAndReturn(run func(context.Context, storage.GetWebhookDeliveryParams) (storage.WebhookDelivery, error)) *MockWebhookStore_GetWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *MockWebhookStore) HasWebhook(ctx context.Context, webhookID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasWebhook")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_HasWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasWebhook'
+type MockWebhookStore_HasWebhook_Call struct {
+	*mock.Call
+}
+
+// HasWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID uuid.UUID
+func (_e *MockWebhookStore_Expecter) HasWebhook(ctx interface{}, webhookID interface{}) *MockWebhookStore_HasWebhook_Call {
+	return &MockWebhookStore_HasWebhook_Call{Call: _e.mock.On("HasWebhook", ctx, webhookID)}
+}
+
+func (_c *MockWebhookStore_HasWebhook_Call) Run(run func(ctx context.Context, webhookID uuid.UUID)) *MockWebhookStore_HasWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_HasWebhook_Call) Return(_a0 bool, _a1 error) *MockWebhookStore_HasWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_HasWebhook_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockWebhookStore_HasWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) ListWebhookDeliveries(ctx context.Context, arg storage.ListWebhookDeliveriesParams) ([]storage.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []storage.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListWebhookDeliveriesParams) ([]storage.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListWebhookDeliveriesParams) []storage.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockWebhookStore_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListWebhookDeliveriesParams
+func (_e *MockWebhookStore_Expecter) ListWebhookDeliveries(ctx interface{}, arg interface{}) *MockWebhookStore_ListWebhookDeliveries_Call {
+	return &MockWebhookStore_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, arg storage.ListWebhookDeliveriesParams)) *MockWebhookStore_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListWebhookDeliveriesParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveries_Call) Return(_a0 []storage.WebhookDelivery, _a1 error) *MockWebhookStore_ListWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveries_Call) RunAndReturn(run func(context.Context, storage.ListWebhookDeliveriesParams) ([]storage.WebhookDelivery, error)) *MockWebhookStore_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveryAttempts provides a mock function with given fields: ctx, webhookDeliveryID
+func (_m *MockWebhookStore) ListWebhookDeliveryAttempts(ctx context.Context, webhookDeliveryID uuid.UUID) ([]storage.WebhookDeliveryAttempt, error) {
+	ret := _m.Called(ctx, webhookDeliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveryAttempts")
+	}
+
+	var r0 []storage.WebhookDeliveryAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]storage.WebhookDeliveryAttempt, error)); ok {
+		return rf(ctx, webhookDeliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []storage.WebhookDeliveryAttempt); ok {
+		r0 = rf(ctx, webhookDeliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.WebhookDeliveryAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, webhookDeliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_ListWebhookDeliveryAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveryAttempts'
+type MockWebhookStore_ListWebhookDeliveryAttempts_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveryAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookDeliveryID uuid.UUID
+func (_e *MockWebhookStore_Expecter) ListWebhookDeliveryAttempts(ctx interface{}, webhookDeliveryID interface{}) *MockWebhookStore_ListWebhookDeliveryAttempts_Call {
+	return &MockWebhookStore_ListWebhookDeliveryAttempts_Call{Call: _e.mock.On("ListWebhookDeliveryAttempts", ctx, webhookDeliveryID)}
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveryAttempts_Call) Run(run func(ctx context.Context, webhookDeliveryID uuid.UUID)) *MockWebhookStore_ListWebhookDeliveryAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveryAttempts_Call) Return(_a0 []storage.WebhookDeliveryAttempt, _a1 error) *MockWebhookStore_ListWebhookDeliveryAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhookDeliveryAttempts_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]storage.WebhookDeliveryAttempt, error)) *MockWebhookStore_ListWebhookDeliveryAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhooksForEvent provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) ListWebhooksForEvent(ctx context.Context, arg storage.ListWebhooksForEventParams) ([]storage.Webhook, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooksForEvent")
+	}
+
+	var r0 []storage.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListWebhooksForEventParams) ([]storage.Webhook, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.ListWebhooksForEventParams) []storage.Webhook); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.ListWebhooksForEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_ListWebhooksForEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooksForEvent'
+type MockWebhookStore_ListWebhooksForEvent_Call struct {
+	*mock.Call
+}
+
+// ListWebhooksForEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.ListWebhooksForEventParams
+func (_e *MockWebhookStore_Expecter) ListWebhooksForEvent(ctx interface{}, arg interface{}) *MockWebhookStore_ListWebhooksForEvent_Call {
+	return &MockWebhookStore_ListWebhooksForEvent_Call{Call: _e.mock.On("ListWebhooksForEvent", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_ListWebhooksForEvent_Call) Run(run func(ctx context.Context, arg storage.ListWebhooksForEventParams)) *MockWebhookStore_ListWebhooksForEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.ListWebhooksForEventParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhooksForEvent_Call) Return(_a0 []storage.Webhook, _a1 error) *MockWebhookStore_ListWebhooksForEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_ListWebhooksForEvent_Call) RunAndReturn(run func(context.Context, storage.ListWebhooksForEventParams) ([]storage.Webhook, error)) *MockWebhookStore_ListWebhooksForEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeliverWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *MockWebhookStore) RedeliverWebhookDelivery(ctx context.Context, arg storage.RedeliverWebhookDeliveryParams) (storage.WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverWebhookDelivery")
+	}
+
+	var r0 storage.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.RedeliverWebhookDeliveryParams) (storage.WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.RedeliverWebhookDeliveryParams) storage.WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(storage.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.RedeliverWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_RedeliverWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhookDelivery'
+type MockWebhookStore_RedeliverWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// RedeliverWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg storage.RedeliverWebhookDeliveryParams
+func (_e *MockWebhookStore_Expecter) RedeliverWebhookDelivery(ctx interface{}, arg interface{}) *MockWebhookStore_RedeliverWebhookDelivery_Call {
+	return &MockWebhookStore_RedeliverWebhookDelivery_Call{Call: _e.mock.On("RedeliverWebhookDelivery", ctx, arg)}
+}
+
+func (_c *MockWebhookStore_RedeliverWebhookDelivery_Call) Run(run func(ctx context.Context, arg storage.RedeliverWebhookDeliveryParams)) *MockWebhookStore_RedeliverWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.RedeliverWebhookDeliveryParams))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_RedeliverWebhookDelivery_Call) Return(_a0 storage.WebhookDelivery, _a1 error) *MockWebhookStore_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_RedeliverWebhookDelivery_Call) RunAndReturn(run func(context.Context, storage.RedeliverWebhookDeliveryParams) (storage.WebhookDelivery, error)) *MockWebhookStore_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookStore creates a new instance of MockWebhookStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookStore {
+	mock := &MockWebhookStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
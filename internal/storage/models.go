@@ -10,15 +10,288 @@ import (
 )
 
 type Account struct {
-	AccountID    uuid.UUID
-	Email        string
-	Name         string
-	CurrencyCode string
+	AccountID               uuid.UUID
+	Email                   string
+	Name                    string
+	CurrencyCode            string
+	AccountNumber           int64
+	IBAN                    pgtype.Text
+	ScreeningStatus         string
+	OverdraftLimit          pgtype.Numeric
+	ProductCode             string
+	CustomerID              uuid.UUID
+	ApprovalThreshold       pgtype.Numeric
+	ParentAccountID         uuid.NullUUID
+	GoalAmount              pgtype.Numeric
+	GoalDate                pgtype.Date
+	LastEventSequence       int64
+	LastTransactionSequence int64
 }
 
-type Transaction struct {
-	TransactionID uuid.UUID
+type AccountHolder struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+	Role       string
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type AccountLimit struct {
 	AccountID     uuid.UUID
+	Tier          string
+	MaxTransfer   pgtype.Numeric
+	DailyAmount   pgtype.Numeric
+	MonthlyAmount pgtype.Numeric
+	DailyCount    pgtype.Int4
+	UpdatedAt     pgtype.Timestamptz
+}
+
+type AccountProduct struct {
+	ProductCode       string
+	Name              string
+	InterestRate      pgtype.Numeric
+	DayCount          string
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	CurrencyCodes     []string
+	LimitTier         string
+	OverdraftEligible bool
+	MaxOverdraftLimit pgtype.Numeric
+}
+
+type AuditEvent struct {
+	AuditEventID int64
+	OccurredAt   pgtype.Timestamptz
+	Principal    string
+	Action       string
+	AccountID    uuid.NullUUID
+	RequestID    string
+	ClientIp     string
+	Outcome      string
+	Before       []byte
+	After        []byte
+	PrevHash     []byte
+	Hash         []byte
+}
+
+type Beneficiary struct {
+	BeneficiaryID    uuid.UUID
+	AccountID        uuid.UUID
+	Nickname         string
+	ReciverAccountID uuid.UUID
+	TransferLimit    pgtype.Numeric
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type Customer struct {
+	CustomerID uuid.UUID
+	Name       string
+	Email      string
+	Phone      pgtype.Text
+	KYCStatus  string
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type Fee struct {
+	TransactionID        uuid.UUID
+	AccountID            uuid.UUID
+	FeeType              string
+	IncomeTransactionID  uuid.UUID
+	ChargedTransactionID uuid.NullUUID
+	Period               pgtype.Date
+	Amount               pgtype.Numeric
+	CreatedAt            pgtype.Timestamptz
+}
+
+type FeeSchedule struct {
+	ProductCode string
+	FeeType     string
+	Kind        string
+	Amount      pgtype.Numeric
+	Rate        pgtype.Numeric
+	MinAmount   pgtype.Numeric
+	MaxAmount   pgtype.Numeric
+	Tiers       []byte
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type IdempotencyKey struct {
+	Key          string
+	RequestHash  []byte
+	StatusCode   pgtype.Int4
+	ContentType  pgtype.Text
+	ResponseBody []byte
+	CreatedAt    pgtype.Timestamptz
+	Principal    string
+}
+
+type InterestAccrual struct {
+	AccountID     uuid.UUID
+	Day           pgtype.Date
+	ProductCode   string
+	Balance       pgtype.Numeric
+	InterestRate  pgtype.Numeric
+	DayCount      string
 	Amount        pgtype.Numeric
-	SourceID      uuid.NullUUID
+	TransactionID uuid.NullUUID
+	CreatedAt     pgtype.Timestamptz
+}
+
+type LimitTier struct {
+	Tier          string
+	MaxTransfer   pgtype.Numeric
+	DailyAmount   pgtype.Numeric
+	MonthlyAmount pgtype.Numeric
+	DailyCount    pgtype.Int4
+	UpdatedAt     pgtype.Timestamptz
+}
+
+type Outbox struct {
+	OutboxEventID   int64
+	EventID         uuid.UUID
+	EventType       string
+	AccountID       uuid.UUID
+	Payload         []byte
+	OccurredAt      pgtype.Timestamptz
+	PublishedAt     pgtype.Timestamptz
+	AccountSequence int64
+}
+
+type OverdraftInterest struct {
+	AccountID     uuid.UUID
+	Day           pgtype.Date
+	TransactionID uuid.UUID
+	Balance       pgtype.Numeric
+	Amount        pgtype.Numeric
+	CreatedAt     pgtype.Timestamptz
+	InterestRate  pgtype.Numeric
+}
+
+type Payment struct {
+	PaymentID            uuid.UUID
+	PaymentFileID        uuid.UUID
+	Position             int32
+	PaymentInformationID string
+	InstructionID        pgtype.Text
+	EndToEndID           string
+	Amount               pgtype.Numeric
+	CurrencyCode         string
+	CreditorAccount      string
+	Status               string
+	ReasonCode           pgtype.Text
+	Reason               pgtype.Text
+	TransactionID        uuid.NullUUID
+	CreatedAt            pgtype.Timestamptz
+	UpdatedAt            pgtype.Timestamptz
+	TransferApprovalID   uuid.NullUUID
+	PendingTransferID    uuid.NullUUID
+}
+
+type PaymentFile struct {
+	PaymentFileID        uuid.UUID
+	AccountID            uuid.UUID
+	MessageID            string
+	MessageCreatedAt     pgtype.Timestamptz
+	NumberOfTransactions int32
+	ControlSum           pgtype.Numeric
+	Status               string
+	ReasonCode           pgtype.Text
+	Reason               pgtype.Text
+	CreatedAt            pgtype.Timestamptz
+	UpdatedAt            pgtype.Timestamptz
+}
+
+type PendingTransfer struct {
+	PendingTransferID uuid.UUID
+	AccountID         uuid.UUID
+	ReciverAccountID  uuid.UUID
+	Amount            pgtype.Numeric
+	Status            string
+	Rules             []string
+	TransactionID     uuid.NullUUID
+	CreatedAt         pgtype.Timestamptz
+	DecidedAt         pgtype.Timestamptz
+	CurrencyCode      string
+}
+
+type SanctionsEntry struct {
+	List      string
+	Reference string
+	Name      string
+}
+
+type SanctionsList struct {
+	List       string
+	Entries    int32
+	ImportedAt pgtype.Timestamptz
+}
+
+type SanctionsScreening struct {
+	ScreeningID uuid.UUID
+	AccountID   uuid.UUID
+	Name        string
+	Status      string
+	Matches     []byte
+	CreatedAt   pgtype.Timestamptz
+	ResolvedAt  pgtype.Timestamptz
+}
+
+type Transaction struct {
+	TransactionID   uuid.UUID
+	AccountID       uuid.UUID
+	Amount          pgtype.Numeric
+	SourceID        uuid.NullUUID
+	CreatedAt       pgtype.Timestamptz
+	Type            string
+	AccountSequence int64
+}
+
+type TransferApproval struct {
+	TransferApprovalID uuid.UUID
+	AccountID          uuid.UUID
+	ReciverAccountID   uuid.UUID
+	Amount             pgtype.Numeric
+	Status             string
+	InitiatedBy        string
+	DecidedBy          pgtype.Text
+	TransactionID      uuid.NullUUID
+	CreatedAt          pgtype.Timestamptz
+	DecidedAt          pgtype.Timestamptz
+	CurrencyCode       string
+}
+
+type Webhook struct {
+	WebhookID  uuid.UUID
+	Url        string
+	EventTypes []string
+	AccountID  uuid.NullUUID
+	Secret     string
+	CreatedAt  pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	WebhookDeliveryID uuid.UUID
+	WebhookID         uuid.UUID
+	EventID           uuid.UUID
+	EventType         string
+	Payload           []byte
+	Status            string
+	Attempts          int32
+	NextAttemptAt     pgtype.Timestamptz
+	LastStatusCode    pgtype.Int4
+	LastError         pgtype.Text
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+}
+
+type WebhookDeliveryAttempt struct {
+	WebhookDeliveryAttemptID int64
+	WebhookDeliveryID        uuid.UUID
+	AttemptedAt              pgtype.Timestamptz
+	StatusCode               pgtype.Int4
+	Error                    pgtype.Text
+	DurationMs               int64
 }
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NoCurrency is the currency code of amounts not held in a currency, e.g. the limits of a tier applying to the
+// accounts of every currency in the minor unit of theirs. They are stored with 2 decimals.
+const NoCurrency = ""
+
+// defaultMinorUnitExp is the exponent of the minor unit of amounts not held in a currency, or in an unknown one.
+const defaultMinorUnitExp = -2
+
+// MinorUnitScale returns the decimal places of the minor unit of a currency, e.g. 2 for EUR and 0 for JPY.
+func MinorUnitScale(currencyCode string) int32 {
+	return -minorUnitExp(currencyCode)
+}
+
+// minorUnitExp returns the exponent of the minor unit of a currency, e.g. -2 for EUR and 0 for JPY.
+func minorUnitExp(currencyCode string) int32 {
+	if c := money.GetCurrency(currencyCode); c != nil {
+		return -int32(c.Fraction) //nolint:gosec // a handful of decimals.
+	}
+
+	return defaultMinorUnitExp
+}
+
+// AmountFromNumeric converts a numeric amount of a currency to the minor unit, e.g. 1.5 EUR to 150.
+func AmountFromNumeric(n pgtype.Numeric, currencyCode string) money.Amount {
+	if !n.Valid || n.Int == nil {
+		return 0
+	}
+
+	amount := new(big.Int).Set(n.Int)
+	ten := big.NewInt(10) //nolint:mnd // decimal base.
+	unitExp := minorUnitExp(currencyCode)
+
+	for exp := n.Exp; exp < unitExp; exp++ {
+		amount.Quo(amount, ten)
+	}
+
+	for exp := n.Exp; exp > unitExp; exp-- {
+		amount.Mul(amount, ten)
+	}
+
+	return amount.Int64()
+}
+
+// NumericFromAmount converts an amount of a currency in the minor unit to a numeric amount, e.g. 150 EUR to 1.50.
+func NumericFromAmount(amount money.Amount, currencyCode string) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(amount), Exp: minorUnitExp(currencyCode), Valid: true}
+}
+
+// RatFromNumeric converts a numeric to an exact rational number, e.g. 0.025 to 1/40.
+func RatFromNumeric(n pgtype.Numeric) *big.Rat {
+	if !n.Valid || n.Int == nil {
+		return new(big.Rat)
+	}
+
+	if n.Exp < 0 {
+		return new(big.Rat).SetFrac(n.Int, pow10(-n.Exp))
+	}
+
+	return new(big.Rat).SetInt(new(big.Int).Mul(n.Int, pow10(n.Exp)))
+}
+
+// NumericFromRat converts a rational number to a numeric of scale decimal places, rounded half away from zero.
+func NumericFromRat(r *big.Rat, scale int32) pgtype.Numeric {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+
+	q, m := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	if m.Mul(m.Abs(m), big.NewInt(2)).Cmp(scaled.Denom()) >= 0 { //nolint:mnd // twice the remainder, to round half up.
+		q.Add(q, big.NewInt(int64(scaled.Sign())))
+	}
+
+	return pgtype.Numeric{Int: q, Exp: -scale, Valid: true}
+}
+
+// DecimalFromNumeric formats a numeric as a decimal number, e.g. 0.025.
+func DecimalFromNumeric(n pgtype.Numeric) string {
+	return RatFromNumeric(n).FloatString(int(max(-n.Exp, 0)))
+}
+
+// pow10 returns 10 to the power of exp.
+func pow10(exp int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil) //nolint:mnd // decimal base.
+}
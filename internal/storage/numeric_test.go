@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumericFromAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		currencyCode string
+		want         pgtype.Numeric
+	}{
+		{
+			name:         "currency with 2 decimals",
+			currencyCode: "EUR",
+			want:         pgtype.Numeric{Int: big.NewInt(1500), Exp: -2, Valid: true},
+		},
+		{
+			name:         "currency without decimals",
+			currencyCode: "JPY",
+			want:         pgtype.Numeric{Int: big.NewInt(1500), Exp: 0, Valid: true},
+		},
+		{
+			name:         "currency with 3 decimals",
+			currencyCode: "BHD",
+			want:         pgtype.Numeric{Int: big.NewInt(1500), Exp: -3, Valid: true},
+		},
+		{
+			name:         "no currency",
+			currencyCode: NoCurrency,
+			want:         pgtype.Numeric{Int: big.NewInt(1500), Exp: -2, Valid: true},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NumericFromAmount(1500, tt.currencyCode)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, money.Amount(1500), AmountFromNumeric(got, tt.currencyCode))
+		})
+	}
+}
+
+func TestAmountFromNumeric(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		n            pgtype.Numeric
+		currencyCode string
+		want         money.Amount
+	}{
+		{
+			name:         "more decimals than the currency",
+			n:            pgtype.Numeric{Int: big.NewInt(15000), Exp: -4, Valid: true},
+			currencyCode: "EUR",
+			want:         150,
+		},
+		{
+			name:         "fewer decimals than the currency",
+			n:            pgtype.Numeric{Int: big.NewInt(15), Exp: -1, Valid: true},
+			currencyCode: "BHD",
+			want:         1500,
+		},
+		{
+			name:         "decimals of a currency without",
+			n:            pgtype.Numeric{Int: big.NewInt(1500), Exp: -2, Valid: true},
+			currencyCode: "JPY",
+			want:         15,
+		},
+		{
+			name:         "null",
+			currencyCode: "EUR",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, AmountFromNumeric(tt.n, tt.currencyCode))
+		})
+	}
+}
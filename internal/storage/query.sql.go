@@ -12,107 +12,4172 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addAuditEvent = `-- name: AddAuditEvent :one
+INSERT INTO "audit_event"(occurred_at, principal, action, account_id, request_id, client_ip, outcome, before, after, prev_hash, hash)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING
+    audit_event_id, occurred_at, principal, action, account_id, request_id, client_ip, outcome, before, after, prev_hash, hash
+`
+
+type AddAuditEventParams struct {
+	OccurredAt pgtype.Timestamptz
+	Principal  string
+	Action     string
+	AccountID  uuid.NullUUID
+	RequestID  string
+	ClientIp   string
+	Outcome    string
+	Before     []byte
+	After      []byte
+	PrevHash   []byte
+	Hash       []byte
+}
+
+func (q *Queries) AddAuditEvent(ctx context.Context, arg AddAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRow(ctx, addAuditEvent,
+		arg.OccurredAt,
+		arg.Principal,
+		arg.Action,
+		arg.AccountID,
+		arg.RequestID,
+		arg.ClientIp,
+		arg.Outcome,
+		arg.Before,
+		arg.After,
+		arg.PrevHash,
+		arg.Hash,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.AuditEventID,
+		&i.OccurredAt,
+		&i.Principal,
+		&i.Action,
+		&i.AccountID,
+		&i.RequestID,
+		&i.ClientIp,
+		&i.Outcome,
+		&i.Before,
+		&i.After,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const addFee = `-- name: AddFee :execrows
+INSERT INTO "fee"(transaction_id, account_id, fee_type, income_transaction_id, charged_transaction_id, period, amount)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id, period)
+WHERE
+    period IS NOT NULL
+        DO NOTHING
+`
+
+type AddFeeParams struct {
+	TransactionID        uuid.UUID
+	AccountID            uuid.UUID
+	FeeType              string
+	IncomeTransactionID  uuid.UUID
+	ChargedTransactionID uuid.NullUUID
+	Period               pgtype.Date
+	Amount               pgtype.Numeric
+}
+
+func (q *Queries) AddFee(ctx context.Context, arg AddFeeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addFee,
+		arg.TransactionID,
+		arg.AccountID,
+		arg.FeeType,
+		arg.IncomeTransactionID,
+		arg.ChargedTransactionID,
+		arg.Period,
+		arg.Amount,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addInterestAccrual = `-- name: AddInterestAccrual :execrows
+INSERT INTO "interest_accrual"(account_id, day, product_code, balance, interest_rate, day_count, amount, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (account_id, day)
+    DO NOTHING
+`
+
+type AddInterestAccrualParams struct {
+	AccountID    uuid.UUID
+	Day          pgtype.Date
+	ProductCode  string
+	Balance      pgtype.Numeric
+	InterestRate pgtype.Numeric
+	DayCount     string
+	Amount       pgtype.Numeric
+	CreatedAt    pgtype.Timestamptz
+}
+
+func (q *Queries) AddInterestAccrual(ctx context.Context, arg AddInterestAccrualParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addInterestAccrual,
+		arg.AccountID,
+		arg.Day,
+		arg.ProductCode,
+		arg.Balance,
+		arg.InterestRate,
+		arg.DayCount,
+		arg.Amount,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addOutboxEvent = `-- name: AddOutboxEvent :one
+WITH numbered AS (
+    UPDATE
+        "account"
+    SET
+        last_event_sequence = last_event_sequence + 1
+    WHERE
+        account_id = $2
+    RETURNING
+        last_event_sequence)
+INSERT INTO "outbox"(event_type, account_id, account_sequence, payload, occurred_at)
+SELECT
+    $1,
+    $2,
+    numbered.last_event_sequence,
+    $3,
+    $4
+FROM
+    numbered
+RETURNING
+    outbox_event_id, event_id, event_type, account_id, payload, occurred_at, published_at, account_sequence
+`
+
+type AddOutboxEventParams struct {
+	EventType  string
+	AccountID  uuid.UUID
+	Payload    []byte
+	OccurredAt pgtype.Timestamptz
+}
+
+// Numbers the event by the next sequence of its account, whose row is locked until the end of the transaction, so
+// that the events of an account are numbered, and their outbox IDs drawn, in the order their transactions commit.
+func (q *Queries) AddOutboxEvent(ctx context.Context, arg AddOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRow(ctx, addOutboxEvent,
+		arg.EventType,
+		arg.AccountID,
+		arg.Payload,
+		arg.OccurredAt,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.OutboxEventID,
+		&i.EventID,
+		&i.EventType,
+		&i.AccountID,
+		&i.Payload,
+		&i.OccurredAt,
+		&i.PublishedAt,
+		&i.AccountSequence,
+	)
+	return i, err
+}
+
+const addOverdraftInterest = `-- name: AddOverdraftInterest :execrows
+INSERT INTO "overdraft_interest"(account_id, day, transaction_id, balance, interest_rate, amount, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id, day)
+    DO NOTHING
+`
+
+type AddOverdraftInterestParams struct {
+	AccountID     uuid.UUID
+	Day           pgtype.Date
+	TransactionID uuid.UUID
+	Balance       pgtype.Numeric
+	InterestRate  pgtype.Numeric
+	Amount        pgtype.Numeric
+	CreatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) AddOverdraftInterest(ctx context.Context, arg AddOverdraftInterestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addOverdraftInterest,
+		arg.AccountID,
+		arg.Day,
+		arg.TransactionID,
+		arg.Balance,
+		arg.InterestRate,
+		arg.Amount,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addPayment = `-- name: AddPayment :one
+INSERT INTO "payment"(payment_file_id, position, payment_information_id, instruction_id, end_to_end_id, amount, currency_code, creditor_account, status, reason_code, reason, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+RETURNING
+    payment_id, payment_file_id, position, payment_information_id, instruction_id, end_to_end_id, amount, currency_code, creditor_account, status, reason_code, reason, transaction_id, created_at, updated_at, transfer_approval_id, pending_transfer_id
+`
+
+type AddPaymentParams struct {
+	PaymentFileID        uuid.UUID
+	Position             int32
+	PaymentInformationID string
+	InstructionID        pgtype.Text
+	EndToEndID           string
+	Amount               pgtype.Numeric
+	CurrencyCode         string
+	CreditorAccount      string
+	Status               string
+	ReasonCode           pgtype.Text
+	Reason               pgtype.Text
+	CreatedAt            pgtype.Timestamptz
+}
+
+func (q *Queries) AddPayment(ctx context.Context, arg AddPaymentParams) (Payment, error) {
+	row := q.db.QueryRow(ctx, addPayment,
+		arg.PaymentFileID,
+		arg.Position,
+		arg.PaymentInformationID,
+		arg.InstructionID,
+		arg.EndToEndID,
+		arg.Amount,
+		arg.CurrencyCode,
+		arg.CreditorAccount,
+		arg.Status,
+		arg.ReasonCode,
+		arg.Reason,
+		arg.CreatedAt,
+	)
+	var i Payment
+	err := row.Scan(
+		&i.PaymentID,
+		&i.PaymentFileID,
+		&i.Position,
+		&i.PaymentInformationID,
+		&i.InstructionID,
+		&i.EndToEndID,
+		&i.Amount,
+		&i.CurrencyCode,
+		&i.CreditorAccount,
+		&i.Status,
+		&i.ReasonCode,
+		&i.Reason,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.TransferApprovalID,
+		&i.PendingTransferID,
+	)
+	return i, err
+}
+
+type AddSanctionsEntriesParams struct {
+	List      string
+	Reference string
+	Name      string
+}
+
+const addSanctionsScreening = `-- name: AddSanctionsScreening :one
+INSERT INTO "sanctions_screening"(account_id, name, status, matches, created_at)
+    VALUES ($1, $2, $3, $4, $5)
+RETURNING
+    screening_id, account_id, name, status, matches, created_at, resolved_at
+`
+
+type AddSanctionsScreeningParams struct {
+	AccountID uuid.UUID
+	Name      string
+	Status    string
+	Matches   []byte
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) AddSanctionsScreening(ctx context.Context, arg AddSanctionsScreeningParams) (SanctionsScreening, error) {
+	row := q.db.QueryRow(ctx, addSanctionsScreening,
+		arg.AccountID,
+		arg.Name,
+		arg.Status,
+		arg.Matches,
+		arg.CreatedAt,
+	)
+	var i SanctionsScreening
+	err := row.Scan(
+		&i.ScreeningID,
+		&i.AccountID,
+		&i.Name,
+		&i.Status,
+		&i.Matches,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
 const addTransaction = `-- name: AddTransaction :one
-INSERT INTO "transaction"(account_id, amount, source_id)
-    VALUES ($1, $2, $3)
+WITH numbered AS (
+    UPDATE
+        "account"
+    SET
+        last_transaction_sequence = last_transaction_sequence + 1
+    WHERE
+        account_id = $1
+    RETURNING
+        last_transaction_sequence)
+INSERT INTO "transaction"(account_id, amount, source_id, type, account_sequence)
+SELECT
+    $1,
+    $2,
+    $3,
+    $4,
+    numbered.last_transaction_sequence
+FROM
+    numbered
 RETURNING
-    transaction_id, account_id, amount, source_id
+    transaction_id, account_id, amount, source_id, created_at, type, account_sequence
 `
 
 type AddTransactionParams struct {
 	AccountID uuid.UUID
 	Amount    pgtype.Numeric
 	SourceID  uuid.NullUUID
+	Type      string
 }
 
+// Numbers the transaction by the next sequence of its account, whose row is locked until the end of the database
+// transaction, so that the transactions of an account are numbered in the order they commit. No transaction is added
+// when the account is not found.
 func (q *Queries) AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error) {
-	row := q.db.QueryRow(ctx, addTransaction, arg.AccountID, arg.Amount, arg.SourceID)
+	row := q.db.QueryRow(ctx, addTransaction,
+		arg.AccountID,
+		arg.Amount,
+		arg.SourceID,
+		arg.Type,
+	)
 	var i Transaction
 	err := row.Scan(
 		&i.TransactionID,
 		&i.AccountID,
 		&i.Amount,
 		&i.SourceID,
+		&i.CreatedAt,
+		&i.Type,
+		&i.AccountSequence,
 	)
 	return i, err
 }
 
-const createAccount = `-- name: CreateAccount :one
-INSERT INTO "account"(email, name, currency_code)
+const addWebhookDelivery = `-- name: AddWebhookDelivery :exec
+INSERT INTO "webhook_delivery"(webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $6, $6)
+ON CONFLICT (webhook_id, event_id)
+    DO NOTHING
+`
+
+type AddWebhookDeliveryParams struct {
+	WebhookID uuid.UUID
+	EventID   uuid.UUID
+	EventType string
+	Payload   []byte
+	Status    string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) AddWebhookDelivery(ctx context.Context, arg AddWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, addWebhookDelivery,
+		arg.WebhookID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.Status,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const addWebhookDeliveryAttempt = `-- name: AddWebhookDeliveryAttempt :exec
+INSERT INTO "webhook_delivery_attempt"(webhook_delivery_id, attempted_at, status_code, error, duration_ms)
+    VALUES ($1, $2, $3, $4, $5)
+`
+
+type AddWebhookDeliveryAttemptParams struct {
+	WebhookDeliveryID uuid.UUID
+	AttemptedAt       pgtype.Timestamptz
+	StatusCode        pgtype.Int4
+	Error             pgtype.Text
+	DurationMs        int64
+}
+
+func (q *Queries) AddWebhookDeliveryAttempt(ctx context.Context, arg AddWebhookDeliveryAttemptParams) error {
+	_, err := q.db.Exec(ctx, addWebhookDeliveryAttempt,
+		arg.WebhookDeliveryID,
+		arg.AttemptedAt,
+		arg.StatusCode,
+		arg.Error,
+		arg.DurationMs,
+	)
+	return err
+}
+
+const capitalizeInterest = `-- name: CapitalizeInterest :exec
+UPDATE
+    "interest_accrual"
+SET
+    transaction_id = $1
+WHERE
+    account_id = $2
+    AND transaction_id IS NULL
+    AND day < $3
+`
+
+type CapitalizeInterestParams struct {
+	TransactionID uuid.NullUUID
+	AccountID     uuid.UUID
+	Before        pgtype.Date
+}
+
+func (q *Queries) CapitalizeInterest(ctx context.Context, arg CapitalizeInterestParams) error {
+	_, err := q.db.Exec(ctx, capitalizeInterest, arg.TransactionID, arg.AccountID, arg.Before)
+	return err
+}
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+WITH due AS (
+    SELECT
+        webhook_delivery.webhook_delivery_id
+    FROM
+        "webhook_delivery"
+    WHERE
+        webhook_delivery.status = 'pending'
+        AND webhook_delivery.next_attempt_at <= $2
+    ORDER BY
+        webhook_delivery.next_attempt_at
+    LIMIT $3
+    FOR UPDATE
+        SKIP LOCKED)
+UPDATE
+    "webhook_delivery" d
+SET
+    next_attempt_at = $1
+FROM
+    due,
+    "webhook" w
+WHERE
+    d.webhook_delivery_id = due.webhook_delivery_id
+    AND w.webhook_id = d.webhook_id
+RETURNING
+    d.webhook_delivery_id,
+    d.event_id,
+    d.event_type,
+    d.payload,
+    d.attempts,
+    w.url,
+    w.secret
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeasedUntil pgtype.Timestamptz
+	Now         pgtype.Timestamptz
+	Limit       int32
+}
+
+type ClaimDueWebhookDeliveriesRow struct {
+	WebhookDeliveryID uuid.UUID
+	EventID           uuid.UUID
+	EventType         string
+	Payload           []byte
+	Attempts          int32
+	Url               string
+	Secret            string
+}
+
+// The due deliveries are leased until leased_until, when they are due again unless their attempt was recorded.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeasedUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDueWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.WebhookDeliveryID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :execrows
+INSERT INTO "idempotency_key"(principal, key, request_hash)
     VALUES ($1, $2, $3)
+ON CONFLICT (principal, key)
+    DO UPDATE SET
+        request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL, response_body = NULL,
+            created_at = now()
+    WHERE ("idempotency_key".status_code IS NULL
+        AND "idempotency_key".created_at < $4)
+        OR "idempotency_key".created_at < $5
+`
+
+type ClaimIdempotencyKeyParams struct {
+	Principal     string
+	Key           string
+	RequestHash   []byte
+	ClaimedBefore pgtype.Timestamptz
+	ExpiredBefore pgtype.Timestamptz
+}
+
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimIdempotencyKey,
+		arg.Principal,
+		arg.Key,
+		arg.RequestHash,
+		arg.ClaimedBefore,
+		arg.ExpiredBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countTransfersSince = `-- name: CountTransfersSince :one
+SELECT
+    COUNT(*)::integer
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= $2
+`
+
+type CountTransfersSinceParams struct {
+	AccountID uuid.UUID
+	Since     pgtype.Timestamptz
+}
+
+func (q *Queries) CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countTransfersSince, arg.AccountID, arg.Since)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createAccount = `-- name: CreateAccount :one
+WITH c AS (
+INSERT INTO "customer"(name, email)
+    SELECT
+        $2::varchar,
+        $1::varchar
+    WHERE
+        $7::uuid IS NULL
+    RETURNING
+        customer_id)
+INSERT INTO "account"(email, name, currency_code, account_number, iban, product_code, customer_id)
+    VALUES ($1, $2, $3, $4, $5,
+        $6, COALESCE($7::uuid,(
+                SELECT
+                    customer_id
+                FROM c)))
 RETURNING
-    account_id, email, name, currency_code
+    account_id, email, name, currency_code, account_number, iban, screening_status, overdraft_limit, product_code, customer_id, approval_threshold, parent_account_id, goal_amount, goal_date, last_event_sequence, last_transaction_sequence
 `
 
 type CreateAccountParams struct {
-	Email        string
-	Name         string
-	CurrencyCode string
+	Email         string
+	Name          string
+	CurrencyCode  string
+	AccountNumber int64
+	IBAN          pgtype.Text
+	ProductCode   string
+	CustomerID    uuid.NullUUID
 }
 
+// The account is opened for the customer of customer_id, or for a new customer of its name and email when it is null.
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRow(ctx, createAccount, arg.Email, arg.Name, arg.CurrencyCode)
+	row := q.db.QueryRow(ctx, createAccount,
+		arg.Email,
+		arg.Name,
+		arg.CurrencyCode,
+		arg.AccountNumber,
+		arg.IBAN,
+		arg.ProductCode,
+		arg.CustomerID,
+	)
 	var i Account
 	err := row.Scan(
 		&i.AccountID,
 		&i.Email,
 		&i.Name,
 		&i.CurrencyCode,
+		&i.AccountNumber,
+		&i.IBAN,
+		&i.ScreeningStatus,
+		&i.OverdraftLimit,
+		&i.ProductCode,
+		&i.CustomerID,
+		&i.ApprovalThreshold,
+		&i.ParentAccountID,
+		&i.GoalAmount,
+		&i.GoalDate,
+		&i.LastEventSequence,
+		&i.LastTransactionSequence,
 	)
 	return i, err
 }
 
-const getAccount = `-- name: GetAccount :one
+const createBeneficiary = `-- name: CreateBeneficiary :one
+INSERT INTO "beneficiary"(account_id, nickname, reciver_account_id, transfer_limit, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $5)
+RETURNING
+    beneficiary_id, account_id, nickname, reciver_account_id, transfer_limit, created_at, updated_at
+`
+
+type CreateBeneficiaryParams struct {
+	AccountID        uuid.UUID
+	Nickname         string
+	ReciverAccountID uuid.UUID
+	TransferLimit    pgtype.Numeric
+	CreatedAt        pgtype.Timestamptz
+}
+
+func (q *Queries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, createBeneficiary,
+		arg.AccountID,
+		arg.Nickname,
+		arg.ReciverAccountID,
+		arg.TransferLimit,
+		arg.CreatedAt,
+	)
+	var i Beneficiary
+	err := row.Scan(
+		&i.BeneficiaryID,
+		&i.AccountID,
+		&i.Nickname,
+		&i.ReciverAccountID,
+		&i.TransferLimit,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createCustomer = `-- name: CreateCustomer :one
+INSERT INTO "customer"(name, email, phone)
+    VALUES ($1, $2, $3)
+RETURNING
+    customer_id, name, email, phone, kyc_status, created_at, updated_at
+`
+
+type CreateCustomerParams struct {
+	Name  string
+	Email string
+	Phone pgtype.Text
+}
+
+func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, createCustomer, arg.Name, arg.Email, arg.Phone)
+	var i Customer
+	err := row.Scan(
+		&i.CustomerID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.KYCStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPaymentFile = `-- name: CreatePaymentFile :one
+INSERT INTO "payment_file"(account_id, message_id, message_created_at, number_of_transactions, control_sum, status, reason_code, reason, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+RETURNING
+    payment_file_id, account_id, message_id, message_created_at, number_of_transactions, control_sum, status, reason_code, reason, created_at, updated_at
+`
+
+type CreatePaymentFileParams struct {
+	AccountID            uuid.UUID
+	MessageID            string
+	MessageCreatedAt     pgtype.Timestamptz
+	NumberOfTransactions int32
+	ControlSum           pgtype.Numeric
+	Status               string
+	ReasonCode           pgtype.Text
+	Reason               pgtype.Text
+	CreatedAt            pgtype.Timestamptz
+}
+
+func (q *Queries) CreatePaymentFile(ctx context.Context, arg CreatePaymentFileParams) (PaymentFile, error) {
+	row := q.db.QueryRow(ctx, createPaymentFile,
+		arg.AccountID,
+		arg.MessageID,
+		arg.MessageCreatedAt,
+		arg.NumberOfTransactions,
+		arg.ControlSum,
+		arg.Status,
+		arg.ReasonCode,
+		arg.Reason,
+		arg.CreatedAt,
+	)
+	var i PaymentFile
+	err := row.Scan(
+		&i.PaymentFileID,
+		&i.AccountID,
+		&i.MessageID,
+		&i.MessageCreatedAt,
+		&i.NumberOfTransactions,
+		&i.ControlSum,
+		&i.Status,
+		&i.ReasonCode,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPendingTransfer = `-- name: CreatePendingTransfer :one
+INSERT INTO "pending_transfer"(account_id, reciver_account_id, amount, currency_code, status, rules, created_at)
+    VALUES ($1, $2, $3, $4, 'pending', $5, $6)
+RETURNING
+    pending_transfer_id, account_id, reciver_account_id, amount, status, rules, transaction_id, created_at, decided_at, currency_code
+`
+
+type CreatePendingTransferParams struct {
+	AccountID        uuid.UUID
+	ReciverAccountID uuid.UUID
+	Amount           pgtype.Numeric
+	CurrencyCode     string
+	Rules            []string
+	CreatedAt        pgtype.Timestamptz
+}
+
+func (q *Queries) CreatePendingTransfer(ctx context.Context, arg CreatePendingTransferParams) (PendingTransfer, error) {
+	row := q.db.QueryRow(ctx, createPendingTransfer,
+		arg.AccountID,
+		arg.ReciverAccountID,
+		arg.Amount,
+		arg.CurrencyCode,
+		arg.Rules,
+		arg.CreatedAt,
+	)
+	var i PendingTransfer
+	err := row.Scan(
+		&i.PendingTransferID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.Rules,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const createPocket = `-- name: CreatePocket :one
+INSERT INTO "account"(email, name, currency_code, account_number, product_code, customer_id, screening_status,
+    parent_account_id)
 SELECT
-    account_id, email, name, currency_code
+    parent.email,
+    $1,
+    parent.currency_code,
+    $2,
+    parent.product_code,
+    parent.customer_id,
+    parent.screening_status,
+    parent.account_id
 FROM
-    "account"
+    "account" parent
 WHERE
-    account_id = $1
+    parent.account_id = $3
+RETURNING
+    account_id, email, name, currency_code, account_number, iban, screening_status, overdraft_limit, product_code, customer_id, approval_threshold, parent_account_id, goal_amount, goal_date, last_event_sequence, last_transaction_sequence
 `
 
-func (q *Queries) GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error) {
-	row := q.db.QueryRow(ctx, getAccount, accountID)
+type CreatePocketParams struct {
+	Name            string
+	AccountNumber   int64
+	ParentAccountID uuid.UUID
+}
+
+// A pocket is opened for the customer of its parent account, in its currency and for its product.
+func (q *Queries) CreatePocket(ctx context.Context, arg CreatePocketParams) (Account, error) {
+	row := q.db.QueryRow(ctx, createPocket, arg.Name, arg.AccountNumber, arg.ParentAccountID)
 	var i Account
 	err := row.Scan(
 		&i.AccountID,
 		&i.Email,
 		&i.Name,
 		&i.CurrencyCode,
+		&i.AccountNumber,
+		&i.IBAN,
+		&i.ScreeningStatus,
+		&i.OverdraftLimit,
+		&i.ProductCode,
+		&i.CustomerID,
+		&i.ApprovalThreshold,
+		&i.ParentAccountID,
+		&i.GoalAmount,
+		&i.GoalDate,
+		&i.LastEventSequence,
+		&i.LastTransactionSequence,
 	)
 	return i, err
 }
 
-const getAccountTotalAmount = `-- name: GetAccountTotalAmount :one
-SELECT
-    SUM(amount)::numeric
-FROM
-    "transaction"
-WHERE
-    account_id = $1
+const createTransferApproval = `-- name: CreateTransferApproval :one
+INSERT INTO "transfer_approval"(account_id, reciver_account_id, amount, currency_code, status, initiated_by, created_at)
+    VALUES ($1, $2, $3, $4, 'pending', $5, $6)
+RETURNING
+    transfer_approval_id, account_id, reciver_account_id, amount, status, initiated_by, decided_by, transaction_id, created_at, decided_at, currency_code
 `
 
-func (q *Queries) GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error) {
-	row := q.db.QueryRow(ctx, getAccountTotalAmount, accountID)
-	var column_1 pgtype.Numeric
-	err := row.Scan(&column_1)
-	return column_1, err
+type CreateTransferApprovalParams struct {
+	AccountID        uuid.UUID
+	ReciverAccountID uuid.UUID
+	Amount           pgtype.Numeric
+	CurrencyCode     string
+	InitiatedBy      string
+	CreatedAt        pgtype.Timestamptz
 }
 
-const hasAccount = `-- name: HasAccount :one
-SELECT
-    EXISTS (
-        SELECT
-            1
-        FROM
-            "account"
-        WHERE
-            account_id = $1)
+func (q *Queries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRow(ctx, createTransferApproval,
+		arg.AccountID,
+		arg.ReciverAccountID,
+		arg.Amount,
+		arg.CurrencyCode,
+		arg.InitiatedBy,
+		arg.CreatedAt,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.TransferApprovalID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.InitiatedBy,
+		&i.DecidedBy,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO "webhook"(url, event_types, account_id, secret)
+    VALUES ($1, $2, $3, $4)
+RETURNING
+    webhook_id, url, event_types, account_id, secret, created_at
 `
 
-func (q *Queries) HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error) {
-	row := q.db.QueryRow(ctx, hasAccount, accountID)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+type CreateWebhookParams struct {
+	Url        string
+	EventTypes []string
+	AccountID  uuid.NullUUID
+	Secret     string
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, createWebhook,
+		arg.Url,
+		arg.EventTypes,
+		arg.AccountID,
+		arg.Secret,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.EventTypes,
+		&i.AccountID,
+		&i.Secret,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const decidePendingTransfer = `-- name: DecidePendingTransfer :one
+UPDATE
+    "pending_transfer"
+SET
+    status = $1,
+    decided_at = $2
+WHERE
+    pending_transfer_id = $3
+    AND status = 'pending'
+RETURNING
+    pending_transfer_id, account_id, reciver_account_id, amount, status, rules, transaction_id, created_at, decided_at, currency_code
+`
+
+type DecidePendingTransferParams struct {
+	Status            string
+	DecidedAt         pgtype.Timestamptz
+	PendingTransferID uuid.UUID
+}
+
+func (q *Queries) DecidePendingTransfer(ctx context.Context, arg DecidePendingTransferParams) (PendingTransfer, error) {
+	row := q.db.QueryRow(ctx, decidePendingTransfer, arg.Status, arg.DecidedAt, arg.PendingTransferID)
+	var i PendingTransfer
+	err := row.Scan(
+		&i.PendingTransferID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.Rules,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const decideTransferApproval = `-- name: DecideTransferApproval :one
+UPDATE
+    "transfer_approval"
+SET
+    status = $1,
+    decided_by = $2,
+    decided_at = $3
+WHERE
+    transfer_approval_id = $4
+    AND status = 'pending'
+RETURNING
+    transfer_approval_id, account_id, reciver_account_id, amount, status, initiated_by, decided_by, transaction_id, created_at, decided_at, currency_code
+`
+
+type DecideTransferApprovalParams struct {
+	Status             string
+	DecidedBy          pgtype.Text
+	DecidedAt          pgtype.Timestamptz
+	TransferApprovalID uuid.UUID
+}
+
+func (q *Queries) DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRow(ctx, decideTransferApproval,
+		arg.Status,
+		arg.DecidedBy,
+		arg.DecidedAt,
+		arg.TransferApprovalID,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.TransferApprovalID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.InitiatedBy,
+		&i.DecidedBy,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const deleteAccountHolder = `-- name: DeleteAccountHolder :execrows
+DELETE FROM "account_holder"
+WHERE account_id = $1
+    AND customer_id = $2
+    AND role <> 'owner'
+`
+
+type DeleteAccountHolderParams struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+}
+
+func (q *Queries) DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAccountHolder, arg.AccountID, arg.CustomerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteBeneficiary = `-- name: DeleteBeneficiary :execrows
+DELETE FROM "beneficiary"
+WHERE account_id = $1
+    AND beneficiary_id = $2
+`
+
+type DeleteBeneficiaryParams struct {
+	AccountID     uuid.UUID
+	BeneficiaryID uuid.UUID
+}
+
+func (q *Queries) DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBeneficiary, arg.AccountID, arg.BeneficiaryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM "idempotency_key"
+WHERE created_at < $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencyKeys, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFeeSchedule = `-- name: DeleteFeeSchedule :execrows
+DELETE FROM "fee_schedule"
+WHERE product_code = $1
+    AND fee_type = $2
+`
+
+type DeleteFeeScheduleParams struct {
+	ProductCode string
+	FeeType     string
+}
+
+func (q *Queries) DeleteFeeSchedule(ctx context.Context, arg DeleteFeeScheduleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFeeSchedule, arg.ProductCode, arg.FeeType)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM "idempotency_key"
+WHERE principal = $1
+    AND key = $2
+`
+
+type DeleteIdempotencyKeyParams struct {
+	Principal string
+	Key       string
+}
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, deleteIdempotencyKey, arg.Principal, arg.Key)
+	return err
+}
+
+const deleteSanctionsEntries = `-- name: DeleteSanctionsEntries :exec
+DELETE FROM "sanctions_entry"
+WHERE list = $1
+`
+
+func (q *Queries) DeleteSanctionsEntries(ctx context.Context, list string) error {
+	_, err := q.db.Exec(ctx, deleteSanctionsEntries, list)
+	return err
+}
+
+const executePayment = `-- name: ExecutePayment :execrows
+UPDATE
+    "payment"
+SET
+    status = $1,
+    reason_code = $2,
+    reason = $3,
+    transaction_id = $4,
+    transfer_approval_id = $5,
+    pending_transfer_id = $6,
+    updated_at = $7
+WHERE
+    payment_id = $8
+    AND status = 'PDNG'
+    AND transaction_id IS NULL
+    AND transfer_approval_id IS NULL
+    AND pending_transfer_id IS NULL
+`
+
+type ExecutePaymentParams struct {
+	Status             string
+	ReasonCode         pgtype.Text
+	Reason             pgtype.Text
+	TransactionID      uuid.NullUUID
+	TransferApprovalID uuid.NullUUID
+	PendingTransferID  uuid.NullUUID
+	UpdatedAt          pgtype.Timestamptz
+	PaymentID          uuid.UUID
+}
+
+// Saves the outcome of the transfer of a payment unless it was executed already: its transfer was made or held, so
+// that a payment is executed once.
+func (q *Queries) ExecutePayment(ctx context.Context, arg ExecutePaymentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, executePayment,
+		arg.Status,
+		arg.ReasonCode,
+		arg.Reason,
+		arg.TransactionID,
+		arg.TransferApprovalID,
+		arg.PendingTransferID,
+		arg.UpdatedAt,
+		arg.PaymentID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT
+    account_id, email, name, currency_code, account_number, iban, screening_status, overdraft_limit, product_code, customer_id, approval_threshold, parent_account_id, goal_amount, goal_date, last_event_sequence, last_transaction_sequence
+FROM
+    "account"
+WHERE
+    account_id = $1
+`
+
+func (q *Queries) GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccount, accountID)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Email,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.AccountNumber,
+		&i.IBAN,
+		&i.ScreeningStatus,
+		&i.OverdraftLimit,
+		&i.ProductCode,
+		&i.CustomerID,
+		&i.ApprovalThreshold,
+		&i.ParentAccountID,
+		&i.GoalAmount,
+		&i.GoalDate,
+		&i.LastEventSequence,
+		&i.LastTransactionSequence,
+	)
+	return i, err
+}
+
+const getAccountBalanceBefore = `-- name: GetAccountBalanceBefore :one
+SELECT
+    COALESCE(SUM(amount), 0)::numeric
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+    AND created_at < $2
+`
+
+type GetAccountBalanceBeforeParams struct {
+	AccountID uuid.UUID
+	Before    pgtype.Timestamptz
+}
+
+func (q *Queries) GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getAccountBalanceBefore, arg.AccountID, arg.Before)
+	var column_1 pgtype.Numeric
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getAccountByIBAN = `-- name: GetAccountByIBAN :one
+SELECT
+    account_id, email, name, currency_code, account_number, iban, screening_status, overdraft_limit, product_code, customer_id, approval_threshold, parent_account_id, goal_amount, goal_date, last_event_sequence, last_transaction_sequence
+FROM
+    "account"
+WHERE
+    iban = $1
+`
+
+func (q *Queries) GetAccountByIBAN(ctx context.Context, iban pgtype.Text) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByIBAN, iban)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Email,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.AccountNumber,
+		&i.IBAN,
+		&i.ScreeningStatus,
+		&i.OverdraftLimit,
+		&i.ProductCode,
+		&i.CustomerID,
+		&i.ApprovalThreshold,
+		&i.ParentAccountID,
+		&i.GoalAmount,
+		&i.GoalDate,
+		&i.LastEventSequence,
+		&i.LastTransactionSequence,
+	)
+	return i, err
+}
+
+const getAccountHolderRole = `-- name: GetAccountHolderRole :one
+SELECT
+    role
+FROM
+    "account_holder"
+WHERE
+    account_id = $1
+    AND customer_id = $2
+`
+
+type GetAccountHolderRoleParams struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+}
+
+func (q *Queries) GetAccountHolderRole(ctx context.Context, arg GetAccountHolderRoleParams) (string, error) {
+	row := q.db.QueryRow(ctx, getAccountHolderRole, arg.AccountID, arg.CustomerID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getAccountLimits = `-- name: GetAccountLimits :one
+SELECT
+    limit_tier.tier,
+    COALESCE(account_limit.max_transfer, limit_tier.max_transfer) AS max_transfer,
+    COALESCE(account_limit.daily_amount, limit_tier.daily_amount) AS daily_amount,
+    COALESCE(account_limit.monthly_amount, limit_tier.monthly_amount) AS monthly_amount,
+    COALESCE(account_limit.daily_count, limit_tier.daily_count) AS daily_count
+FROM
+    "limit_tier"
+    LEFT JOIN "account_limit" ON account_limit.account_id = $1
+WHERE
+    limit_tier.tier = COALESCE(account_limit.tier, (
+            SELECT
+                account_product.limit_tier
+            FROM "account"
+            JOIN "account_product" ON account_product.product_code = account.product_code
+            WHERE
+                account.account_id = $1), 'standard')
+`
+
+type GetAccountLimitsRow struct {
+	Tier          string
+	MaxTransfer   pgtype.Numeric
+	DailyAmount   pgtype.Numeric
+	MonthlyAmount pgtype.Numeric
+	DailyCount    pgtype.Int4
+}
+
+func (q *Queries) GetAccountLimits(ctx context.Context, accountID uuid.UUID) (GetAccountLimitsRow, error) {
+	row := q.db.QueryRow(ctx, getAccountLimits, accountID)
+	var i GetAccountLimitsRow
+	err := row.Scan(
+		&i.Tier,
+		&i.MaxTransfer,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+	)
+	return i, err
+}
+
+const getAccountProduct = `-- name: GetAccountProduct :one
+SELECT
+    product_code, name, interest_rate, day_count, created_at, updated_at, currency_codes, limit_tier, overdraft_eligible, max_overdraft_limit
+FROM
+    "account_product"
+WHERE
+    product_code = $1
+`
+
+func (q *Queries) GetAccountProduct(ctx context.Context, productCode string) (AccountProduct, error) {
+	row := q.db.QueryRow(ctx, getAccountProduct, productCode)
+	var i AccountProduct
+	err := row.Scan(
+		&i.ProductCode,
+		&i.Name,
+		&i.InterestRate,
+		&i.DayCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CurrencyCodes,
+		&i.LimitTier,
+		&i.OverdraftEligible,
+		&i.MaxOverdraftLimit,
+	)
+	return i, err
+}
+
+const getAccountTotalAmount = `-- name: GetAccountTotalAmount :one
+SELECT
+    SUM(amount)::numeric
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+`
+
+func (q *Queries) GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getAccountTotalAmount, accountID)
+	var column_1 pgtype.Numeric
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getBeneficiary = `-- name: GetBeneficiary :one
+SELECT
+    beneficiary.beneficiary_id, beneficiary.account_id, beneficiary.nickname, beneficiary.reciver_account_id, beneficiary.transfer_limit, beneficiary.created_at, beneficiary.updated_at,
+    account.iban AS reciver_iban
+FROM
+    "beneficiary"
+    JOIN "account" ON account.account_id = beneficiary.reciver_account_id
+WHERE
+    beneficiary.account_id = $1
+    AND beneficiary.beneficiary_id = $2
+`
+
+type GetBeneficiaryParams struct {
+	AccountID     uuid.UUID
+	BeneficiaryID uuid.UUID
+}
+
+type GetBeneficiaryRow struct {
+	Beneficiary Beneficiary
+	ReciverIBAN pgtype.Text
+}
+
+func (q *Queries) GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (GetBeneficiaryRow, error) {
+	row := q.db.QueryRow(ctx, getBeneficiary, arg.AccountID, arg.BeneficiaryID)
+	var i GetBeneficiaryRow
+	err := row.Scan(
+		&i.Beneficiary.BeneficiaryID,
+		&i.Beneficiary.AccountID,
+		&i.Beneficiary.Nickname,
+		&i.Beneficiary.ReciverAccountID,
+		&i.Beneficiary.TransferLimit,
+		&i.Beneficiary.CreatedAt,
+		&i.Beneficiary.UpdatedAt,
+		&i.ReciverIBAN,
+	)
+	return i, err
+}
+
+const getCustomer = `-- name: GetCustomer :one
+SELECT
+    customer_id, name, email, phone, kyc_status, created_at, updated_at
+FROM
+    "customer"
+WHERE
+    customer_id = $1
+`
+
+func (q *Queries) GetCustomer(ctx context.Context, customerID uuid.UUID) (Customer, error) {
+	row := q.db.QueryRow(ctx, getCustomer, customerID)
+	var i Customer
+	err := row.Scan(
+		&i.CustomerID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.KYCStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getFeeSchedule = `-- name: GetFeeSchedule :one
+SELECT
+    product_code, fee_type, kind, amount, rate, min_amount, max_amount, tiers, created_at, updated_at
+FROM
+    "fee_schedule"
+WHERE
+    product_code = $1
+    AND fee_type = $2
+`
+
+type GetFeeScheduleParams struct {
+	ProductCode string
+	FeeType     string
+}
+
+func (q *Queries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRow(ctx, getFeeSchedule, arg.ProductCode, arg.FeeType)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ProductCode,
+		&i.FeeType,
+		&i.Kind,
+		&i.Amount,
+		&i.Rate,
+		&i.MinAmount,
+		&i.MaxAmount,
+		&i.Tiers,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT
+    key, request_hash, status_code, content_type, response_body, created_at, principal
+FROM
+    "idempotency_key"
+WHERE
+    principal = $1
+    AND key = $2
+`
+
+type GetIdempotencyKeyParams struct {
+	Principal string
+	Key       string
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, arg.Principal, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.RequestHash,
+		&i.StatusCode,
+		&i.ContentType,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.Principal,
+	)
+	return i, err
+}
+
+const getLastAuditEventHash = `-- name: GetLastAuditEventHash :one
+SELECT
+    hash
+FROM
+    "audit_event"
+ORDER BY
+    audit_event_id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastAuditEventHash(ctx context.Context) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getLastAuditEventHash)
+	var hash []byte
+	err := row.Scan(&hash)
+	return hash, err
+}
+
+const getLastOverdraftInterestDay = `-- name: GetLastOverdraftInterestDay :one
+SELECT
+    MAX(day)::date AS day
+FROM
+    "overdraft_interest"
+`
+
+// Returns the last day interest was charged for on negative balances, null when none was.
+func (q *Queries) GetLastOverdraftInterestDay(ctx context.Context) (pgtype.Date, error) {
+	row := q.db.QueryRow(ctx, getLastOverdraftInterestDay)
+	var day pgtype.Date
+	err := row.Scan(&day)
+	return day, err
+}
+
+const getOverdraftTerms = `-- name: GetOverdraftTerms :one
+SELECT
+    account.currency_code,
+    account_product.overdraft_eligible,
+    account_product.max_overdraft_limit
+FROM
+    "account"
+    JOIN "account_product" ON account_product.product_code = account.product_code
+WHERE
+    account.account_id = $1
+`
+
+type GetOverdraftTermsRow struct {
+	CurrencyCode      string
+	OverdraftEligible bool
+	MaxOverdraftLimit pgtype.Numeric
+}
+
+func (q *Queries) GetOverdraftTerms(ctx context.Context, accountID uuid.UUID) (GetOverdraftTermsRow, error) {
+	row := q.db.QueryRow(ctx, getOverdraftTerms, accountID)
+	var i GetOverdraftTermsRow
+	err := row.Scan(&i.CurrencyCode, &i.OverdraftEligible, &i.MaxOverdraftLimit)
+	return i, err
+}
+
+const getPaymentFile = `-- name: GetPaymentFile :one
+SELECT
+    payment_file_id, account_id, message_id, message_created_at, number_of_transactions, control_sum, status, reason_code, reason, created_at, updated_at
+FROM
+    "payment_file"
+WHERE
+    account_id = $1
+    AND payment_file_id = $2
+`
+
+type GetPaymentFileParams struct {
+	AccountID     uuid.UUID
+	PaymentFileID uuid.UUID
+}
+
+func (q *Queries) GetPaymentFile(ctx context.Context, arg GetPaymentFileParams) (PaymentFile, error) {
+	row := q.db.QueryRow(ctx, getPaymentFile, arg.AccountID, arg.PaymentFileID)
+	var i PaymentFile
+	err := row.Scan(
+		&i.PaymentFileID,
+		&i.AccountID,
+		&i.MessageID,
+		&i.MessageCreatedAt,
+		&i.NumberOfTransactions,
+		&i.ControlSum,
+		&i.Status,
+		&i.ReasonCode,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPaymentFileByMessageID = `-- name: GetPaymentFileByMessageID :one
+SELECT
+    payment_file_id, account_id, message_id, message_created_at, number_of_transactions, control_sum, status, reason_code, reason, created_at, updated_at
+FROM
+    "payment_file"
+WHERE
+    account_id = $1
+    AND message_id = $2
+`
+
+type GetPaymentFileByMessageIDParams struct {
+	AccountID uuid.UUID
+	MessageID string
+}
+
+func (q *Queries) GetPaymentFileByMessageID(ctx context.Context, arg GetPaymentFileByMessageIDParams) (PaymentFile, error) {
+	row := q.db.QueryRow(ctx, getPaymentFileByMessageID, arg.AccountID, arg.MessageID)
+	var i PaymentFile
+	err := row.Scan(
+		&i.PaymentFileID,
+		&i.AccountID,
+		&i.MessageID,
+		&i.MessageCreatedAt,
+		&i.NumberOfTransactions,
+		&i.ControlSum,
+		&i.Status,
+		&i.ReasonCode,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPendingTransfer = `-- name: GetPendingTransfer :one
+SELECT
+    pending_transfer_id, account_id, reciver_account_id, amount, status, rules, transaction_id, created_at, decided_at, currency_code
+FROM
+    "pending_transfer"
+WHERE
+    pending_transfer_id = $1
+`
+
+func (q *Queries) GetPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (PendingTransfer, error) {
+	row := q.db.QueryRow(ctx, getPendingTransfer, pendingTransferID)
+	var i PendingTransfer
+	err := row.Scan(
+		&i.PendingTransferID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.Rules,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const getPocket = `-- name: GetPocket :one
+SELECT
+    account.account_id, account.email, account.name, account.currency_code, account.account_number, account.iban, account.screening_status, account.overdraft_limit, account.product_code, account.customer_id, account.approval_threshold, account.parent_account_id, account.goal_amount, account.goal_date, account.last_event_sequence, account.last_transaction_sequence,
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    account.account_id = $1
+    AND account.parent_account_id = $2
+GROUP BY
+    account.account_id
+`
+
+type GetPocketParams struct {
+	PocketID        uuid.UUID
+	ParentAccountID uuid.NullUUID
+}
+
+type GetPocketRow struct {
+	Account Account
+	Balance pgtype.Numeric
+}
+
+func (q *Queries) GetPocket(ctx context.Context, arg GetPocketParams) (GetPocketRow, error) {
+	row := q.db.QueryRow(ctx, getPocket, arg.PocketID, arg.ParentAccountID)
+	var i GetPocketRow
+	err := row.Scan(
+		&i.Account.AccountID,
+		&i.Account.Email,
+		&i.Account.Name,
+		&i.Account.CurrencyCode,
+		&i.Account.AccountNumber,
+		&i.Account.IBAN,
+		&i.Account.ScreeningStatus,
+		&i.Account.OverdraftLimit,
+		&i.Account.ProductCode,
+		&i.Account.CustomerID,
+		&i.Account.ApprovalThreshold,
+		&i.Account.ParentAccountID,
+		&i.Account.GoalAmount,
+		&i.Account.GoalDate,
+		&i.Account.LastEventSequence,
+		&i.Account.LastTransactionSequence,
+		&i.Balance,
+	)
+	return i, err
+}
+
+const getSanctionsScreening = `-- name: GetSanctionsScreening :one
+SELECT
+    screening_id, account_id, name, status, matches, created_at, resolved_at
+FROM
+    "sanctions_screening"
+WHERE
+    screening_id = $1
+`
+
+func (q *Queries) GetSanctionsScreening(ctx context.Context, screeningID uuid.UUID) (SanctionsScreening, error) {
+	row := q.db.QueryRow(ctx, getSanctionsScreening, screeningID)
+	var i SanctionsScreening
+	err := row.Scan(
+		&i.ScreeningID,
+		&i.AccountID,
+		&i.Name,
+		&i.Status,
+		&i.Matches,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const getSanctionsVersion = `-- name: GetSanctionsVersion :one
+SELECT
+    COUNT(*)::integer AS lists,
+    COALESCE(SUM(entries), 0)::integer AS entries,
+    COALESCE(MAX(imported_at), '-infinity')::timestamptz AS imported_at
+FROM
+    "sanctions_list"
+`
+
+type GetSanctionsVersionRow struct {
+	Lists      int32
+	Entries    int32
+	ImportedAt pgtype.Timestamptz
+}
+
+func (q *Queries) GetSanctionsVersion(ctx context.Context) (GetSanctionsVersionRow, error) {
+	row := q.db.QueryRow(ctx, getSanctionsVersion)
+	var i GetSanctionsVersionRow
+	err := row.Scan(&i.Lists, &i.Entries, &i.ImportedAt)
+	return i, err
+}
+
+const getTransferApproval = `-- name: GetTransferApproval :one
+SELECT
+    transfer_approval_id, account_id, reciver_account_id, amount, status, initiated_by, decided_by, transaction_id, created_at, decided_at, currency_code
+FROM
+    "transfer_approval"
+WHERE
+    transfer_approval_id = $1
+    AND account_id = $2
+`
+
+type GetTransferApprovalParams struct {
+	TransferApprovalID uuid.UUID
+	AccountID          uuid.UUID
+}
+
+func (q *Queries) GetTransferApproval(ctx context.Context, arg GetTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRow(ctx, getTransferApproval, arg.TransferApprovalID, arg.AccountID)
+	var i TransferApproval
+	err := row.Scan(
+		&i.TransferApprovalID,
+		&i.AccountID,
+		&i.ReciverAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.InitiatedBy,
+		&i.DecidedBy,
+		&i.TransactionID,
+		&i.CreatedAt,
+		&i.DecidedAt,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const getTransferAverage = `-- name: GetTransferAverage :one
+SELECT
+    COUNT(*)::integer AS transfers,
+    COALESCE(ROUND(AVG(- amount), 2), 0)::numeric AS average
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= $2
+`
+
+type GetTransferAverageParams struct {
+	AccountID uuid.UUID
+	Since     pgtype.Timestamptz
+}
+
+type GetTransferAverageRow struct {
+	Transfers int32
+	Average   pgtype.Numeric
+}
+
+func (q *Queries) GetTransferAverage(ctx context.Context, arg GetTransferAverageParams) (GetTransferAverageRow, error) {
+	row := q.db.QueryRow(ctx, getTransferAverage, arg.AccountID, arg.Since)
+	var i GetTransferAverageRow
+	err := row.Scan(&i.Transfers, &i.Average)
+	return i, err
+}
+
+const getTransferUsage = `-- name: GetTransferUsage :one
+SELECT
+    COALESCE(SUM(- amount) FILTER (WHERE created_at >= $1), 0)::numeric AS daily_amount,
+    COUNT(*) FILTER (WHERE created_at >= $1)::integer AS daily_count,
+    COALESCE(SUM(- amount), 0)::numeric AS monthly_amount,
+    (
+        SELECT
+            account.currency_code
+        FROM "account"
+        WHERE
+            account.account_id = $2)::varchar AS currency_code
+FROM
+    "transaction"
+WHERE
+    account_id = $2
+    AND type = 'transfer'
+    AND amount < 0
+    AND created_at >= $3
+`
+
+type GetTransferUsageParams struct {
+	DayStart   pgtype.Timestamptz
+	AccountID  uuid.UUID
+	MonthStart pgtype.Timestamptz
+}
+
+type GetTransferUsageRow struct {
+	DailyAmount   pgtype.Numeric
+	DailyCount    int32
+	MonthlyAmount pgtype.Numeric
+	CurrencyCode  string
+}
+
+func (q *Queries) GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error) {
+	row := q.db.QueryRow(ctx, getTransferUsage, arg.DayStart, arg.AccountID, arg.MonthStart)
+	var i GetTransferUsageRow
+	err := row.Scan(
+		&i.DailyAmount,
+		&i.DailyCount,
+		&i.MonthlyAmount,
+		&i.CurrencyCode,
+	)
+	return i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT
+    webhook_delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at
+FROM
+    "webhook_delivery"
+WHERE
+    webhook_id = $1
+    AND webhook_delivery_id = $2
+`
+
+type GetWebhookDeliveryParams struct {
+	WebhookID         uuid.UUID
+	WebhookDeliveryID uuid.UUID
+}
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, getWebhookDelivery, arg.WebhookID, arg.WebhookDeliveryID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.WebhookDeliveryID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const hasAccount = `-- name: HasAccount :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "account"
+        WHERE
+            account_id = $1)
+`
+
+func (q *Queries) HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, hasAccount, accountID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hasAccountTransaction = `-- name: HasAccountTransaction :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "transaction"
+        WHERE
+            account_id = $1
+            AND transaction_id = $2)
+`
+
+type HasAccountTransactionParams struct {
+	AccountID     uuid.UUID
+	TransactionID uuid.UUID
+}
+
+func (q *Queries) HasAccountTransaction(ctx context.Context, arg HasAccountTransactionParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasAccountTransaction, arg.AccountID, arg.TransactionID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hasTransferredTo = `-- name: HasTransferredTo :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "transaction" sent
+            JOIN "transaction" received ON received.source_id = sent.transaction_id
+        WHERE
+            sent.account_id = $1
+            AND received.account_id = $2
+            AND sent.created_at >= $3)
+`
+
+type HasTransferredToParams struct {
+	AccountID        uuid.UUID
+	ReciverAccountID uuid.UUID
+	Since            pgtype.Timestamptz
+}
+
+func (q *Queries) HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasTransferredTo, arg.AccountID, arg.ReciverAccountID, arg.Since)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hasWebhook = `-- name: HasWebhook :one
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "webhook"
+        WHERE
+            webhook_id = $1)
+`
+
+func (q *Queries) HasWebhook(ctx context.Context, webhookID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, hasWebhook, webhookID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listAccountHolders = `-- name: ListAccountHolders :many
+SELECT
+    account_id, customer_id, role, created_at, updated_at
+FROM
+    "account_holder"
+WHERE
+    account_id = $1
+ORDER BY
+    created_at,
+    customer_id
+`
+
+func (q *Queries) ListAccountHolders(ctx context.Context, accountID uuid.UUID) ([]AccountHolder, error) {
+	rows, err := q.db.Query(ctx, listAccountHolders, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccountHolder
+	for rows.Next() {
+		var i AccountHolder
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.CustomerID,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountProducts = `-- name: ListAccountProducts :many
+SELECT
+    product_code, name, interest_rate, day_count, created_at, updated_at, currency_codes, limit_tier, overdraft_eligible, max_overdraft_limit
+FROM
+    "account_product"
+ORDER BY
+    product_code
+`
+
+func (q *Queries) ListAccountProducts(ctx context.Context) ([]AccountProduct, error) {
+	rows, err := q.db.Query(ctx, listAccountProducts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccountProduct
+	for rows.Next() {
+		var i AccountProduct
+		if err := rows.Scan(
+			&i.ProductCode,
+			&i.Name,
+			&i.InterestRate,
+			&i.DayCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CurrencyCodes,
+			&i.LimitTier,
+			&i.OverdraftEligible,
+			&i.MaxOverdraftLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsWithoutIBAN = `-- name: ListAccountsWithoutIBAN :many
+SELECT
+    account_id, email, name, currency_code, account_number, iban, screening_status, overdraft_limit, product_code, customer_id, approval_threshold, parent_account_id, goal_amount, goal_date, last_event_sequence, last_transaction_sequence
+FROM
+    "account"
+WHERE
+    iban IS NULL
+    AND parent_account_id IS NULL
+ORDER BY
+    account_number
+LIMIT $1
+`
+
+// Pockets have no IBAN.
+func (q *Queries) ListAccountsWithoutIBAN(ctx context.Context, limit int32) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsWithoutIBAN, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Email,
+			&i.Name,
+			&i.CurrencyCode,
+			&i.AccountNumber,
+			&i.IBAN,
+			&i.ScreeningStatus,
+			&i.OverdraftLimit,
+			&i.ProductCode,
+			&i.CustomerID,
+			&i.ApprovalThreshold,
+			&i.ParentAccountID,
+			&i.GoalAmount,
+			&i.GoalDate,
+			&i.LastEventSequence,
+			&i.LastTransactionSequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccruingAccounts = `-- name: ListAccruingAccounts :many
+SELECT
+    account.account_id,
+    account.product_code,
+    account_product.interest_rate,
+    account_product.day_count,
+    SUM("transaction".amount)::numeric AS balance
+FROM
+    "account"
+    JOIN "account_product" ON account_product.product_code = account.product_code
+    JOIN "transaction" ON "transaction".account_id = account.account_id
+        AND "transaction".created_at < $1
+WHERE
+    account_product.interest_rate > 0
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "interest_accrual"
+        WHERE
+            interest_accrual.account_id = account.account_id
+            AND interest_accrual.day = $2)
+GROUP BY
+    account.account_id,
+    account_product.product_code
+HAVING
+    SUM("transaction".amount) > 0
+ORDER BY
+    account.account_id
+`
+
+type ListAccruingAccountsParams struct {
+	DayEnd pgtype.Timestamptz
+	Day    pgtype.Date
+}
+
+type ListAccruingAccountsRow struct {
+	AccountID    uuid.UUID
+	ProductCode  string
+	InterestRate pgtype.Numeric
+	DayCount     string
+	Balance      pgtype.Numeric
+}
+
+func (q *Queries) ListAccruingAccounts(ctx context.Context, arg ListAccruingAccountsParams) ([]ListAccruingAccountsRow, error) {
+	rows, err := q.db.Query(ctx, listAccruingAccounts, arg.DayEnd, arg.Day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAccruingAccountsRow
+	for rows.Next() {
+		var i ListAccruingAccountsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.ProductCode,
+			&i.InterestRate,
+			&i.DayCount,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT
+    audit_event_id, occurred_at, principal, action, account_id, request_id, client_ip, outcome, before, after, prev_hash, hash
+FROM
+    "audit_event"
+WHERE ($1::uuid IS NULL
+    OR account_id = $1)
+AND ($2::varchar IS NULL
+    OR principal = $2)
+AND ($3::varchar IS NULL
+    OR action = $3)
+AND ($4::timestamptz IS NULL
+    OR occurred_at >= $4)
+AND ($5::timestamptz IS NULL
+    OR occurred_at < $5)
+ORDER BY
+    audit_event_id DESC
+LIMIT $7 OFFSET $6
+`
+
+type ListAuditEventsParams struct {
+	AccountID uuid.NullUUID
+	Principal pgtype.Text
+	Action    pgtype.Text
+	From      pgtype.Timestamptz
+	To        pgtype.Timestamptz
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEvents,
+		arg.AccountID,
+		arg.Principal,
+		arg.Action,
+		arg.From,
+		arg.To,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.AuditEventID,
+			&i.OccurredAt,
+			&i.Principal,
+			&i.Action,
+			&i.AccountID,
+			&i.RequestID,
+			&i.ClientIp,
+			&i.Outcome,
+			&i.Before,
+			&i.After,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEventsAfter = `-- name: ListAuditEventsAfter :many
+SELECT
+    audit_event_id, occurred_at, principal, action, account_id, request_id, client_ip, outcome, before, after, prev_hash, hash
+FROM
+    "audit_event"
+WHERE
+    audit_event_id > $1
+ORDER BY
+    audit_event_id
+LIMIT $2
+`
+
+type ListAuditEventsAfterParams struct {
+	AuditEventID int64
+	Limit        int32
+}
+
+func (q *Queries) ListAuditEventsAfter(ctx context.Context, arg ListAuditEventsAfterParams) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEventsAfter, arg.AuditEventID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.AuditEventID,
+			&i.OccurredAt,
+			&i.Principal,
+			&i.Action,
+			&i.AccountID,
+			&i.RequestID,
+			&i.ClientIp,
+			&i.Outcome,
+			&i.Before,
+			&i.After,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBeneficiaries = `-- name: ListBeneficiaries :many
+SELECT
+    beneficiary.beneficiary_id, beneficiary.account_id, beneficiary.nickname, beneficiary.reciver_account_id, beneficiary.transfer_limit, beneficiary.created_at, beneficiary.updated_at,
+    account.iban AS reciver_iban
+FROM
+    "beneficiary"
+    JOIN "account" ON account.account_id = beneficiary.reciver_account_id
+WHERE
+    beneficiary.account_id = $1
+ORDER BY
+    beneficiary.nickname,
+    beneficiary.beneficiary_id
+`
+
+type ListBeneficiariesRow struct {
+	Beneficiary Beneficiary
+	ReciverIBAN pgtype.Text
+}
+
+func (q *Queries) ListBeneficiaries(ctx context.Context, accountID uuid.UUID) ([]ListBeneficiariesRow, error) {
+	rows, err := q.db.Query(ctx, listBeneficiaries, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBeneficiariesRow
+	for rows.Next() {
+		var i ListBeneficiariesRow
+		if err := rows.Scan(
+			&i.Beneficiary.BeneficiaryID,
+			&i.Beneficiary.AccountID,
+			&i.Beneficiary.Nickname,
+			&i.Beneficiary.ReciverAccountID,
+			&i.Beneficiary.TransferLimit,
+			&i.Beneficiary.CreatedAt,
+			&i.Beneficiary.UpdatedAt,
+			&i.ReciverIBAN,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomerAccounts = `-- name: ListCustomerAccounts :many
+SELECT
+    account.account_id, account.email, account.name, account.currency_code, account.account_number, account.iban, account.screening_status, account.overdraft_limit, account.product_code, account.customer_id, account.approval_threshold, account.parent_account_id, account.goal_amount, account.goal_date, account.last_event_sequence, account.last_transaction_sequence,
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    EXISTS (
+        SELECT
+            1
+        FROM
+            "account_holder" h
+        WHERE
+            h.customer_id = $1
+            AND h.account_id IN (account.account_id, account.parent_account_id))
+GROUP BY
+    account.account_id
+ORDER BY
+    account.account_number
+`
+
+type ListCustomerAccountsRow struct {
+	Account Account
+	Balance pgtype.Numeric
+}
+
+// The accounts a customer holds, in any role, and their pockets, which are held through their parent account.
+func (q *Queries) ListCustomerAccounts(ctx context.Context, customerID uuid.UUID) ([]ListCustomerAccountsRow, error) {
+	rows, err := q.db.Query(ctx, listCustomerAccounts, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCustomerAccountsRow
+	for rows.Next() {
+		var i ListCustomerAccountsRow
+		if err := rows.Scan(
+			&i.Account.AccountID,
+			&i.Account.Email,
+			&i.Account.Name,
+			&i.Account.CurrencyCode,
+			&i.Account.AccountNumber,
+			&i.Account.IBAN,
+			&i.Account.ScreeningStatus,
+			&i.Account.OverdraftLimit,
+			&i.Account.ProductCode,
+			&i.Account.CustomerID,
+			&i.Account.ApprovalThreshold,
+			&i.Account.ParentAccountID,
+			&i.Account.GoalAmount,
+			&i.Account.GoalDate,
+			&i.Account.LastEventSequence,
+			&i.Account.LastTransactionSequence,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeeSchedules = `-- name: ListFeeSchedules :many
+SELECT
+    product_code, fee_type, kind, amount, rate, min_amount, max_amount, tiers, created_at, updated_at
+FROM
+    "fee_schedule"
+WHERE
+    product_code = $1
+ORDER BY
+    fee_type
+`
+
+func (q *Queries) ListFeeSchedules(ctx context.Context, productCode string) ([]FeeSchedule, error) {
+	rows, err := q.db.Query(ctx, listFeeSchedules, productCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeeSchedule
+	for rows.Next() {
+		var i FeeSchedule
+		if err := rows.Scan(
+			&i.ProductCode,
+			&i.FeeType,
+			&i.Kind,
+			&i.Amount,
+			&i.Rate,
+			&i.MinAmount,
+			&i.MaxAmount,
+			&i.Tiers,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHeldPayments = `-- name: ListHeldPayments :many
+SELECT
+    payment.payment_id, payment.payment_file_id, payment.position, payment.payment_information_id, payment.instruction_id, payment.end_to_end_id, payment.amount, payment.currency_code, payment.creditor_account, payment.status, payment.reason_code, payment.reason, payment.transaction_id, payment.created_at, payment.updated_at, payment.transfer_approval_id, payment.pending_transfer_id,
+    transfer_approval.status AS approval_status,
+    transfer_approval.transaction_id AS approval_transaction_id,
+    pending_transfer.status AS review_status,
+    pending_transfer.transaction_id AS review_transaction_id
+FROM
+    "payment"
+    LEFT JOIN "transfer_approval" ON transfer_approval.transfer_approval_id = payment.transfer_approval_id
+    LEFT JOIN "pending_transfer" ON pending_transfer.pending_transfer_id = payment.pending_transfer_id
+WHERE
+    payment.payment_file_id = $1
+    AND payment.status = 'PDNG'
+    AND (payment.transfer_approval_id IS NOT NULL
+        OR payment.pending_transfer_id IS NOT NULL)
+ORDER BY
+    payment.position
+`
+
+type ListHeldPaymentsRow struct {
+	Payment               Payment
+	ApprovalStatus        pgtype.Text
+	ApprovalTransactionID uuid.NullUUID
+	ReviewStatus          pgtype.Text
+	ReviewTransactionID   uuid.NullUUID
+}
+
+// Lists the pending payments of a file held for approval or review, with the decision of their held transfer.
+func (q *Queries) ListHeldPayments(ctx context.Context, paymentFileID uuid.UUID) ([]ListHeldPaymentsRow, error) {
+	rows, err := q.db.Query(ctx, listHeldPayments, paymentFileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHeldPaymentsRow
+	for rows.Next() {
+		var i ListHeldPaymentsRow
+		if err := rows.Scan(
+			&i.Payment.PaymentID,
+			&i.Payment.PaymentFileID,
+			&i.Payment.Position,
+			&i.Payment.PaymentInformationID,
+			&i.Payment.InstructionID,
+			&i.Payment.EndToEndID,
+			&i.Payment.Amount,
+			&i.Payment.CurrencyCode,
+			&i.Payment.CreditorAccount,
+			&i.Payment.Status,
+			&i.Payment.ReasonCode,
+			&i.Payment.Reason,
+			&i.Payment.TransactionID,
+			&i.Payment.CreatedAt,
+			&i.Payment.UpdatedAt,
+			&i.Payment.TransferApprovalID,
+			&i.Payment.PendingTransferID,
+			&i.ApprovalStatus,
+			&i.ApprovalTransactionID,
+			&i.ReviewStatus,
+			&i.ReviewTransactionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestAccruals = `-- name: ListInterestAccruals :many
+SELECT
+    account_id, day, product_code, balance, interest_rate, day_count, amount, transaction_id, created_at
+FROM
+    "interest_accrual"
+WHERE
+    account_id = $1
+ORDER BY
+    day DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListInterestAccrualsParams struct {
+	AccountID uuid.UUID
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	rows, err := q.db.Query(ctx, listInterestAccruals, arg.AccountID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InterestAccrual
+	for rows.Next() {
+		var i InterestAccrual
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Day,
+			&i.ProductCode,
+			&i.Balance,
+			&i.InterestRate,
+			&i.DayCount,
+			&i.Amount,
+			&i.TransactionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMaintenanceFeeAccounts = `-- name: ListMaintenanceFeeAccounts :many
+SELECT
+    account.account_id,
+    account.currency_code,
+    fee_schedule.product_code, fee_schedule.fee_type, fee_schedule.kind, fee_schedule.amount, fee_schedule.rate, fee_schedule.min_amount, fee_schedule.max_amount, fee_schedule.tiers, fee_schedule.created_at, fee_schedule.updated_at,
+    COALESCE(SUM("transaction".amount), 0)::numeric AS balance
+FROM
+    "account"
+    JOIN "fee_schedule" ON fee_schedule.product_code = account.product_code
+        AND fee_schedule.fee_type = 'maintenance'
+    LEFT JOIN "transaction" ON "transaction".account_id = account.account_id
+        AND "transaction".created_at < $1
+WHERE
+    account.currency_code = ANY ($2::varchar[])
+    AND account.account_id <> ALL ($3::uuid[])
+    AND account.parent_account_id IS NULL
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "fee"
+        WHERE
+            fee.account_id = account.account_id
+            AND fee.period = $4)
+GROUP BY
+    account.account_id,
+    fee_schedule.product_code,
+    fee_schedule.fee_type
+ORDER BY
+    account.account_id
+`
+
+type ListMaintenanceFeeAccountsParams struct {
+	PeriodEnd        pgtype.Timestamptz
+	CurrencyCodes    []string
+	IncomeAccountIds []uuid.UUID
+	Period           pgtype.Date
+}
+
+type ListMaintenanceFeeAccountsRow struct {
+	AccountID    uuid.UUID
+	CurrencyCode string
+	FeeSchedule  FeeSchedule
+	Balance      pgtype.Numeric
+}
+
+func (q *Queries) ListMaintenanceFeeAccounts(ctx context.Context, arg ListMaintenanceFeeAccountsParams) ([]ListMaintenanceFeeAccountsRow, error) {
+	rows, err := q.db.Query(ctx, listMaintenanceFeeAccounts,
+		arg.PeriodEnd,
+		arg.CurrencyCodes,
+		arg.IncomeAccountIds,
+		arg.Period,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMaintenanceFeeAccountsRow
+	for rows.Next() {
+		var i ListMaintenanceFeeAccountsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.CurrencyCode,
+			&i.FeeSchedule.ProductCode,
+			&i.FeeSchedule.FeeType,
+			&i.FeeSchedule.Kind,
+			&i.FeeSchedule.Amount,
+			&i.FeeSchedule.Rate,
+			&i.FeeSchedule.MinAmount,
+			&i.FeeSchedule.MaxAmount,
+			&i.FeeSchedule.Tiers,
+			&i.FeeSchedule.CreatedAt,
+			&i.FeeSchedule.UpdatedAt,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdrawnAccounts = `-- name: ListOverdrawnAccounts :many
+SELECT
+    "transaction".account_id,
+    account.currency_code,
+    SUM(amount)::numeric AS balance
+FROM
+    "transaction"
+    JOIN "account" ON account.account_id = "transaction".account_id
+WHERE
+    "transaction".created_at < $1
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            "overdraft_interest"
+        WHERE
+            overdraft_interest.account_id = "transaction".account_id
+            AND overdraft_interest.day = $2)
+GROUP BY
+    "transaction".account_id,
+    account.currency_code
+HAVING
+    SUM(amount) < 0
+ORDER BY
+    "transaction".account_id
+`
+
+type ListOverdrawnAccountsParams struct {
+	DayEnd pgtype.Timestamptz
+	Day    pgtype.Date
+}
+
+type ListOverdrawnAccountsRow struct {
+	AccountID    uuid.UUID
+	CurrencyCode string
+	Balance      pgtype.Numeric
+}
+
+func (q *Queries) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]ListOverdrawnAccountsRow, error) {
+	rows, err := q.db.Query(ctx, listOverdrawnAccounts, arg.DayEnd, arg.Day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOverdrawnAccountsRow
+	for rows.Next() {
+		var i ListOverdrawnAccountsRow
+		if err := rows.Scan(&i.AccountID, &i.CurrencyCode, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPayments = `-- name: ListPayments :many
+SELECT
+    payment_id, payment_file_id, position, payment_information_id, instruction_id, end_to_end_id, amount, currency_code, creditor_account, status, reason_code, reason, transaction_id, created_at, updated_at, transfer_approval_id, pending_transfer_id
+FROM
+    "payment"
+WHERE
+    payment_file_id = $1
+ORDER BY
+    position
+`
+
+func (q *Queries) ListPayments(ctx context.Context, paymentFileID uuid.UUID) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, listPayments, paymentFileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Payment
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.PaymentID,
+			&i.PaymentFileID,
+			&i.Position,
+			&i.PaymentInformationID,
+			&i.InstructionID,
+			&i.EndToEndID,
+			&i.Amount,
+			&i.CurrencyCode,
+			&i.CreditorAccount,
+			&i.Status,
+			&i.ReasonCode,
+			&i.Reason,
+			&i.TransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TransferApprovalID,
+			&i.PendingTransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingTransfers = `-- name: ListPendingTransfers :many
+SELECT
+    pending_transfer_id, account_id, reciver_account_id, amount, status, rules, transaction_id, created_at, decided_at, currency_code
+FROM
+    "pending_transfer"
+WHERE
+    status = $1
+ORDER BY
+    created_at,
+    pending_transfer_id
+LIMIT $3 OFFSET $2
+`
+
+type ListPendingTransfersParams struct {
+	Status string
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) ListPendingTransfers(ctx context.Context, arg ListPendingTransfersParams) ([]PendingTransfer, error) {
+	rows, err := q.db.Query(ctx, listPendingTransfers, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PendingTransfer
+	for rows.Next() {
+		var i PendingTransfer
+		if err := rows.Scan(
+			&i.PendingTransferID,
+			&i.AccountID,
+			&i.ReciverAccountID,
+			&i.Amount,
+			&i.Status,
+			&i.Rules,
+			&i.TransactionID,
+			&i.CreatedAt,
+			&i.DecidedAt,
+			&i.CurrencyCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPockets = `-- name: ListPockets :many
+SELECT
+    account.account_id, account.email, account.name, account.currency_code, account.account_number, account.iban, account.screening_status, account.overdraft_limit, account.product_code, account.customer_id, account.approval_threshold, account.parent_account_id, account.goal_amount, account.goal_date, account.last_event_sequence, account.last_transaction_sequence,
+    COALESCE(SUM(t.amount), 0)::numeric AS balance
+FROM
+    "account"
+    LEFT JOIN "transaction" t ON t.account_id = account.account_id
+WHERE
+    account.parent_account_id = $1
+GROUP BY
+    account.account_id
+ORDER BY
+    account.account_number
+`
+
+type ListPocketsRow struct {
+	Account Account
+	Balance pgtype.Numeric
+}
+
+func (q *Queries) ListPockets(ctx context.Context, parentAccountID uuid.NullUUID) ([]ListPocketsRow, error) {
+	rows, err := q.db.Query(ctx, listPockets, parentAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPocketsRow
+	for rows.Next() {
+		var i ListPocketsRow
+		if err := rows.Scan(
+			&i.Account.AccountID,
+			&i.Account.Email,
+			&i.Account.Name,
+			&i.Account.CurrencyCode,
+			&i.Account.AccountNumber,
+			&i.Account.IBAN,
+			&i.Account.ScreeningStatus,
+			&i.Account.OverdraftLimit,
+			&i.Account.ProductCode,
+			&i.Account.CustomerID,
+			&i.Account.ApprovalThreshold,
+			&i.Account.ParentAccountID,
+			&i.Account.GoalAmount,
+			&i.Account.GoalDate,
+			&i.Account.LastEventSequence,
+			&i.Account.LastTransactionSequence,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSanctionsEntries = `-- name: ListSanctionsEntries :many
+SELECT
+    list, reference, name
+FROM
+    "sanctions_entry"
+ORDER BY
+    list,
+    reference
+`
+
+func (q *Queries) ListSanctionsEntries(ctx context.Context) ([]SanctionsEntry, error) {
+	rows, err := q.db.Query(ctx, listSanctionsEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SanctionsEntry
+	for rows.Next() {
+		var i SanctionsEntry
+		if err := rows.Scan(&i.List, &i.Reference, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSanctionsScreenings = `-- name: ListSanctionsScreenings :many
+SELECT
+    screening_id, account_id, name, status, matches, created_at, resolved_at
+FROM
+    "sanctions_screening"
+WHERE
+    status = $1
+ORDER BY
+    created_at,
+    screening_id
+LIMIT $3 OFFSET $2
+`
+
+type ListSanctionsScreeningsParams struct {
+	Status string
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) ListSanctionsScreenings(ctx context.Context, arg ListSanctionsScreeningsParams) ([]SanctionsScreening, error) {
+	rows, err := q.db.Query(ctx, listSanctionsScreenings, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SanctionsScreening
+	for rows.Next() {
+		var i SanctionsScreening
+		if err := rows.Scan(
+			&i.ScreeningID,
+			&i.AccountID,
+			&i.Name,
+			&i.Status,
+			&i.Matches,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactions = `-- name: ListTransactions :many
+SELECT
+    transaction_id, account_id, amount, source_id, created_at, type, account_sequence
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+ORDER BY
+    account_sequence DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListTransactionsParams struct {
+	AccountID uuid.UUID
+	Limit     int32
+	Offset    int32
+}
+
+// Lists the transactions of an account, the last committed first, so that the first one is the one the events of the
+// account are streamed after.
+func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactions, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.AccountID,
+			&i.Amount,
+			&i.SourceID,
+			&i.CreatedAt,
+			&i.Type,
+			&i.AccountSequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsAfter = `-- name: ListTransactionsAfter :many
+SELECT
+    t.transaction_id, t.account_id, t.amount, t.source_id, t.created_at, t.type, t.account_sequence
+FROM
+    "transaction" t
+WHERE
+    t.account_id = $1
+    AND ($2::uuid IS NULL
+        OR t.account_sequence > (
+            SELECT
+                a.account_sequence
+            FROM
+                "transaction" a
+            WHERE
+                a.transaction_id = $2))
+ORDER BY
+    t.account_sequence
+LIMIT $3
+`
+
+type ListTransactionsAfterParams struct {
+	AccountID uuid.UUID
+	After     uuid.NullUUID
+	Limit     int32
+}
+
+// Lists the transactions of an account by their sequence, the order they were committed in, so that a transaction
+// committed after the transaction after is listed after it, whatever its creation time.
+func (q *Queries) ListTransactionsAfter(ctx context.Context, arg ListTransactionsAfterParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactionsAfter, arg.AccountID, arg.After, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.AccountID,
+			&i.Amount,
+			&i.SourceID,
+			&i.CreatedAt,
+			&i.Type,
+			&i.AccountSequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsBetween = `-- name: ListTransactionsBetween :many
+SELECT
+    transaction_id, account_id, amount, source_id, created_at, type, account_sequence
+FROM
+    "transaction"
+WHERE
+    account_id = $1
+    AND created_at >= $2
+    AND created_at < $3
+    AND ($4::timestamptz IS NULL
+        OR (created_at, transaction_id) > ($4, $5::uuid))
+ORDER BY
+    created_at,
+    transaction_id
+LIMIT $6
+`
+
+type ListTransactionsBetweenParams struct {
+	AccountID          uuid.UUID
+	From               pgtype.Timestamptz
+	To                 pgtype.Timestamptz
+	AfterCreatedAt     pgtype.Timestamptz
+	AfterTransactionID uuid.NullUUID
+	Limit              int32
+}
+
+func (q *Queries) ListTransactionsBetween(ctx context.Context, arg ListTransactionsBetweenParams) ([]Transaction, error) {
+	rows, err := q.db.Query(ctx, listTransactionsBetween,
+		arg.AccountID,
+		arg.From,
+		arg.To,
+		arg.AfterCreatedAt,
+		arg.AfterTransactionID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transaction
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.AccountID,
+			&i.Amount,
+			&i.SourceID,
+			&i.CreatedAt,
+			&i.Type,
+			&i.AccountSequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferApprovals = `-- name: ListTransferApprovals :many
+SELECT
+    transfer_approval_id, account_id, reciver_account_id, amount, status, initiated_by, decided_by, transaction_id, created_at, decided_at, currency_code
+FROM
+    "transfer_approval"
+WHERE
+    account_id = $1
+    AND status = $2
+ORDER BY
+    created_at,
+    transfer_approval_id
+LIMIT $4 OFFSET $3
+`
+
+type ListTransferApprovalsParams struct {
+	AccountID uuid.UUID
+	Status    string
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error) {
+	rows, err := q.db.Query(ctx, listTransferApprovals,
+		arg.AccountID,
+		arg.Status,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TransferApproval
+	for rows.Next() {
+		var i TransferApproval
+		if err := rows.Scan(
+			&i.TransferApprovalID,
+			&i.AccountID,
+			&i.ReciverAccountID,
+			&i.Amount,
+			&i.Status,
+			&i.InitiatedBy,
+			&i.DecidedBy,
+			&i.TransactionID,
+			&i.CreatedAt,
+			&i.DecidedAt,
+			&i.CurrencyCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUncapitalizedAccounts = `-- name: ListUncapitalizedAccounts :many
+SELECT DISTINCT
+    interest_accrual.account_id,
+    account.currency_code
+FROM
+    "interest_accrual"
+    JOIN "account" ON account.account_id = interest_accrual.account_id
+WHERE
+    interest_accrual.transaction_id IS NULL
+    AND interest_accrual.day < $1
+ORDER BY
+    interest_accrual.account_id
+`
+
+type ListUncapitalizedAccountsRow struct {
+	AccountID    uuid.UUID
+	CurrencyCode string
+}
+
+func (q *Queries) ListUncapitalizedAccounts(ctx context.Context, before pgtype.Date) ([]ListUncapitalizedAccountsRow, error) {
+	rows, err := q.db.Query(ctx, listUncapitalizedAccounts, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUncapitalizedAccountsRow
+	for rows.Next() {
+		var i ListUncapitalizedAccountsRow
+		if err := rows.Scan(&i.AccountID, &i.CurrencyCode); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnpublishedOutboxEvents = `-- name: ListUnpublishedOutboxEvents :many
+SELECT
+    outbox_event_id, event_id, event_type, account_id, payload, occurred_at, published_at, account_sequence
+FROM
+    "outbox"
+WHERE
+    published_at IS NULL
+ORDER BY
+    outbox_event_id
+LIMIT $1
+FOR UPDATE
+`
+
+func (q *Queries) ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, listUnpublishedOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.OutboxEventID,
+			&i.EventID,
+			&i.EventType,
+			&i.AccountID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.PublishedAt,
+			&i.AccountSequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT
+    webhook_delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at
+FROM
+    "webhook_delivery"
+WHERE
+    webhook_id = $1
+ORDER BY
+    created_at DESC,
+    webhook_delivery_id
+LIMIT $2 OFFSET $3
+`
+
+type ListWebhookDeliveriesParams struct {
+	WebhookID uuid.UUID
+	Limit     int32
+	Offset    int32
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.WebhookID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.WebhookDeliveryID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveryAttempts = `-- name: ListWebhookDeliveryAttempts :many
+SELECT
+    webhook_delivery_attempt_id, webhook_delivery_id, attempted_at, status_code, error, duration_ms
+FROM
+    "webhook_delivery_attempt"
+WHERE
+    webhook_delivery_id = $1
+ORDER BY
+    webhook_delivery_attempt_id
+`
+
+func (q *Queries) ListWebhookDeliveryAttempts(ctx context.Context, webhookDeliveryID uuid.UUID) ([]WebhookDeliveryAttempt, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveryAttempts, webhookDeliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDeliveryAttempt
+	for rows.Next() {
+		var i WebhookDeliveryAttempt
+		if err := rows.Scan(
+			&i.WebhookDeliveryAttemptID,
+			&i.WebhookDeliveryID,
+			&i.AttemptedAt,
+			&i.StatusCode,
+			&i.Error,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooksForEvent = `-- name: ListWebhooksForEvent :many
+SELECT
+    webhook_id, url, event_types, account_id, secret, created_at
+FROM
+    "webhook"
+WHERE (account_id IS NULL
+    OR account_id = $1)
+AND (cardinality(event_types) = 0
+    OR $2::varchar = ANY (event_types))
+`
+
+type ListWebhooksForEventParams struct {
+	AccountID uuid.NullUUID
+	EventType string
+}
+
+func (q *Queries) ListWebhooksForEvent(ctx context.Context, arg ListWebhooksForEventParams) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooksForEvent, arg.AccountID, arg.EventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.WebhookID,
+			&i.Url,
+			&i.EventTypes,
+			&i.AccountID,
+			&i.Secret,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAccounts = `-- name: LockAccounts :exec
+SELECT
+    account_id
+FROM
+    "account"
+WHERE
+    account_id = ANY ($1::uuid[])
+ORDER BY
+    account_id
+FOR UPDATE
+`
+
+// Locks accounts until the end of the transaction, so that the money moved from them is checked against their balance
+// one move at a time. The accounts are locked in the order of their IDs, so that the moves between the same accounts
+// in opposite directions do not deadlock.
+func (q *Queries) LockAccounts(ctx context.Context, accountIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockAccounts, accountIds)
+	return err
+}
+
+const lockAuditChain = `-- name: LockAuditChain :exec
+SELECT
+    pg_advisory_xact_lock(hashtext('audit_event'))
+`
+
+func (q *Queries) LockAuditChain(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockAuditChain)
+	return err
+}
+
+const lockUncapitalizedInterest = `-- name: LockUncapitalizedInterest :many
+SELECT
+    amount
+FROM
+    "interest_accrual"
+WHERE
+    account_id = $1
+    AND transaction_id IS NULL
+    AND day < $2
+FOR UPDATE
+`
+
+type LockUncapitalizedInterestParams struct {
+	AccountID uuid.UUID
+	Before    pgtype.Date
+}
+
+func (q *Queries) LockUncapitalizedInterest(ctx context.Context, arg LockUncapitalizedInterestParams) ([]pgtype.Numeric, error) {
+	rows, err := q.db.Query(ctx, lockUncapitalizedInterest, arg.AccountID, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.Numeric
+	for rows.Next() {
+		var amount pgtype.Numeric
+		if err := rows.Scan(&amount); err != nil {
+			return nil, err
+		}
+		items = append(items, amount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventsPublished = `-- name: MarkOutboxEventsPublished :exec
+UPDATE
+    "outbox"
+SET
+    published_at = $1
+WHERE
+    outbox_event_id = ANY ($2::bigint[])
+`
+
+type MarkOutboxEventsPublishedParams struct {
+	PublishedAt    pgtype.Timestamptz
+	OutboxEventIds []int64
+}
+
+func (q *Queries) MarkOutboxEventsPublished(ctx context.Context, arg MarkOutboxEventsPublishedParams) error {
+	_, err := q.db.Exec(ctx, markOutboxEventsPublished, arg.PublishedAt, arg.OutboxEventIds)
+	return err
+}
+
+const nextAccountNumber = `-- name: NextAccountNumber :one
+SELECT
+    nextval('account_number_seq')::bigint
+`
+
+func (q *Queries) NextAccountNumber(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, nextAccountNumber)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const notify = `-- name: Notify :exec
+SELECT
+    pg_notify($1::text, $2::text)
+`
+
+type NotifyParams struct {
+	Channel string
+	Payload string
+}
+
+func (q *Queries) Notify(ctx context.Context, arg NotifyParams) error {
+	_, err := q.db.Exec(ctx, notify, arg.Channel, arg.Payload)
+	return err
+}
+
+const redeliverWebhookDelivery = `-- name: RedeliverWebhookDelivery :one
+UPDATE
+    "webhook_delivery"
+SET
+    status = 'pending',
+    attempts = 0,
+    next_attempt_at = $1,
+    updated_at = $1
+WHERE
+    webhook_id = $2
+    AND webhook_delivery_id = $3
+RETURNING
+    webhook_delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at
+`
+
+type RedeliverWebhookDeliveryParams struct {
+	Now               pgtype.Timestamptz
+	WebhookID         uuid.UUID
+	WebhookDeliveryID uuid.UUID
+}
+
+func (q *Queries) RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, redeliverWebhookDelivery, arg.Now, arg.WebhookID, arg.WebhookDeliveryID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.WebhookDeliveryID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const reopenPendingTransfer = `-- name: ReopenPendingTransfer :exec
+UPDATE
+    "pending_transfer"
+SET
+    status = 'pending',
+    decided_at = NULL
+WHERE
+    pending_transfer_id = $1
+`
+
+func (q *Queries) ReopenPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, reopenPendingTransfer, pendingTransferID)
+	return err
+}
+
+const reopenTransferApproval = `-- name: ReopenTransferApproval :exec
+UPDATE
+    "transfer_approval"
+SET
+    status = 'pending',
+    decided_by = NULL,
+    decided_at = NULL
+WHERE
+    transfer_approval_id = $1
+`
+
+func (q *Queries) ReopenTransferApproval(ctx context.Context, transferApprovalID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, reopenTransferApproval, transferApprovalID)
+	return err
+}
+
+const resolveSanctionsScreening = `-- name: ResolveSanctionsScreening :one
+UPDATE
+    "sanctions_screening"
+SET
+    status = $1,
+    resolved_at = $2
+WHERE
+    screening_id = $3
+    AND status = 'review'
+RETURNING
+    screening_id, account_id, name, status, matches, created_at, resolved_at
+`
+
+type ResolveSanctionsScreeningParams struct {
+	Status      string
+	ResolvedAt  pgtype.Timestamptz
+	ScreeningID uuid.UUID
+}
+
+func (q *Queries) ResolveSanctionsScreening(ctx context.Context, arg ResolveSanctionsScreeningParams) (SanctionsScreening, error) {
+	row := q.db.QueryRow(ctx, resolveSanctionsScreening, arg.Status, arg.ResolvedAt, arg.ScreeningID)
+	var i SanctionsScreening
+	err := row.Scan(
+		&i.ScreeningID,
+		&i.AccountID,
+		&i.Name,
+		&i.Status,
+		&i.Matches,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const saveIdempotencyKeyResponse = `-- name: SaveIdempotencyKeyResponse :exec
+UPDATE
+    "idempotency_key"
+SET
+    status_code = $3,
+    content_type = $4,
+    response_body = $5
+WHERE
+    principal = $1
+    AND key = $2
+`
+
+type SaveIdempotencyKeyResponseParams struct {
+	Principal    string
+	Key          string
+	StatusCode   pgtype.Int4
+	ContentType  pgtype.Text
+	ResponseBody []byte
+}
+
+func (q *Queries) SaveIdempotencyKeyResponse(ctx context.Context, arg SaveIdempotencyKeyResponseParams) error {
+	_, err := q.db.Exec(ctx, saveIdempotencyKeyResponse,
+		arg.Principal,
+		arg.Key,
+		arg.StatusCode,
+		arg.ContentType,
+		arg.ResponseBody,
+	)
+	return err
+}
+
+const setAccountApprovalThreshold = `-- name: SetAccountApprovalThreshold :execrows
+UPDATE
+    "account"
+SET
+    approval_threshold = $2
+WHERE
+    account_id = $1
+`
+
+type SetAccountApprovalThresholdParams struct {
+	AccountID         uuid.UUID
+	ApprovalThreshold pgtype.Numeric
+}
+
+func (q *Queries) SetAccountApprovalThreshold(ctx context.Context, arg SetAccountApprovalThresholdParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAccountApprovalThreshold, arg.AccountID, arg.ApprovalThreshold)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setAccountIBAN = `-- name: SetAccountIBAN :exec
+UPDATE
+    "account"
+SET
+    iban = $2
+WHERE
+    account_id = $1
+`
+
+type SetAccountIBANParams struct {
+	AccountID uuid.UUID
+	IBAN      pgtype.Text
+}
+
+func (q *Queries) SetAccountIBAN(ctx context.Context, arg SetAccountIBANParams) error {
+	_, err := q.db.Exec(ctx, setAccountIBAN, arg.AccountID, arg.IBAN)
+	return err
+}
+
+const setAccountLimits = `-- name: SetAccountLimits :exec
+INSERT INTO "account_limit"(account_id, tier, max_transfer, daily_amount, monthly_amount, daily_count, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id)
+    DO UPDATE SET
+        tier = EXCLUDED.tier, max_transfer = EXCLUDED.max_transfer, daily_amount = EXCLUDED.daily_amount, monthly_amount = EXCLUDED.monthly_amount, daily_count = EXCLUDED.daily_count, updated_at = EXCLUDED.updated_at
+`
+
+type SetAccountLimitsParams struct {
+	AccountID     uuid.UUID
+	Tier          string
+	MaxTransfer   pgtype.Numeric
+	DailyAmount   pgtype.Numeric
+	MonthlyAmount pgtype.Numeric
+	DailyCount    pgtype.Int4
+	UpdatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) SetAccountLimits(ctx context.Context, arg SetAccountLimitsParams) error {
+	_, err := q.db.Exec(ctx, setAccountLimits,
+		arg.AccountID,
+		arg.Tier,
+		arg.MaxTransfer,
+		arg.DailyAmount,
+		arg.MonthlyAmount,
+		arg.DailyCount,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const setAccountOverdraft = `-- name: SetAccountOverdraft :execrows
+UPDATE
+    "account"
+SET
+    overdraft_limit = $2
+WHERE
+    account_id = $1
+`
+
+type SetAccountOverdraftParams struct {
+	AccountID      uuid.UUID
+	OverdraftLimit pgtype.Numeric
+}
+
+func (q *Queries) SetAccountOverdraft(ctx context.Context, arg SetAccountOverdraftParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAccountOverdraft, arg.AccountID, arg.OverdraftLimit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setAccountProduct = `-- name: SetAccountProduct :execrows
+UPDATE
+    "account"
+SET
+    product_code = $2
+WHERE
+    account_id = $1
+`
+
+type SetAccountProductParams struct {
+	AccountID   uuid.UUID
+	ProductCode string
+}
+
+func (q *Queries) SetAccountProduct(ctx context.Context, arg SetAccountProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAccountProduct, arg.AccountID, arg.ProductCode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setAccountScreeningStatus = `-- name: SetAccountScreeningStatus :exec
+UPDATE
+    "account"
+SET
+    screening_status = $2
+WHERE
+    account_id = $1
+`
+
+type SetAccountScreeningStatusParams struct {
+	AccountID       uuid.UUID
+	ScreeningStatus string
+}
+
+func (q *Queries) SetAccountScreeningStatus(ctx context.Context, arg SetAccountScreeningStatusParams) error {
+	_, err := q.db.Exec(ctx, setAccountScreeningStatus, arg.AccountID, arg.ScreeningStatus)
+	return err
+}
+
+const setCustomerKYCStatus = `-- name: SetCustomerKYCStatus :one
+UPDATE
+    "customer"
+SET
+    kyc_status = $2,
+    updated_at = $3
+WHERE
+    customer_id = $1
+RETURNING
+    customer_id, name, email, phone, kyc_status, created_at, updated_at
+`
+
+type SetCustomerKYCStatusParams struct {
+	CustomerID uuid.UUID
+	KYCStatus  string
+	UpdatedAt  pgtype.Timestamptz
+}
+
+func (q *Queries) SetCustomerKYCStatus(ctx context.Context, arg SetCustomerKYCStatusParams) (Customer, error) {
+	row := q.db.QueryRow(ctx, setCustomerKYCStatus, arg.CustomerID, arg.KYCStatus, arg.UpdatedAt)
+	var i Customer
+	err := row.Scan(
+		&i.CustomerID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.KYCStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setLimitTier = `-- name: SetLimitTier :one
+INSERT INTO "limit_tier"(tier, max_transfer, daily_amount, monthly_amount, daily_count, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tier)
+    DO UPDATE SET
+        max_transfer = EXCLUDED.max_transfer, daily_amount = EXCLUDED.daily_amount, monthly_amount = EXCLUDED.monthly_amount, daily_count = EXCLUDED.daily_count, updated_at = EXCLUDED.updated_at
+    RETURNING
+        tier, max_transfer, daily_amount, monthly_amount, daily_count, updated_at
+`
+
+type SetLimitTierParams struct {
+	Tier          string
+	MaxTransfer   pgtype.Numeric
+	DailyAmount   pgtype.Numeric
+	MonthlyAmount pgtype.Numeric
+	DailyCount    pgtype.Int4
+	UpdatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) SetLimitTier(ctx context.Context, arg SetLimitTierParams) (LimitTier, error) {
+	row := q.db.QueryRow(ctx, setLimitTier,
+		arg.Tier,
+		arg.MaxTransfer,
+		arg.DailyAmount,
+		arg.MonthlyAmount,
+		arg.DailyCount,
+		arg.UpdatedAt,
+	)
+	var i LimitTier
+	err := row.Scan(
+		&i.Tier,
+		&i.MaxTransfer,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setPendingTransferTransaction = `-- name: SetPendingTransferTransaction :exec
+UPDATE
+    "pending_transfer"
+SET
+    transaction_id = $2
+WHERE
+    pending_transfer_id = $1
+`
+
+type SetPendingTransferTransactionParams struct {
+	PendingTransferID uuid.UUID
+	TransactionID     uuid.NullUUID
+}
+
+func (q *Queries) SetPendingTransferTransaction(ctx context.Context, arg SetPendingTransferTransactionParams) error {
+	_, err := q.db.Exec(ctx, setPendingTransferTransaction, arg.PendingTransferID, arg.TransactionID)
+	return err
+}
+
+const setPocketGoal = `-- name: SetPocketGoal :execrows
+UPDATE
+    "account"
+SET
+    goal_amount = $1,
+    goal_date = $2
+WHERE
+    account_id = $3
+    AND parent_account_id = $4
+`
+
+type SetPocketGoalParams struct {
+	GoalAmount      pgtype.Numeric
+	GoalDate        pgtype.Date
+	PocketID        uuid.UUID
+	ParentAccountID uuid.NullUUID
+}
+
+func (q *Queries) SetPocketGoal(ctx context.Context, arg SetPocketGoalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setPocketGoal,
+		arg.GoalAmount,
+		arg.GoalDate,
+		arg.PocketID,
+		arg.ParentAccountID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setTransferApprovalTransaction = `-- name: SetTransferApprovalTransaction :exec
+UPDATE
+    "transfer_approval"
+SET
+    transaction_id = $2
+WHERE
+    transfer_approval_id = $1
+`
+
+type SetTransferApprovalTransactionParams struct {
+	TransferApprovalID uuid.UUID
+	TransactionID      uuid.NullUUID
+}
+
+func (q *Queries) SetTransferApprovalTransaction(ctx context.Context, arg SetTransferApprovalTransactionParams) error {
+	_, err := q.db.Exec(ctx, setTransferApprovalTransaction, arg.TransferApprovalID, arg.TransactionID)
+	return err
+}
+
+const updateBeneficiary = `-- name: UpdateBeneficiary :one
+UPDATE
+    "beneficiary"
+SET
+    nickname = $3,
+    transfer_limit = $4,
+    updated_at = $5
+WHERE
+    account_id = $1
+    AND beneficiary_id = $2
+RETURNING
+    beneficiary_id, account_id, nickname, reciver_account_id, transfer_limit, created_at, updated_at
+`
+
+type UpdateBeneficiaryParams struct {
+	AccountID     uuid.UUID
+	BeneficiaryID uuid.UUID
+	Nickname      string
+	TransferLimit pgtype.Numeric
+	UpdatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) UpdateBeneficiary(ctx context.Context, arg UpdateBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRow(ctx, updateBeneficiary,
+		arg.AccountID,
+		arg.BeneficiaryID,
+		arg.Nickname,
+		arg.TransferLimit,
+		arg.UpdatedAt,
+	)
+	var i Beneficiary
+	err := row.Scan(
+		&i.BeneficiaryID,
+		&i.AccountID,
+		&i.Nickname,
+		&i.ReciverAccountID,
+		&i.TransferLimit,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updatePayment = `-- name: UpdatePayment :exec
+UPDATE
+    "payment"
+SET
+    status = $2,
+    reason_code = $3,
+    reason = $4,
+    transaction_id = $5,
+    updated_at = $6
+WHERE
+    payment_id = $1
+`
+
+type UpdatePaymentParams struct {
+	PaymentID     uuid.UUID
+	Status        string
+	ReasonCode    pgtype.Text
+	Reason        pgtype.Text
+	TransactionID uuid.NullUUID
+	UpdatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) UpdatePayment(ctx context.Context, arg UpdatePaymentParams) error {
+	_, err := q.db.Exec(ctx, updatePayment,
+		arg.PaymentID,
+		arg.Status,
+		arg.ReasonCode,
+		arg.Reason,
+		arg.TransactionID,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const updatePaymentFileStatus = `-- name: UpdatePaymentFileStatus :exec
+UPDATE
+    "payment_file"
+SET
+    status = $2,
+    updated_at = $3
+WHERE
+    payment_file_id = $1
+`
+
+type UpdatePaymentFileStatusParams struct {
+	PaymentFileID uuid.UUID
+	Status        string
+	UpdatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) UpdatePaymentFileStatus(ctx context.Context, arg UpdatePaymentFileStatusParams) error {
+	_, err := q.db.Exec(ctx, updatePaymentFileStatus, arg.PaymentFileID, arg.Status, arg.UpdatedAt)
+	return err
+}
+
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :exec
+UPDATE
+    "webhook_delivery"
+SET
+    status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_status_code = $5,
+    last_error = $6,
+    updated_at = $7
+WHERE
+    webhook_delivery_id = $1
+`
+
+type UpdateWebhookDeliveryParams struct {
+	WebhookDeliveryID uuid.UUID
+	Status            string
+	Attempts          int32
+	NextAttemptAt     pgtype.Timestamptz
+	LastStatusCode    pgtype.Int4
+	LastError         pgtype.Text
+	UpdatedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, updateWebhookDelivery,
+		arg.WebhookDeliveryID,
+		arg.Status,
+		arg.Attempts,
+		arg.NextAttemptAt,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertAccountHolder = `-- name: UpsertAccountHolder :one
+INSERT INTO "account_holder"(account_id, customer_id, role, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (account_id, customer_id)
+    DO UPDATE SET
+        role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+    WHERE
+        account_holder.role <> 'owner'
+    RETURNING
+        account_id, customer_id, role, created_at, updated_at
+`
+
+type UpsertAccountHolderParams struct {
+	AccountID  uuid.UUID
+	CustomerID uuid.UUID
+	Role       string
+	CreatedAt  pgtype.Timestamptz
+}
+
+// The role of the owner cannot be changed.
+func (q *Queries) UpsertAccountHolder(ctx context.Context, arg UpsertAccountHolderParams) (AccountHolder, error) {
+	row := q.db.QueryRow(ctx, upsertAccountHolder,
+		arg.AccountID,
+		arg.CustomerID,
+		arg.Role,
+		arg.CreatedAt,
+	)
+	var i AccountHolder
+	err := row.Scan(
+		&i.AccountID,
+		&i.CustomerID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertAccountProduct = `-- name: UpsertAccountProduct :one
+INSERT INTO "account_product"(product_code, name, interest_rate, day_count, currency_codes, limit_tier, overdraft_eligible, max_overdraft_limit, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (product_code)
+    DO UPDATE SET
+        name = EXCLUDED.name, interest_rate = EXCLUDED.interest_rate, day_count = EXCLUDED.day_count, currency_codes = EXCLUDED.currency_codes, limit_tier = EXCLUDED.limit_tier, overdraft_eligible = EXCLUDED.overdraft_eligible, max_overdraft_limit = EXCLUDED.max_overdraft_limit, updated_at = EXCLUDED.updated_at
+    RETURNING
+        product_code, name, interest_rate, day_count, created_at, updated_at, currency_codes, limit_tier, overdraft_eligible, max_overdraft_limit
+`
+
+type UpsertAccountProductParams struct {
+	ProductCode       string
+	Name              string
+	InterestRate      pgtype.Numeric
+	DayCount          string
+	CurrencyCodes     []string
+	LimitTier         string
+	OverdraftEligible bool
+	MaxOverdraftLimit pgtype.Numeric
+	CreatedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertAccountProduct(ctx context.Context, arg UpsertAccountProductParams) (AccountProduct, error) {
+	row := q.db.QueryRow(ctx, upsertAccountProduct,
+		arg.ProductCode,
+		arg.Name,
+		arg.InterestRate,
+		arg.DayCount,
+		arg.CurrencyCodes,
+		arg.LimitTier,
+		arg.OverdraftEligible,
+		arg.MaxOverdraftLimit,
+		arg.CreatedAt,
+	)
+	var i AccountProduct
+	err := row.Scan(
+		&i.ProductCode,
+		&i.Name,
+		&i.InterestRate,
+		&i.DayCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CurrencyCodes,
+		&i.LimitTier,
+		&i.OverdraftEligible,
+		&i.MaxOverdraftLimit,
+	)
+	return i, err
+}
+
+const upsertFeeSchedule = `-- name: UpsertFeeSchedule :one
+INSERT INTO "fee_schedule"(product_code, fee_type, kind, amount, rate, min_amount, max_amount, tiers, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (product_code, fee_type)
+    DO UPDATE SET
+        kind = EXCLUDED.kind, amount = EXCLUDED.amount, rate = EXCLUDED.rate, min_amount = EXCLUDED.min_amount, max_amount = EXCLUDED.max_amount, tiers = EXCLUDED.tiers, updated_at = EXCLUDED.updated_at
+    RETURNING
+        product_code, fee_type, kind, amount, rate, min_amount, max_amount, tiers, created_at, updated_at
+`
+
+type UpsertFeeScheduleParams struct {
+	ProductCode string
+	FeeType     string
+	Kind        string
+	Amount      pgtype.Numeric
+	Rate        pgtype.Numeric
+	MinAmount   pgtype.Numeric
+	MaxAmount   pgtype.Numeric
+	Tiers       []byte
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertFeeSchedule(ctx context.Context, arg UpsertFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRow(ctx, upsertFeeSchedule,
+		arg.ProductCode,
+		arg.FeeType,
+		arg.Kind,
+		arg.Amount,
+		arg.Rate,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Tiers,
+		arg.CreatedAt,
+	)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ProductCode,
+		&i.FeeType,
+		&i.Kind,
+		&i.Amount,
+		&i.Rate,
+		&i.MinAmount,
+		&i.MaxAmount,
+		&i.Tiers,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSanctionsList = `-- name: UpsertSanctionsList :exec
+INSERT INTO "sanctions_list"(list, entries, imported_at)
+    VALUES ($1, $2, $3)
+ON CONFLICT (list)
+    DO UPDATE SET
+        entries = EXCLUDED.entries, imported_at = EXCLUDED.imported_at
+`
+
+type UpsertSanctionsListParams struct {
+	List       string
+	Entries    int32
+	ImportedAt pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertSanctionsList(ctx context.Context, arg UpsertSanctionsListParams) error {
+	_, err := q.db.Exec(ctx, upsertSanctionsList, arg.List, arg.Entries, arg.ImportedAt)
+	return err
 }
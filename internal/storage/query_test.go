@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// testQueries returns queries run within a transaction rolled back at the end of the test, against the migrated
+// database of DATABASE_URL. The test is skipped when it is not set.
+func testQueries(t *testing.T) *Queries {
+	t.Helper()
+
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = tx.Rollback(ctx)
+		_ = conn.Close(ctx)
+	})
+
+	return New(tx)
+}
+
+// testConn returns a connection to the migrated database of DATABASE_URL, for the tests of what concurrent
+// transactions commit. The test is skipped when it is not set.
+func testConn(t *testing.T) *pgx.Conn {
+	t.Helper()
+
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close(ctx) })
+
+	return conn
+}
+
+// testAccount creates an account.
+func testAccount(t *testing.T, q *Queries) uuid.UUID {
+	t.Helper()
+
+	account, err := q.CreateAccount(context.Background(), CreateAccountParams{
+		Email:         uuid.NewString() + "@mail.com",
+		Name:          "name",
+		CurrencyCode:  "EUR",
+		AccountNumber: rand.Int64N(1e10), //nolint:gosec // not a secret.
+		ProductCode:   "current",
+	})
+	require.NoError(t, err)
+
+	return account.AccountID
+}
+
+func TestQueries_transfersWithFee(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	q := testQueries(t)
+	accountID := testAccount(t, q)
+	reciverAccountID := testAccount(t, q)
+	since := pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	_, err := q.AddTransaction(ctx, AddTransactionParams{
+		AccountID: accountID, Amount: NumericFromAmount(10000, "EUR"), Type: types.TransactionTypeDeposit,
+	})
+	require.NoError(t, err)
+
+	transfer, err := q.AddTransaction(ctx, AddTransactionParams{
+		AccountID: accountID, Amount: NumericFromAmount(-1000, "EUR"), Type: types.TransactionTypeTransfer,
+	})
+	require.NoError(t, err)
+
+	_, err = q.AddTransaction(ctx, AddTransactionParams{
+		AccountID: reciverAccountID, Amount: NumericFromAmount(1000, "EUR"),
+		SourceID: uuid.NullUUID{UUID: transfer.TransactionID, Valid: true}, Type: types.TransactionTypeTransfer,
+	})
+	require.NoError(t, err)
+
+	_, err = q.AddTransaction(ctx, AddTransactionParams{
+		AccountID: accountID, Amount: NumericFromAmount(-50, "EUR"), Type: types.TransactionTypeFee,
+	})
+	require.NoError(t, err)
+
+	usage, err := q.GetTransferUsage(ctx, GetTransferUsageParams{
+		AccountID: accountID, DayStart: since, MonthStart: since,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), usage.DailyCount)
+	assert.Equal(t, int64(1000), AmountFromNumeric(usage.DailyAmount, "EUR"))
+	assert.Equal(t, int64(1000), AmountFromNumeric(usage.MonthlyAmount, "EUR"))
+
+	count, err := q.CountTransfersSince(ctx, CountTransfersSinceParams{AccountID: accountID, Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), count)
+
+	average, err := q.GetTransferAverage(ctx, GetTransferAverageParams{AccountID: accountID, Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), average.Transfers)
+	assert.Equal(t, int64(1000), AmountFromNumeric(average.Average, "EUR"))
+}
+
+func TestQueries_ListCustomerAccounts_jointHoldings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	q := testQueries(t)
+	ownAccountID, jointAccountID := testAccount(t, q), testAccount(t, q)
+
+	own, err := q.GetAccount(ctx, ownAccountID)
+	require.NoError(t, err)
+
+	pocket, err := q.CreatePocket(ctx, CreatePocketParams{
+		Name:            "pocket",
+		AccountNumber:   rand.Int64N(1e10), //nolint:gosec // not a secret.
+		ParentAccountID: jointAccountID,
+	})
+	require.NoError(t, err)
+
+	_, err = q.UpsertAccountHolder(ctx, UpsertAccountHolderParams{
+		AccountID:  jointAccountID,
+		CustomerID: own.CustomerID,
+		Role:       types.HolderRoleCoOwner,
+		CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	rows, err := q.ListCustomerAccounts(ctx, own.CustomerID)
+	require.NoError(t, err)
+
+	accountIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		accountIDs = append(accountIDs, row.Account.AccountID)
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{ownAccountID, jointAccountID, pocket.AccountID}, accountIDs)
+}
+
+func TestQueries_outboxSequence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	first, second := testConn(t), testConn(t)
+	accountID := testAccount(t, New(first))
+	event := AddOutboxEventParams{
+		EventType:  "MoneyAdded",
+		AccountID:  accountID,
+		Payload:    []byte(`{}`),
+		OccurredAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	tx, err := first.Begin(ctx)
+	require.NoError(t, err)
+
+	added, err := New(tx).AddOutboxEvent(ctx, event)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), added.AccountSequence)
+
+	// The events of the account cannot be added until the transaction adding the first one ends.
+	blocked, err := second.Begin(ctx)
+	require.NoError(t, err)
+
+	_, err = blocked.Exec(ctx, "SET LOCAL lock_timeout = '100ms'")
+	require.NoError(t, err)
+
+	_, err = New(blocked).AddOutboxEvent(ctx, event)
+
+	pgErr := &pgconn.PgError{}
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "55P03", pgErr.Code)
+	require.NoError(t, blocked.Rollback(ctx))
+
+	require.NoError(t, tx.Commit(ctx))
+
+	next, err := New(second).AddOutboxEvent(ctx, event)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), next.AccountSequence)
+	assert.Greater(t, next.OutboxEventID, added.OutboxEventID)
+}
+
+func TestQueries_ListTransactionsAfter_commitOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	first, second := testConn(t), testConn(t)
+	q := New(first)
+	accountID := testAccount(t, q)
+	deposit := AddTransactionParams{
+		AccountID: accountID, Amount: NumericFromAmount(100, "EUR"), Type: types.TransactionTypeDeposit,
+	}
+
+	// The transaction committed last is created first, its creation time being the start of its database transaction.
+	tx, err := second.Begin(ctx)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, "SELECT now()")
+	require.NoError(t, err)
+
+	committedFirst, err := q.AddTransaction(ctx, deposit)
+	require.NoError(t, err)
+
+	committedLast, err := New(tx).AddTransaction(ctx, deposit)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	assert.True(t, committedLast.CreatedAt.Time.Before(committedFirst.CreatedAt.Time))
+	assert.Equal(t, committedFirst.AccountSequence+1, committedLast.AccountSequence)
+
+	after, err := q.ListTransactionsAfter(ctx, ListTransactionsAfterParams{
+		AccountID: accountID,
+		After:     uuid.NullUUID{UUID: committedFirst.TransactionID, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Transaction{committedLast}, after)
+
+	latest, err := q.ListTransactions(ctx, ListTransactionsParams{AccountID: accountID, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []Transaction{committedLast}, latest)
+}
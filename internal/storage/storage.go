@@ -11,6 +11,7 @@ import (
 type DBConnection interface {
 	Ping(ctx context.Context) error
 	Begin(ctx context.Context) (pgx.Tx, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
 type AccountStore interface {
@@ -18,7 +19,210 @@ type AccountStore interface {
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
 	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
 	GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error)
+	LockAccounts(ctx context.Context, accountIds []uuid.UUID) error
+	ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error)
+	HasAccountTransaction(ctx context.Context, arg HasAccountTransactionParams) (bool, error)
+	ListTransactionsAfter(ctx context.Context, arg ListTransactionsAfterParams) ([]Transaction, error)
+	NextAccountNumber(ctx context.Context) (int64, error)
+	GetAccountByIBAN(ctx context.Context, iban pgtype.Text) (Account, error)
+}
+
+type CustomerStore interface {
+	CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error)
+	GetCustomer(ctx context.Context, customerID uuid.UUID) (Customer, error)
+	SetCustomerKYCStatus(ctx context.Context, arg SetCustomerKYCStatusParams) (Customer, error)
+	ListCustomerAccounts(ctx context.Context, customerID uuid.UUID) ([]ListCustomerAccountsRow, error)
+}
+
+type HolderStore interface {
+	GetAccountHolderRole(ctx context.Context, arg GetAccountHolderRoleParams) (string, error)
+	ListAccountHolders(ctx context.Context, accountID uuid.UUID) ([]AccountHolder, error)
+	UpsertAccountHolder(ctx context.Context, arg UpsertAccountHolderParams) (AccountHolder, error)
+	DeleteAccountHolder(ctx context.Context, arg DeleteAccountHolderParams) (int64, error)
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	SetAccountApprovalThreshold(ctx context.Context, arg SetAccountApprovalThresholdParams) (int64, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	GetCustomer(ctx context.Context, customerID uuid.UUID) (Customer, error)
+}
+
+type TransferApprovalStore interface {
+	HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error)
+	ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error)
+	GetTransferApproval(ctx context.Context, arg GetTransferApprovalParams) (TransferApproval, error)
+	DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error)
+	ReopenTransferApproval(ctx context.Context, transferApprovalID uuid.UUID) error
+	SetTransferApprovalTransaction(ctx context.Context, arg SetTransferApprovalTransactionParams) error
+}
+
+type IBANStore interface {
+	ListAccountsWithoutIBAN(ctx context.Context, limit int32) ([]Account, error)
+	SetAccountIBAN(ctx context.Context, arg SetAccountIBANParams) error
+}
+
+type BeneficiaryStore interface {
+	GetAccountByIBAN(ctx context.Context, iban pgtype.Text) (Account, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	ListBeneficiaries(ctx context.Context, accountID uuid.UUID) ([]ListBeneficiariesRow, error)
+	GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (GetBeneficiaryRow, error)
+	UpdateBeneficiary(ctx context.Context, arg UpdateBeneficiaryParams) (Beneficiary, error)
+	DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (int64, error)
+}
+
+type LimitStore interface {
 	HasAccount(ctx context.Context, accountID uuid.UUID) (bool, error)
+	GetAccountLimits(ctx context.Context, accountID uuid.UUID) (GetAccountLimitsRow, error)
+	GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error)
+	SetLimitTier(ctx context.Context, arg SetLimitTierParams) (LimitTier, error)
+	SetAccountLimits(ctx context.Context, arg SetAccountLimitsParams) error
+}
+
+type RiskStore interface {
+	CountTransfersSince(ctx context.Context, arg CountTransfersSinceParams) (int32, error)
+	GetTransferAverage(ctx context.Context, arg GetTransferAverageParams) (GetTransferAverageRow, error)
+	HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error)
+	CreatePendingTransfer(ctx context.Context, arg CreatePendingTransferParams) (PendingTransfer, error)
+}
+
+type ProductStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	UpsertAccountProduct(ctx context.Context, arg UpsertAccountProductParams) (AccountProduct, error)
+	ListAccountProducts(ctx context.Context) ([]AccountProduct, error)
+	GetAccountProduct(ctx context.Context, productCode string) (AccountProduct, error)
+	SetAccountProduct(ctx context.Context, arg SetAccountProductParams) (int64, error)
+}
+
+type InterestStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	ListAccruingAccounts(ctx context.Context, arg ListAccruingAccountsParams) ([]ListAccruingAccountsRow, error)
+	AddInterestAccrual(ctx context.Context, arg AddInterestAccrualParams) (int64, error)
+	ListUncapitalizedAccounts(ctx context.Context, before pgtype.Date) ([]ListUncapitalizedAccountsRow, error)
+	LockUncapitalizedInterest(ctx context.Context, arg LockUncapitalizedInterestParams) ([]pgtype.Numeric, error)
+	AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error)
+	CapitalizeInterest(ctx context.Context, arg CapitalizeInterestParams) error
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+}
+
+type FeeStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	UpsertFeeSchedule(ctx context.Context, arg UpsertFeeScheduleParams) (FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, productCode string) ([]FeeSchedule, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	DeleteFeeSchedule(ctx context.Context, arg DeleteFeeScheduleParams) (int64, error)
+	AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error)
+	AddFee(ctx context.Context, arg AddFeeParams) (int64, error)
+	ListMaintenanceFeeAccounts(
+		ctx context.Context, arg ListMaintenanceFeeAccountsParams) ([]ListMaintenanceFeeAccountsRow, error)
+}
+
+type OverdraftStore interface {
+	GetOverdraftTerms(ctx context.Context, accountID uuid.UUID) (GetOverdraftTermsRow, error)
+	SetAccountOverdraft(ctx context.Context, arg SetAccountOverdraftParams) (int64, error)
+	ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]ListOverdrawnAccountsRow, error)
+	AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error)
+	AddOverdraftInterest(ctx context.Context, arg AddOverdraftInterestParams) (int64, error)
+	GetLastOverdraftInterestDay(ctx context.Context) (pgtype.Date, error)
+}
+
+type PocketStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	NextAccountNumber(ctx context.Context) (int64, error)
+	CreatePocket(ctx context.Context, arg CreatePocketParams) (Account, error)
+	GetPocket(ctx context.Context, arg GetPocketParams) (GetPocketRow, error)
+	ListPockets(ctx context.Context, parentAccountID uuid.NullUUID) ([]ListPocketsRow, error)
+	SetPocketGoal(ctx context.Context, arg SetPocketGoalParams) (int64, error)
+	LockAccounts(ctx context.Context, accountIds []uuid.UUID) error
+	GetAccountTotalAmount(ctx context.Context, accountID uuid.UUID) (pgtype.Numeric, error)
+	AddTransaction(ctx context.Context, arg AddTransactionParams) (Transaction, error)
+}
+
+type PendingTransferStore interface {
+	GetPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) (PendingTransfer, error)
+	ListPendingTransfers(ctx context.Context, arg ListPendingTransfersParams) ([]PendingTransfer, error)
+	DecidePendingTransfer(ctx context.Context, arg DecidePendingTransferParams) (PendingTransfer, error)
+	ReopenPendingTransfer(ctx context.Context, pendingTransferID uuid.UUID) error
+	SetPendingTransferTransaction(ctx context.Context, arg SetPendingTransferTransactionParams) error
+}
+
+type SanctionsStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	GetSanctionsVersion(ctx context.Context) (GetSanctionsVersionRow, error)
+	ListSanctionsEntries(ctx context.Context) ([]SanctionsEntry, error)
+	AddSanctionsScreening(ctx context.Context, arg AddSanctionsScreeningParams) (SanctionsScreening, error)
+	SetAccountScreeningStatus(ctx context.Context, arg SetAccountScreeningStatusParams) error
+	GetSanctionsScreening(ctx context.Context, screeningID uuid.UUID) (SanctionsScreening, error)
+	ListSanctionsScreenings(ctx context.Context, arg ListSanctionsScreeningsParams) ([]SanctionsScreening, error)
+	ResolveSanctionsScreening(ctx context.Context, arg ResolveSanctionsScreeningParams) (SanctionsScreening, error)
+}
+
+type SanctionsImportStore interface {
+	UpsertSanctionsList(ctx context.Context, arg UpsertSanctionsListParams) error
+	DeleteSanctionsEntries(ctx context.Context, list string) error
+	AddSanctionsEntries(ctx context.Context, arg []AddSanctionsEntriesParams) (int64, error)
+}
+
+type IdempotencyStore interface {
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	SaveIdempotencyKeyResponse(ctx context.Context, arg SaveIdempotencyKeyResponseParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore pgtype.Timestamptz) (int64, error)
+}
+
+type AuditStore interface {
+	LockAuditChain(ctx context.Context) error
+	GetLastAuditEventHash(ctx context.Context) ([]byte, error)
+	AddAuditEvent(ctx context.Context, arg AddAuditEventParams) (AuditEvent, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListAuditEventsAfter(ctx context.Context, arg ListAuditEventsAfterParams) ([]AuditEvent, error)
+}
+
+type OutboxStore interface {
+	AddOutboxEvent(ctx context.Context, arg AddOutboxEventParams) (Outbox, error)
+	ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error)
+	MarkOutboxEventsPublished(ctx context.Context, arg MarkOutboxEventsPublishedParams) error
+}
+
+type StatementStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (pgtype.Numeric, error)
+	ListTransactionsBetween(ctx context.Context, arg ListTransactionsBetweenParams) ([]Transaction, error)
+}
+
+type PaymentFileStore interface {
+	GetAccount(ctx context.Context, accountID uuid.UUID) (Account, error)
+	CreatePaymentFile(ctx context.Context, arg CreatePaymentFileParams) (PaymentFile, error)
+	AddPayment(ctx context.Context, arg AddPaymentParams) (Payment, error)
+	ExecutePayment(ctx context.Context, arg ExecutePaymentParams) (int64, error)
+	UpdatePayment(ctx context.Context, arg UpdatePaymentParams) error
+	UpdatePaymentFileStatus(ctx context.Context, arg UpdatePaymentFileStatusParams) error
+	GetPaymentFile(ctx context.Context, arg GetPaymentFileParams) (PaymentFile, error)
+	GetPaymentFileByMessageID(ctx context.Context, arg GetPaymentFileByMessageIDParams) (PaymentFile, error)
+	ListPayments(ctx context.Context, paymentFileID uuid.UUID) ([]Payment, error)
+	ListHeldPayments(ctx context.Context, paymentFileID uuid.UUID) ([]ListHeldPaymentsRow, error)
+}
+
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	HasWebhook(ctx context.Context, webhookID uuid.UUID) (bool, error)
+	ListWebhooksForEvent(ctx context.Context, arg ListWebhooksForEventParams) ([]Webhook, error)
+	AddWebhookDelivery(ctx context.Context, arg AddWebhookDeliveryParams) error
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
+	ListWebhookDeliveryAttempts(ctx context.Context, webhookDeliveryID uuid.UUID) ([]WebhookDeliveryAttempt, error)
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error)
+}
+
+type WebhookDeliveryStore interface {
+	ClaimDueWebhookDeliveries(
+		ctx context.Context,
+		arg ClaimDueWebhookDeliveriesParams,
+	) ([]ClaimDueWebhookDeliveriesRow, error)
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
+	AddWebhookDeliveryAttempt(ctx context.Context, arg AddWebhookDeliveryAttemptParams) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, arg NotifyParams) error
 }
 
 var AccountStoreWithTx = func(tx pgx.Tx) AccountStore {
@@ -26,3 +230,123 @@ var AccountStoreWithTx = func(tx pgx.Tx) AccountStore {
 		db: tx,
 	}
 }
+
+var AuditStoreWithTx = func(tx pgx.Tx) AuditStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var BeneficiaryStoreWithTx = func(tx pgx.Tx) BeneficiaryStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var CustomerStoreWithTx = func(tx pgx.Tx) CustomerStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var FeeStoreWithTx = func(tx pgx.Tx) FeeStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var HolderStoreWithTx = func(tx pgx.Tx) HolderStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var InterestStoreWithTx = func(tx pgx.Tx) InterestStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var LimitStoreWithTx = func(tx pgx.Tx) LimitStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var OutboxStoreWithTx = func(tx pgx.Tx) OutboxStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var OverdraftStoreWithTx = func(tx pgx.Tx) OverdraftStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var PaymentFileStoreWithTx = func(tx pgx.Tx) PaymentFileStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var PendingTransferStoreWithTx = func(tx pgx.Tx) PendingTransferStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var PocketStoreWithTx = func(tx pgx.Tx) PocketStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var ProductStoreWithTx = func(tx pgx.Tx) ProductStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var RiskStoreWithTx = func(tx pgx.Tx) RiskStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var SanctionsStoreWithTx = func(tx pgx.Tx) SanctionsStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var SanctionsImportStoreWithTx = func(tx pgx.Tx) SanctionsImportStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var StatementStoreWithTx = func(tx pgx.Tx) StatementStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var TransferApprovalStoreWithTx = func(tx pgx.Tx) TransferApprovalStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var WebhookStoreWithTx = func(tx pgx.Tx) WebhookStore {
+	return &Queries{
+		db: tx,
+	}
+}
+
+var WebhookDeliveryStoreWithTx = func(tx pgx.Tx) WebhookDeliveryStore {
+	return &Queries{
+		db: tx,
+	}
+}
@@ -0,0 +1,28 @@
+// Package storagetest provides the storage test doubles shared by the tests of the services.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+)
+
+// NewConn returns a connection beginning one transaction, which is committed when commit is set.
+func NewConn(t *testing.T, commit bool) *storageMocks.MockDBConnection {
+	t.Helper()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	tx := txMocks.NewMockTx(t)
+
+	conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+
+	if commit {
+		tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	}
+
+	return conn
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// InTx runs fn within a transaction of conn, with the store of the transaction, and commits it unless fn fails. The
+// errors of fn are returned as they are, while failing to begin or commit the transaction is logged and reported as
+// types.ErrInternal.
+func InTx[S any](
+	ctx context.Context,
+	conn DBConnection,
+	storeWithTx func(tx pgx.Tx) S,
+	logger logger.Logger,
+	fn func(tx pgx.Tx, store S) error,
+) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to begin transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	defer Rollback(ctx, tx, logger)
+
+	if err := fn(tx, storeWithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.ErrorContext(ctx, "failed to commit transaction", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+// Rollback rolls tx back unless it is already committed, logging the failure. It is deferred once tx is begun.
+func Rollback(ctx context.Context, tx pgx.Tx, logger logger.Logger) {
+	if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		logger.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+	}
+}
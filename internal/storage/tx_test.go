@@ -0,0 +1,83 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var errAnything = errors.New("any")
+
+func TestInTx(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		beginErr  error
+		fnErr     error
+		commit    bool
+		commitErr error
+		wantErr   error
+	}{
+		{
+			name:     "failed when the transaction cannot be begun",
+			beginErr: errAnything,
+			wantErr:  types.ErrInternal,
+		},
+		{
+			name:    "failed with the error of fn, rolled back",
+			fnErr:   types.ErrAccountNotFound,
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name:      "failed when the transaction cannot be committed",
+			commit:    true,
+			commitErr: errAnything,
+			wantErr:   types.ErrInternal,
+		},
+		{
+			name:   "success",
+			commit: true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			tx := txMocks.NewMockTx(t)
+			store := storageMocks.NewMockAccountStore(t)
+
+			conn.EXPECT().Begin(mock.Anything).Return(tx, tt.beginErr).Once()
+
+			if tt.beginErr == nil {
+				tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Once()
+			}
+
+			if tt.commit {
+				tx.EXPECT().Commit(mock.Anything).Return(tt.commitErr).Once()
+			}
+
+			err := storage.InTx(context.Background(), conn, func(pgx.Tx) storage.AccountStore { return store },
+				slog.Default(), func(gotTx pgx.Tx, gotStore storage.AccountStore) error {
+					assert.Equal(t, tx, gotTx)
+					assert.Equal(t, store, gotStore)
+
+					return tt.fnErr
+				})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/stats"
+)
+
+// GRPC starts a span for every gRPC call served, continuing the trace of the W3C traceparent metadata if any.
+type GRPC struct {
+	// provider and propagator default to the global ones when nil.
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// NewGRPC returns a new GRPC using the global tracer provider and propagator.
+func NewGRPC() *GRPC {
+	return &GRPC{}
+}
+
+// Handler returns the stats handler tracing the calls, naming spans after their method,
+// e.g. "xbankapi.v1.AccountService/GetAccount".
+//
+//nolint:ireturn // otelgrpc does not export its handler.
+func (g *GRPC) Handler() stats.Handler {
+	return otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(g.provider),
+		otelgrpc.WithPropagators(g.propagator),
+	)
+}
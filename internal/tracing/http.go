@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTP starts a span for every request served, continuing the trace of the W3C traceparent header
+// if any. It implements http.Instrumentation.
+type HTTP struct {
+	// provider and propagator default to the global ones when nil.
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// NewHTTP returns a new HTTP using the global tracer provider and propagator.
+func NewHTTP() *HTTP {
+	return &HTTP{}
+}
+
+// Instrument traces the requests served by next, naming spans after the route matched, e.g. "GET /accounts/{id}".
+func (h *HTTP) Instrument(next http.Handler, route func(r *http.Request) string) http.Handler {
+	withRoute := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, path, ok := strings.Cut(route(r), " "); ok {
+			trace.SpanFromContext(r.Context()).SetAttributes(semconv.HTTPRoute(path))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+
+	return otelhttp.NewHandler(withRoute, "http.server",
+		otelhttp.WithTracerProvider(h.provider),
+		otelhttp.WithPropagators(h.propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			if pattern := route(r); pattern != "" {
+				return pattern
+			}
+
+			return r.Method
+		}),
+	)
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogHandler adds the trace and span IDs of the context to every log record, so logs can be
+// correlated with traces. Errors logged also mark the span as failed.
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler returns a new LogHandler passing records to handler.
+func NewLogHandler(handler slog.Handler) *LogHandler {
+	return &LogHandler{
+		Handler: handler,
+	}
+}
+
+// Handle adds the trace_id and span_id attributes to the record when ctx carries a span.
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	span := trace.SpanFromContext(ctx)
+
+	if spanContext := span.SpanContext(); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	if record.Level >= slog.LevelError && span.IsRecording() {
+		span.AddEvent(record.Message)
+		span.SetStatus(codes.Error, record.Message)
+	}
+
+	if err := h.Handler.Handle(ctx, record); err != nil {
+		return fmt.Errorf("failed to handle log record: %w", err)
+	}
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewLogHandler(h.Handler.WithAttrs(attrs))
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return NewLogHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/zaidsasa/xbankapi/internal/tracing"
+
+	// sqlcNamePrefix starts the comment naming every query generated by sqlc, e.g. "-- name: GetAccount :one".
+	sqlcNamePrefix = "-- name: "
+)
+
+// QueryTracer traces every query sent by pgx, it implements pgx.QueryTracer.
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+// NewQueryTracer returns a new QueryTracer using the global tracer provider.
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{
+		tracer: otel.Tracer(instrumentationName),
+	}
+}
+
+// TraceQueryStart starts a span named after the query.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, queryName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+// TraceQueryEnd ends the span of the query, recording its error if it failed.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// queryName returns the name sqlc gave the query, or "query" when it has none.
+func queryName(sql string) string {
+	if name, ok := strings.CutPrefix(sql, sqlcNamePrefix); ok {
+		if name, _, ok = strings.Cut(name, " "); ok {
+			return name
+		}
+	}
+
+	return "query"
+}
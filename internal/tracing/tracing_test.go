@@ -0,0 +1,197 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var errAnything = errors.New("any")
+
+func newRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+
+	shutdown, err := Setup(context.Background(), ExporterNone)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), "zipkin")
+	assert.ErrorIs(t, err, errUnknownExporter)
+}
+
+func TestQueryTracer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sql        string
+		err        error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name:       "query named by sqlc",
+			sql:        "-- name: GetAccount :one\nSELECT 1",
+			wantName:   "GetAccount",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "query without a name",
+			sql:        "SELECT 1",
+			wantName:   "query",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "query returning no rows",
+			sql:        "-- name: GetAccount :one\nSELECT 1",
+			err:        pgx.ErrNoRows,
+			wantName:   "GetAccount",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "query failing",
+			sql:        "-- name: AddTransaction :one\nINSERT INTO transaction",
+			err:        errAnything,
+			wantName:   "AddTransaction",
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, recorder := newRecorder()
+			tracer := &QueryTracer{tracer: provider.Tracer("test")}
+
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: tt.sql})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: tt.err})
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantName, spans[0].Name())
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestHTTP_Instrument(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	route := func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+
+		return pattern
+	}
+
+	h := (&HTTP{provider: provider, propagator: propagation.TraceContext{}}).Instrument(mux, route)
+
+	r := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+	r.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /accounts/{id}", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestGRPC_Handler(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.StatsHandler((&GRPC{provider: provider, propagator: propagation.TraceContext{}}).Handler()))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	server.GracefulStop()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "grpc.health.v1.Health/Check", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestLogHandler(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+
+	var buf bytes.Buffer
+
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("service", "test")
+	logger.ErrorContext(ctx, "failed to add transaction", "error", errAnything)
+
+	span.End()
+
+	got := map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, span.SpanContext().TraceID().String(), got["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), got["span_id"])
+	assert.Equal(t, "test", got["service"])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failed to add transaction", spans[0].Status().Description)
+}
@@ -1,12 +1,20 @@
 package validator
 
 import (
+	"regexp"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/Rhymond/go-money"
 	"github.com/gookit/validate"
+	"github.com/zaidsasa/xbankapi/internal/iban"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
 )
 
+// rate matches the rates from 0 to 1, e.g. 0.025 for 2.5%, with up to 8 decimal places.
+var rate = regexp.MustCompile(`^(0(\.\d{1,8})?|1(\.0{1,8})?)$`)
+
 func ConfigureDefaultValidator() {
 	sync.OnceFunc(func() {
 		validate.Config(func(opt *validate.GlobalOption) {
@@ -23,5 +31,82 @@ func ConfigureDefaultValidator() {
 
 			return true
 		})
+
+		validate.AddValidator("money_limit", func(val any) bool {
+			v, ok := val.(money.Amount)
+
+			return ok && v >= 0
+		})
+
+		validate.AddValidator("interest_rate", rateValidator(false))
+		validate.AddValidator("fee_rate", rateValidator(true))
+		validate.AddValidator("currency_code", validCurrencyCode)
+		validate.AddValidator("currency_codes", validCurrencyCodes)
+		validate.AddValidator("date_only", validDate)
+
+		validate.AddValidator("iban", func(val any) bool {
+			v, ok := val.(string)
+
+			return ok && (v == "" || iban.Valid(v))
+		})
+
+		validate.AddValidator("event_types", func(val any) bool {
+			v, ok := val.([]string)
+			if !ok {
+				return val == nil
+			}
+
+			for _, eventType := range v {
+				if !slices.Contains(outbox.EventTypes, eventType) {
+					return false
+				}
+			}
+
+			return true
+		})
 	})()
 }
+
+// rateValidator returns a validator of rates, which accepts empty ones when they are optional.
+func rateValidator(optional bool) func(val any) bool {
+	return func(val any) bool {
+		v, ok := val.(string)
+
+		return ok && ((optional && v == "") || rate.MatchString(v))
+	}
+}
+
+// validCurrencyCode reports whether val is the code of a currency, e.g. EUR.
+func validCurrencyCode(val any) bool {
+	v, ok := val.(string)
+
+	return ok && money.GetCurrency(v) != nil
+}
+
+// validCurrencyCodes reports whether val are codes of currencies, none being valid.
+func validCurrencyCodes(val any) bool {
+	v, ok := val.([]string)
+	if !ok {
+		return val == nil
+	}
+
+	for _, code := range v {
+		if !validCurrencyCode(code) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validDate reports whether val is a date as YYYY-MM-DD, e.g. 2025-07-01, or is empty.
+func validDate(val any) bool {
+	v, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	_, err := time.Parse(time.DateOnly, v)
+
+	return v == "" || err == nil
+}
@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const (
+	HeaderDeliveryID = "X-Webhook-Delivery-ID"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+
+	defaultDeliverInterval = time.Second
+	defaultBatchSize       = 10
+	// defaultLease is how long the deliveries of a batch are claimed by a deliverer, longer than it takes to attempt
+	// them all.
+	defaultLease = 5 * time.Minute
+	// DefaultMaxAttempts is the number of attempts after which a delivery is dead.
+	DefaultMaxAttempts = 8
+
+	backoffBase    = 10 * time.Second
+	backoffMax     = time.Hour
+	maxErrorLength = 1024
+)
+
+var errUnexpectedStatus = errors.New("unexpected status code")
+
+// Deliverer attempts the due deliveries of webhooks.
+type Deliverer struct {
+	conn        storage.DBConnection
+	storeWithTx func(tx pgx.Tx) storage.WebhookDeliveryStore
+	client      *http.Client
+	logger      logger.Logger
+	interval    time.Duration
+	batchSize   int32
+	lease       time.Duration
+	maxAttempts int32
+	now         func() time.Time
+}
+
+// NewDeliverer returns a new Deliverer, deliveries are dead after maxAttempts failed attempts.
+func NewDeliverer(conn storage.DBConnection, client *http.Client, logger logger.Logger, maxAttempts int32) *Deliverer {
+	return &Deliverer{
+		conn:        conn,
+		storeWithTx: storage.WebhookDeliveryStoreWithTx,
+		client:      client,
+		logger:      logger,
+		interval:    defaultDeliverInterval,
+		batchSize:   defaultBatchSize,
+		lease:       defaultLease,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Run attempts the due deliveries every interval until ctx is done.
+func (d *Deliverer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Deliver(ctx); err != nil && ctx.Err() == nil {
+			d.logger.ErrorContext(ctx, "failed to deliver webhooks", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver attempts the due deliveries until none is left.
+func (d *Deliverer) Deliver(ctx context.Context) error {
+	for {
+		n, err := d.deliverBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n < int(d.batchSize) {
+			return nil
+		}
+	}
+}
+
+// deliverBatch attempts a batch of due deliveries, returning the size of the batch. The deliveries are claimed with a
+// lease, so that concurrent deliverers skip them, and their attempts are made outside of any transaction. A delivery
+// whose attempt cannot be recorded, e.g. because the deliverer stopped, is attempted again once its lease expires.
+func (d *Deliverer) deliverBatch(ctx context.Context) (int, error) {
+	var deliveries []storage.ClaimDueWebhookDeliveriesRow
+
+	now := d.now()
+
+	err := storage.InTx(ctx, d.conn, d.storeWithTx, d.logger,
+		func(_ pgx.Tx, store storage.WebhookDeliveryStore) error {
+			var err error
+
+			deliveries, err = store.ClaimDueWebhookDeliveries(ctx, storage.ClaimDueWebhookDeliveriesParams{
+				LeasedUntil: pgtype.Timestamptz{Time: now.Add(d.lease), Valid: true},
+				Now:         pgtype.Timestamptz{Time: now, Valid: true},
+				Limit:       d.batchSize,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver attempts the delivery, then logs the attempt within a transaction. Failed deliveries are retried with
+// exponential backoff, until they are dead.
+func (d *Deliverer) deliver(ctx context.Context, delivery storage.ClaimDueWebhookDeliveriesRow) error {
+	attemptedAt := d.now()
+	statusCode, deliverErr := d.post(ctx, delivery, attemptedAt)
+	duration := d.now().Sub(attemptedAt)
+
+	var lastError pgtype.Text
+	if deliverErr != nil {
+		lastError = pgtype.Text{String: truncate(deliverErr.Error(), maxErrorLength), Valid: true}
+	}
+
+	lastStatusCode := pgtype.Int4{Int32: statusCode, Valid: statusCode != 0}
+
+	params := storage.UpdateWebhookDeliveryParams{
+		WebhookDeliveryID: delivery.WebhookDeliveryID,
+		Status:            types.WebhookDeliverySucceeded,
+		Attempts:          delivery.Attempts + 1,
+		NextAttemptAt:     pgtype.Timestamptz{Time: attemptedAt, Valid: true},
+		LastStatusCode:    lastStatusCode,
+		LastError:         lastError,
+		UpdatedAt:         pgtype.Timestamptz{Time: attemptedAt, Valid: true},
+	}
+
+	if deliverErr != nil {
+		params.Status = types.WebhookDeliveryPending
+		params.NextAttemptAt.Time = attemptedAt.Add(backoff(params.Attempts))
+
+		if params.Attempts >= d.maxAttempts {
+			params.Status = types.WebhookDeliveryDead
+		}
+	}
+
+	return storage.InTx(ctx, d.conn, d.storeWithTx, d.logger, func(_ pgx.Tx, store storage.WebhookDeliveryStore) error {
+		if err := store.AddWebhookDeliveryAttempt(ctx, storage.AddWebhookDeliveryAttemptParams{
+			WebhookDeliveryID: delivery.WebhookDeliveryID,
+			AttemptedAt:       pgtype.Timestamptz{Time: attemptedAt, Valid: true},
+			StatusCode:        lastStatusCode,
+			Error:             lastError,
+			DurationMs:        duration.Milliseconds(),
+		}); err != nil {
+			return fmt.Errorf("failed to add webhook delivery attempt: %w", err)
+		}
+
+		if err := store.UpdateWebhookDelivery(ctx, params); err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// post posts the signed event to the webhook, returning the status code of the response if any.
+func (d *Deliverer) post(
+	ctx context.Context,
+	delivery storage.ClaimDueWebhookDeliveriesRow,
+	at time.Time,
+) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := at.Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(outbox.HeaderEventID, delivery.EventID.String())
+	req.Header.Set(outbox.HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderDeliveryID, delivery.WebhookDeliveryID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post event: %w", err)
+	}
+	defer res.Body.Close()
+
+	statusCode := int32(res.StatusCode) //nolint:gosec // status codes have 3 digits.
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return statusCode, fmt.Errorf("%w: %d", errUnexpectedStatus, res.StatusCode)
+	}
+
+	return statusCode, nil
+}
+
+// Sign returns the signature of a delivery sent at timestamp, in Unix seconds: "sha256=" followed by the hex encoded
+// HMAC-SHA256, keyed with the secret of the webhook, of the timestamp, a dot and the body. Receivers compare it to
+// the X-Webhook-Signature header and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	_, _ = mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the delay before retrying a delivery after its nth failed attempt: 10s, 20s, 40s and so on, up to
+// an hour.
+func backoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return backoffBase
+	}
+
+	if attempts > 10 { //nolint:mnd // 10s << 9 is over an hour already.
+		return backoffMax
+	}
+
+	return min(backoffBase<<(attempts-1), backoffMax)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	txMocks "github.com/zaidsasa/xbankapi/mocks/github.com/jackc/pgx/v5"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const testSecret = "0123456789abcdef"
+
+func TestDeliverer_Deliver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statusCode    int32
+		attempts      int32
+		wantStatus    string
+		wantNextAt    time.Time
+		wantLastError string
+	}{
+		{
+			name:       "succeeded when the receiver accepts the event",
+			statusCode: http.StatusNoContent,
+			wantStatus: types.WebhookDeliverySucceeded,
+			wantNextAt: wantNow,
+		},
+		{
+			name:          "retried with backoff when the receiver fails",
+			statusCode:    http.StatusInternalServerError,
+			attempts:      2,
+			wantStatus:    types.WebhookDeliveryPending,
+			wantNextAt:    wantNow.Add(40 * time.Second),
+			wantLastError: "unexpected status code: 500",
+		},
+		{
+			name:          "dead when the last attempt fails",
+			statusCode:    http.StatusGone,
+			attempts:      DefaultMaxAttempts - 1,
+			wantStatus:    types.WebhookDeliveryDead,
+			wantNextAt:    wantNow.Add(backoff(DefaultMaxAttempts)),
+			wantLastError: "unexpected status code: 410",
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+				assert.NoError(t, err)
+
+				assert.Equal(t, wantNow.Unix(), timestamp)
+				assert.Equal(t, Sign(testSecret, timestamp, body), r.Header.Get(HeaderSignature))
+				assert.Equal(t, wantDeliveryID.String(), r.Header.Get(HeaderDeliveryID))
+				assert.Equal(t, wantEventID.String(), r.Header.Get(outbox.HeaderEventID))
+				assert.Equal(t, outbox.EventMoneyAdded, r.Header.Get(outbox.HeaderEventType))
+				assert.Equal(t, wantPayload, string(body))
+
+				w.WriteHeader(int(tt.statusCode))
+			}))
+			defer srv.Close()
+
+			conn := storageMocks.NewMockDBConnection(t)
+			store := storageMocks.NewMockWebhookDeliveryStore(t)
+			tx := txMocks.NewMockTx(t)
+
+			// The deliveries are claimed in a transaction, and the attempt is recorded in another.
+			conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Twice()
+			tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Twice()
+			tx.EXPECT().Commit(mock.Anything).Return(nil).Twice()
+
+			store.EXPECT().ClaimDueWebhookDeliveries(mock.Anything, storage.ClaimDueWebhookDeliveriesParams{
+				LeasedUntil: pgtype.Timestamptz{Time: wantNow.Add(defaultLease), Valid: true},
+				Now:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+				Limit:       defaultBatchSize,
+			}).Return([]storage.ClaimDueWebhookDeliveriesRow{{
+				WebhookDeliveryID: wantDeliveryID,
+				EventID:           wantEventID,
+				EventType:         outbox.EventMoneyAdded,
+				Payload:           []byte(wantPayload),
+				Attempts:          tt.attempts,
+				Url:               srv.URL,
+				Secret:            testSecret,
+			}}, nil).Once()
+
+			lastError := pgtype.Text{String: tt.wantLastError, Valid: tt.wantLastError != ""}
+			lastStatusCode := pgtype.Int4{Int32: tt.statusCode, Valid: true}
+
+			store.EXPECT().AddWebhookDeliveryAttempt(mock.Anything, storage.AddWebhookDeliveryAttemptParams{
+				WebhookDeliveryID: wantDeliveryID,
+				AttemptedAt:       pgtype.Timestamptz{Time: wantNow, Valid: true},
+				StatusCode:        lastStatusCode,
+				Error:             lastError,
+			}).Return(nil).Once()
+			store.EXPECT().UpdateWebhookDelivery(mock.Anything, storage.UpdateWebhookDeliveryParams{
+				WebhookDeliveryID: wantDeliveryID,
+				Status:            tt.wantStatus,
+				Attempts:          tt.attempts + 1,
+				NextAttemptAt:     pgtype.Timestamptz{Time: tt.wantNextAt, Valid: true},
+				LastStatusCode:    lastStatusCode,
+				LastError:         lastError,
+				UpdatedAt:         pgtype.Timestamptz{Time: wantNow, Valid: true},
+			}).Return(nil).Once()
+
+			d := NewDeliverer(conn, srv.Client(), slog.Default(), DefaultMaxAttempts)
+			d.storeWithTx = func(pgx.Tx) storage.WebhookDeliveryStore { return store }
+			d.now = func() time.Time { return wantNow }
+
+			assert.NoError(t, d.Deliver(context.Background()))
+		})
+	}
+}
+
+func TestDeliverer_Deliver_unreachable(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	store := storageMocks.NewMockWebhookDeliveryStore(t)
+	tx := txMocks.NewMockTx(t)
+
+	conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Twice()
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Twice()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Twice()
+
+	store.EXPECT().ClaimDueWebhookDeliveries(mock.Anything, mock.Anything).
+		Return([]storage.ClaimDueWebhookDeliveriesRow{{WebhookDeliveryID: wantDeliveryID, Url: srv.URL}}, nil).Once()
+	store.EXPECT().AddWebhookDeliveryAttempt(mock.Anything,
+		mock.MatchedBy(func(p storage.AddWebhookDeliveryAttemptParams) bool {
+			return !p.StatusCode.Valid && p.Error.Valid
+		})).Return(nil).Once()
+	store.EXPECT().UpdateWebhookDelivery(mock.Anything,
+		mock.MatchedBy(func(p storage.UpdateWebhookDeliveryParams) bool {
+			return p.Status == types.WebhookDeliveryPending && p.Attempts == 1 && !p.LastStatusCode.Valid
+		})).Return(nil).Once()
+
+	d := NewDeliverer(conn, srv.Client(), slog.Default(), DefaultMaxAttempts)
+	d.storeWithTx = func(pgx.Tx) storage.WebhookDeliveryStore { return store }
+
+	assert.NoError(t, d.Deliver(context.Background()))
+}
+
+func TestDeliverer_Deliver_unrecorded(t *testing.T) {
+	t.Parallel()
+
+	var posts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		posts++
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	store := storageMocks.NewMockWebhookDeliveryStore(t)
+	tx := txMocks.NewMockTx(t)
+
+	conn.EXPECT().Begin(mock.Anything).Return(tx, nil).Times(3)
+	tx.EXPECT().Rollback(mock.Anything).Return(pgx.ErrTxClosed).Times(3)
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Twice()
+
+	otherDeliveryID := uuid.New()
+
+	store.EXPECT().ClaimDueWebhookDeliveries(mock.Anything, mock.Anything).Return([]storage.ClaimDueWebhookDeliveriesRow{
+		{WebhookDeliveryID: wantDeliveryID, Url: srv.URL},
+		{WebhookDeliveryID: otherDeliveryID, Url: srv.URL},
+	}, nil).Once()
+
+	// The first attempt cannot be recorded, its delivery is attempted again once its lease expires.
+	store.EXPECT().AddWebhookDeliveryAttempt(mock.Anything,
+		mock.MatchedBy(func(p storage.AddWebhookDeliveryAttemptParams) bool {
+			return p.WebhookDeliveryID == wantDeliveryID
+		})).Return(errAnything).Once()
+	store.EXPECT().AddWebhookDeliveryAttempt(mock.Anything,
+		mock.MatchedBy(func(p storage.AddWebhookDeliveryAttemptParams) bool {
+			return p.WebhookDeliveryID == otherDeliveryID
+		})).Return(nil).Once()
+	store.EXPECT().UpdateWebhookDelivery(mock.Anything,
+		mock.MatchedBy(func(p storage.UpdateWebhookDeliveryParams) bool {
+			return p.WebhookDeliveryID == otherDeliveryID && p.Status == types.WebhookDeliverySucceeded
+		})).Return(nil).Once()
+
+	d := NewDeliverer(conn, srv.Client(), slog.Default(), DefaultMaxAttempts)
+	d.storeWithTx = func(pgx.Tx) storage.WebhookDeliveryStore { return store }
+
+	assert.ErrorIs(t, d.Deliver(context.Background()), errAnything)
+	assert.Equal(t, 2, posts)
+}
+
+func TestDeliverer_Run(t *testing.T) {
+	t.Parallel()
+
+	conn := storageMocks.NewMockDBConnection(t)
+	conn.EXPECT().Begin(mock.Anything).Return(nil, errAnything)
+
+	d := NewDeliverer(conn, http.DefaultClient, slog.Default(), DefaultMaxAttempts)
+	d.interval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, d.Run(ctx))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"sha256=a216e8f53e80598d2a5386ca96bfe32a34041214a42394b99cd3d874c564416f",
+		Sign(testSecret, 1714557600, []byte(`{"amount":100}`)),
+	)
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 0, want: 10 * time.Second},
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 5, want: 160 * time.Second},
+		{attempts: 9, want: 2560 * time.Second},
+		{attempts: 10, want: time.Hour},
+		{attempts: 100, want: time.Hour},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(strconv.Itoa(int(tt.attempts)), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, backoff(tt.attempts))
+		})
+	}
+}
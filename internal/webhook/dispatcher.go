@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+// Dispatcher schedules the delivery of events to the webhooks subscribed to them, it implements outbox.Publisher.
+type Dispatcher struct {
+	store storage.WebhookStore
+	now   func() time.Time
+}
+
+// NewDispatcher returns a new Dispatcher.
+func NewDispatcher(store storage.WebhookStore) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Publish schedules a delivery of the event to every webhook subscribed to it. An event published again is not
+// delivered twice.
+func (d *Dispatcher) Publish(ctx context.Context, msg outbox.Message) error {
+	webhooks, err := d.store.ListWebhooksForEvent(ctx, storage.ListWebhooksForEventParams{
+		AccountID: uuid.NullUUID{UUID: msg.AccountID, Valid: true},
+		EventType: msg.Type,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	now := pgtype.Timestamptz{Time: d.now(), Valid: true}
+
+	for _, webhook := range webhooks {
+		if err := d.store.AddWebhookDelivery(ctx, storage.AddWebhookDeliveryParams{
+			WebhookID: webhook.WebhookID,
+			EventID:   msg.ID,
+			EventType: msg.Type,
+			Payload:   payload,
+			Status:    types.WebhookDeliveryPending,
+			CreatedAt: now,
+		}); err != nil {
+			return fmt.Errorf("failed to add webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const wantPayload = `{"id":"12345678-1234-1234-1234-123456789003","type":"MoneyAdded",` +
+	`"accountId":"12345678-1234-1234-1234-123456789001","sequence":1,"occurredAt":"2024-05-01T10:00:00Z",` +
+	`"payload":{"amount":100}}`
+
+func testMessage() outbox.Message {
+	return outbox.Message{
+		ID:         wantEventID,
+		Type:       outbox.EventMoneyAdded,
+		AccountID:  wantAccountID,
+		Sequence:   1,
+		OccurredAt: wantNow,
+		Payload:    []byte(`{"amount":100}`),
+	}
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Parallel()
+
+	otherWebhookID := uuid.MustParse("12345678-1234-1234-1234-123456789007")
+
+	tests := []struct {
+		name     string
+		webhooks []storage.Webhook
+		err      error
+		wantErr  bool
+	}{
+		{
+			name: "success when no webhook is subscribed",
+		},
+		{
+			name:     "success when webhooks are subscribed",
+			webhooks: []storage.Webhook{{WebhookID: wantWebhookID}, {WebhookID: otherWebhookID}},
+		},
+		{
+			name:     "failed when a delivery fails to be added",
+			webhooks: []storage.Webhook{{WebhookID: wantWebhookID}},
+			err:      errAnything,
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockWebhookStore(t)
+			store.EXPECT().ListWebhooksForEvent(mock.Anything, storage.ListWebhooksForEventParams{
+				AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				EventType: outbox.EventMoneyAdded,
+			}).Return(tt.webhooks, nil).Once()
+
+			for _, w := range tt.webhooks {
+				store.EXPECT().AddWebhookDelivery(mock.Anything, mock.MatchedBy(func(p storage.AddWebhookDeliveryParams) bool {
+					return p.WebhookID == w.WebhookID &&
+						p.EventID == wantEventID &&
+						p.EventType == outbox.EventMoneyAdded &&
+						string(p.Payload) == wantPayload &&
+						p.Status == types.WebhookDeliveryPending &&
+						p.CreatedAt == pgtype.Timestamptz{Time: wantNow, Valid: true}
+				})).Return(tt.err).Once()
+
+				if tt.err != nil {
+					break
+				}
+			}
+
+			d := NewDispatcher(store)
+			d.now = func() time.Time { return wantNow }
+
+			err := d.Publish(context.Background(), testMessage())
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
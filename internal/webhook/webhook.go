@@ -0,0 +1,251 @@
+// Package webhook delivers domain events to the webhooks subscribed to them, signed and retried with exponential
+// backoff until they succeed or fail too many times.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/logger"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+const pqErrorForeignKeyViolation = "23503"
+
+// Auditor records the webhooks created and the deliveries redelivered within their transactions.
+type Auditor interface {
+	Record(ctx context.Context, tx pgx.Tx, event audit.Event) error
+}
+
+type Service struct {
+	conn        storage.DBConnection
+	store       storage.WebhookStore
+	storeWithTx func(tx pgx.Tx) storage.WebhookStore
+	auditor     Auditor
+	logger      logger.Logger
+	now         func() time.Time
+}
+
+// New returns a new Service.
+func New(conn storage.DBConnection, store storage.WebhookStore, auditor Auditor, logger logger.Logger) *Service {
+	return &Service{
+		conn:        conn,
+		store:       store,
+		storeWithTx: storage.WebhookStoreWithTx,
+		auditor:     auditor,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// CreateWebhook subscribes a webhook to events.
+// returns CreateWebhookResponse.
+func (s *Service) CreateWebhook(
+	ctx context.Context,
+	req *types.CreateWebhookRequest,
+) (types.CreateWebhookResponse, error) {
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	var res types.CreateWebhookResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.WebhookStore) error {
+		webhook, err := store.CreateWebhook(ctx, storage.CreateWebhookParams{
+			Url:        req.URL,
+			EventTypes: eventTypes,
+			AccountID:  req.AccountID,
+			Secret:     req.Secret,
+		})
+		if err != nil {
+			pgErr := &pgconn.PgError{}
+			if errors.As(err, &pgErr) && pgErr.Code == pqErrorForeignKeyViolation {
+				return types.ErrAccountNotFound
+			}
+
+			s.logger.ErrorContext(ctx, "failed to create webhook", "error", err)
+
+			return types.ErrInternal
+		}
+
+		// The secret is left out of the response, and so of the audit log.
+		res.Webhook = types.Webhook{
+			ID:         webhook.WebhookID,
+			URL:        webhook.Url,
+			EventTypes: webhook.EventTypes,
+			AccountID:  webhook.AccountID,
+			CreatedAt:  webhook.CreatedAt.Time,
+		}
+
+		return s.record(ctx, tx, audit.Event{
+			Action:    audit.ActionCreateWebhook,
+			AccountID: webhook.AccountID,
+			Outcome:   audit.OutcomeSuccess,
+			After:     res.Webhook,
+		})
+	})
+	if err != nil {
+		return types.CreateWebhookResponse{}, err
+	}
+
+	return res, nil
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, latest first.
+// returns ListWebhookDeliveriesResponse.
+func (s *Service) ListWebhookDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	limit, offset int32,
+) (types.ListWebhookDeliveriesResponse, error) {
+	ok, err := s.store.HasWebhook(ctx, webhookID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to check webhook", "error", err)
+
+		return types.ListWebhookDeliveriesResponse{}, types.ErrInternal
+	}
+
+	if !ok {
+		return types.ListWebhookDeliveriesResponse{}, types.ErrWebhookNotFound
+	}
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, storage.ListWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list webhook deliveries", "error", err)
+
+		return types.ListWebhookDeliveriesResponse{}, types.ErrInternal
+	}
+
+	res := types.ListWebhookDeliveriesResponse{
+		Deliveries: make([]types.WebhookDelivery, 0, len(deliveries)),
+	}
+
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, toDelivery(d))
+	}
+
+	return res, nil
+}
+
+// GetWebhookDelivery returns a delivery of a webhook and the log of its attempts.
+// returns GetWebhookDeliveryResponse.
+func (s *Service) GetWebhookDelivery(
+	ctx context.Context,
+	webhookID, deliveryID uuid.UUID,
+) (types.GetWebhookDeliveryResponse, error) {
+	delivery, err := s.store.GetWebhookDelivery(ctx, storage.GetWebhookDeliveryParams{
+		WebhookID:         webhookID,
+		WebhookDeliveryID: deliveryID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return types.GetWebhookDeliveryResponse{}, types.ErrWebhookDeliveryNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "failed to get webhook delivery", "error", err)
+
+		return types.GetWebhookDeliveryResponse{}, types.ErrInternal
+	}
+
+	attempts, err := s.store.ListWebhookDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list webhook delivery attempts", "error", err)
+
+		return types.GetWebhookDeliveryResponse{}, types.ErrInternal
+	}
+
+	res := types.GetWebhookDeliveryResponse{
+		WebhookDelivery: toDelivery(delivery),
+		Log:             make([]types.WebhookDeliveryAttempt, 0, len(attempts)),
+	}
+
+	for _, a := range attempts {
+		res.Log = append(res.Log, types.WebhookDeliveryAttempt{
+			AttemptedAt: a.AttemptedAt.Time,
+			StatusCode:  a.StatusCode.Int32,
+			Error:       a.Error.String,
+			DurationMs:  a.DurationMs,
+		})
+	}
+
+	return res, nil
+}
+
+// RedeliverWebhookDelivery schedules a delivery of a webhook to be attempted again right away, with as many
+// retries as a new one.
+// returns RedeliverWebhookDeliveryResponse.
+func (s *Service) RedeliverWebhookDelivery(
+	ctx context.Context,
+	webhookID, deliveryID uuid.UUID,
+) (types.RedeliverWebhookDeliveryResponse, error) {
+	var res types.RedeliverWebhookDeliveryResponse
+
+	err := storage.InTx(ctx, s.conn, s.storeWithTx, s.logger, func(tx pgx.Tx, store storage.WebhookStore) error {
+		delivery, err := store.RedeliverWebhookDelivery(ctx, storage.RedeliverWebhookDeliveryParams{
+			Now:               pgtype.Timestamptz{Time: s.now(), Valid: true},
+			WebhookID:         webhookID,
+			WebhookDeliveryID: deliveryID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return types.ErrWebhookDeliveryNotFound
+			}
+
+			s.logger.ErrorContext(ctx, "failed to redeliver webhook delivery", "error", err)
+
+			return types.ErrInternal
+		}
+
+		res.WebhookDelivery = toDelivery(delivery)
+
+		return s.record(ctx, tx, audit.Event{
+			Action:  audit.ActionRedeliverWebhookDelivery,
+			Outcome: audit.OutcomeSuccess,
+			After:   res.WebhookDelivery,
+		})
+	})
+	if err != nil {
+		return types.RedeliverWebhookDeliveryResponse{}, err
+	}
+
+	return res, nil
+}
+
+func (s *Service) record(ctx context.Context, tx pgx.Tx, event audit.Event) error {
+	if err := s.auditor.Record(ctx, tx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record audit event", "error", err)
+
+		return types.ErrInternal
+	}
+
+	return nil
+}
+
+func toDelivery(d storage.WebhookDelivery) types.WebhookDelivery {
+	return types.WebhookDelivery{
+		ID:             d.WebhookDeliveryID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt.Time,
+		LastStatusCode: d.LastStatusCode.Int32,
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt.Time,
+		UpdatedAt:      d.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,324 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/storage"
+	storageMocks "github.com/zaidsasa/xbankapi/internal/storage/mocks"
+	"github.com/zaidsasa/xbankapi/internal/storage/storagetest"
+	"github.com/zaidsasa/xbankapi/types"
+)
+
+var (
+	wantAccountID  = uuid.MustParse("12345678-1234-1234-1234-123456789001")
+	wantEventID    = uuid.MustParse("12345678-1234-1234-1234-123456789003")
+	wantWebhookID  = uuid.MustParse("12345678-1234-1234-1234-123456789005")
+	wantDeliveryID = uuid.MustParse("12345678-1234-1234-1234-123456789006")
+	wantNow        = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	errAnything    = errors.New("any")
+)
+
+// recordFunc is an Auditor recording events with a function.
+type recordFunc func(event audit.Event) error
+
+func (f recordFunc) Record(_ context.Context, _ pgx.Tx, event audit.Event) error {
+	return f(event)
+}
+
+// newTxTestService returns a Service whose changes are made within a transaction, which is committed when commit is
+// set, and recorded as action with the snapshot want.
+func newTxTestService(t *testing.T, store storage.WebhookStore, commit bool, action string, want any) *Service {
+	t.Helper()
+
+	s := New(storagetest.NewConn(t, commit), store, recordFunc(func(event audit.Event) error {
+		assert.Equal(t, action, event.Action)
+		assert.Equal(t, want, event.After)
+
+		return nil
+	}), slog.Default())
+	s.storeWithTx = func(pgx.Tx) storage.WebhookStore { return store }
+	s.now = func() time.Time { return wantNow }
+
+	return s
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *types.CreateWebhookRequest
+		mock    func(*storageMocks.MockWebhookStore)
+		want    types.CreateWebhookResponse
+		wantErr error
+	}{
+		{
+			name: "failed when account not found",
+			req: &types.CreateWebhookRequest{
+				URL:       "https://example.com/events",
+				AccountID: uuid.NullUUID{UUID: wantAccountID, Valid: true},
+				Secret:    "0123456789abcdef",
+			},
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().CreateWebhook(mock.Anything, mock.Anything).
+					Return(storage.Webhook{}, &pgconn.PgError{Code: pqErrorForeignKeyViolation}).Once()
+			},
+			wantErr: types.ErrAccountNotFound,
+		},
+		{
+			name: "failed when the store fails",
+			req:  &types.CreateWebhookRequest{URL: "https://example.com/events", Secret: "0123456789abcdef"},
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().CreateWebhook(mock.Anything, mock.Anything).Return(storage.Webhook{}, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success when subscribing to every event",
+			req:  &types.CreateWebhookRequest{URL: "https://example.com/events", Secret: "0123456789abcdef"},
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().CreateWebhook(mock.Anything, storage.CreateWebhookParams{
+					Url:        "https://example.com/events",
+					EventTypes: []string{},
+					Secret:     "0123456789abcdef",
+				}).Return(storage.Webhook{
+					WebhookID:  wantWebhookID,
+					Url:        "https://example.com/events",
+					EventTypes: []string{},
+					Secret:     "0123456789abcdef",
+					CreatedAt:  pgtype.Timestamptz{Time: wantNow, Valid: true},
+				}, nil).Once()
+			},
+			want: types.CreateWebhookResponse{
+				Webhook: types.Webhook{
+					ID:         wantWebhookID,
+					URL:        "https://example.com/events",
+					EventTypes: []string{},
+					CreatedAt:  wantNow,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockWebhookStore(t)
+			tt.mock(store)
+
+			got, err := newTxTestService(t, store, tt.wantErr == nil, audit.ActionCreateWebhook, tt.want.Webhook).
+				CreateWebhook(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mock    func(*storageMocks.MockWebhookStore)
+		want    types.ListWebhookDeliveriesResponse
+		wantErr error
+	}{
+		{
+			name: "failed when webhook not found",
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().HasWebhook(mock.Anything, wantWebhookID).Return(false, nil).Once()
+			},
+			wantErr: types.ErrWebhookNotFound,
+		},
+		{
+			name: "failed when the store fails",
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().HasWebhook(mock.Anything, wantWebhookID).Return(true, nil).Once()
+				ms.EXPECT().ListWebhookDeliveries(mock.Anything, mock.Anything).Return(nil, errAnything).Once()
+			},
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().HasWebhook(mock.Anything, wantWebhookID).Return(true, nil).Once()
+				ms.EXPECT().ListWebhookDeliveries(mock.Anything, storage.ListWebhookDeliveriesParams{
+					WebhookID: wantWebhookID,
+					Limit:     10,
+					Offset:    20,
+				}).Return([]storage.WebhookDelivery{{
+					WebhookDeliveryID: wantDeliveryID,
+					WebhookID:         wantWebhookID,
+					EventID:           wantEventID,
+					EventType:         "MoneyAdded",
+					Status:            types.WebhookDeliveryPending,
+					Attempts:          1,
+					LastStatusCode:    pgtype.Int4{Int32: 503, Valid: true},
+					LastError:         pgtype.Text{String: "unexpected status code: 503", Valid: true},
+				}}, nil).Once()
+			},
+			want: types.ListWebhookDeliveriesResponse{
+				Deliveries: []types.WebhookDelivery{{
+					ID:             wantDeliveryID,
+					WebhookID:      wantWebhookID,
+					EventID:        wantEventID,
+					EventType:      "MoneyAdded",
+					Status:         types.WebhookDeliveryPending,
+					Attempts:       1,
+					LastStatusCode: 503,
+					LastError:      "unexpected status code: 503",
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockWebhookStore(t)
+			tt.mock(store)
+
+			got, err := New(nil, store, nil, slog.Default()).ListWebhookDeliveries(context.Background(), wantWebhookID, 10, 20)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_GetWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mock    func(*storageMocks.MockWebhookStore)
+		want    types.GetWebhookDeliveryResponse
+		wantErr error
+	}{
+		{
+			name: "failed when delivery not found",
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().GetWebhookDelivery(mock.Anything, mock.Anything).
+					Return(storage.WebhookDelivery{}, pgx.ErrNoRows).Once()
+			},
+			wantErr: types.ErrWebhookDeliveryNotFound,
+		},
+		{
+			name: "success with the log of attempts",
+			mock: func(ms *storageMocks.MockWebhookStore) {
+				ms.EXPECT().GetWebhookDelivery(mock.Anything, storage.GetWebhookDeliveryParams{
+					WebhookID:         wantWebhookID,
+					WebhookDeliveryID: wantDeliveryID,
+				}).Return(storage.WebhookDelivery{
+					WebhookDeliveryID: wantDeliveryID,
+					WebhookID:         wantWebhookID,
+					Status:            types.WebhookDeliverySucceeded,
+				}, nil).Once()
+				ms.EXPECT().ListWebhookDeliveryAttempts(mock.Anything, wantDeliveryID).
+					Return([]storage.WebhookDeliveryAttempt{{
+						AttemptedAt: pgtype.Timestamptz{Time: wantNow, Valid: true},
+						StatusCode:  pgtype.Int4{Int32: 200, Valid: true},
+						DurationMs:  3,
+					}}, nil).Once()
+			},
+			want: types.GetWebhookDeliveryResponse{
+				WebhookDelivery: types.WebhookDelivery{
+					ID:        wantDeliveryID,
+					WebhookID: wantWebhookID,
+					Status:    types.WebhookDeliverySucceeded,
+				},
+				Log: []types.WebhookDeliveryAttempt{{AttemptedAt: wantNow, StatusCode: 200, DurationMs: 3}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockWebhookStore(t)
+			tt.mock(store)
+
+			got, err := New(nil, store, nil, slog.Default()).
+				GetWebhookDelivery(context.Background(), wantWebhookID, wantDeliveryID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RedeliverWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		want    types.RedeliverWebhookDeliveryResponse
+		wantErr error
+	}{
+		{
+			name:    "failed when delivery not found",
+			err:     pgx.ErrNoRows,
+			wantErr: types.ErrWebhookDeliveryNotFound,
+		},
+		{
+			name:    "failed when the store fails",
+			err:     errAnything,
+			wantErr: types.ErrInternal,
+		},
+		{
+			name: "success",
+			want: types.RedeliverWebhookDeliveryResponse{
+				WebhookDelivery: types.WebhookDelivery{
+					ID:            wantDeliveryID,
+					Status:        types.WebhookDeliveryPending,
+					NextAttemptAt: wantNow,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storageMocks.NewMockWebhookStore(t)
+			store.EXPECT().RedeliverWebhookDelivery(mock.Anything, storage.RedeliverWebhookDeliveryParams{
+				Now:               pgtype.Timestamptz{Time: wantNow, Valid: true},
+				WebhookID:         wantWebhookID,
+				WebhookDeliveryID: wantDeliveryID,
+			}).Return(storage.WebhookDelivery{
+				WebhookDeliveryID: tt.want.ID,
+				Status:            tt.want.Status,
+				NextAttemptAt:     pgtype.Timestamptz{Time: tt.want.NextAttemptAt, Valid: !tt.want.NextAttemptAt.IsZero()},
+			}, tt.err).Once()
+
+			s := newTxTestService(
+				t, store, tt.wantErr == nil, audit.ActionRedeliverWebhookDelivery, tt.want.WebhookDelivery)
+
+			got, err := s.RedeliverWebhookDelivery(context.Background(), wantWebhookID, wantDeliveryID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,65 +3,553 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"log/slog"
+	gohttp "net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/zaidsasa/xbankapi/internal/activity"
 	"github.com/zaidsasa/xbankapi/internal/api"
+	"github.com/zaidsasa/xbankapi/internal/audit"
+	"github.com/zaidsasa/xbankapi/internal/beneficiary"
+	"github.com/zaidsasa/xbankapi/internal/customer"
+	"github.com/zaidsasa/xbankapi/internal/fee"
+	"github.com/zaidsasa/xbankapi/internal/grpc"
+	"github.com/zaidsasa/xbankapi/internal/holder"
 	"github.com/zaidsasa/xbankapi/internal/http"
+	"github.com/zaidsasa/xbankapi/internal/iban"
+	"github.com/zaidsasa/xbankapi/internal/idempotency"
+	"github.com/zaidsasa/xbankapi/internal/interest"
+	"github.com/zaidsasa/xbankapi/internal/limits"
+	"github.com/zaidsasa/xbankapi/internal/metrics"
+	"github.com/zaidsasa/xbankapi/internal/openapi"
+	"github.com/zaidsasa/xbankapi/internal/outbox"
+	"github.com/zaidsasa/xbankapi/internal/overdraft"
+	"github.com/zaidsasa/xbankapi/internal/paymentfile"
+	"github.com/zaidsasa/xbankapi/internal/pocket"
+	"github.com/zaidsasa/xbankapi/internal/product"
+	"github.com/zaidsasa/xbankapi/internal/risk"
+	"github.com/zaidsasa/xbankapi/internal/sanctions"
+	"github.com/zaidsasa/xbankapi/internal/statement"
 	"github.com/zaidsasa/xbankapi/internal/storage"
+	"github.com/zaidsasa/xbankapi/internal/tracing"
 	"github.com/zaidsasa/xbankapi/internal/validator"
+	"github.com/zaidsasa/xbankapi/internal/webhook"
+	"golang.org/x/sync/errgroup"
 )
 
-var errMissingEnviromentVariableDatabaseURL = errors.New("missing environment variable DATABASE_URL")
+var (
+	errMissingEnviromentVariableDatabaseURL = errors.New("missing environment variable DATABASE_URL")
+	errMissingEnviromentVariableWebhookURL  = errors.New("missing environment variable OUTBOX_WEBHOOK_URL")
+	errUnknownOutboxPublisher               = errors.New("unknown outbox publisher, must be one of log, webhook or notify")
+	errInvalidSanctionsMatchThreshold       = errors.New("invalid SANCTIONS_MATCH_THRESHOLD, must be between 0 and 1")
+	errInvalidOverdraftInterestRate         = errors.New("invalid OVERDRAFT_INTEREST_RATE, must not be negative")
+	errInvalidFeeIncomeAccountIDs           = errors.New(
+		"invalid FEE_INCOME_ACCOUNT_IDS, must be comma-separated pairs of a currency code and an account ID")
+)
+
+const (
+	defualtServiceAddr = ":3000"
+	defaultGRPCAddr    = ":3001"
 
-const defualtServiceAddr = ":3000"
+	shutdownTracingTimeout = 5 * time.Second
+	outboxWebhookTimeout   = 10 * time.Second
+	webhookTimeout         = 10 * time.Second
+)
 
 //go:generate go run github.com/sqlc-dev/sqlc/cmd/sqlc generate
 //go:generate go run github.com/vektra/mockery/v2
+//go:generate go build -o ./bin/ google.golang.org/protobuf/cmd/protoc-gen-go google.golang.org/grpc/cmd/protoc-gen-go-grpc
+//go:generate go run github.com/jhump/goprotoc/cmd/goprotoc -I proto --plugin=protoc-gen-go=bin/protoc-gen-go --plugin=protoc-gen-go-grpc=bin/protoc-gen-go-grpc --go_out=paths=source_relative:proto --go-grpc_out=paths=source_relative:proto xbankapi/v1/account_service.proto
 func main() {
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
 		log.Fatal(errMissingEnviromentVariableDatabaseURL)
 	}
 
-	addr := os.Getenv("SERVCE_ADDRESS")
-	if addr == "" {
-		addr = defualtServiceAddr
-
-		slog.Info("using default serivce address", "address", addr)
+	if runCommand(context.Background(), dbURL) {
+		return
 	}
 
+	addr := getenv("SERVCE_ADDRESS", defualtServiceAddr)
+	grpcAddr := getenv("GRPC_ADDRESS", defaultGRPCAddr)
+
 	validator.ConfigureDefaultValidator()
 
-	pool, err := pgxpool.New(context.Background(), dbURL)
+	// Requests authenticated with the admin token may use the admin endpoints, none can when it is unset.
+	identifier := audit.NewIdentifier(os.Getenv("ADMIN_TOKEN"))
+
+	middlewares, err := middlewaresFromEnv(identifier)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), os.Getenv("OTEL_TRACES_EXPORTER"))
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer pool.Close()
 
-	logger := slog.Default()
+	logger := slog.New(tracing.NewLogHandler(slog.Default().Handler()))
+
+	newPublisher, err := publisherFromEnv(logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	accounts, err := accountConfigFromEnv(logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pool, err := newPool(context.Background(), dbURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
 
 	storage := storage.New(pool)
 
-	accountService := api.NewAccountService(pool, storage, logger)
+	registry := metrics.NewRegistry()
+	registry.MustRegister(metrics.NewPoolCollector(pool))
+
+	metrics := metrics.New(registry)
+
+	auditLog := audit.New(storage, logger)
+
+	holders := holder.New(pool, storage, auditLog, logger)
+
+	beneficiaries := accounts.newBeneficiaries(pool, storage, auditLog, holders)
+
+	limits := limits.New(pool, storage, auditLog, holders, logger)
+
+	screenings := accounts.newSanctions(pool, storage, auditLog)
+	accounts.risk.Use(sanctions.CheckName, screenings)
+
+	overdrafts := accounts.newOverdrafts(pool, storage, auditLog)
+
+	products := product.New(pool, storage, auditLog, logger)
+
+	interests := interest.New(pool, storage, auditLog, holders, logger)
+
+	fees := accounts.newFees(pool, storage, auditLog, holders)
+
+	pockets := pocket.New(pool, storage, auditLog, holders, logger)
+
+	accountService := api.NewAccountService(pool, storage, logger, metrics, auditLog, outbox.New(), accounts.ibans,
+		beneficiaries, limits, accounts.risk, screenings, fees, products, holders, pockets, paymentfile.NewLinks(logger))
+
+	approvals := holder.NewApprovals(pool, storage, auditLog, holders, accountService, logger)
+
+	reviews := risk.NewService(pool, storage, auditLog, accountService, logger)
+
+	customers := customer.New(pool, storage, auditLog, accountService, holders, logger)
+
+	webhooks := webhook.New(pool, storage, auditLog, logger)
+
+	statements := statement.New(pool, holders, logger)
+
+	paymentFiles := paymentfile.New(pool, storage, auditLog, accountService, holders, logger)
+
+	hub := activity.NewHub(pool.Config().ConnConfig, logger)
+
+	relay := outbox.NewRelay(pool, outbox.Publishers{newPublisher(storage), webhook.NewDispatcher(storage)}, logger)
+
+	deliverer := webhook.NewDeliverer(
+		pool, &gohttp.Client{Timeout: webhookTimeout}, logger, webhook.DefaultMaxAttempts)
+
+	idempotencyKeys := idempotency.New(storage, logger)
 
 	srv := http.NewServer(
 		logger,
 		api.NewAccountHandler(accountService),
+		api.NewCustomerHandler(customers),
+		api.NewHolderHandler(holders, approvals),
+		api.NewPocketHandler(pockets),
+		api.NewEventHandler(accountService, hub),
+		api.NewStatementHandler(statements),
+		api.NewPaymentFileHandler(paymentFiles),
+		api.NewBeneficiaryHandler(beneficiaries),
+		api.NewLimitHandler(limits),
+		api.NewRiskHandler(reviews),
+		api.NewSanctionsHandler(screenings),
+		api.NewOverdraftHandler(overdrafts),
+		api.NewProductHandler(products, interests),
+		api.NewFeeHandler(fees),
+		api.NewAuditHandler(auditLog),
+		api.NewWebhookHandler(webhooks),
 		api.NewPropsHandler(pool),
+		api.NewOpenAPIHandler(openapi.Spec()),
+		api.NewMetricsHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})),
 	)
 
+	srv.Instrument(tracing.NewHTTP(), metrics)
+	srv.Use(append(middlewares, idempotencyKeys.Handler)...)
+
+	grpcSrv := grpc.NewServer(
+		logger,
+		grpc.NewAccountService(accountService),
+	)
+
+	grpcSrv.Instrument(tracing.NewGRPC())
+	grpcSrv.Use(metrics.UnaryServerInterceptor(), identifier.UnaryServerInterceptor())
+
 	ctx := context.Background()
 
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	if err := srv.Start(ctx, addr); err != nil {
+	// Both servers shut down when a signal is received or when either of them fails.
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return srv.Start(ctx, addr)
+	})
+
+	g.Go(func() error {
+		return grpcSrv.Start(ctx, grpcAddr)
+	})
+
+	g.Go(func() error {
+		return relay.Run(ctx)
+	})
+
+	g.Go(func() error {
+		return assignIBANs(ctx, storage, accounts.ibans, logger)
+	})
+
+	g.Go(func() error {
+		return deliverer.Run(ctx)
+	})
+
+	g.Go(func() error {
+		return hub.Run(ctx)
+	})
+
+	// The changes of the background jobs are audited as made by the system.
+	jobCtx := audit.ContextWithActor(ctx, audit.Actor{Principal: audit.PrincipalSystem})
+
+	g.Go(func() error {
+		return overdrafts.Run(jobCtx)
+	})
+
+	g.Go(func() error {
+		return interests.Run(jobCtx)
+	})
+
+	g.Go(func() error {
+		return fees.Run(jobCtx)
+	})
+
+	g.Go(func() error {
+		return idempotencyKeys.Run(ctx)
+	})
+
+	err = g.Wait()
+
+	// Export the spans of the last requests before exiting.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTracingTimeout)
+	defer cancel()
+
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		logger.Error("failed to shutdown tracing", "error", shutdownErr)
+	}
+
+	if err != nil {
 		panic(err)
 	}
 }
+
+// runCommand runs the command given in the arguments instead of the servers, if any, and returns whether it did.
+func runCommand(ctx context.Context, dbURL string) bool {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		return false
+	}
+
+	var err error
+
+	switch args[0] {
+	case importSanctionsCommand:
+		err = importSanctions(ctx, dbURL, args[1:])
+	case accrueInterestCommand:
+		err = accrueInterest(ctx, dbURL, args[1:])
+	default:
+		return false
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return true
+}
+
+// assignIBANs assigns an IBAN to the accounts created before accounts were assigned one.
+func assignIBANs(ctx context.Context, store storage.IBANStore, ibans *iban.Generator, logger *slog.Logger) error {
+	assigned, err := iban.Assign(ctx, store, ibans)
+	if err != nil {
+		return fmt.Errorf("failed to assign ibans: %w", err)
+	}
+
+	if assigned > 0 {
+		logger.InfoContext(ctx, "assigned ibans", "accounts", assigned)
+	}
+
+	return nil
+}
+
+// newPool returns a new database connection pool tracing every query.
+func newPool(ctx context.Context, dbURL string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database url: %w", err)
+	}
+
+	config.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+// publisherFromEnv returns a constructor of the publisher of domain events set in OUTBOX_PUBLISHER, events are
+// logged by default. The configuration is checked before the database is connected to.
+func publisherFromEnv(logger *slog.Logger) (func(notifier storage.Notifier) outbox.Publisher, error) {
+	switch kind := os.Getenv("OUTBOX_PUBLISHER"); kind {
+	case outbox.PublisherLog, "":
+		return func(storage.Notifier) outbox.Publisher {
+			return outbox.NewLogPublisher(logger)
+		}, nil
+	case outbox.PublisherWebhook:
+		url := os.Getenv("OUTBOX_WEBHOOK_URL")
+		if url == "" {
+			return nil, errMissingEnviromentVariableWebhookURL
+		}
+
+		return func(storage.Notifier) outbox.Publisher {
+			return outbox.NewHTTPPublisher(url, &gohttp.Client{Timeout: outboxWebhookTimeout})
+		}, nil
+	case outbox.PublisherNotify:
+		channel := getenv("OUTBOX_NOTIFY_CHANNEL", outbox.DefaultNotifyChannel)
+
+		return func(notifier storage.Notifier) outbox.Publisher {
+			return outbox.NewNotifyPublisher(notifier, channel)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownOutboxPublisher, kind)
+	}
+}
+
+// middlewaresFromEnv returns the middlewares of the http server: requests are identified by identifier, and are
+// validated against the openapi document when OPENAPI_VALIDATION is true.
+func middlewaresFromEnv(identifier *audit.Identifier) ([]http.Middleware, error) {
+	middlewares := []http.Middleware{identifier.Handler}
+
+	if os.Getenv("OPENAPI_VALIDATION") == "true" {
+		doc, err := openapi.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the openapi document: %w", err)
+		}
+
+		middlewares = append(middlewares, openapi.NewValidator(doc, openapi.WithErrorHandler(api.WriteError)).Middleware)
+
+		slog.Info("validating requests against the openapi document")
+	}
+
+	return middlewares, nil
+}
+
+// newBeneficiariesFunc returns the beneficiaries service.
+type newBeneficiariesFunc func(
+	storage.DBConnection, storage.BeneficiaryStore, beneficiary.Auditor, beneficiary.Holders,
+) *beneficiary.Service
+
+// accountConfig is the configuration of the account service read from the environment.
+type accountConfig struct {
+	ibans            *iban.Generator
+	newBeneficiaries newBeneficiariesFunc
+	risk             *risk.Engine
+	newSanctions     func(storage.DBConnection, storage.SanctionsStore, sanctions.Auditor) *sanctions.Service
+	newOverdrafts    func(storage.DBConnection, storage.OverdraftStore, overdraft.Auditor) *overdraft.Service
+	newFees          func(storage.DBConnection, storage.FeeStore, fee.Auditor, fee.Holders) *fee.Service
+}
+
+// accountConfigFromEnv reads the configuration of the account service from the environment: the country and bank
+// codes of the IBANs in IBAN_COUNTRY_CODE and IBAN_BANK_CODE, the beneficiaries, the risk rules, the sanctions
+// screening, the overdrafts and the fees.
+func accountConfigFromEnv(logger *slog.Logger) (accountConfig, error) {
+	ibans, err := iban.NewGenerator(
+		getenv("IBAN_COUNTRY_CODE", iban.DefaultCountryCode), getenv("IBAN_BANK_CODE", iban.DefaultBankCode))
+	if err != nil {
+		return accountConfig{}, fmt.Errorf("invalid iban configuration: %w", err)
+	}
+
+	newBeneficiaries, err := beneficiariesFromEnv(logger)
+	if err != nil {
+		return accountConfig{}, err
+	}
+
+	riskEngine, err := riskFromEnv(logger)
+	if err != nil {
+		return accountConfig{}, err
+	}
+
+	newSanctions, err := sanctionsFromEnv(logger)
+	if err != nil {
+		return accountConfig{}, err
+	}
+
+	newOverdrafts, err := overdraftsFromEnv(logger)
+	if err != nil {
+		return accountConfig{}, err
+	}
+
+	newFees, err := feesFromEnv(logger)
+	if err != nil {
+		return accountConfig{}, err
+	}
+
+	return accountConfig{
+		ibans:            ibans,
+		newBeneficiaries: newBeneficiaries,
+		risk:             riskEngine,
+		newSanctions:     newSanctions,
+		newOverdrafts:    newOverdrafts,
+		newFees:          newFees,
+	}, nil
+}
+
+// beneficiariesFromEnv returns a constructor of the beneficiaries service, whose cooling-off period is set in
+// BENEFICIARY_COOLING_OFF, e.g. 24h, and the maximum amount of a transfer during this period in
+// BENEFICIARY_COOLING_OFF_LIMIT, in minor units.
+func beneficiariesFromEnv(
+	logger *slog.Logger,
+) (newBeneficiariesFunc, error) {
+	coolingOff, err := time.ParseDuration(getenv("BENEFICIARY_COOLING_OFF", beneficiary.DefaultCoolingOff.String()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BENEFICIARY_COOLING_OFF: %w", err)
+	}
+
+	coolingOffLimit, err := strconv.ParseInt(
+		getenv("BENEFICIARY_COOLING_OFF_LIMIT", strconv.FormatInt(beneficiary.DefaultCoolingOffLimit, 10)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BENEFICIARY_COOLING_OFF_LIMIT: %w", err)
+	}
+
+	return func(
+		conn storage.DBConnection,
+		store storage.BeneficiaryStore,
+		auditor beneficiary.Auditor,
+		holders beneficiary.Holders,
+	) *beneficiary.Service {
+		return beneficiary.New(conn, store, auditor, holders, logger, coolingOff, coolingOffLimit)
+	}, nil
+}
+
+// riskFromEnv returns the risk engine screening transfers with the rules of the JSON file RISK_RULES_FILE, which
+// allows every transfer when it is not set.
+func riskFromEnv(logger *slog.Logger) (*risk.Engine, error) {
+	var config risk.Config
+
+	if path := os.Getenv("RISK_RULES_FILE"); path != "" {
+		var err error
+		if config, err = risk.LoadConfig(path); err != nil {
+			return nil, fmt.Errorf("invalid RISK_RULES_FILE: %w", err)
+		}
+	}
+
+	engine, err := risk.New(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RISK_RULES_FILE: %w", err)
+	}
+
+	return engine, nil
+}
+
+// sanctionsFromEnv returns a constructor of the sanctions screening service, names nearly matching a sanctions entry
+// from the similarity set in SANCTIONS_MATCH_THRESHOLD, between 0 and 1.
+func sanctionsFromEnv(
+	logger *slog.Logger,
+) (func(storage.DBConnection, storage.SanctionsStore, sanctions.Auditor) *sanctions.Service, error) {
+	threshold, err := strconv.ParseFloat(
+		getenv("SANCTIONS_MATCH_THRESHOLD", strconv.FormatFloat(sanctions.DefaultThreshold, 'f', -1, 64)), 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		return nil, fmt.Errorf("%w: %q", errInvalidSanctionsMatchThreshold, os.Getenv("SANCTIONS_MATCH_THRESHOLD"))
+	}
+
+	return func(conn storage.DBConnection, store storage.SanctionsStore, auditor sanctions.Auditor) *sanctions.Service {
+		return sanctions.NewService(conn, store, auditor, threshold, logger)
+	}, nil
+}
+
+// overdraftsFromEnv returns a constructor of the overdrafts service, negative balances being charged the annual
+// interest rate set in OVERDRAFT_INTEREST_RATE, e.g. 0.12 for 12%.
+func overdraftsFromEnv(
+	logger *slog.Logger,
+) (func(storage.DBConnection, storage.OverdraftStore, overdraft.Auditor) *overdraft.Service, error) {
+	var rate pgtype.Numeric
+
+	err := rate.Scan(getenv("OVERDRAFT_INTEREST_RATE", overdraft.DefaultInterestRate))
+	if err != nil || rate.NaN || rate.InfinityModifier != pgtype.Finite || rate.Int.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", errInvalidOverdraftInterestRate, os.Getenv("OVERDRAFT_INTEREST_RATE"))
+	}
+
+	return func(conn storage.DBConnection, store storage.OverdraftStore, auditor overdraft.Auditor) *overdraft.Service {
+		return overdraft.New(conn, store, auditor, rate, logger)
+	}, nil
+}
+
+// feesFromEnv returns a constructor of the fees service, fees being credited to the account of FEE_INCOME_ACCOUNT_IDS
+// of the currency of the account charged, e.g. EUR=<ACCOUNT-ID>,USD=<ACCOUNT-ID>. No fee is charged to accounts in a
+// currency without one.
+func feesFromEnv(
+	logger *slog.Logger,
+) (func(storage.DBConnection, storage.FeeStore, fee.Auditor, fee.Holders) *fee.Service, error) {
+	incomeAccountIDs := map[string]uuid.UUID{}
+
+	for _, pair := range strings.FieldsFunc(os.Getenv("FEE_INCOME_ACCOUNT_IDS"), func(r rune) bool { return r == ',' }) {
+		currencyCode, id, _ := strings.Cut(strings.TrimSpace(pair), "=")
+
+		accountID, err := uuid.Parse(id)
+		if err != nil || money.GetCurrency(currencyCode) == nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidFeeIncomeAccountIDs, pair)
+		}
+
+		incomeAccountIDs[currencyCode] = accountID
+	}
+
+	return func(
+		conn storage.DBConnection,
+		store storage.FeeStore,
+		auditor fee.Auditor,
+		holders fee.Holders,
+	) *fee.Service {
+		return fee.New(conn, store, auditor, holders, incomeAccountIDs, logger)
+	}, nil
+}
+
+// getenv returns the environment variable key, or fallback when it is not set.
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	slog.Info("using default value", "variable", key, "value", fallback)
+
+	return fallback
+}
@@ -9,6 +9,9 @@ package main
 import (
 	_ "github.com/golang-migrate/migrate/v4/cmd/migrate"
 	_ "github.com/golangci/golangci-lint/cmd/golangci-lint"
+	_ "github.com/jhump/goprotoc/cmd/goprotoc"
 	_ "github.com/sqlc-dev/sqlc/cmd/sqlc"
 	_ "github.com/vektra/mockery/v2"
+	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go"
 )
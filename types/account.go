@@ -0,0 +1,114 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	TransactionTypeDeposit  = "deposit"
+	TransactionTypeTransfer = "transfer"
+	TransactionTypeInterest = "interest"
+	TransactionTypeFee      = "fee"
+	TransactionTypePocket   = "pocket"
+)
+
+type CreateAccountRequest struct {
+	_ struct{} `type:"structure"`
+
+	Name         string `json:"name"         validate:"minLen:3|maxLen:255"`
+	Email        string `json:"email"        validate:"required|email|maxLen:255"`
+	CurrencyCode string `json:"currencyCode" message:"currencyCode must be a currency code" validate:"currency_code"`
+	// ProductCode is the product the account is opened for, current by default, which must allow its currency.
+	ProductCode string `json:"productCode" validate:"maxLen:32"`
+}
+
+type CreateAccountResponse struct {
+	_ struct{} `type:"structure"`
+
+	Account
+}
+
+type Account struct {
+	_ struct{} `type:"structure"`
+
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	CurrencyCode string    `json:"currencyCode"`
+	IBAN         string    `json:"iban,omitempty"`
+	// ProductCode is the product the account is opened for.
+	ProductCode string `json:"productCode,omitempty"`
+	// ScreeningStatus is the status of the screening of the name against the sanctions lists.
+	ScreeningStatus string `json:"screeningStatus,omitempty"`
+	// ParentAccountID is the account a pocket is part of, it is absent for other accounts.
+	ParentAccountID *uuid.UUID `json:"parentAccountId,omitempty"`
+}
+
+type AddMoneyRequest struct {
+	_ struct{} `type:"structure"`
+
+	Amount money.Amount `json:"amount" validate:"money_amount"`
+}
+
+type AddMoneyResponse struct {
+	_ struct{} `type:"structure"`
+
+	TransactionID uuid.UUID `json:"id"`
+}
+
+type TransferMoneyRequest struct {
+	_ struct{} `type:"structure"`
+
+	ReciverAccountID uuid.UUID `json:"reciverAccountId"      validate:"required"`
+	ReciverIBAN      string    `json:"reciverIban,omitempty" message:"reciverIban must be a valid iban" validate:"iban"`
+	// BeneficiaryID is the beneficiary of the account the money is transferred to, instead of the receiver account.
+	BeneficiaryID uuid.NullUUID `json:"beneficiaryId"`
+	Amount        money.Amount  `json:"amount"        validate:"money_amount"`
+}
+
+type TransferMoneyResponse struct {
+	_ struct{} `type:"structure"`
+
+	TransactionID uuid.UUID `json:"id"`
+	// Fee is the fee charged for the transfer on top of its amount, if any.
+	Fee money.Amount `json:"fee,omitempty"`
+}
+
+type GetAccountResponse struct {
+	_ struct{} `type:"structure"`
+
+	Account
+	Balance money.Amount `json:"balance"`
+	// AvailableBalance is what can be transferred from the account: its balance plus its overdraft limit.
+	AvailableBalance money.Amount `json:"availableBalance"`
+	// OverdraftLimit is how far below zero the balance can go, there is no overdraft when zero.
+	OverdraftLimit money.Amount `json:"overdraftLimit,omitempty"`
+	// ApprovalThreshold is the amount above which transfers need the approval of a second holder, there is no
+	// mandate when it is absent.
+	ApprovalThreshold *money.Amount `json:"approvalThreshold,omitempty"`
+	// Pockets are the pockets of the account with their balances, TotalBalance being the balance of the account and
+	// of its pockets. Both are absent when the account has no pockets.
+	Pockets      []Pocket      `json:"pockets,omitempty"`
+	TotalBalance *money.Amount `json:"totalBalance,omitempty"`
+}
+
+type Transaction struct {
+	_ struct{} `type:"structure"`
+
+	ID        uuid.UUID    `json:"id"`
+	AccountID uuid.UUID    `json:"accountId"`
+	Amount    money.Amount `json:"amount"`
+	// Type is deposit, transfer, interest, fee or pocket, for the money moved between an account and its pockets.
+	Type      string        `json:"type,omitempty"`
+	SourceID  uuid.NullUUID `json:"sourceId"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type ListTransactionsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Transactions []Transaction `json:"transactions"`
+}
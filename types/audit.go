@@ -0,0 +1,42 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	_ struct{} `type:"structure"`
+
+	ID         int64         `json:"id"`
+	OccurredAt time.Time     `json:"occurredAt"`
+	Principal  string        `json:"principal"`
+	Action     string        `json:"action"`
+	AccountID  uuid.NullUUID `json:"accountId"`
+	RequestID  string        `json:"requestId"`
+	ClientIP   string        `json:"clientIp"`
+	Outcome    string        `json:"outcome"`
+	// Before and After are snapshots of the target of the action, if any.
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	// PrevHash is the hash of the previous event, Hash covers it and the event itself, hex encoded.
+	PrevHash string `json:"prevHash"`
+	Hash     string `json:"hash"`
+}
+
+type ListAuditEventsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Events []AuditEvent `json:"events"`
+}
+
+type VerifyAuditChainResponse struct {
+	_ struct{} `type:"structure"`
+
+	Valid   bool  `json:"valid"`
+	Checked int64 `json:"checked"`
+	// BrokenAt is the ID of the first event whose hash does not match, if any.
+	BrokenAt *int64 `json:"brokenAt,omitempty"`
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+type CreateBeneficiaryRequest struct {
+	_ struct{} `type:"structure"`
+
+	Nickname string `json:"nickname" validate:"required|maxLen:255"`
+	// ReciverAccountID is the receiver account, unless it is given by its IBAN.
+	ReciverAccountID uuid.UUID `json:"reciverAccountId"`
+	ReciverIBAN      string    `json:"reciverIban,omitempty" message:"reciverIban must be a valid iban" validate:"iban"`
+	// TransferLimit is the maximum amount of a transfer to the beneficiary, there is none when zero.
+	TransferLimit money.Amount `json:"transferLimit,omitempty" validate:"money_limit"`
+}
+
+type CreateBeneficiaryResponse struct {
+	_ struct{} `type:"structure"`
+
+	Beneficiary
+}
+
+type UpdateBeneficiaryRequest struct {
+	_ struct{} `type:"structure"`
+
+	Nickname string `json:"nickname" validate:"required|maxLen:255"`
+	// TransferLimit is the maximum amount of a transfer to the beneficiary, there is none when zero.
+	TransferLimit money.Amount `json:"transferLimit,omitempty" validate:"money_limit"`
+}
+
+type UpdateBeneficiaryResponse struct {
+	_ struct{} `type:"structure"`
+
+	Beneficiary
+}
+
+type Beneficiary struct {
+	_ struct{} `type:"structure"`
+
+	ID               uuid.UUID    `json:"id"`
+	AccountID        uuid.UUID    `json:"accountId"`
+	Nickname         string       `json:"nickname"`
+	ReciverAccountID uuid.UUID    `json:"reciverAccountId"`
+	ReciverIBAN      string       `json:"reciverIban,omitempty"`
+	TransferLimit    money.Amount `json:"transferLimit,omitempty"`
+	// CoolingOffEndsAt is when the beneficiary can receive more than the cooling-off limit.
+	CoolingOffEndsAt time.Time `json:"coolingOffEndsAt"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type GetBeneficiaryResponse struct {
+	_ struct{} `type:"structure"`
+
+	Beneficiary
+}
+
+type ListBeneficiariesResponse struct {
+	_ struct{} `type:"structure"`
+
+	Beneficiaries []Beneficiary `json:"beneficiaries"`
+}
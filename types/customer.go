@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KYCStatusPending  = "pending"
+	KYCStatusVerified = "verified"
+	KYCStatusRejected = "rejected"
+)
+
+type Customer struct {
+	_ struct{} `type:"structure"`
+
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Phone string    `json:"phone,omitempty"`
+	// KYCStatus is the status of the KYC checks of the customer: pending, verified or rejected.
+	KYCStatus string    `json:"kycStatus"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateCustomerRequest struct {
+	_ struct{} `type:"structure"`
+
+	Name  string `json:"name"  validate:"required|minLen:3|maxLen:255"`
+	Email string `json:"email" validate:"required|email|maxLen:255"`
+	Phone string `json:"phone" validate:"maxLen:32"`
+}
+
+type CreateCustomerResponse struct {
+	_ struct{} `type:"structure"`
+
+	Customer
+}
+
+type CreateCustomerAccountRequest struct {
+	_ struct{} `type:"structure"`
+
+	// Name is the name of the account, the name of the customer by default.
+	Name         string `json:"name"         validate:"maxLen:255"`
+	CurrencyCode string `json:"currencyCode" message:"currencyCode must be a currency code" validate:"currency_code"`
+	// ProductCode is the product the account is opened for, current by default, which must allow its currency.
+	ProductCode string `json:"productCode" validate:"maxLen:32"`
+}
+
+type ListCustomerAccountsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Accounts []GetAccountResponse `json:"accounts"`
+}
+
+type SetKYCStatusRequest struct {
+	_ struct{} `type:"structure"`
+
+	Status string `json:"status" validate:"required|in:pending,verified,rejected"`
+}
+
+type SetKYCStatusResponse struct {
+	_ struct{} `type:"structure"`
+
+	Customer
+}
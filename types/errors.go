@@ -0,0 +1,222 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ErrorCodeInternal                   = "INTERNAL"
+	ErrorCodeInsufficientAccountBalance = "INSUFFICIENT_ACCOUNT_BALANCE"
+	ErrorCodeAccountNotFound            = "ACCOUNT_NOT_FOUND"
+	ErrorCodeReceiverAccountNotFound    = "RECEIVER_ACCOUNT_NOT_FOUND"
+	ErrorCodeAccountAlreadyExist        = "ACCOUNT_ALREADY_EXISTS"
+	ErrorCodeIdempotencyKeyInUse        = "IDEMPOTENCY_KEY_IN_USE"
+	ErrorCodeIdempotencyKeyReused       = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeForbidden                  = "FORBIDDEN"
+	ErrorCodeWebhookNotFound            = "WEBHOOK_NOT_FOUND"
+	ErrorCodeWebhookDeliveryNotFound    = "WEBHOOK_DELIVERY_NOT_FOUND"
+	ErrorCodeTransactionNotFound        = "TRANSACTION_NOT_FOUND"
+	ErrorCodeInvalidPaymentFile         = "INVALID_PAYMENT_FILE"
+	ErrorCodePaymentFileAlreadyImported = "PAYMENT_FILE_ALREADY_IMPORTED"
+	ErrorCodePaymentFileNotFound        = "PAYMENT_FILE_NOT_FOUND"
+	ErrorCodeInvalidIBAN                = "INVALID_IBAN"
+	ErrorCodeAmbiguousReceiver          = "AMBIGUOUS_RECEIVER"
+	ErrorCodeBeneficiaryNotFound        = "BENEFICIARY_NOT_FOUND"
+	ErrorCodeBeneficiaryAlreadyExist    = "BENEFICIARY_ALREADY_EXISTS"
+	ErrorCodeInvalidBeneficiary         = "INVALID_BENEFICIARY"
+	ErrorCodeBeneficiaryLimitExceeded   = "BENEFICIARY_LIMIT_EXCEEDED"
+	ErrorCodeBeneficiaryCoolingOff      = "BENEFICIARY_COOLING_OFF"
+	ErrorCodeLimitExceeded              = "LIMIT_EXCEEDED"
+	ErrorCodeLimitTierNotFound          = "LIMIT_TIER_NOT_FOUND"
+	ErrorCodeTransferDenied             = "TRANSFER_DENIED"
+	ErrorCodeTransferPendingReview      = "TRANSFER_PENDING_REVIEW"
+	ErrorCodePendingTransferNotFound    = "PENDING_TRANSFER_NOT_FOUND"
+	ErrorCodePendingTransferDecided     = "PENDING_TRANSFER_DECIDED"
+	ErrorCodeSanctionsMatch             = "SANCTIONS_MATCH"
+	ErrorCodeScreeningNotFound          = "SCREENING_NOT_FOUND"
+	ErrorCodeScreeningResolved          = "SCREENING_RESOLVED"
+	ErrorCodeProductNotFound            = "PRODUCT_NOT_FOUND"
+	ErrorCodeFeeScheduleNotFound        = "FEE_SCHEDULE_NOT_FOUND"
+	ErrorCodeInvalidFeeSchedule         = "INVALID_FEE_SCHEDULE"
+	ErrorCodeCurrencyNotAllowed         = "CURRENCY_NOT_ALLOWED"
+	ErrorCodeOverdraftNotAllowed        = "OVERDRAFT_NOT_ALLOWED"
+	ErrorCodeCustomerNotFound           = "CUSTOMER_NOT_FOUND"
+	ErrorCodeCustomerAlreadyExist       = "CUSTOMER_ALREADY_EXISTS"
+	ErrorCodeNotPermitted               = "NOT_PERMITTED"
+	ErrorCodeHolderNotFound             = "HOLDER_NOT_FOUND"
+	ErrorCodeOwnerImmutable             = "OWNER_IMMUTABLE"
+	ErrorCodeTransferPendingApproval    = "TRANSFER_PENDING_APPROVAL"
+	ErrorCodeTransferApprovalNotFound   = "TRANSFER_APPROVAL_NOT_FOUND"
+	ErrorCodeTransferApprovalDecided    = "TRANSFER_APPROVAL_DECIDED"
+	ErrorCodeSameApprover               = "SAME_APPROVER"
+	ErrorCodePocketNotFound             = "POCKET_NOT_FOUND"
+	ErrorCodePocketNotAllowed           = "POCKET_NOT_ALLOWED"
+	ErrorCodeCurrencyMismatch           = "CURRENCY_MISMATCH"
+	ErrorCodePocketTransfer             = "POCKET_TRANSFER"
+	ErrorCodeKYCRejected                = "KYC_REJECTED"
+)
+
+var (
+	ErrInsufficientAccountBalance = errors.New("insufficient account balance")
+	ErrAccountNotFound            = errors.New("account not found")
+	ErrRecieverAccountNotFound    = errors.New("reciver account not found")
+	ErrInternal                   = errors.New("internal error")
+	ErrAccountAlreadyExist        = errors.New("account already exists")
+	ErrIdempotencyKeyInUse        = errors.New("a request with the same idempotency key is in progress")
+	ErrIdempotencyKeyReused       = errors.New("idempotency key was used for a different request")
+	ErrForbidden                  = errors.New("admin credentials are required")
+	ErrWebhookNotFound            = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound    = errors.New("webhook delivery not found")
+	ErrTransactionNotFound        = errors.New("transaction not found")
+	ErrInvalidPaymentFile         = errors.New("invalid payment file")
+	ErrPaymentFileAlreadyImported = errors.New("a payment file with the same message id was already imported")
+	ErrPaymentFileNotFound        = errors.New("payment file not found")
+	ErrInvalidIBAN                = errors.New("invalid iban")
+	ErrAmbiguousReceiver          = errors.New("only one of reciverAccountId, reciverIban and beneficiaryId can be set")
+	ErrBeneficiaryNotFound        = errors.New("beneficiary not found")
+	ErrBeneficiaryAlreadyExist    = errors.New("a beneficiary of the same receiver account already exists")
+	ErrInvalidBeneficiary         = errors.New("the receiver account of a beneficiary cannot be its account")
+	ErrBeneficiaryLimitExceeded   = errors.New("amount exceeds the transfer limit of the beneficiary")
+	ErrBeneficiaryCoolingOff      = errors.New("amount exceeds what a beneficiary can receive in its cooling-off period")
+	ErrLimitExceeded              = errors.New("transfer exceeds a limit of the account")
+	ErrLimitTierNotFound          = errors.New("limit tier not found")
+	ErrTransferDenied             = errors.New("transfer denied by risk screening")
+	ErrTransferPendingReview      = errors.New("transfer held for review")
+	ErrPendingTransferNotFound    = errors.New("pending transfer not found")
+	ErrPendingTransferDecided     = errors.New("pending transfer was already approved or rejected")
+	ErrSanctionsMatch             = errors.New("name matches an entry of a sanctions list")
+	ErrScreeningNotFound          = errors.New("screening not found")
+	ErrScreeningResolved          = errors.New("screening was already resolved")
+	ErrProductNotFound            = errors.New("product not found")
+	ErrFeeScheduleNotFound        = errors.New("fee schedule not found")
+	ErrInvalidFeeSchedule         = errors.New(
+		"percentage fees need a rate and a maximum not less than their minimum, " +
+			"tiered fees need tiers of increasing amounts from 0")
+	ErrCurrencyNotAllowed       = errors.New("the product does not allow accounts in this currency")
+	ErrOverdraftNotAllowed      = errors.New("the product of the account does not allow an overdraft of this limit")
+	ErrCustomerNotFound         = errors.New("customer not found")
+	ErrCustomerAlreadyExist     = errors.New("a customer with the same email already exists")
+	ErrNotPermitted             = errors.New("the role of the holder does not permit this on the account")
+	ErrHolderNotFound           = errors.New("account holder not found")
+	ErrOwnerImmutable           = errors.New("the owner of an account cannot be changed or removed")
+	ErrTransferPendingApproval  = errors.New("transfer held for the approval of a second holder")
+	ErrTransferApprovalNotFound = errors.New("transfer approval not found")
+	ErrTransferApprovalDecided  = errors.New("transfer approval was already approved or rejected")
+	ErrPocketNotFound           = errors.New("pocket not found")
+	ErrPocketNotAllowed         = errors.New("a pocket cannot have pockets")
+	ErrCurrencyMismatch         = errors.New("the receiver account is not in the currency of the account")
+	ErrPocketTransfer           = errors.New("money is moved to and from a pocket through its account only")
+	ErrKYCRejected              = errors.New("the KYC checks of the customer were rejected")
+)
+
+// ErrSameApprover is ErrNotPermitted, the holder who initiated a transfer may not approve it.
+var ErrSameApprover error = &notPermittedError{
+	message: "a transfer must be approved by a holder other than the one who initiated it",
+}
+
+var errorCodes = map[error]string{
+	ErrInternal:                   ErrorCodeInternal,
+	ErrInsufficientAccountBalance: ErrorCodeInsufficientAccountBalance,
+	ErrAccountNotFound:            ErrorCodeAccountNotFound,
+	ErrRecieverAccountNotFound:    ErrorCodeReceiverAccountNotFound,
+	ErrAccountAlreadyExist:        ErrorCodeAccountAlreadyExist,
+	ErrIdempotencyKeyInUse:        ErrorCodeIdempotencyKeyInUse,
+	ErrIdempotencyKeyReused:       ErrorCodeIdempotencyKeyReused,
+	ErrForbidden:                  ErrorCodeForbidden,
+	ErrWebhookNotFound:            ErrorCodeWebhookNotFound,
+	ErrWebhookDeliveryNotFound:    ErrorCodeWebhookDeliveryNotFound,
+	ErrTransactionNotFound:        ErrorCodeTransactionNotFound,
+	ErrInvalidPaymentFile:         ErrorCodeInvalidPaymentFile,
+	ErrPaymentFileAlreadyImported: ErrorCodePaymentFileAlreadyImported,
+	ErrPaymentFileNotFound:        ErrorCodePaymentFileNotFound,
+	ErrInvalidIBAN:                ErrorCodeInvalidIBAN,
+	ErrAmbiguousReceiver:          ErrorCodeAmbiguousReceiver,
+	ErrBeneficiaryNotFound:        ErrorCodeBeneficiaryNotFound,
+	ErrBeneficiaryAlreadyExist:    ErrorCodeBeneficiaryAlreadyExist,
+	ErrInvalidBeneficiary:         ErrorCodeInvalidBeneficiary,
+	ErrBeneficiaryLimitExceeded:   ErrorCodeBeneficiaryLimitExceeded,
+	ErrBeneficiaryCoolingOff:      ErrorCodeBeneficiaryCoolingOff,
+	ErrLimitExceeded:              ErrorCodeLimitExceeded,
+	ErrLimitTierNotFound:          ErrorCodeLimitTierNotFound,
+	ErrTransferDenied:             ErrorCodeTransferDenied,
+	ErrTransferPendingReview:      ErrorCodeTransferPendingReview,
+	ErrPendingTransferNotFound:    ErrorCodePendingTransferNotFound,
+	ErrPendingTransferDecided:     ErrorCodePendingTransferDecided,
+	ErrSanctionsMatch:             ErrorCodeSanctionsMatch,
+	ErrScreeningNotFound:          ErrorCodeScreeningNotFound,
+	ErrScreeningResolved:          ErrorCodeScreeningResolved,
+	ErrProductNotFound:            ErrorCodeProductNotFound,
+	ErrFeeScheduleNotFound:        ErrorCodeFeeScheduleNotFound,
+	ErrInvalidFeeSchedule:         ErrorCodeInvalidFeeSchedule,
+	ErrCurrencyNotAllowed:         ErrorCodeCurrencyNotAllowed,
+	ErrOverdraftNotAllowed:        ErrorCodeOverdraftNotAllowed,
+	ErrCustomerNotFound:           ErrorCodeCustomerNotFound,
+	ErrCustomerAlreadyExist:       ErrorCodeCustomerAlreadyExist,
+	ErrNotPermitted:               ErrorCodeNotPermitted,
+	ErrHolderNotFound:             ErrorCodeHolderNotFound,
+	ErrOwnerImmutable:             ErrorCodeOwnerImmutable,
+	ErrTransferPendingApproval:    ErrorCodeTransferPendingApproval,
+	ErrTransferApprovalNotFound:   ErrorCodeTransferApprovalNotFound,
+	ErrTransferApprovalDecided:    ErrorCodeTransferApprovalDecided,
+	ErrSameApprover:               ErrorCodeSameApprover,
+	ErrPocketNotFound:             ErrorCodePocketNotFound,
+	ErrPocketNotAllowed:           ErrorCodePocketNotAllowed,
+	ErrCurrencyMismatch:           ErrorCodeCurrencyMismatch,
+	ErrPocketTransfer:             ErrorCodePocketTransfer,
+	ErrKYCRejected:                ErrorCodeKYCRejected,
+}
+
+// notPermittedError is ErrNotPermitted reported with a message and a code of its own.
+type notPermittedError struct {
+	message string
+}
+
+func (e *notPermittedError) Error() string {
+	return e.message
+}
+
+func (e *notPermittedError) Unwrap() error {
+	return ErrNotPermitted
+}
+
+// Error is the body of an error response.
+type Error struct {
+	_ struct{} `type:"structure"`
+
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	// Limit and Remaining are set when a transfer exceeds a limit of its account, see LimitExceededError.
+	Limit     string `json:"limit,omitempty"`
+	Remaining *int64 `json:"remaining,omitempty"`
+	// PendingTransferID is set when a transfer is held for review, see PendingReviewError.
+	PendingTransferID *uuid.UUID `json:"pendingTransferId,omitempty"`
+	// TransferApprovalID is set when a transfer is held by the mandate of its account, see PendingApprovalError.
+	TransferApprovalID *uuid.UUID `json:"transferApprovalId,omitempty"`
+}
+
+// ErrorCode returns the code the API reports for err, if any, the one of the most specific error when err is several of
+// them, e.g. ErrSameApprover rather than ErrNotPermitted.
+func ErrorCode(err error) string {
+	var match error
+
+	for e := range errorCodes {
+		if errors.Is(err, e) && (match == nil || errors.Is(e, match)) {
+			match = e
+		}
+	}
+
+	return errorCodes[match]
+}
+
+// ErrorFromCode returns the error the API reports with code, if any.
+func ErrorFromCode(code string) error {
+	for e, c := range errorCodes {
+		if c == code {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+const (
+	// FeeTypeTransfer fees are charged on each transfer from an account.
+	FeeTypeTransfer = "transfer"
+	// FeeTypeMaintenance fees are charged once a month.
+	FeeTypeMaintenance = "maintenance"
+
+	// FeeKindFlat fees are a fixed amount.
+	FeeKindFlat = "flat"
+	// FeeKindPercentage fees are a percentage of the amount, between a minimum and a maximum.
+	FeeKindPercentage = "percentage"
+	// FeeKindTiered fees are the amount of the tier the amount falls in.
+	FeeKindTiered = "tiered"
+)
+
+// FeeSchedule is how the fees of a type are computed for the accounts of a product.
+type FeeSchedule struct {
+	_ struct{} `type:"structure"`
+
+	ProductCode string `json:"productCode"`
+	// Type is transfer or maintenance, the base amount of maintenance fees being the balance at the end of the month.
+	Type string `json:"type"`
+	// Kind is flat, percentage or tiered.
+	Kind   string       `json:"kind"`
+	Amount money.Amount `json:"amount"`
+	// Rate is the percentage of the amount of percentage fees, e.g. 0.005 for 0.5%.
+	Rate      string       `json:"rate"`
+	MinAmount money.Amount `json:"minAmount"`
+	// MaxAmount is 0 when the fee has no maximum.
+	MaxAmount money.Amount `json:"maxAmount"`
+	Tiers     []FeeTier    `json:"tiers"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// FeeTier is the fee of the amounts from From until the next tier.
+type FeeTier struct {
+	_ struct{} `type:"structure"`
+
+	From   money.Amount `json:"from"`
+	Amount money.Amount `json:"amount"`
+}
+
+type ListFeeSchedulesResponse struct {
+	_ struct{} `type:"structure"`
+
+	Schedules []FeeSchedule `json:"schedules"`
+}
+
+type SetFeeScheduleRequest struct {
+	_ struct{} `type:"structure"`
+
+	Kind      string       `json:"kind"      validate:"required|in:flat,percentage,tiered"`
+	Amount    money.Amount `json:"amount"    validate:"money_limit"`
+	Rate      string       `json:"rate"      message:"rate must be a decimal from 0 to 1"  validate:"fee_rate"`
+	MinAmount money.Amount `json:"minAmount" validate:"money_limit"`
+	MaxAmount money.Amount `json:"maxAmount" validate:"money_limit"`
+	Tiers     []FeeTier    `json:"tiers"`
+}
+
+type SetFeeScheduleResponse struct {
+	_ struct{} `type:"structure"`
+
+	FeeSchedule
+}
+
+// TransferFeePreview is the fee a transfer from an account would be charged.
+type TransferFeePreview struct {
+	_ struct{} `type:"structure"`
+
+	Amount money.Amount `json:"amount"`
+	Fee    money.Amount `json:"fee"`
+	// Total is the amount debited from the account, the amount plus the fee.
+	Total        money.Amount `json:"total"`
+	CurrencyCode string       `json:"currencyCode"`
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	// HolderRoleOwner is the role of the customer an account was opened for, which cannot be changed.
+	HolderRoleOwner = "owner"
+	// HolderRoleCoOwner is the role of the holders operating an account as its owner does.
+	HolderRoleCoOwner = "co-owner"
+	// HolderRoleSignatory is the role of the holders making and approving the transfers from an account.
+	HolderRoleSignatory = "signatory"
+	// HolderRoleViewer is the role of the holders viewing an account only.
+	HolderRoleViewer = "viewer"
+)
+
+const (
+	TransferApprovalStatusPending  = "pending"
+	TransferApprovalStatusApproved = "approved"
+	TransferApprovalStatusRejected = "rejected"
+)
+
+// PendingApprovalError is returned when a transfer is held by the mandate of its account, it is
+// ErrTransferPendingApproval. No money is transferred until a second holder approves the transfer approval.
+type PendingApprovalError struct {
+	_ struct{} `type:"structure"`
+
+	TransferApprovalID uuid.UUID `json:"transferApprovalId"`
+}
+
+func (e *PendingApprovalError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTransferPendingApproval, e.TransferApprovalID)
+}
+
+func (e *PendingApprovalError) Unwrap() error {
+	return ErrTransferPendingApproval
+}
+
+type AccountHolder struct {
+	_ struct{} `type:"structure"`
+
+	CustomerID uuid.UUID `json:"customerId"`
+	// Role is owner, co-owner, signatory or viewer.
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ListAccountHoldersResponse struct {
+	_ struct{} `type:"structure"`
+
+	Holders []AccountHolder `json:"holders"`
+}
+
+type SetAccountHolderRequest struct {
+	_ struct{} `type:"structure"`
+
+	Role string `json:"role" validate:"required|in:co-owner,signatory,viewer"`
+}
+
+type SetAccountHolderResponse struct {
+	_ struct{} `type:"structure"`
+
+	AccountHolder
+}
+
+type Mandate struct {
+	_ struct{} `type:"structure"`
+
+	AccountID uuid.UUID `json:"accountId"`
+	// ApprovalThreshold is the amount above which transfers need the approval of a second holder.
+	ApprovalThreshold money.Amount `json:"approvalThreshold"`
+}
+
+type SetMandateRequest struct {
+	_ struct{} `type:"structure"`
+
+	ApprovalThreshold money.Amount `json:"approvalThreshold" validate:"money_limit"`
+}
+
+type SetMandateResponse struct {
+	_ struct{} `type:"structure"`
+
+	Mandate
+}
+
+type TransferApproval struct {
+	_ struct{} `type:"structure"`
+
+	ID               uuid.UUID    `json:"id"`
+	AccountID        uuid.UUID    `json:"accountId"`
+	ReciverAccountID uuid.UUID    `json:"reciverAccountId"`
+	Amount           money.Amount `json:"amount"`
+	Status           string       `json:"status"`
+	// InitiatedBy and DecidedBy are the principals who initiated and approved or rejected the transfer.
+	InitiatedBy string `json:"initiatedBy"`
+	DecidedBy   string `json:"decidedBy,omitempty"`
+	// TransactionID is the transaction received once the transfer is approved.
+	TransactionID uuid.NullUUID `json:"transactionId"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	DecidedAt     *time.Time    `json:"decidedAt,omitempty"`
+}
+
+type ListTransferApprovalsResponse struct {
+	_ struct{} `type:"structure"`
+
+	TransferApprovals []TransferApproval `json:"transferApprovals"`
+}
+
+type ApproveTransferResponse struct {
+	_ struct{} `type:"structure"`
+
+	TransferApproval
+}
+
+type RejectTransferResponse struct {
+	_ struct{} `type:"structure"`
+
+	TransferApproval
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	LimitMaxTransfer   = "maxTransfer"
+	LimitDailyAmount   = "dailyAmount"
+	LimitMonthlyAmount = "monthlyAmount"
+	LimitDailyCount    = "dailyCount"
+)
+
+// LimitExceededError is returned when a transfer exceeds a limit of its account, it is ErrLimitExceeded.
+type LimitExceededError struct {
+	_ struct{} `type:"structure"`
+
+	// Limit is the limit exceeded, e.g. LimitDailyAmount.
+	Limit string `json:"limit"`
+	// Remaining is what is left of the limit: an amount, or a number of transfers for LimitDailyCount.
+	Remaining int64 `json:"remaining"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrLimitExceeded, e.Limit)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Limits are the limits of the transfers from an account, there is none when zero.
+type Limits struct {
+	_ struct{} `type:"structure"`
+
+	// MaxTransfer is the maximum amount of a transfer.
+	MaxTransfer money.Amount `json:"maxTransfer,omitempty" validate:"money_limit"`
+	// DailyAmount and MonthlyAmount are the maximum amounts transferred in a calendar day and month, in UTC.
+	DailyAmount   money.Amount `json:"dailyAmount,omitempty"   validate:"money_limit"`
+	MonthlyAmount money.Amount `json:"monthlyAmount,omitempty" validate:"money_limit"`
+	// DailyCount is the maximum number of transfers in a calendar day, in UTC.
+	DailyCount int32 `json:"dailyCount,omitempty" validate:"min:0"`
+}
+
+// LimitUsage is how much of the limits of an account is used by the transfers of the current day and month.
+type LimitUsage struct {
+	_ struct{} `type:"structure"`
+
+	DailyAmount   money.Amount `json:"dailyAmount"`
+	MonthlyAmount money.Amount `json:"monthlyAmount"`
+	DailyCount    int32        `json:"dailyCount"`
+}
+
+type GetAccountLimitsResponse struct {
+	_ struct{} `type:"structure"`
+
+	AccountID uuid.UUID `json:"accountId"`
+	Tier      string    `json:"tier"`
+	// Limits are the limits of the tier, overridden by those of the account.
+	Limits Limits     `json:"limits"`
+	Usage  LimitUsage `json:"usage"`
+}
+
+type SetLimitTierRequest struct {
+	_ struct{} `type:"structure"`
+
+	Limits
+}
+
+type SetLimitTierResponse struct {
+	_ struct{} `type:"structure"`
+
+	Tier   string `json:"tier"`
+	Limits Limits `json:"limits"`
+}
+
+type SetAccountLimitsRequest struct {
+	_ struct{} `type:"structure"`
+
+	// Tier is the tier of the account, standard when empty.
+	Tier string `json:"tier,omitempty" validate:"maxLen:32"`
+	// Limits override those of the tier, the limits of the tier apply when zero.
+	Limits
+}
+
+type SetAccountLimitsResponse struct {
+	_ struct{} `type:"structure"`
+
+	GetAccountLimitsResponse
+}
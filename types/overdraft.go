@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+type GrantOverdraftRequest struct {
+	_ struct{} `type:"structure"`
+
+	// Limit is how far below zero the balance of the account can go.
+	Limit money.Amount `json:"limit" validate:"money_amount"`
+}
+
+type GrantOverdraftResponse struct {
+	_ struct{} `type:"structure"`
+
+	Overdraft
+}
+
+type Overdraft struct {
+	_ struct{} `type:"structure"`
+
+	AccountID uuid.UUID    `json:"accountId"`
+	Limit     money.Amount `json:"limit"`
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+// Pocket is an account ring-fencing money inside its parent account, whose owner and currency it shares.
+type Pocket struct {
+	_ struct{} `type:"structure"`
+
+	ID              uuid.UUID    `json:"id"`
+	ParentAccountID uuid.UUID    `json:"parentAccountId"`
+	Name            string       `json:"name"`
+	CurrencyCode    string       `json:"currencyCode"`
+	Balance         money.Amount `json:"balance"`
+	// GoalAmount is the balance to save in the pocket, there is no goal when zero.
+	GoalAmount money.Amount `json:"goalAmount,omitempty"`
+	// GoalDate is the date to save it by, as YYYY-MM-DD, if any.
+	GoalDate string `json:"goalDate,omitempty"`
+}
+
+type CreatePocketRequest struct {
+	_ struct{} `type:"structure"`
+
+	Name string `json:"name" validate:"required|minLen:3|maxLen:255"`
+}
+
+type CreatePocketResponse struct {
+	_ struct{} `type:"structure"`
+
+	Pocket
+}
+
+type ListPocketsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Pockets []Pocket `json:"pockets"`
+}
+
+type SetPocketGoalRequest struct {
+	_ struct{} `type:"structure"`
+
+	Amount money.Amount `json:"amount" validate:"money_amount"`
+	Date   string       `json:"date"   message:"date must be a date as YYYY-MM-DD" validate:"date_only"`
+}
+
+type SetPocketGoalResponse struct {
+	_ struct{} `type:"structure"`
+
+	Pocket
+}
+
+// MovePocketMoneyRequest moves money between an account and one of its pockets, instantly and free of fees.
+type MovePocketMoneyRequest struct {
+	_ struct{} `type:"structure"`
+
+	Amount money.Amount `json:"amount" validate:"money_amount"`
+}
+
+type MovePocketMoneyResponse struct {
+	_ struct{} `type:"structure"`
+
+	Pocket
+}
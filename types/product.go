@@ -0,0 +1,100 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	// DayCountACT365 counts the actual days, in years of 365 days.
+	DayCountACT365 = "ACT/365"
+	// DayCount30360 counts months of 30 days, in years of 360 days.
+	DayCount30360 = "30/360"
+)
+
+type Product struct {
+	_ struct{} `type:"structure"`
+
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// InterestRate is the annual interest rate of positive balances, e.g. 0.025 for 2.5%.
+	InterestRate string `json:"interestRate"`
+	// DayCount is the day count convention of the interest, ACT/365 or 30/360.
+	DayCount string `json:"dayCount"`
+	// CurrencyCodes are the currencies accounts of the product can be opened in.
+	CurrencyCodes []string `json:"currencyCodes"`
+	// LimitTier is the limit tier of the accounts of the product whose tier was not set.
+	LimitTier string `json:"limitTier"`
+	// OverdraftEligible is whether accounts of the product can be granted an overdraft, up to MaxOverdraftLimit
+	// unless it is 0.
+	OverdraftEligible bool         `json:"overdraftEligible"`
+	MaxOverdraftLimit money.Amount `json:"maxOverdraftLimit"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+type ListProductsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Products []Product `json:"products"`
+}
+
+type SetProductRequest struct {
+	_ struct{} `type:"structure"`
+
+	Name         string `json:"name"         validate:"required|maxLen:255"`
+	InterestRate string `json:"interestRate" message:"interestRate must be a decimal from 0 to 1" validate:"interest_rate"`
+	DayCount     string `json:"dayCount"     validate:"required|in:ACT/365,30/360"`
+
+	// CurrencyCodes default to EUR, and LimitTier to the standard tier.
+	CurrencyCodes []string `json:"currencyCodes" message:"currencyCodes must be currency codes" validate:"currency_codes"`
+	LimitTier     string   `json:"limitTier"     validate:"maxLen:32"`
+
+	OverdraftEligible bool         `json:"overdraftEligible"`
+	MaxOverdraftLimit money.Amount `json:"maxOverdraftLimit" validate:"money_limit"`
+}
+
+type SetProductResponse struct {
+	_ struct{} `type:"structure"`
+
+	Product
+}
+
+type SetAccountProductRequest struct {
+	_ struct{} `type:"structure"`
+
+	ProductCode string `json:"productCode" validate:"required|maxLen:32"`
+}
+
+type SetAccountProductResponse struct {
+	_ struct{} `type:"structure"`
+
+	AccountID   uuid.UUID `json:"accountId"`
+	ProductCode string    `json:"productCode"`
+}
+
+// InterestAccrual is the interest accrued on the balance of an account at the end of a day.
+type InterestAccrual struct {
+	_ struct{} `type:"structure"`
+
+	// Day is the day, in UTC, e.g. 2024-05-16.
+	Day         string       `json:"day"`
+	ProductCode string       `json:"productCode"`
+	Balance     money.Amount `json:"balance"`
+	// InterestRate and DayCount are those of the product on that day.
+	InterestRate string `json:"interestRate"`
+	DayCount     string `json:"dayCount"`
+	// Amount is the exact interest in the major unit of the currency, rounded to the minor unit when capitalized.
+	Amount string `json:"amount"`
+	// TransactionID is the transaction capitalizing the interest of the month, once it is.
+	TransactionID uuid.NullUUID `json:"transactionId"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
+
+type ListInterestAccrualsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Accruals []InterestAccrual `json:"accruals"`
+}
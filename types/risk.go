@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+const (
+	PendingTransferStatusPending  = "pending"
+	PendingTransferStatusApproved = "approved"
+	PendingTransferStatusRejected = "rejected"
+)
+
+// PendingReviewError is returned when a transfer is held for review by the admin, it is ErrTransferPendingReview. No
+// money is transferred until the pending transfer is approved.
+type PendingReviewError struct {
+	_ struct{} `type:"structure"`
+
+	PendingTransferID uuid.UUID `json:"pendingTransferId"`
+}
+
+func (e *PendingReviewError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTransferPendingReview, e.PendingTransferID)
+}
+
+func (e *PendingReviewError) Unwrap() error {
+	return ErrTransferPendingReview
+}
+
+type PendingTransfer struct {
+	_ struct{} `type:"structure"`
+
+	ID               uuid.UUID    `json:"id"`
+	AccountID        uuid.UUID    `json:"accountId"`
+	ReciverAccountID uuid.UUID    `json:"reciverAccountId"`
+	Amount           money.Amount `json:"amount"`
+	Status           string       `json:"status"`
+	// Rules are the names of the risk rules the transfer matched.
+	Rules []string `json:"rules"`
+	// TransactionID is the transaction received once the transfer is approved.
+	TransactionID uuid.NullUUID `json:"transactionId"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	DecidedAt     *time.Time    `json:"decidedAt,omitempty"`
+}
+
+type ListPendingTransfersResponse struct {
+	_ struct{} `type:"structure"`
+
+	PendingTransfers []PendingTransfer `json:"pendingTransfers"`
+}
+
+type ApprovePendingTransferResponse struct {
+	_ struct{} `type:"structure"`
+
+	PendingTransfer
+}
+
+type RejectPendingTransferResponse struct {
+	_ struct{} `type:"structure"`
+
+	PendingTransfer
+}
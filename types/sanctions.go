@@ -0,0 +1,60 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ScreeningStatusClear is the status of the names matching no sanctions entry.
+	ScreeningStatusClear = "clear"
+	// ScreeningStatusReview is the status of the names nearly matching a sanctions entry, until the admin resolves
+	// their screening.
+	ScreeningStatusReview = "review"
+	// ScreeningStatusCleared is the status of the names the admin cleared after review.
+	ScreeningStatusCleared = "cleared"
+	// ScreeningStatusBlocked is the status of the names the admin blocked after review.
+	ScreeningStatusBlocked = "blocked"
+)
+
+type SanctionsMatch struct {
+	_ struct{} `type:"structure"`
+
+	List      string `json:"list"`
+	Reference string `json:"reference"`
+	Name      string `json:"name"`
+	// Score is the similarity of the names, from 0 to 1 for an exact match.
+	Score float64 `json:"score"`
+}
+
+type SanctionsScreening struct {
+	_ struct{} `type:"structure"`
+
+	ID        uuid.UUID        `json:"id"`
+	AccountID uuid.UUID        `json:"accountId"`
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	Matches   []SanctionsMatch `json:"matches"`
+	CreatedAt time.Time        `json:"createdAt"`
+	// ResolvedAt is set once the admin cleared or blocked the account.
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+type ListSanctionsScreeningsResponse struct {
+	_ struct{} `type:"structure"`
+
+	Screenings []SanctionsScreening `json:"screenings"`
+}
+
+type ResolveSanctionsScreeningRequest struct {
+	_ struct{} `type:"structure"`
+
+	Status string `json:"status" message:"status must be cleared or blocked" validate:"required|in:cleared,blocked"`
+}
+
+type ResolveSanctionsScreeningResponse struct {
+	_ struct{} `type:"structure"`
+
+	SanctionsScreening
+}
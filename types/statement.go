@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+)
+
+// Statement is the JSON statement of an account for a period, from and to being dates, both included.
+type Statement struct {
+	_ struct{} `type:"structure"`
+
+	AccountID      uuid.UUID    `json:"accountId"`
+	CurrencyCode   string       `json:"currencyCode"`
+	From           string       `json:"from"`
+	To             string       `json:"to"`
+	OpeningBalance money.Amount `json:"openingBalance"`
+	ClosingBalance money.Amount `json:"closingBalance"`
+	// Entries is last, as it is streamed after the other fields.
+	Entries []StatementEntry `json:"entries"`
+}
+
+// StatementEntry is a transaction and the balance of the account after it.
+type StatementEntry struct {
+	_ struct{} `type:"structure"`
+
+	Transaction
+	Balance money.Amount `json:"balance"`
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	// WebhookDeliveryDead is the status of deliveries which failed too many times, they are only retried when
+	// redelivered.
+	WebhookDeliveryDead = "dead"
+)
+
+type CreateWebhookRequest struct {
+	_ struct{} `type:"structure"`
+
+	URL string `json:"url" validate:"required|fullUrl|maxLen:2048"`
+	// EventTypes are the types of the events delivered, every event is when empty.
+	EventTypes []string `json:"eventTypes" validate:"event_types"`
+	// AccountID restricts the events delivered to the ones of the account, if set.
+	AccountID uuid.NullUUID `json:"accountId"`
+	// Secret is the key deliveries are signed with.
+	Secret string `json:"secret" validate:"required|minLen:16|maxLen:255"`
+}
+
+type CreateWebhookResponse struct {
+	_ struct{} `type:"structure"`
+
+	Webhook
+}
+
+type Webhook struct {
+	_ struct{} `type:"structure"`
+
+	ID         uuid.UUID     `json:"id"`
+	URL        string        `json:"url"`
+	EventTypes []string      `json:"eventTypes"`
+	AccountID  uuid.NullUUID `json:"accountId"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	_ struct{} `type:"structure"`
+
+	ID        uuid.UUID       `json:"id"`
+	WebhookID uuid.UUID       `json:"webhookId"`
+	EventID   uuid.UUID       `json:"eventId"`
+	EventType string          `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    string          `json:"status"`
+	Attempts  int32           `json:"attempts"`
+	// NextAttemptAt is when a pending delivery is attempted next.
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+	LastStatusCode int32     `json:"lastStatusCode,omitempty"`
+	LastError      string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type WebhookDeliveryAttempt struct {
+	_ struct{} `type:"structure"`
+
+	AttemptedAt time.Time `json:"attemptedAt"`
+	StatusCode  int32     `json:"statusCode,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"durationMs"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	_ struct{} `type:"structure"`
+
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type GetWebhookDeliveryResponse struct {
+	_ struct{} `type:"structure"`
+
+	WebhookDelivery
+	// Log lists the attempts of the delivery, oldest first.
+	Log []WebhookDeliveryAttempt `json:"log"`
+}
+
+type RedeliverWebhookDeliveryResponse struct {
+	_ struct{} `type:"structure"`
+
+	WebhookDelivery
+}